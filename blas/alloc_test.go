@@ -0,0 +1,125 @@
+package blas
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// allocCase is a call whose allocations are counted. Calls that split may be
+// split among goroutines and allocate at most 8+w objects for w goroutines;
+// the others must not allocate.
+type allocCase struct {
+	name  string
+	split bool
+	f     func()
+}
+
+func TestAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	defer SetNumThreads(SetNumThreads(1))
+	var r Reference
+	rm := NewRowMajor(nil)
+	ctx := context.Background()
+	rnd := rand.New(rand.NewSource(1))
+
+	// The n×n matrices are large enough for every Level 3 routine to be
+	// split among 4 goroutines, and the strided batch for its problems to
+	// be.
+	const n = 256
+	d := func(size int) []float64 { return realParts(randC(rnd, size, false)) }
+	z := func(size int) []complex128 { return randC(rnd, size, true) }
+	da, db, dc := d(n*n), d(n*n), d(n*n)
+	za, zb, zc := z(n*n), z(n*n), z(n*n)
+	for i := 0; i < n; i++ {
+		da[i+i*n] += n
+		za[i+i*n] += n
+	}
+	dx, dy := d(n), d(n)
+	zx, zy := z(n), z(n)
+	cx, cy := c64s(z(n)), c64s(z(n))
+	ca := c64s(za)
+	sx, sy := f32s(d(n)), f32s(d(n))
+
+	const bn, count = 64, 8
+	dab, dbb, dcb := d(count*bn*bn), d(count*bn*bn), d(count*bn*bn)
+	trsmGroups := []TrsmGroup[complex128]{{
+		Side: SideL, Uplo: UploU, Trans: TransC, Diag: DiagN, M: bn, N: bn, Alpha: 1,
+		A: [][]complex128{za, za}, Lda: n, B: [][]complex128{z(bn * n), z(bn * n)}, Ldb: n,
+	}, {
+		Side: SideR, Uplo: UploL, Trans: TransN, Diag: DiagU, M: bn, N: bn, Alpha: 1,
+		A: [][]complex128{za}, Lda: n, B: [][]complex128{z(bn * n)}, Ldb: n,
+	}}
+	gemvGroups := []GemvGroup[float64]{{
+		Trans: TransT, M: n, N: n, Alpha: 1, A: [][]float64{da, db}, Lda: n,
+		X: [][]float64{dx, dx}, IncX: 1, Beta: 1, Y: [][]float64{d(n), d(n)}, IncY: 1,
+	}}
+
+	cases := []allocCase{
+		// Level 1.
+		{"SDOT", false, func() { r.SDOT(n, sx, 1, sy, -1) }},
+		{"DAXPY", false, func() { r.DAXPY(n/2, 0.5, dx, 2, dy, 1) }},
+		{"DROT", false, func() { r.DROT(n, dx, 1, dy, 1, 0.6, 0.8) }},
+		{"ZDOTC", false, func() { r.ZDOTC(n, zx, 1, zy, 1) }},
+		{"ZSCAL", false, func() { r.ZSCAL(n, 1i, zx, 1) }},
+		{"DZNRM2", false, func() { r.DZNRM2(n, zx, -1) }},
+		{"IZAMAX", false, func() { r.IZAMAX(n, zx, 1) }},
+
+		// Level 2.
+		{"DGEMV", false, func() { r.DGEMV(TransT, n, n, 1, da, n, dx, 1, 0, dy, 1) }},
+		{"CHEMV", false, func() { r.CHEMV(UploL, n, 1, ca, n, cx, 1, 0, cy, 1) }},
+		{"ZTRSV", false, func() { r.ZTRSV(UploU, TransC, DiagN, n, za, n, zx, -1) }},
+		{"DTBSV", false, func() { r.DTBSV(UploL, TransN, DiagN, n, 3, da, n, dx, 1) }},
+		{"DSYR2", false, func() { r.DSYR2(UploU, n, 1, dx, 1, dy, 1, dc, n) }},
+		{"ZHPR", false, func() { r.ZHPR(UploL, n, 1, zx, 1, zc) }},
+
+		// Row-major Level 2, of which the conjugate forms use copies of the
+		// vectors.
+		{"RowMajor CGBMV", false, func() { rm.CGBMV(TransC, n, n, 2, 3, 1, ca, n, cx, 1, 1, cy, 1) }},
+		{"RowMajor ZGEMV", false, func() { rm.ZGEMV(TransC, n, n, 1, za, n, zx, 1, 1, zy, -1) }},
+		{"RowMajor ZHEMV", false, func() { rm.ZHEMV(UploU, n, 1, za, n, zx, 1, 0, zy, 1) }},
+		{"RowMajor ZTRMV", false, func() { rm.ZTRMV(UploL, TransC, DiagU, n, za, n, zx, 1) }},
+		{"RowMajor ZGERC", false, func() { rm.ZGERC(n, n, 1, zx, 1, zy, 1, zc, n) }},
+		{"RowMajor ZHER2", false, func() { rm.ZHER2(UploU, n, 1, zx, 1, zy, 1, zc, n) }},
+		{"RowMajor CHPR", false, func() { rm.CHPR(UploL, n, 1, cx, 1, ca) }},
+
+		// Level 3.
+		{"DGEMM", true, func() { r.DGEMM(TransN, TransT, n, n, n, 1, da, n, db, n, 0, dc, n) }},
+		{"ZGEMM", true, func() { r.ZGEMM(TransC, TransN, n, n, n, 1, za, n, zb, n, 0, zc, n) }},
+		{"DSYMM", true, func() { r.DSYMM(SideR, UploL, n, n, 1, da, n, db, n, 0, dc, n) }},
+		{"ZHEMM", true, func() { r.ZHEMM(SideL, UploU, n, n, 1, za, n, zb, n, 0, zc, n) }},
+		{"DSYRK", true, func() { r.DSYRK(UploU, TransT, n, n, 1, da, n, 0, dc, n) }},
+		{"ZHERK", true, func() { r.ZHERK(UploL, TransN, n, n, 1, za, n, 0, zc, n) }},
+		{"DSYR2K", true, func() { r.DSYR2K(UploL, TransN, n, n, 1, da, n, db, n, 0, dc, n) }},
+		{"ZHER2K", true, func() { r.ZHER2K(UploU, TransC, n, n, 1, za, n, zb, n, 0, zc, n) }},
+		{"DTRMM", true, func() { r.DTRMM(SideL, UploU, TransN, DiagN, n, n, 1.0/n, da, n, db, n) }},
+		{"ZTRMM", true, func() { r.ZTRMM(SideR, UploL, TransC, DiagU, n, n, 1.0/n, za, n, zb, n) }},
+		{"DTRSM", true, func() { r.DTRSM(SideR, UploU, TransT, DiagN, n, n, n, da, n, db, n) }},
+		{"ZTRSM", true, func() { r.ZTRSM(SideL, UploL, TransN, DiagN, n, n, n, za, n, zb, n) }},
+		{"DGEMMContext", true, func() { r.DGEMMContext(ctx, TransN, TransN, n, n, n, 1, da, n, db, n, 0, dc, n) }},
+		{"ZTRSMContext", true, func() { r.ZTRSMContext(ctx, SideR, UploU, TransC, DiagN, n, n, n, za, n, zb, n) }},
+		{"RowMajor DGEMM", true, func() { rm.DGEMM(TransT, TransN, n, n, n, 1, da, n, db, n, 0, dc, n) }},
+
+		// Batched.
+		{"DGEMMStridedBatched", true, func() {
+			r.DGEMMStridedBatched(TransN, TransN, bn, bn, bn, 1, dab, bn, bn*bn, dbb, bn, 0, 0, dcb, bn, bn*bn, count)
+		}},
+		{"ZTRSMBatched", true, func() { r.ZTRSMBatched(trsmGroups) }},
+		{"DGEMVBatched", true, func() { r.DGEMVBatched(gemvGroups) }},
+	}
+
+	for _, threads := range []int{1, 4} {
+		SetNumThreads(threads)
+		for _, c := range cases {
+			limit := 0
+			if c.split {
+				limit = 8 + threads
+			}
+			if got := testing.AllocsPerRun(3, c.f); got > float64(limit) {
+				t.Errorf("%s with %d threads: %v allocations, want at most %d", c.name, threads, got, limit)
+			}
+		}
+	}
+}
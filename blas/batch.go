@@ -0,0 +1,277 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// The batched routines of Reference follow the proposed Batched BLAS
+// standard. The group form, xGEMMBatched, xTRSMBatched and xGEMVBatched,
+// takes groups of problems that share their options, dimensions and scalars,
+// with one slice per matrix or vector of each problem. The strided form,
+// xGEMMStridedBatched, xTRSMStridedBatched and xGEMVStridedBatched, takes
+// problems of the same shape stored at a fixed stride in one slice each, and
+// a stride of zero shares one operand among all problems.
+//
+// The problems of a batch are split among goroutines as the Level 3
+// routines are, see SetNumThreads, and may run in any order, so their
+// outputs must not overlap. Batches too small to be split run on the calling
+// goroutine.
+
+// GemmGroup is a group of problems of xGEMMBatched.
+type GemmGroup[T gen.Scalar] = gen.GemmGroup[T]
+
+// TrsmGroup is a group of problems of xTRSMBatched.
+type TrsmGroup[T gen.Scalar] = gen.TrsmGroup[T]
+
+// GemvGroup is a group of problems of xGEMVBatched.
+type GemvGroup[T gen.Scalar] = gen.GemvGroup[T]
+
+// SGEMMBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i] for the
+// problems of every group.
+func (Reference) SGEMMBatched(groups []GemmGroup[float32]) {
+	if !checkGemmGroups("SGEMM_BATCH", groups) {
+		return
+	}
+	gen.GemmBatched(groups)
+}
+
+// SGEMMStridedBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i]
+// for i in [0, batchCount), where A[i] starts at a[i*strideA], B[i] at
+// b[i*strideB] and C[i] at c[i*strideC].
+// c is updated in place and returned.
+func (Reference) SGEMMStridedBatched(transA, transB Transpose, m, n, k int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB int, beta float32, c []float32, ldc, strideC, batchCount int) (rc []float32) {
+	if !checkGemmStrided("SGEMM_BATCH_STRIDED", transA, transB, m, n, k, len(a), lda, strideA, len(b), ldb, strideB, len(c), ldc, strideC, batchCount) {
+		return c
+	}
+	gen.GemmStridedBatched(transA, transB, m, n, k, alpha, a, lda, strideA, b, ldb, strideB, beta, c, ldc, strideC, batchCount)
+	return c
+}
+
+// STRSMBatched solves op(A[i])*X = alpha*B[i] or X*op(A[i]) = alpha*B[i]
+// for the problems of every group. B[i] is overwritten by X.
+func (Reference) STRSMBatched(groups []TrsmGroup[float32]) {
+	if !checkTrsmGroups("STRSM_BATCH", groups) {
+		return
+	}
+	gen.TrsmBatched(groups)
+}
+
+// STRSMStridedBatched solves op(A[i])*X = alpha*B[i] or
+// X*op(A[i]) = alpha*B[i] for i in [0, batchCount), where A[i] starts at
+// a[i*strideA] and B[i] at b[i*strideB]. B[i] is overwritten by X.
+// b is updated in place and returned.
+func (Reference) STRSMStridedBatched(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB, batchCount int) (rb []float32) {
+	if !checkTrsmStrided("STRSM_BATCH_STRIDED", side, uplo, trans, diag, m, n, len(a), lda, strideA, len(b), ldb, strideB, batchCount) {
+		return b
+	}
+	gen.TrsmStridedBatched(side, uplo, trans, diag, m, n, alpha, a, lda, strideA, b, ldb, strideB, batchCount)
+	return b
+}
+
+// SGEMVBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for the
+// problems of every group.
+func (Reference) SGEMVBatched(groups []GemvGroup[float32]) {
+	if !checkGemvGroups("SGEMV_BATCH", groups) {
+		return
+	}
+	gen.GemvBatched(groups)
+}
+
+// SGEMVStridedBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for
+// i in [0, batchCount), where A[i] starts at a[i*strideA], x[i] at
+// x[i*strideX] and y[i] at y[i*strideY].
+// y is updated in place and returned.
+func (Reference) SGEMVStridedBatched(trans Transpose, m, n int, alpha float32, a []float32, lda, strideA int, x []float32, incX, strideX int, beta float32, y []float32, incY, strideY, batchCount int) (ry []float32) {
+	if !checkGemvStrided("SGEMV_BATCH_STRIDED", trans, m, n, len(a), lda, strideA, len(x), incX, strideX, len(y), incY, strideY, batchCount) {
+		return y
+	}
+	gen.GemvStridedBatched(trans, m, n, alpha, a, lda, strideA, x, incX, strideX, beta, y, incY, strideY, batchCount)
+	return y
+}
+
+// DGEMMBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i] for the
+// problems of every group.
+func (Reference) DGEMMBatched(groups []GemmGroup[float64]) {
+	if !checkGemmGroups("DGEMM_BATCH", groups) {
+		return
+	}
+	gen.GemmBatched(groups)
+}
+
+// DGEMMStridedBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i]
+// for i in [0, batchCount), where A[i] starts at a[i*strideA], B[i] at
+// b[i*strideB] and C[i] at c[i*strideC].
+// c is updated in place and returned.
+func (Reference) DGEMMStridedBatched(transA, transB Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC, batchCount int) (rc []float64) {
+	if !checkGemmStrided("DGEMM_BATCH_STRIDED", transA, transB, m, n, k, len(a), lda, strideA, len(b), ldb, strideB, len(c), ldc, strideC, batchCount) {
+		return c
+	}
+	gen.GemmStridedBatched(transA, transB, m, n, k, alpha, a, lda, strideA, b, ldb, strideB, beta, c, ldc, strideC, batchCount)
+	return c
+}
+
+// DTRSMBatched solves op(A[i])*X = alpha*B[i] or X*op(A[i]) = alpha*B[i]
+// for the problems of every group. B[i] is overwritten by X.
+func (Reference) DTRSMBatched(groups []TrsmGroup[float64]) {
+	if !checkTrsmGroups("DTRSM_BATCH", groups) {
+		return
+	}
+	gen.TrsmBatched(groups)
+}
+
+// DTRSMStridedBatched solves op(A[i])*X = alpha*B[i] or
+// X*op(A[i]) = alpha*B[i] for i in [0, batchCount), where A[i] starts at
+// a[i*strideA] and B[i] at b[i*strideB]. B[i] is overwritten by X.
+// b is updated in place and returned.
+func (Reference) DTRSMStridedBatched(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB, batchCount int) (rb []float64) {
+	if !checkTrsmStrided("DTRSM_BATCH_STRIDED", side, uplo, trans, diag, m, n, len(a), lda, strideA, len(b), ldb, strideB, batchCount) {
+		return b
+	}
+	gen.TrsmStridedBatched(side, uplo, trans, diag, m, n, alpha, a, lda, strideA, b, ldb, strideB, batchCount)
+	return b
+}
+
+// DGEMVBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for the
+// problems of every group.
+func (Reference) DGEMVBatched(groups []GemvGroup[float64]) {
+	if !checkGemvGroups("DGEMV_BATCH", groups) {
+		return
+	}
+	gen.GemvBatched(groups)
+}
+
+// DGEMVStridedBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for
+// i in [0, batchCount), where A[i] starts at a[i*strideA], x[i] at
+// x[i*strideX] and y[i] at y[i*strideY].
+// y is updated in place and returned.
+func (Reference) DGEMVStridedBatched(trans Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY, batchCount int) (ry []float64) {
+	if !checkGemvStrided("DGEMV_BATCH_STRIDED", trans, m, n, len(a), lda, strideA, len(x), incX, strideX, len(y), incY, strideY, batchCount) {
+		return y
+	}
+	gen.GemvStridedBatched(trans, m, n, alpha, a, lda, strideA, x, incX, strideX, beta, y, incY, strideY, batchCount)
+	return y
+}
+
+// CGEMMBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i] for the
+// problems of every group.
+func (Reference) CGEMMBatched(groups []GemmGroup[complex64]) {
+	if !checkGemmGroups("CGEMM_BATCH", groups) {
+		return
+	}
+	gen.GemmBatched(groups)
+}
+
+// CGEMMStridedBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i]
+// for i in [0, batchCount), where A[i] starts at a[i*strideA], B[i] at
+// b[i*strideB] and C[i] at c[i*strideC].
+// c is updated in place and returned.
+func (Reference) CGEMMStridedBatched(transA, transB Transpose, m, n, k int, alpha complex64, a []complex64, lda, strideA int, b []complex64, ldb, strideB int, beta complex64, c []complex64, ldc, strideC, batchCount int) (rc []complex64) {
+	if !checkGemmStrided("CGEMM_BATCH_STRIDED", transA, transB, m, n, k, len(a), lda, strideA, len(b), ldb, strideB, len(c), ldc, strideC, batchCount) {
+		return c
+	}
+	gen.GemmStridedBatched(transA, transB, m, n, k, alpha, a, lda, strideA, b, ldb, strideB, beta, c, ldc, strideC, batchCount)
+	return c
+}
+
+// CTRSMBatched solves op(A[i])*X = alpha*B[i] or X*op(A[i]) = alpha*B[i]
+// for the problems of every group. B[i] is overwritten by X.
+func (Reference) CTRSMBatched(groups []TrsmGroup[complex64]) {
+	if !checkTrsmGroups("CTRSM_BATCH", groups) {
+		return
+	}
+	gen.TrsmBatched(groups)
+}
+
+// CTRSMStridedBatched solves op(A[i])*X = alpha*B[i] or
+// X*op(A[i]) = alpha*B[i] for i in [0, batchCount), where A[i] starts at
+// a[i*strideA] and B[i] at b[i*strideB]. B[i] is overwritten by X.
+// b is updated in place and returned.
+func (Reference) CTRSMStridedBatched(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex64, a []complex64, lda, strideA int, b []complex64, ldb, strideB, batchCount int) (rb []complex64) {
+	if !checkTrsmStrided("CTRSM_BATCH_STRIDED", side, uplo, trans, diag, m, n, len(a), lda, strideA, len(b), ldb, strideB, batchCount) {
+		return b
+	}
+	gen.TrsmStridedBatched(side, uplo, trans, diag, m, n, alpha, a, lda, strideA, b, ldb, strideB, batchCount)
+	return b
+}
+
+// CGEMVBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for the
+// problems of every group.
+func (Reference) CGEMVBatched(groups []GemvGroup[complex64]) {
+	if !checkGemvGroups("CGEMV_BATCH", groups) {
+		return
+	}
+	gen.GemvBatched(groups)
+}
+
+// CGEMVStridedBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for
+// i in [0, batchCount), where A[i] starts at a[i*strideA], x[i] at
+// x[i*strideX] and y[i] at y[i*strideY].
+// y is updated in place and returned.
+func (Reference) CGEMVStridedBatched(trans Transpose, m, n int, alpha complex64, a []complex64, lda, strideA int, x []complex64, incX, strideX int, beta complex64, y []complex64, incY, strideY, batchCount int) (ry []complex64) {
+	if !checkGemvStrided("CGEMV_BATCH_STRIDED", trans, m, n, len(a), lda, strideA, len(x), incX, strideX, len(y), incY, strideY, batchCount) {
+		return y
+	}
+	gen.GemvStridedBatched(trans, m, n, alpha, a, lda, strideA, x, incX, strideX, beta, y, incY, strideY, batchCount)
+	return y
+}
+
+// ZGEMMBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i] for the
+// problems of every group.
+func (Reference) ZGEMMBatched(groups []GemmGroup[complex128]) {
+	if !checkGemmGroups("ZGEMM_BATCH", groups) {
+		return
+	}
+	gen.GemmBatched(groups)
+}
+
+// ZGEMMStridedBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i]
+// for i in [0, batchCount), where A[i] starts at a[i*strideA], B[i] at
+// b[i*strideB] and C[i] at c[i*strideC].
+// c is updated in place and returned.
+func (Reference) ZGEMMStridedBatched(transA, transB Transpose, m, n, k int, alpha complex128, a []complex128, lda, strideA int, b []complex128, ldb, strideB int, beta complex128, c []complex128, ldc, strideC, batchCount int) (rc []complex128) {
+	if !checkGemmStrided("ZGEMM_BATCH_STRIDED", transA, transB, m, n, k, len(a), lda, strideA, len(b), ldb, strideB, len(c), ldc, strideC, batchCount) {
+		return c
+	}
+	gen.GemmStridedBatched(transA, transB, m, n, k, alpha, a, lda, strideA, b, ldb, strideB, beta, c, ldc, strideC, batchCount)
+	return c
+}
+
+// ZTRSMBatched solves op(A[i])*X = alpha*B[i] or X*op(A[i]) = alpha*B[i]
+// for the problems of every group. B[i] is overwritten by X.
+func (Reference) ZTRSMBatched(groups []TrsmGroup[complex128]) {
+	if !checkTrsmGroups("ZTRSM_BATCH", groups) {
+		return
+	}
+	gen.TrsmBatched(groups)
+}
+
+// ZTRSMStridedBatched solves op(A[i])*X = alpha*B[i] or
+// X*op(A[i]) = alpha*B[i] for i in [0, batchCount), where A[i] starts at
+// a[i*strideA] and B[i] at b[i*strideB]. B[i] is overwritten by X.
+// b is updated in place and returned.
+func (Reference) ZTRSMStridedBatched(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex128, a []complex128, lda, strideA int, b []complex128, ldb, strideB, batchCount int) (rb []complex128) {
+	if !checkTrsmStrided("ZTRSM_BATCH_STRIDED", side, uplo, trans, diag, m, n, len(a), lda, strideA, len(b), ldb, strideB, batchCount) {
+		return b
+	}
+	gen.TrsmStridedBatched(side, uplo, trans, diag, m, n, alpha, a, lda, strideA, b, ldb, strideB, batchCount)
+	return b
+}
+
+// ZGEMVBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for the
+// problems of every group.
+func (Reference) ZGEMVBatched(groups []GemvGroup[complex128]) {
+	if !checkGemvGroups("ZGEMV_BATCH", groups) {
+		return
+	}
+	gen.GemvBatched(groups)
+}
+
+// ZGEMVStridedBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for
+// i in [0, batchCount), where A[i] starts at a[i*strideA], x[i] at
+// x[i*strideX] and y[i] at y[i*strideY].
+// y is updated in place and returned.
+func (Reference) ZGEMVStridedBatched(trans Transpose, m, n int, alpha complex128, a []complex128, lda, strideA int, x []complex128, incX, strideX int, beta complex128, y []complex128, incY, strideY, batchCount int) (ry []complex128) {
+	if !checkGemvStrided("ZGEMV_BATCH_STRIDED", trans, m, n, len(a), lda, strideA, len(x), incX, strideX, len(y), incY, strideY, batchCount) {
+		return y
+	}
+	gen.GemvStridedBatched(trans, m, n, alpha, a, lda, strideA, x, incX, strideX, beta, y, incY, strideY, batchCount)
+	return y
+}
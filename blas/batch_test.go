@@ -0,0 +1,262 @@
+package blas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// batchRoutines holds the batched routines of one precision and the plain
+// routines their members are compared with.
+type batchRoutines[T scalar] struct {
+	gemm        func(Transpose, Transpose, int, int, int, T, []T, int, []T, int, T, []T, int) []T
+	gemmBatched func([]GemmGroup[T])
+	gemmStrided func(Transpose, Transpose, int, int, int, T, []T, int, int, []T, int, int, T, []T, int, int, int) []T
+	trsm        func(Side, Uplo, Transpose, Diag, int, int, T, []T, int, []T, int) []T
+	trsmBatched func([]TrsmGroup[T])
+	trsmStrided func(Side, Uplo, Transpose, Diag, int, int, T, []T, int, int, []T, int, int, int) []T
+	gemv        func(Transpose, int, int, T, []T, int, []T, int, T, []T, int) []T
+	gemvBatched func([]GemvGroup[T])
+	gemvStrided func(Transpose, int, int, T, []T, int, int, []T, int, int, T, []T, int, int, int) []T
+}
+
+func TestBatch(t *testing.T) {
+	var r Reference
+	testBatch(t, "S", batchRoutines[float32]{
+		r.SGEMM, r.SGEMMBatched, r.SGEMMStridedBatched,
+		r.STRSM, r.STRSMBatched, r.STRSMStridedBatched,
+		r.SGEMV, r.SGEMVBatched, r.SGEMVStridedBatched,
+	})
+	testBatch(t, "D", batchRoutines[float64]{
+		r.DGEMM, r.DGEMMBatched, r.DGEMMStridedBatched,
+		r.DTRSM, r.DTRSMBatched, r.DTRSMStridedBatched,
+		r.DGEMV, r.DGEMVBatched, r.DGEMVStridedBatched,
+	})
+	testBatch(t, "C", batchRoutines[complex64]{
+		r.CGEMM, r.CGEMMBatched, r.CGEMMStridedBatched,
+		r.CTRSM, r.CTRSMBatched, r.CTRSMStridedBatched,
+		r.CGEMV, r.CGEMVBatched, r.CGEMVStridedBatched,
+	})
+	testBatch(t, "Z", batchRoutines[complex128]{
+		r.ZGEMM, r.ZGEMMBatched, r.ZGEMMStridedBatched,
+		r.ZTRSM, r.ZTRSMBatched, r.ZTRSMStridedBatched,
+		r.ZGEMV, r.ZGEMVBatched, r.ZGEMVStridedBatched,
+	})
+}
+
+// testBatch compares every member of batches of groups of mixed sizes,
+// empty ones included, and of strided batches, some sharing their read-only
+// operands through a zero stride, with a call of the plain routine on that
+// member. The batches are run on one goroutine and split among several.
+func testBatch[T scalar](t *testing.T, prec string, f batchRoutines[T]) {
+	defer SetNumThreads(SetNumThreads(1))
+	rnd := rand.New(rand.NewSource(1))
+	cplx, tol := isCmplx[T](), tolOf[T]()
+	rand := func(n int) []T { return toT[T](randC(rnd, n, cplx)) }
+	// tri returns a well-conditioned n×n triangular matrix with leading
+	// dimension lda.
+	tri := func(n, lda int) []T {
+		a := randC(rnd, lda*n, cplx)
+		for i := 0; i < n; i++ {
+			a[i+i*lda] += complex(float64(n), 0)
+		}
+		return toT[T](a)
+	}
+	clone := func(x [][]T) [][]T {
+		y := make([][]T, len(x))
+		for i := range x {
+			y[i] = append([]T(nil), x[i]...)
+		}
+		return y
+	}
+	check := func(name string, got, want []T) {
+		t.Helper()
+		if !closeTo(toC(got), toC(want), tol) {
+			t.Errorf("%s%s: got %v, want %v", prec, name, got, want)
+		}
+	}
+	trans := TransT
+	if cplx {
+		trans = TransC
+	}
+	alpha, _ := scal[T](complex(0.75, -0.5))
+	beta, _ := scal[T](complex(-0.25, 0.5))
+
+	for _, threads := range []int{1, 4} {
+		SetNumThreads(threads)
+		tag := fmt.Sprintf(" with %d threads", threads)
+
+		// Groups of 2, 0, 3 and 1 members; the last is large enough for
+		// the product to be packed and split.
+		var gemmGroups []GemmGroup[T]
+		for _, g := range []struct {
+			tA, tB  Transpose
+			m, n, k int
+			count   int
+		}{{TransN, TransN, 5, 4, 3, 2}, {TransT, trans, 2, 2, 2, 0}, {trans, TransT, 3, 6, 5, 3}, {TransN, trans, 130, 129, 131, 1}} {
+			ra, ca, rb, cb := g.m, g.k, g.k, g.n
+			if g.tA != TransN {
+				ra, ca = ca, ra
+			}
+			if g.tB != TransN {
+				rb, cb = cb, rb
+			}
+			p := GemmGroup[T]{TransA: g.tA, TransB: g.tB, M: g.m, N: g.n, K: g.k, Alpha: alpha, Lda: ra + 1, Ldb: rb + 2, Beta: beta, Ldc: g.m + 3}
+			for i := 0; i < g.count; i++ {
+				p.A = append(p.A, rand(p.Lda*ca))
+				p.B = append(p.B, rand(p.Ldb*cb))
+				p.C = append(p.C, rand(p.Ldc*g.n))
+			}
+			gemmGroups = append(gemmGroups, p)
+		}
+		want := make([][][]T, len(gemmGroups))
+		for g, p := range gemmGroups {
+			want[g] = clone(p.C)
+			for i := range p.C {
+				f.gemm(p.TransA, p.TransB, p.M, p.N, p.K, p.Alpha, p.A[i], p.Lda, p.B[i], p.Ldb, p.Beta, want[g][i], p.Ldc)
+			}
+		}
+		f.gemmBatched(gemmGroups)
+		for g, p := range gemmGroups {
+			for i := range p.C {
+				check(fmt.Sprintf("GEMMBatched group %d member %d%s", g, i, tag), p.C[i], want[g][i])
+			}
+		}
+
+		var trsmGroups []TrsmGroup[T]
+		for _, g := range []struct {
+			side  Side
+			uplo  Uplo
+			trans Transpose
+			diag  Diag
+			m, n  int
+			count int
+		}{{SideL, UploU, TransN, DiagN, 4, 3, 3}, {SideR, UploL, trans, DiagU, 2, 2, 0}, {SideR, UploU, TransT, DiagN, 5, 6, 2}, {SideL, UploL, trans, DiagU, 1, 2, 1}} {
+			na := g.m
+			if g.side == SideR {
+				na = g.n
+			}
+			p := TrsmGroup[T]{Side: g.side, Uplo: g.uplo, Trans: g.trans, Diag: g.diag, M: g.m, N: g.n, Alpha: alpha, Lda: na + 2, Ldb: g.m + 1}
+			for i := 0; i < g.count; i++ {
+				p.A = append(p.A, tri(na, p.Lda))
+				p.B = append(p.B, rand(p.Ldb*g.n))
+			}
+			trsmGroups = append(trsmGroups, p)
+		}
+		want = make([][][]T, len(trsmGroups))
+		for g, p := range trsmGroups {
+			want[g] = clone(p.B)
+			for i := range p.B {
+				f.trsm(p.Side, p.Uplo, p.Trans, p.Diag, p.M, p.N, p.Alpha, p.A[i], p.Lda, want[g][i], p.Ldb)
+			}
+		}
+		f.trsmBatched(trsmGroups)
+		for g, p := range trsmGroups {
+			for i := range p.B {
+				check(fmt.Sprintf("TRSMBatched group %d member %d%s", g, i, tag), p.B[i], want[g][i])
+			}
+		}
+
+		var gemvGroups []GemvGroup[T]
+		for _, g := range []struct {
+			trans      Transpose
+			m, n       int
+			incX, incY int
+			count      int
+		}{{TransN, 5, 4, 1, 1, 1}, {trans, 4, 6, -2, 3, 3}, {TransT, 3, 3, 1, 1, 0}, {TransN, 2, 7, 2, -1, 2}} {
+			lx, ly := g.n, g.m
+			if g.trans != TransN {
+				lx, ly = ly, lx
+			}
+			p := GemvGroup[T]{Trans: g.trans, M: g.m, N: g.n, Alpha: alpha, Lda: g.m + 1, IncX: g.incX, Beta: beta, IncY: g.incY}
+			for i := 0; i < g.count; i++ {
+				p.A = append(p.A, rand(p.Lda*g.n))
+				p.X = append(p.X, rand(vecLen(lx, g.incX)))
+				p.Y = append(p.Y, rand(vecLen(ly, g.incY)))
+			}
+			gemvGroups = append(gemvGroups, p)
+		}
+		want = make([][][]T, len(gemvGroups))
+		for g, p := range gemvGroups {
+			want[g] = clone(p.Y)
+			for i := range p.Y {
+				f.gemv(p.Trans, p.M, p.N, p.Alpha, p.A[i], p.Lda, p.X[i], p.IncX, p.Beta, want[g][i], p.IncY)
+			}
+		}
+		f.gemvBatched(gemvGroups)
+		for g, p := range gemvGroups {
+			for i := range p.Y {
+				check(fmt.Sprintf("GEMVBatched group %d member %d%s", g, i, tag), p.Y[i], want[g][i])
+			}
+		}
+
+		// Batches without members leave the outputs alone.
+		f.gemmBatched(nil)
+		f.gemmBatched(gemmGroups[1:2])
+		f.trsmBatched(trsmGroups[1:2])
+		f.gemvBatched(gemvGroups[2:3])
+
+		// Strided batches of 8 members, with gaps between the outputs
+		// that must be left alone, and with a zero stride for each
+		// read-only operand in turn. The GEMM members are large enough
+		// for the batch to be split.
+		const count = 8
+		for _, zero := range []string{"", "A", "B"} {
+			const m, n, k, lda, ldb, ldc = 40, 45, 43, 41, 47, 42
+			sa, sb, sc := lda*k+3, ldb*n+1, ldc*n+5
+			switch zero {
+			case "A":
+				sa = 0
+			case "B":
+				sb = 0
+			}
+			a, b := rand(sa*(count-1)+lda*k), rand(sb*(count-1)+ldb*n)
+			c := rand(sc * count)
+			want := append([]T(nil), c...)
+			for i := 0; i < count; i++ {
+				f.gemm(TransN, TransN, m, n, k, alpha, a[i*sa:], lda, b[i*sb:], ldb, beta, want[i*sc:], ldc)
+			}
+			f.gemmStrided(TransN, TransN, m, n, k, alpha, a, lda, sa, b, ldb, sb, beta, c, ldc, sc, count)
+			check(fmt.Sprintf("GEMMStridedBatched zero stride %q%s", zero, tag), c, want)
+			f.gemmStrided(TransN, TransN, m, n, k, alpha, a, lda, sa, b, ldb, sb, beta, c, ldc, sc, 0)
+			check(fmt.Sprintf("GEMMStridedBatched batchCount 0%s", tag), c, want)
+		}
+		for _, sa := range []int{0, 7*7 + 2} {
+			const m, n, lda, ldb = 6, 7, 7, 8
+			sb := ldb*n + 3
+			a := tri(m, lda)
+			for i := 1; i < count && sa > 0; i++ {
+				a = append(append(a, rand(sa-lda*m)...), tri(m, lda)...)
+			}
+			b := rand(sb * count)
+			want := append([]T(nil), b...)
+			for i := 0; i < count; i++ {
+				f.trsm(SideL, UploU, trans, DiagN, m, n, alpha, a[i*sa:], lda, want[i*sb:], ldb)
+			}
+			f.trsmStrided(SideL, UploU, trans, DiagN, m, n, alpha, a, lda, sa, b, ldb, sb, count)
+			check(fmt.Sprintf("TRSMStridedBatched strideA %d%s", sa, tag), b, want)
+			f.trsmStrided(SideL, UploU, trans, DiagN, m, n, alpha, a, lda, sa, b, ldb, sb, 0)
+			check(fmt.Sprintf("TRSMStridedBatched batchCount 0%s", tag), b, want)
+		}
+		for _, zero := range []string{"", "A", "x"} {
+			const m, n, lda, incX, incY = 9, 5, 10, -2, 3
+			sa, sx, sy := lda*n, vecLen(m, incX)+1, vecLen(n, incY)+2
+			switch zero {
+			case "A":
+				sa = 0
+			case "x":
+				sx = 0
+			}
+			a, x := rand(sa*(count-1)+lda*n), rand(sx*(count-1)+vecLen(m, incX))
+			y := rand(sy * count)
+			want := append([]T(nil), y...)
+			for i := 0; i < count; i++ {
+				f.gemv(trans, m, n, alpha, a[i*sa:], lda, x[i*sx:], incX, beta, want[i*sy:], incY)
+			}
+			f.gemvStrided(trans, m, n, alpha, a, lda, sa, x, incX, sx, beta, y, incY, sy, count)
+			check(fmt.Sprintf("GEMVStridedBatched zero stride %q%s", zero, tag), y, want)
+			f.gemvStrided(trans, m, n, alpha, a, lda, sa, x, incX, sx, beta, y, incY, sy, 0)
+			check(fmt.Sprintf("GEMVStridedBatched batchCount 0%s", tag), y, want)
+		}
+	}
+}
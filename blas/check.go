@@ -0,0 +1,677 @@
+package blas
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// layout is the storage order of the general matrices of a call.
+type layout int
+
+const (
+	colMajor layout = iota
+	rowMajor
+)
+
+// checker validates the arguments of a single call. The checks are made in
+// the order of the Netlib routines, followed by the slice lengths, and only
+// the first violation is reported to the error handler.
+type checker struct {
+	routine string
+	layout  layout
+	group   int    // 1 + the group of a batched call, or 0
+	err     *Error // the reported violation, or nil
+}
+
+// fail reports that parameter param, named name, violates reason.
+func (c *checker) fail(param int, name, reason string) {
+	if c.err != nil {
+		return
+	}
+	if c.group > 0 {
+		reason += fmt.Sprintf(" in group %d", c.group-1)
+	}
+	c.err = &Error{Routine: c.routine, Param: param, Name: name, Reason: reason}
+	Xerbla(c.err)
+}
+
+func (c *checker) ok() bool {
+	return c.err == nil
+}
+
+// result returns the reported error, or nil.
+func (c *checker) result() error {
+	if c.err == nil {
+		return nil
+	}
+	return c.err
+}
+
+func (c *checker) trans(param int, name string, t Transpose, allowed ...Transpose) {
+	for _, v := range allowed {
+		if t == v {
+			return
+		}
+	}
+	reason := "must be "
+	for i, v := range allowed {
+		switch {
+		case i == 0:
+		case i == len(allowed)-1:
+			reason += " or "
+		default:
+			reason += ", "
+		}
+		reason += fmt.Sprintf("%c", v)
+	}
+	c.fail(param, name, reason)
+}
+
+func (c *checker) uplo(param int, u Uplo) {
+	if u != UploU && u != UploL {
+		c.fail(param, "uplo", "must be U or L")
+	}
+}
+
+func (c *checker) diag(param int, d Diag) {
+	if d != DiagU && d != DiagN {
+		c.fail(param, "diag", "must be U or N")
+	}
+}
+
+func (c *checker) side(param int, s Side) {
+	if s != SideL && s != SideR {
+		c.fail(param, "side", "must be L or R")
+	}
+}
+
+// atLeast checks that v >= min, where expr is min as written in the message.
+func (c *checker) atLeast(param int, name string, v, min int, expr string) {
+	if v < min {
+		c.fail(param, name, "must be >= "+expr)
+	}
+}
+
+func (c *checker) nonNeg(param int, name string, v int) {
+	c.atLeast(param, name, v, 0, "0")
+}
+
+// ld checks a leading dimension against the number of rows, named rows in
+// the message.
+func (c *checker) ld(param int, name string, ld, m int, rows string) {
+	c.atLeast(param, name, ld, max(1, m), "max(1,"+rows+")")
+}
+
+// ldMat checks the leading dimension of an m×n matrix stored in the layout of
+// the call. rows and cols name m and n in the message.
+func (c *checker) ldMat(param int, name string, ld, m, n int, rows, cols string) {
+	if c.layout == rowMajor {
+		c.ld(param, name, ld, n, cols)
+		return
+	}
+	c.ld(param, name, ld, m, rows)
+}
+
+// matLen returns the length needed by an m×n matrix with leading dimension
+// ld stored in the layout of the call.
+func (c *checker) matLen(m, n, ld int) int {
+	if c.layout == rowMajor {
+		return matLen(n, m, ld)
+	}
+	return matLen(m, n, ld)
+}
+
+func (c *checker) inc(param int, name string, inc int) {
+	if inc == 0 {
+		c.fail(param, name, "must not be zero")
+	}
+}
+
+// length checks that a slice of length have holds at least need elements.
+func (c *checker) length(param int, name string, have, need int) {
+	if have < need {
+		c.fail(param, name, fmt.Sprintf("has length %d, need at least %d", have, need))
+	}
+}
+
+// vecLen returns the length needed by a vector of n elements with increment
+// inc.
+func vecLen(n, inc int) int {
+	if n == 0 {
+		return 0
+	}
+	if inc < 0 {
+		inc = -inc
+	}
+	return 1 + (n-1)*inc
+}
+
+// matLen returns the length needed by an m×n column-major matrix with
+// leading dimension ld.
+func matLen(m, n, ld int) int {
+	if m == 0 || n == 0 {
+		return 0
+	}
+	return ld*(n-1) + m
+}
+
+// packedLen returns the length needed by a packed triangular n×n matrix.
+func packedLen(n int) int {
+	return n * (n + 1) / 2
+}
+
+// rowsName returns notrans when t is TransN and trans otherwise. It names the
+// dimensions of a matrix operand in the messages.
+func rowsName(t Transpose, notrans, trans string) string {
+	if t == TransN {
+		return notrans
+	}
+	return trans
+}
+
+// checkVec checks a Level 1 call on the vector x at position xPos.
+func checkVec(routine string, n, xPos, lenX, incX int) bool {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	if c.ok() {
+		c.length(xPos, "x", lenX, vecLen(n, incX))
+	}
+	return c.ok()
+}
+
+// checkVecs checks a Level 1 call on the vectors x at position xPos and y at
+// position xPos+2.
+func checkVecs(routine string, n, xPos, lenX, incX, lenY, incY int) bool {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	if c.ok() {
+		c.length(xPos, "x", lenX, vecLen(n, incX))
+		c.length(xPos+2, "y", lenY, vecLen(n, incY))
+	}
+	return c.ok()
+}
+
+func checkGemv(routine string, l layout, trans Transpose, m, n, lenA, lda, lenX, incX, lenY, incY int) bool {
+	c := checker{routine: routine, layout: l}
+	c.gemv(trans, m, n, lenA, lda, lenX, incX, lenY, incY)
+	return c.ok()
+}
+
+// gemv checks the arguments of a GEMV call.
+func (c *checker) gemv(trans Transpose, m, n, lenA, lda, lenX, incX, lenY, incY int) {
+	c.trans(1, "trans", trans, TransN, TransT, TransC)
+	c.nonNeg(2, "m", m)
+	c.nonNeg(3, "n", n)
+	c.ldMat(6, "lda", lda, m, n, "m", "n")
+	c.inc(8, "incX", incX)
+	c.inc(11, "incY", incY)
+	if c.ok() {
+		lx, ly := n, m
+		if trans != TransN {
+			lx, ly = m, n
+		}
+		c.length(5, "a", lenA, c.matLen(m, n, lda))
+		c.length(7, "x", lenX, vecLen(lx, incX))
+		c.length(10, "y", lenY, vecLen(ly, incY))
+	}
+}
+
+func checkGbmv(routine string, l layout, trans Transpose, m, n, kl, ku, lenA, lda, lenX, incX, lenY, incY int) bool {
+	c := checker{routine: routine, layout: l}
+	c.trans(1, "trans", trans, TransN, TransT, TransC)
+	c.nonNeg(2, "m", m)
+	c.nonNeg(3, "n", n)
+	c.nonNeg(4, "kL", kl)
+	c.nonNeg(5, "kU", ku)
+	c.atLeast(8, "lda", lda, kl+ku+1, "kL+kU+1")
+	c.inc(10, "incX", incX)
+	c.inc(13, "incY", incY)
+	if c.ok() {
+		lx, ly := n, m
+		if trans != TransN {
+			lx, ly = m, n
+		}
+		if c.layout == rowMajor {
+			c.length(7, "a", lenA, matLen(kl+ku+1, min(m, n+kl), lda))
+		} else {
+			c.length(7, "a", lenA, matLen(kl+ku+1, min(n, m+ku), lda))
+		}
+		c.length(9, "x", lenX, vecLen(lx, incX))
+		c.length(12, "y", lenY, vecLen(ly, incY))
+	}
+	return c.ok()
+}
+
+// checkSymv checks the SYMV and HEMV routines.
+func checkSymv(routine string, uplo Uplo, n, lenA, lda, lenX, incX, lenY, incY int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.ld(5, "lda", lda, n, "n")
+	c.inc(7, "incX", incX)
+	c.inc(10, "incY", incY)
+	if c.ok() {
+		c.length(4, "a", lenA, matLen(n, n, lda))
+		c.length(6, "x", lenX, vecLen(n, incX))
+		c.length(9, "y", lenY, vecLen(n, incY))
+	}
+	return c.ok()
+}
+
+// checkSbmv checks the SBMV and HBMV routines.
+func checkSbmv(routine string, uplo Uplo, n, k, lenA, lda, lenX, incX, lenY, incY int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.nonNeg(3, "k", k)
+	c.atLeast(6, "lda", lda, k+1, "k+1")
+	c.inc(8, "incX", incX)
+	c.inc(11, "incY", incY)
+	if c.ok() {
+		c.length(5, "a", lenA, matLen(k+1, n, lda))
+		c.length(7, "x", lenX, vecLen(n, incX))
+		c.length(10, "y", lenY, vecLen(n, incY))
+	}
+	return c.ok()
+}
+
+// checkSpmv checks the SPMV and HPMV routines.
+func checkSpmv(routine string, uplo Uplo, n, lenAP, lenX, incX, lenY, incY int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.inc(6, "incX", incX)
+	c.inc(9, "incY", incY)
+	if c.ok() {
+		c.length(4, "ap", lenAP, packedLen(n))
+		c.length(5, "x", lenX, vecLen(n, incX))
+		c.length(8, "y", lenY, vecLen(n, incY))
+	}
+	return c.ok()
+}
+
+// checkTrmv checks the TRMV and TRSV routines.
+func checkTrmv(routine string, uplo Uplo, trans Transpose, diag Diag, n, lenA, lda, lenX, incX int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.trans(2, "trans", trans, TransN, TransT, TransC)
+	c.diag(3, diag)
+	c.nonNeg(4, "n", n)
+	c.ld(6, "lda", lda, n, "n")
+	c.inc(8, "incX", incX)
+	if c.ok() {
+		c.length(5, "a", lenA, matLen(n, n, lda))
+		c.length(7, "x", lenX, vecLen(n, incX))
+	}
+	return c.ok()
+}
+
+// checkTbmv checks the TBMV and TBSV routines.
+func checkTbmv(routine string, uplo Uplo, trans Transpose, diag Diag, n, k, lenA, lda, lenX, incX int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.trans(2, "trans", trans, TransN, TransT, TransC)
+	c.diag(3, diag)
+	c.nonNeg(4, "n", n)
+	c.nonNeg(5, "k", k)
+	c.atLeast(7, "lda", lda, k+1, "k+1")
+	c.inc(9, "incX", incX)
+	if c.ok() {
+		c.length(6, "a", lenA, matLen(k+1, n, lda))
+		c.length(8, "x", lenX, vecLen(n, incX))
+	}
+	return c.ok()
+}
+
+// checkTpmv checks the TPMV and TPSV routines.
+func checkTpmv(routine string, uplo Uplo, trans Transpose, diag Diag, n, lenAP, lenX, incX int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.trans(2, "trans", trans, TransN, TransT, TransC)
+	c.diag(3, diag)
+	c.nonNeg(4, "n", n)
+	c.inc(7, "incX", incX)
+	if c.ok() {
+		c.length(5, "ap", lenAP, packedLen(n))
+		c.length(6, "x", lenX, vecLen(n, incX))
+	}
+	return c.ok()
+}
+
+// checkGer checks the GER, GERU and GERC routines.
+func checkGer(routine string, l layout, m, n, lenX, incX, lenY, incY, lenA, lda int) bool {
+	c := checker{routine: routine, layout: l}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.inc(5, "incX", incX)
+	c.inc(7, "incY", incY)
+	c.ldMat(9, "lda", lda, m, n, "m", "n")
+	if c.ok() {
+		c.length(4, "x", lenX, vecLen(m, incX))
+		c.length(6, "y", lenY, vecLen(n, incY))
+		c.length(8, "a", lenA, c.matLen(m, n, lda))
+	}
+	return c.ok()
+}
+
+// checkSyr checks the SYR and HER routines.
+func checkSyr(routine string, uplo Uplo, n, lenX, incX, lenA, lda int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.inc(5, "incX", incX)
+	c.ld(7, "lda", lda, n, "n")
+	if c.ok() {
+		c.length(4, "x", lenX, vecLen(n, incX))
+		c.length(6, "a", lenA, matLen(n, n, lda))
+	}
+	return c.ok()
+}
+
+// checkSpr checks the SPR and HPR routines.
+func checkSpr(routine string, uplo Uplo, n, lenX, incX, lenAP int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.inc(5, "incX", incX)
+	if c.ok() {
+		c.length(4, "x", lenX, vecLen(n, incX))
+		c.length(6, "ap", lenAP, packedLen(n))
+	}
+	return c.ok()
+}
+
+// checkSyr2 checks the SYR2 and HER2 routines.
+func checkSyr2(routine string, uplo Uplo, n, lenX, incX, lenY, incY, lenA, lda int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.inc(5, "incX", incX)
+	c.inc(7, "incY", incY)
+	c.ld(9, "lda", lda, n, "n")
+	if c.ok() {
+		c.length(4, "x", lenX, vecLen(n, incX))
+		c.length(6, "y", lenY, vecLen(n, incY))
+		c.length(8, "a", lenA, matLen(n, n, lda))
+	}
+	return c.ok()
+}
+
+// checkSpr2 checks the SPR2 and HPR2 routines.
+func checkSpr2(routine string, uplo Uplo, n, lenX, incX, lenY, incY, lenAP int) bool {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.inc(5, "incX", incX)
+	c.inc(7, "incY", incY)
+	if c.ok() {
+		c.length(4, "x", lenX, vecLen(n, incX))
+		c.length(6, "y", lenY, vecLen(n, incY))
+		c.length(8, "ap", lenAP, packedLen(n))
+	}
+	return c.ok()
+}
+
+func checkGemm(routine string, l layout, transA, transB Transpose, m, n, k, lenA, lda, lenB, ldb, lenC, ldc int) bool {
+	c := checker{routine: routine, layout: l}
+	c.gemm(transA, transB, m, n, k, lenA, lda, lenB, ldb, lenC, ldc)
+	return c.ok()
+}
+
+// gemmError is checkGemm for the routines that return the reported error.
+func gemmError(routine string, transA, transB Transpose, m, n, k, lenA, lda, lenB, ldb, lenC, ldc int) error {
+	c := checker{routine: routine}
+	c.gemm(transA, transB, m, n, k, lenA, lda, lenB, ldb, lenC, ldc)
+	return c.result()
+}
+
+// gemm checks the arguments of a GEMM call.
+func (c *checker) gemm(transA, transB Transpose, m, n, k, lenA, lda, lenB, ldb, lenC, ldc int) {
+	c.trans(1, "transA", transA, TransN, TransT, TransC)
+	c.trans(2, "transB", transB, TransN, TransT, TransC)
+	c.nonNeg(3, "m", m)
+	c.nonNeg(4, "n", n)
+	c.nonNeg(5, "k", k)
+	am, ak := m, k
+	if transA != TransN {
+		am, ak = k, m
+	}
+	bk, bn := k, n
+	if transB != TransN {
+		bk, bn = n, k
+	}
+	c.ldMat(8, "lda", lda, am, ak, rowsName(transA, "m", "k"), rowsName(transA, "k", "m"))
+	c.ldMat(10, "ldb", ldb, bk, bn, rowsName(transB, "k", "n"), rowsName(transB, "n", "k"))
+	c.ldMat(13, "ldc", ldc, m, n, "m", "n")
+	if c.ok() {
+		c.length(7, "a", lenA, c.matLen(am, ak, lda))
+		c.length(9, "b", lenB, c.matLen(bk, bn, ldb))
+		c.length(12, "c", lenC, c.matLen(m, n, ldc))
+	}
+}
+
+// checkSymm checks the SYMM and HEMM routines.
+func checkSymm(routine string, l layout, side Side, uplo Uplo, m, n, lenA, lda, lenB, ldb, lenC, ldc int) bool {
+	c := checker{routine: routine, layout: l}
+	c.side(1, side)
+	c.uplo(2, uplo)
+	c.nonNeg(3, "m", m)
+	c.nonNeg(4, "n", n)
+	na, rows := m, "m"
+	if side == SideR {
+		na, rows = n, "n"
+	}
+	c.ld(7, "lda", lda, na, rows)
+	c.ldMat(9, "ldb", ldb, m, n, "m", "n")
+	c.ldMat(12, "ldc", ldc, m, n, "m", "n")
+	if c.ok() {
+		c.length(6, "a", lenA, matLen(na, na, lda))
+		c.length(8, "b", lenB, c.matLen(m, n, ldb))
+		c.length(11, "c", lenC, c.matLen(m, n, ldc))
+	}
+	return c.ok()
+}
+
+// checkSyrk checks the SYRK and HERK routines. allowed lists the values of
+// trans accepted by the routine.
+func checkSyrk(routine string, l layout, uplo Uplo, trans Transpose, n, k, lenA, lda, lenC, ldc int, allowed ...Transpose) bool {
+	c := checker{routine: routine, layout: l}
+	c.uplo(1, uplo)
+	c.trans(2, "trans", trans, allowed...)
+	c.nonNeg(3, "n", n)
+	c.nonNeg(4, "k", k)
+	an, ak := n, k
+	if trans != TransN {
+		an, ak = k, n
+	}
+	c.ldMat(7, "lda", lda, an, ak, rowsName(trans, "n", "k"), rowsName(trans, "k", "n"))
+	c.ld(10, "ldc", ldc, n, "n")
+	if c.ok() {
+		c.length(6, "a", lenA, c.matLen(an, ak, lda))
+		c.length(9, "c", lenC, matLen(n, n, ldc))
+	}
+	return c.ok()
+}
+
+// checkSyr2k checks the SYR2K and HER2K routines. allowed lists the values of
+// trans accepted by the routine.
+func checkSyr2k(routine string, l layout, uplo Uplo, trans Transpose, n, k, lenA, lda, lenB, ldb, lenC, ldc int, allowed ...Transpose) bool {
+	c := checker{routine: routine, layout: l}
+	c.uplo(1, uplo)
+	c.trans(2, "trans", trans, allowed...)
+	c.nonNeg(3, "n", n)
+	c.nonNeg(4, "k", k)
+	an, ak := n, k
+	if trans != TransN {
+		an, ak = k, n
+	}
+	c.ldMat(7, "lda", lda, an, ak, rowsName(trans, "n", "k"), rowsName(trans, "k", "n"))
+	c.ldMat(9, "ldb", ldb, an, ak, rowsName(trans, "n", "k"), rowsName(trans, "k", "n"))
+	c.ld(12, "ldc", ldc, n, "n")
+	if c.ok() {
+		c.length(6, "a", lenA, c.matLen(an, ak, lda))
+		c.length(8, "b", lenB, c.matLen(an, ak, ldb))
+		c.length(11, "c", lenC, matLen(n, n, ldc))
+	}
+	return c.ok()
+}
+
+// checkTrmm checks the TRMM and TRSM routines.
+func checkTrmm(routine string, l layout, side Side, uplo Uplo, trans Transpose, diag Diag, m, n, lenA, lda, lenB, ldb int) bool {
+	c := checker{routine: routine, layout: l}
+	c.trmm(side, uplo, trans, diag, m, n, lenA, lda, lenB, ldb)
+	return c.ok()
+}
+
+// trmmError is checkTrmm for the routines that return the reported error.
+func trmmError(routine string, side Side, uplo Uplo, trans Transpose, diag Diag, m, n, lenA, lda, lenB, ldb int) error {
+	c := checker{routine: routine}
+	c.trmm(side, uplo, trans, diag, m, n, lenA, lda, lenB, ldb)
+	return c.result()
+}
+
+// trmm checks the arguments of a TRMM or TRSM call.
+func (c *checker) trmm(side Side, uplo Uplo, trans Transpose, diag Diag, m, n, lenA, lda, lenB, ldb int) {
+	c.side(1, side)
+	c.uplo(2, uplo)
+	c.trans(3, "trans", trans, TransN, TransT, TransC)
+	c.diag(4, diag)
+	c.nonNeg(5, "m", m)
+	c.nonNeg(6, "n", n)
+	na, rows := m, "m"
+	if side == SideR {
+		na, rows = n, "n"
+	}
+	c.ld(9, "lda", lda, na, rows)
+	c.ldMat(11, "ldb", ldb, m, n, "m", "n")
+	if c.ok() {
+		c.length(8, "a", lenA, matLen(na, na, lda))
+		c.length(10, "b", lenB, c.matLen(m, n, ldb))
+	}
+}
+
+// minLen returns the length of the shortest slice of s, or math.MaxInt when
+// s is empty.
+func minLen[T any](s [][]T) int {
+	n := math.MaxInt
+	for _, v := range s {
+		n = min(n, len(v))
+	}
+	return n
+}
+
+// memberLen returns the length left for the last member of a strided batch
+// of count members in a slice of length have, or math.MaxInt when the batch
+// is empty.
+func memberLen(have, stride, count int) int {
+	if count == 0 {
+		return math.MaxInt
+	}
+	return have - (count-1)*stride
+}
+
+// count checks that the slice of matrices or vectors at position param of a
+// group holds need elements.
+func (c *checker) count(param int, name string, have, need int) {
+	if have != need {
+		c.fail(param, name, fmt.Sprintf("holds %d elements, need %d", have, need))
+	}
+}
+
+// checkGemmGroups checks the groups of a batched GEMM call.
+func checkGemmGroups[T gen.Scalar](routine string, groups []GemmGroup[T]) bool {
+	for g, p := range groups {
+		c := checker{routine: routine, group: g + 1}
+		c.count(7, "a", len(p.A), len(p.C))
+		c.count(9, "b", len(p.B), len(p.C))
+		c.gemm(p.TransA, p.TransB, p.M, p.N, p.K, minLen(p.A), p.Lda, minLen(p.B), p.Ldb, minLen(p.C), p.Ldc)
+		if !c.ok() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkGemmStrided checks a strided batched GEMM call. The strides and the
+// count are checked first; the other arguments are checked against the last
+// product.
+func checkGemmStrided(routine string, transA, transB Transpose, m, n, k, lenA, lda, strideA, lenB, ldb, strideB, lenC, ldc, strideC, count int) bool {
+	c := checker{routine: routine}
+	c.nonNeg(9, "strideA", strideA)
+	c.nonNeg(12, "strideB", strideB)
+	if count > 1 && ldc >= max(1, m) {
+		c.atLeast(16, "strideC", strideC, matLen(m, n, ldc), "ldc*(n-1)+m")
+	}
+	c.nonNeg(17, "batchCount", count)
+	if !c.ok() {
+		return false
+	}
+	c.gemm(transA, transB, m, n, k, memberLen(lenA, strideA, count), lda, memberLen(lenB, strideB, count), ldb, memberLen(lenC, strideC, count), ldc)
+	return c.ok()
+}
+
+// checkTrsmGroups checks the groups of a batched TRSM call.
+func checkTrsmGroups[T gen.Scalar](routine string, groups []TrsmGroup[T]) bool {
+	for g, p := range groups {
+		c := checker{routine: routine, group: g + 1}
+		c.count(8, "a", len(p.A), len(p.B))
+		c.trmm(p.Side, p.Uplo, p.Trans, p.Diag, p.M, p.N, minLen(p.A), p.Lda, minLen(p.B), p.Ldb)
+		if !c.ok() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkTrsmStrided checks a strided batched TRSM call in the manner of
+// checkGemmStrided.
+func checkTrsmStrided(routine string, side Side, uplo Uplo, trans Transpose, diag Diag, m, n, lenA, lda, strideA, lenB, ldb, strideB, count int) bool {
+	c := checker{routine: routine}
+	c.nonNeg(10, "strideA", strideA)
+	if count > 1 && ldb >= max(1, m) {
+		c.atLeast(13, "strideB", strideB, matLen(m, n, ldb), "ldb*(n-1)+m")
+	}
+	c.nonNeg(14, "batchCount", count)
+	if !c.ok() {
+		return false
+	}
+	c.trmm(side, uplo, trans, diag, m, n, memberLen(lenA, strideA, count), lda, memberLen(lenB, strideB, count), ldb)
+	return c.ok()
+}
+
+// checkGemvGroups checks the groups of a batched GEMV call.
+func checkGemvGroups[T gen.Scalar](routine string, groups []GemvGroup[T]) bool {
+	for g, p := range groups {
+		c := checker{routine: routine, group: g + 1}
+		c.count(5, "a", len(p.A), len(p.Y))
+		c.count(7, "x", len(p.X), len(p.Y))
+		c.gemv(p.Trans, p.M, p.N, minLen(p.A), p.Lda, minLen(p.X), p.IncX, minLen(p.Y), p.IncY)
+		if !c.ok() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkGemvStrided checks a strided batched GEMV call in the manner of
+// checkGemmStrided.
+func checkGemvStrided(routine string, trans Transpose, m, n, lenA, lda, strideA, lenX, incX, strideX, lenY, incY, strideY, count int) bool {
+	c := checker{routine: routine}
+	c.nonNeg(7, "strideA", strideA)
+	c.nonNeg(10, "strideX", strideX)
+	if count > 1 {
+		ly := m
+		if trans != TransN {
+			ly = n
+		}
+		c.atLeast(14, "strideY", strideY, vecLen(ly, incY), "the length of y")
+	}
+	c.nonNeg(15, "batchCount", count)
+	if !c.ok() {
+		return false
+	}
+	c.gemv(trans, m, n, memberLen(lenA, strideA, count), lda, memberLen(lenX, strideX, count), incX, memberLen(lenY, strideY, count), incY)
+	return c.ok()
+}
@@ -0,0 +1,156 @@
+package blas
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// catch installs an error handler that records each error and returns. It
+// returns the recorded errors and a function that restores the previous
+// handler.
+func catch() (reported *[]*Error, restore func()) {
+	var errs []*Error
+	prev := SetErrorHandler(func(err *Error) { errs = append(errs, err) })
+	return &errs, func() { SetErrorHandler(prev) }
+}
+
+func TestErrorHandler(t *testing.T) {
+	var r Reference
+	ones := func(n int) []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = 1
+		}
+		return x
+	}
+	onesC := func(n int) []complex128 {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = 1
+		}
+		return x
+	}
+
+	// Each call passes one illegal argument and returns its output, which
+	// starts as ones and must be left untouched. The parameter positions
+	// are those reported by the Netlib routines.
+	for _, test := range []struct {
+		routine string
+		param   int
+		name    string
+		reason  string
+		call    func() []complex128
+	}{
+		{"DGEMM", 8, "lda", "must be >= max(1,m)", func() []complex128 {
+			return toC(r.DGEMM(TransN, TransN, 3, 2, 2, 1, ones(6), 2, ones(4), 2, 1, ones(6), 3))
+		}},
+		{"DGEMM", 10, "ldb", "must be >= max(1,n)", func() []complex128 {
+			return toC(r.DGEMM(TransN, TransT, 3, 2, 2, 1, ones(6), 3, ones(4), 1, 1, ones(6), 3))
+		}},
+		{"DGEMM", 13, "ldc", "must be >= max(1,m)", func() []complex128 {
+			return toC(r.DGEMM(TransN, TransN, 3, 2, 2, 1, ones(6), 3, ones(4), 2, 1, ones(6), 2))
+		}},
+		{"DGEMM", 5, "k", "must be >= 0", func() []complex128 {
+			return toC(r.DGEMM(TransN, TransN, 3, 2, -1, 1, ones(6), 3, ones(4), 2, 1, ones(6), 3))
+		}},
+		{"DGEMM", 12, "c", "has length 5, need at least 6", func() []complex128 {
+			return toC(r.DGEMM(TransN, TransN, 3, 2, 2, 1, ones(6), 3, ones(4), 2, 1, ones(5), 3))
+		}},
+		{"DGEMV", 8, "incX", "must not be zero", func() []complex128 {
+			return toC(r.DGEMV(TransN, 2, 2, 1, ones(4), 2, ones(2), 0, 1, ones(2), 1))
+		}},
+		{"DAXPY", 1, "n", "must be >= 0", func() []complex128 {
+			return toC(r.DAXPY(-1, 1, ones(2), 1, ones(2), 1))
+		}},
+
+		// Illegal values of the typed options.
+		{"DGEMM", 1, "transA", "must be N, T or C", func() []complex128 {
+			return toC(r.DGEMM(Transpose('X'), TransN, 2, 2, 2, 1, ones(4), 2, ones(4), 2, 1, ones(4), 2))
+		}},
+		{"DGEMM", 2, "transB", "must be N, T or C", func() []complex128 {
+			return toC(r.DGEMM(TransN, Transpose('X'), 2, 2, 2, 1, ones(4), 2, ones(4), 2, 1, ones(4), 2))
+		}},
+		{"DGEMV", 1, "trans", "must be N, T or C", func() []complex128 {
+			return toC(r.DGEMV(Transpose(0), 2, 2, 1, ones(4), 2, ones(2), 1, 1, ones(2), 1))
+		}},
+		{"DTRMV", 1, "uplo", "must be U or L", func() []complex128 {
+			return toC(r.DTRMV(Uplo(0), TransN, DiagN, 2, ones(4), 2, ones(2), 1))
+		}},
+		{"DTRMV", 2, "trans", "must be N, T or C", func() []complex128 {
+			return toC(r.DTRMV(UploU, Transpose('X'), DiagN, 2, ones(4), 2, ones(2), 1))
+		}},
+		{"DTRMV", 3, "diag", "must be U or N", func() []complex128 {
+			return toC(r.DTRMV(UploU, TransN, Diag('X'), 2, ones(4), 2, ones(2), 1))
+		}},
+		{"DTRSM", 1, "side", "must be L or R", func() []complex128 {
+			return toC(r.DTRSM(Side('Q'), UploU, TransN, DiagN, 2, 2, 1, ones(4), 2, ones(4), 2))
+		}},
+		{"DTRSM", 2, "uplo", "must be U or L", func() []complex128 {
+			return toC(r.DTRSM(SideL, Uplo('X'), TransN, DiagN, 2, 2, 1, ones(4), 2, ones(4), 2))
+		}},
+		{"DTRSM", 3, "trans", "must be N, T or C", func() []complex128 {
+			return toC(r.DTRSM(SideL, UploU, Transpose('X'), DiagN, 2, 2, 1, ones(4), 2, ones(4), 2))
+		}},
+		{"DTRSM", 4, "diag", "must be U or N", func() []complex128 {
+			return toC(r.DTRSM(SideL, UploU, TransN, Diag(0), 2, 2, 1, ones(4), 2, ones(4), 2))
+		}},
+		{"DSYMM", 1, "side", "must be L or R", func() []complex128 {
+			return toC(r.DSYMM(Side(0), UploU, 2, 2, 1, ones(4), 2, ones(4), 2, 1, ones(4), 2))
+		}},
+		{"DSYMM", 2, "uplo", "must be U or L", func() []complex128 {
+			return toC(r.DSYMM(SideL, Uplo(0), 2, 2, 1, ones(4), 2, ones(4), 2, 1, ones(4), 2))
+		}},
+		{"ZHERK", 2, "trans", "must be N or C", func() []complex128 {
+			return r.ZHERK(UploU, TransT, 2, 2, 1, onesC(4), 2, 1, onesC(4), 2)
+		}},
+	} {
+		errs, restore := catch()
+		out := test.call()
+		restore()
+		if len(*errs) != 1 {
+			t.Errorf("%s: handler called %d times, want once", test.routine, len(*errs))
+			continue
+		}
+		want := Error{Routine: test.routine, Param: test.param, Name: test.name, Reason: test.reason}
+		if got := *(*errs)[0]; got != want {
+			t.Errorf("%s: reported %v, want %v", test.routine, &got, &want)
+		}
+		if !slices.Equal(out, onesC(len(out))) {
+			t.Errorf("%s with illegal %s: output modified", test.routine, test.name)
+		}
+	}
+
+	// The index routines return -1 after an illegal argument.
+	errs, restore := catch()
+	i := r.IDAMAX(-1, ones(2), 1)
+	j := r.IZAMAX(3, onesC(2), 1)
+	restore()
+	if i != -1 || j != -1 {
+		t.Errorf("IxAMAX with illegal arguments = %d, %d, want -1", i, j)
+	}
+	if len(*errs) != 2 || (*errs)[0].Param != 1 || (*errs)[1].Param != 2 {
+		t.Errorf("IxAMAX with illegal arguments: reported %v, want parameters 1 and 2", *errs)
+	}
+
+	// A nil handler restores PanicHandler, which panics with the *Error,
+	// and SetErrorHandler returns the handler it replaces.
+	called := false
+	prev := SetErrorHandler(func(*Error) { called = true })
+	if got := SetErrorHandler(nil); got == nil {
+		t.Errorf("SetErrorHandler(nil) returned a nil previous handler")
+	} else if got(&Error{}); !called {
+		t.Errorf("SetErrorHandler(nil) did not return the previous handler")
+	}
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			var e *Error
+			if !errors.As(err, &e) || e.Routine != "DGEMM" || e.Param != 8 {
+				t.Errorf("DGEMM with the default handler: recovered %v, want the *Error for parameter 8", err)
+			}
+		}()
+		r.DGEMM(TransN, TransN, 3, 2, 2, 1, ones(6), 2, ones(4), 2, 1, ones(6), 3)
+	}()
+	SetErrorHandler(prev)
+}
@@ -0,0 +1,86 @@
+package blas
+
+import (
+	"context"
+
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// The Context variants of the long-running Level 3 routines of Reference
+// check ctx between the blocks of their work and return ctx.Err() once ctx is
+// done. Illegal arguments are reported as by the plain routines, and the
+// reported *Error is returned if the error handler returns. Neither such a
+// call nor one whose ctx is already done touches the output matrix. On
+// cancellation during the call the output matrix is left partially updated
+// and its contents are unspecified:
+//
+//   - xGEMMContext has scaled C by beta and added part of alpha*op(A)*op(B).
+//   - xTRSMContext has scaled B by alpha, overwritten some of its blocks with
+//     the matching blocks of X and partly updated the others.
+//
+// A call that returns a nil error has completed and has the result of the
+// plain routine.
+
+// SGEMMContext is SGEMM that stops early when ctx is done.
+func (Reference) SGEMMContext(ctx context.Context, transA, transB Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32, err error) {
+	if err := gemmError("SGEMM", transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc); err != nil {
+		return c, err
+	}
+	return c, gen.GemmContext(ctx, transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// STRSMContext is STRSM that stops early when ctx is done.
+func (Reference) STRSMContext(ctx context.Context, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) (rb []float32, err error) {
+	if err := trmmError("STRSM", side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb); err != nil {
+		return b, err
+	}
+	return b, gen.TrsmContext(ctx, side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// DGEMMContext is DGEMM that stops early when ctx is done.
+func (Reference) DGEMMContext(ctx context.Context, transA, transB Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64, err error) {
+	if err := gemmError("DGEMM", transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc); err != nil {
+		return c, err
+	}
+	return c, gen.GemmContext(ctx, transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// DTRSMContext is DTRSM that stops early when ctx is done.
+func (Reference) DTRSMContext(ctx context.Context, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) (rb []float64, err error) {
+	if err := trmmError("DTRSM", side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb); err != nil {
+		return b, err
+	}
+	return b, gen.TrsmContext(ctx, side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// CGEMMContext is CGEMM that stops early when ctx is done.
+func (Reference) CGEMMContext(ctx context.Context, transA, transB Transpose, m, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64, err error) {
+	if err := gemmError("CGEMM", transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc); err != nil {
+		return c, err
+	}
+	return c, gen.GemmContext(ctx, transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// CTRSMContext is CTRSM that stops early when ctx is done.
+func (Reference) CTRSMContext(ctx context.Context, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) (rb []complex64, err error) {
+	if err := trmmError("CTRSM", side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb); err != nil {
+		return b, err
+	}
+	return b, gen.TrsmContext(ctx, side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// ZGEMMContext is ZGEMM that stops early when ctx is done.
+func (Reference) ZGEMMContext(ctx context.Context, transA, transB Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128, err error) {
+	if err := gemmError("ZGEMM", transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc); err != nil {
+		return c, err
+	}
+	return c, gen.GemmContext(ctx, transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// ZTRSMContext is ZTRSM that stops early when ctx is done.
+func (Reference) ZTRSMContext(ctx context.Context, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) (rb []complex128, err error) {
+	if err := trmmError("ZTRSM", side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb); err != nil {
+		return b, err
+	}
+	return b, gen.TrsmContext(ctx, side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
@@ -0,0 +1,199 @@
+package blas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// countdown is a context whose Err reports cancellation from its n+1st
+// call on, which stops the Context routines at a chosen point of their work.
+type countdown struct {
+	context.Context
+	n atomic.Int64
+}
+
+func newCountdown(n int) *countdown {
+	c := &countdown{Context: context.Background()}
+	c.n.Store(int64(n))
+	return c
+}
+
+func (c *countdown) Err() error {
+	if c.n.Add(-1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+// contextRoutines holds the Context routines of one precision and the plain
+// xTRSM they are compared with.
+type contextRoutines[T scalar] struct {
+	gemm func(context.Context, Transpose, Transpose, int, int, int, T, []T, int, []T, int, T, []T, int) ([]T, error)
+	trsm func(context.Context, Side, Uplo, Transpose, Diag, int, int, T, []T, int, []T, int) ([]T, error)
+	ref  func(Side, Uplo, Transpose, Diag, int, int, T, []T, int, []T, int) []T
+}
+
+func TestContext(t *testing.T) {
+	var r Reference
+	testContext(t, "S", contextRoutines[float32]{r.SGEMMContext, r.STRSMContext, r.STRSM})
+	testContext(t, "D", contextRoutines[float64]{r.DGEMMContext, r.DTRSMContext, r.DTRSM})
+	testContext(t, "C", contextRoutines[complex64]{r.CGEMMContext, r.CTRSMContext, r.CTRSM})
+	testContext(t, "Z", contextRoutines[complex128]{r.ZGEMMContext, r.ZTRSMContext, r.ZTRSM})
+}
+
+func testContext[T scalar](t *testing.T, prec string, f contextRoutines[T]) {
+	defer SetNumThreads(SetNumThreads(1))
+	rnd := rand.New(rand.NewSource(1))
+	cplx, tol := isCmplx[T](), tolOf[T]()
+
+	// With A and B of ones, alpha one and beta two, every element of C
+	// starts at one and becomes 2+l, where l is the number of terms of
+	// its sum added so far; l is k once the product is complete.
+	const n, k = 300, 300
+	ones := func(size int) []T {
+		x := make([]T, size)
+		for i := range x {
+			x[i] = 1
+		}
+		return x
+	}
+	a, b := ones(n*k), ones(k*n)
+	added := func(c []T) (lo, hi int, ok bool) {
+		lo, hi = k, 0
+		for _, v := range toC(c) {
+			l := real(v) - 2
+			if imag(v) != 0 || l != float64(int(l)) || l < 0 || l > k {
+				return 0, 0, false
+			}
+			lo, hi = min(lo, int(l)), max(hi, int(l))
+		}
+		return lo, hi, true
+	}
+
+	// A triangular A that is well conditioned and a random B.
+	ta := randC(rnd, n*n, cplx)
+	for i := 0; i < n; i++ {
+		ta[i+i*n] += n
+	}
+	at := toT[T](ta)
+	b0 := toT[T](randC(rnd, n*n, cplx))
+	x := f.ref(SideL, UploU, TransN, DiagN, n, n, 2, at, n, append([]T(nil), b0...), n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := ones(n * n)
+	if _, err := f.gemm(ctx, TransN, TransN, n, n, k, 1, a, n, b, k, 2, c, n); !errors.Is(err, context.Canceled) {
+		t.Errorf("%sGEMMContext cancelled before the call: err = %v, want %v", prec, err, context.Canceled)
+	}
+	for i, v := range c {
+		if v != 1 {
+			t.Errorf("%sGEMMContext cancelled before the call: C[%d] = %v, want untouched 1", prec, i, v)
+			break
+		}
+	}
+	bt := append([]T(nil), b0...)
+	if _, err := f.trsm(ctx, SideL, UploU, TransN, DiagN, n, n, 2, at, n, bt, n); !errors.Is(err, context.Canceled) {
+		t.Errorf("%sTRSMContext cancelled before the call: err = %v, want %v", prec, err, context.Canceled)
+	}
+	for i := range bt {
+		if bt[i] != b0[i] {
+			t.Errorf("%sTRSMContext cancelled before the call: B[%d] = %v, want untouched %v", prec, i, bt[i], b0[i])
+			break
+		}
+	}
+
+	// With a handler that returns, an illegal argument is returned as the
+	// reported *Error, and the output is untouched.
+	var reported *Error
+	prev := SetErrorHandler(func(err *Error) { reported = err })
+	c = ones(n * n)
+	_, err := f.gemm(context.Background(), TransN, TransN, n, n, k, 1, a, n-1, b, k, 2, c, n)
+	var e *Error
+	if !errors.As(err, &e) || e != reported || e.Param != 8 {
+		t.Errorf("%sGEMMContext with lda = n-1: err = %v, want the reported error for parameter 8", prec, err)
+	}
+	if !slices.Equal(c, ones(n*n)) {
+		t.Errorf("%sGEMMContext with lda = n-1: C modified", prec)
+	}
+	bt = append([]T(nil), b0...)
+	_, err = f.trsm(context.Background(), Side('Q'), UploU, TransN, DiagN, n, n, 2, at, n, bt, n)
+	if !errors.As(err, &e) || e != reported || e.Param != 1 {
+		t.Errorf("%sTRSMContext with side Q: err = %v, want the reported error for parameter 1", prec, err)
+	}
+	if !slices.Equal(bt, b0) {
+		t.Errorf("%sTRSMContext with side Q: B modified", prec)
+	}
+	SetErrorHandler(prev)
+
+	for _, threads := range []int{1, 4} {
+		SetNumThreads(threads)
+		name := fmt.Sprintf("%s with %d threads", prec, threads)
+
+		// The product has several blocks along k and along m, so that
+		// cancelling after three of them leaves it part done.
+		c := ones(n * n)
+		if _, err := f.gemm(newCountdown(3), TransN, TransN, n, n, k, 1, a, n, b, k, 2, c, n); !errors.Is(err, context.Canceled) {
+			t.Errorf("%sGEMMContext cancelled %s: err = %v, want %v", prec, name, err, context.Canceled)
+		}
+		if lo, _, ok := added(c); !ok {
+			t.Errorf("%sGEMMContext cancelled %s: C is not beta*C plus part of the product", prec, name)
+		} else if lo == k {
+			t.Errorf("%sGEMMContext cancelled %s: the product is complete", prec, name)
+		}
+
+		// Uncancelled calls complete.
+		c = ones(n * n)
+		if _, err := f.gemm(newCountdown(1000), TransN, TransN, n, n, k, 1, a, n, b, k, 2, c, n); err != nil {
+			t.Errorf("%sGEMMContext %s: unexpected error %v", prec, name, err)
+		} else if lo, hi, ok := added(c); !ok || lo != k || hi != k {
+			t.Errorf("%sGEMMContext %s: incomplete product", prec, name)
+		}
+
+		// The diagonal blocks of A are solved from the bottom up, so the
+		// rows of the blocks solved before the cancellation hold X, and
+		// the others do not.
+		bt := append([]T(nil), b0...)
+		if _, err := f.trsm(newCountdown(2), SideL, UploU, TransN, DiagN, n, n, 2, at, n, bt, n); !errors.Is(err, context.Canceled) {
+			t.Errorf("%sTRSMContext cancelled %s: err = %v, want %v", prec, name, err, context.Canceled)
+		}
+		if threads == 1 {
+			solved := 0
+			for i := n - 1; i >= 0; i-- {
+				if !closeTo(rowOf(toC(bt), n, n, i), rowOf(toC(x), n, n, i), tol) {
+					break
+				}
+				solved++
+			}
+			if solved == 0 || solved == n {
+				t.Errorf("%sTRSMContext cancelled %s: %d rows of B hold X, want some but not all", prec, name, solved)
+			}
+			for i := 0; i < n-solved; i++ {
+				if closeTo(rowOf(toC(bt), n, n, i), rowOf(toC(x), n, n, i), tol) {
+					t.Errorf("%sTRSMContext cancelled %s: row %d of B holds X above an unsolved row", prec, name, i)
+					break
+				}
+			}
+		}
+
+		bt = append([]T(nil), b0...)
+		if _, err := f.trsm(newCountdown(1000), SideL, UploU, TransN, DiagN, n, n, 2, at, n, bt, n); err != nil {
+			t.Errorf("%sTRSMContext %s: unexpected error %v", prec, name, err)
+		} else if !closeTo(toC(bt), toC(x), tol) {
+			t.Errorf("%sTRSMContext %s: result differs from %sTRSM", prec, name, prec)
+		}
+	}
+}
+
+// rowOf returns row i of the m×n column-major matrix a.
+func rowOf(a []complex128, m, n, i int) []complex128 {
+	r := make([]complex128, n)
+	for j := range r {
+		r[j] = a[i+j*m]
+	}
+	return r
+}
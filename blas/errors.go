@@ -0,0 +1,55 @@
+package blas
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Error describes an illegal argument passed to a routine. Param is the
+// 1-based position of the argument in the parameter list of the routine, as
+// reported by the Netlib XERBLA routine. The batched routines report the
+// arguments of their products at their position in the plain routine, with
+// the group named in Reason.
+type Error struct {
+	Routine string // name of the routine, e.g. "DGEMM"
+	Param   int    // position of the illegal argument
+	Name    string // name of the illegal argument, e.g. "lda"
+	Reason  string // what the argument violates, e.g. "must be >= max(1,m)"
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: parameter %d (%s) %s", e.Routine, e.Param, e.Name, e.Reason)
+}
+
+// ErrorHandler is called with the first illegal argument a routine detects,
+// in the role of the Fortran XERBLA routine. If the handler returns, the
+// routine returns immediately without doing any work: slices are returned
+// unchanged and scalar results are zero (-1 for the IxAMAX routines).
+type ErrorHandler func(err *Error)
+
+// PanicHandler is the default ErrorHandler. It panics with err.
+func PanicHandler(err *Error) {
+	panic(err)
+}
+
+var errorHandler atomic.Value // ErrorHandler
+
+func init() {
+	errorHandler.Store(ErrorHandler(PanicHandler))
+}
+
+// SetErrorHandler installs h as the handler for illegal arguments and returns
+// the previous handler. A nil h restores PanicHandler. The handler is shared
+// by all goroutines and must be safe for concurrent use.
+func SetErrorHandler(h ErrorHandler) (previous ErrorHandler) {
+	if h == nil {
+		h = PanicHandler
+	}
+	return errorHandler.Swap(h).(ErrorHandler)
+}
+
+// Xerbla reports err to the installed error handler. Packages built on BLAS,
+// such as lapack, use it to report their own illegal arguments.
+func Xerbla(err *Error) {
+	errorHandler.Load().(ErrorHandler)(err)
+}
@@ -0,0 +1,175 @@
+package gen
+
+import (
+	"context"
+	"slices"
+)
+
+// GemmGroup is a group of a batched Gemm: the products
+// C[i] = Alpha*op(A[i])*op(B[i]) + Beta*C[i] for every i, which share their
+// options, dimensions and scalars. A, B and C hold the same number of
+// matrices.
+type GemmGroup[T Scalar] struct {
+	TransA, TransB Transpose
+	M, N, K        int
+	Alpha          T
+	A              [][]T
+	Lda            int
+	B              [][]T
+	Ldb            int
+	Beta           T
+	C              [][]T
+	Ldc            int
+}
+
+// TrsmGroup is a group of a batched Trsm: the systems op(A[i])*X = Alpha*B[i]
+// (Side L) or X*op(A[i]) = Alpha*B[i] (Side R) for every i, which share their
+// options, dimensions and scalar. A and B hold the same number of matrices.
+type TrsmGroup[T Scalar] struct {
+	Side  Side
+	Uplo  Uplo
+	Trans Transpose
+	Diag  Diag
+	M, N  int
+	Alpha T
+	A     [][]T
+	Lda   int
+	B     [][]T
+	Ldb   int
+}
+
+// GemvGroup is a group of a batched Gemv: the products
+// y[i] = Alpha*op(A[i])*x[i] + Beta*y[i] for every i, which share their
+// options, dimensions and scalars. A, X and Y hold the same number of
+// matrices and vectors.
+type GemvGroup[T Scalar] struct {
+	Trans Transpose
+	M, N  int
+	Alpha T
+	A     [][]T
+	Lda   int
+	X     [][]T
+	IncX  int
+	Beta  T
+	Y     [][]T
+	IncY  int
+}
+
+// batch calls f(i, w) for the count members of a batch that total work
+// multiply-adds. The members are handed out to goroutines as in parallel,
+// and w is the number of goroutines member i may use in turn, which is more
+// than one only for batches with fewer members than goroutines.
+func batch(count int, work float64, f func(i, w int)) {
+	w := workers(work)
+	if w == 1 || count == 1 {
+		for i := 0; i < count; i++ {
+			f(i, w)
+		}
+		return
+	}
+	inner := max(1, w/count)
+	parallel(count, w, func(i int) {
+		f(i, inner)
+	})
+}
+
+// member returns the group that holds member i of a batch, given the index
+// of the first member of every group in starts, and the index of the member
+// within the group. Empty groups share their start with the next group and
+// are skipped.
+func member(starts []int, i int) (g, j int) {
+	g, _ = slices.BinarySearch(starts, i+1)
+	g--
+	return g, i - starts[g]
+}
+
+// GemmBatched computes the products of every group. The products may run
+// concurrently, so the C matrices must not overlap.
+func GemmBatched[T Scalar](groups []GemmGroup[T]) {
+	var count int
+	var work float64
+	starts := make([]int, len(groups))
+	for i, g := range groups {
+		starts[i] = count
+		count += len(g.C)
+		work += float64(len(g.C)) * float64(g.M) * float64(g.N) * float64(g.K)
+	}
+	batch(count, work, func(i, w int) {
+		g, j := member(starts, i)
+		p := &groups[g]
+		w = min(w, workers(float64(p.M)*float64(p.N)*float64(p.K)))
+		gemmOn(context.Background(), w, p.TransA, p.TransB, p.M, p.N, p.K, p.Alpha, p.A[j], p.Lda, p.B[j], p.Ldb, p.Beta, p.C[j], p.Ldc)
+	})
+}
+
+// GemmStridedBatched computes C[i] = alpha*op(A[i])*op(B[i]) + beta*C[i] for
+// i in [0, count), where A[i] starts at a[i*strideA], B[i] at b[i*strideB]
+// and C[i] at c[i*strideC]. A zero stride reuses one matrix for every
+// product; the C matrices must not overlap.
+func GemmStridedBatched[T Scalar](transA, transB Transpose, m, n, k int, alpha T, a []T, lda, strideA int, b []T, ldb, strideB int, beta T, c []T, ldc, strideC, count int) {
+	mnk := float64(m) * float64(n) * float64(k)
+	batch(count, float64(count)*mnk, func(i, w int) {
+		w = min(w, workers(mnk))
+		gemmOn(context.Background(), w, transA, transB, m, n, k, alpha, a[i*strideA:], lda, b[i*strideB:], ldb, beta, c[i*strideC:], ldc)
+	})
+}
+
+// TrsmBatched solves the systems of every group. The systems may be solved
+// concurrently, so the B matrices must not overlap.
+func TrsmBatched[T Scalar](groups []TrsmGroup[T]) {
+	var count int
+	var work float64
+	starts := make([]int, len(groups))
+	for i, g := range groups {
+		starts[i] = count
+		count += len(g.B)
+		work += float64(len(g.B)) * triWork(g.Side, g.M, g.N)
+	}
+	batch(count, work, func(i, w int) {
+		g, j := member(starts, i)
+		p := &groups[g]
+		w = min(w, workers(triWork(p.Side, p.M, p.N)))
+		trsmOn(context.Background(), w, p.Side, p.Uplo, p.Trans, p.Diag, p.M, p.N, p.Alpha, p.A[j], p.Lda, p.B[j], p.Ldb)
+	})
+}
+
+// TrsmStridedBatched solves op(A[i])*X = alpha*B[i] (side L) or
+// X*op(A[i]) = alpha*B[i] (side R) for i in [0, count), where A[i] starts at
+// a[i*strideA] and B[i] at b[i*strideB]. B[i] is overwritten by X. A zero
+// strideA reuses one matrix for every system; the B matrices must not
+// overlap.
+func TrsmStridedBatched[T Scalar](side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda, strideA int, b []T, ldb, strideB, count int) {
+	work := triWork(side, m, n)
+	batch(count, float64(count)*work, func(i, w int) {
+		w = min(w, workers(work))
+		trsmOn(context.Background(), w, side, uplo, trans, diag, m, n, alpha, a[i*strideA:], lda, b[i*strideB:], ldb)
+	})
+}
+
+// GemvBatched computes the products of every group. The products may run
+// concurrently, so the y vectors must not overlap.
+func GemvBatched[T Scalar](groups []GemvGroup[T]) {
+	var count int
+	var work float64
+	starts := make([]int, len(groups))
+	for i, g := range groups {
+		starts[i] = count
+		count += len(g.Y)
+		work += float64(len(g.Y)) * float64(g.M) * float64(g.N)
+	}
+	batch(count, work, func(i, _ int) {
+		g, j := member(starts, i)
+		p := &groups[g]
+		Gemv(p.Trans, p.M, p.N, p.Alpha, p.A[j], p.Lda, p.X[j], p.IncX, p.Beta, p.Y[j], p.IncY)
+	})
+}
+
+// GemvStridedBatched computes y[i] = alpha*op(A[i])*x[i] + beta*y[i] for i in
+// [0, count), where A[i] starts at a[i*strideA], x[i] at x[i*strideX] and
+// y[i] at y[i*strideY]. A zero stride reuses one matrix or vector for every
+// product; the y vectors must not overlap.
+func GemvStridedBatched[T Scalar](trans Transpose, m, n int, alpha T, a []T, lda, strideA int, x []T, incX, strideX int, beta T, y []T, incY, strideY, count int) {
+	batch(count, float64(count)*float64(m)*float64(n), func(i, _ int) {
+		Gemv(trans, m, n, alpha, a[i*strideA:], lda, x[i*strideX:], incX, beta, y[i*strideY:], incY)
+	})
+}
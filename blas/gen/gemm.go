@@ -0,0 +1,261 @@
+package gen
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Blocking holds the cache block sizes of the packed matrix multiplication
+// behind Gemm, counted in elements. Gemm multiplies MC×KC blocks of op(A) by
+// KC×NC panels of op(B): a block of A should fill about half of the L2 cache
+// and a panel of B a part of the L3 cache. MC and NC are rounded up to
+// multiples of the micro-kernel tile.
+type Blocking struct {
+	MC, KC, NC int
+}
+
+// defaultBlocking suits the L2 caches of 256 KiB and up of current x86 and
+// ARM cores for float64.
+var defaultBlocking = Blocking{MC: 96, KC: 256, NC: 4096}
+
+var blocking atomic.Pointer[Blocking]
+
+func init() {
+	b := defaultBlocking
+	blocking.Store(&b)
+}
+
+// SetBlocking sets the block sizes used by Gemm and returns the previous
+// ones. Fields that are not positive take their default value. It is safe to
+// call concurrently with Gemm; calls already running keep the sizes they
+// started with.
+func SetBlocking(b Blocking) (previous Blocking) {
+	if b.MC <= 0 {
+		b.MC = defaultBlocking.MC
+	}
+	if b.KC <= 0 {
+		b.KC = defaultBlocking.KC
+	}
+	if b.NC <= 0 {
+		b.NC = defaultBlocking.NC
+	}
+	return *blocking.Swap(&b)
+}
+
+// microKernel computes C += alpha*A*B for an mr×nr tile of C, where A is an
+// mr×k panel packed by packA and B is a k×nr panel packed by packB.
+type microKernel[T Scalar] struct {
+	mr, nr int
+	fn     func(k int, alpha T, a, b, c []T, ldc int)
+}
+
+// packedMin is the smallest m*n*k for which Gemm packs its operands. Smaller
+// products are dominated by the cost of packing.
+const packedMin = 32 * 32 * 32
+
+// gemmPacked computes C += alpha*op(A)*op(B) in the manner of GotoBLAS and
+// BLIS: panels of op(B) and blocks of op(A) are copied into contiguous
+// buffers, conjugated as requested, and C is updated one mr×nr tile at a
+// time by the micro-kernel. It checks ctx before each block of A and returns
+// ctx.Err(), leaving C partially updated, once ctx is done.
+func gemmPacked[T Scalar](ctx context.Context, ta, tb, ca, cb bool, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, c []T, ldc int) error {
+	uk := gemmMicroKernel[T]()
+	mr, nr := uk.mr, uk.nr
+	bs := *blocking.Load()
+	mc := roundUp(min(bs.MC, m), mr)
+	nc := roundUp(min(bs.NC, n), nr)
+	kc := min(bs.KC, k)
+
+	// The packed block of A, the packed panel of B and the edge tile share
+	// one pooled buffer; a tile on the stack would escape through uk.fn.
+	buf := getBuf[T](mc*kc + kc*nc + mr*nr)
+	defer putBuf(buf)
+	ap, bp, tile := (*buf)[:mc*kc], (*buf)[mc*kc:mc*kc+kc*nc], (*buf)[mc*kc+kc*nc:]
+	for j0 := 0; j0 < n; j0 += nc {
+		jb := min(nc, n-j0)
+		for l0 := 0; l0 < k; l0 += kc {
+			lb := min(kc, k-l0)
+			packB(tb, cb, lb, jb, nr, b, ldb, l0, j0, bp)
+			for i0 := 0; i0 < m; i0 += mc {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				ib := min(mc, m-i0)
+				packA(ta, ca, ib, lb, mr, a, lda, i0, l0, ap)
+				for jr := 0; jr < jb; jr += nr {
+					nn := min(nr, jb-jr)
+					bpan := bp[jr*lb : (jr+nr)*lb]
+					for ir := 0; ir < ib; ir += mr {
+						mm := min(mr, ib-ir)
+						apan := ap[ir*lb : (ir+mr)*lb]
+						off := i0 + ir + (j0+jr)*ldc
+						if mm == mr && nn == nr {
+							uk.fn(lb, alpha, apan, bpan, c[off:off+(nr-1)*ldc+mr], ldc)
+							continue
+						}
+						clear(tile)
+						uk.fn(lb, alpha, apan, bpan, tile, mr)
+						for j := 0; j < nn; j++ {
+							ccol := c[off+j*ldc : off+j*ldc+mm]
+							for i, v := range tile[j*mr : j*mr+mm] {
+								ccol[i] += v
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// packA copies the ib×lb block of op(A) starting at (i0, l0) into buf as
+// row panels of mr rows. Within a panel the mr elements of each column are
+// contiguous; rows past ib are zero.
+func packA[T Scalar](ta, ca bool, ib, lb, mr int, a []T, lda, i0, l0 int, buf []T) {
+	p := 0
+	for ir := 0; ir < ib; ir += mr {
+		mm := min(mr, ib-ir)
+		for l := 0; l < lb; l++ {
+			dst := buf[p : p+mr]
+			if !ta {
+				off := i0 + ir + (l0+l)*lda
+				copy(dst, a[off:off+mm])
+			} else {
+				off := l0 + l + (i0+ir)*lda
+				for i := 0; i < mm; i++ {
+					dst[i] = a[off+i*lda]
+				}
+			}
+			if ca {
+				for i := range dst[:mm] {
+					dst[i] = Conj(dst[i])
+				}
+			}
+			clear(dst[mm:])
+			p += mr
+		}
+	}
+}
+
+// packB copies the lb×jb block of op(B) starting at (l0, j0) into buf as
+// column panels of nr columns. Within a panel the nr elements of each row are
+// contiguous; columns past jb are zero.
+func packB[T Scalar](tb, cb bool, lb, jb, nr int, b []T, ldb, l0, j0 int, buf []T) {
+	p := 0
+	for jr := 0; jr < jb; jr += nr {
+		nn := min(nr, jb-jr)
+		for l := 0; l < lb; l++ {
+			dst := buf[p : p+nr]
+			if tb {
+				off := j0 + jr + (l0+l)*ldb
+				copy(dst, b[off:off+nn])
+			} else {
+				off := l0 + l + (j0+jr)*ldb
+				for j := 0; j < nn; j++ {
+					dst[j] = b[off+j*ldb]
+				}
+			}
+			if cb {
+				for j := range dst[:nn] {
+					dst[j] = Conj(dst[j])
+				}
+			}
+			clear(dst[nn:])
+			p += nr
+		}
+	}
+}
+
+// goKernel is the 4×4 micro-kernel in Go used where no assembly kernel is
+// available.
+func goKernel[T Scalar](k int, alpha T, a, b, c []T, ldc int) {
+	var c00, c10, c20, c30 T
+	var c01, c11, c21, c31 T
+	var c02, c12, c22, c32 T
+	var c03, c13, c23, c33 T
+	a, b = a[:4*k], b[:4*k]
+	for l := 0; l < 4*k; l += 4 {
+		a0, a1, a2, a3 := a[l], a[l+1], a[l+2], a[l+3]
+		b0, b1, b2, b3 := b[l], b[l+1], b[l+2], b[l+3]
+		c00 += a0 * b0
+		c10 += a1 * b0
+		c20 += a2 * b0
+		c30 += a3 * b0
+		c01 += a0 * b1
+		c11 += a1 * b1
+		c21 += a2 * b1
+		c31 += a3 * b1
+		c02 += a0 * b2
+		c12 += a1 * b2
+		c22 += a2 * b2
+		c32 += a3 * b2
+		c03 += a0 * b3
+		c13 += a1 * b3
+		c23 += a2 * b3
+		c33 += a3 * b3
+	}
+	col := c[:4]
+	col[0] += alpha * c00
+	col[1] += alpha * c10
+	col[2] += alpha * c20
+	col[3] += alpha * c30
+	col = c[ldc : ldc+4]
+	col[0] += alpha * c01
+	col[1] += alpha * c11
+	col[2] += alpha * c21
+	col[3] += alpha * c31
+	col = c[2*ldc : 2*ldc+4]
+	col[0] += alpha * c02
+	col[1] += alpha * c12
+	col[2] += alpha * c22
+	col[3] += alpha * c32
+	col = c[3*ldc : 3*ldc+4]
+	col[0] += alpha * c03
+	col[1] += alpha * c13
+	col[2] += alpha * c23
+	col[3] += alpha * c33
+}
+
+// goMicroKernel returns goKernel as a microKernel.
+func goMicroKernel[T Scalar]() microKernel[T] {
+	return microKernel[T]{mr: 4, nr: 4, fn: goKernel[T]}
+}
+
+func roundUp(n, m int) int {
+	return (n + m - 1) / m * m
+}
+
+// bufPools hold the packing buffers of Gemm, one pool per element type, so
+// that repeated calls do not allocate.
+var bufPools [4]sync.Pool
+
+func bufPool[T Scalar]() *sync.Pool {
+	var z T
+	switch any(z).(type) {
+	case float32:
+		return &bufPools[0]
+	case float64:
+		return &bufPools[1]
+	case complex64:
+		return &bufPools[2]
+	}
+	return &bufPools[3]
+}
+
+// getBuf returns a buffer of n elements from the pool. Its contents are
+// undefined.
+func getBuf[T Scalar](n int) *[]T {
+	if p, ok := bufPool[T]().Get().(*[]T); ok && cap(*p) >= n {
+		*p = (*p)[:n]
+		return p
+	}
+	s := make([]T, n)
+	return &s
+}
+
+// putBuf returns a buffer obtained from getBuf to the pool.
+func putBuf[T Scalar](p *[]T) {
+	bufPool[T]().Put(p)
+}
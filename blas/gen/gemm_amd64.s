@@ -0,0 +1,192 @@
+//go:build !noasm
+
+#include "textflag.h"
+
+// GEMM micro-kernels using AVX2 and FMA. For each of the k columns of the
+// packed panel of A, two YMM loads bring in mr elements and each of the six
+// elements of the packed row of B is broadcast and multiplied into two
+// accumulators. The accumulated tile is scaled by alpha and added to C.
+
+// func kernelF64(k int, alpha float64, a, b, c []float64, ldc int)
+TEXT ·kernelF64(SB), NOSPLIT, $0-96
+	MOVQ k+0(FP), CX
+	MOVQ a_base+16(FP), SI
+	MOVQ b_base+40(FP), DI
+	MOVQ c_base+64(FP), R8
+	MOVQ ldc+88(FP), R9
+	SHLQ $3, R9
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	VXORPD Y5, Y5, Y5
+	VXORPD Y6, Y6, Y6
+	VXORPD Y7, Y7, Y7
+	VXORPD Y8, Y8, Y8
+	VXORPD Y9, Y9, Y9
+	VXORPD Y10, Y10, Y10
+	VXORPD Y11, Y11, Y11
+
+kernelF64_loop:
+	VMOVUPD (SI), Y12
+	VMOVUPD 32(SI), Y13
+	VBROADCASTSD 0(DI), Y14
+	VFMADD231PD Y12, Y14, Y0
+	VFMADD231PD Y13, Y14, Y1
+	VBROADCASTSD 8(DI), Y15
+	VFMADD231PD Y12, Y15, Y2
+	VFMADD231PD Y13, Y15, Y3
+	VBROADCASTSD 16(DI), Y14
+	VFMADD231PD Y12, Y14, Y4
+	VFMADD231PD Y13, Y14, Y5
+	VBROADCASTSD 24(DI), Y15
+	VFMADD231PD Y12, Y15, Y6
+	VFMADD231PD Y13, Y15, Y7
+	VBROADCASTSD 32(DI), Y14
+	VFMADD231PD Y12, Y14, Y8
+	VFMADD231PD Y13, Y14, Y9
+	VBROADCASTSD 40(DI), Y15
+	VFMADD231PD Y12, Y15, Y10
+	VFMADD231PD Y13, Y15, Y11
+	ADDQ $64, SI
+	ADDQ $48, DI
+	DECQ CX
+	JNZ  kernelF64_loop
+
+	VBROADCASTSD alpha+8(FP), Y14
+	VMOVUPD (R8), Y15
+	VFMADD231PD Y0, Y14, Y15
+	VMOVUPD Y15, (R8)
+	VMOVUPD 32(R8), Y15
+	VFMADD231PD Y1, Y14, Y15
+	VMOVUPD Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPD (R8), Y15
+	VFMADD231PD Y2, Y14, Y15
+	VMOVUPD Y15, (R8)
+	VMOVUPD 32(R8), Y15
+	VFMADD231PD Y3, Y14, Y15
+	VMOVUPD Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPD (R8), Y15
+	VFMADD231PD Y4, Y14, Y15
+	VMOVUPD Y15, (R8)
+	VMOVUPD 32(R8), Y15
+	VFMADD231PD Y5, Y14, Y15
+	VMOVUPD Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPD (R8), Y15
+	VFMADD231PD Y6, Y14, Y15
+	VMOVUPD Y15, (R8)
+	VMOVUPD 32(R8), Y15
+	VFMADD231PD Y7, Y14, Y15
+	VMOVUPD Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPD (R8), Y15
+	VFMADD231PD Y8, Y14, Y15
+	VMOVUPD Y15, (R8)
+	VMOVUPD 32(R8), Y15
+	VFMADD231PD Y9, Y14, Y15
+	VMOVUPD Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPD (R8), Y15
+	VFMADD231PD Y10, Y14, Y15
+	VMOVUPD Y15, (R8)
+	VMOVUPD 32(R8), Y15
+	VFMADD231PD Y11, Y14, Y15
+	VMOVUPD Y15, 32(R8)
+	VZEROUPPER
+	RET
+
+// func kernelF32(k int, alpha float32, a, b, c []float32, ldc int)
+TEXT ·kernelF32(SB), NOSPLIT, $0-96
+	MOVQ k+0(FP), CX
+	MOVQ a_base+16(FP), SI
+	MOVQ b_base+40(FP), DI
+	MOVQ c_base+64(FP), R8
+	MOVQ ldc+88(FP), R9
+	SHLQ $2, R9
+	VXORPS Y0, Y0, Y0
+	VXORPS Y1, Y1, Y1
+	VXORPS Y2, Y2, Y2
+	VXORPS Y3, Y3, Y3
+	VXORPS Y4, Y4, Y4
+	VXORPS Y5, Y5, Y5
+	VXORPS Y6, Y6, Y6
+	VXORPS Y7, Y7, Y7
+	VXORPS Y8, Y8, Y8
+	VXORPS Y9, Y9, Y9
+	VXORPS Y10, Y10, Y10
+	VXORPS Y11, Y11, Y11
+
+kernelF32_loop:
+	VMOVUPS (SI), Y12
+	VMOVUPS 32(SI), Y13
+	VBROADCASTSS 0(DI), Y14
+	VFMADD231PS Y12, Y14, Y0
+	VFMADD231PS Y13, Y14, Y1
+	VBROADCASTSS 4(DI), Y15
+	VFMADD231PS Y12, Y15, Y2
+	VFMADD231PS Y13, Y15, Y3
+	VBROADCASTSS 8(DI), Y14
+	VFMADD231PS Y12, Y14, Y4
+	VFMADD231PS Y13, Y14, Y5
+	VBROADCASTSS 12(DI), Y15
+	VFMADD231PS Y12, Y15, Y6
+	VFMADD231PS Y13, Y15, Y7
+	VBROADCASTSS 16(DI), Y14
+	VFMADD231PS Y12, Y14, Y8
+	VFMADD231PS Y13, Y14, Y9
+	VBROADCASTSS 20(DI), Y15
+	VFMADD231PS Y12, Y15, Y10
+	VFMADD231PS Y13, Y15, Y11
+	ADDQ $64, SI
+	ADDQ $24, DI
+	DECQ CX
+	JNZ  kernelF32_loop
+
+	VBROADCASTSS alpha+8(FP), Y14
+	VMOVUPS (R8), Y15
+	VFMADD231PS Y0, Y14, Y15
+	VMOVUPS Y15, (R8)
+	VMOVUPS 32(R8), Y15
+	VFMADD231PS Y1, Y14, Y15
+	VMOVUPS Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPS (R8), Y15
+	VFMADD231PS Y2, Y14, Y15
+	VMOVUPS Y15, (R8)
+	VMOVUPS 32(R8), Y15
+	VFMADD231PS Y3, Y14, Y15
+	VMOVUPS Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPS (R8), Y15
+	VFMADD231PS Y4, Y14, Y15
+	VMOVUPS Y15, (R8)
+	VMOVUPS 32(R8), Y15
+	VFMADD231PS Y5, Y14, Y15
+	VMOVUPS Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPS (R8), Y15
+	VFMADD231PS Y6, Y14, Y15
+	VMOVUPS Y15, (R8)
+	VMOVUPS 32(R8), Y15
+	VFMADD231PS Y7, Y14, Y15
+	VMOVUPS Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPS (R8), Y15
+	VFMADD231PS Y8, Y14, Y15
+	VMOVUPS Y15, (R8)
+	VMOVUPS 32(R8), Y15
+	VFMADD231PS Y9, Y14, Y15
+	VMOVUPS Y15, 32(R8)
+	ADDQ R9, R8
+	VMOVUPS (R8), Y15
+	VFMADD231PS Y10, Y14, Y15
+	VMOVUPS Y15, (R8)
+	VMOVUPS 32(R8), Y15
+	VFMADD231PS Y11, Y14, Y15
+	VMOVUPS Y15, 32(R8)
+	VZEROUPPER
+	RET
@@ -0,0 +1,29 @@
+//go:build !noasm
+
+package gen
+
+// kernelF64 is the 8×4 float64 micro-kernel and kernelF32 the 16×4 float32
+// micro-kernel. Both keep the tile of C in sixteen NEON registers.
+//
+//go:noescape
+func kernelF64(k int, alpha float64, a, b, c []float64, ldc int)
+
+//go:noescape
+func kernelF32(k int, alpha float32, a, b, c []float32, ldc int)
+
+var (
+	microKernelF64 = microKernel[float64]{mr: 8, nr: 4, fn: kernelF64}
+	microKernelF32 = microKernel[float32]{mr: 16, nr: 4, fn: kernelF32}
+)
+
+// gemmMicroKernel returns the micro-kernel used by the packed Gemm for T.
+func gemmMicroKernel[T Scalar]() microKernel[T] {
+	var z T
+	switch any(z).(type) {
+	case float64:
+		return *any(&microKernelF64).(*microKernel[T])
+	case float32:
+		return *any(&microKernelF32).(*microKernel[T])
+	}
+	return goMicroKernel[T]()
+}
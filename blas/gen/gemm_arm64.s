@@ -0,0 +1,171 @@
+//go:build !noasm
+
+#include "textflag.h"
+
+// GEMM micro-kernels using NEON. For each of the k columns of the packed
+// panel of A, four vector loads bring in mr elements and each of the four
+// elements of the packed row of B is replicated across a vector and
+// multiplied into four accumulators. The accumulated tile is scaled by alpha
+// and added to C.
+
+// func kernelF64(k int, alpha float64, a, b, c []float64, ldc int)
+TEXT ·kernelF64(SB), NOSPLIT, $0-96
+	MOVD k+0(FP), R0
+	MOVD a_base+16(FP), R1
+	MOVD b_base+40(FP), R2
+	MOVD c_base+64(FP), R3
+	MOVD ldc+88(FP), R4
+	LSL  $3, R4
+	VEOR V0.B16, V0.B16, V0.B16
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	VEOR V4.B16, V4.B16, V4.B16
+	VEOR V5.B16, V5.B16, V5.B16
+	VEOR V6.B16, V6.B16, V6.B16
+	VEOR V7.B16, V7.B16, V7.B16
+	VEOR V8.B16, V8.B16, V8.B16
+	VEOR V9.B16, V9.B16, V9.B16
+	VEOR V10.B16, V10.B16, V10.B16
+	VEOR V11.B16, V11.B16, V11.B16
+	VEOR V12.B16, V12.B16, V12.B16
+	VEOR V13.B16, V13.B16, V13.B16
+	VEOR V14.B16, V14.B16, V14.B16
+	VEOR V15.B16, V15.B16, V15.B16
+
+kernelF64_loop:
+	VLD1.P  64(R1), [V16.D2, V17.D2, V18.D2, V19.D2]
+	VLD1R.P 8(R2), [V20.D2]
+	VLD1R.P 8(R2), [V21.D2]
+	VLD1R.P 8(R2), [V22.D2]
+	VLD1R.P 8(R2), [V23.D2]
+	VFMLA   V20.D2, V16.D2, V0.D2
+	VFMLA   V20.D2, V17.D2, V1.D2
+	VFMLA   V20.D2, V18.D2, V2.D2
+	VFMLA   V20.D2, V19.D2, V3.D2
+	VFMLA   V21.D2, V16.D2, V4.D2
+	VFMLA   V21.D2, V17.D2, V5.D2
+	VFMLA   V21.D2, V18.D2, V6.D2
+	VFMLA   V21.D2, V19.D2, V7.D2
+	VFMLA   V22.D2, V16.D2, V8.D2
+	VFMLA   V22.D2, V17.D2, V9.D2
+	VFMLA   V22.D2, V18.D2, V10.D2
+	VFMLA   V22.D2, V19.D2, V11.D2
+	VFMLA   V23.D2, V16.D2, V12.D2
+	VFMLA   V23.D2, V17.D2, V13.D2
+	VFMLA   V23.D2, V18.D2, V14.D2
+	VFMLA   V23.D2, V19.D2, V15.D2
+	SUBS    $1, R0, R0
+	BNE     kernelF64_loop
+
+	MOVD alpha+8(FP), R5
+	VDUP R5, V24.D2
+	VLD1 (R3), [V25.D2, V26.D2, V27.D2, V28.D2]
+	VFMLA V24.D2, V0.D2, V25.D2
+	VFMLA V24.D2, V1.D2, V26.D2
+	VFMLA V24.D2, V2.D2, V27.D2
+	VFMLA V24.D2, V3.D2, V28.D2
+	VST1 [V25.D2, V26.D2, V27.D2, V28.D2], (R3)
+	ADD  R4, R3, R3
+	VLD1 (R3), [V25.D2, V26.D2, V27.D2, V28.D2]
+	VFMLA V24.D2, V4.D2, V25.D2
+	VFMLA V24.D2, V5.D2, V26.D2
+	VFMLA V24.D2, V6.D2, V27.D2
+	VFMLA V24.D2, V7.D2, V28.D2
+	VST1 [V25.D2, V26.D2, V27.D2, V28.D2], (R3)
+	ADD  R4, R3, R3
+	VLD1 (R3), [V25.D2, V26.D2, V27.D2, V28.D2]
+	VFMLA V24.D2, V8.D2, V25.D2
+	VFMLA V24.D2, V9.D2, V26.D2
+	VFMLA V24.D2, V10.D2, V27.D2
+	VFMLA V24.D2, V11.D2, V28.D2
+	VST1 [V25.D2, V26.D2, V27.D2, V28.D2], (R3)
+	ADD  R4, R3, R3
+	VLD1 (R3), [V25.D2, V26.D2, V27.D2, V28.D2]
+	VFMLA V24.D2, V12.D2, V25.D2
+	VFMLA V24.D2, V13.D2, V26.D2
+	VFMLA V24.D2, V14.D2, V27.D2
+	VFMLA V24.D2, V15.D2, V28.D2
+	VST1 [V25.D2, V26.D2, V27.D2, V28.D2], (R3)
+	RET
+
+// func kernelF32(k int, alpha float32, a, b, c []float32, ldc int)
+TEXT ·kernelF32(SB), NOSPLIT, $0-96
+	MOVD k+0(FP), R0
+	MOVD a_base+16(FP), R1
+	MOVD b_base+40(FP), R2
+	MOVD c_base+64(FP), R3
+	MOVD ldc+88(FP), R4
+	LSL  $2, R4
+	VEOR V0.B16, V0.B16, V0.B16
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+	VEOR V4.B16, V4.B16, V4.B16
+	VEOR V5.B16, V5.B16, V5.B16
+	VEOR V6.B16, V6.B16, V6.B16
+	VEOR V7.B16, V7.B16, V7.B16
+	VEOR V8.B16, V8.B16, V8.B16
+	VEOR V9.B16, V9.B16, V9.B16
+	VEOR V10.B16, V10.B16, V10.B16
+	VEOR V11.B16, V11.B16, V11.B16
+	VEOR V12.B16, V12.B16, V12.B16
+	VEOR V13.B16, V13.B16, V13.B16
+	VEOR V14.B16, V14.B16, V14.B16
+	VEOR V15.B16, V15.B16, V15.B16
+
+kernelF32_loop:
+	VLD1.P  64(R1), [V16.S4, V17.S4, V18.S4, V19.S4]
+	VLD1R.P 4(R2), [V20.S4]
+	VLD1R.P 4(R2), [V21.S4]
+	VLD1R.P 4(R2), [V22.S4]
+	VLD1R.P 4(R2), [V23.S4]
+	VFMLA   V20.S4, V16.S4, V0.S4
+	VFMLA   V20.S4, V17.S4, V1.S4
+	VFMLA   V20.S4, V18.S4, V2.S4
+	VFMLA   V20.S4, V19.S4, V3.S4
+	VFMLA   V21.S4, V16.S4, V4.S4
+	VFMLA   V21.S4, V17.S4, V5.S4
+	VFMLA   V21.S4, V18.S4, V6.S4
+	VFMLA   V21.S4, V19.S4, V7.S4
+	VFMLA   V22.S4, V16.S4, V8.S4
+	VFMLA   V22.S4, V17.S4, V9.S4
+	VFMLA   V22.S4, V18.S4, V10.S4
+	VFMLA   V22.S4, V19.S4, V11.S4
+	VFMLA   V23.S4, V16.S4, V12.S4
+	VFMLA   V23.S4, V17.S4, V13.S4
+	VFMLA   V23.S4, V18.S4, V14.S4
+	VFMLA   V23.S4, V19.S4, V15.S4
+	SUBS    $1, R0, R0
+	BNE     kernelF32_loop
+
+	MOVWU alpha+8(FP), R5
+	VDUP R5, V24.S4
+	VLD1 (R3), [V25.S4, V26.S4, V27.S4, V28.S4]
+	VFMLA V24.S4, V0.S4, V25.S4
+	VFMLA V24.S4, V1.S4, V26.S4
+	VFMLA V24.S4, V2.S4, V27.S4
+	VFMLA V24.S4, V3.S4, V28.S4
+	VST1 [V25.S4, V26.S4, V27.S4, V28.S4], (R3)
+	ADD  R4, R3, R3
+	VLD1 (R3), [V25.S4, V26.S4, V27.S4, V28.S4]
+	VFMLA V24.S4, V4.S4, V25.S4
+	VFMLA V24.S4, V5.S4, V26.S4
+	VFMLA V24.S4, V6.S4, V27.S4
+	VFMLA V24.S4, V7.S4, V28.S4
+	VST1 [V25.S4, V26.S4, V27.S4, V28.S4], (R3)
+	ADD  R4, R3, R3
+	VLD1 (R3), [V25.S4, V26.S4, V27.S4, V28.S4]
+	VFMLA V24.S4, V8.S4, V25.S4
+	VFMLA V24.S4, V9.S4, V26.S4
+	VFMLA V24.S4, V10.S4, V27.S4
+	VFMLA V24.S4, V11.S4, V28.S4
+	VST1 [V25.S4, V26.S4, V27.S4, V28.S4], (R3)
+	ADD  R4, R3, R3
+	VLD1 (R3), [V25.S4, V26.S4, V27.S4, V28.S4]
+	VFMLA V24.S4, V12.S4, V25.S4
+	VFMLA V24.S4, V13.S4, V26.S4
+	VFMLA V24.S4, V14.S4, V27.S4
+	VFMLA V24.S4, V15.S4, V28.S4
+	VST1 [V25.S4, V26.S4, V27.S4, V28.S4], (R3)
+	RET
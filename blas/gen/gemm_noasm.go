@@ -0,0 +1,8 @@
+//go:build !(amd64 || arm64) || noasm
+
+package gen
+
+// gemmMicroKernel returns the micro-kernel used by the packed Gemm for T.
+func gemmMicroKernel[T Scalar]() microKernel[T] {
+	return goMicroKernel[T]()
+}
@@ -0,0 +1,67 @@
+// Package gen holds the generic kernels behind the blas package. Each kernel
+// is written once over the Float, Complex or Scalar element types and is
+// instantiated by the S, D, C and Z routines of blas.Reference.
+//
+// The kernels do not check their arguments; callers must pass dimensions,
+// increments and slice lengths that the corresponding BLAS routine accepts.
+// Vectors and matrices are column-major and are updated in place.
+//
+// On amd64 processors with AVX2 and FMA, Axpy, Dotu, Scal and Asum run
+// assembly kernels for float32 and float64 vectors.
+//
+// Gemm packs large operands into contiguous panels and updates C one
+// register tile at a time with a micro-kernel, written in assembly for
+// float32 and float64 on amd64 (AVX2 and FMA) and arm64 (NEON) and in Go
+// otherwise. The cache block sizes are set with SetBlocking.
+//
+// Gemm, Syrk, Herk, Syr2k, Her2k, Symm, Hemm, Trmm and Trsm split large
+// problems among goroutines: Gemm over a grid of blocks of C, the rank-k
+// updates over block columns of C and the others over the columns or rows of
+// B that can be computed independently. SetNumThreads bounds the number of
+// goroutines and SetDeterministic controls whether results must not depend
+// on it.
+//
+// Building with the noasm tag removes all assembly and leaves the generic Go
+// code.
+package gen
+
+// Transpose specifies the operation op(A) applied to a matrix A.
+type Transpose rune
+
+// Uplo specifies which triangle of a matrix is referenced.
+type Uplo rune
+
+// Diag specifies whether a triangular matrix has a unit diagonal.
+type Diag rune
+
+// Side specifies on which side a matrix multiplies another.
+type Side rune
+
+const (
+	//TransN means TRANS = 'N'  y := alpha*A*x + beta*y.
+	TransN Transpose = 'N'
+
+	//TransT means TRANS = 'T'  y := alpha*A**T*x + beta*y.
+	TransT Transpose = 'T'
+
+	//TransC means TRANS = 'C'  y := alpha*A**H*x + beta*y.
+	TransC Transpose = 'C'
+
+	// UploU means UPLO = 'U' Only the upper triangular part of A is to be referenced.
+	UploU Uplo = 'U'
+
+	// UploL means UPLO = 'L' Only the lower triangular part of A is to be referenced.
+	UploL Uplo = 'L'
+
+	//DiagU means DIAG = 'U'   A is assumed to be unit triangular.
+	DiagU Diag = 'U'
+
+	//DiagN means DIAG = 'N'   A is not assumed to be unit triangular.
+	DiagN Diag = 'N'
+
+	// SideL means SIDE = 'L'  B := alpha*op(A)*B.
+	SideL Side = 'L'
+
+	// SideR means SIDE = 'R'  B := alpha*B*op(A).
+	SideR Side = 'R'
+)
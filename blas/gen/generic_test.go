@@ -0,0 +1,492 @@
+package gen
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// The benchmarks of this file compare the generic kernels with the same code
+// written by hand for float64, complex64 and complex128, to measure the cost
+// of the type parameters. Each benchmark runs the generic kernel under
+// "generic/" and the hand-written one under the name of the type. Build with
+// -tags noasm to compare the float64 Axpy and Dotu with their Go loops
+// rather than with the assembly kernels.
+
+var benchSizes = []int{16, 256, 4096, 65536}
+
+func benchVec[T Scalar](n int) []T {
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]T, n)
+	for i := range x {
+		x[i] = fromParts[T](rnd.Float64(), rnd.Float64())
+	}
+	return x
+}
+
+var (
+	sink    float64
+	sinkC64 complex64
+	sinkC   complex128
+)
+
+func axpyFloat64(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	if n <= 0 || alpha == 0 {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			y[i] += alpha * v
+		}
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy] += alpha * x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+func axpyComplex64(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) {
+	if n <= 0 || alpha == 0 {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			y[i] += alpha * v
+		}
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy] += alpha * x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+func axpyComplex128(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
+	if n <= 0 || alpha == 0 {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			y[i] += alpha * v
+		}
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy] += alpha * x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+func dotFloat64(n int, x []float64, incX int, y []float64, incY int) float64 {
+	var sum float64
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			sum += v * y[i]
+		}
+		return sum
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		sum += x[ix] * y[iy]
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// dotcComplex64 is Dotc for complex64, which conjugates x.
+func dotcComplex64(n int, x []complex64, incX int, y []complex64, incY int) complex64 {
+	var sum complex64
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			sum += complex(real(v), -imag(v)) * y[i]
+		}
+		return sum
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		v := x[ix]
+		sum += complex(real(v), -imag(v)) * y[iy]
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// dotcComplex128 is Dotc for complex128, which conjugates x.
+func dotcComplex128(n int, x []complex128, incX int, y []complex128, incY int) complex128 {
+	var sum complex128
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			sum += complex(real(v), -imag(v)) * y[i]
+		}
+		return sum
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		v := x[ix]
+		sum += complex(real(v), -imag(v)) * y[iy]
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// gemvFloat64 is Gemv for float64 and no conjugation.
+func gemvFloat64(trans Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	notrans := trans == TransN
+	lenX, lenY := n, m
+	if !notrans {
+		lenX, lenY = m, n
+	}
+	kx, ky := start(lenX, incX), start(lenY, incY)
+	scaleVec(lenY, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	if notrans {
+		for j, jx := 0, kx; j < n; j, jx = j+1, jx+incX {
+			temp := alpha * x[jx]
+			col := a[j*lda : j*lda+m]
+			if incY == 1 {
+				for i, v := range col {
+					y[i] += temp * v
+				}
+				continue
+			}
+			for i, iy := 0, ky; i < m; i, iy = i+1, iy+incY {
+				y[iy] += temp * col[i]
+			}
+		}
+		return
+	}
+	for j, jy := 0, ky; j < n; j, jy = j+1, jy+incY {
+		var temp float64
+		col := a[j*lda : j*lda+m]
+		for i, ix := 0, kx; i < m; i, ix = i+1, ix+incX {
+			temp += col[i] * x[ix]
+		}
+		y[jy] += alpha * temp
+	}
+}
+
+// gemvComplex128 is Gemv for complex128.
+func gemvComplex128(trans Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	notrans, cj := trans == TransN, trans == TransC
+	lenX, lenY := n, m
+	if !notrans {
+		lenX, lenY = m, n
+	}
+	kx, ky := start(lenX, incX), start(lenY, incY)
+	scaleVec(lenY, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	if notrans {
+		for j, jx := 0, kx; j < n; j, jx = j+1, jx+incX {
+			temp := alpha * x[jx]
+			col := a[j*lda : j*lda+m]
+			if incY == 1 {
+				for i, v := range col {
+					y[i] += temp * v
+				}
+				continue
+			}
+			for i, iy := 0, ky; i < m; i, iy = i+1, iy+incY {
+				y[iy] += temp * col[i]
+			}
+		}
+		return
+	}
+	for j, jy := 0, ky; j < n; j, jy = j+1, jy+incY {
+		var temp complex128
+		col := a[j*lda : j*lda+m]
+		for i, ix := 0, kx; i < m; i, ix = i+1, ix+incX {
+			if cj {
+				v := col[i]
+				temp += complex(real(v), -imag(v)) * x[ix]
+			} else {
+				temp += col[i] * x[ix]
+			}
+		}
+		y[jy] += alpha * temp
+	}
+}
+
+// gemmKernelFloat64 is gemmKernel for float64 and op(A) = A, op(B) = B.
+func gemmKernelFloat64(m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) {
+	for j := 0; j < n; j++ {
+		ccol := c[j*ldc : j*ldc+m]
+		for l := 0; l < k; l++ {
+			temp := alpha * b[l+j*ldb]
+			for i, v := range a[l*lda : l*lda+m] {
+				ccol[i] += temp * v
+			}
+		}
+	}
+}
+
+// gemmKernelComplex64 is gemmKernel for complex64 and op(A) = A, op(B) = B.
+func gemmKernelComplex64(m, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, c []complex64, ldc int) {
+	for j := 0; j < n; j++ {
+		ccol := c[j*ldc : j*ldc+m]
+		for l := 0; l < k; l++ {
+			temp := alpha * b[l+j*ldb]
+			for i, v := range a[l*lda : l*lda+m] {
+				ccol[i] += temp * v
+			}
+		}
+	}
+}
+
+// gemmKernelComplex128 is gemmKernel for complex128 and op(A) = A,
+// op(B) = B.
+func gemmKernelComplex128(m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int) {
+	for j := 0; j < n; j++ {
+		ccol := c[j*ldc : j*ldc+m]
+		for l := 0; l < k; l++ {
+			temp := alpha * b[l+j*ldb]
+			for i, v := range a[l*lda : l*lda+m] {
+				ccol[i] += temp * v
+			}
+		}
+	}
+}
+
+// goKernelFloat64 is goKernel for float64.
+func goKernelFloat64(k int, alpha float64, a, b, c []float64, ldc int) {
+	var c00, c10, c20, c30 float64
+	var c01, c11, c21, c31 float64
+	var c02, c12, c22, c32 float64
+	var c03, c13, c23, c33 float64
+	a, b = a[:4*k], b[:4*k]
+	for l := 0; l < 4*k; l += 4 {
+		a0, a1, a2, a3 := a[l], a[l+1], a[l+2], a[l+3]
+		b0, b1, b2, b3 := b[l], b[l+1], b[l+2], b[l+3]
+		c00 += a0 * b0
+		c10 += a1 * b0
+		c20 += a2 * b0
+		c30 += a3 * b0
+		c01 += a0 * b1
+		c11 += a1 * b1
+		c21 += a2 * b1
+		c31 += a3 * b1
+		c02 += a0 * b2
+		c12 += a1 * b2
+		c22 += a2 * b2
+		c32 += a3 * b2
+		c03 += a0 * b3
+		c13 += a1 * b3
+		c23 += a2 * b3
+		c33 += a3 * b3
+	}
+	col := c[:4]
+	col[0] += alpha * c00
+	col[1] += alpha * c10
+	col[2] += alpha * c20
+	col[3] += alpha * c30
+	col = c[ldc : ldc+4]
+	col[0] += alpha * c01
+	col[1] += alpha * c11
+	col[2] += alpha * c21
+	col[3] += alpha * c31
+	col = c[2*ldc : 2*ldc+4]
+	col[0] += alpha * c02
+	col[1] += alpha * c12
+	col[2] += alpha * c22
+	col[3] += alpha * c32
+	col = c[3*ldc : 3*ldc+4]
+	col[0] += alpha * c03
+	col[1] += alpha * c13
+	col[2] += alpha * c23
+	col[3] += alpha * c33
+}
+
+func BenchmarkAxpy(b *testing.B) {
+	for _, n := range benchSizes {
+		for _, inc := range []int{1, 2} {
+			name := "n=" + strconv.Itoa(n) + "/inc=" + strconv.Itoa(inc)
+			x, y := benchVec[float64](n*inc), benchVec[float64](n*inc)
+			b.Run("generic/float64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Axpy(n, 1e-9, x, inc, y, inc)
+				}
+			})
+			b.Run("float64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					axpyFloat64(n, 1e-9, x, inc, y, inc)
+				}
+			})
+			x64, y64 := benchVec[complex64](n*inc), benchVec[complex64](n*inc)
+			b.Run("generic/complex64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Axpy(n, 1e-4i, x64, inc, y64, inc)
+				}
+			})
+			b.Run("complex64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					axpyComplex64(n, 1e-4i, x64, inc, y64, inc)
+				}
+			})
+			x128, y128 := benchVec[complex128](n*inc), benchVec[complex128](n*inc)
+			b.Run("generic/complex128/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Axpy(n, 1e-9i, x128, inc, y128, inc)
+				}
+			})
+			b.Run("complex128/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					axpyComplex128(n, 1e-9i, x128, inc, y128, inc)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDot(b *testing.B) {
+	for _, n := range benchSizes {
+		for _, inc := range []int{1, 2} {
+			name := "n=" + strconv.Itoa(n) + "/inc=" + strconv.Itoa(inc)
+			x, y := benchVec[float64](n*inc), benchVec[float64](n*inc)
+			b.Run("generic/float64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sink += Dotu(n, x, inc, y, inc)
+				}
+			})
+			b.Run("float64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sink += dotFloat64(n, x, inc, y, inc)
+				}
+			})
+			x64, y64 := benchVec[complex64](n*inc), benchVec[complex64](n*inc)
+			b.Run("generic/complex64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sinkC64 += Dotc(n, x64, inc, y64, inc)
+				}
+			})
+			b.Run("complex64/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sinkC64 += dotcComplex64(n, x64, inc, y64, inc)
+				}
+			})
+			x128, y128 := benchVec[complex128](n*inc), benchVec[complex128](n*inc)
+			b.Run("generic/complex128/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sinkC += Dotc(n, x128, inc, y128, inc)
+				}
+			})
+			b.Run("complex128/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sinkC += dotcComplex128(n, x128, inc, y128, inc)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkGemv(b *testing.B) {
+	for _, n := range []int{16, 128, 1024} {
+		for _, trans := range []Transpose{TransN, TransT, TransC} {
+			name := "n=" + strconv.Itoa(n) + "/trans=" + string(trans)
+			if trans != TransC {
+				a, x, y := benchVec[float64](n*n), benchVec[float64](n), benchVec[float64](n)
+				b.Run("generic/float64/"+name, func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						Gemv(trans, n, n, 1, a, n, x, 1, 0.5, y, 1)
+					}
+				})
+				b.Run("float64/"+name, func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						gemvFloat64(trans, n, n, 1, a, n, x, 1, 0.5, y, 1)
+					}
+				})
+			}
+			a, x, y := benchVec[complex128](n*n), benchVec[complex128](n), benchVec[complex128](n)
+			b.Run("generic/complex128/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Gemv(trans, n, n, 1, a, n, x, 1, 0.5i, y, 1)
+				}
+			})
+			b.Run("complex128/"+name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					gemvComplex128(trans, n, n, 1, a, n, x, 1, 0.5i, y, 1)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkGemm compares the inner loop of the unpacked Gemm, which does all
+// of its floating-point work, for small and large matrices, and the Go
+// micro-kernel of the packed Gemm for several depths.
+func BenchmarkGemm(b *testing.B) {
+	for _, n := range []int{8, 32, 64, 256, 512} {
+		name := "n=" + strconv.Itoa(n)
+		a, bm, c := benchVec[float64](n*n), benchVec[float64](n*n), benchVec[float64](n*n)
+		b.Run("generic/float64/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gemmKernel(false, false, false, false, n, n, n, 1e-9, a, n, bm, n, c, n)
+			}
+		})
+		b.Run("float64/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gemmKernelFloat64(n, n, n, 1e-9, a, n, bm, n, c, n)
+			}
+		})
+		a64, b64, c64 := benchVec[complex64](n*n), benchVec[complex64](n*n), benchVec[complex64](n*n)
+		b.Run("generic/complex64/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gemmKernel(false, false, false, false, n, n, n, 1e-4i, a64, n, b64, n, c64, n)
+			}
+		})
+		b.Run("complex64/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gemmKernelComplex64(n, n, n, 1e-4i, a64, n, b64, n, c64, n)
+			}
+		})
+		a128, b128, c128 := benchVec[complex128](n*n), benchVec[complex128](n*n), benchVec[complex128](n*n)
+		b.Run("generic/complex128/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gemmKernel(false, false, false, false, n, n, n, 1e-9i, a128, n, b128, n, c128, n)
+			}
+		})
+		b.Run("complex128/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				gemmKernelComplex128(n, n, n, 1e-9i, a128, n, b128, n, c128, n)
+			}
+		})
+	}
+	for _, k := range []int{64, 256} {
+		a, bm, c := benchVec[float64](4*k), benchVec[float64](4*k), benchVec[float64](16)
+		name := "kernel/k=" + strconv.Itoa(k)
+		b.Run("generic/float64/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				goKernel(k, 1e-9, a, bm, c, 4)
+			}
+		})
+		b.Run("float64/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				goKernelFloat64(k, 1e-9, a, bm, c, 4)
+			}
+		})
+	}
+}
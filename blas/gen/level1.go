@@ -0,0 +1,640 @@
+package gen
+
+// start returns the index of the first element visited in a vector of n
+// elements stored with increment inc. As in the Netlib routines, a negative
+// increment walks the vector backwards, starting at (1-n)*inc.
+func start(n, inc int) int {
+	if inc < 0 {
+		return (1 - n) * inc
+	}
+	return 0
+}
+
+// Swap exchanges the elements of x and y.
+func Swap[T Scalar](n int, x []T, incX int, y []T, incY int) {
+	if n <= 0 {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			x[i], y[i] = y[i], v
+		}
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		x[ix], y[iy] = y[iy], x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+// Scal computes x = alpha*x.
+func Scal[T Scalar](n int, alpha T, x []T, incX int) {
+	if n <= 0 || incX <= 0 {
+		return
+	}
+	if scalAsm(n, alpha, x, incX) {
+		return
+	}
+	if incX == 1 {
+		x = x[:n]
+		for i := range x {
+			x[i] *= alpha
+		}
+		return
+	}
+	for ix := 0; ix < n*incX; ix += incX {
+		x[ix] *= alpha
+	}
+}
+
+// ScalReal computes x = alpha*x for real alpha. The real and imaginary parts
+// of complex elements are scaled separately, as csscal and zdscal do.
+func ScalReal[T Scalar, R Float](n int, alpha R, x []T, incX int) {
+	if n <= 0 || incX <= 0 {
+		return
+	}
+	switch v := any(x).(type) {
+	case []complex64:
+		a := float32(alpha)
+		for ix := 0; ix < n*incX; ix += incX {
+			v[ix] = complex(a*real(v[ix]), a*imag(v[ix]))
+		}
+	case []complex128:
+		a := float64(alpha)
+		for ix := 0; ix < n*incX; ix += incX {
+			v[ix] = complex(a*real(v[ix]), a*imag(v[ix]))
+		}
+	default:
+		Scal(n, fromReal[T](float64(alpha)), x, incX)
+	}
+}
+
+// Rscl computes x = x/a for real a, as drscl from LAPACK. The reciprocal is
+// applied in steps when forming 1/a directly would overflow or underflow.
+func Rscl[T Scalar, R Float](n int, a R, x []T, incX int) {
+	if n <= 0 {
+		return
+	}
+	m := consts[R]()
+	smlnum, bignum := m.safmin, 1/m.safmin
+	cden, cnum := a, R(1)
+	for {
+		cden1 := cden * smlnum
+		cnum1 := cnum / bignum
+		var mul R
+		done := false
+		switch {
+		case absf(cden1) > absf(cnum) && cnum != 0:
+			mul, cden = smlnum, cden1
+		case absf(cnum1) > absf(cden):
+			mul, cnum = bignum, cnum1
+		default:
+			mul, done = cnum/cden, true
+		}
+		ScalReal(n, mul, x, incX)
+		if done {
+			return
+		}
+	}
+}
+
+// Crscl computes x = x/a for complex a = ar + i*ai, as zrscl from LAPACK.
+func Crscl[T Scalar, R Float](n int, ar, ai R, x []T, incX int) {
+	if n <= 0 {
+		return
+	}
+	m := consts[R]()
+	safmin, safmax := m.safmin, 1/m.safmin
+	scalc := func(re, im R) {
+		Scal(n, fromParts[T](float64(re), float64(im)), x, incX)
+	}
+	absr, absi := absf(ar), absf(ai)
+	switch {
+	case ai == 0:
+		Rscl(n, ar, x, incX)
+	case ar == 0:
+		switch {
+		case absi > safmax:
+			ScalReal(n, safmin, x, incX)
+			scalc(0, -safmax/ai)
+		case absi < safmin:
+			scalc(0, -safmin/ai)
+			ScalReal(n, safmax, x, incX)
+		default:
+			scalc(0, -1/ai)
+		}
+	default:
+		ur := ar + ai*(ai/ar)
+		ui := ai + ar*(ar/ai)
+		switch {
+		case absf(ur) < safmin || absf(ui) < safmin:
+			scalc(safmin/ur, -safmin/ui)
+			ScalReal(n, safmax, x, incX)
+		case absf(ur) > safmax || absf(ui) > safmax:
+			if absr > m.huge || absi > m.huge {
+				scalc(1/ur, -1/ui)
+				return
+			}
+			ScalReal(n, safmin, x, incX)
+			if absf(ur) > m.huge || absf(ui) > m.huge {
+				if absr >= absi {
+					ur = safmin*ar + safmin*(ai*(ai/ar))
+					ui = safmin*ai + ar*((safmin*ar)/ai)
+				} else {
+					ur = safmin*ar + ai*((safmin*ai)/ar)
+					ui = safmin*ai + safmin*(ar*(ar/ai))
+				}
+				scalc(1/ur, -1/ui)
+			} else {
+				scalc(safmax/ur, -safmax/ui)
+			}
+		default:
+			scalc(1/ur, -1/ui)
+		}
+	}
+}
+
+// Copy copies x into y.
+func Copy[T Scalar](n int, x []T, incX int, y []T, incY int) {
+	if n <= 0 {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		copy(y[:n], x[:n])
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy] = x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+// Axpy computes y = alpha*x + y.
+func Axpy[T Scalar](n int, alpha T, x []T, incX int, y []T, incY int) {
+	if n <= 0 || alpha == 0 {
+		return
+	}
+	if axpyAsm(n, alpha, x, incX, y, incY) {
+		return
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			y[i] += alpha * v
+		}
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy] += alpha * x[ix]
+		ix += incX
+		iy += incY
+	}
+}
+
+// Dotu returns the unconjugated dot product x'*y.
+func Dotu[T Scalar](n int, x []T, incX int, y []T, incY int) T {
+	var sum T
+	if n <= 0 || dotAsm(n, x, incX, y, incY, &sum) {
+		return sum
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			sum += v * y[i]
+		}
+		return sum
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		sum += x[ix] * y[iy]
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// Dotc returns the conjugated dot product conjg(x')*y.
+func Dotc[T Scalar](n int, x []T, incX int, y []T, incY int) T {
+	var sum T
+	if n <= 0 {
+		return sum
+	}
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			sum += Conj(v) * y[i]
+		}
+		return sum
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		sum += Conj(x[ix]) * y[iy]
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// Dsdot returns the dot product of the float32 vectors x and y accumulated
+// in float64.
+func Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
+	var sum float64
+	if n <= 0 {
+		return sum
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		sum += float64(x[ix]) * float64(y[iy])
+		ix += incX
+		iy += incY
+	}
+	return sum
+}
+
+// Rot applies the plane rotation
+//
+//	[ x ] = [       c  s ] [ x ]
+//	[ y ]   [ -conjg(s) c ] [ y ]
+//
+// to the vectors x and y. The cosine c is real.
+func Rot[T Scalar](n int, x []T, incX int, y []T, incY int, c, s T) {
+	if n <= 0 {
+		return
+	}
+	cs := Conj(s)
+	if incX == 1 && incY == 1 {
+		x, y = x[:n], y[:n]
+		for i, v := range x {
+			w := y[i]
+			x[i] = c*v + s*w
+			y[i] = c*w - cs*v
+		}
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		v, w := x[ix], y[iy]
+		x[ix] = c*v + s*w
+		y[iy] = c*w - cs*v
+		ix += incX
+		iy += incY
+	}
+}
+
+// Asum returns the sum of the absolute values of the elements of x.
+func Asum[R Float](n int, x []R, incX int) R {
+	var sum R
+	if n <= 0 || incX <= 0 || asumAsm(n, x, incX, &sum) {
+		return sum
+	}
+	if incX == 1 {
+		for _, v := range x[:n] {
+			sum += absf(v)
+		}
+		return sum
+	}
+	for ix := 0; ix < n*incX; ix += incX {
+		sum += absf(x[ix])
+	}
+	return sum
+}
+
+// Iamax returns the index of the first element of x with the largest abs1
+// value, or -1 if n < 1 or incX <= 0.
+func Iamax[T Scalar](n int, x []T, incX int) int {
+	if n < 1 || incX <= 0 {
+		return -1
+	}
+	imax := 0
+	vmax := abs1(x[0])
+	for i, ix := 1, incX; i < n; i, ix = i+1, ix+incX {
+		if v := abs1(x[ix]); v > vmax {
+			imax, vmax = i, v
+		}
+	}
+	return imax
+}
+
+// Casum returns the sum of |re(x[i])|+|im(x[i])| over a complex vector, as
+// SCASUM and DZASUM. R must be the real type of T.
+func Casum[R Float, T Complex](n int, x []T, incX int) R {
+	var sum R
+	if n <= 0 || incX <= 0 {
+		return sum
+	}
+	switch x := any(x).(type) {
+	case []complex64:
+		for ix := 0; ix < n*incX; ix += incX {
+			sum += R(absf(real(x[ix])) + absf(imag(x[ix])))
+		}
+	case []complex128:
+		for ix := 0; ix < n*incX; ix += incX {
+			sum += R(absf(real(x[ix])) + absf(imag(x[ix])))
+		}
+	}
+	return sum
+}
+
+// sumsq accumulates the sum of squares of a vector with Blue's algorithm so
+// that the Euclidean norm neither overflows nor underflows, as in dnrm2.f90
+// from LAPACK 3.10.
+type sumsq[R Float] struct {
+	machine[R]
+	asml, amed, abig R
+	notbig           bool
+}
+
+func newSumsq[R Float]() sumsq[R] {
+	return sumsq[R]{machine: consts[R](), notbig: true}
+}
+
+// add accumulates the square of v.
+func (s *sumsq[R]) add(v R) {
+	ax := absf(v)
+	switch {
+	case ax > s.tbig:
+		s.abig += (ax * s.sbig) * (ax * s.sbig)
+		s.notbig = false
+	case ax < s.tsml:
+		if s.notbig {
+			s.asml += (ax * s.ssml) * (ax * s.ssml)
+		}
+	default:
+		s.amed += ax * ax
+	}
+}
+
+// norm returns the square root of the accumulated sum of squares.
+func (s *sumsq[R]) norm() R {
+	var scl, sum R
+	switch {
+	case s.abig > 0:
+		// Combine abig and amed if amed > 0 or amed is NaN.
+		if s.amed > 0 || s.amed != s.amed {
+			s.abig += (s.amed * s.sbig) * s.sbig
+		}
+		scl, sum = 1/s.sbig, s.abig
+	case s.asml > 0:
+		if s.amed > 0 || s.amed != s.amed {
+			amed := sqrtf(s.amed)
+			asml := sqrtf(s.asml) / s.ssml
+			ymin, ymax := asml, amed
+			if asml > amed {
+				ymin, ymax = amed, asml
+			}
+			scl, sum = 1, ymax*ymax*(1+(ymin/ymax)*(ymin/ymax))
+		} else {
+			scl, sum = 1/s.ssml, s.asml
+		}
+	default:
+		scl, sum = 1, s.amed
+	}
+	return scl * sqrtf(sum)
+}
+
+// Nrm2 returns the Euclidean norm of x.
+func Nrm2[R Float](n int, x []R, incX int) R {
+	if n <= 0 {
+		return 0
+	}
+	s := newSumsq[R]()
+	for i, ix := 0, start(n, incX); i < n; i, ix = i+1, ix+incX {
+		s.add(x[ix])
+	}
+	return s.norm()
+}
+
+// Cnrm2 returns the Euclidean norm of a complex vector, as SCNRM2 and DZNRM2.
+// R must be the real type of T.
+func Cnrm2[R Float, T Complex](n int, x []T, incX int) R {
+	if n <= 0 {
+		return 0
+	}
+	s := newSumsq[R]()
+	switch x := any(x).(type) {
+	case []complex64:
+		for i, ix := 0, start(n, incX); i < n; i, ix = i+1, ix+incX {
+			s.add(R(real(x[ix])))
+			s.add(R(imag(x[ix])))
+		}
+	case []complex128:
+		for i, ix := 0, start(n, incX); i < n; i, ix = i+1, ix+incX {
+			s.add(R(real(x[ix])))
+			s.add(R(imag(x[ix])))
+		}
+	}
+	return s.norm()
+}
+
+// Rotg constructs the Givens rotation that zeros b, using the safe scaling
+// of drotg.f90 from LAPACK 3.10.
+func Rotg[R Float](a, b R) (c, s R) {
+	m := consts[R]()
+	anorm, bnorm := absf(a), absf(b)
+	switch {
+	case bnorm == 0:
+		return 1, 0
+	case anorm == 0:
+		return 0, 1
+	}
+	scl := min(m.safmax, max(m.safmin, anorm, bnorm))
+	sigma := signf(1, b)
+	if anorm > bnorm {
+		sigma = signf(1, a)
+	}
+	as, bs := a/scl, b/scl
+	r := sigma * (scl * sqrtf(as*as+bs*bs))
+	return a / r, b / r
+}
+
+// Crotg constructs the complex Givens rotation with real cosine c and complex
+// sine s that zeros g = gr + i*gi, using the safe scaling of zrotg.f90 from
+// LAPACK 3.10. The arguments and results are given by their real and
+// imaginary parts.
+func Crotg[R Float](fr, fi, gr, gi R) (c, sr, si R) {
+	m := consts[R]()
+	abssq := func(re, im R) R { return re*re + im*im }
+	switch {
+	case gr == 0 && gi == 0:
+		return 1, 0, 0
+	case fr == 0 && fi == 0:
+		g1 := max(absf(gr), absf(gi))
+		if g1 > m.rtmin && g1 < m.rtmax {
+			d := sqrtf(abssq(gr, gi))
+			return 0, gr / d, -gi / d
+		}
+		uu := 1 / min(m.safmax, max(m.safmin, g1))
+		gsr, gsi := gr*uu, gi*uu
+		d := sqrtf(abssq(gsr, gsi))
+		return 0, gsr / d, -gsi / d
+	}
+	f1 := max(absf(fr), absf(fi))
+	g1 := max(absf(gr), absf(gi))
+	if f1 > m.rtmin && f1 < m.rtmax && g1 > m.rtmin && g1 < m.rtmax {
+		f2 := abssq(fr, fi)
+		h2 := f2 + abssq(gr, gi)
+		var d R
+		if f2 > m.rtmin && h2 < m.rtmax {
+			d = sqrtf(f2 * h2)
+		} else {
+			d = sqrtf(f2) * sqrtf(h2)
+		}
+		p := 1 / d
+		fpr, fpi := fr*p, fi*p
+		return f2 * p, gr*fpr + gi*fpi, gr*fpi - gi*fpr
+	}
+	u := min(m.safmax, max(m.safmin, f1, g1))
+	uu := 1 / u
+	gsr, gsi := gr*uu, gi*uu
+	g2 := abssq(gsr, gsi)
+	var w, fsr, fsi, f2, h2 R
+	if f1*uu < m.rtmin {
+		// f is not well-scaled when scaled by g1. Use a different
+		// scaling for f.
+		v := min(m.safmax, max(m.safmin, f1))
+		vv := 1 / v
+		w = v * uu
+		fsr, fsi = fr*vv, fi*vv
+		f2 = abssq(fsr, fsi)
+		h2 = f2*w*w + g2
+	} else {
+		w = 1
+		fsr, fsi = fr*uu, fi*uu
+		f2 = abssq(fsr, fsi)
+		h2 = f2 + g2
+	}
+	var d R
+	if f2 > m.rtmin && h2 < m.rtmax {
+		d = sqrtf(f2 * h2)
+	} else {
+		d = sqrtf(f2) * sqrtf(h2)
+	}
+	p := 1 / d
+	fpr, fpi := fsr*p, fsi*p
+	return (f2 * p) * w, gsr*fpr + gsi*fpi, gsr*fpi - gsi*fpr
+}
+
+// Rotmg constructs the modified Givens transformation that zeros the second
+// component of the vector (sqrt(d1)*x1, sqrt(d2)*y1)', as in the reference
+// drotmg, including the gam/gamsq rescaling of d1 and d2.
+func Rotmg[R Float](d1, d2, x1, y1 R) (rd1, rd2, rx1 R, flag, h11, h21, h12, h22 R) {
+	const gam = 4096
+	var gamsq, rgamsq R = 16777216, 5.9604645e-8
+	if _, ok := any(gamsq).(float32); ok {
+		// Constants of the reference srotmg.
+		gamsq, rgamsq = 1.67772e7, 5.96046e-8
+	}
+	if d1 < 0 {
+		return 0, 0, 0, -1, 0, 0, 0, 0
+	}
+	p2 := d2 * y1
+	if p2 == 0 {
+		return d1, d2, x1, -2, 0, 0, 0, 0
+	}
+	p1 := d1 * x1
+	q2 := p2 * y1
+	q1 := p1 * x1
+	if absf(q1) > absf(q2) {
+		h21 = -y1 / x1
+		h12 = p2 / p1
+		u := 1 - h12*h21
+		if u <= 0 {
+			// This path is here for safety; it is only reached through
+			// rounding errors. See doi:10.1145/355841.355847.
+			return 0, 0, 0, -1, 0, 0, 0, 0
+		}
+		flag = 0
+		d1 /= u
+		d2 /= u
+		x1 *= u
+	} else {
+		if q2 < 0 {
+			return 0, 0, 0, -1, 0, 0, 0, 0
+		}
+		flag = 1
+		h11 = p1 / p2
+		h22 = x1 / y1
+		u := 1 + h11*h22
+		d1, d2 = d2/u, d1/u
+		x1 = y1 * u
+	}
+	if d1 != 0 {
+		for d1 <= rgamsq || d1 >= gamsq {
+			if flag == 0 {
+				h11, h22 = 1, 1
+			} else if flag > 0 {
+				h21, h12 = -1, 1
+			}
+			flag = -1
+			if d1 <= rgamsq {
+				d1 *= gam * gam
+				x1 /= gam
+				h11 /= gam
+				h12 /= gam
+			} else {
+				d1 /= gam * gam
+				x1 *= gam
+				h11 *= gam
+				h12 *= gam
+			}
+		}
+	}
+	if d2 != 0 {
+		for absf(d2) <= rgamsq || absf(d2) >= gamsq {
+			if flag == 0 {
+				h11, h22 = 1, 1
+			} else if flag > 0 {
+				h21, h12 = -1, 1
+			}
+			flag = -1
+			if absf(d2) <= rgamsq {
+				d2 *= gam * gam
+				h21 /= gam
+				h22 /= gam
+			} else {
+				d2 /= gam * gam
+				h21 *= gam
+				h22 *= gam
+			}
+		}
+	}
+	switch {
+	case flag < 0:
+	case flag == 0:
+		h11, h22 = 0, 0
+	default:
+		h21, h12 = 0, 0
+	}
+	return d1, d2, x1, flag, h11, h21, h12, h22
+}
+
+// Rotm applies the modified Givens transformation H described by flag and
+// h11..h22 to the vectors x and y.
+func Rotm[R Float](n int, x []R, incX int, y []R, incY int, flag, h11, h21, h12, h22 R) {
+	if n <= 0 || flag == -2 {
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		w, z := x[ix], y[iy]
+		switch {
+		case flag < 0:
+			x[ix] = w*h11 + z*h12
+			y[iy] = w*h21 + z*h22
+		case flag == 0:
+			x[ix] = w + z*h12
+			y[iy] = w*h21 + z
+		default:
+			x[ix] = w*h11 + z
+			y[iy] = -w + h22*z
+		}
+		ix += incX
+		iy += incY
+	}
+}
@@ -0,0 +1,163 @@
+//go:build !noasm
+
+package gen
+
+// The assembly kernels below handle float32 and float64 vectors. The Unitary
+// kernels take vectors of equal length with unit increment, the Inc kernels
+// take n, the increments and the index of the first element visited; as in
+// the generic code a negative increment walks the vector backwards.
+
+func axpyUnitaryF64(alpha float64, x, y []float64)
+func axpyIncF64(alpha float64, x, y []float64, n, incX, incY, ix, iy int)
+func axpyUnitaryF32(alpha float32, x, y []float32)
+func axpyIncF32(alpha float32, x, y []float32, n, incX, incY, ix, iy int)
+
+func dotUnitaryF64(x, y []float64) (sum float64)
+func dotIncF64(x, y []float64, n, incX, incY, ix, iy int) (sum float64)
+func dotUnitaryF32(x, y []float32) (sum float32)
+func dotIncF32(x, y []float32, n, incX, incY, ix, iy int) (sum float32)
+
+func scalUnitaryF64(alpha float64, x []float64)
+func scalIncF64(alpha float64, x []float64, n, incX int)
+func scalUnitaryF32(alpha float32, x []float32)
+func scalIncF32(alpha float32, x []float32, n, incX int)
+
+func asumUnitaryF64(x []float64) (sum float64)
+func asumIncF64(x []float64, n, incX int) (sum float64)
+func asumUnitaryF32(x []float32) (sum float32)
+func asumIncF32(x []float32, n, incX int) (sum float32)
+
+// minAsmLen is the shortest vector handed to the assembly kernels. Below it
+// the call and reduction overhead outweighs the vector instructions.
+const minAsmLen = 16
+
+// checkInc panics unless the n elements of x visited from index ix with
+// increment inc lie within x, so that the Inc kernels stay in bounds.
+func checkInc[T Scalar](n int, x []T, ix, inc int) {
+	_ = x[ix]
+	_ = x[ix+(n-1)*inc]
+}
+
+// axpyAsm computes y = alpha*x + y with an assembly kernel and reports
+// whether it did. n must be positive.
+func axpyAsm[T Scalar](n int, alpha T, x []T, incX int, y []T, incY int) bool {
+	if !useAsm || n < minAsmLen {
+		return false
+	}
+	switch a := any(&alpha).(type) {
+	case *float64:
+		x, y := any(x).([]float64), any(y).([]float64)
+		if incX == 1 && incY == 1 {
+			axpyUnitaryF64(*a, x[:n], y[:n])
+			return true
+		}
+		ix, iy := start(n, incX), start(n, incY)
+		checkInc(n, x, ix, incX)
+		checkInc(n, y, iy, incY)
+		axpyIncF64(*a, x, y, n, incX, incY, ix, iy)
+		return true
+	case *float32:
+		x, y := any(x).([]float32), any(y).([]float32)
+		if incX == 1 && incY == 1 {
+			axpyUnitaryF32(*a, x[:n], y[:n])
+			return true
+		}
+		ix, iy := start(n, incX), start(n, incY)
+		checkInc(n, x, ix, incX)
+		checkInc(n, y, iy, incY)
+		axpyIncF32(*a, x, y, n, incX, incY, ix, iy)
+		return true
+	}
+	return false
+}
+
+// dotAsm computes x'*y into *sum with an assembly kernel and reports whether
+// it did. n must be positive.
+func dotAsm[T Scalar](n int, x []T, incX int, y []T, incY int, sum *T) bool {
+	if !useAsm || n < minAsmLen {
+		return false
+	}
+	switch s := any(sum).(type) {
+	case *float64:
+		x, y := any(x).([]float64), any(y).([]float64)
+		if incX == 1 && incY == 1 {
+			*s = dotUnitaryF64(x[:n], y[:n])
+			return true
+		}
+		ix, iy := start(n, incX), start(n, incY)
+		checkInc(n, x, ix, incX)
+		checkInc(n, y, iy, incY)
+		*s = dotIncF64(x, y, n, incX, incY, ix, iy)
+		return true
+	case *float32:
+		x, y := any(x).([]float32), any(y).([]float32)
+		if incX == 1 && incY == 1 {
+			*s = dotUnitaryF32(x[:n], y[:n])
+			return true
+		}
+		ix, iy := start(n, incX), start(n, incY)
+		checkInc(n, x, ix, incX)
+		checkInc(n, y, iy, incY)
+		*s = dotIncF32(x, y, n, incX, incY, ix, iy)
+		return true
+	}
+	return false
+}
+
+// scalAsm computes x = alpha*x with an assembly kernel and reports whether it
+// did. n and incX must be positive.
+func scalAsm[T Scalar](n int, alpha T, x []T, incX int) bool {
+	if !useAsm || n < minAsmLen {
+		return false
+	}
+	switch a := any(&alpha).(type) {
+	case *float64:
+		x := any(x).([]float64)
+		if incX == 1 {
+			scalUnitaryF64(*a, x[:n])
+			return true
+		}
+		checkInc(n, x, 0, incX)
+		scalIncF64(*a, x, n, incX)
+		return true
+	case *float32:
+		x := any(x).([]float32)
+		if incX == 1 {
+			scalUnitaryF32(*a, x[:n])
+			return true
+		}
+		checkInc(n, x, 0, incX)
+		scalIncF32(*a, x, n, incX)
+		return true
+	}
+	return false
+}
+
+// asumAsm computes the sum of |x[i]| into *sum with an assembly kernel and
+// reports whether it did. n and incX must be positive.
+func asumAsm[R Float](n int, x []R, incX int, sum *R) bool {
+	if !useAsm || n < minAsmLen {
+		return false
+	}
+	switch s := any(sum).(type) {
+	case *float64:
+		x := any(x).([]float64)
+		if incX == 1 {
+			*s = asumUnitaryF64(x[:n])
+			return true
+		}
+		checkInc(n, x, 0, incX)
+		*s = asumIncF64(x, n, incX)
+		return true
+	case *float32:
+		x := any(x).([]float32)
+		if incX == 1 {
+			*s = asumUnitaryF32(x[:n])
+			return true
+		}
+		checkInc(n, x, 0, incX)
+		*s = asumIncF32(x, n, incX)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,753 @@
+//go:build !noasm
+
+#include "textflag.h"
+
+// Level 1 kernels for float64 (F64) and float32 (F32) vectors using AVX2 and
+// FMA. The unit-stride kernels process four YMM registers per iteration and
+// finish with scalar instructions; the strided kernels are unrolled scalar
+// loops. Sums are accumulated in several registers, so DOT and ASUM may
+// round differently from a sequential loop.
+
+DATA absmaskF64<>+0(SB)/8, $0x7fffffffffffffff
+GLOBL absmaskF64<>(SB), RODATA|NOPTR, $8
+
+DATA absmaskF32<>+0(SB)/4, $0x7fffffff
+GLOBL absmaskF32<>(SB), RODATA|NOPTR, $4
+
+// func axpyUnitaryF64(alpha float64, x, y []float64)
+TEXT ·axpyUnitaryF64(SB), NOSPLIT, $0-56
+	MOVQ x_base+8(FP), SI
+	MOVQ y_base+32(FP), DI
+	MOVQ x_len+16(FP), CX
+	VBROADCASTSD alpha+0(FP), Y0
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-16, BX
+	JZ   axpyF64_tail
+
+axpyF64_loop:
+	VMOVUPD (SI)(AX*8), Y1
+	VMOVUPD 32(SI)(AX*8), Y2
+	VMOVUPD 64(SI)(AX*8), Y3
+	VMOVUPD 96(SI)(AX*8), Y4
+	VFMADD213PD (DI)(AX*8), Y0, Y1
+	VFMADD213PD 32(DI)(AX*8), Y0, Y2
+	VFMADD213PD 64(DI)(AX*8), Y0, Y3
+	VFMADD213PD 96(DI)(AX*8), Y0, Y4
+	VMOVUPD Y1, (DI)(AX*8)
+	VMOVUPD Y2, 32(DI)(AX*8)
+	VMOVUPD Y3, 64(DI)(AX*8)
+	VMOVUPD Y4, 96(DI)(AX*8)
+	ADDQ $16, AX
+	CMPQ AX, BX
+	JB   axpyF64_loop
+
+axpyF64_tail:
+	CMPQ AX, CX
+	JAE  axpyF64_done
+
+axpyF64_loop1:
+	VMOVSD (SI)(AX*8), X1
+	VFMADD213SD (DI)(AX*8), X0, X1
+	VMOVSD X1, (DI)(AX*8)
+	INCQ AX
+	CMPQ AX, CX
+	JB   axpyF64_loop1
+
+axpyF64_done:
+	VZEROUPPER
+	RET
+
+// func axpyIncF64(alpha float64, x, y []float64, n, incX, incY, ix, iy int)
+TEXT ·axpyIncF64(SB), NOSPLIT, $0-96
+	MOVQ x_base+8(FP), SI
+	MOVQ y_base+32(FP), DI
+	MOVQ n+56(FP), CX
+	MOVQ incX+64(FP), R8
+	MOVQ incY+72(FP), R9
+	MOVQ ix+80(FP), AX
+	MOVQ iy+88(FP), BX
+	LEAQ (SI)(AX*8), SI
+	LEAQ (DI)(BX*8), DI
+	SHLQ $3, R8
+	SHLQ $3, R9
+	VMOVSD alpha+0(FP), X0
+	MOVQ CX, DX
+	SHRQ $2, DX
+	JZ   axpyincF64_tail
+
+axpyincF64_loop:
+	VMOVSD (SI), X1
+	VFMADD213SD (DI), X0, X1
+	VMOVSD X1, (DI)
+	VMOVSD (SI)(R8*1), X2
+	VFMADD213SD (DI)(R9*1), X0, X2
+	VMOVSD X2, (DI)(R9*1)
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	VMOVSD (SI), X3
+	VFMADD213SD (DI), X0, X3
+	VMOVSD X3, (DI)
+	VMOVSD (SI)(R8*1), X4
+	VFMADD213SD (DI)(R9*1), X0, X4
+	VMOVSD X4, (DI)(R9*1)
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	DECQ DX
+	JNZ  axpyincF64_loop
+
+axpyincF64_tail:
+	ANDQ $3, CX
+	JZ   axpyincF64_done
+
+axpyincF64_loop1:
+	VMOVSD (SI), X1
+	VFMADD213SD (DI), X0, X1
+	VMOVSD X1, (DI)
+	ADDQ R8, SI
+	ADDQ R9, DI
+	DECQ CX
+	JNZ  axpyincF64_loop1
+
+axpyincF64_done:
+	RET
+
+// func dotUnitaryF64(x, y []float64) (sum float64)
+TEXT ·dotUnitaryF64(SB), NOSPLIT, $0-56
+	MOVQ x_base+0(FP), SI
+	MOVQ y_base+24(FP), DI
+	MOVQ x_len+8(FP), CX
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-16, BX
+	JZ   dotF64_reduce
+
+dotF64_loop:
+	VMOVUPD (SI)(AX*8), Y5
+	VMOVUPD 32(SI)(AX*8), Y6
+	VMOVUPD 64(SI)(AX*8), Y7
+	VMOVUPD 96(SI)(AX*8), Y8
+	VFMADD231PD (DI)(AX*8), Y5, Y1
+	VFMADD231PD 32(DI)(AX*8), Y6, Y2
+	VFMADD231PD 64(DI)(AX*8), Y7, Y3
+	VFMADD231PD 96(DI)(AX*8), Y8, Y4
+	ADDQ $16, AX
+	CMPQ AX, BX
+	JB   dotF64_loop
+
+dotF64_reduce:
+	VADDPD Y2, Y1, Y1
+	VADDPD Y4, Y3, Y3
+	VADDPD Y3, Y1, Y1
+	VEXTRACTF128 $1, Y1, X2
+	VADDPD X2, X1, X1
+	VHADDPD X1, X1, X1
+
+	CMPQ AX, CX
+	JAE  dotF64_done
+
+dotF64_loop1:
+	VMOVSD (SI)(AX*8), X5
+	VFMADD231SD (DI)(AX*8), X5, X1
+	INCQ AX
+	CMPQ AX, CX
+	JB   dotF64_loop1
+
+dotF64_done:
+	VMOVSD X1, sum+48(FP)
+	VZEROUPPER
+	RET
+
+// func dotIncF64(x, y []float64, n, incX, incY, ix, iy int) (sum float64)
+TEXT ·dotIncF64(SB), NOSPLIT, $0-96
+	MOVQ x_base+0(FP), SI
+	MOVQ y_base+24(FP), DI
+	MOVQ n+48(FP), CX
+	MOVQ incX+56(FP), R8
+	MOVQ incY+64(FP), R9
+	MOVQ ix+72(FP), AX
+	MOVQ iy+80(FP), BX
+	LEAQ (SI)(AX*8), SI
+	LEAQ (DI)(BX*8), DI
+	SHLQ $3, R8
+	SHLQ $3, R9
+	VXORPD X1, X1, X1
+	VXORPD X2, X2, X2
+	VXORPD X3, X3, X3
+	VXORPD X4, X4, X4
+	MOVQ CX, DX
+	SHRQ $2, DX
+	JZ   dotincF64_tail
+
+dotincF64_loop:
+	VMOVSD (SI), X5
+	VFMADD231SD (DI), X5, X1
+	VMOVSD (SI)(R8*1), X6
+	VFMADD231SD (DI)(R9*1), X6, X2
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	VMOVSD (SI), X7
+	VFMADD231SD (DI), X7, X3
+	VMOVSD (SI)(R8*1), X8
+	VFMADD231SD (DI)(R9*1), X8, X4
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	DECQ DX
+	JNZ  dotincF64_loop
+
+dotincF64_tail:
+	ANDQ $3, CX
+	JZ   dotincF64_done
+
+dotincF64_loop1:
+	VMOVSD (SI), X5
+	VFMADD231SD (DI), X5, X1
+	ADDQ R8, SI
+	ADDQ R9, DI
+	DECQ CX
+	JNZ  dotincF64_loop1
+
+dotincF64_done:
+	VADDSD X2, X1, X1
+	VADDSD X4, X3, X3
+	VADDSD X3, X1, X1
+	VMOVSD X1, sum+88(FP)
+	RET
+
+// func scalUnitaryF64(alpha float64, x []float64)
+TEXT ·scalUnitaryF64(SB), NOSPLIT, $0-32
+	MOVQ x_base+8(FP), SI
+	MOVQ x_len+16(FP), CX
+	VBROADCASTSD alpha+0(FP), Y0
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-16, BX
+	JZ   scalF64_tail
+
+scalF64_loop:
+	VMULPD (SI)(AX*8), Y0, Y1
+	VMULPD 32(SI)(AX*8), Y0, Y2
+	VMULPD 64(SI)(AX*8), Y0, Y3
+	VMULPD 96(SI)(AX*8), Y0, Y4
+	VMOVUPD Y1, (SI)(AX*8)
+	VMOVUPD Y2, 32(SI)(AX*8)
+	VMOVUPD Y3, 64(SI)(AX*8)
+	VMOVUPD Y4, 96(SI)(AX*8)
+	ADDQ $16, AX
+	CMPQ AX, BX
+	JB   scalF64_loop
+
+scalF64_tail:
+	CMPQ AX, CX
+	JAE  scalF64_done
+
+scalF64_loop1:
+	VMULSD (SI)(AX*8), X0, X1
+	VMOVSD X1, (SI)(AX*8)
+	INCQ AX
+	CMPQ AX, CX
+	JB   scalF64_loop1
+
+scalF64_done:
+	VZEROUPPER
+	RET
+
+// func scalIncF64(alpha float64, x []float64, n, incX int)
+TEXT ·scalIncF64(SB), NOSPLIT, $0-48
+	MOVQ x_base+8(FP), SI
+	MOVQ n+32(FP), CX
+	MOVQ incX+40(FP), R8
+	SHLQ $3, R8
+	VMOVSD alpha+0(FP), X0
+	MOVQ CX, DX
+	SHRQ $2, DX
+	JZ   scalincF64_tail
+
+scalincF64_loop:
+	VMULSD (SI), X0, X1
+	VMOVSD X1, (SI)
+	VMULSD (SI)(R8*1), X0, X2
+	VMOVSD X2, (SI)(R8*1)
+	LEAQ (SI)(R8*2), SI
+	VMULSD (SI), X0, X3
+	VMOVSD X3, (SI)
+	VMULSD (SI)(R8*1), X0, X4
+	VMOVSD X4, (SI)(R8*1)
+	LEAQ (SI)(R8*2), SI
+	DECQ DX
+	JNZ  scalincF64_loop
+
+scalincF64_tail:
+	ANDQ $3, CX
+	JZ   scalincF64_done
+
+scalincF64_loop1:
+	VMULSD (SI), X0, X1
+	VMOVSD X1, (SI)
+	ADDQ R8, SI
+	DECQ CX
+	JNZ  scalincF64_loop1
+
+scalincF64_done:
+	RET
+
+// func asumUnitaryF64(x []float64) (sum float64)
+TEXT ·asumUnitaryF64(SB), NOSPLIT, $0-32
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	VBROADCASTSD absmaskF64<>(SB), Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-16, BX
+	JZ   asumF64_reduce
+
+asumF64_loop:
+	VANDPD (SI)(AX*8), Y0, Y5
+	VANDPD 32(SI)(AX*8), Y0, Y6
+	VANDPD 64(SI)(AX*8), Y0, Y7
+	VANDPD 96(SI)(AX*8), Y0, Y8
+	VADDPD Y5, Y1, Y1
+	VADDPD Y6, Y2, Y2
+	VADDPD Y7, Y3, Y3
+	VADDPD Y8, Y4, Y4
+	ADDQ $16, AX
+	CMPQ AX, BX
+	JB   asumF64_loop
+
+asumF64_reduce:
+	VADDPD Y2, Y1, Y1
+	VADDPD Y4, Y3, Y3
+	VADDPD Y3, Y1, Y1
+	VEXTRACTF128 $1, Y1, X2
+	VADDPD X2, X1, X1
+	VHADDPD X1, X1, X1
+
+	CMPQ AX, CX
+	JAE  asumF64_done
+
+asumF64_loop1:
+	VMOVSD (SI)(AX*8), X5
+	VANDPD X0, X5, X5
+	VADDSD X5, X1, X1
+	INCQ AX
+	CMPQ AX, CX
+	JB   asumF64_loop1
+
+asumF64_done:
+	VMOVSD X1, sum+24(FP)
+	VZEROUPPER
+	RET
+
+// func asumIncF64(x []float64, n, incX int) (sum float64)
+TEXT ·asumIncF64(SB), NOSPLIT, $0-48
+	MOVQ x_base+0(FP), SI
+	MOVQ n+24(FP), CX
+	MOVQ incX+32(FP), R8
+	SHLQ $3, R8
+	VMOVSD absmaskF64<>(SB), X0
+	VXORPD X1, X1, X1
+	VXORPD X2, X2, X2
+	MOVQ CX, DX
+	SHRQ $1, DX
+	JZ   asumincF64_tail
+
+asumincF64_loop:
+	VMOVSD (SI), X5
+	VANDPD X0, X5, X5
+	VADDSD X5, X1, X1
+	VMOVSD (SI)(R8*1), X6
+	VANDPD X0, X6, X6
+	VADDSD X6, X2, X2
+	LEAQ (SI)(R8*2), SI
+	DECQ DX
+	JNZ  asumincF64_loop
+
+asumincF64_tail:
+	ANDQ $1, CX
+	JZ   asumincF64_done
+	VMOVSD (SI), X5
+	VANDPD X0, X5, X5
+	VADDSD X5, X1, X1
+
+asumincF64_done:
+	VADDSD X2, X1, X1
+	VMOVSD X1, sum+40(FP)
+	RET
+
+// func axpyUnitaryF32(alpha float32, x, y []float32)
+TEXT ·axpyUnitaryF32(SB), NOSPLIT, $0-56
+	MOVQ x_base+8(FP), SI
+	MOVQ y_base+32(FP), DI
+	MOVQ x_len+16(FP), CX
+	VBROADCASTSS alpha+0(FP), Y0
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-32, BX
+	JZ   axpyF32_tail
+
+axpyF32_loop:
+	VMOVUPS (SI)(AX*4), Y1
+	VMOVUPS 32(SI)(AX*4), Y2
+	VMOVUPS 64(SI)(AX*4), Y3
+	VMOVUPS 96(SI)(AX*4), Y4
+	VFMADD213PS (DI)(AX*4), Y0, Y1
+	VFMADD213PS 32(DI)(AX*4), Y0, Y2
+	VFMADD213PS 64(DI)(AX*4), Y0, Y3
+	VFMADD213PS 96(DI)(AX*4), Y0, Y4
+	VMOVUPS Y1, (DI)(AX*4)
+	VMOVUPS Y2, 32(DI)(AX*4)
+	VMOVUPS Y3, 64(DI)(AX*4)
+	VMOVUPS Y4, 96(DI)(AX*4)
+	ADDQ $32, AX
+	CMPQ AX, BX
+	JB   axpyF32_loop
+
+axpyF32_tail:
+	CMPQ AX, CX
+	JAE  axpyF32_done
+
+axpyF32_loop1:
+	VMOVSS (SI)(AX*4), X1
+	VFMADD213SS (DI)(AX*4), X0, X1
+	VMOVSS X1, (DI)(AX*4)
+	INCQ AX
+	CMPQ AX, CX
+	JB   axpyF32_loop1
+
+axpyF32_done:
+	VZEROUPPER
+	RET
+
+// func axpyIncF32(alpha float32, x, y []float32, n, incX, incY, ix, iy int)
+TEXT ·axpyIncF32(SB), NOSPLIT, $0-96
+	MOVQ x_base+8(FP), SI
+	MOVQ y_base+32(FP), DI
+	MOVQ n+56(FP), CX
+	MOVQ incX+64(FP), R8
+	MOVQ incY+72(FP), R9
+	MOVQ ix+80(FP), AX
+	MOVQ iy+88(FP), BX
+	LEAQ (SI)(AX*4), SI
+	LEAQ (DI)(BX*4), DI
+	SHLQ $2, R8
+	SHLQ $2, R9
+	VMOVSS alpha+0(FP), X0
+	MOVQ CX, DX
+	SHRQ $2, DX
+	JZ   axpyincF32_tail
+
+axpyincF32_loop:
+	VMOVSS (SI), X1
+	VFMADD213SS (DI), X0, X1
+	VMOVSS X1, (DI)
+	VMOVSS (SI)(R8*1), X2
+	VFMADD213SS (DI)(R9*1), X0, X2
+	VMOVSS X2, (DI)(R9*1)
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	VMOVSS (SI), X3
+	VFMADD213SS (DI), X0, X3
+	VMOVSS X3, (DI)
+	VMOVSS (SI)(R8*1), X4
+	VFMADD213SS (DI)(R9*1), X0, X4
+	VMOVSS X4, (DI)(R9*1)
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	DECQ DX
+	JNZ  axpyincF32_loop
+
+axpyincF32_tail:
+	ANDQ $3, CX
+	JZ   axpyincF32_done
+
+axpyincF32_loop1:
+	VMOVSS (SI), X1
+	VFMADD213SS (DI), X0, X1
+	VMOVSS X1, (DI)
+	ADDQ R8, SI
+	ADDQ R9, DI
+	DECQ CX
+	JNZ  axpyincF32_loop1
+
+axpyincF32_done:
+	RET
+
+// func dotUnitaryF32(x, y []float32) (sum float32)
+TEXT ·dotUnitaryF32(SB), NOSPLIT, $0-52
+	MOVQ x_base+0(FP), SI
+	MOVQ y_base+24(FP), DI
+	MOVQ x_len+8(FP), CX
+	VXORPS Y1, Y1, Y1
+	VXORPS Y2, Y2, Y2
+	VXORPS Y3, Y3, Y3
+	VXORPS Y4, Y4, Y4
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-32, BX
+	JZ   dotF32_reduce
+
+dotF32_loop:
+	VMOVUPS (SI)(AX*4), Y5
+	VMOVUPS 32(SI)(AX*4), Y6
+	VMOVUPS 64(SI)(AX*4), Y7
+	VMOVUPS 96(SI)(AX*4), Y8
+	VFMADD231PS (DI)(AX*4), Y5, Y1
+	VFMADD231PS 32(DI)(AX*4), Y6, Y2
+	VFMADD231PS 64(DI)(AX*4), Y7, Y3
+	VFMADD231PS 96(DI)(AX*4), Y8, Y4
+	ADDQ $32, AX
+	CMPQ AX, BX
+	JB   dotF32_loop
+
+dotF32_reduce:
+	VADDPS Y2, Y1, Y1
+	VADDPS Y4, Y3, Y3
+	VADDPS Y3, Y1, Y1
+	VEXTRACTF128 $1, Y1, X2
+	VADDPS X2, X1, X1
+	VHADDPS X1, X1, X1
+	VHADDPS X1, X1, X1
+
+	CMPQ AX, CX
+	JAE  dotF32_done
+
+dotF32_loop1:
+	VMOVSS (SI)(AX*4), X5
+	VFMADD231SS (DI)(AX*4), X5, X1
+	INCQ AX
+	CMPQ AX, CX
+	JB   dotF32_loop1
+
+dotF32_done:
+	VMOVSS X1, sum+48(FP)
+	VZEROUPPER
+	RET
+
+// func dotIncF32(x, y []float32, n, incX, incY, ix, iy int) (sum float32)
+TEXT ·dotIncF32(SB), NOSPLIT, $0-92
+	MOVQ x_base+0(FP), SI
+	MOVQ y_base+24(FP), DI
+	MOVQ n+48(FP), CX
+	MOVQ incX+56(FP), R8
+	MOVQ incY+64(FP), R9
+	MOVQ ix+72(FP), AX
+	MOVQ iy+80(FP), BX
+	LEAQ (SI)(AX*4), SI
+	LEAQ (DI)(BX*4), DI
+	SHLQ $2, R8
+	SHLQ $2, R9
+	VXORPS X1, X1, X1
+	VXORPS X2, X2, X2
+	VXORPS X3, X3, X3
+	VXORPS X4, X4, X4
+	MOVQ CX, DX
+	SHRQ $2, DX
+	JZ   dotincF32_tail
+
+dotincF32_loop:
+	VMOVSS (SI), X5
+	VFMADD231SS (DI), X5, X1
+	VMOVSS (SI)(R8*1), X6
+	VFMADD231SS (DI)(R9*1), X6, X2
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	VMOVSS (SI), X7
+	VFMADD231SS (DI), X7, X3
+	VMOVSS (SI)(R8*1), X8
+	VFMADD231SS (DI)(R9*1), X8, X4
+	LEAQ (SI)(R8*2), SI
+	LEAQ (DI)(R9*2), DI
+	DECQ DX
+	JNZ  dotincF32_loop
+
+dotincF32_tail:
+	ANDQ $3, CX
+	JZ   dotincF32_done
+
+dotincF32_loop1:
+	VMOVSS (SI), X5
+	VFMADD231SS (DI), X5, X1
+	ADDQ R8, SI
+	ADDQ R9, DI
+	DECQ CX
+	JNZ  dotincF32_loop1
+
+dotincF32_done:
+	VADDSS X2, X1, X1
+	VADDSS X4, X3, X3
+	VADDSS X3, X1, X1
+	VMOVSS X1, sum+88(FP)
+	RET
+
+// func scalUnitaryF32(alpha float32, x []float32)
+TEXT ·scalUnitaryF32(SB), NOSPLIT, $0-32
+	MOVQ x_base+8(FP), SI
+	MOVQ x_len+16(FP), CX
+	VBROADCASTSS alpha+0(FP), Y0
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-32, BX
+	JZ   scalF32_tail
+
+scalF32_loop:
+	VMULPS (SI)(AX*4), Y0, Y1
+	VMULPS 32(SI)(AX*4), Y0, Y2
+	VMULPS 64(SI)(AX*4), Y0, Y3
+	VMULPS 96(SI)(AX*4), Y0, Y4
+	VMOVUPS Y1, (SI)(AX*4)
+	VMOVUPS Y2, 32(SI)(AX*4)
+	VMOVUPS Y3, 64(SI)(AX*4)
+	VMOVUPS Y4, 96(SI)(AX*4)
+	ADDQ $32, AX
+	CMPQ AX, BX
+	JB   scalF32_loop
+
+scalF32_tail:
+	CMPQ AX, CX
+	JAE  scalF32_done
+
+scalF32_loop1:
+	VMULSS (SI)(AX*4), X0, X1
+	VMOVSS X1, (SI)(AX*4)
+	INCQ AX
+	CMPQ AX, CX
+	JB   scalF32_loop1
+
+scalF32_done:
+	VZEROUPPER
+	RET
+
+// func scalIncF32(alpha float32, x []float32, n, incX int)
+TEXT ·scalIncF32(SB), NOSPLIT, $0-48
+	MOVQ x_base+8(FP), SI
+	MOVQ n+32(FP), CX
+	MOVQ incX+40(FP), R8
+	SHLQ $2, R8
+	VMOVSS alpha+0(FP), X0
+	MOVQ CX, DX
+	SHRQ $2, DX
+	JZ   scalincF32_tail
+
+scalincF32_loop:
+	VMULSS (SI), X0, X1
+	VMOVSS X1, (SI)
+	VMULSS (SI)(R8*1), X0, X2
+	VMOVSS X2, (SI)(R8*1)
+	LEAQ (SI)(R8*2), SI
+	VMULSS (SI), X0, X3
+	VMOVSS X3, (SI)
+	VMULSS (SI)(R8*1), X0, X4
+	VMOVSS X4, (SI)(R8*1)
+	LEAQ (SI)(R8*2), SI
+	DECQ DX
+	JNZ  scalincF32_loop
+
+scalincF32_tail:
+	ANDQ $3, CX
+	JZ   scalincF32_done
+
+scalincF32_loop1:
+	VMULSS (SI), X0, X1
+	VMOVSS X1, (SI)
+	ADDQ R8, SI
+	DECQ CX
+	JNZ  scalincF32_loop1
+
+scalincF32_done:
+	RET
+
+// func asumUnitaryF32(x []float32) (sum float32)
+TEXT ·asumUnitaryF32(SB), NOSPLIT, $0-28
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	VBROADCASTSS absmaskF32<>(SB), Y0
+	VXORPS Y1, Y1, Y1
+	VXORPS Y2, Y2, Y2
+	VXORPS Y3, Y3, Y3
+	VXORPS Y4, Y4, Y4
+	XORQ AX, AX
+	MOVQ CX, BX
+	ANDQ $-32, BX
+	JZ   asumF32_reduce
+
+asumF32_loop:
+	VANDPS (SI)(AX*4), Y0, Y5
+	VANDPS 32(SI)(AX*4), Y0, Y6
+	VANDPS 64(SI)(AX*4), Y0, Y7
+	VANDPS 96(SI)(AX*4), Y0, Y8
+	VADDPS Y5, Y1, Y1
+	VADDPS Y6, Y2, Y2
+	VADDPS Y7, Y3, Y3
+	VADDPS Y8, Y4, Y4
+	ADDQ $32, AX
+	CMPQ AX, BX
+	JB   asumF32_loop
+
+asumF32_reduce:
+	VADDPS Y2, Y1, Y1
+	VADDPS Y4, Y3, Y3
+	VADDPS Y3, Y1, Y1
+	VEXTRACTF128 $1, Y1, X2
+	VADDPS X2, X1, X1
+	VHADDPS X1, X1, X1
+	VHADDPS X1, X1, X1
+
+	CMPQ AX, CX
+	JAE  asumF32_done
+
+asumF32_loop1:
+	VMOVSS (SI)(AX*4), X5
+	VANDPS X0, X5, X5
+	VADDSS X5, X1, X1
+	INCQ AX
+	CMPQ AX, CX
+	JB   asumF32_loop1
+
+asumF32_done:
+	VMOVSS X1, sum+24(FP)
+	VZEROUPPER
+	RET
+
+// func asumIncF32(x []float32, n, incX int) (sum float32)
+TEXT ·asumIncF32(SB), NOSPLIT, $0-44
+	MOVQ x_base+0(FP), SI
+	MOVQ n+24(FP), CX
+	MOVQ incX+32(FP), R8
+	SHLQ $2, R8
+	VMOVSS absmaskF32<>(SB), X0
+	VXORPS X1, X1, X1
+	VXORPS X2, X2, X2
+	MOVQ CX, DX
+	SHRQ $1, DX
+	JZ   asumincF32_tail
+
+asumincF32_loop:
+	VMOVSS (SI), X5
+	VANDPS X0, X5, X5
+	VADDSS X5, X1, X1
+	VMOVSS (SI)(R8*1), X6
+	VANDPS X0, X6, X6
+	VADDSS X6, X2, X2
+	LEAQ (SI)(R8*2), SI
+	DECQ DX
+	JNZ  asumincF32_loop
+
+asumincF32_tail:
+	ANDQ $1, CX
+	JZ   asumincF32_done
+	VMOVSS (SI), X5
+	VANDPS X0, X5, X5
+	VADDSS X5, X1, X1
+
+asumincF32_done:
+	VADDSS X2, X1, X1
+	VMOVSS X1, sum+40(FP)
+	RET
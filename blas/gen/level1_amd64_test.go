@@ -0,0 +1,175 @@
+//go:build !noasm
+
+package gen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// setAsm enables or disables the assembly kernels and reports whether they
+// are in use. They cannot be enabled without AVX2 and FMA.
+func setAsm(on bool) bool {
+	useAsm = on && hasAVX2FMA()
+	return useAsm
+}
+
+// asmKernels holds the assembly kernels of one precision.
+type asmKernels[T float32 | float64] struct {
+	axpyUnitary func(alpha T, x, y []T)
+	axpyInc     func(alpha T, x, y []T, n, incX, incY, ix, iy int)
+	dotUnitary  func(x, y []T) T
+	dotInc      func(x, y []T, n, incX, incY, ix, iy int) T
+	scalUnitary func(alpha T, x []T)
+	scalInc     func(alpha T, x []T, n, incX int)
+	asumUnitary func(x []T) T
+	asumInc     func(x []T, n, incX int) T
+}
+
+// TestAsmLevel1 compares the assembly kernels, called directly so that
+// vectors shorter than minAsmLen reach them too, and the routines that
+// dispatch to them with the Go loops.
+func TestAsmLevel1(t *testing.T) {
+	if !setAsm(true) {
+		t.Skip("no AVX2 and FMA")
+	}
+	testAsmLevel1(t, asmKernels[float32]{
+		axpyUnitaryF32, axpyIncF32, dotUnitaryF32, dotIncF32,
+		scalUnitaryF32, scalIncF32, asumUnitaryF32, asumIncF32,
+	})
+	testAsmLevel1(t, asmKernels[float64]{
+		axpyUnitaryF64, axpyIncF64, dotUnitaryF64, dotIncF64,
+		scalUnitaryF64, scalIncF64, asumUnitaryF64, asumIncF64,
+	})
+}
+
+func testAsmLevel1[T float32 | float64](t *testing.T, k asmKernels[T]) {
+	defer setAsm(true)
+	rnd := rand.New(rand.NewSource(1))
+	eps := math.Nextafter(1, 2) - 1
+	if _, ok := any(T(0)).(float32); ok {
+		eps = float64(math.Nextafter32(1, 2) - 1)
+	}
+	name := fmt.Sprintf("%T", T(0))
+	vec := func(n int) []T {
+		x := make([]T, n)
+		for i := range x {
+			x[i] = T(rnd.NormFloat64())
+		}
+		return x
+	}
+	// same reports whether got and want agree to within a few roundings
+	// of a sum of n terms of magnitude scale.
+	same := func(got, want []T, n int, scale float64) bool {
+		for i := range got {
+			if math.Abs(float64(got[i]-want[i])) > 4*float64(n+1)*eps*scale {
+				return false
+			}
+		}
+		return true
+	}
+	asum := func(x []T) float64 {
+		var s float64
+		for _, v := range x {
+			s += math.Abs(float64(v))
+		}
+		return s
+	}
+
+	// Every length up to 100 covers minAsmLen and several blocks of the
+	// unit-stride loops; the longer ones straddle multiples of the block.
+	var lengths []int
+	for n := 0; n <= 100; n++ {
+		lengths = append(lengths, n)
+	}
+	lengths = append(lengths, 127, 128, 129, 255, 256, 257)
+	const alpha = 0.75
+	for _, n := range lengths {
+		for _, inc := range [][2]int{{1, 1}, {-1, -1}, {1, -1}, {-1, 1}, {3, 3}, {-3, -3}, {3, -1}, {-1, 3}, {-3, 1}, {1, -3}} {
+			incX, incY := inc[0], inc[1]
+			x, y := vec(1+max(n-1, 0)*max(incX, -incX)), vec(1+max(n-1, 0)*max(incY, -incY))
+			ix, iy := start(n, incX), start(n, incY)
+			unit := incX == 1 && incY == 1
+			tag := fmt.Sprintf("%s n=%d incX=%d incY=%d", name, n, incX, incY)
+
+			setAsm(false)
+			want := append([]T(nil), y...)
+			Axpy(n, alpha, x, incX, want, incY)
+			wantDot := Dotu(n, x, incX, y, incY)
+			scale := asum(x) + asum(y)
+
+			if unit || n > 0 {
+				got := append([]T(nil), y...)
+				if unit {
+					k.axpyUnitary(alpha, x[:n], got[:n])
+				} else {
+					k.axpyInc(alpha, x, got, n, incX, incY, ix, iy)
+				}
+				if !same(got, want, 1, scale) {
+					t.Errorf("axpy kernel %s: got %v, want %v", tag, got, want)
+				}
+				var dot T
+				if unit {
+					dot = k.dotUnitary(x[:n], y[:n])
+				} else {
+					dot = k.dotInc(x, y, n, incX, incY, ix, iy)
+				}
+				if !same([]T{dot}, []T{wantDot}, n, scale*scale) {
+					t.Errorf("dot kernel %s: got %v, want %v", tag, dot, wantDot)
+				}
+			}
+
+			setAsm(true)
+			got := append([]T(nil), y...)
+			Axpy(n, alpha, x, incX, got, incY)
+			if !same(got, want, 1, scale) {
+				t.Errorf("Axpy %s: got %v, want %v", tag, got, want)
+			}
+			if dot := Dotu(n, x, incX, y, incY); !same([]T{dot}, []T{wantDot}, n, scale*scale) {
+				t.Errorf("Dotu %s: got %v, want %v", tag, dot, wantDot)
+			}
+
+			// SCAL and ASUM take a positive increment only.
+			if incX < 0 || incX != incY {
+				continue
+			}
+			setAsm(false)
+			wantScal := append([]T(nil), x...)
+			Scal(n, alpha, wantScal, incX)
+			wantAsum := Asum(n, x, incX)
+
+			if n > 0 {
+				got := append([]T(nil), x...)
+				if unit {
+					k.scalUnitary(alpha, got[:n])
+				} else {
+					k.scalInc(alpha, got, n, incX)
+				}
+				if !same(got, wantScal, 0, 0) {
+					t.Errorf("scal kernel %s: got %v, want %v", tag, got, wantScal)
+				}
+				var s T
+				if unit {
+					s = k.asumUnitary(x[:n])
+				} else {
+					s = k.asumInc(x, n, incX)
+				}
+				if !same([]T{s}, []T{wantAsum}, n, asum(x)) {
+					t.Errorf("asum kernel %s: got %v, want %v", tag, s, wantAsum)
+				}
+			}
+
+			setAsm(true)
+			got = append([]T(nil), x...)
+			Scal(n, alpha, got, incX)
+			if !same(got, wantScal, 0, 0) {
+				t.Errorf("Scal %s: got %v, want %v", tag, got, wantScal)
+			}
+			if s := Asum(n, x, incX); !same([]T{s}, []T{wantAsum}, n, asum(x)) {
+				t.Errorf("Asum %s: got %v, want %v", tag, s, wantAsum)
+			}
+		}
+	}
+}
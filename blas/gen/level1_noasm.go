@@ -0,0 +1,21 @@
+//go:build !amd64 || noasm
+
+package gen
+
+// Without assembly kernels every routine runs its generic Go loop.
+
+func axpyAsm[T Scalar](n int, alpha T, x []T, incX int, y []T, incY int) bool {
+	return false
+}
+
+func dotAsm[T Scalar](n int, x []T, incX int, y []T, incY int, sum *T) bool {
+	return false
+}
+
+func scalAsm[T Scalar](n int, alpha T, x []T, incX int) bool {
+	return false
+}
+
+func asumAsm[R Float](n int, x []R, incX int, sum *R) bool {
+	return false
+}
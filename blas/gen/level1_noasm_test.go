@@ -0,0 +1,8 @@
+//go:build !amd64 || noasm
+
+package gen
+
+// setAsm reports that no assembly kernels are in use.
+func setAsm(on bool) bool {
+	return false
+}
@@ -0,0 +1,75 @@
+package gen
+
+import (
+	"strconv"
+	"testing"
+)
+
+// The benchmarks below time the real Axpy, Dotu, Scal and Asum over a range
+// of lengths with the assembly kernels, under "asm/", and with the Go loops,
+// under "go/". On other architectures, without AVX2 and FMA or when built
+// with -tags noasm, only the Go loops are timed.
+
+var level1Sizes = []int{1, 10, 100, 1000, 10000, 100000, 1000000}
+
+// benchLevel1 runs f for float32 and float64 vectors of every length of
+// level1Sizes, with and without the assembly kernels. f reads vecs of the
+// vectors x and y.
+func benchLevel1(b *testing.B, vecs int, f32 func(n int, x, y []float32), f64 func(n int, x, y []float64)) {
+	defer setAsm(true)
+	for _, kernel := range []string{"asm", "go"} {
+		if !setAsm(kernel == "asm") && kernel == "asm" {
+			continue
+		}
+		for _, n := range level1Sizes {
+			name := "/n=" + strconv.Itoa(n)
+			x32, y32 := benchVec[float32](n), benchVec[float32](n)
+			b.Run(kernel+"/float32"+name, func(b *testing.B) {
+				b.SetBytes(int64(4 * vecs * n))
+				for i := 0; i < b.N; i++ {
+					f32(n, x32, y32)
+				}
+			})
+			x64, y64 := benchVec[float64](n), benchVec[float64](n)
+			b.Run(kernel+"/float64"+name, func(b *testing.B) {
+				b.SetBytes(int64(8 * vecs * n))
+				for i := 0; i < b.N; i++ {
+					f64(n, x64, y64)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkLevel1Axpy(b *testing.B) {
+	benchLevel1(b, 2,
+		func(n int, x, y []float32) { Axpy(n, 1e-9, x, 1, y, 1) },
+		func(n int, x, y []float64) { Axpy(n, 1e-9, x, 1, y, 1) })
+}
+
+func BenchmarkLevel1Dot(b *testing.B) {
+	benchLevel1(b, 2,
+		func(n int, x, y []float32) { sink = float64(Dotu(n, x, 1, y, 1)) },
+		func(n int, x, y []float64) { sink = Dotu(n, x, 1, y, 1) })
+}
+
+// Scal alternates between two factors whose product is one, so that the
+// vectors neither overflow nor underflow.
+func BenchmarkLevel1Scal(b *testing.B) {
+	var k32, k64 int
+	benchLevel1(b, 1,
+		func(n int, x, _ []float32) {
+			k32++
+			Scal(n, float32(1+k32&1)/float32(2-k32&1), x, 1)
+		},
+		func(n int, x, _ []float64) {
+			k64++
+			Scal(n, float64(1+k64&1)/float64(2-k64&1), x, 1)
+		})
+}
+
+func BenchmarkLevel1Asum(b *testing.B) {
+	benchLevel1(b, 1,
+		func(n int, x, _ []float32) { sink = float64(Asum(n, x, 1)) },
+		func(n int, x, _ []float64) { sink = Asum(n, x, 1) })
+}
@@ -0,0 +1,844 @@
+package gen
+
+// scaleVec computes y = beta*y for the n elements of y. When beta is zero y
+// is set to zero without being read, so NaNs in y do not propagate.
+func scaleVec[T Scalar](n int, beta T, y []T, incY int) {
+	if beta == 1 {
+		return
+	}
+	iy := start(n, incY)
+	if beta == 0 {
+		for i := 0; i < n; i++ {
+			y[iy] = 0
+			iy += incY
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		y[iy] *= beta
+		iy += incY
+	}
+}
+
+// Gemv computes y = alpha*op(A)*x + beta*y where A is an m×n general matrix.
+func Gemv[T Scalar](trans Transpose, m, n int, alpha T, a []T, lda int, x []T, incX int, beta T, y []T, incY int) {
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	notrans, cj := trans == TransN, trans == TransC
+	lenX, lenY := n, m
+	if !notrans {
+		lenX, lenY = m, n
+	}
+	kx, ky := start(lenX, incX), start(lenY, incY)
+	scaleVec(lenY, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	if notrans {
+		for j, jx := 0, kx; j < n; j, jx = j+1, jx+incX {
+			temp := alpha * x[jx]
+			col := a[j*lda : j*lda+m]
+			if incY == 1 {
+				for i, v := range col {
+					y[i] += temp * v
+				}
+				continue
+			}
+			for i, iy := 0, ky; i < m; i, iy = i+1, iy+incY {
+				y[iy] += temp * col[i]
+			}
+		}
+		return
+	}
+	for j, jy := 0, ky; j < n; j, jy = j+1, jy+incY {
+		var temp T
+		col := a[j*lda : j*lda+m]
+		// The test of cj is kept out of the inner loops, which the
+		// compiler does not unswitch.
+		if cj {
+			for i, ix := 0, kx; i < m; i, ix = i+1, ix+incX {
+				temp += Conj(col[i]) * x[ix]
+			}
+		} else {
+			for i, ix := 0, kx; i < m; i, ix = i+1, ix+incX {
+				temp += col[i] * x[ix]
+			}
+		}
+		y[jy] += alpha * temp
+	}
+}
+
+// Gbmv computes y = alpha*op(A)*x + beta*y where A is an m×n band matrix with
+// kl sub-diagonals and ku super-diagonals.
+func Gbmv[T Scalar](trans Transpose, m, n, kl, ku int, alpha T, a []T, lda int, x []T, incX int, beta T, y []T, incY int) {
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	notrans, cj := trans == TransN, trans == TransC
+	lenX, lenY := n, m
+	if !notrans {
+		lenX, lenY = m, n
+	}
+	kx, ky := start(lenX, incX), start(lenY, incY)
+	scaleVec(lenY, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	if notrans {
+		for j, jx := 0, kx; j < n; j, jx = j+1, jx+incX {
+			temp := alpha * x[jx]
+			k := ku - j + j*lda
+			for i := max(0, j-ku); i <= min(m-1, j+kl); i++ {
+				y[ky+i*incY] += temp * a[k+i]
+			}
+		}
+		return
+	}
+	for j, jy := 0, ky; j < n; j, jy = j+1, jy+incY {
+		var temp T
+		k := ku - j + j*lda
+		for i := max(0, j-ku); i <= min(m-1, j+kl); i++ {
+			if cj {
+				temp += Conj(a[k+i]) * x[kx+i*incX]
+			} else {
+				temp += a[k+i] * x[kx+i*incX]
+			}
+		}
+		y[jy] += alpha * temp
+	}
+}
+
+// Hemv computes y = alpha*A*x + beta*y where A is an n×n Hermitian matrix of
+// which only the uplo triangle is referenced. For real types A is symmetric.
+func Hemv[T Scalar](uplo Uplo, n int, alpha T, a []T, lda int, x []T, incX int, beta T, y []T, incY int) {
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	kx, ky := start(n, incX), start(n, incY)
+	scaleVec(n, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	if uplo == UploU {
+		for j, jx, jy := 0, kx, ky; j < n; j, jx, jy = j+1, jx+incX, jy+incY {
+			temp1 := alpha * x[jx]
+			var temp2 T
+			col := a[j*lda : j*lda+j+1]
+			for i, ix, iy := 0, kx, ky; i < j; i, ix, iy = i+1, ix+incX, iy+incY {
+				y[iy] += temp1 * col[i]
+				temp2 += Conj(col[i]) * x[ix]
+			}
+			y[jy] += temp1*realPart(col[j]) + alpha*temp2
+		}
+		return
+	}
+	for j, jx, jy := 0, kx, ky; j < n; j, jx, jy = j+1, jx+incX, jy+incY {
+		temp1 := alpha * x[jx]
+		var temp2 T
+		col := a[j*lda : j*lda+n]
+		y[jy] += temp1 * realPart(col[j])
+		for i, ix, iy := j+1, jx+incX, jy+incY; i < n; i, ix, iy = i+1, ix+incX, iy+incY {
+			y[iy] += temp1 * col[i]
+			temp2 += Conj(col[i]) * x[ix]
+		}
+		y[jy] += alpha * temp2
+	}
+}
+
+// Hbmv computes y = alpha*A*x + beta*y where A is an n×n Hermitian band
+// matrix with k super-diagonals, stored in band form.
+func Hbmv[T Scalar](uplo Uplo, n, k int, alpha T, a []T, lda int, x []T, incX int, beta T, y []T, incY int) {
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	kx, ky := start(n, incX), start(n, incY)
+	scaleVec(n, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	if uplo == UploU {
+		for j, jx, jy := 0, kx, ky; j < n; j, jx, jy = j+1, jx+incX, jy+incY {
+			temp1 := alpha * x[jx]
+			var temp2 T
+			l := k - j + j*lda
+			for i := max(0, j-k); i < j; i++ {
+				y[ky+i*incY] += temp1 * a[l+i]
+				temp2 += Conj(a[l+i]) * x[kx+i*incX]
+			}
+			y[jy] += temp1*realPart(a[k+j*lda]) + alpha*temp2
+		}
+		return
+	}
+	for j, jx, jy := 0, kx, ky; j < n; j, jx, jy = j+1, jx+incX, jy+incY {
+		temp1 := alpha * x[jx]
+		var temp2 T
+		y[jy] += temp1 * realPart(a[j*lda])
+		l := -j + j*lda
+		for i := j + 1; i <= min(n-1, j+k); i++ {
+			y[ky+i*incY] += temp1 * a[l+i]
+			temp2 += Conj(a[l+i]) * x[kx+i*incX]
+		}
+		y[jy] += alpha * temp2
+	}
+}
+
+// Hpmv computes y = alpha*A*x + beta*y where A is an n×n Hermitian matrix
+// supplied in packed form.
+func Hpmv[T Scalar](uplo Uplo, n int, alpha T, ap []T, x []T, incX int, beta T, y []T, incY int) {
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	kx, ky := start(n, incX), start(n, incY)
+	scaleVec(n, beta, y, incY)
+	if alpha == 0 {
+		return
+	}
+	kk := 0
+	if uplo == UploU {
+		for j, jx, jy := 0, kx, ky; j < n; j, jx, jy = j+1, jx+incX, jy+incY {
+			temp1 := alpha * x[jx]
+			var temp2 T
+			for i, ix, iy := 0, kx, ky; i < j; i, ix, iy = i+1, ix+incX, iy+incY {
+				y[iy] += temp1 * ap[kk+i]
+				temp2 += Conj(ap[kk+i]) * x[ix]
+			}
+			y[jy] += temp1*realPart(ap[kk+j]) + alpha*temp2
+			kk += j + 1
+		}
+		return
+	}
+	for j, jx, jy := 0, kx, ky; j < n; j, jx, jy = j+1, jx+incX, jy+incY {
+		temp1 := alpha * x[jx]
+		var temp2 T
+		y[jy] += temp1 * realPart(ap[kk])
+		for k, ix, iy := kk+1, jx+incX, jy+incY; k < kk+n-j; k, ix, iy = k+1, ix+incX, iy+incY {
+			y[iy] += temp1 * ap[k]
+			temp2 += Conj(ap[k]) * x[ix]
+		}
+		y[jy] += alpha * temp2
+		kk += n - j
+	}
+}
+
+// Trmv computes x = op(A)*x where A is an n×n triangular matrix.
+func Trmv[T Scalar](uplo Uplo, trans Transpose, diag Diag, n int, a []T, lda int, x []T, incX int) {
+	if n == 0 {
+		return
+	}
+	upper, notrans, cj := uplo == UploU, trans == TransN, trans == TransC
+	nounit := diag == DiagN
+	kx := start(n, incX)
+	op := func(v T) T {
+		if cj {
+			return Conj(v)
+		}
+		return v
+	}
+	switch {
+	case notrans && upper:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			temp := x[jx]
+			col := a[j*lda : j*lda+j+1]
+			for i := 0; i < j; i++ {
+				x[kx+i*incX] += temp * col[i]
+			}
+			if nounit {
+				x[jx] *= col[j]
+			}
+		}
+	case notrans:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			temp := x[jx]
+			col := a[j*lda : j*lda+n]
+			for i := n - 1; i > j; i-- {
+				x[kx+i*incX] += temp * col[i]
+			}
+			if nounit {
+				x[jx] *= col[j]
+			}
+		}
+	case upper:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			temp := x[jx]
+			col := a[j*lda : j*lda+j+1]
+			if nounit {
+				temp *= op(col[j])
+			}
+			for i := j - 1; i >= 0; i-- {
+				temp += op(col[i]) * x[kx+i*incX]
+			}
+			x[jx] = temp
+		}
+	default:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			temp := x[jx]
+			col := a[j*lda : j*lda+n]
+			if nounit {
+				temp *= op(col[j])
+			}
+			for i := j + 1; i < n; i++ {
+				temp += op(col[i]) * x[kx+i*incX]
+			}
+			x[jx] = temp
+		}
+	}
+}
+
+// Tbmv computes x = op(A)*x where A is an n×n triangular band matrix with k
+// off-diagonals.
+func Tbmv[T Scalar](uplo Uplo, trans Transpose, diag Diag, n, k int, a []T, lda int, x []T, incX int) {
+	if n == 0 {
+		return
+	}
+	upper, notrans, cj := uplo == UploU, trans == TransN, trans == TransC
+	nounit := diag == DiagN
+	kx := start(n, incX)
+	op := func(v T) T {
+		if cj {
+			return Conj(v)
+		}
+		return v
+	}
+	switch {
+	case notrans && upper:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			temp := x[jx]
+			l := k - j + j*lda
+			for i := max(0, j-k); i < j; i++ {
+				x[kx+i*incX] += temp * a[l+i]
+			}
+			if nounit {
+				x[jx] *= a[k+j*lda]
+			}
+		}
+	case notrans:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			temp := x[jx]
+			l := -j + j*lda
+			for i := min(n-1, j+k); i > j; i-- {
+				x[kx+i*incX] += temp * a[l+i]
+			}
+			if nounit {
+				x[jx] *= a[j*lda]
+			}
+		}
+	case upper:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			temp := x[jx]
+			l := k - j + j*lda
+			if nounit {
+				temp *= op(a[k+j*lda])
+			}
+			for i := j - 1; i >= max(0, j-k); i-- {
+				temp += op(a[l+i]) * x[kx+i*incX]
+			}
+			x[jx] = temp
+		}
+	default:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			temp := x[jx]
+			l := -j + j*lda
+			if nounit {
+				temp *= op(a[j*lda])
+			}
+			for i := j + 1; i <= min(n-1, j+k); i++ {
+				temp += op(a[l+i]) * x[kx+i*incX]
+			}
+			x[jx] = temp
+		}
+	}
+}
+
+// Tpmv computes x = op(A)*x where A is an n×n triangular matrix supplied in
+// packed form.
+func Tpmv[T Scalar](uplo Uplo, trans Transpose, diag Diag, n int, ap []T, x []T, incX int) {
+	if n == 0 {
+		return
+	}
+	upper, notrans, cj := uplo == UploU, trans == TransN, trans == TransC
+	nounit := diag == DiagN
+	kx := start(n, incX)
+	op := func(v T) T {
+		if cj {
+			return Conj(v)
+		}
+		return v
+	}
+	switch {
+	case notrans && upper:
+		// Column j starts at kk = j*(j+1)/2.
+		for j, kk := 0, 0; j < n; j, kk = j+1, kk+j+1 {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			temp := x[jx]
+			for i := 0; i < j; i++ {
+				x[kx+i*incX] += temp * ap[kk+i]
+			}
+			if nounit {
+				x[jx] *= ap[kk+j]
+			}
+		}
+	case notrans:
+		// Column j starts at kk = j*(2n-j+1)/2, with its diagonal first.
+		for j, kk := n-1, n*(n+1)/2-1; j >= 0; j, kk = j-1, kk-(n-j) {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			temp := x[jx]
+			for i, k := n-1, kk; i > j; i, k = i-1, k-1 {
+				x[kx+i*incX] += temp * ap[k]
+			}
+			if nounit {
+				x[jx] *= ap[kk-n+j+1]
+			}
+		}
+	case upper:
+		for j, kk := n-1, n*(n+1)/2-1; j >= 0; j, kk = j-1, kk-j-1 {
+			jx := kx + j*incX
+			temp := x[jx]
+			if nounit {
+				temp *= op(ap[kk])
+			}
+			for i, k := j-1, kk-1; i >= 0; i, k = i-1, k-1 {
+				temp += op(ap[k]) * x[kx+i*incX]
+			}
+			x[jx] = temp
+		}
+	default:
+		for j, kk := 0, 0; j < n; j, kk = j+1, kk+n-j {
+			jx := kx + j*incX
+			temp := x[jx]
+			if nounit {
+				temp *= op(ap[kk])
+			}
+			for i, k := j+1, kk+1; i < n; i, k = i+1, k+1 {
+				temp += op(ap[k]) * x[kx+i*incX]
+			}
+			x[jx] = temp
+		}
+	}
+}
+
+// Trsv solves op(A)*x = b where A is an n×n triangular matrix. b is supplied
+// in x and overwritten by the solution.
+func Trsv[T Scalar](uplo Uplo, trans Transpose, diag Diag, n int, a []T, lda int, x []T, incX int) {
+	if n == 0 {
+		return
+	}
+	upper, notrans, cj := uplo == UploU, trans == TransN, trans == TransC
+	nounit := diag == DiagN
+	kx := start(n, incX)
+	op := func(v T) T {
+		if cj {
+			return Conj(v)
+		}
+		return v
+	}
+	switch {
+	case notrans && upper:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			col := a[j*lda : j*lda+j+1]
+			if nounit {
+				x[jx] /= col[j]
+			}
+			temp := x[jx]
+			for i := j - 1; i >= 0; i-- {
+				x[kx+i*incX] -= temp * col[i]
+			}
+		}
+	case notrans:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			col := a[j*lda : j*lda+n]
+			if nounit {
+				x[jx] /= col[j]
+			}
+			temp := x[jx]
+			for i := j + 1; i < n; i++ {
+				x[kx+i*incX] -= temp * col[i]
+			}
+		}
+	case upper:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			temp := x[jx]
+			col := a[j*lda : j*lda+j+1]
+			for i := 0; i < j; i++ {
+				temp -= op(col[i]) * x[kx+i*incX]
+			}
+			if nounit {
+				temp /= op(col[j])
+			}
+			x[jx] = temp
+		}
+	default:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			temp := x[jx]
+			col := a[j*lda : j*lda+n]
+			for i := n - 1; i > j; i-- {
+				temp -= op(col[i]) * x[kx+i*incX]
+			}
+			if nounit {
+				temp /= op(col[j])
+			}
+			x[jx] = temp
+		}
+	}
+}
+
+// Tbsv solves op(A)*x = b where A is an n×n triangular band matrix with k
+// off-diagonals. b is supplied in x and overwritten by the solution.
+func Tbsv[T Scalar](uplo Uplo, trans Transpose, diag Diag, n, k int, a []T, lda int, x []T, incX int) {
+	if n == 0 {
+		return
+	}
+	upper, notrans, cj := uplo == UploU, trans == TransN, trans == TransC
+	nounit := diag == DiagN
+	kx := start(n, incX)
+	op := func(v T) T {
+		if cj {
+			return Conj(v)
+		}
+		return v
+	}
+	switch {
+	case notrans && upper:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			l := k - j + j*lda
+			if nounit {
+				x[jx] /= a[k+j*lda]
+			}
+			temp := x[jx]
+			for i := j - 1; i >= max(0, j-k); i-- {
+				x[kx+i*incX] -= temp * a[l+i]
+			}
+		}
+	case notrans:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			l := -j + j*lda
+			if nounit {
+				x[jx] /= a[j*lda]
+			}
+			temp := x[jx]
+			for i := j + 1; i <= min(n-1, j+k); i++ {
+				x[kx+i*incX] -= temp * a[l+i]
+			}
+		}
+	case upper:
+		for j := 0; j < n; j++ {
+			jx := kx + j*incX
+			temp := x[jx]
+			l := k - j + j*lda
+			for i := max(0, j-k); i < j; i++ {
+				temp -= op(a[l+i]) * x[kx+i*incX]
+			}
+			if nounit {
+				temp /= op(a[k+j*lda])
+			}
+			x[jx] = temp
+		}
+	default:
+		for j := n - 1; j >= 0; j-- {
+			jx := kx + j*incX
+			temp := x[jx]
+			l := -j + j*lda
+			for i := min(n-1, j+k); i > j; i-- {
+				temp -= op(a[l+i]) * x[kx+i*incX]
+			}
+			if nounit {
+				temp /= op(a[j*lda])
+			}
+			x[jx] = temp
+		}
+	}
+}
+
+// Tpsv solves op(A)*x = b where A is an n×n triangular matrix supplied in
+// packed form. b is supplied in x and overwritten by the solution.
+func Tpsv[T Scalar](uplo Uplo, trans Transpose, diag Diag, n int, ap []T, x []T, incX int) {
+	if n == 0 {
+		return
+	}
+	upper, notrans, cj := uplo == UploU, trans == TransN, trans == TransC
+	nounit := diag == DiagN
+	kx := start(n, incX)
+	op := func(v T) T {
+		if cj {
+			return Conj(v)
+		}
+		return v
+	}
+	switch {
+	case notrans && upper:
+		// kk is the index of the diagonal element of column j.
+		for j, kk := n-1, n*(n+1)/2-1; j >= 0; j, kk = j-1, kk-j-1 {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			if nounit {
+				x[jx] /= ap[kk]
+			}
+			temp := x[jx]
+			for i, k := j-1, kk-1; i >= 0; i, k = i-1, k-1 {
+				x[kx+i*incX] -= temp * ap[k]
+			}
+		}
+	case notrans:
+		for j, kk := 0, 0; j < n; j, kk = j+1, kk+n-j {
+			jx := kx + j*incX
+			if x[jx] == 0 {
+				continue
+			}
+			if nounit {
+				x[jx] /= ap[kk]
+			}
+			temp := x[jx]
+			for i, k := j+1, kk+1; i < n; i, k = i+1, k+1 {
+				x[kx+i*incX] -= temp * ap[k]
+			}
+		}
+	case upper:
+		// kk is the index of the first element of column j.
+		for j, kk := 0, 0; j < n; j, kk = j+1, kk+j+1 {
+			jx := kx + j*incX
+			temp := x[jx]
+			for i := 0; i < j; i++ {
+				temp -= op(ap[kk+i]) * x[kx+i*incX]
+			}
+			if nounit {
+				temp /= op(ap[kk+j])
+			}
+			x[jx] = temp
+		}
+	default:
+		// kk is the index of the last element of column j.
+		for j, kk := n-1, n*(n+1)/2-1; j >= 0; j, kk = j-1, kk-(n-j) {
+			jx := kx + j*incX
+			temp := x[jx]
+			for i, k := n-1, kk; i > j; i, k = i-1, k-1 {
+				temp -= op(ap[k]) * x[kx+i*incX]
+			}
+			if nounit {
+				temp /= op(ap[kk-n+j+1])
+			}
+			x[jx] = temp
+		}
+	}
+}
+
+// Ger computes A = alpha*x*y**T + A where A is an m×n matrix.
+func Ger[T Scalar](m, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	ger(false, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+// Gerc computes A = alpha*x*y**H + A where A is an m×n matrix.
+func Gerc[T Scalar](m, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	ger(true, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+// ger computes A = alpha*x*y' + A, or A = alpha*x*conjg(y') + A when cj is
+// set, where A is an m×n matrix.
+func ger[T Scalar](cj bool, m, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	if m == 0 || n == 0 || alpha == 0 {
+		return
+	}
+	kx := start(m, incX)
+	for j, jy := 0, start(n, incY); j < n; j, jy = j+1, jy+incY {
+		if y[jy] == 0 {
+			continue
+		}
+		temp := y[jy]
+		if cj {
+			temp = Conj(temp)
+		}
+		temp *= alpha
+		col := a[j*lda : j*lda+m]
+		if incX == 1 {
+			for i, v := range x[:m] {
+				col[i] += v * temp
+			}
+			continue
+		}
+		for i, ix := 0, kx; i < m; i, ix = i+1, ix+incX {
+			col[i] += x[ix] * temp
+		}
+	}
+}
+
+// Her computes A = alpha*x*conjg(x') + A where A is an n×n Hermitian matrix
+// of which only the uplo triangle is updated. alpha is real.
+func Her[T Scalar](uplo Uplo, n int, alpha T, x []T, incX int, a []T, lda int) {
+	if n == 0 || alpha == 0 {
+		return
+	}
+	kx := start(n, incX)
+	upper := uplo == UploU
+	for j := 0; j < n; j++ {
+		jx := kx + j*incX
+		jj := j + j*lda
+		if x[jx] == 0 {
+			a[jj] = realPart(a[jj])
+			continue
+		}
+		temp := alpha * Conj(x[jx])
+		if upper {
+			for i := 0; i < j; i++ {
+				a[i+j*lda] += x[kx+i*incX] * temp
+			}
+		} else {
+			for i := j + 1; i < n; i++ {
+				a[i+j*lda] += x[kx+i*incX] * temp
+			}
+		}
+		a[jj] = realPart(a[jj]) + realPart(x[jx]*temp)
+	}
+}
+
+// Hpr computes A = alpha*x*conjg(x') + A where A is an n×n Hermitian matrix
+// supplied in packed form. alpha is real.
+func Hpr[T Scalar](uplo Uplo, n int, alpha T, x []T, incX int, ap []T) {
+	if n == 0 || alpha == 0 {
+		return
+	}
+	kx := start(n, incX)
+	upper := uplo == UploU
+	kk := 0
+	for j := 0; j < n; j++ {
+		jx := kx + j*incX
+		// jj is the index of the diagonal element of column j.
+		jj := kk + j
+		if !upper {
+			jj = kk
+		}
+		if x[jx] == 0 {
+			ap[jj] = realPart(ap[jj])
+		} else {
+			temp := alpha * Conj(x[jx])
+			if upper {
+				for i := 0; i < j; i++ {
+					ap[kk+i] += x[kx+i*incX] * temp
+				}
+			} else {
+				for i, k := j+1, kk+1; i < n; i, k = i+1, k+1 {
+					ap[k] += x[kx+i*incX] * temp
+				}
+			}
+			ap[jj] = realPart(ap[jj]) + realPart(x[jx]*temp)
+		}
+		if upper {
+			kk += j + 1
+		} else {
+			kk += n - j
+		}
+	}
+}
+
+// Her2 computes A = alpha*x*conjg(y') + conjg(alpha)*y*conjg(x') + A where A
+// is an n×n Hermitian matrix of which only the uplo triangle is updated.
+func Her2[T Scalar](uplo Uplo, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	if n == 0 || alpha == 0 {
+		return
+	}
+	kx, ky := start(n, incX), start(n, incY)
+	upper := uplo == UploU
+	for j := 0; j < n; j++ {
+		jx, jy := kx+j*incX, ky+j*incY
+		jj := j + j*lda
+		if x[jx] == 0 && y[jy] == 0 {
+			a[jj] = realPart(a[jj])
+			continue
+		}
+		temp1 := alpha * Conj(y[jy])
+		temp2 := Conj(alpha * x[jx])
+		if upper {
+			for i := 0; i < j; i++ {
+				a[i+j*lda] += x[kx+i*incX]*temp1 + y[ky+i*incY]*temp2
+			}
+		} else {
+			for i := j + 1; i < n; i++ {
+				a[i+j*lda] += x[kx+i*incX]*temp1 + y[ky+i*incY]*temp2
+			}
+		}
+		a[jj] = realPart(a[jj]) + realPart(x[jx]*temp1+y[jy]*temp2)
+	}
+}
+
+// Hpr2 computes A = alpha*x*conjg(y') + conjg(alpha)*y*conjg(x') + A where A
+// is an n×n Hermitian matrix supplied in packed form.
+func Hpr2[T Scalar](uplo Uplo, n int, alpha T, x []T, incX int, y []T, incY int, ap []T) {
+	if n == 0 || alpha == 0 {
+		return
+	}
+	kx, ky := start(n, incX), start(n, incY)
+	upper := uplo == UploU
+	kk := 0
+	for j := 0; j < n; j++ {
+		jx, jy := kx+j*incX, ky+j*incY
+		jj := kk + j
+		if !upper {
+			jj = kk
+		}
+		if x[jx] == 0 && y[jy] == 0 {
+			ap[jj] = realPart(ap[jj])
+		} else {
+			temp1 := alpha * Conj(y[jy])
+			temp2 := Conj(alpha * x[jx])
+			if upper {
+				for i := 0; i < j; i++ {
+					ap[kk+i] += x[kx+i*incX]*temp1 + y[ky+i*incY]*temp2
+				}
+			} else {
+				for i, k := j+1, kk+1; i < n; i, k = i+1, k+1 {
+					ap[k] += x[kx+i*incX]*temp1 + y[ky+i*incY]*temp2
+				}
+			}
+			ap[jj] = realPart(ap[jj]) + realPart(x[jx]*temp1+y[jy]*temp2)
+		}
+		if upper {
+			kk += j + 1
+		} else {
+			kk += n - j
+		}
+	}
+}
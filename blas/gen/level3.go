@@ -0,0 +1,802 @@
+package gen
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// blockSize is the order of the diagonal blocks handled by the unblocked
+// kernels in the blocked triangular and symmetric routines. The remaining
+// work is cast in terms of gemm.
+const blockSize = 64
+
+// scaleMat computes C = beta*C for the m×n matrix C. When beta is zero C is
+// set to zero without being read.
+func scaleMat[T Scalar](m, n int, beta T, c []T, ldc int) {
+	if beta == 1 {
+		return
+	}
+	for j := 0; j < n; j++ {
+		col := c[j*ldc : j*ldc+m]
+		if beta == 0 {
+			for i := range col {
+				col[i] = 0
+			}
+			continue
+		}
+		for i := range col {
+			col[i] *= beta
+		}
+	}
+}
+
+// Gemm computes C = alpha*op(A)*op(B) + beta*C where op(A) is m×k, op(B) is
+// k×n and C is m×n.
+func Gemm[T Scalar](transA, transB Transpose, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	GemmContext(context.Background(), transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// GemmContext is Gemm that checks ctx between the blocks of the packed
+// product and returns ctx.Err() once ctx is done. C is then partially
+// updated: it has been scaled by beta and holds part of alpha*op(A)*op(B).
+// Products too small to be packed run to completion.
+func GemmContext[T Scalar](ctx context.Context, transA, transB Transpose, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return gemmOn(ctx, workers(float64(m)*float64(n)*float64(k)), transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// gemmOn computes the product of GemmContext on up to w goroutines.
+func gemmOn[T Scalar](ctx context.Context, w int, transA, transB Transpose, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) error {
+	if m == 0 || n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return nil
+	}
+	scaleMat(m, n, beta, c, ldc)
+	if alpha == 0 || k == 0 {
+		return nil
+	}
+	ta, tb := transA != TransN, transB != TransN
+	ca, cb := transA == TransC, transB == TransC
+	if m*n*k < packedMin {
+		gemmKernel(ta, tb, ca, cb, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+		return nil
+	}
+	if w > 1 {
+		return gemmParallel(ctx, w, ta, tb, ca, cb, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+	}
+	return gemmPacked(ctx, ta, tb, ca, cb, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+}
+
+// gemm computes C = alpha*op(A)*op(B) + beta*C like Gemm, always with the
+// packed product and on the calling goroutine. The blocked routines use it
+// for their off-diagonal blocks, so that splitting them among goroutines
+// does not change how any element is computed.
+func gemm[T Scalar](transA, transB Transpose, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	if m == 0 || n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	scaleMat(m, n, beta, c, ldc)
+	if alpha == 0 || k == 0 {
+		return
+	}
+	gemmPacked(context.Background(), transA != TransN, transB != TransN, transA == TransC, transB == TransC, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+}
+
+// gemmKernel computes C += alpha*op(A)*op(B) without blocking or packing. ta
+// and tb select the transposed forms, ca and cb additionally conjugate.
+func gemmKernel[T Scalar](ta, tb, ca, cb bool, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, c []T, ldc int) {
+	for j := 0; j < n; j++ {
+		ccol := c[j*ldc : j*ldc+m]
+		if !ta {
+			// C(:,j) += alpha*op(B)(l,j)*A(:,l), accessing A by columns.
+			for l := 0; l < k; l++ {
+				var temp T
+				if tb {
+					temp = b[j+l*ldb]
+					if cb {
+						temp = Conj(temp)
+					}
+				} else {
+					temp = b[l+j*ldb]
+				}
+				temp *= alpha
+				for i, v := range a[l*lda : l*lda+m] {
+					ccol[i] += temp * v
+				}
+			}
+			continue
+		}
+		// C(i,j) += alpha*dot(op(A(:,i)), op(B)(:,j)), accessing A by columns.
+		for i := range ccol {
+			acol := a[i*lda : i*lda+k]
+			var temp T
+			switch {
+			case !tb:
+				bcol := b[j*ldb : j*ldb+k]
+				if ca {
+					for l, v := range acol {
+						temp += Conj(v) * bcol[l]
+					}
+				} else {
+					for l, v := range acol {
+						temp += v * bcol[l]
+					}
+				}
+			default:
+				for l, v := range acol {
+					if ca {
+						v = Conj(v)
+					}
+					w := b[j+l*ldb]
+					if cb {
+						w = Conj(w)
+					}
+					temp += v * w
+				}
+			}
+			ccol[i] += alpha * temp
+		}
+	}
+}
+
+// Symm computes C = alpha*A*B + beta*C (side L) or C = alpha*B*A + beta*C
+// (side R) where A is symmetric and only its uplo triangle is referenced.
+func Symm[T Scalar](side Side, uplo Uplo, m, n int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	hemm(false, side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// Hemm computes C = alpha*A*B + beta*C (side L) or C = alpha*B*A + beta*C
+// (side R) where A is Hermitian and only its uplo triangle is referenced.
+func Hemm[T Scalar](side Side, uplo Uplo, m, n int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	hemm(true, side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// hemm computes C = alpha*A*B + beta*C (side L) or C = alpha*B*A + beta*C
+// (side R) where A is Hermitian when herm is set and symmetric otherwise, and
+// only its uplo triangle is referenced.
+func hemm[T Scalar](herm bool, side Side, uplo Uplo, m, n int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	order := m
+	if side == SideR {
+		order = n
+	}
+	if w := workers(float64(m) * float64(n) * float64(order)); w > 1 {
+		parallelSide[T](side, m, n, w, func(i0, mb, j0, nb int) {
+			hemmBlocked(herm, side, uplo, mb, nb, alpha, a, lda, b[i0+j0*ldb:], ldb, beta, c[i0+j0*ldc:], ldc)
+		})
+		return
+	}
+	hemmBlocked(herm, side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// hemmBlocked computes the product of hemm on the calling goroutine.
+func hemmBlocked[T Scalar](herm bool, side Side, uplo Uplo, m, n int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	scaleMat(m, n, beta, c, ldc)
+	if alpha == 0 {
+		return
+	}
+	left, upper := side == SideL, uplo == UploU
+	opT := TransT
+	if herm {
+		opT = TransC
+	}
+	nt := TransN
+	if left {
+		for i0 := 0; i0 < m; i0 += blockSize {
+			ib := min(blockSize, m-i0)
+			hemmUnblocked(herm, true, upper, ib, n, alpha, a[i0+i0*lda:], lda, b[i0:], ldb, c[i0:], ldc)
+			// Apply the stored off-diagonal block A(I,J) of block row I
+			// and its reflection A(J,I) = op(A(I,J)).
+			switch {
+			case upper && i0+ib < m:
+				r0 := i0 + ib
+				gemm(nt, nt, ib, n, m-r0, alpha, a[i0+r0*lda:], lda, b[r0:], ldb, 1, c[i0:], ldc)
+				gemm(opT, nt, m-r0, n, ib, alpha, a[i0+r0*lda:], lda, b[i0:], ldb, 1, c[r0:], ldc)
+			case !upper && i0 > 0:
+				gemm(nt, nt, ib, n, i0, alpha, a[i0:], lda, b, ldb, 1, c[i0:], ldc)
+				gemm(opT, nt, i0, n, ib, alpha, a[i0:], lda, b[i0:], ldb, 1, c, ldc)
+			}
+		}
+		return
+	}
+	for j0 := 0; j0 < n; j0 += blockSize {
+		jb := min(blockSize, n-j0)
+		hemmUnblocked(herm, false, upper, m, jb, alpha, a[j0+j0*lda:], lda, b[j0*ldb:], ldb, c[j0*ldc:], ldc)
+		switch {
+		case upper && j0+jb < n:
+			c0 := j0 + jb
+			gemm(nt, nt, m, n-c0, jb, alpha, b[j0*ldb:], ldb, a[j0+c0*lda:], lda, 1, c[c0*ldc:], ldc)
+			gemm(nt, opT, m, jb, n-c0, alpha, b[c0*ldb:], ldb, a[j0+c0*lda:], lda, 1, c[j0*ldc:], ldc)
+		case !upper && j0 > 0:
+			gemm(nt, nt, m, j0, jb, alpha, b[j0*ldb:], ldb, a[j0:], lda, 1, c, ldc)
+			gemm(nt, opT, m, jb, j0, alpha, b, ldb, a[j0:], lda, 1, c[j0*ldc:], ldc)
+		}
+	}
+}
+
+// hemmUnblocked computes C += alpha*A*B or C += alpha*B*A as the reference
+// xSYMM and xHEMM do, with beta already applied to C.
+func hemmUnblocked[T Scalar](herm, left, upper bool, m, n int, alpha T, a []T, lda int, b []T, ldb int, c []T, ldc int) {
+	op := func(v T) T {
+		if herm {
+			return Conj(v)
+		}
+		return v
+	}
+	re := func(v T) T {
+		if herm {
+			return realPart(v)
+		}
+		return v
+	}
+	if left {
+		for j := 0; j < n; j++ {
+			bcol, ccol := b[j*ldb:j*ldb+m], c[j*ldc:j*ldc+m]
+			if upper {
+				for i := 0; i < m; i++ {
+					temp1 := alpha * bcol[i]
+					var temp2 T
+					acol := a[i*lda : i*lda+i+1]
+					for k := 0; k < i; k++ {
+						ccol[k] += temp1 * acol[k]
+						temp2 += bcol[k] * op(acol[k])
+					}
+					ccol[i] += temp1*re(acol[i]) + alpha*temp2
+				}
+				continue
+			}
+			for i := m - 1; i >= 0; i-- {
+				temp1 := alpha * bcol[i]
+				var temp2 T
+				acol := a[i*lda : i*lda+m]
+				for k := i + 1; k < m; k++ {
+					ccol[k] += temp1 * acol[k]
+					temp2 += bcol[k] * op(acol[k])
+				}
+				ccol[i] += temp1*re(acol[i]) + alpha*temp2
+			}
+		}
+		return
+	}
+	for j := 0; j < n; j++ {
+		ccol := c[j*ldc : j*ldc+m]
+		for k := 0; k < n; k++ {
+			var temp T
+			switch {
+			case k == j:
+				temp = re(a[j+j*lda])
+			case (k < j) == upper:
+				temp = a[k+j*lda]
+			default:
+				temp = op(a[j+k*lda])
+			}
+			temp *= alpha
+			for i, v := range b[k*ldb : k*ldb+m] {
+				ccol[i] += temp * v
+			}
+		}
+	}
+}
+
+// Syrk computes C = alpha*A*A**T + beta*C (trans N) or
+// C = alpha*A**T*A + beta*C otherwise, updating only the uplo triangle of the
+// n×n matrix C.
+func Syrk[T Scalar](uplo Uplo, trans Transpose, n, k int, alpha T, a []T, lda int, beta T, c []T, ldc int) {
+	herk(false, uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+// Herk computes C = alpha*A*A**H + beta*C (trans N) or
+// C = alpha*A**H*A + beta*C otherwise, updating only the uplo triangle of the
+// n×n matrix C. alpha and beta must be real and the diagonal of C is kept
+// real.
+func Herk[T Scalar](uplo Uplo, trans Transpose, n, k int, alpha T, a []T, lda int, beta T, c []T, ldc int) {
+	herk(true, uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+// herk computes C = alpha*A*op(A) + beta*C (trans N) or C = alpha*op(A)*A +
+// beta*C otherwise, updating only the uplo triangle of the n×n matrix C.
+// When herm is set op is the conjugate transpose, alpha and beta are real and
+// the diagonal of C is kept real; otherwise op is the transpose.
+func herk[T Scalar](herm bool, uplo Uplo, trans Transpose, n, k int, alpha T, a []T, lda int, beta T, c []T, ldc int) {
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	upper, tr := uplo == UploU, trans != TransN
+	if alpha == 0 || k == 0 {
+		// Only the scaling by beta remains and A is not referenced.
+		k, a, lda = 0, nil, 0
+	}
+	// The block columns of C are independent.
+	if w := workers(float64(n) * float64(n) * float64(k) / 2); w > 1 {
+		parallel((n+blockSize-1)/blockSize, w, func(i int) {
+			herkBlock(herm, upper, tr, i*blockSize, n, k, alpha, a, lda, beta, c, ldc)
+		})
+		return
+	}
+	for j0 := 0; j0 < n; j0 += blockSize {
+		herkBlock(herm, upper, tr, j0, n, k, alpha, a, lda, beta, c, ldc)
+	}
+}
+
+// herkBlock updates the block column of C starting at column j0 for herk:
+// its diagonal block and its off-diagonal block inside the triangle.
+func herkBlock[T Scalar](herm, upper, tr bool, j0, n, k int, alpha T, a []T, lda int, beta T, c []T, ldc int) {
+	opT, nt := TransT, TransN
+	if herm {
+		opT = TransC
+	}
+	// offA returns the offset in a of the rows of op(A) starting at i.
+	offA := func(i int) int {
+		if k == 0 {
+			return 0
+		}
+		if tr {
+			return i * lda
+		}
+		return i
+	}
+	jb := min(blockSize, n-j0)
+	herkUnblocked(herm, upper, tr, jb, k, alpha, a[offA(j0):], lda, beta, c[j0+j0*ldc:], ldc)
+	r0, rows := 0, j0
+	if !upper {
+		r0, rows = j0+jb, n-j0-jb
+	}
+	if rows == 0 {
+		return
+	}
+	if tr {
+		gemm(opT, nt, rows, jb, k, alpha, a[offA(r0):], lda, a[offA(j0):], lda, beta, c[r0+j0*ldc:], ldc)
+	} else {
+		gemm(nt, opT, rows, jb, k, alpha, a[offA(r0):], lda, a[offA(j0):], lda, beta, c[r0+j0*ldc:], ldc)
+	}
+}
+
+// herkUnblocked is the unblocked form of herk, following the reference
+// xSYRK and xHERK.
+func herkUnblocked[T Scalar](herm, upper, tr bool, n, k int, alpha T, a []T, lda int, beta T, c []T, ldc int) {
+	op := func(v T) T {
+		if herm {
+			return Conj(v)
+		}
+		return v
+	}
+	re := func(v T) T {
+		if herm {
+			return realPart(v)
+		}
+		return v
+	}
+	for j := 0; j < n; j++ {
+		i0, i1 := 0, j+1
+		if !upper {
+			i0, i1 = j, n
+		}
+		ccol := c[j*ldc : j*ldc+i1]
+		if !tr {
+			scaleMat(i1-i0, 1, beta, ccol[i0:], 0)
+			ccol[j] = re(ccol[j])
+			for l := 0; l < k; l++ {
+				ajl := a[j+l*lda]
+				if ajl == 0 {
+					continue
+				}
+				temp := alpha * op(ajl)
+				acol := a[l*lda : l*lda+i1]
+				for i := i0; i < i1; i++ {
+					ccol[i] += temp * acol[i]
+				}
+			}
+			ccol[j] = re(ccol[j])
+			continue
+		}
+		bcol := a[j*lda : j*lda+k]
+		for i := i0; i < i1; i++ {
+			var temp T
+			for l, v := range a[i*lda : i*lda+k] {
+				temp += op(v) * bcol[l]
+			}
+			if i == j {
+				temp = re(temp)
+			}
+			if beta == 0 {
+				ccol[i] = alpha * temp
+			} else if i == j {
+				ccol[i] = alpha*temp + beta*re(ccol[i])
+			} else {
+				ccol[i] = alpha*temp + beta*ccol[i]
+			}
+		}
+	}
+}
+
+// Syr2k computes C = alpha*A*B**T + alpha*B*A**T + beta*C (trans N) or
+// C = alpha*A**T*B + alpha*B**T*A + beta*C otherwise, updating only the uplo
+// triangle of the n×n matrix C.
+func Syr2k[T Scalar](uplo Uplo, trans Transpose, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	her2k(false, uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// Her2k computes C = alpha*A*B**H + conjg(alpha)*B*A**H + beta*C (trans N) or
+// C = alpha*A**H*B + conjg(alpha)*B**H*A + beta*C otherwise, updating only
+// the uplo triangle of the n×n matrix C. beta must be real and the diagonal
+// of C is kept real.
+func Her2k[T Scalar](uplo Uplo, trans Transpose, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	her2k(true, uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+// her2k computes C = alpha*A*op(B) + calpha*B*op(A) + beta*C (trans N) or
+// C = alpha*op(A)*B + calpha*op(B)*A + beta*C otherwise, updating only the
+// uplo triangle of the n×n matrix C. When herm is set op is the conjugate
+// transpose, calpha = conjg(alpha), beta is real and the diagonal of C is kept
+// real; otherwise op is the transpose and calpha = alpha.
+func her2k[T Scalar](herm bool, uplo Uplo, trans Transpose, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	upper, tr := uplo == UploU, trans != TransN
+	if alpha == 0 || k == 0 {
+		k, a, lda, b, ldb = 0, nil, 0, nil, 0
+	}
+	if w := workers(float64(n) * float64(n) * float64(k)); w > 1 {
+		parallel((n+blockSize-1)/blockSize, w, func(i int) {
+			her2kBlock(herm, upper, tr, i*blockSize, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		})
+		return
+	}
+	for j0 := 0; j0 < n; j0 += blockSize {
+		her2kBlock(herm, upper, tr, j0, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	}
+}
+
+// her2kBlock updates the block column of C starting at column j0 for
+// her2k.
+func her2kBlock[T Scalar](herm, upper, tr bool, j0, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	opT, nt := TransT, TransN
+	calpha := alpha
+	if herm {
+		opT = TransC
+		calpha = Conj(alpha)
+	}
+	off := func(i, ld int) int {
+		if k == 0 {
+			return 0
+		}
+		if tr {
+			return i * ld
+		}
+		return i
+	}
+	jb := min(blockSize, n-j0)
+	her2kUnblocked(herm, upper, tr, jb, k, alpha, a[off(j0, lda):], lda, b[off(j0, ldb):], ldb, beta, c[j0+j0*ldc:], ldc)
+	r0, rows := 0, j0
+	if !upper {
+		r0, rows = j0+jb, n-j0-jb
+	}
+	if rows == 0 {
+		return
+	}
+	ta, tb := nt, opT
+	if tr {
+		ta, tb = opT, nt
+	}
+	cc := c[r0+j0*ldc:]
+	gemm(ta, tb, rows, jb, k, alpha, a[off(r0, lda):], lda, b[off(j0, ldb):], ldb, beta, cc, ldc)
+	gemm(ta, tb, rows, jb, k, calpha, b[off(r0, ldb):], ldb, a[off(j0, lda):], lda, 1, cc, ldc)
+}
+
+// her2kUnblocked is the unblocked form of her2k, following the reference
+// xSYR2K and xHER2K.
+func her2kUnblocked[T Scalar](herm, upper, tr bool, n, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	op := func(v T) T {
+		if herm {
+			return Conj(v)
+		}
+		return v
+	}
+	re := func(v T) T {
+		if herm {
+			return realPart(v)
+		}
+		return v
+	}
+	for j := 0; j < n; j++ {
+		i0, i1 := 0, j+1
+		if !upper {
+			i0, i1 = j, n
+		}
+		ccol := c[j*ldc : j*ldc+i1]
+		if !tr {
+			scaleMat(i1-i0, 1, beta, ccol[i0:], 0)
+			ccol[j] = re(ccol[j])
+			for l := 0; l < k; l++ {
+				ajl, bjl := a[j+l*lda], b[j+l*ldb]
+				if ajl == 0 && bjl == 0 {
+					continue
+				}
+				temp1 := alpha * op(bjl)
+				temp2 := op(alpha * ajl)
+				acol, bcol := a[l*lda:l*lda+i1], b[l*ldb:l*ldb+i1]
+				for i := i0; i < i1; i++ {
+					ccol[i] += acol[i]*temp1 + bcol[i]*temp2
+				}
+			}
+			ccol[j] = re(ccol[j])
+			continue
+		}
+		aj, bj := a[j*lda:j*lda+k], b[j*ldb:j*ldb+k]
+		for i := i0; i < i1; i++ {
+			var temp1, temp2 T
+			ai, bi := a[i*lda:i*lda+k], b[i*ldb:i*ldb+k]
+			for l := 0; l < k; l++ {
+				temp1 += op(ai[l]) * bj[l]
+				temp2 += op(bi[l]) * aj[l]
+			}
+			v := alpha*temp1 + op(alpha)*temp2
+			switch {
+			case i == j && beta == 0:
+				ccol[i] = re(v)
+			case i == j:
+				ccol[i] = re(v) + beta*re(ccol[i])
+			case beta == 0:
+				ccol[i] = v
+			default:
+				ccol[i] = v + beta*ccol[i]
+			}
+		}
+	}
+}
+
+// Trmm computes B = alpha*op(A)*B (side L) or B = alpha*B*op(A) (side R)
+// where A is triangular.
+func Trmm[T Scalar](side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda int, b []T, ldb int) {
+	if w := workers(triWork(side, m, n)); w > 1 {
+		parallelSide[T](side, m, n, w, func(i0, mb, j0, nb int) {
+			trmm(side, uplo, trans, diag, mb, nb, alpha, a, lda, b[i0+j0*ldb:], ldb)
+		})
+		return
+	}
+	trmm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// trmm computes the product of Trmm on the calling goroutine.
+func trmm[T Scalar](side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda int, b []T, ldb int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	if alpha == 0 {
+		scaleMat(m, n, 0, b, ldb)
+		return
+	}
+	left, notrans := side == SideL, trans == TransN
+	// op(A) is upper triangular when A is upper and not transposed or
+	// lower and transposed.
+	opUpper := uplo == UploU == notrans
+	nt := TransN
+	if left {
+		// B(I) = alpha*(op(A)(I,I)*B(I) + sum op(A)(I,J)*B(J)) over the
+		// blocks J on the nonzero side of the diagonal, which must not
+		// have been overwritten yet.
+		step := func(i0, ib int) {
+			bi := b[i0:]
+			triDiag(false, true, uplo, trans, diag, ib, n, a[i0+i0*lda:], lda, bi, ldb)
+			scaleMat(ib, n, alpha, bi, ldb)
+			switch {
+			case opUpper && i0+ib < m:
+				r0 := i0 + ib
+				if notrans {
+					gemm(nt, nt, ib, n, m-r0, alpha, a[i0+r0*lda:], lda, b[r0:], ldb, 1, bi, ldb)
+				} else {
+					gemm(trans, nt, ib, n, m-r0, alpha, a[r0+i0*lda:], lda, b[r0:], ldb, 1, bi, ldb)
+				}
+			case !opUpper && i0 > 0:
+				if notrans {
+					gemm(nt, nt, ib, n, i0, alpha, a[i0:], lda, b, ldb, 1, bi, ldb)
+				} else {
+					gemm(trans, nt, ib, n, i0, alpha, a[i0*lda:], lda, b, ldb, 1, bi, ldb)
+				}
+			}
+		}
+		forBlocks(m, opUpper, step)
+		return
+	}
+	// B(J) = alpha*(B(J)*op(A)(J,J) + sum B(I)*op(A)(I,J)).
+	step := func(j0, jb int) {
+		bj := b[j0*ldb:]
+		triDiag(false, false, uplo, trans, diag, m, jb, a[j0+j0*lda:], lda, bj, ldb)
+		scaleMat(m, jb, alpha, bj, ldb)
+		switch {
+		case opUpper && j0 > 0:
+			if notrans {
+				gemm(nt, nt, m, jb, j0, alpha, b, ldb, a[j0*lda:], lda, 1, bj, ldb)
+			} else {
+				gemm(nt, trans, m, jb, j0, alpha, b, ldb, a[j0:], lda, 1, bj, ldb)
+			}
+		case !opUpper && j0+jb < n:
+			c0 := j0 + jb
+			if notrans {
+				gemm(nt, nt, m, jb, n-c0, alpha, b[c0*ldb:], ldb, a[c0+j0*lda:], lda, 1, bj, ldb)
+			} else {
+				gemm(nt, trans, m, jb, n-c0, alpha, b[c0*ldb:], ldb, a[j0+c0*lda:], lda, 1, bj, ldb)
+			}
+		}
+	}
+	forBlocks(n, !opUpper, step)
+}
+
+// Trsm solves op(A)*X = alpha*B (side L) or X*op(A) = alpha*B (side R) where
+// A is triangular. B is overwritten by X.
+func Trsm[T Scalar](side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda int, b []T, ldb int) {
+	TrsmContext(context.Background(), side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// TrsmContext is Trsm that checks ctx between the diagonal blocks of A and
+// returns ctx.Err() once ctx is done. B is then partially overwritten: it
+// has been scaled by alpha, some blocks of it hold blocks of X and the
+// blocks still to be solved have been partly updated.
+func TrsmContext[T Scalar](ctx context.Context, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda int, b []T, ldb int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return trsmOn(ctx, workers(triWork(side, m, n)), side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// trsmOn solves the system of TrsmContext on up to w goroutines.
+func trsmOn[T Scalar](ctx context.Context, w int, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda int, b []T, ldb int) error {
+	if w > 1 {
+		var stopped atomic.Bool
+		parallelSide[T](side, m, n, w, func(i0, mb, j0, nb int) {
+			if trsm(ctx, side, uplo, trans, diag, mb, nb, alpha, a, lda, b[i0+j0*ldb:], ldb) != nil {
+				stopped.Store(true)
+			}
+		})
+		if stopped.Load() {
+			return ctx.Err()
+		}
+		return nil
+	}
+	return trsm(ctx, side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+}
+
+// trsm solves the system of Trsm on the calling goroutine, checking ctx
+// before each diagonal block.
+func trsm[T Scalar](ctx context.Context, side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha T, a []T, lda int, b []T, ldb int) error {
+	if m == 0 || n == 0 {
+		return nil
+	}
+	scaleMat(m, n, alpha, b, ldb)
+	if alpha == 0 {
+		return nil
+	}
+	var err error
+	checked := func(step func(i0, ib int)) func(i0, ib int) {
+		return func(i0, ib int) {
+			if err == nil {
+				err = ctx.Err()
+			}
+			if err == nil {
+				step(i0, ib)
+			}
+		}
+	}
+	left, notrans := side == SideL, trans == TransN
+	opUpper := uplo == UploU == notrans
+	nt := TransN
+	if left {
+		// Solve for X(I) and eliminate it from the blocks of B still to be
+		// solved.
+		step := func(i0, ib int) {
+			bi := b[i0:]
+			triDiag(true, true, uplo, trans, diag, ib, n, a[i0+i0*lda:], lda, bi, ldb)
+			switch {
+			case opUpper && i0 > 0:
+				if notrans {
+					gemm(nt, nt, i0, n, ib, -1, a[i0*lda:], lda, bi, ldb, 1, b, ldb)
+				} else {
+					gemm(trans, nt, i0, n, ib, -1, a[i0:], lda, bi, ldb, 1, b, ldb)
+				}
+			case !opUpper && i0+ib < m:
+				r0 := i0 + ib
+				if notrans {
+					gemm(nt, nt, m-r0, n, ib, -1, a[r0+i0*lda:], lda, bi, ldb, 1, b[r0:], ldb)
+				} else {
+					gemm(trans, nt, m-r0, n, ib, -1, a[i0+r0*lda:], lda, bi, ldb, 1, b[r0:], ldb)
+				}
+			}
+		}
+		forBlocks(m, !opUpper, checked(step))
+		return err
+	}
+	step := func(j0, jb int) {
+		bj := b[j0*ldb:]
+		triDiag(true, false, uplo, trans, diag, m, jb, a[j0+j0*lda:], lda, bj, ldb)
+		switch {
+		case opUpper && j0+jb < n:
+			c0 := j0 + jb
+			if notrans {
+				gemm(nt, nt, m, n-c0, jb, -1, bj, ldb, a[j0+c0*lda:], lda, 1, b[c0*ldb:], ldb)
+			} else {
+				gemm(nt, trans, m, n-c0, jb, -1, bj, ldb, a[c0+j0*lda:], lda, 1, b[c0*ldb:], ldb)
+			}
+		case !opUpper && j0 > 0:
+			if notrans {
+				gemm(nt, nt, m, j0, jb, -1, bj, ldb, a[j0:], lda, 1, b, ldb)
+			} else {
+				gemm(nt, trans, m, j0, jb, -1, bj, ldb, a[j0*lda:], lda, 1, b, ldb)
+			}
+		}
+	}
+	forBlocks(n, opUpper, checked(step))
+	return err
+}
+
+// forBlocks calls step for the consecutive blocks of at most blockSize
+// indices covering [0, n), in increasing order when forward is set and in
+// decreasing order otherwise.
+func forBlocks(n int, forward bool, step func(i0, ib int)) {
+	if forward {
+		for i0 := 0; i0 < n; i0 += blockSize {
+			step(i0, min(blockSize, n-i0))
+		}
+		return
+	}
+	for i0 := (n - 1) / blockSize * blockSize; i0 >= 0; i0 -= blockSize {
+		step(i0, min(blockSize, n-i0))
+	}
+}
+
+// triDiag computes B = op(A)*B or B = B*op(A) (B = inv(op(A))*B or
+// B = B*inv(op(A)) when solve is set) for an m×n block B and a triangular
+// diagonal block A, one column or row of B at a time with the Level 2
+// kernels.
+func triDiag[T Scalar](solve, left bool, uplo Uplo, trans Transpose, diag Diag, m, n int, a []T, lda int, b []T, ldb int) {
+	kernel := Trmv[T]
+	if solve {
+		kernel = Trsv[T]
+	}
+	if left {
+		for j := 0; j < n; j++ {
+			kernel(uplo, trans, diag, m, a, lda, b[j*ldb:], 1)
+		}
+		return
+	}
+	// Row i of B*op(A) is op(A)' times row i of B.
+	switch {
+	case trans == TransN:
+		for i := 0; i < m; i++ {
+			kernel(uplo, TransT, diag, n, a, lda, b[i:], ldb)
+		}
+	case trans == TransC:
+		// op(A)' is conjg(A), applied as conjg(A*conjg(x)).
+		for i := 0; i < m; i++ {
+			conjVec(n, b[i:], ldb)
+			kernel(uplo, TransN, diag, n, a, lda, b[i:], ldb)
+			conjVec(n, b[i:], ldb)
+		}
+	default:
+		for i := 0; i < m; i++ {
+			kernel(uplo, TransN, diag, n, a, lda, b[i:], ldb)
+		}
+	}
+}
+
+// conjVec conjugates the n elements of x in place.
+func conjVec[T Scalar](n int, x []T, incX int) {
+	if incX < 0 {
+		incX = -incX
+	}
+	for i := 0; i < n*incX; i += incX {
+		x[i] = Conj(x[i])
+	}
+}
+
+// triWork returns the number of multiply-adds of Trmm and Trsm.
+func triWork(side Side, m, n int) float64 {
+	order := m
+	if side == SideR {
+		order = n
+	}
+	return float64(m) * float64(n) * float64(order) / 2
+}
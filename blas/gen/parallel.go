@@ -0,0 +1,202 @@
+package gen
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	numThreads    atomic.Int64
+	deterministic atomic.Bool
+)
+
+// SetNumThreads sets the number of goroutines the Level 3 routines may use
+// and returns the previous setting. Zero, the default, uses
+// runtime.GOMAXPROCS(0) at the time of each call; one keeps every call on
+// the calling goroutine. Negative values are treated as zero.
+func SetNumThreads(n int) (previous int) {
+	return int(numThreads.Swap(int64(max(n, 0))))
+}
+
+// SetDeterministic sets whether the Level 3 routines must produce results
+// bitwise identical to a single-threaded call and returns the previous
+// setting. The output is always split among goroutines along the register
+// tiles of the Gemm micro-kernel, so that every element is computed by the
+// same operations as in a single-threaded call. When deterministic mode is
+// off, Gemm may in addition split the inner dimension of products whose C is
+// too small to keep every goroutine busy and sum the partial products, which
+// rounds differently.
+func SetDeterministic(on bool) (previous bool) {
+	return deterministic.Swap(on)
+}
+
+// parallelMin is the number of multiply-adds below which a call stays on
+// the calling goroutine. Every further goroutine is given at least as much
+// work.
+const parallelMin = 1 << 20
+
+// workers returns the number of goroutines to use for work multiply-adds.
+func workers(work float64) int {
+	n := int(numThreads.Load())
+	if n == 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	return max(1, min(n, int(work/parallelMin)))
+}
+
+// parallel calls f(i) for every i in [0, n) on up to w goroutines, the
+// calling goroutine included, handing out the indices in increasing order.
+func parallel(n, w int, f func(i int)) {
+	w = min(w, n)
+	var next atomic.Int64
+	run := func() {
+		for {
+			i := int(next.Add(1)) - 1
+			if i >= n {
+				return
+			}
+			f(i)
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(w - 1)
+	for g := 1; g < w; g++ {
+		go func() {
+			defer wg.Done()
+			run()
+		}()
+	}
+	run()
+	wg.Wait()
+}
+
+// splitAt returns the start of part p of the parts parts of [0, total), with
+// every boundary but the last on a multiple of align.
+func splitAt(p, parts, total, align int) int {
+	units := (total + align - 1) / align
+	return min(total, units*p/parts*align)
+}
+
+// parallelRanges splits [0, total) into up to w ranges whose boundaries are
+// multiples of align and calls f on each range in parallel.
+func parallelRanges(total, align, w int, f func(i0, i1 int)) {
+	parts := min(w, (total+align-1)/align)
+	parallel(parts, parts, func(p int) {
+		f(splitAt(p, parts, total, align), splitAt(p+1, parts, total, align))
+	})
+}
+
+// parallelSide calls f(i0, mb, j0, nb) in parallel on the mb×nb blocks of
+// an m×n matrix B starting at (i0, j0) that a routine multiplying B by a
+// matrix on the given side can process independently: groups of columns for
+// side L and groups of rows for side R. The boundaries fall on the
+// micro-kernel tiles.
+func parallelSide[T Scalar](side Side, m, n, w int, f func(i0, mb, j0, nb int)) {
+	uk := gemmMicroKernel[T]()
+	if side == SideL {
+		parallelRanges(n, uk.nr, w, func(j0, j1 int) {
+			f(0, m, j0, j1-j0)
+		})
+		return
+	}
+	parallelRanges(m, uk.mr, w, func(i0, i1 int) {
+		f(i0, i1-i0, 0, n)
+	})
+}
+
+// gemmParallel computes C += alpha*op(A)*op(B) with gemmPacked on w
+// goroutines. C is split into a grid of blocks whose boundaries fall on the
+// micro-kernel tiles. Outside deterministic mode, a C with fewer tiles than
+// goroutines is computed instead as a sum of products over parts of k. Like
+// gemmPacked it returns ctx.Err() once ctx is done.
+func gemmParallel[T Scalar](ctx context.Context, w int, ta, tb, ca, cb bool, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, c []T, ldc int) error {
+	uk := gemmMicroKernel[T]()
+	mt, nt := (m+uk.mr-1)/uk.mr, (n+uk.nr-1)/uk.nr
+	if mt*nt < w && !deterministic.Load() {
+		return gemmSplitK(ctx, min(w, k), ta, tb, ca, cb, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+	}
+	// Choose the grid of at most w blocks with the most blocks and, among
+	// those, the squarest blocks.
+	pr, pc := 1, 1
+	for r := 1; r <= min(w, mt); r++ {
+		c := min(w/r, nt)
+		if r*c > pr*pc || r*c == pr*pc && squareness(m, n, r, c) < squareness(m, n, pr, pc) {
+			pr, pc = r, c
+		}
+	}
+	var stopped atomic.Bool
+	parallel(pr*pc, pr*pc, func(p int) {
+		r, s := p%pr, p/pr
+		i0, i1 := splitAt(r, pr, m, uk.mr), splitAt(r+1, pr, m, uk.mr)
+		j0, j1 := splitAt(s, pc, n, uk.nr), splitAt(s+1, pc, n, uk.nr)
+		if i0 == i1 || j0 == j1 {
+			return
+		}
+		offA, offB := i0, j0*ldb
+		if ta {
+			offA = i0 * lda
+		}
+		if tb {
+			offB = j0
+		}
+		if gemmPacked(ctx, ta, tb, ca, cb, i1-i0, j1-j0, k, alpha, a[offA:], lda, b[offB:], ldb, c[i0+j0*ldc:], ldc) != nil {
+			stopped.Store(true)
+		}
+	})
+	if stopped.Load() {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// squareness measures how far the blocks of an m×n matrix split into r×c
+// blocks are from square.
+func squareness(m, n, r, c int) float64 {
+	q := float64(m*c) / float64(n*r)
+	return max(q, 1/q)
+}
+
+// gemmSplitK computes C += alpha*op(A)*op(B) on w goroutines, each of which
+// multiplies a part of the k columns of op(A) by the matching rows of op(B).
+// The first part is added to C directly, the others are accumulated in
+// buffers that are added to C in order once all parts are done. When ctx is
+// done before then, the buffers are dropped and ctx.Err() is returned.
+func gemmSplitK[T Scalar](ctx context.Context, w int, ta, tb, ca, cb bool, m, n, k int, alpha T, a []T, lda int, b []T, ldb int, c []T, ldc int) error {
+	buf := getBuf[T]((w - 1) * m * n)
+	defer putBuf(buf)
+	parts := *buf
+	clear(parts)
+	var stopped atomic.Bool
+	parallel(w, w, func(p int) {
+		l0, l1 := splitAt(p, w, k, 1), splitAt(p+1, w, k, 1)
+		offA, offB := l0*lda, l0
+		if ta {
+			offA = l0
+		}
+		if tb {
+			offB = l0 * ldb
+		}
+		cp, ldcp := c, ldc
+		if p > 0 {
+			cp, ldcp = parts[(p-1)*m*n:p*m*n], m
+		}
+		if gemmPacked(ctx, ta, tb, ca, cb, m, n, l1-l0, alpha, a[offA:], lda, b[offB:], ldb, cp, ldcp) != nil {
+			stopped.Store(true)
+		}
+	})
+	if stopped.Load() {
+		return ctx.Err()
+	}
+	for p := 1; p < w; p++ {
+		part := parts[(p-1)*m*n : p*m*n]
+		for j := 0; j < n; j++ {
+			ccol := c[j*ldc : j*ldc+m]
+			for i, v := range part[j*m : j*m+m] {
+				ccol[i] += v
+			}
+		}
+	}
+	return nil
+}
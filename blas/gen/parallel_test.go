@@ -0,0 +1,139 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// threadCounts are the numbers of goroutines the tests of this file split
+// their calls among; the matrices are large enough for every one of them to
+// be used.
+var threadCounts = []int{1, 2, 3, 4, 7}
+
+func TestDeterministic(t *testing.T) {
+	testDeterministic[float32](t)
+	testDeterministic[float64](t)
+	testDeterministic[complex64](t)
+	testDeterministic[complex128](t)
+}
+
+// testDeterministic checks that in deterministic mode Gemm and Trsm return
+// the same bits whatever the number of goroutines, including for a product
+// with a C too small to be split, which would otherwise be split along k.
+func testDeterministic[T Scalar](t *testing.T) {
+	defer SetNumThreads(SetNumThreads(1))
+	defer SetDeterministic(SetDeterministic(true))
+	uk := gemmMicroKernel[T]()
+	const n = 200
+	a, b := benchVec[T](n*n), benchVec[T](n*n)
+	for i := 0; i < n; i++ {
+		a[i+i*n] += fromReal[T](n)
+	}
+	thin := 1 << 18
+	at, bt := benchVec[T](uk.mr*thin), benchVec[T](thin*uk.nr)
+
+	type call struct {
+		name string
+		size int
+		f    func(c []T)
+	}
+	calls := []call{
+		{"Gemm NN", n * n, func(c []T) { Gemm(TransN, TransN, n, n, n, 1, a, n, b, n, 0.5, c, n) }},
+		{"Gemm CT", n * n, func(c []T) { Gemm(TransC, TransT, n, n, n, 1, a, n, b, n, 0.5, c, n) }},
+		{"Gemm thin", uk.mr * uk.nr, func(c []T) {
+			Gemm(TransN, TransN, uk.mr, uk.nr, thin, 1, at, uk.mr, bt, thin, 0.5, c, uk.mr)
+		}},
+	}
+	for _, side := range []Side{SideL, SideR} {
+		for _, op := range []struct {
+			uplo  Uplo
+			trans Transpose
+		}{{UploU, TransN}, {UploL, TransC}} {
+			calls = append(calls, call{fmt.Sprintf("Trsm %c%c%c", side, op.uplo, op.trans), n * n, func(c []T) {
+				Trsm(side, op.uplo, op.trans, DiagN, n, n, 2, a, n, c, n)
+			}})
+		}
+	}
+
+	for _, c := range calls {
+		var want []T
+		for _, w := range threadCounts {
+			SetNumThreads(w)
+			got := benchVec[T](c.size)
+			c.f(got)
+			if want == nil {
+				want = got
+				continue
+			}
+			for i := range got {
+				if !sameBits(got[i], want[i]) {
+					t.Errorf("%T %s with %d threads: element %d is %v, want %v with 1 thread", got[i], c.name, w, i, got[i], want[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+// sameBits reports whether x and y have the same representation.
+func sameBits[T Scalar](x, y T) bool {
+	switch x := any(x).(type) {
+	case float32:
+		return math.Float32bits(x) == math.Float32bits(any(y).(float32))
+	case float64:
+		return math.Float64bits(x) == math.Float64bits(any(y).(float64))
+	case complex64:
+		y := any(y).(complex64)
+		return math.Float32bits(real(x)) == math.Float32bits(real(y)) &&
+			math.Float32bits(imag(x)) == math.Float32bits(imag(y))
+	}
+	x2, y2 := any(x).(complex128), any(y).(complex128)
+	return math.Float64bits(real(x2)) == math.Float64bits(real(y2)) &&
+		math.Float64bits(imag(x2)) == math.Float64bits(imag(y2))
+}
+
+func TestGemmSplitK(t *testing.T) {
+	testGemmSplitK[float32](t, 0x1p-23)
+	testGemmSplitK[float64](t, 0x1p-52)
+	testGemmSplitK[complex64](t, 0x1p-23)
+	testGemmSplitK[complex128](t, 0x1p-52)
+}
+
+// testGemmSplitK checks that outside deterministic mode a product whose C
+// has fewer micro-kernel tiles than goroutines, which Gemm splits along k,
+// agrees with naiveGemm. The elements of A and B are positive, so the sums
+// have no cancellation and the error of every order of summation is within
+// k roundings.
+func testGemmSplitK[T Scalar](t *testing.T, eps float64) {
+	defer SetNumThreads(SetNumThreads(1))
+	defer SetDeterministic(SetDeterministic(false))
+	uk := gemmMicroKernel[T]()
+	m, n, k := uk.mr+1, uk.nr, 1<<18
+	for _, transA := range []Transpose{TransN, TransC} {
+		for _, transB := range []Transpose{TransN, TransT} {
+			a, b := benchVec[T](m*k), benchVec[T](k*n)
+			lda, ldb := m, k
+			if transA != TransN {
+				lda = k
+			}
+			if transB != TransN {
+				ldb = n
+			}
+			want := benchVec[T](m * n)
+			c := append([]T(nil), want...)
+			naiveGemm(transA != TransN, transB != TransN, transA == TransC, transB == TransC, m, n, k, 1, a, lda, b, ldb, want, m)
+			for _, w := range threadCounts {
+				SetNumThreads(w)
+				got := append([]T(nil), c...)
+				Gemm(transA, transB, m, n, k, 1, a, lda, b, ldb, 1, got, m)
+				for i := range got {
+					if abs1(got[i]-want[i]) > eps*float64(k)*abs1(want[i]) {
+						t.Errorf("%T %c%c with %d threads: element %d is %v, want %v", got[i], transA, transB, w, i, got[i], want[i])
+						break
+					}
+				}
+			}
+		}
+	}
+}
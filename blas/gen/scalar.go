@@ -0,0 +1,175 @@
+package gen
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Float is the set of real element types handled by the generic kernels.
+type Float interface {
+	float32 | float64
+}
+
+// Complex is the set of complex element types handled by the generic kernels.
+type Complex interface {
+	complex64 | complex128
+}
+
+// Scalar is the set of all element types handled by the generic kernels.
+type Scalar interface {
+	Float | Complex
+}
+
+// Conj returns the complex conjugate of x. It is the identity for real types.
+//
+// Conj is called in the inner loops of the conjugated kernels, so it tells
+// the types apart by their size and alignment, which are constant for each
+// instantiation, rather than by a type switch, which is evaluated on every
+// call and doubles the cost of ZDOTC. complex128 is the only type of 16
+// bytes, and where float64 is 8-byte aligned complex64 is the only type of 8
+// bytes with a smaller alignment.
+func Conj[T Scalar](x T) T {
+	const f64Align = unsafe.Alignof(float64(0))
+	switch {
+	case unsafe.Sizeof(x) == 16:
+		p := (*[2]float64)(unsafe.Pointer(&x))
+		p[1] = -p[1]
+	case unsafe.Sizeof(x) == 8 && unsafe.Alignof(x) < f64Align:
+		p := (*[2]float32)(unsafe.Pointer(&x))
+		p[1] = -p[1]
+	case unsafe.Sizeof(x) == 8 && f64Align < 8:
+		// float64 and complex64 cannot be told apart on 32-bit systems.
+		if v, ok := any(x).(complex64); ok {
+			v = complex(real(v), -imag(v))
+			return *(*T)(unsafe.Pointer(&v))
+		}
+	}
+	return x
+}
+
+// realPart returns x with its imaginary part cleared. It is the identity for
+// real types.
+func realPart[T Scalar](x T) T {
+	switch p := any(&x).(type) {
+	case *complex64:
+		*p = complex(real(*p), 0)
+	case *complex128:
+		*p = complex(real(*p), 0)
+	}
+	return x
+}
+
+// fromReal converts the real value r to T.
+func fromReal[T Scalar](r float64) (x T) {
+	switch p := any(&x).(type) {
+	case *float32:
+		*p = float32(r)
+	case *float64:
+		*p = r
+	case *complex64:
+		*p = complex(float32(r), 0)
+	case *complex128:
+		*p = complex(r, 0)
+	}
+	return x
+}
+
+// fromParts converts the complex value re + i*im to T. The imaginary part is
+// dropped for real types.
+func fromParts[T Scalar](re, im float64) (x T) {
+	switch p := any(&x).(type) {
+	case *complex64:
+		*p = complex(float32(re), float32(im))
+	case *complex128:
+		*p = complex(re, im)
+	default:
+		x = fromReal[T](re)
+	}
+	return x
+}
+
+// abs1 returns |x| for real types and |re(x)|+|im(x)| for complex types,
+// evaluated in the precision of T.
+func abs1[T Scalar](x T) float64 {
+	switch v := any(x).(type) {
+	case float32:
+		return float64(absf(v))
+	case float64:
+		return absf(v)
+	case complex64:
+		return float64(absf(real(v)) + absf(imag(v)))
+	case complex128:
+		return absf(real(v)) + absf(imag(v))
+	}
+	panic("unreachable")
+}
+
+// absf returns the absolute value of x.
+func absf[R Float](x R) R {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// sqrtf returns the square root of x. The float64 square root is correctly
+// rounded for float32 arguments as well.
+func sqrtf[R Float](x R) R {
+	return R(math.Sqrt(float64(x)))
+}
+
+// signf returns |a| with the sign of b, as the Fortran SIGN intrinsic.
+func signf[R Float](a, b R) R {
+	if b >= 0 {
+		return absf(a)
+	}
+	return -absf(a)
+}
+
+// Machine constants used by the overflow-safe routines, as defined by
+// la_constants in LAPACK 3.10.
+type machine[R Float] struct {
+	safmin, safmax R // smallest normal number and its reciprocal
+	rtmin, rtmax   R // sqrt(safmin) and sqrt(safmax/2)
+	huge           R // largest finite number
+
+	// Blue's scaling thresholds and factors for the Euclidean norm.
+	tsml, tbig, ssml, sbig R
+}
+
+var (
+	machine32 = machine[float32]{
+		safmin: 0x1p-126,
+		safmax: 0x1p126,
+		rtmin:  0x1p-63,
+		rtmax:  float32(math.Sqrt(0x1p125)),
+		huge:   math.MaxFloat32,
+		tsml:   0x1p-63,
+		tbig:   0x1p52,
+		ssml:   0x1p75,
+		sbig:   0x1p-76,
+	}
+	machine64 = machine[float64]{
+		safmin: 0x1p-1022,
+		safmax: 0x1p1022,
+		rtmin:  0x1p-511,
+		rtmax:  math.Sqrt(0x1p1021),
+		huge:   math.MaxFloat64,
+		tsml:   0x1p-511,
+		tbig:   0x1p486,
+		ssml:   0x1p537,
+		sbig:   0x1p-538,
+	}
+)
+
+// consts returns the machine constants for R.
+func consts[R Float]() machine[R] {
+	var m machine[R]
+	switch p := any(&m).(type) {
+	case *machine[float32]:
+		*p = machine32
+	case *machine[float64]:
+		*p = machine64
+	}
+	return m
+}
@@ -0,0 +1,597 @@
+package blas
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// The expected values of these tables are worked out by hand from the
+// Netlib reference routines. A negative increment walks the vector backwards
+// from element (n-1)*|inc|, except in the routines with a single vector
+// argument (xSCAL, xASUM, IxAMAX), which return at once, as Netlib does.
+
+func sameF64(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameC128(a, b []complex128, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if cmplx.Abs(a[i]-b[i]) > tol*math.Max(1, cmplx.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameF32(a, b []float32, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(float64(a[i]-b[i])) > tol*math.Max(1, math.Abs(float64(b[i]))) {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	tol64 = 1e-15
+	tol32 = 1e-6
+)
+
+func TestDrotg(t *testing.T) {
+	for _, test := range []struct {
+		a, b, c, s float64
+	}{
+		{a: 3, b: 4, c: 0.6, s: 0.8},
+		{a: -3, b: 4, c: -0.6, s: 0.8},
+		{a: 4, b: -3, c: 0.8, s: -0.6},
+		{a: -3, b: -4, c: 0.6, s: 0.8},
+		{a: 5, b: 0, c: 1, s: 0},
+		{a: 0, b: 0, c: 1, s: 0},
+		{a: 0, b: 2, c: 0, s: 1},
+		{a: 0, b: -2, c: 0, s: 1},
+	} {
+		c, s := Reference{}.DROTG(test.a, test.b)
+		if !sameF64([]float64{c, s}, []float64{test.c, test.s}, tol64) {
+			t.Errorf("DROTG(%v,%v) = %v,%v, want %v,%v", test.a, test.b, c, s, test.c, test.s)
+		}
+		c32, s32 := Reference{}.SROTG(float32(test.a), float32(test.b))
+		if !sameF32([]float32{c32, s32}, []float32{float32(test.c), float32(test.s)}, tol32) {
+			t.Errorf("SROTG(%v,%v) = %v,%v, want %v,%v", test.a, test.b, c32, s32, test.c, test.s)
+		}
+	}
+}
+
+func TestZrotg(t *testing.T) {
+	for _, test := range []struct {
+		a, b complex128
+		c    float64
+		s    complex128
+	}{
+		{a: 3, b: 4, c: 0.6, s: 0.8},
+		{a: 3i, b: 4, c: 0.6, s: 0.8i},
+		{a: 0, b: 4i, c: 0, s: -1i},
+		{a: 2 + 1i, b: 0, c: 1, s: 0},
+	} {
+		c, s := Reference{}.ZROTG(test.a, test.b)
+		if math.Abs(c-test.c) > tol64 || cmplx.Abs(s-test.s) > tol64 {
+			t.Errorf("ZROTG(%v,%v) = %v,%v, want %v,%v", test.a, test.b, c, s, test.c, test.s)
+		}
+		c64, s64 := Reference{}.CROTG(complex64(test.a), complex64(test.b))
+		if cmplx.Abs(complex128(c64)-complex(test.c, 0)) > tol32 || cmplx.Abs(complex128(s64)-test.s) > tol32 {
+			t.Errorf("CROTG(%v,%v) = %v,%v, want %v,%v", test.a, test.b, c64, s64, test.c, test.s)
+		}
+	}
+}
+
+func TestDvec2(t *testing.T) {
+	// Routines that update x and y.
+	for _, test := range []struct {
+		name         string
+		n            int
+		x            []float64
+		incX         int
+		y            []float64
+		incY         int
+		wantX, wantY []float64
+	}{
+		{
+			name: "DSWAP", n: 3,
+			x: []float64{1, 2, 3, 4, 5}, incX: 2,
+			y: []float64{10, 20, 30}, incY: -1,
+			wantX: []float64{30, 2, 20, 4, 10}, wantY: []float64{5, 3, 1},
+		},
+		{
+			name: "DSWAP", n: 0,
+			x: []float64{1}, incX: 1,
+			y: []float64{2}, incY: 1,
+			wantX: []float64{1}, wantY: []float64{2},
+		},
+		{
+			name: "DCOPY", n: 3,
+			x: []float64{1, 2, 3}, incX: -1,
+			y: []float64{0, 9, 0, 9, 0}, incY: 2,
+			wantX: []float64{1, 2, 3}, wantY: []float64{3, 9, 2, 9, 1},
+		},
+		{
+			name: "DAXPY", n: 3,
+			x: []float64{1, 2, 3}, incX: -1,
+			y: []float64{10, 20, 30}, incY: 1,
+			wantX: []float64{1, 2, 3}, wantY: []float64{16, 24, 32},
+		},
+		{
+			name: "DAXPY", n: 3,
+			x: []float64{1, 2, 3}, incX: -1,
+			y: []float64{10, 20, 30}, incY: -1,
+			wantX: []float64{1, 2, 3}, wantY: []float64{12, 24, 36},
+		},
+		{
+			name: "DAXPY", n: 2,
+			x: []float64{1, 0, 2}, incX: 2,
+			y: []float64{10, 0, 0, 20}, incY: -3,
+			wantX: []float64{1, 0, 2}, wantY: []float64{14, 0, 0, 22},
+		},
+		{
+			// x' = c*x + s*y, y' = c*y - s*x over the pairs
+			// (x[0],y[1]) and (x[1],y[0]).
+			name: "DROT", n: 2,
+			x: []float64{1, 2}, incX: 1,
+			y: []float64{3, 4}, incY: -1,
+			wantX: []float64{3.8, 3.6}, wantY: []float64{0.2, 1.6},
+		},
+	} {
+		x := append([]float64(nil), test.x...)
+		y := append([]float64(nil), test.y...)
+		switch test.name {
+		case "DSWAP":
+			Reference{}.DSWAP(test.n, x, test.incX, y, test.incY)
+		case "DCOPY":
+			Reference{}.DCOPY(test.n, x, test.incX, y, test.incY)
+		case "DAXPY":
+			Reference{}.DAXPY(test.n, 2, x, test.incX, y, test.incY)
+		case "DROT":
+			Reference{}.DROT(test.n, x, test.incX, y, test.incY, 0.6, 0.8)
+		}
+		if !sameF64(x, test.wantX, tol64) || !sameF64(y, test.wantY, tol64) {
+			t.Errorf("%s n=%d incX=%d incY=%d: x=%v y=%v, want x=%v y=%v",
+				test.name, test.n, test.incX, test.incY, x, y, test.wantX, test.wantY)
+		}
+
+		// The single precision routines follow the same code.
+		x32, y32 := f32s(test.x), f32s(test.y)
+		switch test.name {
+		case "DSWAP":
+			Reference{}.SSWAP(test.n, x32, test.incX, y32, test.incY)
+		case "DCOPY":
+			Reference{}.SCOPY(test.n, x32, test.incX, y32, test.incY)
+		case "DAXPY":
+			Reference{}.SAXPY(test.n, 2, x32, test.incX, y32, test.incY)
+		case "DROT":
+			Reference{}.SROT(test.n, x32, test.incX, y32, test.incY, 0.6, 0.8)
+		}
+		if !sameF32(x32, f32s(test.wantX), tol32) || !sameF32(y32, f32s(test.wantY), tol32) {
+			t.Errorf("S%s n=%d incX=%d incY=%d: x=%v y=%v, want x=%v y=%v",
+				test.name[1:], test.n, test.incX, test.incY, x32, y32, test.wantX, test.wantY)
+		}
+	}
+}
+
+func f32s(x []float64) []float32 {
+	r := make([]float32, len(x))
+	for i, v := range x {
+		r[i] = float32(v)
+	}
+	return r
+}
+
+func TestDdot(t *testing.T) {
+	for _, test := range []struct {
+		n    int
+		x    []float64
+		incX int
+		y    []float64
+		incY int
+		want float64
+	}{
+		{n: 3, x: []float64{1, 2, 3}, incX: 1, y: []float64{4, 5, 6}, incY: 1, want: 32},
+		{n: 3, x: []float64{1, 2, 3}, incX: 1, y: []float64{4, 5, 6}, incY: -1, want: 28},
+		{n: 3, x: []float64{1, 2, 3}, incX: -1, y: []float64{4, 5, 6}, incY: -1, want: 32},
+		{n: 2, x: []float64{1, 9, 2}, incX: -2, y: []float64{4, 5}, incY: 1, want: 13},
+		{n: 0, x: nil, incX: 1, y: nil, incY: 1, want: 0},
+	} {
+		if got := (Reference{}).DDOT(test.n, test.x, test.incX, test.y, test.incY); got != test.want {
+			t.Errorf("DDOT n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.want)
+		}
+		x, y := f32s(test.x), f32s(test.y)
+		if got := (Reference{}).SDOT(test.n, x, test.incX, y, test.incY); got != float32(test.want) {
+			t.Errorf("SDOT n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.want)
+		}
+		if got := (Reference{}).DSDOT(test.n, x, test.incX, y, test.incY); got != test.want {
+			t.Errorf("DSDOT n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.want)
+		}
+		if got := (Reference{}).SDSDOT(test.n, 0.5, x, test.incX, y, test.incY); got != float32(test.want+0.5) {
+			t.Errorf("SDSDOT n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.want+0.5)
+		}
+	}
+}
+
+func TestDvec1(t *testing.T) {
+	for _, test := range []struct {
+		n     int
+		x     []float64
+		incX  int
+		scal  []float64 // x after DSCAL with alpha = 2
+		rscl  []float64 // x after DRSCL with alpha = 4
+		nrm2  float64
+		asum  float64
+		iamax int
+	}{
+		{
+			n: 3, x: []float64{1, -2, 3}, incX: 1,
+			scal: []float64{2, -4, 6}, rscl: []float64{0.25, -0.5, 0.75},
+			nrm2: math.Sqrt(14), asum: 6, iamax: 2,
+		},
+		{
+			n: 2, x: []float64{3, 1, -4, 1}, incX: 2,
+			scal: []float64{6, 1, -8, 1}, rscl: []float64{0.75, 1, -1, 1},
+			nrm2: 5, asum: 7, iamax: 1,
+		},
+		{
+			// The first of equal maxima is returned.
+			n: 4, x: []float64{1, -5, 5, 2}, incX: 1,
+			scal: []float64{2, -10, 10, 4}, rscl: []float64{0.25, -1.25, 1.25, 0.5},
+			nrm2: math.Sqrt(55), asum: 13, iamax: 1,
+		},
+		{
+			// Netlib xNRM2 accepts a negative increment; the other
+			// routines do nothing.
+			n: 2, x: []float64{3, 1, -4, 1}, incX: -2,
+			scal: []float64{3, 1, -4, 1}, rscl: []float64{3, 1, -4, 1},
+			nrm2: 5, asum: 0, iamax: -1,
+		},
+		{
+			n: 0, x: nil, incX: 1,
+			scal: nil, rscl: nil,
+			nrm2: 0, asum: 0, iamax: -1,
+		},
+	} {
+		x := append([]float64(nil), test.x...)
+		Reference{}.DSCAL(test.n, 2, x, test.incX)
+		if !sameF64(x, test.scal, 0) {
+			t.Errorf("DSCAL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x, test.scal)
+		}
+		x = append([]float64(nil), test.x...)
+		Reference{}.DRSCL(test.n, 4, x, test.incX)
+		if !sameF64(x, test.rscl, 0) {
+			t.Errorf("DRSCL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x, test.rscl)
+		}
+		if got := (Reference{}).DNRM2(test.n, test.x, test.incX); math.Abs(got-test.nrm2) > tol64*test.nrm2 {
+			t.Errorf("DNRM2 n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.nrm2)
+		}
+		if got := (Reference{}).DASUM(test.n, test.x, test.incX); got != test.asum {
+			t.Errorf("DASUM n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.asum)
+		}
+		if got := (Reference{}).IDAMAX(test.n, test.x, test.incX); got != test.iamax {
+			t.Errorf("IDAMAX n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.iamax)
+		}
+
+		x32 := f32s(test.x)
+		Reference{}.SSCAL(test.n, 2, x32, test.incX)
+		if !sameF32(x32, f32s(test.scal), 0) {
+			t.Errorf("SSCAL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x32, test.scal)
+		}
+		if got := (Reference{}).SNRM2(test.n, f32s(test.x), test.incX); math.Abs(float64(got)-test.nrm2) > tol32*test.nrm2 {
+			t.Errorf("SNRM2 n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.nrm2)
+		}
+		if got := (Reference{}).SASUM(test.n, f32s(test.x), test.incX); got != float32(test.asum) {
+			t.Errorf("SASUM n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.asum)
+		}
+		if got := (Reference{}).ISAMAX(test.n, f32s(test.x), test.incX); got != test.iamax {
+			t.Errorf("ISAMAX n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.iamax)
+		}
+	}
+}
+
+func c64s(x []complex128) []complex64 {
+	r := make([]complex64, len(x))
+	for i, v := range x {
+		r[i] = complex64(v)
+	}
+	return r
+}
+
+func c128s(x []complex64) []complex128 {
+	r := make([]complex128, len(x))
+	for i, v := range x {
+		r[i] = complex128(v)
+	}
+	return r
+}
+
+func TestZvec2(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		n            int
+		x            []complex128
+		incX         int
+		y            []complex128
+		incY         int
+		wantX, wantY []complex128
+	}{
+		{
+			name: "ZSWAP", n: 2,
+			x: []complex128{1, 2}, incX: -1,
+			y: []complex128{1i, 2i}, incY: 1,
+			wantX: []complex128{2i, 1i}, wantY: []complex128{2, 1},
+		},
+		{
+			name: "ZCOPY", n: 2,
+			x: []complex128{1 + 1i, 2}, incX: 1,
+			y: []complex128{0, 7, 0}, incY: -2,
+			wantX: []complex128{1 + 1i, 2}, wantY: []complex128{2, 7, 1 + 1i},
+		},
+		{
+			// alpha = i: y[0] += i*x[1], y[1] += i*x[0].
+			name: "ZAXPY", n: 2,
+			x: []complex128{1, 2i}, incX: -1,
+			y: []complex128{1, 1}, incY: 1,
+			wantX: []complex128{1, 2i}, wantY: []complex128{-1, 1 + 1i},
+		},
+		{
+			// c = 0.6, s = 0.8i: x' = c*x + s*y, y' = c*y - conj(s)*x.
+			name: "ZROT", n: 2,
+			x: []complex128{1, 0, 2}, incX: -2,
+			y: []complex128{1i, 0}, incY: 1,
+			wantX: []complex128{0.6, 0, 0.4}, wantY: []complex128{2.2i, 0.8i},
+		},
+		{
+			// c = 0.6, s = 0.8: x' = c*x + s*y, y' = c*y - s*x.
+			name: "ZDROT", n: 1,
+			x: []complex128{1}, incX: 1,
+			y: []complex128{1i}, incY: -1,
+			wantX: []complex128{0.6 + 0.8i}, wantY: []complex128{-0.8 + 0.6i},
+		},
+	} {
+		x := append([]complex128(nil), test.x...)
+		y := append([]complex128(nil), test.y...)
+		x64, y64 := c64s(test.x), c64s(test.y)
+		switch test.name {
+		case "ZSWAP":
+			Reference{}.ZSWAP(test.n, x, test.incX, y, test.incY)
+			Reference{}.CSWAP(test.n, x64, test.incX, y64, test.incY)
+		case "ZCOPY":
+			Reference{}.ZCOPY(test.n, x, test.incX, y, test.incY)
+			Reference{}.CCOPY(test.n, x64, test.incX, y64, test.incY)
+		case "ZAXPY":
+			Reference{}.ZAXPY(test.n, 1i, x, test.incX, y, test.incY)
+			Reference{}.CAXPY(test.n, 1i, x64, test.incX, y64, test.incY)
+		case "ZROT":
+			Reference{}.ZROT(test.n, x, test.incX, y, test.incY, 0.6, 0.8i)
+			Reference{}.CROT(test.n, x64, test.incX, y64, test.incY, 0.6, 0.8i)
+		case "ZDROT":
+			Reference{}.ZDROT(test.n, x, test.incX, y, test.incY, 0.6, 0.8)
+			Reference{}.CSROT(test.n, x64, test.incX, y64, test.incY, 0.6, 0.8)
+		}
+		if !sameC128(x, test.wantX, tol64) || !sameC128(y, test.wantY, tol64) {
+			t.Errorf("%s n=%d incX=%d incY=%d: x=%v y=%v, want x=%v y=%v",
+				test.name, test.n, test.incX, test.incY, x, y, test.wantX, test.wantY)
+		}
+		if !sameC128(c128s(x64), test.wantX, tol32) || !sameC128(c128s(y64), test.wantY, tol32) {
+			t.Errorf("complex64 %s n=%d incX=%d incY=%d: x=%v y=%v, want x=%v y=%v",
+				test.name, test.n, test.incX, test.incY, x64, y64, test.wantX, test.wantY)
+		}
+	}
+}
+
+func TestZdot(t *testing.T) {
+	for _, test := range []struct {
+		n          int
+		x          []complex128
+		incX       int
+		y          []complex128
+		incY       int
+		dotu, dotc complex128
+	}{
+		{n: 2, x: []complex128{1 + 1i, 2}, incX: 1, y: []complex128{3, 1i}, incY: 1, dotu: 3 + 5i, dotc: 3 - 1i},
+		{n: 2, x: []complex128{1 + 1i, 2}, incX: 1, y: []complex128{3, 1i}, incY: -1, dotu: 5 + 1i, dotc: 7 + 1i},
+		{n: 2, x: []complex128{1 + 1i, 0, 2}, incX: -2, y: []complex128{3, 1i}, incY: 1, dotu: 5 + 1i, dotc: 7 + 1i},
+		{n: 0, dotu: 0, dotc: 0},
+	} {
+		if got := (Reference{}).ZDOTU(test.n, test.x, test.incX, test.y, test.incY); got != test.dotu {
+			t.Errorf("ZDOTU n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.dotu)
+		}
+		if got := (Reference{}).ZDOTC(test.n, test.x, test.incX, test.y, test.incY); got != test.dotc {
+			t.Errorf("ZDOTC n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.dotc)
+		}
+		x, y := c64s(test.x), c64s(test.y)
+		if got := (Reference{}).CDOTU(test.n, x, test.incX, y, test.incY); got != complex64(test.dotu) {
+			t.Errorf("CDOTU n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.dotu)
+		}
+		if got := (Reference{}).CDOTC(test.n, x, test.incX, y, test.incY); got != complex64(test.dotc) {
+			t.Errorf("CDOTC n=%d incX=%d incY=%d = %v, want %v", test.n, test.incX, test.incY, got, test.dotc)
+		}
+	}
+}
+
+func TestZvec1(t *testing.T) {
+	for _, test := range []struct {
+		n     int
+		x     []complex128
+		incX  int
+		scal  []complex128 // x after ZSCAL with alpha = i
+		dscal []complex128 // x after ZDSCAL with alpha = 2
+		rscl  []complex128 // x after ZRSCL with alpha = 2i
+		nrm2  float64
+		asum  float64
+		iamax int
+	}{
+		{
+			n: 2, x: []complex128{1 + 2i, 3}, incX: 1,
+			scal: []complex128{-2 + 1i, 3i}, dscal: []complex128{2 + 4i, 6},
+			rscl: []complex128{1 - 0.5i, -1.5i},
+			nrm2: math.Sqrt(14), asum: 6, iamax: 0,
+		},
+		{
+			// IxAMAX compares |re|+|im|, not the modulus.
+			n: 3, x: []complex128{1 + 1i, -2, 1 - 3i}, incX: 1,
+			scal: []complex128{-1 + 1i, -2i, 3 + 1i}, dscal: []complex128{2 + 2i, -4, 2 - 6i},
+			rscl: []complex128{0.5 - 0.5i, 1i, -1.5 - 0.5i},
+			nrm2: 4, asum: 8, iamax: 2,
+		},
+		{
+			n: 2, x: []complex128{3 + 4i, 9, 12i}, incX: 2,
+			scal: []complex128{-4 + 3i, 9, -12}, dscal: []complex128{6 + 8i, 9, 24i},
+			rscl: []complex128{2 - 1.5i, 9, 6},
+			nrm2: 13, asum: 19, iamax: 1,
+		},
+		{
+			n: 2, x: []complex128{3 + 4i, 9, 12i}, incX: -2,
+			scal: []complex128{3 + 4i, 9, 12i}, dscal: []complex128{3 + 4i, 9, 12i},
+			rscl: []complex128{3 + 4i, 9, 12i},
+			nrm2: 13, asum: 0, iamax: -1,
+		},
+	} {
+		x := append([]complex128(nil), test.x...)
+		Reference{}.ZSCAL(test.n, 1i, x, test.incX)
+		if !sameC128(x, test.scal, 0) {
+			t.Errorf("ZSCAL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x, test.scal)
+		}
+		x = append([]complex128(nil), test.x...)
+		Reference{}.ZDSCAL(test.n, 2, x, test.incX)
+		if !sameC128(x, test.dscal, 0) {
+			t.Errorf("ZDSCAL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x, test.dscal)
+		}
+		x = append([]complex128(nil), test.x...)
+		Reference{}.ZRSCL(test.n, 2i, x, test.incX)
+		if !sameC128(x, test.rscl, tol64) {
+			t.Errorf("ZRSCL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x, test.rscl)
+		}
+		x = append([]complex128(nil), test.x...)
+		Reference{}.ZDRSCL(test.n, 0.5, x, test.incX)
+		if !sameC128(x, test.dscal, 0) {
+			t.Errorf("ZDRSCL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x, test.dscal)
+		}
+		if got := (Reference{}).DZNRM2(test.n, test.x, test.incX); math.Abs(got-test.nrm2) > tol64*test.nrm2 {
+			t.Errorf("DZNRM2 n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.nrm2)
+		}
+		if got := (Reference{}).DZASUM(test.n, test.x, test.incX); got != test.asum {
+			t.Errorf("DZASUM n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.asum)
+		}
+		if got := (Reference{}).IZAMAX(test.n, test.x, test.incX); got != test.iamax {
+			t.Errorf("IZAMAX n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.iamax)
+		}
+
+		x64 := c64s(test.x)
+		Reference{}.CSCAL(test.n, 1i, x64, test.incX)
+		if !sameC128(c128s(x64), test.scal, 0) {
+			t.Errorf("CSCAL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x64, test.scal)
+		}
+		x64 = c64s(test.x)
+		Reference{}.CSSCAL(test.n, 2, x64, test.incX)
+		if !sameC128(c128s(x64), test.dscal, 0) {
+			t.Errorf("CSSCAL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x64, test.dscal)
+		}
+		x64 = c64s(test.x)
+		Reference{}.CRSCL(test.n, 2i, x64, test.incX)
+		if !sameC128(c128s(x64), test.rscl, tol32) {
+			t.Errorf("CRSCL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x64, test.rscl)
+		}
+		x64 = c64s(test.x)
+		Reference{}.CSRSCL(test.n, 0.5, x64, test.incX)
+		if !sameC128(c128s(x64), test.dscal, 0) {
+			t.Errorf("CSRSCL n=%d incX=%d: x=%v, want %v", test.n, test.incX, x64, test.dscal)
+		}
+		if got := (Reference{}).SCNRM2(test.n, c64s(test.x), test.incX); math.Abs(float64(got)-test.nrm2) > tol32*test.nrm2 {
+			t.Errorf("SCNRM2 n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.nrm2)
+		}
+		if got := (Reference{}).SCASUM(test.n, c64s(test.x), test.incX); got != float32(test.asum) {
+			t.Errorf("SCASUM n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.asum)
+		}
+		if got := (Reference{}).ICAMAX(test.n, c64s(test.x), test.incX); got != test.iamax {
+			t.Errorf("ICAMAX n=%d incX=%d = %v, want %v", test.n, test.incX, got, test.iamax)
+		}
+	}
+}
+
+// TestDrotmg reproduces the DROTMG test of the Netlib dblat1 program. Each
+// case gives d1, d2, x1 and y1, and the expected d1, d2, x1, y1 and
+// FLAG, H11, H21, H12, H22, with d12 = gam*gam for the rescaled cases.
+func TestDrotmg(t *testing.T) {
+	const d12 = 4096
+	for i, test := range []struct {
+		in   [4]float64
+		want [9]float64
+	}{
+		// flag = 0.
+		{in: [4]float64{0.1, 0.3, 1.2, 0.2}, want: [9]float64{12.0 / 130, 36.0 / 130, 1.3, 0.2, 0, 0, -1.0 / 6, 0.5, 0}},
+		// flag = 1.
+		{in: [4]float64{0.7, 0.2, 0.6, 4.2}, want: [9]float64{14.0 / 75, 49.0 / 75, 4.5, 4.2, 1, 0.5, 0, 0, 1.0 / 7}},
+		// flag = -2: y1 = 0.
+		{in: [4]float64{0, 0, 0, 0}, want: [9]float64{0, 0, 0, 0, -2, 0, 0, 0, 0}},
+		// flag = -1 with H = 0: q2 < 0.
+		{in: [4]float64{4, -1, 2, 4}, want: [9]float64{0, 0, 0, 4, -1, 0, 0, 0, 0}},
+		// d1 is rescaled up by gam**2.
+		{in: [4]float64{6e-10, 2e-2, 1e5, 10}, want: [9]float64{45e-11 * d12 * d12, 15e-3, 4e5 / (3 * d12), 10, -1, 1.0 / d12, -1e-4, 1e4 / (3 * d12), 1}},
+		// d1 is rescaled down by gam**2.
+		{in: [4]float64{4e10, 2e-2, 1e-5, 10}, want: [9]float64{4e10 / (1.5 * d12 * d12), 2e-2 / 1.5, 6144e-5, 10, -1, 4096, -1e6, 5e-7 * d12, 1}},
+		// d2 is rescaled up by gam**2.
+		{in: [4]float64{2e-10, 4e-2, 1e5, 10}, want: [9]float64{4.0 / 150, (2e-10 / 1.5) * d12 * d12, 15, 10, -1, 5e-5, -1.0 / d12, 1, 1e4 / d12}},
+		// d2 is rescaled down by gam**2.
+		{in: [4]float64{2e10, 4e-2, 1e-5, 10}, want: [9]float64{4.0 / 150, 2e10 / (1.5 * d12 * d12), 15, 10, -1, 5e5, -4096, 1, 4096e-6}},
+		// flag = 0 with a negative d2.
+		{in: [4]float64{4, -2, 8, 4}, want: [9]float64{32.0 / 7, -16.0 / 7, 7, 4, 0, 0, -0.5, -0.25, 0}},
+	} {
+		d1, d2, x1, p := Reference{}.DROTMG(test.in[0], test.in[1], test.in[2], test.in[3])
+		got := []float64{d1, d2, x1, test.in[3], p.FLAG, p.H11, p.H21, p.H12, p.H22}
+		if !sameF64(got, test.want[:], 1e-14) {
+			t.Errorf("case %d: DROTMG%v = %v, want %v", i+1, test.in, got, test.want)
+		}
+		s1, s2, sx1, sp := Reference{}.SROTMG(float32(test.in[0]), float32(test.in[1]), float32(test.in[2]), float32(test.in[3]))
+		got32 := []float32{s1, s2, sx1, float32(test.in[3]), sp.FLAG, sp.H11, sp.H21, sp.H12, sp.H22}
+		if !sameF32(got32, f32s(test.want[:]), 1e-5) {
+			t.Errorf("case %d: SROTMG%v = %v, want %v", i+1, test.in, got32, test.want)
+		}
+	}
+}
+
+// TestDrotm applies H = [2 4; 3 5] under each flag to the pairs (x[0],y[1])
+// and (x[1],y[0]): x' = h11*x + h12*y and y' = h21*x + h22*y, with the
+// elements implied by the flag taking their implied values.
+func TestDrotm(t *testing.T) {
+	for _, test := range []struct {
+		flag         float64
+		wantX, wantY []float64
+	}{
+		{flag: -1, wantX: []float64{18, 16}, wantY: []float64{21, 23}},
+		{flag: 0, wantX: []float64{17, 14}, wantY: []float64{9, 7}},
+		{flag: 1, wantX: []float64{6, 7}, wantY: []float64{13, 19}},
+		{flag: -2, wantX: []float64{1, 2}, wantY: []float64{3, 4}},
+	} {
+		x, y := []float64{1, 2}, []float64{3, 4}
+		p := DParams{FLAG: test.flag, H11: 2, H21: 3, H12: 4, H22: 5}
+		Reference{}.DROTM(2, x, 1, y, -1, p)
+		if !sameF64(x, test.wantX, 0) || !sameF64(y, test.wantY, 0) {
+			t.Errorf("DROTM flag=%v: x=%v y=%v, want x=%v y=%v", test.flag, x, y, test.wantX, test.wantY)
+		}
+		x32, y32 := []float32{1, 2}, []float32{3, 4}
+		sp := SParams{FLAG: float32(test.flag), H11: 2, H21: 3, H12: 4, H22: 5}
+		Reference{}.SROTM(2, x32, 1, y32, -1, sp)
+		if !sameF32(x32, f32s(test.wantX), 0) || !sameF32(y32, f32s(test.wantY), 0) {
+			t.Errorf("SROTM flag=%v: x=%v y=%v, want x=%v y=%v", test.flag, x32, y32, test.wantX, test.wantY)
+		}
+	}
+}
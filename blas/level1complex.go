@@ -0,0 +1,122 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// CROTG setup Givens rotation
+func (Reference) CROTG(a, b complex64) (c, s complex64) {
+	rc, sr, si := gen.Crotg(real(a), imag(a), real(b), imag(b))
+	return complex(rc, 0), complex(sr, si)
+}
+
+// CSROT apply Givens rotation
+func (Reference) CSROT(n int, x []complex64, incX int, y []complex64, incY int, c, s float32) (ry []complex64) {
+	if !checkVecs("CSROT", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Rot(n, x, incX, y, incY, complex(c, 0), complex(s, 0))
+	return y
+}
+
+// CROT apply Givens rotation with real cosine and complex sine
+func (Reference) CROT(n int, x []complex64, incX int, y []complex64, incY int, c float32, s complex64) (ry []complex64) {
+	if !checkVecs("CROT", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Rot(n, x, incX, y, incY, complex(c, 0), s)
+	return y
+}
+
+// CSWAP swap x and y
+func (Reference) CSWAP(n int, x []complex64, incX int, y []complex64, incY int) (rx, ry []complex64) {
+	if !checkVecs("CSWAP", n, 2, len(x), incX, len(y), incY) {
+		return x, y
+	}
+	gen.Swap(n, x, incX, y, incY)
+	return x, y
+}
+
+// CSCAL x = a*x
+func (Reference) CSCAL(n int, alpha complex64, x []complex64, incX int) (rx []complex64) {
+	if !checkVec("CSCAL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Scal(n, alpha, x, incX)
+	return x
+}
+
+// CSSCAL x = a*x
+func (Reference) CSSCAL(n int, alpha float32, x []complex64, incX int) (rx []complex64) {
+	if !checkVec("CSSCAL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.ScalReal(n, alpha, x, incX)
+	return x
+}
+
+// CRSCL x = x/a
+func (Reference) CRSCL(n int, alpha complex64, x []complex64, incX int) (rx []complex64) {
+	if !checkVec("CRSCL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Crscl(n, real(alpha), imag(alpha), x, incX)
+	return x
+}
+
+// CSRSCL x = x/a
+func (Reference) CSRSCL(n int, alpha float32, x []complex64, incX int) (rx []complex64) {
+	if !checkVec("CSRSCL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Rscl(n, alpha, x, incX)
+	return x
+}
+
+// CCOPY copy x into y
+func (Reference) CCOPY(n int, x []complex64, incX int, y []complex64, incY int) (ry []complex64) {
+	if !checkVecs("CCOPY", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Copy(n, x, incX, y, incY)
+	return y
+}
+
+// CAXPY y = a*x + y
+func (Reference) CAXPY(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) (ry []complex64) {
+	if !checkVecs("CAXPY", n, 3, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Axpy(n, alpha, x, incX, y, incY)
+	return y
+}
+
+// CDOTU dot product
+func (Reference) CDOTU(n int, x []complex64, incX int, y []complex64, incY int) (r complex64) {
+	if !checkVecs("CDOTU", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dotu(n, x, incX, y, incY)
+}
+
+// CDOTC dot product, conjugating the first vector
+func (Reference) CDOTC(n int, x []complex64, incX int, y []complex64, incY int) (r complex64) {
+	if !checkVecs("CDOTC", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dotc(n, x, incX, y, incY)
+}
+
+// SCASUM sum of absolute values
+func (Reference) SCASUM(n int, x []complex64, incX int) (r float32) {
+	if !checkVec("SCASUM", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Casum[float32](n, x, incX)
+}
+
+// ICAMAX index of max abs value
+func (Reference) ICAMAX(n int, x []complex64, incX int) (r int) {
+	if !checkVec("ICAMAX", n, 2, len(x), incX) {
+		return -1
+	}
+	return gen.Iamax(n, x, incX)
+}
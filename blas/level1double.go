@@ -0,0 +1,125 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// DROTG setup Givens rotation
+func (Reference) DROTG(a, b float64) (c, s float64) {
+	return gen.Rotg(a, b)
+}
+
+// DROTMG setup modified Givens rotation
+func (Reference) DROTMG(d1, d2, x, y float64) (rd1, rd2, rx float64, p DParams) {
+	rd1, rd2, rx, p.FLAG, p.H11, p.H21, p.H12, p.H22 = gen.Rotmg(d1, d2, x, y)
+	return rd1, rd2, rx, p
+}
+
+// DROT apply Givens rotation
+func (Reference) DROT(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) (ry []float64) {
+	if !checkVecs("DROT", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Rot(n, x, incX, y, incY, c, s)
+	return y
+}
+
+// DROTM apply modified Givens rotation
+func (Reference) DROTM(n int, x []float64, incX int, y []float64, incY int, p DParams) (rx, ry []float64) {
+	if !checkVecs("DROTM", n, 2, len(x), incX, len(y), incY) {
+		return x, y
+	}
+	gen.Rotm(n, x, incX, y, incY, p.FLAG, p.H11, p.H21, p.H12, p.H22)
+	return x, y
+}
+
+// DSWAP swap x and y
+func (Reference) DSWAP(n int, x []float64, incX int, y []float64, incY int) (rx, ry []float64) {
+	if !checkVecs("DSWAP", n, 2, len(x), incX, len(y), incY) {
+		return x, y
+	}
+	gen.Swap(n, x, incX, y, incY)
+	return x, y
+}
+
+// DSCAL x = a*x
+func (Reference) DSCAL(n int, alpha float64, x []float64, incX int) (rx []float64) {
+	if !checkVec("DSCAL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Scal(n, alpha, x, incX)
+	return x
+}
+
+// DRSCL x = x/a
+func (Reference) DRSCL(n int, alpha float64, x []float64, incX int) (rx []float64) {
+	if !checkVec("DRSCL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Rscl(n, alpha, x, incX)
+	return x
+}
+
+// DCOPY copy x into y
+func (Reference) DCOPY(n int, x []float64, incX int, y []float64, incY int) (ry []float64) {
+	if !checkVecs("DCOPY", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Copy(n, x, incX, y, incY)
+	return y
+}
+
+// DAXPY y = a*x + y
+func (Reference) DAXPY(n int, alpha float64, x []float64, incX int, y []float64, incY int) (ry []float64) {
+	if !checkVecs("DAXPY", n, 3, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Axpy(n, alpha, x, incX, y, incY)
+	return y
+}
+
+// DDOT dot product
+func (Reference) DDOT(n int, x []float64, incX int, y []float64, incY int) (r float64) {
+	if !checkVecs("DDOT", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dotu(n, x, incX, y, incY)
+}
+
+// DSDOT dot product with extended precision accumulation
+func (Reference) DSDOT(n int, x []float32, incX int, y []float32, incY int) (r float64) {
+	if !checkVecs("DSDOT", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dsdot(n, x, incX, y, incY)
+}
+
+// DNRM2 Euclidean norm
+func (Reference) DNRM2(n int, x []float64, incX int) (r float64) {
+	if !checkVec("DNRM2", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Nrm2(n, x, incX)
+}
+
+// DZNRM2 Euclidean norm
+func (Reference) DZNRM2(n int, x []complex128, incX int) (r float64) {
+	if !checkVec("DZNRM2", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Cnrm2[float64](n, x, incX)
+}
+
+// DASUM sum of absolute values
+func (Reference) DASUM(n int, x []float64, incX int) (r float64) {
+	if !checkVec("DASUM", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Asum(n, x, incX)
+}
+
+// IDAMAX index of max abs value
+func (Reference) IDAMAX(n int, x []float64, incX int) (r int) {
+	if !checkVec("IDAMAX", n, 2, len(x), incX) {
+		return -1
+	}
+	return gen.Iamax(n, x, incX)
+}
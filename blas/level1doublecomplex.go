@@ -0,0 +1,122 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// ZROTG setup Givens rotation
+func (Reference) ZROTG(a, b complex128) (c float64, s complex128) {
+	c, sr, si := gen.Crotg(real(a), imag(a), real(b), imag(b))
+	return c, complex(sr, si)
+}
+
+// ZDROT apply Givens rotation
+func (Reference) ZDROT(n int, x []complex128, incX int, y []complex128, incY int, c, s float64) (ry []complex128) {
+	if !checkVecs("ZDROT", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Rot(n, x, incX, y, incY, complex(c, 0), complex(s, 0))
+	return y
+}
+
+// ZROT apply Givens rotation with real cosine and complex sine
+func (Reference) ZROT(n int, x []complex128, incX int, y []complex128, incY int, c float64, s complex128) (ry []complex128) {
+	if !checkVecs("ZROT", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Rot(n, x, incX, y, incY, complex(c, 0), s)
+	return y
+}
+
+// ZSWAP swap x and y
+func (Reference) ZSWAP(n int, x []complex128, incX int, y []complex128, incY int) (rx []complex128, ry []complex128) {
+	if !checkVecs("ZSWAP", n, 2, len(x), incX, len(y), incY) {
+		return x, y
+	}
+	gen.Swap(n, x, incX, y, incY)
+	return x, y
+}
+
+// ZSCAL x = a*x
+func (Reference) ZSCAL(n int, alpha complex128, x []complex128, incX int) (rx []complex128) {
+	if !checkVec("ZSCAL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Scal(n, alpha, x, incX)
+	return x
+}
+
+// ZDSCAL x = a*x
+func (Reference) ZDSCAL(n int, alpha float64, x []complex128, incX int) (rx []complex128) {
+	if !checkVec("ZDSCAL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.ScalReal(n, alpha, x, incX)
+	return x
+}
+
+// ZRSCL x = x/a
+func (Reference) ZRSCL(n int, alpha complex128, x []complex128, incX int) (rx []complex128) {
+	if !checkVec("ZRSCL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Crscl(n, real(alpha), imag(alpha), x, incX)
+	return x
+}
+
+// ZDRSCL x = x/a
+func (Reference) ZDRSCL(n int, alpha float64, x []complex128, incX int) (rx []complex128) {
+	if !checkVec("ZDRSCL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Rscl(n, alpha, x, incX)
+	return x
+}
+
+// ZCOPY copy x into y
+func (Reference) ZCOPY(n int, x []complex128, incX int, y []complex128, incY int) (ry []complex128) {
+	if !checkVecs("ZCOPY", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Copy(n, x, incX, y, incY)
+	return y
+}
+
+// ZAXPY y = a*x + y
+func (Reference) ZAXPY(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) (ry []complex128) {
+	if !checkVecs("ZAXPY", n, 3, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Axpy(n, alpha, x, incX, y, incY)
+	return y
+}
+
+// ZDOTU dot product
+func (Reference) ZDOTU(n int, x []complex128, incX int, y []complex128, incY int) (r complex128) {
+	if !checkVecs("ZDOTU", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dotu(n, x, incX, y, incY)
+}
+
+// ZDOTC dot product, conjugating the first vector
+func (Reference) ZDOTC(n int, x []complex128, incX int, y []complex128, incY int) (r complex128) {
+	if !checkVecs("ZDOTC", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dotc(n, x, incX, y, incY)
+}
+
+// DZASUM sum of absolute values
+func (Reference) DZASUM(n int, x []complex128, incX int) (r float64) {
+	if !checkVec("DZASUM", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Casum[float64](n, x, incX)
+}
+
+// IZAMAX index of max abs value
+func (Reference) IZAMAX(n int, x []complex128, incX int) (r int) {
+	if !checkVec("IZAMAX", n, 2, len(x), incX) {
+		return -1
+	}
+	return gen.Iamax(n, x, incX)
+}
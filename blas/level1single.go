@@ -0,0 +1,125 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// SROTG setup Givens rotation
+func (Reference) SROTG(a, b float32) (c, s float32) {
+	return gen.Rotg(a, b)
+}
+
+// SROTMG setup modified Givens rotation
+func (Reference) SROTMG(d1, d2, x, y float32) (rd1, rd2, rx float32, p SParams) {
+	rd1, rd2, rx, p.FLAG, p.H11, p.H21, p.H12, p.H22 = gen.Rotmg(d1, d2, x, y)
+	return rd1, rd2, rx, p
+}
+
+// SROT apply Givens rotation
+func (Reference) SROT(n int, x []float32, incX int, y []float32, incY int, c, s float32) (ry []float32) {
+	if !checkVecs("SROT", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Rot(n, x, incX, y, incY, c, s)
+	return y
+}
+
+// SROTM apply modified Givens rotation
+func (Reference) SROTM(n int, x []float32, incX int, y []float32, incY int, p SParams) (rx, ry []float32) {
+	if !checkVecs("SROTM", n, 2, len(x), incX, len(y), incY) {
+		return x, y
+	}
+	gen.Rotm(n, x, incX, y, incY, p.FLAG, p.H11, p.H21, p.H12, p.H22)
+	return x, y
+}
+
+// SSWAP swap x and y
+func (Reference) SSWAP(n int, x []float32, incX int, y []float32, incY int) (rx, ry []float32) {
+	if !checkVecs("SSWAP", n, 2, len(x), incX, len(y), incY) {
+		return x, y
+	}
+	gen.Swap(n, x, incX, y, incY)
+	return x, y
+}
+
+// SSCAL x = a*x
+func (Reference) SSCAL(n int, alpha float32, x []float32, incX int) (rx []float32) {
+	if !checkVec("SSCAL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Scal(n, alpha, x, incX)
+	return x
+}
+
+// SRSCL x = x/a
+func (Reference) SRSCL(n int, alpha float32, x []float32, incX int) (rx []float32) {
+	if !checkVec("SRSCL", n, 3, len(x), incX) {
+		return x
+	}
+	gen.Rscl(n, alpha, x, incX)
+	return x
+}
+
+// SCOPY copy x into y
+func (Reference) SCOPY(n int, x []float32, incX int, y []float32, incY int) (ry []float32) {
+	if !checkVecs("SCOPY", n, 2, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Copy(n, x, incX, y, incY)
+	return y
+}
+
+// SAXPY y = a*x + y
+func (Reference) SAXPY(n int, alpha float32, x []float32, incX int, y []float32, incY int) (ry []float32) {
+	if !checkVecs("SAXPY", n, 3, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Axpy(n, alpha, x, incX, y, incY)
+	return y
+}
+
+// SDOT dot product
+func (Reference) SDOT(n int, x []float32, incX int, y []float32, incY int) (r float32) {
+	if !checkVecs("SDOT", n, 2, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return gen.Dotu(n, x, incX, y, incY)
+}
+
+// SDSDOT dot product with extended precision accumulation
+func (Reference) SDSDOT(n int, alpha float32, x []float32, incX int, y []float32, incY int) (r float32) {
+	if !checkVecs("SDSDOT", n, 3, len(x), incX, len(y), incY) {
+		return 0
+	}
+	return float32(float64(alpha) + gen.Dsdot(n, x, incX, y, incY))
+}
+
+// SNRM2 Euclidean norm
+func (Reference) SNRM2(n int, x []float32, incX int) (r float32) {
+	if !checkVec("SNRM2", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Nrm2(n, x, incX)
+}
+
+// SCNRM2 Euclidean norm
+func (Reference) SCNRM2(n int, x []complex64, incX int) (r float32) {
+	if !checkVec("SCNRM2", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Cnrm2[float32](n, x, incX)
+}
+
+// SASUM sum of absolute values
+func (Reference) SASUM(n int, x []float32, incX int) (r float32) {
+	if !checkVec("SASUM", n, 2, len(x), incX) {
+		return 0
+	}
+	return gen.Asum(n, x, incX)
+}
+
+// ISAMAX index of max abs value
+func (Reference) ISAMAX(n int, x []float32, incX int) (r int) {
+	if !checkVec("ISAMAX", n, 2, len(x), incX) {
+		return -1
+	}
+	return gen.Iamax(n, x, incX)
+}
@@ -0,0 +1,505 @@
+package blas
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// The tests of this file and of level3_test.go evaluate the definition of
+// each routine naively on dense complex128 matrices and compare the result
+// with the routine of Reference, for the four precisions. The operands are
+// rounded to the precision under test before the naive evaluation, so only
+// the rounding errors of the computation itself are left.
+
+type scalar interface {
+	float32 | float64 | complex64 | complex128
+}
+
+// toT converts x to T, dropping the imaginary parts for real types.
+func toT[T scalar](x []complex128) []T {
+	r := make([]T, len(x))
+	for i, v := range x {
+		switch p := any(&r[i]).(type) {
+		case *float32:
+			*p = float32(real(v))
+		case *float64:
+			*p = real(v)
+		case *complex64:
+			*p = complex64(v)
+		case *complex128:
+			*p = v
+		}
+	}
+	return r
+}
+
+// toC converts x to complex128.
+func toC[T scalar](x []T) []complex128 {
+	r := make([]complex128, len(x))
+	for i, v := range x {
+		switch v := any(v).(type) {
+		case float32:
+			r[i] = complex(float64(v), 0)
+		case float64:
+			r[i] = complex(v, 0)
+		case complex64:
+			r[i] = complex128(v)
+		case complex128:
+			r[i] = v
+		}
+	}
+	return r
+}
+
+// scal converts v to T and returns it with its value in complex128.
+func scal[T scalar](v complex128) (T, complex128) {
+	r := toT[T]([]complex128{v})
+	return r[0], toC(r)[0]
+}
+
+// tolOf returns the tolerance of the comparisons in the precision of T.
+func tolOf[T scalar]() float64 {
+	var z T
+	switch any(z).(type) {
+	case float32, complex64:
+		return 1e-4
+	}
+	return 1e-12
+}
+
+func isCmplx[T scalar]() bool {
+	var z T
+	switch any(z).(type) {
+	case complex64, complex128:
+		return true
+	}
+	return false
+}
+
+// closeTo reports whether got and want agree to within tol relative to the
+// largest element of want.
+func closeTo(got, want []complex128, tol float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	scale := 1.0
+	for _, v := range want {
+		scale = math.Max(scale, cmplx.Abs(v))
+	}
+	for i := range got {
+		if cmplx.Abs(got[i]-want[i]) > tol*scale {
+			return false
+		}
+	}
+	return true
+}
+
+// vecIndex returns the index of the element i of a vector of n elements
+// stored with increment inc.
+func vecIndex(n, inc, i int) int {
+	if inc < 0 {
+		return (n - 1 - i) * (-inc)
+	}
+	return i * inc
+}
+
+// getVec returns the n logical elements of the vector x stored with
+// increment inc.
+func getVec(n int, x []complex128, inc int) []complex128 {
+	r := make([]complex128, n)
+	for i := range r {
+		r[i] = x[vecIndex(n, inc, i)]
+	}
+	return r
+}
+
+// opElem returns element (i,j) of op(A) for the dense r×c column-major A.
+func opElem(tr Transpose, r int, a []complex128, i, j int) complex128 {
+	switch tr {
+	case TransT:
+		return a[j+i*r]
+	case TransC:
+		return cmplx.Conj(a[j+i*r])
+	}
+	return a[i+j*r]
+}
+
+// mulMV returns op(A)*x for the dense r×c column-major A.
+func mulMV(tr Transpose, r, c int, a, x []complex128) []complex128 {
+	m, n := r, c
+	if tr != TransN {
+		m, n = c, r
+	}
+	y := make([]complex128, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			y[i] += opElem(tr, r, a, i, j) * x[j]
+		}
+	}
+	return y
+}
+
+// bandOf returns the dense m×n a with the elements outside the kL
+// subdiagonals and kU superdiagonals set to zero.
+func bandOf(m, n, kL, kU int, a []complex128) []complex128 {
+	r := make([]complex128, m*n)
+	for j := 0; j < n; j++ {
+		for i := max(0, j-kU); i <= min(m-1, j+kL); i++ {
+			r[i+j*m] = a[i+j*m]
+		}
+	}
+	return r
+}
+
+// inTriangle reports whether element (i,j) lies in the uplo triangle.
+func inTriangle(uplo Uplo, i, j int) bool {
+	if uplo == UploU {
+		return i <= j
+	}
+	return i >= j
+}
+
+// hermOf returns the dense n×n symmetric (herm false) or Hermitian matrix
+// defined by the uplo triangle of a. The Hermitian diagonal is real, as the
+// routines ignore the imaginary parts of the stored diagonal.
+func hermOf(herm bool, uplo Uplo, n int, a []complex128) []complex128 {
+	r := make([]complex128, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if inTriangle(uplo, i, j) {
+				r[i+j*n] = a[i+j*n]
+				continue
+			}
+			r[i+j*n] = a[j+i*n]
+			if herm {
+				r[i+j*n] = cmplx.Conj(r[i+j*n])
+			}
+		}
+		if herm {
+			r[j+j*n] = complex(real(r[j+j*n]), 0)
+		}
+	}
+	return r
+}
+
+// triOf returns the dense n×n triangular matrix defined by the uplo triangle
+// of a, with a unit diagonal if diag is DiagU.
+func triOf(uplo Uplo, diag Diag, n int, a []complex128) []complex128 {
+	r := make([]complex128, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if inTriangle(uplo, i, j) {
+				r[i+j*n] = a[i+j*n]
+			}
+		}
+		if diag == DiagU {
+			r[j+j*n] = 1
+		}
+	}
+	return r
+}
+
+// solveTri returns the solution of op(T)*x = b for the dense n×n triangular
+// matrix T by substitution.
+func solveTri(tr Transpose, n int, a, b []complex128) []complex128 {
+	x := append([]complex128(nil), b...)
+	lower := true
+	for j := 0; j < n && lower; j++ {
+		for i := 0; i < j; i++ {
+			if opElem(tr, n, a, i, j) != 0 {
+				lower = false
+				break
+			}
+		}
+	}
+	if lower {
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				x[i] -= opElem(tr, n, a, i, j) * x[j]
+			}
+			x[i] /= opElem(tr, n, a, i, i)
+		}
+		return x
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= opElem(tr, n, a, i, j) * x[j]
+		}
+		x[i] /= opElem(tr, n, a, i, i)
+	}
+	return x
+}
+
+// stored returns the elements of the m×n matrix held in a with storage s as
+// a dense column-major matrix, with zeros where nothing is stored.
+func stored(s storage, m, n int, a []complex128) []complex128 {
+	r := make([]complex128, m*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			if k, ok := s(i, j); ok {
+				r[i+j*m] = a[k]
+			}
+		}
+	}
+	return r
+}
+
+// level2 holds the Level 2 routines of one precision. The real precisions
+// use the symmetric routines as the Hermitian ones and xGER as both xGERU and
+// xGERC.
+type level2[T scalar, R float32 | float64] struct {
+	gemv       func(Transpose, int, int, T, []T, int, []T, int, T, []T, int) []T
+	gbmv       func(Transpose, int, int, int, int, T, []T, int, []T, int, T, []T, int) []T
+	hemv       func(Uplo, int, T, []T, int, []T, int, T, []T, int) []T
+	hbmv       func(Uplo, int, int, T, []T, int, []T, int, T, []T, int) []T
+	hpmv       func(Uplo, int, T, []T, []T, int, T, []T, int) []T
+	trmv, trsv func(Uplo, Transpose, Diag, int, []T, int, []T, int) []T
+	tbmv, tbsv func(Uplo, Transpose, Diag, int, int, []T, int, []T, int) []T
+	tpmv, tpsv func(Uplo, Transpose, Diag, int, []T, []T, int) []T
+	geru, gerc func(int, int, T, []T, int, []T, int, []T, int) []T
+	her        func(Uplo, int, R, []T, int, []T, int) []T
+	hpr        func(Uplo, int, R, []T, int, []T) []T
+	her2       func(Uplo, int, T, []T, int, []T, int, []T, int) []T
+	hpr2       func(Uplo, int, T, []T, int, []T, int, []T) []T
+}
+
+func TestLevel2(t *testing.T) {
+	var r Reference
+	testLevel2(t, "S", level2[float32, float32]{
+		r.SGEMV, r.SGBMV, r.SSYMV, r.SSBMV, r.SSPMV,
+		r.STRMV, r.STRSV, r.STBMV, r.STBSV, r.STPMV, r.STPSV,
+		r.SGER, r.SGER, r.SSYR, r.SSPR, r.SSYR2, r.SSPR2,
+	})
+	testLevel2(t, "D", level2[float64, float64]{
+		r.DGEMV, r.DGBMV, r.DSYMV, r.DSBMV, r.DSPMV,
+		r.DTRMV, r.DTRSV, r.DTBMV, r.DTBSV, r.DTPMV, r.DTPSV,
+		r.DGER, r.DGER, r.DSYR, r.DSPR, r.DSYR2, r.DSPR2,
+	})
+	testLevel2(t, "C", level2[complex64, float32]{
+		r.CGEMV, r.CGBMV, r.CHEMV, r.CHBMV, r.CHPMV,
+		r.CTRMV, r.CTRSV, r.CTBMV, r.CTBSV, r.CTPMV, r.CTPSV,
+		r.CGERU, r.CGERC, r.CHER, r.CHPR, r.CHER2, r.CHPR2,
+	})
+	testLevel2(t, "Z", level2[complex128, float64]{
+		r.ZGEMV, r.ZGBMV, r.ZHEMV, r.ZHBMV, r.ZHPMV,
+		r.ZTRMV, r.ZTRSV, r.ZTBMV, r.ZTBSV, r.ZTPMV, r.ZTPSV,
+		r.ZGERU, r.ZGERC, r.ZHER, r.ZHPR, r.ZHER2, r.ZHPR2,
+	})
+}
+
+func testLevel2[T scalar, R float32 | float64](t *testing.T, prec string, f level2[T, R]) {
+	rnd := rand.New(rand.NewSource(1))
+	cplx, tol := isCmplx[T](), tolOf[T]()
+	trans := []Transpose{TransN, TransT}
+	if cplx {
+		trans = append(trans, TransC)
+	}
+	// rand returns n random elements rounded to T.
+	rand := func(n int) []complex128 { return toC(toT[T](randC(rnd, n, cplx))) }
+	check := func(name string, got, want []complex128) {
+		t.Helper()
+		if !closeTo(got, want, tol) {
+			t.Errorf("%s%s:\ngot  %v\nwant %v", prec, name, got, want)
+		}
+	}
+	alpha, ca := scal[T](complex(0.7, 0.3))
+	beta, cb := scal[T](complex(-0.4, 0.2))
+	ralpha := R(0.6)
+
+	for _, incs := range [][2]int{{1, 1}, {2, -1}, {-3, 2}, {-1, -2}} {
+		incX, incY := incs[0], incs[1]
+		for _, mn := range [][2]int{{0, 3}, {1, 1}, {5, 4}, {4, 6}} {
+			m, n := mn[0], mn[1]
+			// General and band matrix-vector products, with lda > m.
+			for _, tr := range trans {
+				lx, ly := n, m
+				if tr != TransN {
+					lx, ly = m, n
+				}
+				a := rand(m * n)
+				x, y := rand(vecLen(lx, incX)), rand(vecLen(ly, incY))
+				want := mulMV(tr, m, n, a, getVec(lx, x, incX))
+				yv := getVec(ly, y, incY)
+				for i := range want {
+					want[i] = ca*want[i] + cb*yv[i]
+				}
+				if m == 0 || n == 0 {
+					// Netlib returns at once, without scaling y.
+					want = yv
+				}
+				lda := m + 2
+				got := f.gemv(tr, m, n, alpha, toT[T](store(general(false, lda), m, n, n*lda, a)), lda, toT[T](x), incX, beta, toT[T](y), incY)
+				check(fmt.Sprintf("GEMV(%c) m=%d n=%d incX=%d incY=%d", tr, m, n, incX, incY), getVec(ly, toC(got), incY), want)
+
+				for _, kl := range [][2]int{{0, 0}, {2, 1}, {1, 3}} {
+					kL, kU := kl[0], kl[1]
+					want := mulMV(tr, m, n, bandOf(m, n, kL, kU, a), getVec(lx, x, incX))
+					for i := range want {
+						want[i] = ca*want[i] + cb*yv[i]
+					}
+					if m == 0 || n == 0 {
+						want = yv
+					}
+					lda := kL + kU + 2
+					got := f.gbmv(tr, m, n, kL, kU, alpha, toT[T](store(band(false, kL, kU, lda), m, n, n*lda, a)), lda, toT[T](x), incX, beta, toT[T](y), incY)
+					check(fmt.Sprintf("GBMV(%c) m=%d n=%d kL=%d kU=%d incX=%d incY=%d", tr, m, n, kL, kU, incX, incY), getVec(ly, toC(got), incY), want)
+				}
+			}
+
+			// Rank one updates of general matrices.
+			{
+				a := rand(m * n)
+				x, y := rand(vecLen(m, incX)), rand(vecLen(n, incY))
+				xv, yv := getVec(m, x, incX), getVec(n, y, incY)
+				lda := m + 2
+				s := general(false, lda)
+				for _, cj := range []bool{false, true} {
+					want := append([]complex128(nil), a...)
+					for j := 0; j < n; j++ {
+						w := yv[j]
+						if cj {
+							w = cmplx.Conj(w)
+						}
+						for i := 0; i < m; i++ {
+							want[i+j*m] += ca * xv[i] * w
+						}
+					}
+					ger, name := f.geru, "GERU"
+					if cj {
+						ger, name = f.gerc, "GERC"
+					}
+					got := ger(m, n, alpha, toT[T](x), incX, toT[T](y), incY, toT[T](store(s, m, n, n*lda, a)), lda)
+					check(fmt.Sprintf("%s m=%d n=%d incX=%d incY=%d", name, m, n, incX, incY), stored(s, m, n, toC(got)), want)
+				}
+			}
+		}
+
+		const n = 5
+		for _, uplo := range []Uplo{UploU, UploL} {
+			// Symmetric and Hermitian products: full with lda > n, band
+			// with ldab > k+1, and packed.
+			a := rand(n * n)
+			x, y := rand(vecLen(n, incX)), rand(vecLen(n, incY))
+			xv, yv := getVec(n, x, incX), getVec(n, y, incY)
+			for _, k := range []int{0, 1, 3, n - 1} {
+				for _, form := range []string{"full", "band", "packed"} {
+					if form != "band" && k != 0 {
+						continue
+					}
+					h := hermOf(cplx, uplo, n, a)
+					var s storage
+					var size, lda int
+					switch form {
+					case "full":
+						lda = n + 1
+						s, size = triangle(general(false, lda), uplo), n*lda
+					case "band":
+						lda = k + 2
+						s, size = triBand(false, uplo, k, lda), n*lda
+						h = bandOf(n, n, k, k, h)
+					case "packed":
+						s, size = packed(false, uplo, n), n*(n+1)/2
+					}
+					ap := toT[T](store(s, n, n, size, a))
+					want := mulMV(TransN, n, n, h, xv)
+					for i := range want {
+						want[i] = ca*want[i] + cb*yv[i]
+					}
+					var got []T
+					switch form {
+					case "full":
+						got = f.hemv(uplo, n, alpha, ap, lda, toT[T](x), incX, beta, toT[T](y), incY)
+					case "band":
+						got = f.hbmv(uplo, n, k, alpha, ap, lda, toT[T](x), incX, beta, toT[T](y), incY)
+					case "packed":
+						got = f.hpmv(uplo, n, alpha, ap, toT[T](x), incX, beta, toT[T](y), incY)
+					}
+					check(fmt.Sprintf("HEMV %s uplo=%c k=%d incX=%d incY=%d", form, uplo, k, incX, incY), getVec(n, toC(got), incY), want)
+
+					// Rank one and two updates; only the uplo triangle
+					// is referenced and updated.
+					if form == "band" {
+						continue
+					}
+					h = hermOf(cplx, uplo, n, a)
+					want1 := append([]complex128(nil), h...)
+					want2 := append([]complex128(nil), h...)
+					for j := 0; j < n; j++ {
+						for i := 0; i < n; i++ {
+							want1[i+j*n] += complex(float64(ralpha), 0) * xv[i] * cmplx.Conj(xv[j])
+							want2[i+j*n] += ca*xv[i]*cmplx.Conj(yv[j]) + cmplx.Conj(ca)*yv[i]*cmplx.Conj(xv[j])
+						}
+					}
+					var got1, got2 []T
+					if form == "full" {
+						got1 = f.her(uplo, n, ralpha, toT[T](x), incX, toT[T](store(s, n, n, size, a)), lda)
+						got2 = f.her2(uplo, n, alpha, toT[T](x), incX, toT[T](y), incY, toT[T](store(s, n, n, size, a)), lda)
+					} else {
+						got1 = f.hpr(uplo, n, ralpha, toT[T](x), incX, toT[T](store(s, n, n, size, a)))
+						got2 = f.hpr2(uplo, n, alpha, toT[T](x), incX, toT[T](y), incY, toT[T](store(s, n, n, size, a)))
+					}
+					check(fmt.Sprintf("HER %s uplo=%c incX=%d", form, uplo, incX), stored(s, n, n, toC(got1)), stored(s, n, n, store(s, n, n, size, want1)))
+					check(fmt.Sprintf("HER2 %s uplo=%c incX=%d incY=%d", form, uplo, incX, incY), stored(s, n, n, toC(got2)), stored(s, n, n, store(s, n, n, size, want2)))
+				}
+			}
+
+			// Triangular products and solves: full with lda > n, band
+			// with ldab > k+1, and packed.
+			a = dominant(n, rand(n*n))
+			for _, tr := range trans {
+				for _, diag := range []Diag{DiagN, DiagU} {
+					for _, k := range []int{0, 2, n - 1} {
+						for _, form := range []string{"full", "band", "packed"} {
+							if form != "band" && k != 0 {
+								continue
+							}
+							tri := triOf(uplo, diag, n, a)
+							var s storage
+							var size, lda int
+							switch form {
+							case "full":
+								lda = n + 2
+								s, size = triangle(general(false, lda), uplo), n*lda
+							case "band":
+								lda = k + 3
+								s, size = triBand(false, uplo, k, lda), n*lda
+								tri = bandOf(n, n, k, k, tri)
+							case "packed":
+								s, size = packed(false, uplo, n), n*(n+1)/2
+							}
+							ap := toT[T](store(s, n, n, size, a))
+							for _, solve := range []bool{false, true} {
+								var want []complex128
+								if solve {
+									want = solveTri(tr, n, tri, xv)
+								} else {
+									want = mulMV(tr, n, n, tri, xv)
+								}
+								xt := toT[T](x)
+								var got []T
+								switch {
+								case form == "full" && solve:
+									got = f.trsv(uplo, tr, diag, n, ap, lda, xt, incX)
+								case form == "full":
+									got = f.trmv(uplo, tr, diag, n, ap, lda, xt, incX)
+								case form == "band" && solve:
+									got = f.tbsv(uplo, tr, diag, n, k, ap, lda, xt, incX)
+								case form == "band":
+									got = f.tbmv(uplo, tr, diag, n, k, ap, lda, xt, incX)
+								case solve:
+									got = f.tpsv(uplo, tr, diag, n, ap, xt, incX)
+								default:
+									got = f.tpmv(uplo, tr, diag, n, ap, xt, incX)
+								}
+								check(fmt.Sprintf("triangular %s solve=%v uplo=%c trans=%c diag=%c k=%d incX=%d", form, solve, uplo, tr, diag, k, incX), getVec(n, toC(got), incX), want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// CGEMV matrix vector multiply
+func (Reference) CGEMV(trans Transpose, m, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkGemv("CGEMV", colMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gemv(trans, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// CGBMV banded matrix vector multiply
+func (Reference) CGBMV(trans Transpose, m, n, kL, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkGbmv("CGBMV", colMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gbmv(trans, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// CHEMV hermitian matrix vector multiply
+func (Reference) CHEMV(uplo Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkSymv("CHEMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hemv(uplo, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// CHBMV hermitian banded matrix vector multiply
+func (Reference) CHBMV(uplo Uplo, n, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkSbmv("CHBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hbmv(uplo, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// CHPMV hermitian packed matrix vector multiply
+func (Reference) CHPMV(uplo Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkSpmv("CHPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hpmv(uplo, n, alpha, ap, x, incX, beta, y, incY)
+	return y
+}
+
+// CTRMV triangular matrix vector multiply
+func (Reference) CTRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTrmv("CTRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trmv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// CTBMV triangular banded matrix vector multiply
+func (Reference) CTBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTbmv("CTBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbmv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// CTPMV triangular packed matrix vector multiply
+func (Reference) CTPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex64, x []complex64, incX int) (rx []complex64) {
+	if !checkTpmv("CTPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpmv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// CTRSV solving triangular matrix problems
+func (Reference) CTRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTrmv("CTRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trsv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// CTBSV solving triangular banded matrix problems
+func (Reference) CTBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTbmv("CTBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbsv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// CTPSV solving triangular packed matrix problems
+func (Reference) CTPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex64, x []complex64, incX int) (rx []complex64) {
+	if !checkTpmv("CTPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpsv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// CGERU performs the rank 1 operation A := alpha*x*y' + A
+func (Reference) CGERU(m, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) (ra []complex64) {
+	if !checkGer("CGERU", colMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Ger(m, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// CGERC performs the rank 1 operation A := alpha*x*conjg( y' ) + A
+func (Reference) CGERC(m, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) (ra []complex64) {
+	if !checkGer("CGERC", colMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Gerc(m, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// CHER hermitian rank 1 operation A := alpha*x*conjg(x') + A
+func (Reference) CHER(uplo Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) (ra []complex64) {
+	if !checkSyr("CHER", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	gen.Her(uplo, n, complex(alpha, 0), x, incX, a, lda)
+	return a
+}
+
+// CHPR hermitian packed rank 1 operation A := alpha*x*conjg( x' ) + A
+func (Reference) CHPR(uplo Uplo, n int, alpha float32, x []complex64, incX int, a []complex64) (ra []complex64) {
+	if !checkSpr("CHPR", uplo, n, len(x), incX, len(a)) {
+		return a
+	}
+	gen.Hpr(uplo, n, complex(alpha, 0), x, incX, a)
+	return a
+}
+
+// CHER2 hermitian rank 2 operation
+func (Reference) CHER2(uplo Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) (ra []complex64) {
+	if !checkSyr2("CHER2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Her2(uplo, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// CHPR2 hermitian packed rank 2 operation
+func (Reference) CHPR2(uplo Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) (ra []complex64) {
+	if !checkSpr2("CHPR2", uplo, n, len(x), incX, len(y), incY, len(ap)) {
+		return ap
+	}
+	gen.Hpr2(uplo, n, alpha, x, incX, y, incY, ap)
+	return ap
+}
@@ -0,0 +1,147 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// DGEMV matrix vector multiply
+func (Reference) DGEMV(trans Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkGemv("DGEMV", colMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gemv(trans, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DGBMV banded matrix vector multiply
+func (Reference) DGBMV(trans Transpose, m, n, kL, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkGbmv("DGBMV", colMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gbmv(trans, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DSYMV symmetric matrix vector multiply
+func (Reference) DSYMV(uplo Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkSymv("DSYMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hemv(uplo, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DSBMV symmetric banded matrix vector multiply
+func (Reference) DSBMV(uplo Uplo, n, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkSbmv("DSBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hbmv(uplo, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DSPMV symmetric packed matrix vector multiply
+func (Reference) DSPMV(uplo Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkSpmv("DSPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hpmv(uplo, n, alpha, ap, x, incX, beta, y, incY)
+	return y
+}
+
+// DTRMV triangular matrix vector multiply
+func (Reference) DTRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTrmv("DTRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trmv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// DTBMV triangular banded matrix vector multiply
+func (Reference) DTBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTbmv("DTBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbmv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// DTPMV triangular packed matrix vector multiply
+func (Reference) DTPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float64, x []float64, incX int) (rx []float64) {
+	if !checkTpmv("DTPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpmv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// DTRSV solving triangular matrix problems
+func (Reference) DTRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTrmv("DTRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trsv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// DTBSV solving triangular banded matrix problems
+func (Reference) DTBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTbmv("DTBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbsv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// DTPSV solving triangular packed matrix problems
+func (Reference) DTPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float64, x []float64, incX int) (rx []float64) {
+	if !checkTpmv("DTPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpsv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// DGER performs the rank 1 operation A := alpha*x*y' + A
+func (Reference) DGER(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) (ra []float64) {
+	if !checkGer("DGER", colMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Ger(m, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// DSYR performs the symmetric rank 1 operation A := alpha*x*x' + A
+func (Reference) DSYR(uplo Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) (ra []float64) {
+	if !checkSyr("DSYR", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	gen.Her(uplo, n, alpha, x, incX, a, lda)
+	return a
+}
+
+// DSPR symmetric packed rank 1 operation A := alpha*x*x' + A
+func (Reference) DSPR(uplo Uplo, n int, alpha float64, x []float64, incX int, ap []float64) (ra []float64) {
+	if !checkSpr("DSPR", uplo, n, len(x), incX, len(ap)) {
+		return ap
+	}
+	gen.Hpr(uplo, n, alpha, x, incX, ap)
+	return ap
+}
+
+// DSYR2 performs the symmetric rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (Reference) DSYR2(uplo Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) (ra []float64) {
+	if !checkSyr2("DSYR2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Her2(uplo, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// DSPR2 performs the symmetric packed rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (Reference) DSPR2(uplo Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64) (ra []float64) {
+	if !checkSpr2("DSPR2", uplo, n, len(x), incX, len(y), incY, len(a)) {
+		return a
+	}
+	gen.Hpr2(uplo, n, alpha, x, incX, y, incY, a)
+	return a
+}
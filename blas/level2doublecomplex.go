@@ -0,0 +1,156 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// ZGEMV matrix vector multiply
+func (Reference) ZGEMV(trans Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkGemv("ZGEMV", colMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gemv(trans, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// ZGBMV banded matrix vector multiply
+func (Reference) ZGBMV(trans Transpose, m, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkGbmv("ZGBMV", colMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gbmv(trans, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// ZHEMV hermitian matrix vector multiply
+func (Reference) ZHEMV(uplo Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkSymv("ZHEMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hemv(uplo, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// ZHBMV hermitian banded matrix vector multiply
+func (Reference) ZHBMV(uplo Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkSbmv("ZHBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hbmv(uplo, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// ZHPMV hermitian packed matrix vector multiply
+func (Reference) ZHPMV(uplo Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkSpmv("ZHPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hpmv(uplo, n, alpha, ap, x, incX, beta, y, incY)
+	return y
+}
+
+// ZTRMV triangular matrix vector multiply
+func (Reference) ZTRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTrmv("ZTRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trmv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// ZTBMV triangular banded matrix vector multiply
+func (Reference) ZTBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTbmv("ZTBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbmv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// ZTPMV triangular packed matrix vector multiply
+func (Reference) ZTPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex128, x []complex128, incX int) (rx []complex128) {
+	if !checkTpmv("ZTPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpmv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// ZTRSV solving triangular matrix problems
+func (Reference) ZTRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTrmv("ZTRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trsv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// ZTBSV solving triangular banded matrix problems
+func (Reference) ZTBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTbmv("ZTBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbsv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// ZTPSV solving triangular packed matrix problems
+func (Reference) ZTPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex128, x []complex128, incX int) (rx []complex128) {
+	if !checkTpmv("ZTPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpsv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// ZGERU performs the rank 1 operation A := alpha*x*y' + A
+func (Reference) ZGERU(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) (ra []complex128) {
+	if !checkGer("ZGERU", colMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Ger(m, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// ZGERC performs the rank 1 operation A := alpha*x*conjg( y' ) + A
+func (Reference) ZGERC(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) (ra []complex128) {
+	if !checkGer("ZGERC", colMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Gerc(m, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// ZHER hermitian rank 1 operation A := alpha*x*conjg(x') + A
+func (Reference) ZHER(uplo Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) (ra []complex128) {
+	if !checkSyr("ZHER", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	gen.Her(uplo, n, complex(alpha, 0), x, incX, a, lda)
+	return a
+}
+
+// ZHPR hermitian packed rank 1 operation A := alpha*x*conjg( x' ) + A
+func (Reference) ZHPR(uplo Uplo, n int, alpha float64, x []complex128, incX int, a []complex128) (ra []complex128) {
+	if !checkSpr("ZHPR", uplo, n, len(x), incX, len(a)) {
+		return a
+	}
+	gen.Hpr(uplo, n, complex(alpha, 0), x, incX, a)
+	return a
+}
+
+// ZHER2 hermitian rank 2 operation
+func (Reference) ZHER2(uplo Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) (ra []complex128) {
+	if !checkSyr2("ZHER2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Her2(uplo, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// ZHPR2 hermitian packed rank 2 operation
+func (Reference) ZHPR2(uplo Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) (ra []complex128) {
+	if !checkSpr2("ZHPR2", uplo, n, len(x), incX, len(y), incY, len(ap)) {
+		return ap
+	}
+	gen.Hpr2(uplo, n, alpha, x, incX, y, incY, ap)
+	return ap
+}
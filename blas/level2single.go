@@ -0,0 +1,147 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// SGEMV matrix vector multiply
+func (Reference) SGEMV(trans Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkGemv("SGEMV", colMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gemv(trans, m, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SGBMV banded matrix vector multiply
+func (Reference) SGBMV(trans Transpose, m, n, kL, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkGbmv("SGBMV", colMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Gbmv(trans, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SSYMV symmetric matrix vector multiply
+func (Reference) SSYMV(uplo Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkSymv("SSYMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hemv(uplo, n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SSBMV symmetric banded matrix vector multiply
+func (Reference) SSBMV(uplo Uplo, n, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkSbmv("SSBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hbmv(uplo, n, k, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SSPMV symmetric packed matrix vector multiply
+func (Reference) SSPMV(uplo Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkSpmv("SSPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	gen.Hpmv(uplo, n, alpha, ap, x, incX, beta, y, incY)
+	return y
+}
+
+// STRMV triangular matrix vector multiply
+func (Reference) STRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTrmv("STRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trmv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// STBMV triangular banded matrix vector multiply
+func (Reference) STBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTbmv("STBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbmv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// STPMV triangular packed matrix vector multiply
+func (Reference) STPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float32, x []float32, incX int) (rx []float32) {
+	if !checkTpmv("STPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpmv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// STRSV solving triangular matrix problems
+func (Reference) STRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTrmv("STRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Trsv(uplo, trans, diag, n, a, lda, x, incX)
+	return x
+}
+
+// STBSV solving triangular banded matrix problems
+func (Reference) STBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTbmv("STBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	gen.Tbsv(uplo, trans, diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// STPSV solving triangular packed matrix problems
+func (Reference) STPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float32, x []float32, incX int) (rx []float32) {
+	if !checkTpmv("STPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	gen.Tpsv(uplo, trans, diag, n, ap, x, incX)
+	return x
+}
+
+// SGER performs the rank 1 operation A := alpha*x*y' + A
+func (Reference) SGER(m, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) (ra []float32) {
+	if !checkGer("SGER", colMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Ger(m, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// SSYR performs the symmetric rank 1 operation A := alpha*x*x' + A
+func (Reference) SSYR(uplo Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) (ra []float32) {
+	if !checkSyr("SSYR", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	gen.Her(uplo, n, alpha, x, incX, a, lda)
+	return a
+}
+
+// SSPR symmetric packed rank 1 operation A := alpha*x*x' + A
+func (Reference) SSPR(uplo Uplo, n int, alpha float32, x []float32, incX int, ap []float32) (ra []float32) {
+	if !checkSpr("SSPR", uplo, n, len(x), incX, len(ap)) {
+		return ap
+	}
+	gen.Hpr(uplo, n, alpha, x, incX, ap)
+	return ap
+}
+
+// SSYR2 performs the symmetric rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (Reference) SSYR2(uplo Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) (ra []float32) {
+	if !checkSyr2("SSYR2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	gen.Her2(uplo, n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// SSPR2 performs the symmetric packed rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (Reference) SSPR2(uplo Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32) (ra []float32) {
+	if !checkSpr2("SSPR2", uplo, n, len(x), incX, len(y), incY, len(a)) {
+		return a
+	}
+	gen.Hpr2(uplo, n, alpha, x, incX, y, incY, a)
+	return a
+}
@@ -0,0 +1,313 @@
+package blas
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// mulMM returns op(A)*op(B) for the dense column-major A and B, where op(A)
+// is m×k and op(B) is k×n.
+func mulMM(tA, tB Transpose, m, n, k int, a []complex128, ra int, b []complex128, rb int) []complex128 {
+	c := make([]complex128, m*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			var s complex128
+			for l := 0; l < k; l++ {
+				s += opElem(tA, ra, a, i, l) * opElem(tB, rb, b, l, j)
+			}
+			c[i+j*m] = s
+		}
+	}
+	return c
+}
+
+// level3 holds the Level 3 routines of one precision. The real precisions
+// use the symmetric routines as the Hermitian ones.
+type level3[T scalar, R float32 | float64] struct {
+	gemm       func(Transpose, Transpose, int, int, int, T, []T, int, []T, int, T, []T, int) []T
+	symm, hemm func(Side, Uplo, int, int, T, []T, int, []T, int, T, []T, int) []T
+	syrk       func(Uplo, Transpose, int, int, T, []T, int, T, []T, int) []T
+	herk       func(Uplo, Transpose, int, int, R, []T, int, R, []T, int) []T
+	syr2k      func(Uplo, Transpose, int, int, T, []T, int, []T, int, T, []T, int) []T
+	her2k      func(Uplo, Transpose, int, int, T, []T, int, []T, int, R, []T, int) []T
+	trmm, trsm func(Side, Uplo, Transpose, Diag, int, int, T, []T, int, []T, int) []T
+}
+
+func TestLevel3(t *testing.T) {
+	var r Reference
+	testLevel3(t, "S", level3[float32, float32]{
+		r.SGEMM, r.SSYMM, r.SSYMM, r.SSYRK, r.SSYRK, r.SSYR2K, r.SSYR2K, r.STRMM, r.STRSM,
+	})
+	testLevel3(t, "D", level3[float64, float64]{
+		r.DGEMM, r.DSYMM, r.DSYMM, r.DSYRK, r.DSYRK, r.DSYR2K, r.DSYR2K, r.DTRMM, r.DTRSM,
+	})
+	testLevel3(t, "C", level3[complex64, float32]{
+		r.CGEMM, r.CSYMM, r.CHEMM, r.CSYRK, r.CHERK, r.CSYR2K, r.CHER2K, r.CTRMM, r.CTRSM,
+	})
+	testLevel3(t, "Z", level3[complex128, float64]{
+		r.ZGEMM, r.ZSYMM, r.ZHEMM, r.ZSYRK, r.ZHERK, r.ZSYR2K, r.ZHER2K, r.ZTRMM, r.ZTRSM,
+	})
+}
+
+func testLevel3[T scalar, R float32 | float64](t *testing.T, prec string, f level3[T, R]) {
+	rnd := rand.New(rand.NewSource(1))
+	cplx, tol := isCmplx[T](), tolOf[T]()
+	trans := []Transpose{TransN, TransT}
+	if cplx {
+		trans = append(trans, TransC)
+	}
+	rand := func(n int) []complex128 { return toC(toT[T](randC(rnd, n, cplx))) }
+	check := func(name string, got, want []complex128) {
+		t.Helper()
+		if !closeTo(got, want, tol) {
+			t.Errorf("%s%s:\ngot  %v\nwant %v", prec, name, got, want)
+		}
+	}
+	alpha, ca := scal[T](complex(0.75, -0.5))
+	beta, cb := scal[T](complex(-0.25, 0.5))
+	ralpha, rbeta := R(0.75), R(-0.25)
+	// mat returns the dense r×c matrix a stored with leading dimension
+	// r+3, larger than required.
+	mat := func(r, c int, a []complex128) ([]T, int, storage) {
+		ld := r + 3
+		s := general(false, ld)
+		return toT[T](store(s, r, c, max(1, c*ld), a)), ld, s
+	}
+
+	// The last shape crosses the block sizes of the blocked routines.
+	for _, sh := range [][3]int{{0, 3, 2}, {3, 0, 2}, {4, 3, 0}, {1, 1, 1}, {5, 4, 3}, {7, 9, 11}, {67, 70, 65}} {
+		m, n, k := sh[0], sh[1], sh[2]
+		big := m > 16
+		for _, tA := range trans {
+			for _, tB := range trans {
+				if big && (tA == TransC || tB == TransC) {
+					continue
+				}
+				ra, cA := m, k
+				if tA != TransN {
+					ra, cA = k, m
+				}
+				rb, cB := k, n
+				if tB != TransN {
+					rb, cB = n, k
+				}
+				a, b, c := rand(ra*cA), rand(rb*cB), rand(m*n)
+				want := mulMM(tA, tB, m, n, k, a, ra, b, rb)
+				for i := range want {
+					want[i] = ca*want[i] + cb*c[i]
+				}
+				if k == 0 {
+					for i := range want {
+						want[i] = cb * c[i]
+					}
+				}
+				at, lda, _ := mat(ra, cA, a)
+				bt, ldb, _ := mat(rb, cB, b)
+				ct, ldc, sc := mat(m, n, c)
+				got := f.gemm(tA, tB, m, n, k, alpha, at, lda, bt, ldb, beta, ct, ldc)
+				check(fmt.Sprintf("GEMM(%c,%c) m=%d n=%d k=%d", tA, tB, m, n, k), stored(sc, m, n, toC(got)), want)
+
+				// C is not read when beta is zero.
+				if tA == TransN && tB == TransN {
+					nan := make([]complex128, m*n)
+					for i := range nan {
+						nan[i] = complex(math.NaN(), 0)
+					}
+					ct, ldc, sc := mat(m, n, nan)
+					want := mulMM(tA, tB, m, n, k, a, ra, b, rb)
+					for i := range want {
+						want[i] *= ca
+					}
+					got := f.gemm(tA, tB, m, n, k, alpha, at, lda, bt, ldb, 0, ct, ldc)
+					check(fmt.Sprintf("GEMM(%c,%c) beta=0 m=%d n=%d k=%d", tA, tB, m, n, k), stored(sc, m, n, toC(got)), want)
+				}
+			}
+		}
+		if m == 0 || n == 0 {
+			continue
+		}
+
+		for _, uplo := range []Uplo{UploU, UploL} {
+			for _, side := range []Side{SideL, SideR} {
+				na := m
+				if side == SideR {
+					na = n
+				}
+				// Symmetric and Hermitian products.
+				for _, herm := range []bool{false, true} {
+					if herm && !cplx {
+						continue
+					}
+					a, b, c := rand(na*na), rand(m*n), rand(m*n)
+					h := hermOf(herm, uplo, na, a)
+					var want []complex128
+					if side == SideL {
+						want = mulMM(TransN, TransN, m, n, m, h, m, b, m)
+					} else {
+						want = mulMM(TransN, TransN, m, n, n, b, m, h, n)
+					}
+					for i := range want {
+						want[i] = ca*want[i] + cb*c[i]
+					}
+					at, lda, _ := mat(na, na, a)
+					bt, ldb, _ := mat(m, n, b)
+					ct, ldc, sc := mat(m, n, c)
+					symm, name := f.symm, "SYMM"
+					if herm {
+						symm, name = f.hemm, "HEMM"
+					}
+					got := symm(side, uplo, m, n, alpha, at, lda, bt, ldb, beta, ct, ldc)
+					check(fmt.Sprintf("%s side=%c uplo=%c m=%d n=%d", name, side, uplo, m, n), stored(sc, m, n, toC(got)), want)
+				}
+
+				// Triangular products and solves.
+				a := dominant(na, rand(na*na))
+				b := rand(m * n)
+				for _, tr := range trans {
+					if big && tr == TransC {
+						continue
+					}
+					for _, diag := range []Diag{DiagN, DiagU} {
+						tri := triOf(uplo, diag, na, a)
+						at, lda, _ := mat(na, na, a)
+						for _, solve := range []bool{false, true} {
+							want := make([]complex128, m*n)
+							switch {
+							case solve && side == SideL:
+								// op(A)*X = alpha*B column by column.
+								for j := 0; j < n; j++ {
+									col := make([]complex128, m)
+									for i := range col {
+										col[i] = ca * b[i+j*m]
+									}
+									copy(want[j*m:], solveTri(tr, m, tri, col))
+								}
+							case solve:
+								// X*op(A) = alpha*B is op(A)^T*X^T =
+								// alpha*B^T, solved row by row.
+								tt := TransT
+								switch tr {
+								case TransT:
+									tt = TransN
+								case TransC:
+									tt = TransC
+								}
+								for i := 0; i < m; i++ {
+									row := make([]complex128, n)
+									for j := range row {
+										row[j] = ca * b[i+j*m]
+									}
+									if tr == TransC {
+										// X*A^H = B is A*X^H = B^H.
+										for j := range row {
+											row[j] = cmplx.Conj(row[j])
+										}
+										row = solveTri(TransN, n, tri, row)
+										for j := range row {
+											row[j] = cmplx.Conj(row[j])
+										}
+									} else {
+										row = solveTri(tt, n, tri, row)
+									}
+									for j, v := range row {
+										want[i+j*m] = v
+									}
+								}
+							case side == SideL:
+								want = mulMM(tr, TransN, m, n, m, tri, m, b, m)
+							default:
+								want = mulMM(TransN, tr, m, n, n, b, m, tri, n)
+							}
+							if !solve {
+								for i := range want {
+									want[i] *= ca
+								}
+							}
+							bt, ldb, sb := mat(m, n, b)
+							trm, name := f.trmm, "TRMM"
+							if solve {
+								trm, name = f.trsm, "TRSM"
+							}
+							got := trm(side, uplo, tr, diag, m, n, alpha, at, lda, bt, ldb)
+							check(fmt.Sprintf("%s side=%c uplo=%c trans=%c diag=%c m=%d n=%d", name, side, uplo, tr, diag, m, n), stored(sb, m, n, toC(got)), want)
+						}
+					}
+				}
+			}
+
+			// Rank k and 2k updates of the n×n C; only the uplo triangle
+			// is referenced and updated.
+			for _, tr := range trans {
+				for _, herm := range []bool{false, true} {
+					if herm && !cplx {
+						continue
+					}
+					if (herm && tr == TransT) || (!herm && tr == TransC) {
+						continue
+					}
+					ra, cA := n, k
+					if tr != TransN {
+						ra, cA = k, n
+					}
+					a, b, c := rand(ra*cA), rand(ra*cA), rand(n*n)
+					tB := TransT
+					if herm {
+						tB = TransC
+					}
+					opB := TransN
+					if tr != TransN {
+						opB = tr
+						tB = TransN
+					}
+					// op(A)*op(A)^T or ^H with op(A) n×k.
+					aat := mulMM(opB, tB, n, n, k, a, ra, a, ra)
+					abt := mulMM(opB, tB, n, n, k, a, ra, b, ra)
+					bat := mulMM(opB, tB, n, n, k, b, ra, a, ra)
+					c0 := c
+					if herm {
+						c0 = hermOf(true, uplo, n, c)
+					}
+					want1 := make([]complex128, n*n)
+					want2 := make([]complex128, n*n)
+					for i := range want1 {
+						if herm {
+							want1[i] = complex(float64(ralpha), 0)*aat[i] + complex(float64(rbeta), 0)*c0[i]
+							want2[i] = ca*abt[i] + cmplx.Conj(ca)*bat[i] + complex(float64(rbeta), 0)*c0[i]
+						} else {
+							want1[i] = ca*aat[i] + cb*c0[i]
+							want2[i] = ca*abt[i] + ca*bat[i] + cb*c0[i]
+						}
+					}
+					at, lda, _ := mat(ra, cA, a)
+					bt, ldb, _ := mat(ra, cA, b)
+					ct, ldc, sc := mat(n, n, c)
+					ct2, _, _ := mat(n, n, c)
+					var got1, got2 []T
+					name := "SYRK/SYR2K"
+					if herm {
+						name = "HERK/HER2K"
+						got1 = f.herk(uplo, tr, n, k, ralpha, at, lda, rbeta, ct, ldc)
+						got2 = f.her2k(uplo, tr, n, k, alpha, at, lda, bt, ldb, rbeta, ct2, ldc)
+					} else {
+						got1 = f.syrk(uplo, tr, n, k, alpha, at, lda, beta, ct, ldc)
+						got2 = f.syr2k(uplo, tr, n, k, alpha, at, lda, bt, ldb, beta, ct2, ldc)
+					}
+					// Outside the uplo triangle C keeps its old values.
+					tri := triangle(general(false, n), uplo)
+					for j := 0; j < n; j++ {
+						for i := 0; i < n; i++ {
+							if _, ok := tri(i, j); !ok {
+								want1[i+j*n], want2[i+j*n] = c[i+j*n], c[i+j*n]
+							}
+						}
+					}
+					check(fmt.Sprintf("%s rank k uplo=%c trans=%c n=%d k=%d", name, uplo, tr, n, k), stored(sc, n, n, toC(got1)), want1)
+					check(fmt.Sprintf("%s rank 2k uplo=%c trans=%c n=%d k=%d", name, uplo, tr, n, k), stored(sc, n, n, toC(got2)), want2)
+				}
+			}
+		}
+	}
+}
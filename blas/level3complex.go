@@ -0,0 +1,84 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// CGEMM matrix matrix multiply
+func (Reference) CGEMM(transA, transB Transpose, m, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkGemm("CGEMM", colMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Gemm(transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CSYMM symmetric matrix matrix multiply
+func (Reference) CSYMM(side Side, uplo Uplo, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSymm("CSYMM", colMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Symm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CHEMM hermitian matrix matrix multiply
+func (Reference) CHEMM(side Side, uplo Uplo, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSymm("CHEMM", colMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Hemm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CSYRK symmetric rank-k update to a matrix
+func (Reference) CSYRK(uplo Uplo, trans Transpose, n, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyrk("CSYRK", colMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	gen.Syrk(uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// CHERK hermitian rank-k update to a matrix
+func (Reference) CHERK(uplo Uplo, trans Transpose, n, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyrk("CHERK", colMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	gen.Herk(uplo, trans, n, k, complex(alpha, 0), a, lda, complex(beta, 0), c, ldc)
+	return c
+}
+
+// CSYR2K symmetric rank-2k update to a matrix
+func (Reference) CSYR2K(uplo Uplo, trans Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyr2k("CSYR2K", colMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	gen.Syr2k(uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CHER2K hermitian rank-2k update to a matrix
+func (Reference) CHER2K(uplo Uplo, trans Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyr2k("CHER2K", colMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	gen.Her2k(uplo, trans, n, k, alpha, a, lda, b, ldb, complex(beta, 0), c, ldc)
+	return c
+}
+
+// CTRMM triangular matrix matrix multiply
+func (Reference) CTRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) (rb []complex64) {
+	if !checkTrmm("CTRMM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trmm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
+
+// CTRSM solving triangular matrix with multiple right hand sides
+func (Reference) CTRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) (rb []complex64) {
+	if !checkTrmm("CTRSM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trsm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
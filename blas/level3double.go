@@ -0,0 +1,57 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// DGEMM matrix matrix multiply
+func (Reference) DGEMM(transA, transB Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkGemm("DGEMM", colMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Gemm(transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// DSYMM symmetric matrix matrix multiply
+func (Reference) DSYMM(side Side, uplo Uplo, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkSymm("DSYMM", colMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Symm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// DSYRK symmetric rank-k update to a matrix
+func (Reference) DSYRK(uplo Uplo, trans Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkSyrk("DSYRK", colMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	gen.Syrk(uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// DSYR2K symmetric rank-2k update to a matrix
+func (Reference) DSYR2K(uplo Uplo, trans Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkSyr2k("DSYR2K", colMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	gen.Syr2k(uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// DTRMM triangular matrix matrix multiply
+func (Reference) DTRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) (rb []float64) {
+	if !checkTrmm("DTRMM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trmm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
+
+// DTRSM solving triangular matrix with multiple right hand sides
+func (Reference) DTRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) (rb []float64) {
+	if !checkTrmm("DTRSM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trsm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
@@ -0,0 +1,84 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// ZGEMM matrix matrix multiply
+func (Reference) ZGEMM(transA, transB Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkGemm("ZGEMM", colMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Gemm(transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZSYMM symmetric matrix matrix multiply
+func (Reference) ZSYMM(side Side, uplo Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSymm("ZSYMM", colMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Symm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZHEMM hermitian matrix matrix multiply
+func (Reference) ZHEMM(side Side, uplo Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSymm("ZHEMM", colMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Hemm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZSYRK symmetric rank-k update to a matrix
+func (Reference) ZSYRK(uplo Uplo, trans Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyrk("ZSYRK", colMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	gen.Syrk(uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// ZHERK hermitian rank-k update to a matrix
+func (Reference) ZHERK(uplo Uplo, trans Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyrk("ZHERK", colMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	gen.Herk(uplo, trans, n, k, complex(alpha, 0), a, lda, complex(beta, 0), c, ldc)
+	return c
+}
+
+// ZSYR2K symmetric rank-2k update to a matrix
+func (Reference) ZSYR2K(uplo Uplo, trans Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyr2k("ZSYR2K", colMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	gen.Syr2k(uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZHER2K hermitian rank-2k update to a matrix
+func (Reference) ZHER2K(uplo Uplo, trans Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyr2k("ZHER2K", colMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	gen.Her2k(uplo, trans, n, k, alpha, a, lda, b, ldb, complex(beta, 0), c, ldc)
+	return c
+}
+
+// ZTRMM triangular matrix matrix multiply
+func (Reference) ZTRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) (rb []complex128) {
+	if !checkTrmm("ZTRMM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trmm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
+
+// ZTRSM solving triangular matrix with multiple right hand sides
+func (Reference) ZTRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) (rb []complex128) {
+	if !checkTrmm("ZTRSM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trsm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
@@ -0,0 +1,57 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// SGEMM matrix matrix multiply
+func (Reference) SGEMM(transA, transB Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkGemm("SGEMM", colMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Gemm(transA, transB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// SSYMM symmetric matrix matrix multiply
+func (Reference) SSYMM(side Side, uplo Uplo, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkSymm("SSYMM", colMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	gen.Symm(side, uplo, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// SSYRK symmetric rank-k update to a matrix
+func (Reference) SSYRK(uplo Uplo, trans Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkSyrk("SSYRK", colMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	gen.Syrk(uplo, trans, n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// SSYR2K symmetric rank-2k update to a matrix
+func (Reference) SSYR2K(uplo Uplo, trans Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkSyr2k("SSYR2K", colMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	gen.Syr2k(uplo, trans, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// STRMM triangular matrix matrix multiply
+func (Reference) STRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) (rb []float32) {
+	if !checkTrmm("STRMM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trmm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
+
+// STRSM solving triangular matrix with multiple right hand sides
+func (Reference) STRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) (rb []float32) {
+	if !checkTrmm("STRSM", colMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	gen.Trsm(side, uplo, trans, diag, m, n, alpha, a, lda, b, ldb)
+	return b
+}
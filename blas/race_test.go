@@ -0,0 +1,7 @@
+//go:build race
+
+package blas
+
+// raceEnabled reports whether the tests run under the race detector, whose
+// instrumentation allocates.
+const raceEnabled = true
@@ -0,0 +1,52 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// Reference is a pure Go implementation of BLAS that follows the Netlib
+// reference routines. Vectors and matrices are updated in place, and a call
+// with legal arguments that runs on the calling goroutine does not allocate,
+// so Reference is safe to use in hot loops; the packing buffers of the
+// Level 3 routines are kept in a pool and reused.
+//
+// Large Level 3 calls are split among goroutines, see SetNumThreads. A call
+// of xGEMM, xSYMM, xHEMM, xSYRK, xHERK, xSYR2K, xHER2K, xTRMM, xTRSM,
+// xGEMMContext or xTRSMContext that is split among w goroutines allocates at
+// most 8+w small objects to start and join them, however large the
+// matrices. The batched routines allocate as many on every call, split or
+// not. These counts, like the calls that do not allocate, hold only without
+// the race detector, whose instrumentation allocates.
+//
+// Illegal arguments are reported to the handler installed with
+// SetErrorHandler. The zero value is ready to use.
+type Reference struct{}
+
+var _ BLAS = Reference{}
+
+// GemmBlocking holds the cache block sizes of the packed matrix
+// multiplication used by the Level 3 routines of Reference.
+type GemmBlocking = gen.Blocking
+
+// SetGemmBlocking sets the cache block sizes of the Level 3 routines of
+// Reference and returns the previous ones. Fields that are not positive take
+// their default value.
+func SetGemmBlocking(b GemmBlocking) (previous GemmBlocking) {
+	return gen.SetBlocking(b)
+}
+
+// SetNumThreads sets the number of goroutines the Level 3 routines of
+// Reference may use and returns the previous setting. Zero, the default,
+// follows runtime.GOMAXPROCS; one runs every call on the calling goroutine.
+// Calls with too little work to be worth splitting always run on the calling
+// goroutine.
+func SetNumThreads(n int) (previous int) {
+	return gen.SetNumThreads(n)
+}
+
+// SetDeterministic sets whether the Level 3 routines of Reference must
+// return results bitwise identical to those of a single-threaded call,
+// whatever the number of goroutines, and returns the previous setting. It is
+// off by default, which allows xGEMM to split the inner dimension of
+// products with a small C among goroutines.
+func SetDeterministic(on bool) (previous bool) {
+	return gen.SetDeterministic(on)
+}
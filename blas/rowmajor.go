@@ -0,0 +1,122 @@
+package blas
+
+import (
+	"sync"
+
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// RowMajor provides the Level 2 and Level 3 routines for matrices stored in
+// row-major order, as CBLAS does for CblasRowMajor. The Level 1 routines and
+// the handling of vectors are those of the embedded implementation.
+//
+// A row-major m×n matrix with leading dimension lda holds the same elements
+// as the column-major n×m matrix A**T, so each call is translated to a call
+// of the embedded column-major routine with the dimensions swapped and uplo,
+// trans and side exchanged as needed. The conjugate transpose forms of the
+// general, banded and triangular matrix-vector routines and the Hermitian
+// Level 2 routines are computed on conjugated vectors, for which input
+// vectors are copied to buffers kept in a pool, so that they do not allocate
+// either. Arguments are checked against the row-major layout before the call
+// is translated.
+//
+// The zero value is not usable, as it has no implementation to translate
+// the calls to: the embedded BLAS must be set, as NewRowMajor does.
+//
+//	var impl BLAS = NewRowMajor(Reference{})
+type RowMajor struct {
+	BLAS
+}
+
+var _ BLAS = RowMajor{}
+
+// NewRowMajor returns the row-major form of the column-major implementation
+// b, or of Reference if b is nil.
+func NewRowMajor(b BLAS) RowMajor {
+	if b == nil {
+		b = Reference{}
+	}
+	return RowMajor{b}
+}
+
+// flipUplo returns the triangle that holds the transpose of the uplo
+// triangle.
+func flipUplo(u Uplo) Uplo {
+	if u == UploU {
+		return UploL
+	}
+	return UploU
+}
+
+// flipSide returns the side on which the transpose of a matrix multiplies.
+func flipSide(s Side) Side {
+	if s == SideL {
+		return SideR
+	}
+	return SideL
+}
+
+// flipTrans swaps TransN and TransT. TransC is treated as TransT, which is
+// only valid for real matrices.
+func flipTrans(t Transpose) Transpose {
+	if t == TransN {
+		return TransT
+	}
+	return TransN
+}
+
+// flipConjTrans swaps TransN and TransC.
+func flipConjTrans(t Transpose) Transpose {
+	if t == TransN {
+		return TransC
+	}
+	return TransN
+}
+
+// conjPools hold the buffers of conjCopy for complex64 and complex128, so
+// that repeated calls do not allocate.
+var conjPools [2]sync.Pool
+
+func conjPool[T gen.Scalar]() *sync.Pool {
+	var z T
+	if _, ok := any(z).(complex64); ok {
+		return &conjPools[0]
+	}
+	return &conjPools[1]
+}
+
+// conjCopy returns the conjugate of the n elements of x as a vector with
+// unit increment, in a buffer from the pool that must be handed back with
+// putConj.
+func conjCopy[T gen.Scalar](n int, x []T, incX int) *[]T {
+	p, ok := conjPool[T]().Get().(*[]T)
+	if !ok || cap(*p) < n {
+		s := make([]T, n)
+		p = &s
+	}
+	y := (*p)[:n]
+	*p = y
+	ix := 0
+	if incX < 0 {
+		ix = (1 - n) * incX
+	}
+	for i := 0; i < n; i, ix = i+1, ix+incX {
+		y[i] = gen.Conj(x[ix])
+	}
+	return p
+}
+
+// putConj returns a buffer obtained from conjCopy to the pool.
+func putConj[T gen.Scalar](p *[]T) {
+	conjPool[T]().Put(p)
+}
+
+// conjVec conjugates the n elements of x in place.
+func conjVec[T gen.Scalar](n int, x []T, incX int) {
+	if incX < 0 {
+		incX = -incX
+	}
+	for i := 0; i < n*incX; i += incX {
+		x[i] = gen.Conj(x[i])
+	}
+}
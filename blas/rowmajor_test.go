@@ -0,0 +1,570 @@
+package blas
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// The tests of RowMajor store the same logical matrices in row-major and in
+// column-major order, call the RowMajor routine on the first and the
+// column-major routine of Reference on the second, and compare the results
+// element by element.
+
+// storage maps the logical element (i,j) of a matrix to its index in a
+// slice, and reports whether the element is stored at all.
+type storage func(i, j int) (int, bool)
+
+func general(rowMajor bool, ld int) storage {
+	return func(i, j int) (int, bool) {
+		if rowMajor {
+			return i*ld + j, true
+		}
+		return i + j*ld, true
+	}
+}
+
+// triangle restricts s to the uplo triangle.
+func triangle(s storage, uplo Uplo) storage {
+	return func(i, j int) (int, bool) {
+		if (uplo == UploU && i > j) || (uplo == UploL && i < j) {
+			return 0, false
+		}
+		return s(i, j)
+	}
+}
+
+// band stores the kL subdiagonals and kU superdiagonals of a general band
+// matrix, as CBLAS does for the row-major layout.
+func band(rowMajor bool, kL, kU, ld int) storage {
+	return func(i, j int) (int, bool) {
+		if j-i > kU || i-j > kL {
+			return 0, false
+		}
+		if rowMajor {
+			return i*ld + kL + j - i, true
+		}
+		return kU + i - j + j*ld, true
+	}
+}
+
+// triBand stores the uplo triangle of a symmetric, Hermitian or triangular
+// band matrix with k off-diagonals.
+func triBand(rowMajor bool, uplo Uplo, k, ld int) storage {
+	return func(i, j int) (int, bool) {
+		if uplo == UploU {
+			if j < i || j-i > k {
+				return 0, false
+			}
+			if rowMajor {
+				return i*ld + j - i, true
+			}
+			return k + i - j + j*ld, true
+		}
+		if j > i || i-j > k {
+			return 0, false
+		}
+		if rowMajor {
+			return i*ld + k + j - i, true
+		}
+		return i - j + j*ld, true
+	}
+}
+
+// packed stores the uplo triangle of an n×n matrix in packed form.
+func packed(rowMajor bool, uplo Uplo, n int) storage {
+	return func(i, j int) (int, bool) {
+		if uplo == UploU {
+			if j < i {
+				return 0, false
+			}
+			if rowMajor {
+				return i*(2*n-i+1)/2 + j - i, true
+			}
+			return i + j*(j+1)/2, true
+		}
+		if j > i {
+			return 0, false
+		}
+		if rowMajor {
+			return i*(i+1)/2 + j, true
+		}
+		return i - j + j*(2*n-j+1)/2, true
+	}
+}
+
+// store returns a slice of length size holding the stored elements of the
+// m×n logical matrix a, with dense column-major elements, and an arbitrary
+// value elsewhere.
+func store(s storage, m, n, size int, a []complex128) []complex128 {
+	r := make([]complex128, size)
+	for i := range r {
+		r[i] = 999 + 999i
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			if k, ok := s(i, j); ok {
+				r[k] = a[i+j*m]
+			}
+		}
+	}
+	return r
+}
+
+// sameStored reports whether the stored elements of two m×n matrices agree.
+func sameStored(m, n int, s1 storage, a1 []complex128, s2 storage, a2 []complex128) bool {
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			k1, ok := s1(i, j)
+			if !ok {
+				continue
+			}
+			k2, _ := s2(i, j)
+			if cmplx.Abs(a1[k1]-a2[k2]) > 1e-12*math.Max(1, cmplx.Abs(a2[k2])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func randC(rnd *rand.Rand, n int, cplx bool) []complex128 {
+	r := make([]complex128, n)
+	for i := range r {
+		r[i] = complex(rnd.NormFloat64(), 0)
+		if cplx {
+			r[i] += complex(0, rnd.NormFloat64())
+		}
+	}
+	return r
+}
+
+// dominant makes the diagonal of the n×n dense matrix a dominant, so that
+// the triangular solves are well conditioned.
+func dominant(n int, a []complex128) []complex128 {
+	for i := 0; i < n; i++ {
+		a[i+i*n] += complex(float64(2*n), 0)
+	}
+	return a
+}
+
+func realParts(x []complex128) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = real(v)
+	}
+	return r
+}
+
+func cmplxParts(x []float64) []complex128 {
+	r := make([]complex128, len(x))
+	for i, v := range x {
+		r[i] = complex(v, 0)
+	}
+	return r
+}
+
+var (
+	rmUplos  = []Uplo{UploU, UploL}
+	rmDiags  = []Diag{DiagN, DiagU}
+	rmSides  = []Side{SideL, SideR}
+	rmTransD = []Transpose{TransN, TransT}
+	rmTransZ = []Transpose{TransN, TransT, TransC}
+)
+
+func TestNewRowMajor(t *testing.T) {
+	r := NewRowMajor(nil)
+	y := []float64{1, 2}
+	r.DGEMV(TransN, 2, 2, 1, []float64{1, 2, 3, 4}, 2, []float64{1, 1}, 1, 0, y, 1)
+	if y[0] != 3 || y[1] != 7 {
+		t.Errorf("NewRowMajor(nil).DGEMV = %v, want [3 7]", y)
+	}
+}
+
+func TestRowMajorLevel2(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	row, col := NewRowMajor(Reference{}), Reference{}
+	const m, n, kL, kU = 5, 4, 2, 1
+	for _, cplx := range []bool{false, true} {
+		trans := rmTransD
+		if cplx {
+			trans = rmTransZ
+		}
+		for _, incX := range []int{1, -2} {
+			incY := -incX
+			vec := func(n, inc int) []complex128 {
+				if inc < 0 {
+					inc = -inc
+				}
+				return randC(rnd, 1+(n-1)*inc, cplx)
+			}
+			check := func(name string, got, want []complex128) {
+				t.Helper()
+				if !sameC128(got, want, 1e-12) {
+					t.Errorf("%s cplx=%v incX=%d: row-major %v, column-major %v", name, cplx, incX, got, want)
+				}
+			}
+
+			// General and band matrix-vector products.
+			for _, tr := range trans {
+				lx, ly := n, m
+				if tr != TransN {
+					lx, ly = m, n
+				}
+				a := randC(rnd, m*n, cplx)
+				x, y := vec(lx, incX), vec(ly, incY)
+				for _, isBand := range []bool{false, true} {
+					var ar, ac []complex128
+					var lda int
+					if isBand {
+						lda = kL + kU + 2
+						ar = store(band(true, kL, kU, lda), m, n, m*lda, a)
+						ac = store(band(false, kL, kU, lda), m, n, n*lda, a)
+					} else {
+						lda = n + 1
+						ar = store(general(true, lda), m, n, m*lda, a)
+						ac = store(general(false, m+2), m, n, n*(m+2), a)
+					}
+					yr, yc := append([]complex128(nil), y...), append([]complex128(nil), y...)
+					alpha, beta := complex(0.7, 0.3), complex(-0.4, 0.2)
+					name := "GEMV"
+					switch {
+					case cplx && isBand:
+						name = "ZGBMV"
+						row.ZGBMV(tr, m, n, kL, kU, alpha, ar, lda, x, incX, beta, yr, incY)
+						col.ZGBMV(tr, m, n, kL, kU, alpha, ac, kL+kU+2, x, incX, beta, yc, incY)
+					case cplx:
+						name = "ZGEMV"
+						row.ZGEMV(tr, m, n, alpha, ar, lda, x, incX, beta, yr, incY)
+						col.ZGEMV(tr, m, n, alpha, ac, m+2, x, incX, beta, yc, incY)
+					case isBand:
+						name = "DGBMV"
+						yr = cmplxParts(row.DGBMV(tr, m, n, kL, kU, 0.7, realParts(ar), lda, realParts(x), incX, -0.4, realParts(yr), incY))
+						yc = cmplxParts(col.DGBMV(tr, m, n, kL, kU, 0.7, realParts(ac), kL+kU+2, realParts(x), incX, -0.4, realParts(yc), incY))
+					default:
+						name = "DGEMV"
+						yr = cmplxParts(row.DGEMV(tr, m, n, 0.7, realParts(ar), lda, realParts(x), incX, -0.4, realParts(yr), incY))
+						yc = cmplxParts(col.DGEMV(tr, m, n, 0.7, realParts(ac), m+2, realParts(x), incX, -0.4, realParts(yc), incY))
+					}
+					check(name+" "+string(rune(tr)), yr, yc)
+				}
+			}
+
+			// Rank one and two updates of general matrices.
+			{
+				a := randC(rnd, m*n, cplx)
+				x, y := vec(m, incX), vec(n, incY)
+				ar, ac := store(general(true, n+1), m, n, m*(n+1), a), store(general(false, m), m, n, m*n, a)
+				if cplx {
+					ar2, ac2 := append([]complex128(nil), ar...), append([]complex128(nil), ac...)
+					row.ZGERU(m, n, 0.5-1i, x, incX, y, incY, ar, n+1)
+					col.ZGERU(m, n, 0.5-1i, x, incX, y, incY, ac, m)
+					row.ZGERC(m, n, 0.5-1i, x, incX, y, incY, ar2, n+1)
+					col.ZGERC(m, n, 0.5-1i, x, incX, y, incY, ac2, m)
+					if !sameStored(m, n, general(true, n+1), ar2, general(false, m), ac2) {
+						t.Errorf("ZGERC incX=%d differs", incX)
+					}
+				} else {
+					arr, acr := realParts(ar), realParts(ac)
+					row.DGER(m, n, 0.5, realParts(x), incX, realParts(y), incY, arr, n+1)
+					col.DGER(m, n, 0.5, realParts(x), incX, realParts(y), incY, acr, m)
+					ar, ac = cmplxParts(arr), cmplxParts(acr)
+				}
+				if !sameStored(m, n, general(true, n+1), ar, general(false, m), ac) {
+					t.Errorf("GER cplx=%v incX=%d differs", cplx, incX)
+				}
+			}
+
+			for _, uplo := range rmUplos {
+				// Symmetric and Hermitian products, full, band and packed.
+				const k = 2
+				a := randC(rnd, n*n, cplx)
+				x, y := vec(n, incX), vec(n, incY)
+				alpha, beta := complex(1.5, -0.5), complex(0.5, 0.25)
+				for _, form := range []string{"full", "band", "packed"} {
+					var sr, sc storage
+					var size int
+					switch form {
+					case "full":
+						sr, sc, size = triangle(general(true, n+1), uplo), triangle(general(false, n+1), uplo), n*(n+1)
+					case "band":
+						sr, sc, size = triBand(true, uplo, k, k+1), triBand(false, uplo, k, k+1), n*(k+1)
+					case "packed":
+						sr, sc, size = packed(true, uplo, n), packed(false, uplo, n), n*(n+1)/2
+					}
+					ar, ac := store(sr, n, n, size, a), store(sc, n, n, size, a)
+					yr, yc := append([]complex128(nil), y...), append([]complex128(nil), y...)
+					switch {
+					case cplx && form == "full":
+						row.ZHEMV(uplo, n, alpha, ar, n+1, x, incX, beta, yr, incY)
+						col.ZHEMV(uplo, n, alpha, ac, n+1, x, incX, beta, yc, incY)
+					case cplx && form == "band":
+						row.ZHBMV(uplo, n, k, alpha, ar, k+1, x, incX, beta, yr, incY)
+						col.ZHBMV(uplo, n, k, alpha, ac, k+1, x, incX, beta, yc, incY)
+					case cplx:
+						row.ZHPMV(uplo, n, alpha, ar, x, incX, beta, yr, incY)
+						col.ZHPMV(uplo, n, alpha, ac, x, incX, beta, yc, incY)
+					case form == "full":
+						yr = cmplxParts(row.DSYMV(uplo, n, 1.5, realParts(ar), n+1, realParts(x), incX, 0.5, realParts(yr), incY))
+						yc = cmplxParts(col.DSYMV(uplo, n, 1.5, realParts(ac), n+1, realParts(x), incX, 0.5, realParts(yc), incY))
+					case form == "band":
+						yr = cmplxParts(row.DSBMV(uplo, n, k, 1.5, realParts(ar), k+1, realParts(x), incX, 0.5, realParts(yr), incY))
+						yc = cmplxParts(col.DSBMV(uplo, n, k, 1.5, realParts(ac), k+1, realParts(x), incX, 0.5, realParts(yc), incY))
+					default:
+						yr = cmplxParts(row.DSPMV(uplo, n, 1.5, realParts(ar), realParts(x), incX, 0.5, realParts(yr), incY))
+						yc = cmplxParts(col.DSPMV(uplo, n, 1.5, realParts(ac), realParts(x), incX, 0.5, realParts(yc), incY))
+					}
+					check("symmetric/Hermitian "+form+" product uplo="+string(rune(uplo)), yr, yc)
+
+					// Rank one and two updates; there are no band forms.
+					if form == "band" {
+						continue
+					}
+					full := form == "full"
+					ar2, ac2 := append([]complex128(nil), ar...), append([]complex128(nil), ac...)
+					switch {
+					case cplx && full:
+						row.ZHER(uplo, n, 0.5, x, incX, ar, n+1)
+						col.ZHER(uplo, n, 0.5, x, incX, ac, n+1)
+						row.ZHER2(uplo, n, alpha, x, incX, y, incY, ar2, n+1)
+						col.ZHER2(uplo, n, alpha, x, incX, y, incY, ac2, n+1)
+					case cplx:
+						row.ZHPR(uplo, n, 0.5, x, incX, ar)
+						col.ZHPR(uplo, n, 0.5, x, incX, ac)
+						row.ZHPR2(uplo, n, alpha, x, incX, y, incY, ar2)
+						col.ZHPR2(uplo, n, alpha, x, incX, y, incY, ac2)
+					case full:
+						ar = cmplxParts(row.DSYR(uplo, n, 0.5, realParts(x), incX, realParts(ar), n+1))
+						ac = cmplxParts(col.DSYR(uplo, n, 0.5, realParts(x), incX, realParts(ac), n+1))
+						ar2 = cmplxParts(row.DSYR2(uplo, n, 1.5, realParts(x), incX, realParts(y), incY, realParts(ar2), n+1))
+						ac2 = cmplxParts(col.DSYR2(uplo, n, 1.5, realParts(x), incX, realParts(y), incY, realParts(ac2), n+1))
+					default:
+						ar = cmplxParts(row.DSPR(uplo, n, 0.5, realParts(x), incX, realParts(ar)))
+						ac = cmplxParts(col.DSPR(uplo, n, 0.5, realParts(x), incX, realParts(ac)))
+						ar2 = cmplxParts(row.DSPR2(uplo, n, 1.5, realParts(x), incX, realParts(y), incY, realParts(ar2)))
+						ac2 = cmplxParts(col.DSPR2(uplo, n, 1.5, realParts(x), incX, realParts(y), incY, realParts(ac2)))
+					}
+					if !sameStored(n, n, sr, ar, sc, ac) || !sameStored(n, n, sr, ar2, sc, ac2) {
+						t.Errorf("%s rank updates cplx=%v uplo=%c incX=%d differ", form, cplx, uplo, incX)
+					}
+				}
+
+				// Triangular products and solves, full, band and packed.
+				a = dominant(n, randC(rnd, n*n, cplx))
+				for _, tr := range trans {
+					for _, diag := range rmDiags {
+						for _, form := range []string{"full", "band", "packed"} {
+							var sr, sc storage
+							var size int
+							switch form {
+							case "full":
+								sr, sc, size = triangle(general(true, n+1), uplo), triangle(general(false, n+1), uplo), n*(n+1)
+							case "band":
+								sr, sc, size = triBand(true, uplo, k, k+1), triBand(false, uplo, k, k+1), n*(k+1)
+							case "packed":
+								sr, sc, size = packed(true, uplo, n), packed(false, uplo, n), n*(n+1)/2
+							}
+							ar, ac := store(sr, n, n, size, a), store(sc, n, n, size, a)
+							for _, solve := range []bool{false, true} {
+								xr, xc := append([]complex128(nil), x...), append([]complex128(nil), x...)
+								switch {
+								case cplx && form == "full" && solve:
+									row.ZTRSV(uplo, tr, diag, n, ar, n+1, xr, incX)
+									col.ZTRSV(uplo, tr, diag, n, ac, n+1, xc, incX)
+								case cplx && form == "full":
+									row.ZTRMV(uplo, tr, diag, n, ar, n+1, xr, incX)
+									col.ZTRMV(uplo, tr, diag, n, ac, n+1, xc, incX)
+								case cplx && form == "band" && solve:
+									row.ZTBSV(uplo, tr, diag, n, k, ar, k+1, xr, incX)
+									col.ZTBSV(uplo, tr, diag, n, k, ac, k+1, xc, incX)
+								case cplx && form == "band":
+									row.ZTBMV(uplo, tr, diag, n, k, ar, k+1, xr, incX)
+									col.ZTBMV(uplo, tr, diag, n, k, ac, k+1, xc, incX)
+								case cplx && solve:
+									row.ZTPSV(uplo, tr, diag, n, ar, xr, incX)
+									col.ZTPSV(uplo, tr, diag, n, ac, xc, incX)
+								case cplx:
+									row.ZTPMV(uplo, tr, diag, n, ar, xr, incX)
+									col.ZTPMV(uplo, tr, diag, n, ac, xc, incX)
+								case form == "full" && solve:
+									xr = cmplxParts(row.DTRSV(uplo, tr, diag, n, realParts(ar), n+1, realParts(xr), incX))
+									xc = cmplxParts(col.DTRSV(uplo, tr, diag, n, realParts(ac), n+1, realParts(xc), incX))
+								case form == "full":
+									xr = cmplxParts(row.DTRMV(uplo, tr, diag, n, realParts(ar), n+1, realParts(xr), incX))
+									xc = cmplxParts(col.DTRMV(uplo, tr, diag, n, realParts(ac), n+1, realParts(xc), incX))
+								case form == "band" && solve:
+									xr = cmplxParts(row.DTBSV(uplo, tr, diag, n, k, realParts(ar), k+1, realParts(xr), incX))
+									xc = cmplxParts(col.DTBSV(uplo, tr, diag, n, k, realParts(ac), k+1, realParts(xc), incX))
+								case form == "band":
+									xr = cmplxParts(row.DTBMV(uplo, tr, diag, n, k, realParts(ar), k+1, realParts(xr), incX))
+									xc = cmplxParts(col.DTBMV(uplo, tr, diag, n, k, realParts(ac), k+1, realParts(xc), incX))
+								case solve:
+									xr = cmplxParts(row.DTPSV(uplo, tr, diag, n, realParts(ar), realParts(xr), incX))
+									xc = cmplxParts(col.DTPSV(uplo, tr, diag, n, realParts(ac), realParts(xc), incX))
+								default:
+									xr = cmplxParts(row.DTPMV(uplo, tr, diag, n, realParts(ar), realParts(xr), incX))
+									xc = cmplxParts(col.DTPMV(uplo, tr, diag, n, realParts(ac), realParts(xc), incX))
+								}
+								check("triangular "+form+" solve="+boolStr(solve)+" uplo="+string(rune(uplo))+" trans="+string(rune(tr))+" diag="+string(rune(diag)), xr, xc)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func boolStr(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func TestRowMajorLevel3(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	row, col := NewRowMajor(Reference{}), Reference{}
+	const m, n, k = 6, 5, 4
+	for _, cplx := range []bool{false, true} {
+		trans := rmTransD
+		if cplx {
+			trans = rmTransZ
+		}
+		// pair returns the logical r×c matrix a stored row-major with
+		// leading dimension c+1 and column-major with leading dimension r+2.
+		pair := func(r, c int, a []complex128) (ar []complex128, ldr int, sr storage, ac []complex128, ldc int, sc storage) {
+			ldr, ldc = c+1, r+2
+			sr, sc = general(true, ldr), general(false, ldc)
+			return store(sr, r, c, r*ldr, a), ldr, sr, store(sc, r, c, c*ldc, a), ldc, sc
+		}
+		alpha, beta := complex(0.75, -0.5), complex(-0.25, 0.5)
+
+		for _, tA := range trans {
+			for _, tB := range trans {
+				ra, ca := m, k
+				if tA != TransN {
+					ra, ca = k, m
+				}
+				rb, cb := k, n
+				if tB != TransN {
+					rb, cb = n, k
+				}
+				ar, lar, _, ac, lac, _ := pair(ra, ca, randC(rnd, ra*ca, cplx))
+				br, lbr, _, bc, lbc, _ := pair(rb, cb, randC(rnd, rb*cb, cplx))
+				cr, lcr, scr, cc, lcc, scc := pair(m, n, randC(rnd, m*n, cplx))
+				if cplx {
+					row.ZGEMM(tA, tB, m, n, k, alpha, ar, lar, br, lbr, beta, cr, lcr)
+					col.ZGEMM(tA, tB, m, n, k, alpha, ac, lac, bc, lbc, beta, cc, lcc)
+				} else {
+					cr = cmplxParts(row.DGEMM(tA, tB, m, n, k, 0.75, realParts(ar), lar, realParts(br), lbr, -0.25, realParts(cr), lcr))
+					cc = cmplxParts(col.DGEMM(tA, tB, m, n, k, 0.75, realParts(ac), lac, realParts(bc), lbc, -0.25, realParts(cc), lcc))
+				}
+				if !sameStored(m, n, scr, cr, scc, cc) {
+					t.Errorf("GEMM cplx=%v transA=%c transB=%c differs", cplx, tA, tB)
+				}
+			}
+		}
+
+		for _, uplo := range rmUplos {
+			for _, side := range rmSides {
+				na := m
+				if side == SideR {
+					na = n
+				}
+				// Symmetric and Hermitian products.
+				for _, herm := range []bool{false, true} {
+					if herm && !cplx {
+						continue
+					}
+					a := randC(rnd, na*na, cplx)
+					ar, lar, _, ac, lac, _ := pair(na, na, a)
+					br, lbr, _, bc, lbc, _ := pair(m, n, randC(rnd, m*n, cplx))
+					cr, lcr, scr, cc, lcc, scc := pair(m, n, randC(rnd, m*n, cplx))
+					switch {
+					case herm:
+						row.ZHEMM(side, uplo, m, n, alpha, ar, lar, br, lbr, beta, cr, lcr)
+						col.ZHEMM(side, uplo, m, n, alpha, ac, lac, bc, lbc, beta, cc, lcc)
+					case cplx:
+						row.ZSYMM(side, uplo, m, n, alpha, ar, lar, br, lbr, beta, cr, lcr)
+						col.ZSYMM(side, uplo, m, n, alpha, ac, lac, bc, lbc, beta, cc, lcc)
+					default:
+						cr = cmplxParts(row.DSYMM(side, uplo, m, n, 0.75, realParts(ar), lar, realParts(br), lbr, -0.25, realParts(cr), lcr))
+						cc = cmplxParts(col.DSYMM(side, uplo, m, n, 0.75, realParts(ac), lac, realParts(bc), lbc, -0.25, realParts(cc), lcc))
+					}
+					if !sameStored(m, n, scr, cr, scc, cc) {
+						t.Errorf("SYMM/HEMM cplx=%v herm=%v side=%c uplo=%c differs", cplx, herm, side, uplo)
+					}
+				}
+
+				// Triangular products and solves.
+				a := dominant(na, randC(rnd, na*na, cplx))
+				for _, tr := range trans {
+					for _, diag := range rmDiags {
+						ar, lar, _, ac, lac, _ := pair(na, na, a)
+						b := randC(rnd, m*n, cplx)
+						for _, solve := range []bool{false, true} {
+							br, lbr, sbr, bc, lbc, sbc := pair(m, n, b)
+							switch {
+							case cplx && solve:
+								row.ZTRSM(side, uplo, tr, diag, m, n, alpha, ar, lar, br, lbr)
+								col.ZTRSM(side, uplo, tr, diag, m, n, alpha, ac, lac, bc, lbc)
+							case cplx:
+								row.ZTRMM(side, uplo, tr, diag, m, n, alpha, ar, lar, br, lbr)
+								col.ZTRMM(side, uplo, tr, diag, m, n, alpha, ac, lac, bc, lbc)
+							case solve:
+								br = cmplxParts(row.DTRSM(side, uplo, tr, diag, m, n, 0.75, realParts(ar), lar, realParts(br), lbr))
+								bc = cmplxParts(col.DTRSM(side, uplo, tr, diag, m, n, 0.75, realParts(ac), lac, realParts(bc), lbc))
+							default:
+								br = cmplxParts(row.DTRMM(side, uplo, tr, diag, m, n, 0.75, realParts(ar), lar, realParts(br), lbr))
+								bc = cmplxParts(col.DTRMM(side, uplo, tr, diag, m, n, 0.75, realParts(ac), lac, realParts(bc), lbc))
+							}
+							if !sameStored(m, n, sbr, br, sbc, bc) {
+								t.Errorf("TRMM/TRSM cplx=%v solve=%v side=%c uplo=%c trans=%c diag=%c differs", cplx, solve, side, uplo, tr, diag)
+							}
+						}
+					}
+				}
+			}
+
+			// Rank k and 2k updates.
+			for _, tr := range trans {
+				for _, herm := range []bool{false, true} {
+					if (herm && (!cplx || tr == TransT)) || (!herm && cplx && tr == TransC) {
+						continue
+					}
+					ra, ca := n, k
+					if tr != TransN {
+						ra, ca = k, n
+					}
+					ar, lar, _, ac, lac, _ := pair(ra, ca, randC(rnd, ra*ca, cplx))
+					br, lbr, _, bc, lbc, _ := pair(ra, ca, randC(rnd, ra*ca, cplx))
+					c := randC(rnd, n*n, cplx)
+					cr, lcr, scr, cc, lcc, scc := pair(n, n, c)
+					cr2, _, _, cc2, _, _ := pair(n, n, c)
+					switch {
+					case herm:
+						row.ZHERK(uplo, tr, n, k, 0.75, ar, lar, -0.25, cr, lcr)
+						col.ZHERK(uplo, tr, n, k, 0.75, ac, lac, -0.25, cc, lcc)
+						row.ZHER2K(uplo, tr, n, k, alpha, ar, lar, br, lbr, -0.25, cr2, lcr)
+						col.ZHER2K(uplo, tr, n, k, alpha, ac, lac, bc, lbc, -0.25, cc2, lcc)
+					case cplx:
+						row.ZSYRK(uplo, tr, n, k, alpha, ar, lar, beta, cr, lcr)
+						col.ZSYRK(uplo, tr, n, k, alpha, ac, lac, beta, cc, lcc)
+						row.ZSYR2K(uplo, tr, n, k, alpha, ar, lar, br, lbr, beta, cr2, lcr)
+						col.ZSYR2K(uplo, tr, n, k, alpha, ac, lac, bc, lbc, beta, cc2, lcc)
+					default:
+						cr = cmplxParts(row.DSYRK(uplo, tr, n, k, 0.75, realParts(ar), lar, -0.25, realParts(cr), lcr))
+						cc = cmplxParts(col.DSYRK(uplo, tr, n, k, 0.75, realParts(ac), lac, -0.25, realParts(cc), lcc))
+						cr2 = cmplxParts(row.DSYR2K(uplo, tr, n, k, 0.75, realParts(ar), lar, realParts(br), lbr, -0.25, realParts(cr2), lcr))
+						cc2 = cmplxParts(col.DSYR2K(uplo, tr, n, k, 0.75, realParts(ac), lac, realParts(bc), lbc, -0.25, realParts(cc2), lcc))
+					}
+					if !sameStored(n, n, scr, cr, scc, cc) || !sameStored(n, n, scr, cr2, scc, cc2) {
+						t.Errorf("rank updates cplx=%v herm=%v uplo=%c trans=%c differ", cplx, herm, uplo, tr)
+					}
+				}
+			}
+		}
+	}
+}
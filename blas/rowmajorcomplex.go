@@ -0,0 +1,313 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// CGEMV matrix vector multiply
+func (r RowMajor) CGEMV(trans Transpose, m, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkGemv("CGEMV", rowMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	if trans == TransC {
+		xc := conjCopy(m, x, incX)
+		defer putConj(xc)
+		conjVec(n, y, incY)
+		r.BLAS.CGEMV(TransN, n, m, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+		conjVec(n, y, incY)
+		return y
+	}
+	r.BLAS.CGEMV(flipTrans(trans), n, m, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// CGBMV banded matrix vector multiply
+func (r RowMajor) CGBMV(trans Transpose, m, n, kL, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkGbmv("CGBMV", rowMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	if trans == TransC {
+		xc := conjCopy(m, x, incX)
+		defer putConj(xc)
+		conjVec(n, y, incY)
+		r.BLAS.CGBMV(TransN, n, m, kU, kL, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+		conjVec(n, y, incY)
+		return y
+	}
+	r.BLAS.CGBMV(flipTrans(trans), n, m, kU, kL, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// CHEMV hermitian matrix vector multiply
+func (r RowMajor) CHEMV(uplo Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkSymv("CHEMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	conjVec(n, y, incY)
+	r.BLAS.CHEMV(flipUplo(uplo), n, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+	conjVec(n, y, incY)
+	return y
+}
+
+// CHBMV hermitian banded matrix vector multiply
+func (r RowMajor) CHBMV(uplo Uplo, n, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkSbmv("CHBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	conjVec(n, y, incY)
+	r.BLAS.CHBMV(flipUplo(uplo), n, k, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+	conjVec(n, y, incY)
+	return y
+}
+
+// CHPMV hermitian packed matrix vector multiply
+func (r RowMajor) CHPMV(uplo Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) (ry []complex64) {
+	if !checkSpmv("CHPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	conjVec(n, y, incY)
+	r.BLAS.CHPMV(flipUplo(uplo), n, gen.Conj(alpha), ap, *xc, 1, gen.Conj(beta), y, incY)
+	conjVec(n, y, incY)
+	return y
+}
+
+// CTRMV triangular matrix vector multiply
+func (r RowMajor) CTRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTrmv("CTRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.CTRMV(flipUplo(uplo), TransN, diag, n, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.CTRMV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// CTBMV triangular banded matrix vector multiply
+func (r RowMajor) CTBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTbmv("CTBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.CTBMV(flipUplo(uplo), TransN, diag, n, k, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.CTBMV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// CTPMV triangular packed matrix vector multiply
+func (r RowMajor) CTPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex64, x []complex64, incX int) (rx []complex64) {
+	if !checkTpmv("CTPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.CTPMV(flipUplo(uplo), TransN, diag, n, ap, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.CTPMV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// CTRSV solving triangular matrix problems
+func (r RowMajor) CTRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTrmv("CTRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.CTRSV(flipUplo(uplo), TransN, diag, n, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.CTRSV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// CTBSV solving triangular banded matrix problems
+func (r RowMajor) CTBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex64, lda int, x []complex64, incX int) (rx []complex64) {
+	if !checkTbmv("CTBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.CTBSV(flipUplo(uplo), TransN, diag, n, k, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.CTBSV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// CTPSV solving triangular packed matrix problems
+func (r RowMajor) CTPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex64, x []complex64, incX int) (rx []complex64) {
+	if !checkTpmv("CTPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.CTPSV(flipUplo(uplo), TransN, diag, n, ap, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.CTPSV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// CGERU performs the rank 1 operation A := alpha*x*y' + A
+func (r RowMajor) CGERU(m, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) (ra []complex64) {
+	if !checkGer("CGERU", rowMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	r.BLAS.CGERU(n, m, alpha, y, incY, x, incX, a, lda)
+	return a
+}
+
+// CGERC performs the rank 1 operation A := alpha*x*conjg( y' ) + A
+func (r RowMajor) CGERC(m, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) (ra []complex64) {
+	if !checkGer("CGERC", rowMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	yc := conjCopy(n, y, incY)
+	defer putConj(yc)
+	r.BLAS.CGERU(n, m, alpha, *yc, 1, x, incX, a, lda)
+	return a
+}
+
+// CHER hermitian rank 1 operation A := alpha*x*conjg(x') + A
+func (r RowMajor) CHER(uplo Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) (ra []complex64) {
+	if !checkSyr("CHER", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	r.BLAS.CHER(flipUplo(uplo), n, alpha, *xc, 1, a, lda)
+	return a
+}
+
+// CHPR hermitian packed rank 1 operation A := alpha*x*conjg( x' ) + A
+func (r RowMajor) CHPR(uplo Uplo, n int, alpha float32, x []complex64, incX int, a []complex64) (ra []complex64) {
+	if !checkSpr("CHPR", uplo, n, len(x), incX, len(a)) {
+		return a
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	r.BLAS.CHPR(flipUplo(uplo), n, alpha, *xc, 1, a)
+	return a
+}
+
+// CHER2 hermitian rank 2 operation
+func (r RowMajor) CHER2(uplo Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) (ra []complex64) {
+	if !checkSyr2("CHER2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	yc, xc := conjCopy(n, y, incY), conjCopy(n, x, incX)
+	defer putConj(yc)
+	defer putConj(xc)
+	r.BLAS.CHER2(flipUplo(uplo), n, alpha, *yc, 1, *xc, 1, a, lda)
+	return a
+}
+
+// CHPR2 hermitian packed rank 2 operation
+func (r RowMajor) CHPR2(uplo Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) (ra []complex64) {
+	if !checkSpr2("CHPR2", uplo, n, len(x), incX, len(y), incY, len(ap)) {
+		return ap
+	}
+	yc, xc := conjCopy(n, y, incY), conjCopy(n, x, incX)
+	defer putConj(yc)
+	defer putConj(xc)
+	r.BLAS.CHPR2(flipUplo(uplo), n, alpha, *yc, 1, *xc, 1, ap)
+	return ap
+}
+
+// CGEMM matrix matrix multiply
+func (r RowMajor) CGEMM(transA, transB Transpose, m, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkGemm("CGEMM", rowMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.CGEMM(transB, transA, n, m, k, alpha, b, ldb, a, lda, beta, c, ldc)
+	return c
+}
+
+// CSYMM symmetric matrix matrix multiply
+func (r RowMajor) CSYMM(side Side, uplo Uplo, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSymm("CSYMM", rowMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.CSYMM(flipSide(side), flipUplo(uplo), n, m, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CHEMM hermitian matrix matrix multiply
+func (r RowMajor) CHEMM(side Side, uplo Uplo, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSymm("CHEMM", rowMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.CHEMM(flipSide(side), flipUplo(uplo), n, m, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CSYRK symmetric rank-k update to a matrix
+func (r RowMajor) CSYRK(uplo Uplo, trans Transpose, n, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyrk("CSYRK", rowMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	r.BLAS.CSYRK(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// CHERK hermitian rank-k update to a matrix
+func (r RowMajor) CHERK(uplo Uplo, trans Transpose, n, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyrk("CHERK", rowMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	r.BLAS.CHERK(flipUplo(uplo), flipConjTrans(trans), n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// CSYR2K symmetric rank-2k update to a matrix
+func (r RowMajor) CSYR2K(uplo Uplo, trans Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyr2k("CSYR2K", rowMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	r.BLAS.CSYR2K(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CHER2K hermitian rank-2k update to a matrix
+func (r RowMajor) CHER2K(uplo Uplo, trans Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) (rc []complex64) {
+	if !checkSyr2k("CHER2K", rowMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	r.BLAS.CHER2K(flipUplo(uplo), flipConjTrans(trans), n, k, gen.Conj(alpha), a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// CTRMM triangular matrix matrix multiply
+func (r RowMajor) CTRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) (rb []complex64) {
+	if !checkTrmm("CTRMM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.CTRMM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
+
+// CTRSM solving triangular matrix with multiple right hand sides
+func (r RowMajor) CTRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) (rb []complex64) {
+	if !checkTrmm("CTRSM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.CTRSM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
@@ -0,0 +1,199 @@
+package blas
+
+// DGEMV matrix vector multiply
+func (r RowMajor) DGEMV(trans Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkGemv("DGEMV", rowMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.DGEMV(flipTrans(trans), n, m, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DGBMV banded matrix vector multiply
+func (r RowMajor) DGBMV(trans Transpose, m, n, kL, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkGbmv("DGBMV", rowMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.DGBMV(flipTrans(trans), n, m, kU, kL, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DSYMV symmetric matrix vector multiply
+func (r RowMajor) DSYMV(uplo Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkSymv("DSYMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.DSYMV(flipUplo(uplo), n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DSBMV symmetric banded matrix vector multiply
+func (r RowMajor) DSBMV(uplo Uplo, n, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkSbmv("DSBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.DSBMV(flipUplo(uplo), n, k, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// DSPMV symmetric packed matrix vector multiply
+func (r RowMajor) DSPMV(uplo Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) (ry []float64) {
+	if !checkSpmv("DSPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.DSPMV(flipUplo(uplo), n, alpha, ap, x, incX, beta, y, incY)
+	return y
+}
+
+// DTRMV triangular matrix vector multiply
+func (r RowMajor) DTRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTrmv("DTRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.DTRMV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// DTBMV triangular banded matrix vector multiply
+func (r RowMajor) DTBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTbmv("DTBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.DTBMV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// DTPMV triangular packed matrix vector multiply
+func (r RowMajor) DTPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float64, x []float64, incX int) (rx []float64) {
+	if !checkTpmv("DTPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	r.BLAS.DTPMV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// DTRSV solving triangular matrix problems
+func (r RowMajor) DTRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTrmv("DTRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.DTRSV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// DTBSV solving triangular banded matrix problems
+func (r RowMajor) DTBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float64, lda int, x []float64, incX int) (rx []float64) {
+	if !checkTbmv("DTBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.DTBSV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// DTPSV solving triangular packed matrix problems
+func (r RowMajor) DTPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float64, x []float64, incX int) (rx []float64) {
+	if !checkTpmv("DTPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	r.BLAS.DTPSV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// DGER performs the rank 1 operation A := alpha*x*y' + A
+func (r RowMajor) DGER(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) (ra []float64) {
+	if !checkGer("DGER", rowMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	r.BLAS.DGER(n, m, alpha, y, incY, x, incX, a, lda)
+	return a
+}
+
+// DSYR performs the symmetric rank 1 operation A := alpha*x*x' + A
+func (r RowMajor) DSYR(uplo Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) (ra []float64) {
+	if !checkSyr("DSYR", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	r.BLAS.DSYR(flipUplo(uplo), n, alpha, x, incX, a, lda)
+	return a
+}
+
+// DSPR symmetric packed rank 1 operation A := alpha*x*x' + A
+func (r RowMajor) DSPR(uplo Uplo, n int, alpha float64, x []float64, incX int, ap []float64) (ra []float64) {
+	if !checkSpr("DSPR", uplo, n, len(x), incX, len(ap)) {
+		return ap
+	}
+	r.BLAS.DSPR(flipUplo(uplo), n, alpha, x, incX, ap)
+	return ap
+}
+
+// DSYR2 performs the symmetric rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (r RowMajor) DSYR2(uplo Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) (ra []float64) {
+	if !checkSyr2("DSYR2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	r.BLAS.DSYR2(flipUplo(uplo), n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// DSPR2 performs the symmetric packed rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (r RowMajor) DSPR2(uplo Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64) (ra []float64) {
+	if !checkSpr2("DSPR2", uplo, n, len(x), incX, len(y), incY, len(a)) {
+		return a
+	}
+	r.BLAS.DSPR2(flipUplo(uplo), n, alpha, x, incX, y, incY, a)
+	return a
+}
+
+// DGEMM matrix matrix multiply
+func (r RowMajor) DGEMM(transA, transB Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkGemm("DGEMM", rowMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.DGEMM(transB, transA, n, m, k, alpha, b, ldb, a, lda, beta, c, ldc)
+	return c
+}
+
+// DSYMM symmetric matrix matrix multiply
+func (r RowMajor) DSYMM(side Side, uplo Uplo, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkSymm("DSYMM", rowMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.DSYMM(flipSide(side), flipUplo(uplo), n, m, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// DSYRK symmetric rank-k update to a matrix
+func (r RowMajor) DSYRK(uplo Uplo, trans Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkSyrk("DSYRK", rowMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	r.BLAS.DSYRK(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// DSYR2K symmetric rank-2k update to a matrix
+func (r RowMajor) DSYR2K(uplo Uplo, trans Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) (rc []float64) {
+	if !checkSyr2k("DSYR2K", rowMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	r.BLAS.DSYR2K(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// DTRMM triangular matrix matrix multiply
+func (r RowMajor) DTRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) (rb []float64) {
+	if !checkTrmm("DTRMM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.DTRMM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
+
+// DTRSM solving triangular matrix with multiple right hand sides
+func (r RowMajor) DTRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) (rb []float64) {
+	if !checkTrmm("DTRSM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.DTRSM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
@@ -0,0 +1,313 @@
+package blas
+
+import "github.com/visionom/lapack/blas/gen"
+
+// ZGEMV matrix vector multiply
+func (r RowMajor) ZGEMV(trans Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkGemv("ZGEMV", rowMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	if trans == TransC {
+		xc := conjCopy(m, x, incX)
+		defer putConj(xc)
+		conjVec(n, y, incY)
+		r.BLAS.ZGEMV(TransN, n, m, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+		conjVec(n, y, incY)
+		return y
+	}
+	r.BLAS.ZGEMV(flipTrans(trans), n, m, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// ZGBMV banded matrix vector multiply
+func (r RowMajor) ZGBMV(trans Transpose, m, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkGbmv("ZGBMV", rowMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	if trans == TransC {
+		xc := conjCopy(m, x, incX)
+		defer putConj(xc)
+		conjVec(n, y, incY)
+		r.BLAS.ZGBMV(TransN, n, m, kU, kL, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+		conjVec(n, y, incY)
+		return y
+	}
+	r.BLAS.ZGBMV(flipTrans(trans), n, m, kU, kL, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// ZHEMV hermitian matrix vector multiply
+func (r RowMajor) ZHEMV(uplo Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkSymv("ZHEMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	conjVec(n, y, incY)
+	r.BLAS.ZHEMV(flipUplo(uplo), n, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+	conjVec(n, y, incY)
+	return y
+}
+
+// ZHBMV hermitian banded matrix vector multiply
+func (r RowMajor) ZHBMV(uplo Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkSbmv("ZHBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	conjVec(n, y, incY)
+	r.BLAS.ZHBMV(flipUplo(uplo), n, k, gen.Conj(alpha), a, lda, *xc, 1, gen.Conj(beta), y, incY)
+	conjVec(n, y, incY)
+	return y
+}
+
+// ZHPMV hermitian packed matrix vector multiply
+func (r RowMajor) ZHPMV(uplo Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) (ry []complex128) {
+	if !checkSpmv("ZHPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	conjVec(n, y, incY)
+	r.BLAS.ZHPMV(flipUplo(uplo), n, gen.Conj(alpha), ap, *xc, 1, gen.Conj(beta), y, incY)
+	conjVec(n, y, incY)
+	return y
+}
+
+// ZTRMV triangular matrix vector multiply
+func (r RowMajor) ZTRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTrmv("ZTRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.ZTRMV(flipUplo(uplo), TransN, diag, n, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.ZTRMV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// ZTBMV triangular banded matrix vector multiply
+func (r RowMajor) ZTBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTbmv("ZTBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.ZTBMV(flipUplo(uplo), TransN, diag, n, k, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.ZTBMV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// ZTPMV triangular packed matrix vector multiply
+func (r RowMajor) ZTPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex128, x []complex128, incX int) (rx []complex128) {
+	if !checkTpmv("ZTPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.ZTPMV(flipUplo(uplo), TransN, diag, n, ap, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.ZTPMV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// ZTRSV solving triangular matrix problems
+func (r RowMajor) ZTRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTrmv("ZTRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.ZTRSV(flipUplo(uplo), TransN, diag, n, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.ZTRSV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// ZTBSV solving triangular banded matrix problems
+func (r RowMajor) ZTBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []complex128, lda int, x []complex128, incX int) (rx []complex128) {
+	if !checkTbmv("ZTBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.ZTBSV(flipUplo(uplo), TransN, diag, n, k, a, lda, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.ZTBSV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// ZTPSV solving triangular packed matrix problems
+func (r RowMajor) ZTPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []complex128, x []complex128, incX int) (rx []complex128) {
+	if !checkTpmv("ZTPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	if trans == TransC {
+		conjVec(n, x, incX)
+		r.BLAS.ZTPSV(flipUplo(uplo), TransN, diag, n, ap, x, incX)
+		conjVec(n, x, incX)
+		return x
+	}
+	r.BLAS.ZTPSV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// ZGERU performs the rank 1 operation A := alpha*x*y' + A
+func (r RowMajor) ZGERU(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) (ra []complex128) {
+	if !checkGer("ZGERU", rowMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	r.BLAS.ZGERU(n, m, alpha, y, incY, x, incX, a, lda)
+	return a
+}
+
+// ZGERC performs the rank 1 operation A := alpha*x*conjg( y' ) + A
+func (r RowMajor) ZGERC(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) (ra []complex128) {
+	if !checkGer("ZGERC", rowMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	yc := conjCopy(n, y, incY)
+	defer putConj(yc)
+	r.BLAS.ZGERU(n, m, alpha, *yc, 1, x, incX, a, lda)
+	return a
+}
+
+// ZHER hermitian rank 1 operation A := alpha*x*conjg(x') + A
+func (r RowMajor) ZHER(uplo Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) (ra []complex128) {
+	if !checkSyr("ZHER", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	r.BLAS.ZHER(flipUplo(uplo), n, alpha, *xc, 1, a, lda)
+	return a
+}
+
+// ZHPR hermitian packed rank 1 operation A := alpha*x*conjg( x' ) + A
+func (r RowMajor) ZHPR(uplo Uplo, n int, alpha float64, x []complex128, incX int, a []complex128) (ra []complex128) {
+	if !checkSpr("ZHPR", uplo, n, len(x), incX, len(a)) {
+		return a
+	}
+	xc := conjCopy(n, x, incX)
+	defer putConj(xc)
+	r.BLAS.ZHPR(flipUplo(uplo), n, alpha, *xc, 1, a)
+	return a
+}
+
+// ZHER2 hermitian rank 2 operation
+func (r RowMajor) ZHER2(uplo Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) (ra []complex128) {
+	if !checkSyr2("ZHER2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	yc, xc := conjCopy(n, y, incY), conjCopy(n, x, incX)
+	defer putConj(yc)
+	defer putConj(xc)
+	r.BLAS.ZHER2(flipUplo(uplo), n, alpha, *yc, 1, *xc, 1, a, lda)
+	return a
+}
+
+// ZHPR2 hermitian packed rank 2 operation
+func (r RowMajor) ZHPR2(uplo Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) (ra []complex128) {
+	if !checkSpr2("ZHPR2", uplo, n, len(x), incX, len(y), incY, len(ap)) {
+		return ap
+	}
+	yc, xc := conjCopy(n, y, incY), conjCopy(n, x, incX)
+	defer putConj(yc)
+	defer putConj(xc)
+	r.BLAS.ZHPR2(flipUplo(uplo), n, alpha, *yc, 1, *xc, 1, ap)
+	return ap
+}
+
+// ZGEMM matrix matrix multiply
+func (r RowMajor) ZGEMM(transA, transB Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkGemm("ZGEMM", rowMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.ZGEMM(transB, transA, n, m, k, alpha, b, ldb, a, lda, beta, c, ldc)
+	return c
+}
+
+// ZSYMM symmetric matrix matrix multiply
+func (r RowMajor) ZSYMM(side Side, uplo Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSymm("ZSYMM", rowMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.ZSYMM(flipSide(side), flipUplo(uplo), n, m, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZHEMM hermitian matrix matrix multiply
+func (r RowMajor) ZHEMM(side Side, uplo Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSymm("ZHEMM", rowMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.ZHEMM(flipSide(side), flipUplo(uplo), n, m, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZSYRK symmetric rank-k update to a matrix
+func (r RowMajor) ZSYRK(uplo Uplo, trans Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyrk("ZSYRK", rowMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	r.BLAS.ZSYRK(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// ZHERK hermitian rank-k update to a matrix
+func (r RowMajor) ZHERK(uplo Uplo, trans Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyrk("ZHERK", rowMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	r.BLAS.ZHERK(flipUplo(uplo), flipConjTrans(trans), n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// ZSYR2K symmetric rank-2k update to a matrix
+func (r RowMajor) ZSYR2K(uplo Uplo, trans Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyr2k("ZSYR2K", rowMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT) {
+		return c
+	}
+	r.BLAS.ZSYR2K(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZHER2K hermitian rank-2k update to a matrix
+func (r RowMajor) ZHER2K(uplo Uplo, trans Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) (rc []complex128) {
+	if !checkSyr2k("ZHER2K", rowMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransC) {
+		return c
+	}
+	r.BLAS.ZHER2K(flipUplo(uplo), flipConjTrans(trans), n, k, gen.Conj(alpha), a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// ZTRMM triangular matrix matrix multiply
+func (r RowMajor) ZTRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) (rb []complex128) {
+	if !checkTrmm("ZTRMM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.ZTRMM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
+
+// ZTRSM solving triangular matrix with multiple right hand sides
+func (r RowMajor) ZTRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) (rb []complex128) {
+	if !checkTrmm("ZTRSM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.ZTRSM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
@@ -0,0 +1,199 @@
+package blas
+
+// SGEMV matrix vector multiply
+func (r RowMajor) SGEMV(trans Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkGemv("SGEMV", rowMajor, trans, m, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.SGEMV(flipTrans(trans), n, m, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SGBMV banded matrix vector multiply
+func (r RowMajor) SGBMV(trans Transpose, m, n, kL, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkGbmv("SGBMV", rowMajor, trans, m, n, kL, kU, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.SGBMV(flipTrans(trans), n, m, kU, kL, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SSYMV symmetric matrix vector multiply
+func (r RowMajor) SSYMV(uplo Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkSymv("SSYMV", uplo, n, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.SSYMV(flipUplo(uplo), n, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SSBMV symmetric banded matrix vector multiply
+func (r RowMajor) SSBMV(uplo Uplo, n, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkSbmv("SSBMV", uplo, n, k, len(a), lda, len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.SSBMV(flipUplo(uplo), n, k, alpha, a, lda, x, incX, beta, y, incY)
+	return y
+}
+
+// SSPMV symmetric packed matrix vector multiply
+func (r RowMajor) SSPMV(uplo Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) (ry []float32) {
+	if !checkSpmv("SSPMV", uplo, n, len(ap), len(x), incX, len(y), incY) {
+		return y
+	}
+	r.BLAS.SSPMV(flipUplo(uplo), n, alpha, ap, x, incX, beta, y, incY)
+	return y
+}
+
+// STRMV triangular matrix vector multiply
+func (r RowMajor) STRMV(uplo Uplo, trans Transpose, diag Diag, n int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTrmv("STRMV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.STRMV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// STBMV triangular banded matrix vector multiply
+func (r RowMajor) STBMV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTbmv("STBMV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.STBMV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// STPMV triangular packed matrix vector multiply
+func (r RowMajor) STPMV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float32, x []float32, incX int) (rx []float32) {
+	if !checkTpmv("STPMV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	r.BLAS.STPMV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// STRSV solving triangular matrix problems
+func (r RowMajor) STRSV(uplo Uplo, trans Transpose, diag Diag, n int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTrmv("STRSV", uplo, trans, diag, n, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.STRSV(flipUplo(uplo), flipTrans(trans), diag, n, a, lda, x, incX)
+	return x
+}
+
+// STBSV solving triangular banded matrix problems
+func (r RowMajor) STBSV(uplo Uplo, trans Transpose, diag Diag, n, k int, a []float32, lda int, x []float32, incX int) (rx []float32) {
+	if !checkTbmv("STBSV", uplo, trans, diag, n, k, len(a), lda, len(x), incX) {
+		return x
+	}
+	r.BLAS.STBSV(flipUplo(uplo), flipTrans(trans), diag, n, k, a, lda, x, incX)
+	return x
+}
+
+// STPSV solving triangular packed matrix problems
+func (r RowMajor) STPSV(uplo Uplo, trans Transpose, diag Diag, n int, ap []float32, x []float32, incX int) (rx []float32) {
+	if !checkTpmv("STPSV", uplo, trans, diag, n, len(ap), len(x), incX) {
+		return x
+	}
+	r.BLAS.STPSV(flipUplo(uplo), flipTrans(trans), diag, n, ap, x, incX)
+	return x
+}
+
+// SGER performs the rank 1 operation A := alpha*x*y' + A
+func (r RowMajor) SGER(m, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) (ra []float32) {
+	if !checkGer("SGER", rowMajor, m, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	r.BLAS.SGER(n, m, alpha, y, incY, x, incX, a, lda)
+	return a
+}
+
+// SSYR performs the symmetric rank 1 operation A := alpha*x*x' + A
+func (r RowMajor) SSYR(uplo Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) (ra []float32) {
+	if !checkSyr("SSYR", uplo, n, len(x), incX, len(a), lda) {
+		return a
+	}
+	r.BLAS.SSYR(flipUplo(uplo), n, alpha, x, incX, a, lda)
+	return a
+}
+
+// SSPR symmetric packed rank 1 operation A := alpha*x*x' + A
+func (r RowMajor) SSPR(uplo Uplo, n int, alpha float32, x []float32, incX int, ap []float32) (ra []float32) {
+	if !checkSpr("SSPR", uplo, n, len(x), incX, len(ap)) {
+		return ap
+	}
+	r.BLAS.SSPR(flipUplo(uplo), n, alpha, x, incX, ap)
+	return ap
+}
+
+// SSYR2 performs the symmetric rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (r RowMajor) SSYR2(uplo Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) (ra []float32) {
+	if !checkSyr2("SSYR2", uplo, n, len(x), incX, len(y), incY, len(a), lda) {
+		return a
+	}
+	r.BLAS.SSYR2(flipUplo(uplo), n, alpha, x, incX, y, incY, a, lda)
+	return a
+}
+
+// SSPR2 performs the symmetric packed rank 2 operation, A := alpha*x*y' + alpha*y*x' + A
+func (r RowMajor) SSPR2(uplo Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32) (ra []float32) {
+	if !checkSpr2("SSPR2", uplo, n, len(x), incX, len(y), incY, len(a)) {
+		return a
+	}
+	r.BLAS.SSPR2(flipUplo(uplo), n, alpha, x, incX, y, incY, a)
+	return a
+}
+
+// SGEMM matrix matrix multiply
+func (r RowMajor) SGEMM(transA, transB Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkGemm("SGEMM", rowMajor, transA, transB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.SGEMM(transB, transA, n, m, k, alpha, b, ldb, a, lda, beta, c, ldc)
+	return c
+}
+
+// SSYMM symmetric matrix matrix multiply
+func (r RowMajor) SSYMM(side Side, uplo Uplo, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkSymm("SSYMM", rowMajor, side, uplo, m, n, len(a), lda, len(b), ldb, len(c), ldc) {
+		return c
+	}
+	r.BLAS.SSYMM(flipSide(side), flipUplo(uplo), n, m, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// SSYRK symmetric rank-k update to a matrix
+func (r RowMajor) SSYRK(uplo Uplo, trans Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkSyrk("SSYRK", rowMajor, uplo, trans, n, k, len(a), lda, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	r.BLAS.SSYRK(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, beta, c, ldc)
+	return c
+}
+
+// SSYR2K symmetric rank-2k update to a matrix
+func (r RowMajor) SSYR2K(uplo Uplo, trans Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) (rc []float32) {
+	if !checkSyr2k("SSYR2K", rowMajor, uplo, trans, n, k, len(a), lda, len(b), ldb, len(c), ldc, TransN, TransT, TransC) {
+		return c
+	}
+	r.BLAS.SSYR2K(flipUplo(uplo), flipTrans(trans), n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	return c
+}
+
+// STRMM triangular matrix matrix multiply
+func (r RowMajor) STRMM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) (rb []float32) {
+	if !checkTrmm("STRMM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.STRMM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
+
+// STRSM solving triangular matrix with multiple right hand sides
+func (r RowMajor) STRSM(side Side, uplo Uplo, trans Transpose, diag Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) (rb []float32) {
+	if !checkTrmm("STRSM", rowMajor, side, uplo, trans, diag, m, n, len(a), lda, len(b), ldb) {
+		return b
+	}
+	r.BLAS.STRSM(flipSide(side), flipUplo(uplo), trans, diag, n, m, alpha, a, lda, b, ldb)
+	return b
+}
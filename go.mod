@@ -0,0 +1,3 @@
+module github.com/visionom/lapack
+
+go 1.24
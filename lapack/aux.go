@@ -0,0 +1,469 @@
+package lapack
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// lacgv conjugates the n elements of x with increment incX. It does nothing
+// for real types.
+func lacgv[T gen.Scalar](n int, x []T, incX int) {
+	if !isComplex[T]() {
+		return
+	}
+	ix := 0
+	if incX < 0 {
+		ix = (1 - n) * incX
+	}
+	for i := 0; i < n; i, ix = i+1, ix+incX {
+		x[ix] = conj(x[ix])
+	}
+}
+
+// uploAll is passed as uplo to the auxiliary routines below that act on a
+// triangle or on the whole of a matrix, as any other UPLO does in LAPACK.
+const uploAll blas.Uplo = 'A'
+
+// lacpy copies the upper (uplo U) or lower (uplo L) triangle, or all
+// (uplo uploAll), of the m×n matrix A to B.
+func lacpy[T gen.Scalar](uplo blas.Uplo, m, n int, a []T, lda int, b []T, ldb int) {
+	for j := 0; j < n; j++ {
+		i0, i1 := 0, m
+		switch uplo {
+		case blas.UploU:
+			i1 = min(j+1, m)
+		case blas.UploL:
+			i0 = min(j, m)
+		}
+		copy(b[i0+j*ldb:i1+j*ldb], a[i0+j*lda:i1+j*lda])
+	}
+}
+
+// lacp2 copies the m×n real matrix A to B, as xLACP2 with UPLO = 'A'.
+func lacp2[T gen.Scalar, R gen.Float](m, n int, a []R, lda int, b []T, ldb int) {
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			b[i+j*ldb] = fromReal[T](float64(a[i+j*lda]))
+		}
+	}
+}
+
+// laset sets the strictly upper (uplo U) or strictly lower (uplo L)
+// triangle, or all off-diagonal elements (uplo uploAll), of the m×n matrix
+// A to alpha and its diagonal to beta.
+func laset[T gen.Scalar](uplo blas.Uplo, m, n int, alpha, beta T, a []T, lda int) {
+	for j := 0; j < n; j++ {
+		i0, i1 := 0, m
+		switch uplo {
+		case blas.UploU:
+			i1 = min(j, m)
+		case blas.UploL:
+			i0 = min(j+1, m)
+		}
+		for i := i0; i < i1; i++ {
+			if i != j {
+				a[i+j*lda] = alpha
+			}
+		}
+	}
+	for i := 0; i < min(m, n); i++ {
+		a[i+i*lda] = beta
+	}
+}
+
+// lascl multiplies the upper (uplo U) or lower (uplo L) triangle, or all
+// (uplo uploAll), of the m×n matrix A by cto/cfrom without overflow or
+// underflow in the intermediate steps, as xLASCL. cfrom must be nonzero.
+func lascl[T gen.Scalar](uplo blas.Uplo, cfrom, cto float64, m, n int, a []T, lda int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	smlnum := safmin[T]()
+	bignum := 1 / smlnum
+	for done := false; !done; {
+		var mul float64
+		cfrom1 := cfrom * smlnum
+		if cfrom1 == cfrom {
+			// cfrom is infinite: the result is a signed zero or NaN.
+			mul, done = cto/cfrom, true
+		} else if cto1 := cto / bignum; cto1 == cto {
+			// cto is zero or infinite.
+			mul, done, cfrom = cto, true, 1
+		} else if math.Abs(cfrom1) > math.Abs(cto) && cto != 0 {
+			mul, cfrom = smlnum, cfrom1
+		} else if math.Abs(cto1) > math.Abs(cfrom) {
+			mul, cto = bignum, cto1
+		} else {
+			mul, done = cto/cfrom, true
+			if mul == 1 {
+				return
+			}
+		}
+		f := fromReal[T](mul)
+		for j := 0; j < n; j++ {
+			i0, i1 := 0, m
+			switch uplo {
+			case blas.UploU:
+				i1 = min(j+1, m)
+			case blas.UploL:
+				i0 = min(j, m)
+			}
+			col := a[j*lda : j*lda+m]
+			for i := i0; i < i1; i++ {
+				col[i] *= f
+			}
+		}
+	}
+}
+
+// matNorm selects the matrix norm computed by lange and lanst.
+type matNorm byte
+
+const (
+	normMax  matNorm = 'M' // largest absolute value, not a consistent norm
+	normOne  matNorm = 'O' // largest column sum of absolute values
+	normInf  matNorm = 'I' // largest row sum of absolute values
+	normFrob matNorm = 'F' // square root of the sum of squares
+)
+
+// lassq updates the scaled sum of squares scale**2*sumsq with the squares
+// of the moduli of the n elements of x with increment incX, and returns the
+// new scale and sumsq.
+func lassq[T gen.Scalar](n int, x []T, incX int, scale, sumsq float64) (float64, float64) {
+	add := func(v float64) {
+		if v == 0 {
+			return
+		}
+		v = math.Abs(v)
+		if scale < v {
+			sumsq = 1 + sumsq*(scale/v)*(scale/v)
+			scale = v
+		} else {
+			sumsq += (v / scale) * (v / scale)
+		}
+	}
+	for i, ix := 0, 0; i < n; i, ix = i+1, ix+incX {
+		add(re(x[ix]))
+		add(im(x[ix]))
+	}
+	return scale, sumsq
+}
+
+// lange returns the norm of the m×n matrix A selected by norm. The
+// maximum of a NaN is NaN.
+func lange[T gen.Scalar](norm matNorm, m, n int, a []T, lda int) float64 {
+	if m == 0 || n == 0 {
+		return 0
+	}
+	var value float64
+	bigger := func(v float64) {
+		if value < v || math.IsNaN(v) {
+			value = v
+		}
+	}
+	switch norm {
+	case normMax:
+		for j := 0; j < n; j++ {
+			for _, v := range a[j*lda : j*lda+m] {
+				bigger(abs(v))
+			}
+		}
+	case normOne:
+		for j := 0; j < n; j++ {
+			var sum float64
+			for _, v := range a[j*lda : j*lda+m] {
+				sum += abs(v)
+			}
+			bigger(sum)
+		}
+	case normInf:
+		for i := 0; i < m; i++ {
+			var sum float64
+			for j := 0; j < n; j++ {
+				sum += abs(a[i+j*lda])
+			}
+			bigger(sum)
+		}
+	case normFrob:
+		scale, sumsq := 0.0, 1.0
+		for j := 0; j < n; j++ {
+			scale, sumsq = lassq(m, a[j*lda:], 1, scale, sumsq)
+		}
+		value = scale * math.Sqrt(sumsq)
+	}
+	return value
+}
+
+// lanst returns the norm selected by norm of the n×n symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e.
+func lanst[R gen.Float](norm matNorm, n int, d, e []R) float64 {
+	if n == 0 {
+		return 0
+	}
+	var value float64
+	bigger := func(v float64) {
+		if value < v || math.IsNaN(v) {
+			value = v
+		}
+	}
+	switch norm {
+	case normMax:
+		for i := 0; i < n; i++ {
+			bigger(math.Abs(float64(d[i])))
+		}
+		for i := 0; i < n-1; i++ {
+			bigger(math.Abs(float64(e[i])))
+		}
+	case normOne, normInf:
+		for i := 0; i < n; i++ {
+			sum := math.Abs(float64(d[i]))
+			if i > 0 {
+				sum += math.Abs(float64(e[i-1]))
+			}
+			if i < n-1 {
+				sum += math.Abs(float64(e[i]))
+			}
+			bigger(sum)
+		}
+	case normFrob:
+		scale, sumsq := 0.0, 1.0
+		if n > 1 {
+			scale, sumsq = lassq(n-1, e, 1, scale, sumsq)
+			sumsq *= 2
+		}
+		scale, sumsq = lassq(n, d, 1, scale, sumsq)
+		value = scale * math.Sqrt(sumsq)
+	}
+	return value
+}
+
+// lanhe returns the largest absolute value of the elements of the n×n
+// Hermitian matrix A of which only the uplo triangle is referenced, as
+// xLANHE and xLANSY with NORM = 'M'. The imaginary parts of the diagonal
+// are taken to be zero. The maximum of a NaN is NaN.
+func lanhe[T gen.Scalar](uplo blas.Uplo, n int, a []T, lda int) float64 {
+	var value float64
+	bigger := func(v float64) {
+		if value < v || math.IsNaN(v) {
+			value = v
+		}
+	}
+	for j := 0; j < n; j++ {
+		i0, i1 := 0, j
+		if uplo == blas.UploL {
+			i0, i1 = j+1, n
+		}
+		for _, v := range a[i0+j*lda : i1+j*lda] {
+			bigger(abs(v))
+		}
+		bigger(math.Abs(re(a[j+j*lda])))
+	}
+	return value
+}
+
+// lapy2 returns sqrt(x**2+y**2) without unnecessary overflow.
+func lapy2(x, y float64) float64 {
+	return lapy3(x, y, 0)
+}
+
+// lartg generates a plane rotation with real cosine c and sine s such that
+// [c s; -s c] * [f; g] = [r; 0], with c > 0 unless f is zero, as the
+// LAPACK 3.10 xLARTG. The computation is made in float64 and is free of
+// overflow and underflow for the inputs of either precision.
+func lartg(f, g float64) (c, s, r float64) {
+	const (
+		safmin = 0x1p-1022
+		safmax = 1 / safmin
+	)
+	if g == 0 {
+		return 1, 0, f
+	}
+	if f == 0 {
+		return 0, math.Copysign(1, g), math.Abs(g)
+	}
+	rtmin, rtmax := math.Sqrt(safmin), math.Sqrt(safmax/2)
+	f1, g1 := math.Abs(f), math.Abs(g)
+	if rtmin < f1 && f1 < rtmax && rtmin < g1 && g1 < rtmax {
+		d := math.Sqrt(f*f + g*g)
+		r = math.Copysign(d, f)
+		return f1 / d, g / r, r
+	}
+	u := min(safmax, max(safmin, f1, g1))
+	fs, gs := f/u, g/u
+	d := math.Sqrt(fs*fs + gs*gs)
+	r = math.Copysign(d, f)
+	return math.Abs(fs) / d, gs / r, r * u
+}
+
+// clartg generates a plane rotation with real cosine c and complex sine s
+// such that [c s; -conj(s) c] * [f; g] = [r; 0], with c >= 0, as xLARTG for
+// complex types. The computation is made in complex128.
+func clartg[T gen.Scalar](f, g T) (c float64, s, r T) {
+	fc, gc := toC128(f), toC128(g)
+	if gc == 0 {
+		return 1, 0, f
+	}
+	if fc == 0 {
+		ga := cmplx.Abs(gc)
+		return 0, fromC128[T](cmplx.Conj(gc) / complex(ga, 0)), fromReal[T](ga)
+	}
+	fa := cmplx.Abs(fc)
+	d := math.Hypot(fa, cmplx.Abs(gc))
+	sgn := fc / complex(fa, 0)
+	return fa / d, fromC128[T](sgn * cmplx.Conj(gc) / complex(d, 0)), fromC128[T](sgn * complex(d, 0))
+}
+
+// lasr applies a sequence of real plane rotations to the m×n matrix A from
+// the left (side L) or the right (side R). Rotation j, [c[j] s[j];
+// -s[j] c[j]], acts on the rows or columns j and j+1, and the rotations are
+// applied for j = 0, 1, ... (direct F) or in the reverse order (direct B),
+// as xLASR with PIVOT = 'V'. c and s hold m-1 elements for side L and n-1
+// for side R.
+func lasr[T gen.Scalar, R gen.Float](bl blas.BLAS, side blas.Side, direct Direct, m, n int, c, s []R, a []T, lda int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	nrot := n - 1
+	if side == blas.SideL {
+		nrot = m - 1
+	}
+	for k := 0; k < nrot; k++ {
+		j := k
+		if direct == DirectB {
+			j = nrot - 1 - k
+		}
+		cj, sj := float64(c[j]), float64(s[j])
+		if cj == 1 && sj == 0 {
+			continue
+		}
+		if side == blas.SideL {
+			rrot(bl, n, a[j:], lda, a[j+1:], lda, cj, sj)
+		} else {
+			rrot(bl, m, a[j*lda:], 1, a[(j+1)*lda:], 1, cj, sj)
+		}
+	}
+}
+
+// las2 returns the smaller and larger singular values of the 2×2 upper
+// triangular matrix [f g; 0 h].
+func las2(f, g, h float64) (ssmin, ssmax float64) {
+	fa, ga, ha := math.Abs(f), math.Abs(g), math.Abs(h)
+	fhmin, fhmax := min(fa, ha), max(fa, ha)
+	if fhmin == 0 {
+		if fhmax == 0 {
+			return 0, ga
+		}
+		v := min(fhmax, ga) / max(fhmax, ga)
+		return 0, max(fhmax, ga) * math.Sqrt(1+v*v)
+	}
+	if ga < fhmax {
+		as := 1 + fhmin/fhmax
+		at := (fhmax - fhmin) / fhmax
+		au := (ga / fhmax) * (ga / fhmax)
+		c := 2 / (math.Sqrt(as*as+au) + math.Sqrt(at*at+au))
+		return fhmin * c, fhmax / c
+	}
+	au := fhmax / ga
+	if au == 0 {
+		// Avoid underflow in the computation of ssmin.
+		return fhmin * fhmax / ga, ga
+	}
+	as := 1 + fhmin/fhmax
+	at := (fhmax - fhmin) / fhmax
+	c := 1 / (math.Sqrt(1+(as*au)*(as*au)) + math.Sqrt(1+(at*au)*(at*au)))
+	return 2 * (fhmin * c) * au, ga / (c + c)
+}
+
+// lasv2 computes the singular value decomposition of the 2×2 upper
+// triangular matrix [f g; 0 h]:
+//
+//	[ csl snl] [f g] [csr -snr] = [ssmax     0]
+//	[-snl csl] [0 h] [snr  csr]   [    0 ssmin]
+//
+// where |ssmax| >= |ssmin|.
+func lasv2(f, g, h float64) (ssmin, ssmax, snr, csr, snl, csl float64) {
+	ft, fa, ht, ha := f, math.Abs(f), h, math.Abs(h)
+	// pmax points to the largest element of the matrix: 1 for f, 2 for g
+	// and 3 for h.
+	pmax := 1
+	swap := ha > fa
+	if swap {
+		pmax = 3
+		ft, ht = ht, ft
+		fa, ha = ha, fa
+	}
+	gt, ga := g, math.Abs(g)
+	var clt, crt, slt, srt float64
+	if ga == 0 {
+		// The matrix is diagonal.
+		ssmin, ssmax = ha, fa
+		clt, crt, slt, srt = 1, 1, 0, 0
+	} else {
+		gasmall := true
+		if ga > fa {
+			pmax = 2
+			if fa/ga < 0x1p-53 {
+				// g is very large compared to f and h.
+				gasmall = false
+				ssmax = ga
+				if ha > 1 {
+					ssmin = fa / (ga / ha)
+				} else {
+					ssmin = (fa / ga) * ha
+				}
+				clt, slt = 1, ht/gt
+				srt, crt = 1, ft/gt
+			}
+		}
+		if gasmall {
+			d := fa - ha
+			l := d / fa
+			if d == fa {
+				// Copes with infinite f or h.
+				l = 1
+			}
+			m := gt / ft
+			t := 2 - l
+			s := math.Hypot(t, m)
+			r := math.Abs(m)
+			if l != 0 {
+				r = math.Hypot(l, m)
+			}
+			a := 0.5 * (s + r)
+			ssmin, ssmax = ha/a, fa*a
+			switch {
+			case m == 0 && l == 0:
+				t = math.Copysign(2, ft) * math.Copysign(1, gt)
+			case m == 0:
+				t = gt/math.Copysign(d, ft) + m/t
+			default:
+				t = (m/(s+t) + m/(r+l)) * (1 + a)
+			}
+			l = math.Hypot(t, 2)
+			crt, srt = 2/l, t/l
+			clt = (crt + srt*m) / a
+			slt = (ht / ft) * srt / a
+		}
+	}
+	if swap {
+		csl, snl, csr, snr = srt, crt, slt, clt
+	} else {
+		csl, snl, csr, snr = clt, slt, crt, srt
+	}
+	// Correct the signs of ssmax and ssmin.
+	var tsign float64
+	switch pmax {
+	case 1:
+		tsign = math.Copysign(1, csr) * math.Copysign(1, csl) * math.Copysign(1, f)
+	case 2:
+		tsign = math.Copysign(1, snr) * math.Copysign(1, csl) * math.Copysign(1, g)
+	case 3:
+		tsign = math.Copysign(1, snr) * math.Copysign(1, snl) * math.Copysign(1, h)
+	}
+	ssmax = math.Copysign(ssmax, tsign)
+	ssmin = math.Copysign(ssmin, tsign*math.Copysign(1, f)*math.Copysign(1, h))
+	return ssmin, ssmax, snr, csr, snl, csl
+}
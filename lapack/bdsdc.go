@@ -0,0 +1,484 @@
+package lapack
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SBDSDC computes the singular value decomposition B = U*S*VT of the n×n
+// real upper (uplo U) or lower (uplo L) bidiagonal matrix B with diagonal d
+// and off-diagonal e. For compq I the n×n matrices U and VT of the singular
+// vectors are computed by the divide and conquer method, which is much
+// faster than SBDSQR for large matrices; for compq N only the singular
+// values are computed, by SBDSQR, and U and VT are not referenced. The
+// compact form of compq P of LAPACK is not provided. On return d holds the
+// singular values in decreasing order and e is destroyed. A
+// *ConvergenceError is returned if a subproblem failed to converge. work
+// holds 4*n (compq N) or max(1,6*n*n+8*n) (compq I) elements and iwork 0 or
+// 3*n.
+func (impl Implementation) SBDSDC(uplo blas.Uplo, compq CompZ, n int, d, e, u []float32, ldu int, vt []float32, ldvt int, work []float32, iwork []int) error {
+	if err := checkBdsdc("SBDSDC", uplo, compq, n, len(d), len(e), len(u), ldu, len(vt), ldvt, len(work), len(iwork)); err != nil {
+		return err
+	}
+	return convergence("SBDSDC", bdsdc(impl.bl(), uplo, compq, n, d, e, u, ldu, vt, ldvt, work, iwork))
+}
+
+// DBDSDC computes the singular value decomposition B = U*S*VT of the n×n
+// real upper (uplo U) or lower (uplo L) bidiagonal matrix B with diagonal d
+// and off-diagonal e. For compq I the n×n matrices U and VT of the singular
+// vectors are computed by the divide and conquer method, which is much
+// faster than DBDSQR for large matrices; for compq N only the singular
+// values are computed, by DBDSQR, and U and VT are not referenced. The
+// compact form of compq P of LAPACK is not provided. On return d holds the
+// singular values in decreasing order and e is destroyed. A
+// *ConvergenceError is returned if a subproblem failed to converge. work
+// holds 4*n (compq N) or max(1,6*n*n+8*n) (compq I) elements and iwork 0 or
+// 3*n.
+func (impl Implementation) DBDSDC(uplo blas.Uplo, compq CompZ, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) error {
+	if err := checkBdsdc("DBDSDC", uplo, compq, n, len(d), len(e), len(u), ldu, len(vt), ldvt, len(work), len(iwork)); err != nil {
+		return err
+	}
+	return convergence("DBDSDC", bdsdc(impl.bl(), uplo, compq, n, d, e, u, ldu, vt, ldvt, work, iwork))
+}
+
+// checkBdsdc checks the BDSDC routines, whose work and iwork have the
+// positions 12 and 13 of LAPACK after Q and IQ.
+func checkBdsdc(routine string, uplo blas.Uplo, compq CompZ, n, lenD, lenE, lenU, ldu, lenVT, ldvt, lenWork, lenIwork int) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	if compq != CompZN && compq != CompZI {
+		c.fail(2, "compq", "must be N or I")
+	}
+	c.nonNeg(3, "n", n)
+	wantv := compq == CompZI
+	if wantv {
+		c.ld(7, "ldu", ldu, n, "n")
+		c.ld(9, "ldvt", ldvt, n, "n")
+	} else {
+		c.atLeast(7, "ldu", ldu, 1, "1")
+		c.atLeast(9, "ldvt", ldvt, 1, "1")
+	}
+	if c.ok() {
+		c.length(4, "d", lenD, n)
+		c.length(5, "e", lenE, max(0, n-1))
+		if wantv {
+			c.length(6, "u", lenU, matLen(n, n, ldu))
+			c.length(8, "vt", lenVT, matLen(n, n, ldvt))
+			c.length(12, "work", lenWork, lasd0Work(n))
+			c.length(13, "iwork", lenIwork, 3*n)
+		} else {
+			c.length(12, "work", lenWork, 4*n)
+		}
+	}
+	return c.result()
+}
+
+// bdsdc computes the singular values and, for compq I, the singular vectors
+// of the n×n real upper (uplo U) or lower (uplo L) bidiagonal matrix B with
+// diagonal d and off-diagonal e, as xBDSDC without its compact form. The
+// vectors are computed by lasd0 on B scaled to unit max-norm, or on B**T
+// for uplo L, whose singular vectors are those of B swapped and transposed.
+// On return d holds the singular values in decreasing order and e is
+// destroyed. work holds 4*n (compq N) or lasd0Work(n) (compq I) elements and
+// iwork 3*n. bdsdc returns 0 or the info of bdsqr or lasd0.
+func bdsdc[R gen.Float](bl blas.BLAS, uplo blas.Uplo, compq CompZ, n int, d, e, u []R, ldu int, vt []R, ldvt int, work []R, iwork []int) (info int) {
+	if n == 0 {
+		return 0
+	}
+	if compq == CompZN {
+		return bdsqr[R](bl, uplo, n, 0, 0, 0, d, e, nil, 1, nil, 1, nil, 1, work)
+	}
+	if n == 1 {
+		u[0] = 1
+		if d[0] < 0 {
+			u[0] = -1
+			d[0] = -d[0]
+		}
+		vt[0] = 1
+		return 0
+	}
+
+	orgnrm := lanst(normMax, n, d, e)
+	if orgnrm == 0 {
+		laset(uploAll, n, n, 0, 1, u, ldu)
+		laset(uploAll, n, n, 0, 1, vt, ldvt)
+		return 0
+	}
+	lascl(uploAll, orgnrm, 1, n, 1, d, n)
+	lascl(uploAll, orgnrm, 1, n-1, 1, e, n-1)
+	if uplo == blas.UploU {
+		info = lasd0(bl, n, 0, d, e, u, ldu, vt, ldvt, work, iwork)
+	} else {
+		info = lasd0(bl, n, 0, d, e, vt, ldvt, u, ldu, work, iwork)
+		for j := 1; j < n; j++ {
+			for i := 0; i < j; i++ {
+				u[i+j*ldu], u[j+i*ldu] = u[j+i*ldu], u[i+j*ldu]
+				vt[i+j*ldvt], vt[j+i*ldvt] = vt[j+i*ldvt], vt[i+j*ldvt]
+			}
+		}
+	}
+	if info != 0 {
+		return info
+	}
+	lascl(uploAll, 1, orgnrm, n, 1, d, n)
+	svdSort(bl, n, d, n, u, ldu, n, vt, ldvt)
+	return 0
+}
+
+// bdsdcLeaf is the order up to which lasd0 solves a subproblem by bdsqr,
+// the value of ILAENV for SMLSIZ.
+const bdsdcLeaf = 25
+
+// lasd4MaxIter bounds the number of iterations of lasd4, as MAXIT of
+// xLASD4.
+const lasd4MaxIter = 400
+
+// lasd0Work returns the length of the workspace of lasd0 for order n.
+func lasd0Work(n int) int {
+	return max(1, 6*n*n+8*n)
+}
+
+// lasd0 computes the singular value decomposition B = U*[D 0]*VT of the
+// n×(n+sqre) real upper bidiagonal matrix B with diagonal d and the
+// n-1+sqre elements of the off-diagonal in e, by the divide and conquer
+// method of xLASD0. sqre is 0 or 1. The n×n matrix U and the
+// (n+sqre)×(n+sqre) matrix VT are formed explicitly; the singular values
+// are returned in d in no particular order and e is destroyed. work holds
+// lasd0Work(n) elements and iwork 3*n. lasd0 returns 0, or a positive value
+// if a subproblem failed to converge.
+func lasd0[R gen.Float](bl blas.BLAS, n, sqre int, d, e, u []R, ldu int, vt []R, ldvt int, work []R, iwork []int) (info int) {
+	m := n + sqre
+	if n <= bdsdcLeaf {
+		laset(uploAll, n, n, 0, 1, u, ldu)
+		laset(uploAll, m, m, 0, 1, vt, ldvt)
+		if sqre == 1 {
+			// Chase the element e[n-1] of the extra column up and out of
+			// the matrix with rotations of the columns i and n.
+			f := float64(e[n-1])
+			for i := n - 1; i >= 0; i-- {
+				c, s, r := lartg(float64(d[i]), f)
+				d[i] = R(r)
+				if i > 0 {
+					f = -s * float64(e[i-1])
+					e[i-1] = R(c * float64(e[i-1]))
+				}
+				rrot(bl, m, vt[i:], ldvt, vt[n:], ldvt, c, s)
+			}
+		}
+		return bdsqr(bl, blas.UploU, n, m, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	}
+	// Split B at row nl into an nl×(nl+1) upper block, the row nl with the
+	// elements alpha and beta, and an nr×(nr+sqre) lower block.
+	nl := n / 2
+	nr := n - nl - 1
+	alpha, beta := float64(d[nl]), float64(e[nl])
+	laset(uploAll, n, n, 0, 0, u, ldu)
+	u[nl+nl*ldu] = 1
+	laset(uploAll, m, m, 0, 0, vt, ldvt)
+	if info = lasd0(bl, nl, 1, d, e, u, ldu, vt, ldvt, work, iwork); info != 0 {
+		return info
+	}
+	j := nl + 1
+	if info = lasd0(bl, nr, sqre, d[j:], e[j:], u[j+j*ldu:], ldu, vt[j+j*ldvt:], ldvt, work, iwork); info != 0 {
+		return info
+	}
+	return lasd1(bl, nl, nr, sqre, d, alpha, beta, u, ldu, vt, ldvt, work, iwork)
+}
+
+// lasd1 merges the singular value decompositions of the two blocks of the
+// n×(n+sqre) bidiagonal matrix split by lasd0 at row nl, where n is
+// nl+nr+1, into that of the whole matrix, as xLASD1. On entry d holds the
+// singular values of the blocks in d[:nl] and d[nl+1:], and U and VT hold
+// the block diagonal matrices of their singular vectors with a 1 at
+// U[nl,nl]; alpha and beta are the elements of row nl of B. The merged
+// singular values and vectors overwrite d, U and VT.
+func lasd1[R gen.Float](bl blas.BLAS, nl, nr, sqre int, d []R, alpha, beta float64, u []R, ldu int, vt []R, ldvt int, work []R, iwork []int) (info int) {
+	n := nl + nr + 1
+	m := n + sqre
+	d[nl] = 0
+	orgnrm := max(math.Abs(alpha), math.Abs(beta))
+	for i := 0; i < n; i++ {
+		orgnrm = max(orgnrm, math.Abs(float64(d[i])))
+	}
+	if orgnrm == 0 {
+		return 0
+	}
+	alpha /= orgnrm
+	beta /= orgnrm
+	lascl(uploAll, orgnrm, 1, n, 1, d, n)
+
+	// B = U*M*VT, where M is diagonal apart from row nl, which holds z in
+	// the coordinates of the rows of VT. Rotate the null rows nl and n of
+	// VT so that z[n] is zero and the row n is the null vector of B.
+	z := work[:m]
+	for i := 0; i <= nl; i++ {
+		z[i] = R(alpha * float64(vt[i+nl*ldvt]))
+	}
+	for i := nl + 1; i < m; i++ {
+		z[i] = R(beta * float64(vt[i+(nl+1)*ldvt]))
+	}
+	if sqre == 1 {
+		c, s, r := lartg(float64(z[nl]), float64(z[n]))
+		rrot(bl, m, vt[nl:], ldvt, vt[n:], ldvt, c, s)
+		z[nl], z[n] = R(r), 0
+	}
+
+	// Order the singular values of the blocks increasingly after the
+	// zero pole nl and deflate, as xLASD2: a negligible z[g] leaves d[g]
+	// as a singular value, a negligible d[g] is rotated into the pole nl,
+	// and of two close d the first is rotated into the second.
+	idx := iwork[:n-1]
+	for i, k := 0, 0; i < n; i++ {
+		if i != nl {
+			idx[k] = i
+			k++
+		}
+	}
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(d[a], d[b]) })
+	tol := 8 * eps[R]() * max(math.Abs(alpha), math.Abs(beta), float64(d[idx[n-2]]))
+	keep := iwork[n : n : 2*n]
+	defl := iwork[2*n : 2*n : 3*n]
+	keep = append(keep, nl)
+	if math.Abs(float64(z[nl])) <= tol {
+		z[nl] = R(tol)
+	}
+	prev := -1
+	for _, g := range idx {
+		zg := float64(z[g])
+		switch {
+		case math.Abs(zg) <= tol:
+			defl = append(defl, g)
+		case float64(d[g]) <= tol:
+			z0 := float64(z[nl])
+			t := lapy2(z0, zg)
+			rrot(bl, m, vt[nl:], ldvt, vt[g:], ldvt, z0/t, zg/t)
+			z[nl], z[g], d[g] = R(t), 0, 0
+			defl = append(defl, g)
+		case prev >= 0 && float64(d[g])-float64(d[prev]) <= tol:
+			zp := float64(z[prev])
+			t := lapy2(zp, zg)
+			c, s := zg/t, -zp/t
+			rrot(bl, n, u[prev*ldu:], 1, u[g*ldu:], 1, c, s)
+			rrot(bl, m, vt[prev:], ldvt, vt[g:], ldvt, c, s)
+			z[g], z[prev] = R(t), 0
+			keep[len(keep)-1] = g
+			defl = append(defl, prev)
+			prev = g
+		default:
+			keep = append(keep, g)
+			prev = g
+		}
+	}
+
+	// Solve the secular equation of the k undeflated poles and form the
+	// singular vectors of M from the z recomputed by Löwner's formula, as
+	// xLASD3.
+	k := len(keep)
+	w := work[m:]
+	dd, zz, sig, zh := w[:k], w[k:2*k], w[2*k:3*k], w[3*k:4*k]
+	w = w[4*k:]
+	dlt, sum, uh, vh := w[:k*k], w[k*k:2*k*k], w[2*k*k:3*k*k], w[3*k*k:4*k*k]
+	w = w[4*k*k:]
+	gu, gvt := w[:n*n], w[n*n:n*n+n*m]
+	for i, g := range keep {
+		dd[i], zz[i] = d[g], z[g]
+	}
+	eps := eps[R]()
+	for i := 0; i < k; i++ {
+		s, ok := lasd4(k, i, dd, zz, dlt[i*k:], sum[i*k:], eps)
+		if !ok {
+			return 1
+		}
+		sig[i] = R(s)
+	}
+	for j := 0; j < k; j++ {
+		dj := float64(dd[j])
+		p := -float64(dlt[j+(k-1)*k]) * float64(sum[j+(k-1)*k])
+		for r := 0; r < j; r++ {
+			dr := float64(dd[r])
+			p *= float64(dlt[j+r*k]) * float64(sum[j+r*k]) / ((dj - dr) * (dj + dr))
+		}
+		for r := j; r < k-1; r++ {
+			dr := float64(dd[r+1])
+			p *= float64(dlt[j+r*k]) * float64(sum[j+r*k]) / ((dj - dr) * (dj + dr))
+		}
+		zh[j] = R(math.Copysign(math.Sqrt(math.Abs(p)), float64(zz[j])))
+	}
+	for i := 0; i < k; i++ {
+		ui, vi := uh[i*k:(i+1)*k], vh[i*k:(i+1)*k]
+		for j := 0; j < k; j++ {
+			v := float64(zh[j]) / float64(dlt[j+i*k]) / float64(sum[j+i*k])
+			vi[j] = R(v)
+			ui[j] = R(float64(dd[j]) * v)
+		}
+		ui[0] = -1
+		rscal(bl, k, 1/nrm2(bl, k, ui, 1), ui, 1)
+		rscal(bl, k, 1/nrm2(bl, k, vi, 1), vi, 1)
+	}
+
+	// Gather the vectors of the undeflated and then the deflated poles and
+	// multiply the former by those of M.
+	for i, g := range keep {
+		copyVec(bl, n, u[g*ldu:], 1, gu[i*n:], 1)
+		copyVec(bl, m, vt[g:], ldvt, gvt[i:], n)
+	}
+	for i, g := range defl {
+		copyVec(bl, n, u[g*ldu:], 1, gu[(k+i)*n:], 1)
+		copyVec(bl, m, vt[g:], ldvt, gvt[k+i:], n)
+		z[i] = d[g]
+	}
+	gemm(bl, blas.TransN, blas.TransN, n, k, k, 1, gu, n, uh, k, 0, u, ldu)
+	gemm(bl, blas.TransT, blas.TransN, k, m, k, 1, vh, k, gvt, n, 0, vt, ldvt)
+	if k < n {
+		lacpy(uploAll, n, n-k, gu[k*n:], n, u[k*ldu:], ldu)
+		lacpy(uploAll, n-k, m, gvt[k:], n, vt[k:], ldvt)
+	}
+	copy(d, sig)
+	copy(d[k:n], z[:n-k])
+	lascl(uploAll, 1, orgnrm, n, 1, d, n)
+	return 0
+}
+
+// lasd4 computes the root sigma in (d[i], d[i+1]), or above d[k-1] for
+// i = k-1, of the secular equation 1 + sum_j z[j]**2/(d[j]**2-sigma**2) = 0,
+// where 0 = d[0] < d[1] < ... < d[k-1] and z has no zero element, as
+// xLASD4. The root is sought as sigma**2 = d[o]**2 + tau for the pole d[o]
+// nearer to it, so that delta[j] = d[j]-sigma and sum[j] = d[j]+sigma,
+// which it sets, are accurate. It reports whether the iteration converged.
+func lasd4[R gen.Float](k, i int, d, z, delta, sum []R, eps float64) (sigma float64, ok bool) {
+	// Choose the origin and bracket tau; f is increasing between poles.
+	o := i
+	var lo, hi float64
+	if i == k-1 {
+		for j := 0; j < k; j++ {
+			hi += float64(z[j]) * float64(z[j])
+		}
+	} else {
+		di, di1 := float64(d[i]), float64(d[i+1])
+		mid := (di1 - di) * (di1 + di) / 2
+		f := 1.0
+		for j := 0; j < k; j++ {
+			dj, zj := float64(d[j]), float64(z[j])
+			f += zj * zj / ((dj-di)*(dj+di) - mid)
+		}
+		if f >= 0 {
+			hi = mid
+		} else {
+			o = i + 1
+			lo = -mid
+		}
+	}
+	do := float64(d[o])
+	dif := func(j int) float64 {
+		dj := float64(d[j])
+		return (dj - do) * (dj + do)
+	}
+
+	// Iterate on the rational model c + s/(dif(i)-x) + t/(dif(i+1)-x) that
+	// matches f and the derivatives of its two parts at tau, falling back
+	// to bisection whenever the step leaves the bracket.
+	tau := (lo + hi) / 2
+	for iter := 0; iter < lasd4MaxIter; iter++ {
+		var psi, dpsi, phi, dphi float64
+		for j := 0; j <= i; j++ {
+			t := float64(z[j]) / (dif(j) - tau)
+			psi += float64(z[j]) * t
+			dpsi += t * t
+		}
+		for j := i + 1; j < k; j++ {
+			t := float64(z[j]) / (dif(j) - tau)
+			phi += float64(z[j]) * t
+			dphi += t * t
+		}
+		f := 1 + psi + phi
+		if math.Abs(f) <= 8*float64(k)*eps*(1+math.Abs(psi)+math.Abs(phi)) {
+			ok = true
+			break
+		}
+		if f < 0 {
+			lo = tau
+		} else {
+			hi = tau
+		}
+		if hi-lo <= 2*eps*max(math.Abs(lo), math.Abs(hi)) {
+			ok = true
+			break
+		}
+		a := dif(i) - tau
+		c := f - dpsi*a
+		s := dpsi * a * a
+		next := math.NaN()
+		if i == k-1 {
+			if c != 0 {
+				next = tau + a + s/c
+			}
+		} else {
+			b := dif(i+1) - tau
+			t := dphi * b * b
+			c -= dphi * b
+			// Solve c*eta**2 - bb*eta + cc = 0 for the step eta.
+			bb := c*(a+b) + s + t
+			cc := c*a*b + s*b + t*a
+			switch {
+			case c == 0:
+				if bb != 0 {
+					next = tau + cc/bb
+				}
+			case bb*bb >= 4*c*cc:
+				q := (bb + math.Copysign(math.Sqrt(bb*bb-4*c*cc), bb)) / 2
+				for _, eta := range [2]float64{q / c, cc / q} {
+					if x := tau + eta; x > lo && x < hi {
+						next = x
+					}
+				}
+			}
+		}
+		if !(next > lo && (next < hi || i == k-1 && next == hi)) {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-tau) <= eps*math.Abs(next) {
+			tau = next
+			ok = true
+			break
+		}
+		tau = next
+	}
+	if !ok {
+		return 0, false
+	}
+	mu := tau / (do + math.Sqrt(do*do+tau))
+	for j := 0; j < k; j++ {
+		dj := float64(d[j])
+		delta[j] = R((dj - do) - mu)
+		sum[j] = R((dj + do) + mu)
+	}
+	return do + mu, true
+}
+
+// svdSort sorts the n singular values in d into decreasing order by
+// selection sort, swapping the columns of the nru×n matrix U and the rows
+// of the n×ncvt matrix VT with them.
+func svdSort[T gen.Scalar, R gen.Float](bl blas.BLAS, n int, d []R, nru int, u []T, ldu int, ncvt int, vt []T, ldvt int) {
+	for i := 0; i < n-1; i++ {
+		k := i
+		for j := i + 1; j < n; j++ {
+			if d[j] > d[k] {
+				k = j
+			}
+		}
+		if k == i {
+			continue
+		}
+		d[i], d[k] = d[k], d[i]
+		if nru > 0 {
+			swap(bl, nru, u[i*ldu:], 1, u[k*ldu:], 1)
+		}
+		if ncvt > 0 {
+			swap(bl, ncvt, vt[i:], ldvt, vt[k:], ldvt)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SBDSQR computes the singular value decomposition B = Q*S*P**T of the
+// n×n real upper (uplo U) or lower (uplo L) bidiagonal matrix B with
+// diagonal d and off-diagonal e by the implicit zero-shift QR algorithm,
+// which computes the singular values to high relative accuracy. On return d
+// holds the singular values in decreasing order and e is destroyed. The
+// rotations are applied to the n×ncvt matrix VT, overwritten by P**T*VT, the
+// nru×n matrix U, overwritten by U*Q, and the n×ncc matrix C, overwritten by
+// Q**T*C, so that with the Q and P**T of SGEBRD on entry they receive the
+// singular vectors of the reduced matrix. A *ConvergenceError is returned if
+// the iteration failed, Info elements of e not converging to zero. work must
+// hold 4*n elements.
+func (impl Implementation) SBDSQR(uplo blas.Uplo, n, ncvt, nru, ncc int, d, e []float32, vt []float32, ldvt int, u []float32, ldu int, c []float32, ldc int, work []float32) error {
+	if err := checkBdsqr("SBDSQR", uplo, n, ncvt, nru, ncc, len(d), len(e), len(vt), ldvt, len(u), ldu, len(c), ldc, len(work), false); err != nil {
+		return err
+	}
+	return convergence("SBDSQR", bdsqr(impl.bl(), uplo, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, work))
+}
+
+// DBDSQR computes the singular value decomposition B = Q*S*P**T of the
+// n×n real upper (uplo U) or lower (uplo L) bidiagonal matrix B with
+// diagonal d and off-diagonal e by the implicit zero-shift QR algorithm,
+// which computes the singular values to high relative accuracy. On return d
+// holds the singular values in decreasing order and e is destroyed. The
+// rotations are applied to the n×ncvt matrix VT, overwritten by P**T*VT, the
+// nru×n matrix U, overwritten by U*Q, and the n×ncc matrix C, overwritten by
+// Q**T*C, so that with the Q and P**T of DGEBRD on entry they receive the
+// singular vectors of the reduced matrix. A *ConvergenceError is returned if
+// the iteration failed, Info elements of e not converging to zero. work must
+// hold 4*n elements.
+func (impl Implementation) DBDSQR(uplo blas.Uplo, n, ncvt, nru, ncc int, d, e []float64, vt []float64, ldvt int, u []float64, ldu int, c []float64, ldc int, work []float64) error {
+	if err := checkBdsqr("DBDSQR", uplo, n, ncvt, nru, ncc, len(d), len(e), len(vt), ldvt, len(u), ldu, len(c), ldc, len(work), false); err != nil {
+		return err
+	}
+	return convergence("DBDSQR", bdsqr(impl.bl(), uplo, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, work))
+}
+
+// CBDSQR computes the singular value decomposition B = Q*S*P**T of the
+// n×n real upper (uplo U) or lower (uplo L) bidiagonal matrix B with
+// diagonal d and off-diagonal e by the implicit zero-shift QR algorithm,
+// which computes the singular values to high relative accuracy. On return d
+// holds the singular values in decreasing order and e is destroyed. The
+// rotations are applied to the n×ncvt matrix VT, overwritten by P**T*VT, the
+// nru×n matrix U, overwritten by U*Q, and the n×ncc matrix C, overwritten by
+// Q**T*C, so that with the Q and P**H of CGEBRD on entry they receive the
+// singular vectors of the reduced matrix. A *ConvergenceError is returned if
+// the iteration failed, Info elements of e not converging to zero. rwork must
+// hold 4*n elements.
+func (impl Implementation) CBDSQR(uplo blas.Uplo, n, ncvt, nru, ncc int, d, e []float32, vt []complex64, ldvt int, u []complex64, ldu int, c []complex64, ldc int, rwork []float32) error {
+	if err := checkBdsqr("CBDSQR", uplo, n, ncvt, nru, ncc, len(d), len(e), len(vt), ldvt, len(u), ldu, len(c), ldc, len(rwork), true); err != nil {
+		return err
+	}
+	return convergence("CBDSQR", bdsqr(impl.bl(), uplo, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, rwork))
+}
+
+// ZBDSQR computes the singular value decomposition B = Q*S*P**T of the
+// n×n real upper (uplo U) or lower (uplo L) bidiagonal matrix B with
+// diagonal d and off-diagonal e by the implicit zero-shift QR algorithm,
+// which computes the singular values to high relative accuracy. On return d
+// holds the singular values in decreasing order and e is destroyed. The
+// rotations are applied to the n×ncvt matrix VT, overwritten by P**T*VT, the
+// nru×n matrix U, overwritten by U*Q, and the n×ncc matrix C, overwritten by
+// Q**T*C, so that with the Q and P**H of ZGEBRD on entry they receive the
+// singular vectors of the reduced matrix. A *ConvergenceError is returned if
+// the iteration failed, Info elements of e not converging to zero. rwork must
+// hold 4*n elements.
+func (impl Implementation) ZBDSQR(uplo blas.Uplo, n, ncvt, nru, ncc int, d, e []float64, vt []complex128, ldvt int, u []complex128, ldu int, c []complex128, ldc int, rwork []float64) error {
+	if err := checkBdsqr("ZBDSQR", uplo, n, ncvt, nru, ncc, len(d), len(e), len(vt), ldvt, len(u), ldu, len(c), ldc, len(rwork), true); err != nil {
+		return err
+	}
+	return convergence("ZBDSQR", bdsqr(impl.bl(), uplo, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, rwork))
+}
+
+// checkBdsqr checks the BDSQR routines. The complex routines name their
+// real workspace rwork.
+func checkBdsqr(routine string, uplo blas.Uplo, n, ncvt, nru, ncc, lenD, lenE, lenVT, ldvt, lenU, ldu, lenC, ldc, lenWork int, complex bool) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.nonNeg(3, "ncvt", ncvt)
+	c.nonNeg(4, "nru", nru)
+	c.nonNeg(5, "ncc", ncc)
+	if ncvt > 0 {
+		c.ld(9, "ldvt", ldvt, n, "n")
+	} else {
+		c.atLeast(9, "ldvt", ldvt, 1, "1")
+	}
+	c.ld(11, "ldu", ldu, nru, "nru")
+	if ncc > 0 {
+		c.ld(13, "ldc", ldc, n, "n")
+	} else {
+		c.atLeast(13, "ldc", ldc, 1, "1")
+	}
+	if c.ok() {
+		c.length(6, "d", lenD, n)
+		c.length(7, "e", lenE, max(0, n-1))
+		c.length(8, "vt", lenVT, matLen(n, ncvt, ldvt))
+		c.length(10, "u", lenU, matLen(nru, n, ldu))
+		c.length(12, "c", lenC, matLen(n, ncc, ldc))
+		if complex {
+			c.length(14, "rwork", lenWork, 4*n)
+		} else {
+			c.length(14, "work", lenWork, 4*n)
+		}
+	}
+	return c.result()
+}
+
+// bdsqrMaxIter bounds the number of QR sweeps of bdsqr to bdsqrMaxIter*n*n
+// inner steps, as MAXITR of xBDSQR.
+const bdsqrMaxIter = 6
+
+// bdsqr computes the singular value decomposition B = Q*S*P**T of the n×n
+// real upper (uplo U) or lower (uplo L) bidiagonal matrix with diagonal d
+// and off-diagonal e by the implicit zero-shift QR algorithm of Demmel and
+// Kahan, as xBDSQR. On return d holds the singular values in decreasing
+// order and e is destroyed. The rotations are applied to the rows of the
+// n×ncvt matrix VT, which is overwritten by P**T*VT, to the columns of the
+// nru×n matrix U, overwritten by U*Q, and to the rows of the n×ncc matrix
+// C, overwritten by Q**T*C. work must hold 4*n elements. bdsqr returns 0,
+// or the number of elements of e that did not converge to zero.
+func bdsqr[T gen.Scalar, R gen.Float](bl blas.BLAS, uplo blas.Uplo, n, ncvt, nru, ncc int, d, e []R, vt []T, ldvt int, u []T, ldu int, c []T, ldc int, work []R) (info int) {
+	if n == 0 {
+		return 0
+	}
+	if n > 1 {
+		if info = bdsqrIterate(bl, uplo, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, work); info > 0 {
+			return info
+		}
+	}
+	// Make the singular values positive.
+	for i := 0; i < n; i++ {
+		if d[i] < 0 {
+			d[i] = -d[i]
+			if ncvt > 0 {
+				rscal(bl, ncvt, -1, vt[i:], ldvt)
+			}
+		}
+	}
+	// Sort the singular values into decreasing order by selection sort,
+	// which minimizes the number of swaps of the vectors.
+	for i := 0; i < n-1; i++ {
+		isub, smin := 0, d[0]
+		for j := 1; j < n-i; j++ {
+			if d[j] <= smin {
+				isub, smin = j, d[j]
+			}
+		}
+		if last := n - i - 1; isub != last {
+			d[isub], d[last] = d[last], smin
+			if ncvt > 0 {
+				swap(bl, ncvt, vt[isub:], ldvt, vt[last:], ldvt)
+			}
+			if nru > 0 {
+				swap(bl, nru, u[isub*ldu:], 1, u[last*ldu:], 1)
+			}
+			if ncc > 0 {
+				swap(bl, ncc, c[isub:], ldc, c[last:], ldc)
+			}
+		}
+	}
+	return 0
+}
+
+// bdsqrIterate runs the QR iteration of bdsqr for n > 1 and returns 0 or
+// the number of elements of e that did not converge.
+func bdsqrIterate[T gen.Scalar, R gen.Float](bl blas.BLAS, uplo blas.Uplo, n, ncvt, nru, ncc int, d, e []R, vt []T, ldvt int, u []T, ldu int, c []T, ldc int, work []R) (info int) {
+	nm1 := n - 1
+	nm12 := nm1 + nm1
+	nm13 := nm12 + nm1
+	eps := eps[T]()
+	unfl := safmin[T]()
+
+	// If the matrix is lower bidiagonal, rotate it to upper bidiagonal by
+	// applying Givens rotations on the left.
+	if uplo == blas.UploL {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := lartg(float64(d[i]), float64(e[i]))
+			d[i] = R(r)
+			e[i] = R(sn * float64(d[i+1]))
+			d[i+1] = R(cs * float64(d[i+1]))
+			work[i] = R(cs)
+			work[nm1+i] = R(sn)
+		}
+		if nru > 0 {
+			lasr(bl, blas.SideR, DirectF, nru, n, work, work[nm1:], u, ldu)
+		}
+		if ncc > 0 {
+			lasr(bl, blas.SideL, DirectF, n, ncc, work, work[nm1:], c, ldc)
+		}
+	}
+
+	absd := func(i int) float64 { return math.Abs(float64(d[i])) }
+	abse := func(i int) float64 { return math.Abs(float64(e[i])) }
+
+	// Compute the tolerance for the relative accuracy of the singular values
+	// and the approximate maximum singular value.
+	tol := max(10, min(100, math.Pow(eps, -0.125))) * eps
+	var smax float64
+	for i := 0; i < n; i++ {
+		smax = max(smax, absd(i))
+	}
+	for i := 0; i < n-1; i++ {
+		smax = max(smax, abse(i))
+	}
+	// Compute the approximate minimum singular value to set the threshold
+	// for convergence.
+	sminoa := absd(0)
+	if sminoa != 0 {
+		mu := sminoa
+		for i := 1; i < n; i++ {
+			mu = absd(i) * (mu / (mu + abse(i-1)))
+			sminoa = min(sminoa, mu)
+			if sminoa == 0 {
+				break
+			}
+		}
+	}
+	sminoa /= math.Sqrt(float64(n))
+	thresh := max(tol*sminoa, float64(bdsqrMaxIter*n*n)*unfl)
+
+	// Prepare for the main iteration loop over the unconverged block
+	// d[ll:m], where m is one past its last row. idir records the direction
+	// of the chase, 1 from top to bottom and 2 from bottom to top.
+	maxit := bdsqrMaxIter * n * n
+	iter := 0
+	oldll, oldm := -1, -1
+	idir := 0
+	m := n
+	var smin float64
+Outer:
+	for m > 1 {
+		if iter > maxit {
+			for i := 0; i < n-1; i++ {
+				if e[i] != 0 {
+					info++
+				}
+			}
+			return info
+		}
+		// Find a diagonal block of the matrix to work on.
+		smax = absd(m - 1)
+		ll := 0
+		for lll := 1; lll < m; lll++ {
+			l := m - 1 - lll
+			abss, abse := absd(l), abse(l)
+			if abse <= thresh {
+				// The block splits at e[l].
+				e[l] = 0
+				if l == m-2 {
+					// The bottom singular value has converged.
+					m--
+					continue Outer
+				}
+				ll = l + 1
+				break
+			}
+			smax = max(smax, abss, abse)
+		}
+		// d[ll:m] is an unreduced block.
+		if ll == m-2 {
+			// Handle a 2×2 block separately.
+			sigmn, sigmx, sinr, cosr, sinl, cosl := lasv2(float64(d[m-2]), float64(e[m-2]), float64(d[m-1]))
+			d[m-2], d[m-1], e[m-2] = R(sigmx), R(sigmn), 0
+			if ncvt > 0 {
+				rrot(bl, ncvt, vt[m-2:], ldvt, vt[m-1:], ldvt, cosr, sinr)
+			}
+			if nru > 0 {
+				rrot(bl, nru, u[(m-2)*ldu:], 1, u[(m-1)*ldu:], 1, cosl, sinl)
+			}
+			if ncc > 0 {
+				rrot(bl, ncc, c[m-2:], ldc, c[m-1:], ldc, cosl, sinl)
+			}
+			m -= 2
+			continue
+		}
+		// If working on a new submatrix, choose the shift direction from the
+		// larger end diagonal element towards the smaller.
+		if ll > oldm-1 || m-1 < oldll {
+			if absd(ll) >= absd(m-1) {
+				idir = 1
+			} else {
+				idir = 2
+			}
+		}
+		// Apply the convergence tests.
+		if idir == 1 {
+			// Test the last off-diagonal element, then run the convergence
+			// criterion forward.
+			if abse(m-2) <= tol*absd(m-1) {
+				e[m-2] = 0
+				continue
+			}
+			mu := absd(ll)
+			smin = mu
+			for l := ll; l < m-1; l++ {
+				if abse(l) <= tol*mu {
+					e[l] = 0
+					continue Outer
+				}
+				mu = absd(l+1) * (mu / (mu + abse(l)))
+				smin = min(smin, mu)
+			}
+		} else {
+			// Test the first off-diagonal element, then run the convergence
+			// criterion backward.
+			if abse(ll) <= tol*absd(ll) {
+				e[ll] = 0
+				continue
+			}
+			mu := absd(m - 1)
+			smin = mu
+			for l := m - 2; l >= ll; l-- {
+				if abse(l) <= tol*mu {
+					e[l] = 0
+					continue Outer
+				}
+				mu = absd(l) * (mu / (mu + abse(l)))
+				smin = min(smin, mu)
+			}
+		}
+		oldll, oldm = ll, m
+
+		// Compute the shift. Use a zero shift if it would ruin the relative
+		// accuracy of the result.
+		var shift float64
+		if float64(n)*tol*(smin/smax) > max(eps, 0.01*tol) {
+			var sll float64
+			if idir == 1 {
+				sll = absd(ll)
+				shift, _ = las2(float64(d[m-2]), float64(e[m-2]), float64(d[m-1]))
+			} else {
+				sll = absd(m - 1)
+				shift, _ = las2(float64(d[ll]), float64(e[ll]), float64(d[ll+1]))
+			}
+			if sll > 0 && (shift/sll)*(shift/sll) < eps {
+				shift = 0
+			}
+		}
+		iter += m - ll
+
+		cw, sw := work[:nm1], work[nm1:nm12]
+		cw2, sw2 := work[nm12:nm13], work[nm13:nm13+nm1]
+		switch {
+		case shift == 0 && idir == 1:
+			// Chase the bulge from top to bottom with a zero shift.
+			cs, oldcs := 1.0, 1.0
+			var sn, r, oldsn float64
+			for i := ll; i < m-1; i++ {
+				cs, sn, r = lartg(float64(d[i])*cs, float64(e[i]))
+				if i > ll {
+					e[i-1] = R(oldsn * r)
+				}
+				var di float64
+				oldcs, oldsn, di = lartg(oldcs*r, float64(d[i+1])*sn)
+				d[i] = R(di)
+				cw[i-ll], sw[i-ll] = R(cs), R(sn)
+				cw2[i-ll], sw2[i-ll] = R(oldcs), R(oldsn)
+			}
+			h := float64(d[m-1]) * cs
+			d[m-1] = R(h * oldcs)
+			e[m-2] = R(h * oldsn)
+			bdsqrUpdate(bl, DirectF, m-ll, ncvt, nru, ncc, cw, sw, cw2, sw2, ll, vt, ldvt, u, ldu, c, ldc)
+			if abse(m-2) <= thresh {
+				e[m-2] = 0
+			}
+		case shift == 0:
+			// Chase the bulge from bottom to top with a zero shift.
+			cs, oldcs := 1.0, 1.0
+			var sn, r, oldsn float64
+			for i := m - 1; i > ll; i-- {
+				cs, sn, r = lartg(float64(d[i])*cs, float64(e[i-1]))
+				if i < m-1 {
+					e[i] = R(oldsn * r)
+				}
+				var di float64
+				oldcs, oldsn, di = lartg(oldcs*r, float64(d[i-1])*sn)
+				d[i] = R(di)
+				cw[i-ll-1], sw[i-ll-1] = R(cs), R(-sn)
+				cw2[i-ll-1], sw2[i-ll-1] = R(oldcs), R(-oldsn)
+			}
+			h := float64(d[ll]) * cs
+			d[ll] = R(h * oldcs)
+			e[ll] = R(h * oldsn)
+			bdsqrUpdate(bl, DirectB, m-ll, ncvt, nru, ncc, cw2, sw2, cw, sw, ll, vt, ldvt, u, ldu, c, ldc)
+			if abse(ll) <= thresh {
+				e[ll] = 0
+			}
+		case idir == 1:
+			// Chase the bulge from top to bottom with a nonzero shift.
+			dll := float64(d[ll])
+			f := (math.Abs(dll) - shift) * (math.Copysign(1, dll) + shift/dll)
+			g := float64(e[ll])
+			for i := ll; i < m-1; i++ {
+				cosr, sinr, r := lartg(f, g)
+				if i > ll {
+					e[i-1] = R(r)
+				}
+				di, ei, di1 := float64(d[i]), float64(e[i]), float64(d[i+1])
+				f = cosr*di + sinr*ei
+				ei = cosr*ei - sinr*di
+				g = sinr * di1
+				di1 *= cosr
+				cosl, sinl, r := lartg(f, g)
+				d[i] = R(r)
+				f = cosl*ei + sinl*di1
+				d[i+1] = R(cosl*di1 - sinl*ei)
+				e[i] = R(ei)
+				if i < m-2 {
+					g = sinl * float64(e[i+1])
+					e[i+1] = R(cosl * float64(e[i+1]))
+				}
+				cw[i-ll], sw[i-ll] = R(cosr), R(sinr)
+				cw2[i-ll], sw2[i-ll] = R(cosl), R(sinl)
+			}
+			e[m-2] = R(f)
+			bdsqrUpdate(bl, DirectF, m-ll, ncvt, nru, ncc, cw, sw, cw2, sw2, ll, vt, ldvt, u, ldu, c, ldc)
+			if abse(m-2) <= thresh {
+				e[m-2] = 0
+			}
+		default:
+			// Chase the bulge from bottom to top with a nonzero shift.
+			dm := float64(d[m-1])
+			f := (math.Abs(dm) - shift) * (math.Copysign(1, dm) + shift/dm)
+			g := float64(e[m-2])
+			for i := m - 1; i > ll; i-- {
+				cosr, sinr, r := lartg(f, g)
+				if i < m-1 {
+					e[i] = R(r)
+				}
+				di, ei, di1 := float64(d[i]), float64(e[i-1]), float64(d[i-1])
+				f = cosr*di + sinr*ei
+				ei = cosr*ei - sinr*di
+				g = sinr * di1
+				di1 *= cosr
+				cosl, sinl, r := lartg(f, g)
+				d[i] = R(r)
+				f = cosl*ei + sinl*di1
+				d[i-1] = R(cosl*di1 - sinl*ei)
+				e[i-1] = R(ei)
+				if i > ll+1 {
+					g = sinl * float64(e[i-2])
+					e[i-2] = R(cosl * float64(e[i-2]))
+				}
+				cw[i-ll-1], sw[i-ll-1] = R(cosr), R(-sinr)
+				cw2[i-ll-1], sw2[i-ll-1] = R(cosl), R(-sinl)
+			}
+			e[ll] = R(f)
+			if abse(ll) <= thresh {
+				e[ll] = 0
+			}
+			bdsqrUpdate(bl, DirectB, m-ll, ncvt, nru, ncc, cw2, sw2, cw, sw, ll, vt, ldvt, u, ldu, c, ldc)
+		}
+	}
+	return 0
+}
+
+// bdsqrUpdate applies the right rotations cr, sr of a QR sweep over the
+// unreduced block of order nb starting at row ll to the rows of VT, and the
+// left rotations cl, sl to the columns of U and the rows of C.
+func bdsqrUpdate[T gen.Scalar, R gen.Float](bl blas.BLAS, direct Direct, nb, ncvt, nru, ncc int, cr, sr, cl, sl []R, ll int, vt []T, ldvt int, u []T, ldu int, c []T, ldc int) {
+	if ncvt > 0 {
+		lasr(bl, blas.SideL, direct, nb, ncvt, cr, sr, vt[ll:], ldvt)
+	}
+	if nru > 0 {
+		lasr(bl, blas.SideR, direct, nru, nb, cl, sl, u[ll*ldu:], ldu)
+	}
+	if ncc > 0 {
+		lasr(bl, blas.SideL, direct, nb, ncc, cl, sl, c[ll:], ldc)
+	}
+}
@@ -0,0 +1,386 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// The generic routines call the BLAS routine for their element type through
+// the functions below. Real scalars are passed as float64 and converted to
+// the precision of the call, and TransC is passed as TransT to the real
+// routines.
+
+// swap exchanges the vectors x and y.
+func swap[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int, y []T, incY int) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SSWAP(n, any(x).([]float32), incX, y, incY)
+	case []float64:
+		bl.DSWAP(n, any(x).([]float64), incX, y, incY)
+	case []complex64:
+		bl.CSWAP(n, any(x).([]complex64), incX, y, incY)
+	case []complex128:
+		bl.ZSWAP(n, any(x).([]complex128), incX, y, incY)
+	}
+}
+
+// scal computes x = alpha*x.
+func scal[T gen.Scalar](bl blas.BLAS, n int, alpha T, x []T, incX int) {
+	switch x := any(x).(type) {
+	case []float32:
+		bl.SSCAL(n, as[float32](alpha), x, incX)
+	case []float64:
+		bl.DSCAL(n, as[float64](alpha), x, incX)
+	case []complex64:
+		bl.CSCAL(n, as[complex64](alpha), x, incX)
+	case []complex128:
+		bl.ZSCAL(n, as[complex128](alpha), x, incX)
+	}
+}
+
+// rscal computes x = alpha*x for a real alpha.
+func rscal[T gen.Scalar](bl blas.BLAS, n int, alpha float64, x []T, incX int) {
+	switch x := any(x).(type) {
+	case []float32:
+		bl.SSCAL(n, float32(alpha), x, incX)
+	case []float64:
+		bl.DSCAL(n, alpha, x, incX)
+	case []complex64:
+		bl.CSSCAL(n, float32(alpha), x, incX)
+	case []complex128:
+		bl.ZDSCAL(n, alpha, x, incX)
+	}
+}
+
+// copyVec computes y = x.
+func copyVec[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int, y []T, incY int) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SCOPY(n, any(x).([]float32), incX, y, incY)
+	case []float64:
+		bl.DCOPY(n, any(x).([]float64), incX, y, incY)
+	case []complex64:
+		bl.CCOPY(n, any(x).([]complex64), incX, y, incY)
+	case []complex128:
+		bl.ZCOPY(n, any(x).([]complex128), incX, y, incY)
+	}
+}
+
+// axpy computes y = alpha*x + y.
+func axpy[T gen.Scalar](bl blas.BLAS, n int, alpha T, x []T, incX int, y []T, incY int) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SAXPY(n, as[float32](alpha), any(x).([]float32), incX, y, incY)
+	case []float64:
+		bl.DAXPY(n, as[float64](alpha), any(x).([]float64), incX, y, incY)
+	case []complex64:
+		bl.CAXPY(n, as[complex64](alpha), any(x).([]complex64), incX, y, incY)
+	case []complex128:
+		bl.ZAXPY(n, as[complex128](alpha), any(x).([]complex128), incX, y, incY)
+	}
+}
+
+// dotc returns x**H*y.
+func dotc[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int, y []T, incY int) T {
+	switch y := any(y).(type) {
+	case []float32:
+		return as[T](bl.SDOT(n, any(x).([]float32), incX, y, incY))
+	case []float64:
+		return as[T](bl.DDOT(n, any(x).([]float64), incX, y, incY))
+	case []complex64:
+		return as[T](bl.CDOTC(n, any(x).([]complex64), incX, y, incY))
+	case []complex128:
+		return as[T](bl.ZDOTC(n, any(x).([]complex128), incX, y, incY))
+	}
+	panic("unreachable")
+}
+
+// dotu returns x**T*y.
+func dotu[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int, y []T, incY int) T {
+	switch y := any(y).(type) {
+	case []float32:
+		return as[T](bl.SDOT(n, any(x).([]float32), incX, y, incY))
+	case []float64:
+		return as[T](bl.DDOT(n, any(x).([]float64), incX, y, incY))
+	case []complex64:
+		return as[T](bl.CDOTU(n, any(x).([]complex64), incX, y, incY))
+	case []complex128:
+		return as[T](bl.ZDOTU(n, any(x).([]complex128), incX, y, incY))
+	}
+	panic("unreachable")
+}
+
+// nrm2 returns the Euclidean norm of x.
+func nrm2[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int) float64 {
+	switch x := any(x).(type) {
+	case []float32:
+		return float64(bl.SNRM2(n, x, incX))
+	case []float64:
+		return bl.DNRM2(n, x, incX)
+	case []complex64:
+		return float64(bl.SCNRM2(n, x, incX))
+	case []complex128:
+		return bl.DZNRM2(n, x, incX)
+	}
+	panic("unreachable")
+}
+
+// asum returns the sum of the absolute values of x, with |re|+|im|
+// for complex elements.
+func asum[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int) float64 {
+	switch x := any(x).(type) {
+	case []float32:
+		return float64(bl.SASUM(n, x, incX))
+	case []float64:
+		return bl.DASUM(n, x, incX)
+	case []complex64:
+		return float64(bl.SCASUM(n, x, incX))
+	case []complex128:
+		return bl.DZASUM(n, x, incX)
+	}
+	panic("unreachable")
+}
+
+// iamax returns the index of the element of x with the largest
+// absolute value as measured by asum.
+func iamax[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int) int {
+	switch x := any(x).(type) {
+	case []float32:
+		return bl.ISAMAX(n, x, incX)
+	case []float64:
+		return bl.IDAMAX(n, x, incX)
+	case []complex64:
+		return bl.ICAMAX(n, x, incX)
+	case []complex128:
+		return bl.IZAMAX(n, x, incX)
+	}
+	panic("unreachable")
+}
+
+// rot applies the plane rotation [c s; -conj(s) c] to the vectors x and y.
+func rot[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int, y []T, incY int, c float64, s T) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SROT(n, any(x).([]float32), incX, y, incY, float32(c), as[float32](s))
+	case []float64:
+		bl.DROT(n, any(x).([]float64), incX, y, incY, c, as[float64](s))
+	case []complex64:
+		bl.CROT(n, any(x).([]complex64), incX, y, incY, float32(c), as[complex64](s))
+	case []complex128:
+		bl.ZROT(n, any(x).([]complex128), incX, y, incY, c, as[complex128](s))
+	}
+}
+
+// rrot applies the real plane rotation [c s; -s c] to the vectors x
+// and y.
+func rrot[T gen.Scalar](bl blas.BLAS, n int, x []T, incX int, y []T, incY int, c float64, s float64) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SROT(n, any(x).([]float32), incX, y, incY, float32(c), float32(s))
+	case []float64:
+		bl.DROT(n, any(x).([]float64), incX, y, incY, c, s)
+	case []complex64:
+		bl.CSROT(n, any(x).([]complex64), incX, y, incY, float32(c), float32(s))
+	case []complex128:
+		bl.ZDROT(n, any(x).([]complex128), incX, y, incY, c, s)
+	}
+}
+
+// gemv computes y = alpha*op(A)*x + beta*y.
+func gemv[T gen.Scalar](bl blas.BLAS, trans blas.Transpose, m int, n int, alpha T, a []T, lda int, x []T, incX int, beta T, y []T, incY int) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SGEMV(realTrans(trans), m, n, as[float32](alpha), any(a).([]float32), lda, any(x).([]float32), incX, as[float32](beta), y, incY)
+	case []float64:
+		bl.DGEMV(realTrans(trans), m, n, as[float64](alpha), any(a).([]float64), lda, any(x).([]float64), incX, as[float64](beta), y, incY)
+	case []complex64:
+		bl.CGEMV(trans, m, n, as[complex64](alpha), any(a).([]complex64), lda, any(x).([]complex64), incX, as[complex64](beta), y, incY)
+	case []complex128:
+		bl.ZGEMV(trans, m, n, as[complex128](alpha), any(a).([]complex128), lda, any(x).([]complex128), incX, as[complex128](beta), y, incY)
+	}
+}
+
+// geru computes A += alpha*x*y**T.
+func geru[T gen.Scalar](bl blas.BLAS, m int, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	switch a := any(a).(type) {
+	case []float32:
+		bl.SGER(m, n, as[float32](alpha), any(x).([]float32), incX, any(y).([]float32), incY, a, lda)
+	case []float64:
+		bl.DGER(m, n, as[float64](alpha), any(x).([]float64), incX, any(y).([]float64), incY, a, lda)
+	case []complex64:
+		bl.CGERU(m, n, as[complex64](alpha), any(x).([]complex64), incX, any(y).([]complex64), incY, a, lda)
+	case []complex128:
+		bl.ZGERU(m, n, as[complex128](alpha), any(x).([]complex128), incX, any(y).([]complex128), incY, a, lda)
+	}
+}
+
+// gerc computes A += alpha*x*y**H.
+func gerc[T gen.Scalar](bl blas.BLAS, m int, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	switch a := any(a).(type) {
+	case []float32:
+		bl.SGER(m, n, as[float32](alpha), any(x).([]float32), incX, any(y).([]float32), incY, a, lda)
+	case []float64:
+		bl.DGER(m, n, as[float64](alpha), any(x).([]float64), incX, any(y).([]float64), incY, a, lda)
+	case []complex64:
+		bl.CGERC(m, n, as[complex64](alpha), any(x).([]complex64), incX, any(y).([]complex64), incY, a, lda)
+	case []complex128:
+		bl.ZGERC(m, n, as[complex128](alpha), any(x).([]complex128), incX, any(y).([]complex128), incY, a, lda)
+	}
+}
+
+// trmv computes x = op(A)*x for a triangular A.
+func trmv[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []T, lda int, x []T, incX int) {
+	switch x := any(x).(type) {
+	case []float32:
+		bl.STRMV(uplo, realTrans(trans), diag, n, any(a).([]float32), lda, x, incX)
+	case []float64:
+		bl.DTRMV(uplo, realTrans(trans), diag, n, any(a).([]float64), lda, x, incX)
+	case []complex64:
+		bl.CTRMV(uplo, trans, diag, n, any(a).([]complex64), lda, x, incX)
+	case []complex128:
+		bl.ZTRMV(uplo, trans, diag, n, any(a).([]complex128), lda, x, incX)
+	}
+}
+
+// trsv solves op(A)*x = b for a triangular A, overwriting b by x.
+func trsv[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []T, lda int, x []T, incX int) {
+	switch x := any(x).(type) {
+	case []float32:
+		bl.STRSV(uplo, realTrans(trans), diag, n, any(a).([]float32), lda, x, incX)
+	case []float64:
+		bl.DTRSV(uplo, realTrans(trans), diag, n, any(a).([]float64), lda, x, incX)
+	case []complex64:
+		bl.CTRSV(uplo, trans, diag, n, any(a).([]complex64), lda, x, incX)
+	case []complex128:
+		bl.ZTRSV(uplo, trans, diag, n, any(a).([]complex128), lda, x, incX)
+	}
+}
+
+// hemv computes y = alpha*A*x + beta*y for a Hermitian A.
+func hemv[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, alpha T, a []T, lda int, x []T, incX int, beta T, y []T, incY int) {
+	switch y := any(y).(type) {
+	case []float32:
+		bl.SSYMV(uplo, n, as[float32](alpha), any(a).([]float32), lda, any(x).([]float32), incX, as[float32](beta), y, incY)
+	case []float64:
+		bl.DSYMV(uplo, n, as[float64](alpha), any(a).([]float64), lda, any(x).([]float64), incX, as[float64](beta), y, incY)
+	case []complex64:
+		bl.CHEMV(uplo, n, as[complex64](alpha), any(a).([]complex64), lda, any(x).([]complex64), incX, as[complex64](beta), y, incY)
+	case []complex128:
+		bl.ZHEMV(uplo, n, as[complex128](alpha), any(a).([]complex128), lda, any(x).([]complex128), incX, as[complex128](beta), y, incY)
+	}
+}
+
+// her computes A += alpha*x*x**H for a Hermitian A.
+func her[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, alpha float64, x []T, incX int, a []T, lda int) {
+	switch a := any(a).(type) {
+	case []float32:
+		bl.SSYR(uplo, n, float32(alpha), any(x).([]float32), incX, a, lda)
+	case []float64:
+		bl.DSYR(uplo, n, alpha, any(x).([]float64), incX, a, lda)
+	case []complex64:
+		bl.CHER(uplo, n, float32(alpha), any(x).([]complex64), incX, a, lda)
+	case []complex128:
+		bl.ZHER(uplo, n, alpha, any(x).([]complex128), incX, a, lda)
+	}
+}
+
+// her2 computes A += alpha*x*y**H + conj(alpha)*y*x**H for a Hermitian A.
+func her2[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, alpha T, x []T, incX int, y []T, incY int, a []T, lda int) {
+	switch a := any(a).(type) {
+	case []float32:
+		bl.SSYR2(uplo, n, as[float32](alpha), any(x).([]float32), incX, any(y).([]float32), incY, a, lda)
+	case []float64:
+		bl.DSYR2(uplo, n, as[float64](alpha), any(x).([]float64), incX, any(y).([]float64), incY, a, lda)
+	case []complex64:
+		bl.CHER2(uplo, n, as[complex64](alpha), any(x).([]complex64), incX, any(y).([]complex64), incY, a, lda)
+	case []complex128:
+		bl.ZHER2(uplo, n, as[complex128](alpha), any(x).([]complex128), incX, any(y).([]complex128), incY, a, lda)
+	}
+}
+
+// gemm computes C = alpha*op(A)*op(B) + beta*C.
+func gemm[T gen.Scalar](bl blas.BLAS, transA blas.Transpose, transB blas.Transpose, m int, n int, k int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	switch c := any(c).(type) {
+	case []float32:
+		bl.SGEMM(realTrans(transA), realTrans(transB), m, n, k, as[float32](alpha), any(a).([]float32), lda, any(b).([]float32), ldb, as[float32](beta), c, ldc)
+	case []float64:
+		bl.DGEMM(realTrans(transA), realTrans(transB), m, n, k, as[float64](alpha), any(a).([]float64), lda, any(b).([]float64), ldb, as[float64](beta), c, ldc)
+	case []complex64:
+		bl.CGEMM(transA, transB, m, n, k, as[complex64](alpha), any(a).([]complex64), lda, any(b).([]complex64), ldb, as[complex64](beta), c, ldc)
+	case []complex128:
+		bl.ZGEMM(transA, transB, m, n, k, as[complex128](alpha), any(a).([]complex128), lda, any(b).([]complex128), ldb, as[complex128](beta), c, ldc)
+	}
+}
+
+// hemm computes C = alpha*A*B + beta*C or C = alpha*B*A + beta*C for a
+// Hermitian A.
+func hemm[T gen.Scalar](bl blas.BLAS, side blas.Side, uplo blas.Uplo, m int, n int, alpha T, a []T, lda int, b []T, ldb int, beta T, c []T, ldc int) {
+	switch c := any(c).(type) {
+	case []float32:
+		bl.SSYMM(side, uplo, m, n, as[float32](alpha), any(a).([]float32), lda, any(b).([]float32), ldb, as[float32](beta), c, ldc)
+	case []float64:
+		bl.DSYMM(side, uplo, m, n, as[float64](alpha), any(a).([]float64), lda, any(b).([]float64), ldb, as[float64](beta), c, ldc)
+	case []complex64:
+		bl.CHEMM(side, uplo, m, n, as[complex64](alpha), any(a).([]complex64), lda, any(b).([]complex64), ldb, as[complex64](beta), c, ldc)
+	case []complex128:
+		bl.ZHEMM(side, uplo, m, n, as[complex128](alpha), any(a).([]complex128), lda, any(b).([]complex128), ldb, as[complex128](beta), c, ldc)
+	}
+}
+
+// herk computes C = alpha*A*A**H + beta*C or C = alpha*A**H*A + beta*C
+// for a Hermitian C.
+func herk[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, trans blas.Transpose, n int, k int, alpha float64, a []T, lda int, beta float64, c []T, ldc int) {
+	switch c := any(c).(type) {
+	case []float32:
+		bl.SSYRK(uplo, realTrans(trans), n, k, float32(alpha), any(a).([]float32), lda, float32(beta), c, ldc)
+	case []float64:
+		bl.DSYRK(uplo, realTrans(trans), n, k, alpha, any(a).([]float64), lda, beta, c, ldc)
+	case []complex64:
+		bl.CHERK(uplo, trans, n, k, float32(alpha), any(a).([]complex64), lda, float32(beta), c, ldc)
+	case []complex128:
+		bl.ZHERK(uplo, trans, n, k, alpha, any(a).([]complex128), lda, beta, c, ldc)
+	}
+}
+
+// her2k computes the Hermitian rank-2k update of C.
+func her2k[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, trans blas.Transpose, n int, k int, alpha T, a []T, lda int, b []T, ldb int, beta float64, c []T, ldc int) {
+	switch c := any(c).(type) {
+	case []float32:
+		bl.SSYR2K(uplo, realTrans(trans), n, k, as[float32](alpha), any(a).([]float32), lda, any(b).([]float32), ldb, float32(beta), c, ldc)
+	case []float64:
+		bl.DSYR2K(uplo, realTrans(trans), n, k, as[float64](alpha), any(a).([]float64), lda, any(b).([]float64), ldb, beta, c, ldc)
+	case []complex64:
+		bl.CHER2K(uplo, trans, n, k, as[complex64](alpha), any(a).([]complex64), lda, any(b).([]complex64), ldb, float32(beta), c, ldc)
+	case []complex128:
+		bl.ZHER2K(uplo, trans, n, k, as[complex128](alpha), any(a).([]complex128), lda, any(b).([]complex128), ldb, beta, c, ldc)
+	}
+}
+
+// trmm computes B = alpha*op(A)*B or B = alpha*B*op(A) for a triangular A.
+func trmm[T gen.Scalar](bl blas.BLAS, side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m int, n int, alpha T, a []T, lda int, b []T, ldb int) {
+	switch b := any(b).(type) {
+	case []float32:
+		bl.STRMM(side, uplo, realTrans(trans), diag, m, n, as[float32](alpha), any(a).([]float32), lda, b, ldb)
+	case []float64:
+		bl.DTRMM(side, uplo, realTrans(trans), diag, m, n, as[float64](alpha), any(a).([]float64), lda, b, ldb)
+	case []complex64:
+		bl.CTRMM(side, uplo, trans, diag, m, n, as[complex64](alpha), any(a).([]complex64), lda, b, ldb)
+	case []complex128:
+		bl.ZTRMM(side, uplo, trans, diag, m, n, as[complex128](alpha), any(a).([]complex128), lda, b, ldb)
+	}
+}
+
+// trsm solves op(A)*X = alpha*B or X*op(A) = alpha*B for a triangular
+// A, overwriting B by X.
+func trsm[T gen.Scalar](bl blas.BLAS, side blas.Side, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, m int, n int, alpha T, a []T, lda int, b []T, ldb int) {
+	switch b := any(b).(type) {
+	case []float32:
+		bl.STRSM(side, uplo, realTrans(trans), diag, m, n, as[float32](alpha), any(a).([]float32), lda, b, ldb)
+	case []float64:
+		bl.DTRSM(side, uplo, realTrans(trans), diag, m, n, as[float64](alpha), any(a).([]float64), lda, b, ldb)
+	case []complex64:
+		bl.CTRSM(side, uplo, trans, diag, m, n, as[complex64](alpha), any(a).([]complex64), lda, b, ldb)
+	case []complex128:
+		bl.ZTRSM(side, uplo, trans, diag, m, n, as[complex128](alpha), any(a).([]complex128), lda, b, ldb)
+	}
+}
@@ -0,0 +1,689 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEBD2 reduces the m×n matrix A to bidiagonal form B = Q**T*A*P one
+// column and row at a time. B is upper bidiagonal if m >= n and lower
+// bidiagonal otherwise, and is real; its diagonal is returned in d and its
+// off-diagonal in e. Q = H(0)*H(1)*...*H(k-1) and P = G(0)*G(1)*...*G(k-1),
+// k = min(m,n), are products of elementary reflectors H(i) = I -
+// tauq[i]*v*v**T and G(i) = I - taup[i]*u*u**T. For m >= n the vectors v
+// are stored below the diagonal of A and the vectors u right of the
+// superdiagonal; for m < n they are stored below the subdiagonal and right
+// of the diagonal. work must hold max(m,n) elements.
+func (impl Implementation) SGEBD2(m, n int, a []float32, lda int, d, e []float32, tauq, taup, work []float32) error {
+	if err := checkGebrd("SGEBD2", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), noLwork); err != nil {
+		return err
+	}
+	gebd2(impl.bl(), m, n, a, lda, d, e, tauq, taup, work)
+	return nil
+}
+
+// SGEBRD computes the reduction of SGEBD2 with a blocked algorithm that
+// reduces panels of rows and columns and updates the rest of A with matrix
+// products. work holds lwork >= max(1,m,n) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SGEBRD(m, n int, a []float32, lda int, d, e []float32, tauq, taup, work []float32, lwork int) error {
+	if err := checkGebrd("SGEBRD", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), lwork); err != nil {
+		return err
+	}
+	gebrd(impl.bl(), m, n, a, lda, d, e, tauq, taup, work, lwork)
+	return nil
+}
+
+// SORGBR overwrites the m×n matrix A, which holds the reflectors left by
+// SGEBRD, with one of the orthogonal matrices of the reduction. For vect Q,
+// SGEBRD reduced an m×k matrix and A receives the first n columns of the
+// m×m matrix Q, with m >= n >= min(m,k). For vect P, SGEBRD reduced a k×n
+// matrix and A receives the first m rows of the n×n matrix P**T, with
+// n >= m >= min(n,k). tau is tauq or taup. work holds lwork >=
+// max(1,min(m,n)) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGBR(vect Vect, m, n, k int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrgbr("SORGBR", vect, m, n, k, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgbr(impl.bl(), vect, m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORMBR overwrites the m×n matrix C with op(X)*C (side L) or C*op(X)
+// (side R), where X is the Q (vect Q) or P (vect P) of the reduction of
+// SGEBRD, given by the reflectors left in A and tau, and op(X) is X
+// (trans N) or X**T (trans T). Let nq be m for side L and n for side R. For
+// vect Q, SGEBRD reduced an nq×k matrix; for vect P, a k×nq matrix. work
+// holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) SORMBR(vect Vect, side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmbr("SORMBR", vect, side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false); err != nil {
+		return err
+	}
+	ormbr(impl.bl(), vect, side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DGEBD2 reduces the m×n matrix A to bidiagonal form B = Q**T*A*P one
+// column and row at a time. B is upper bidiagonal if m >= n and lower
+// bidiagonal otherwise, and is real; its diagonal is returned in d and its
+// off-diagonal in e. Q = H(0)*H(1)*...*H(k-1) and P = G(0)*G(1)*...*G(k-1),
+// k = min(m,n), are products of elementary reflectors H(i) = I -
+// tauq[i]*v*v**T and G(i) = I - taup[i]*u*u**T. For m >= n the vectors v
+// are stored below the diagonal of A and the vectors u right of the
+// superdiagonal; for m < n they are stored below the subdiagonal and right
+// of the diagonal. work must hold max(m,n) elements.
+func (impl Implementation) DGEBD2(m, n int, a []float64, lda int, d, e []float64, tauq, taup, work []float64) error {
+	if err := checkGebrd("DGEBD2", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), noLwork); err != nil {
+		return err
+	}
+	gebd2(impl.bl(), m, n, a, lda, d, e, tauq, taup, work)
+	return nil
+}
+
+// DGEBRD computes the reduction of DGEBD2 with a blocked algorithm that
+// reduces panels of rows and columns and updates the rest of A with matrix
+// products. work holds lwork >= max(1,m,n) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DGEBRD(m, n int, a []float64, lda int, d, e []float64, tauq, taup, work []float64, lwork int) error {
+	if err := checkGebrd("DGEBRD", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), lwork); err != nil {
+		return err
+	}
+	gebrd(impl.bl(), m, n, a, lda, d, e, tauq, taup, work, lwork)
+	return nil
+}
+
+// DORGBR overwrites the m×n matrix A, which holds the reflectors left by
+// DGEBRD, with one of the orthogonal matrices of the reduction. For vect Q,
+// DGEBRD reduced an m×k matrix and A receives the first n columns of the
+// m×m matrix Q, with m >= n >= min(m,k). For vect P, DGEBRD reduced a k×n
+// matrix and A receives the first m rows of the n×n matrix P**T, with
+// n >= m >= min(n,k). tau is tauq or taup. work holds lwork >=
+// max(1,min(m,n)) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGBR(vect Vect, m, n, k int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrgbr("DORGBR", vect, m, n, k, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgbr(impl.bl(), vect, m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORMBR overwrites the m×n matrix C with op(X)*C (side L) or C*op(X)
+// (side R), where X is the Q (vect Q) or P (vect P) of the reduction of
+// DGEBRD, given by the reflectors left in A and tau, and op(X) is X
+// (trans N) or X**T (trans T). Let nq be m for side L and n for side R. For
+// vect Q, DGEBRD reduced an nq×k matrix; for vect P, a k×nq matrix. work
+// holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) DORMBR(vect Vect, side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmbr("DORMBR", vect, side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false); err != nil {
+		return err
+	}
+	ormbr(impl.bl(), vect, side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CGEBD2 reduces the m×n matrix A to bidiagonal form B = Q**H*A*P one
+// column and row at a time. B is upper bidiagonal if m >= n and lower
+// bidiagonal otherwise, and is real; its diagonal is returned in d and its
+// off-diagonal in e. Q = H(0)*H(1)*...*H(k-1) and P = G(0)*G(1)*...*G(k-1),
+// k = min(m,n), are products of elementary reflectors H(i) = I -
+// tauq[i]*v*v**H and G(i) = I - taup[i]*u*u**H. For m >= n the vectors v
+// are stored below the diagonal of A and the vectors u right of the
+// superdiagonal; for m < n they are stored below the subdiagonal and right
+// of the diagonal. work must hold max(m,n) elements.
+func (impl Implementation) CGEBD2(m, n int, a []complex64, lda int, d, e []float32, tauq, taup, work []complex64) error {
+	if err := checkGebrd("CGEBD2", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), noLwork); err != nil {
+		return err
+	}
+	gebd2(impl.bl(), m, n, a, lda, d, e, tauq, taup, work)
+	return nil
+}
+
+// CGEBRD computes the reduction of CGEBD2 with a blocked algorithm that
+// reduces panels of rows and columns and updates the rest of A with matrix
+// products. work holds lwork >= max(1,m,n) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CGEBRD(m, n int, a []complex64, lda int, d, e []float32, tauq, taup, work []complex64, lwork int) error {
+	if err := checkGebrd("CGEBRD", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), lwork); err != nil {
+		return err
+	}
+	gebrd(impl.bl(), m, n, a, lda, d, e, tauq, taup, work, lwork)
+	return nil
+}
+
+// CUNGBR overwrites the m×n matrix A, which holds the reflectors left by
+// CGEBRD, with one of the unitary matrices of the reduction. For vect Q,
+// CGEBRD reduced an m×k matrix and A receives the first n columns of the
+// m×m matrix Q, with m >= n >= min(m,k). For vect P, CGEBRD reduced a k×n
+// matrix and A receives the first m rows of the n×n matrix P**H, with
+// n >= m >= min(n,k). tau is tauq or taup. work holds lwork >=
+// max(1,min(m,n)) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGBR(vect Vect, m, n, k int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrgbr("CUNGBR", vect, m, n, k, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgbr(impl.bl(), vect, m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNMBR overwrites the m×n matrix C with op(X)*C (side L) or C*op(X)
+// (side R), where X is the Q (vect Q) or P (vect P) of the reduction of
+// CGEBRD, given by the reflectors left in A and tau, and op(X) is X
+// (trans N) or X**H (trans C). Let nq be m for side L and n for side R. For
+// vect Q, CGEBRD reduced an nq×k matrix; for vect P, a k×nq matrix. work
+// holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CUNMBR(vect Vect, side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmbr("CUNMBR", vect, side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true); err != nil {
+		return err
+	}
+	ormbr(impl.bl(), vect, side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZGEBD2 reduces the m×n matrix A to bidiagonal form B = Q**H*A*P one
+// column and row at a time. B is upper bidiagonal if m >= n and lower
+// bidiagonal otherwise, and is real; its diagonal is returned in d and its
+// off-diagonal in e. Q = H(0)*H(1)*...*H(k-1) and P = G(0)*G(1)*...*G(k-1),
+// k = min(m,n), are products of elementary reflectors H(i) = I -
+// tauq[i]*v*v**H and G(i) = I - taup[i]*u*u**H. For m >= n the vectors v
+// are stored below the diagonal of A and the vectors u right of the
+// superdiagonal; for m < n they are stored below the subdiagonal and right
+// of the diagonal. work must hold max(m,n) elements.
+func (impl Implementation) ZGEBD2(m, n int, a []complex128, lda int, d, e []float64, tauq, taup, work []complex128) error {
+	if err := checkGebrd("ZGEBD2", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), noLwork); err != nil {
+		return err
+	}
+	gebd2(impl.bl(), m, n, a, lda, d, e, tauq, taup, work)
+	return nil
+}
+
+// ZGEBRD computes the reduction of ZGEBD2 with a blocked algorithm that
+// reduces panels of rows and columns and updates the rest of A with matrix
+// products. work holds lwork >= max(1,m,n) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZGEBRD(m, n int, a []complex128, lda int, d, e []float64, tauq, taup, work []complex128, lwork int) error {
+	if err := checkGebrd("ZGEBRD", m, n, len(a), lda, len(d), len(e), len(tauq), len(taup), len(work), lwork); err != nil {
+		return err
+	}
+	gebrd(impl.bl(), m, n, a, lda, d, e, tauq, taup, work, lwork)
+	return nil
+}
+
+// ZUNGBR overwrites the m×n matrix A, which holds the reflectors left by
+// ZGEBRD, with one of the unitary matrices of the reduction. For vect Q,
+// ZGEBRD reduced an m×k matrix and A receives the first n columns of the
+// m×m matrix Q, with m >= n >= min(m,k). For vect P, ZGEBRD reduced a k×n
+// matrix and A receives the first m rows of the n×n matrix P**H, with
+// n >= m >= min(n,k). tau is tauq or taup. work holds lwork >=
+// max(1,min(m,n)) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGBR(vect Vect, m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrgbr("ZUNGBR", vect, m, n, k, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgbr(impl.bl(), vect, m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNMBR overwrites the m×n matrix C with op(X)*C (side L) or C*op(X)
+// (side R), where X is the Q (vect Q) or P (vect P) of the reduction of
+// ZGEBRD, given by the reflectors left in A and tau, and op(X) is X
+// (trans N) or X**H (trans C). Let nq be m for side L and n for side R. For
+// vect Q, ZGEBRD reduced an nq×k matrix; for vect P, a k×nq matrix. work
+// holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZUNMBR(vect Vect, side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmbr("ZUNMBR", vect, side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true); err != nil {
+		return err
+	}
+	ormbr(impl.bl(), vect, side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// checkGebrd checks the GEBD2 and GEBRD routines.
+func checkGebrd(routine string, m, n, lenA, lda, lenD, lenE, lenTauq, lenTaup, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, m, "m")
+	if lwork != noLwork {
+		c.lwork(10, lwork, max(1, m, n), "max(1,m,n)")
+	}
+	if c.ok() {
+		if lwork == noLwork {
+			c.length(9, "work", lenWork, max(m, n))
+		} else {
+			c.work(9, lenWork, lwork)
+		}
+		if lwork != -1 {
+			k := min(m, n)
+			c.length(3, "a", lenA, matLen(m, n, lda))
+			c.length(5, "d", lenD, k)
+			c.length(6, "e", lenE, max(0, k-1))
+			c.length(7, "tauq", lenTauq, k)
+			c.length(8, "taup", lenTaup, k)
+		}
+	}
+	return c.result()
+}
+
+// checkOrgbr checks the ORGBR and UNGBR routines.
+func checkOrgbr(routine string, vect Vect, m, n, k, lenA, lda, lenTau, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.vect(1, vect)
+	c.nonNeg(2, "m", m)
+	wantq := vect == VectQ
+	switch {
+	case wantq && (n < 0 || n > m || n < min(m, k)):
+		c.fail(3, "n", "must satisfy min(m,k) <= n <= m")
+	case !wantq && (n < 0 || m > n || m < min(n, k)):
+		c.fail(3, "n", "must satisfy min(n,k) <= m <= n")
+	}
+	c.nonNeg(4, "k", k)
+	c.ld(6, "lda", lda, m, "m")
+	c.lwork(9, lwork, max(1, min(m, n)), "max(1,min(m,n))")
+	if c.ok() {
+		c.work(8, lenWork, lwork)
+		if lwork != -1 {
+			c.length(5, "a", lenA, matLen(m, n, lda))
+			if wantq {
+				c.length(7, "tau", lenTau, min(m, k))
+			} else {
+				c.length(7, "tau", lenTau, min(n, k))
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkOrmbr checks the ORMBR and UNMBR routines.
+func checkOrmbr(routine string, vect Vect, side blas.Side, trans blas.Transpose, m, n, k, lenA, lda, lenTau, lenC, ldc, lenWork, lwork int, complex bool) error {
+	c := checker{routine: routine}
+	c.vect(1, vect)
+	c.side(2, side)
+	c.transQ(3, trans, complex)
+	c.nonNeg(4, "m", m)
+	c.nonNeg(5, "n", n)
+	c.nonNeg(6, "k", k)
+	nq, nw := m, n
+	if side == blas.SideR {
+		nq, nw = n, m
+	}
+	wantq := vect == VectQ
+	if wantq {
+		c.ld(8, "lda", lda, nq, "nq")
+	} else {
+		c.ld(8, "lda", lda, min(nq, k), "min(nq,k)")
+	}
+	c.ld(11, "ldc", ldc, m, "m")
+	c.lwork(13, lwork, max(1, nw), "max(1,nw)")
+	if c.ok() {
+		c.work(12, lenWork, lwork)
+		if lwork != -1 {
+			if wantq {
+				c.length(7, "a", lenA, matLen(nq, min(nq, k), lda))
+			} else {
+				c.length(7, "a", lenA, matLen(min(nq, k), nq, lda))
+			}
+			c.length(9, "tau", lenTau, min(nq, k))
+			c.length(10, "c", lenC, matLen(m, n, ldc))
+		}
+	}
+	return c.result()
+}
+
+// brdBlock is the block size of gebrd, the value of ILAENV for xGEBRD.
+const brdBlock = 32
+
+// brdCrossover is the order below which gebrd uses the unblocked code, the
+// value of ILAENV(3, ...) for xGEBRD.
+const brdCrossover = 128
+
+// gebd2 reduces the m×n matrix A to real bidiagonal form B = Q**H*A*P one
+// column and row at a time, as xGEBD2. B is upper bidiagonal if m >= n and
+// lower bidiagonal otherwise; its diagonal is returned in d and its
+// off-diagonal in e, which hold min(m,n) and min(m,n)-1 elements. Q and P
+// are the products of the elementary reflectors H(i) = I - tauq[i]*v*v**H
+// and G(i) = I - taup[i]*u*u**H, whose vectors are stored in A below and
+// right of the bidiagonal as in xGEBRD. work must hold max(m,n) elements.
+func gebd2[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n int, a []T, lda int, d, e []R, tauq, taup, work []T) {
+	if m >= n {
+		for i := 0; i < n; i++ {
+			ii := i + i*lda
+			// Generate H(i) to annihilate A(i+1:m,i).
+			var alpha T
+			alpha, tauq[i] = larfg(bl, m-i, a[ii], a[min(i+1, m-1)+i*lda:], 1)
+			d[i] = R(re(alpha))
+			a[ii] = 1
+			// Apply H(i)**H to A(i:m,i+1:n) from the left.
+			if i < n-1 {
+				larf(bl, blas.SideL, m-i, n-i-1, a[ii:], 1, conj(tauq[i]), a[ii+lda:], lda, work)
+			}
+			a[ii] = fromReal[T](float64(d[i]))
+			if i == n-1 {
+				taup[i] = 0
+				continue
+			}
+			// Generate G(i) to annihilate A(i,i+2:n).
+			lacgv(n-i-1, a[ii+lda:], lda)
+			alpha, taup[i] = larfg(bl, n-i-1, a[ii+lda], a[i+min(i+2, n-1)*lda:], lda)
+			e[i] = R(re(alpha))
+			a[ii+lda] = 1
+			// Apply G(i) to A(i+1:m,i+1:n) from the right.
+			larf(bl, blas.SideR, m-i-1, n-i-1, a[ii+lda:], lda, taup[i], a[ii+1+lda:], lda, work)
+			lacgv(n-i-1, a[ii+lda:], lda)
+			a[ii+lda] = fromReal[T](float64(e[i]))
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		ii := i + i*lda
+		// Generate G(i) to annihilate A(i,i+1:n).
+		lacgv(n-i, a[ii:], lda)
+		var alpha T
+		alpha, taup[i] = larfg(bl, n-i, a[ii], a[i+min(i+1, n-1)*lda:], lda)
+		d[i] = R(re(alpha))
+		a[ii] = 1
+		// Apply G(i) to A(i+1:m,i:n) from the right.
+		if i < m-1 {
+			larf(bl, blas.SideR, m-i-1, n-i, a[ii:], lda, taup[i], a[ii+1:], lda, work)
+		}
+		lacgv(n-i, a[ii:], lda)
+		a[ii] = fromReal[T](float64(d[i]))
+		if i == m-1 {
+			tauq[i] = 0
+			continue
+		}
+		// Generate H(i) to annihilate A(i+2:m,i).
+		alpha, tauq[i] = larfg(bl, m-i-1, a[ii+1], a[min(i+2, m-1)+i*lda:], 1)
+		e[i] = R(re(alpha))
+		a[ii+1] = 1
+		// Apply H(i)**H to A(i+1:m,i+1:n) from the left.
+		larf(bl, blas.SideL, m-i-1, n-i-1, a[ii+1:], 1, conj(tauq[i]), a[ii+1+lda:], lda, work)
+		a[ii+1] = fromReal[T](float64(e[i]))
+	}
+}
+
+// labrd reduces the first nb rows and columns of the m×n matrix A to
+// bidiagonal form as a panel of gebrd, as xLABRD, and returns the m×nb
+// matrix X and the n×nb matrix Y needed to apply the transformation to the
+// rest of A as A - V*Y**H - X*U**H, where V and U hold the vectors of the
+// reflectors. d, e, tauq and taup receive nb elements.
+func labrd[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n, nb int, a []T, lda int, d, e []R, tauq, taup, x []T, ldx int, y []T, ldy int) {
+	if m <= 0 || n <= 0 {
+		return
+	}
+	one, zero := T(1), T(0)
+	if m >= n {
+		// Reduce to upper bidiagonal form.
+		for i := 0; i < nb; i++ {
+			ii := i + i*lda
+			// Update A(i:m,i).
+			lacgv(i, y[i:], ldy)
+			gemv(bl, blas.TransN, m-i, i, -one, a[i:], lda, y[i:], ldy, one, a[ii:], 1)
+			lacgv(i, y[i:], ldy)
+			gemv(bl, blas.TransN, m-i, i, -one, x[i:], ldx, a[i*lda:], 1, one, a[ii:], 1)
+			// Generate H(i) to annihilate A(i+1:m,i).
+			var alpha T
+			alpha, tauq[i] = larfg(bl, m-i, a[ii], a[min(i+1, m-1)+i*lda:], 1)
+			d[i] = R(re(alpha))
+			if i == n-1 {
+				taup[i] = 0
+				continue
+			}
+			a[ii] = 1
+			// Compute Y(i+1:n,i).
+			yi := y[i*ldy:]
+			gemv(bl, blas.TransC, m-i, n-i-1, one, a[ii+lda:], lda, a[ii:], 1, zero, yi[i+1:], 1)
+			gemv(bl, blas.TransC, m-i, i, one, a[i:], lda, a[ii:], 1, zero, yi, 1)
+			gemv(bl, blas.TransN, n-i-1, i, -one, y[i+1:], ldy, yi, 1, one, yi[i+1:], 1)
+			gemv(bl, blas.TransC, m-i, i, one, x[i:], ldx, a[ii:], 1, zero, yi, 1)
+			gemv(bl, blas.TransC, i, n-i-1, -one, a[(i+1)*lda:], lda, yi, 1, one, yi[i+1:], 1)
+			scal(bl, n-i-1, tauq[i], yi[i+1:], 1)
+			// Update A(i,i+1:n).
+			lacgv(n-i-1, a[ii+lda:], lda)
+			lacgv(i+1, a[i:], lda)
+			gemv(bl, blas.TransN, n-i-1, i+1, -one, y[i+1:], ldy, a[i:], lda, one, a[ii+lda:], lda)
+			lacgv(i+1, a[i:], lda)
+			lacgv(i, x[i:], ldx)
+			gemv(bl, blas.TransC, i, n-i-1, -one, a[(i+1)*lda:], lda, x[i:], ldx, one, a[ii+lda:], lda)
+			lacgv(i, x[i:], ldx)
+			// Generate G(i) to annihilate A(i,i+2:n).
+			alpha, taup[i] = larfg(bl, n-i-1, a[ii+lda], a[i+min(i+2, n-1)*lda:], lda)
+			e[i] = R(re(alpha))
+			a[ii+lda] = 1
+			// Compute X(i+1:m,i).
+			xi := x[i*ldx:]
+			gemv(bl, blas.TransN, m-i-1, n-i-1, one, a[ii+1+lda:], lda, a[ii+lda:], lda, zero, xi[i+1:], 1)
+			gemv(bl, blas.TransC, n-i-1, i+1, one, y[i+1:], ldy, a[ii+lda:], lda, zero, xi, 1)
+			gemv(bl, blas.TransN, m-i-1, i+1, -one, a[i+1:], lda, xi, 1, one, xi[i+1:], 1)
+			gemv(bl, blas.TransN, i, n-i-1, one, a[(i+1)*lda:], lda, a[ii+lda:], lda, zero, xi, 1)
+			gemv(bl, blas.TransN, m-i-1, i, -one, x[i+1:], ldx, xi, 1, one, xi[i+1:], 1)
+			scal(bl, m-i-1, taup[i], xi[i+1:], 1)
+			lacgv(n-i-1, a[ii+lda:], lda)
+		}
+		return
+	}
+	// Reduce to lower bidiagonal form.
+	for i := 0; i < nb; i++ {
+		ii := i + i*lda
+		// Update A(i,i:n).
+		lacgv(n-i, a[ii:], lda)
+		lacgv(i, a[i:], lda)
+		gemv(bl, blas.TransN, n-i, i, -one, y[i:], ldy, a[i:], lda, one, a[ii:], lda)
+		lacgv(i, a[i:], lda)
+		lacgv(i, x[i:], ldx)
+		gemv(bl, blas.TransC, i, n-i, -one, a[i*lda:], lda, x[i:], ldx, one, a[ii:], lda)
+		lacgv(i, x[i:], ldx)
+		// Generate G(i) to annihilate A(i,i+1:n).
+		var alpha T
+		alpha, taup[i] = larfg(bl, n-i, a[ii], a[i+min(i+1, n-1)*lda:], lda)
+		d[i] = R(re(alpha))
+		if i == m-1 {
+			tauq[i] = 0
+			lacgv(n-i, a[ii:], lda)
+			continue
+		}
+		a[ii] = 1
+		// Compute X(i+1:m,i).
+		xi := x[i*ldx:]
+		gemv(bl, blas.TransN, m-i-1, n-i, one, a[ii+1:], lda, a[ii:], lda, zero, xi[i+1:], 1)
+		gemv(bl, blas.TransC, n-i, i, one, y[i:], ldy, a[ii:], lda, zero, xi, 1)
+		gemv(bl, blas.TransN, m-i-1, i, -one, a[i+1:], lda, xi, 1, one, xi[i+1:], 1)
+		gemv(bl, blas.TransN, i, n-i, one, a[i*lda:], lda, a[ii:], lda, zero, xi, 1)
+		gemv(bl, blas.TransN, m-i-1, i, -one, x[i+1:], ldx, xi, 1, one, xi[i+1:], 1)
+		scal(bl, m-i-1, taup[i], xi[i+1:], 1)
+		lacgv(n-i, a[ii:], lda)
+		// Update A(i+1:m,i).
+		lacgv(i, y[i:], ldy)
+		gemv(bl, blas.TransN, m-i-1, i, -one, a[i+1:], lda, y[i:], ldy, one, a[ii+1:], 1)
+		lacgv(i, y[i:], ldy)
+		gemv(bl, blas.TransN, m-i-1, i+1, -one, x[i+1:], ldx, a[i*lda:], 1, one, a[ii+1:], 1)
+		// Generate H(i) to annihilate A(i+2:m,i).
+		alpha, tauq[i] = larfg(bl, m-i-1, a[ii+1], a[min(i+2, m-1)+i*lda:], 1)
+		e[i] = R(re(alpha))
+		a[ii+1] = 1
+		// Compute Y(i+1:n,i).
+		yi := y[i*ldy:]
+		gemv(bl, blas.TransC, m-i-1, n-i-1, one, a[ii+1+lda:], lda, a[ii+1:], 1, zero, yi[i+1:], 1)
+		gemv(bl, blas.TransC, m-i-1, i, one, a[i+1:], lda, a[ii+1:], 1, zero, yi, 1)
+		gemv(bl, blas.TransN, n-i-1, i, -one, y[i+1:], ldy, yi, 1, one, yi[i+1:], 1)
+		gemv(bl, blas.TransC, m-i-1, i+1, one, x[i+1:], ldx, a[ii+1:], 1, zero, yi, 1)
+		gemv(bl, blas.TransC, i+1, n-i-1, -one, a[(i+1)*lda:], lda, yi, 1, one, yi[i+1:], 1)
+		scal(bl, n-i-1, tauq[i], yi[i+1:], 1)
+	}
+}
+
+// gebrdWork returns the optimal workspace length of gebrd.
+func gebrdWork(m, n int) int {
+	return max(1, (m+n)*brdBlock)
+}
+
+// gebrd computes the reduction of gebd2 with the blocked algorithm of
+// xGEBRD, which reduces panels with labrd and updates the rest of A with
+// two matrix products. work holds lwork >= max(m,n) elements, and the block
+// size is reduced to fit. A workspace query, lwork = -1, only sets work[0]
+// to the optimal lwork.
+func gebrd[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n int, a []T, lda int, d, e []R, tauq, taup, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(gebrdWork(m, n)))
+		return
+	}
+	minmn := min(m, n)
+	if minmn == 0 {
+		return
+	}
+	nb, nx := brdBlock, minmn
+	ldwrkx, ldwrky := m, n
+	if nb > 1 && nb < minmn {
+		nx = max(nb, brdCrossover)
+		if nx < minmn && lwork < (m+n)*nb {
+			nb = lwork / (m + n)
+			if nb < 2 {
+				nx = minmn
+			}
+		}
+	}
+	one := T(1)
+	i := 0
+	for ; i < minmn-nx; i += nb {
+		ii := i + i*lda
+		// Reduce rows and columns i:i+nb to bidiagonal form and return the
+		// matrices X and Y needed to update the rest of A.
+		labrd(bl, m-i, n-i, nb, a[ii:], lda, d[i:], e[i:], tauq[i:], taup[i:], work, ldwrkx, work[ldwrkx*nb:], ldwrky)
+		// Update A(i+nb:m,i+nb:n) = A - V*Y**H - X*U**H.
+		gemm(bl, blas.TransN, blas.TransC, m-i-nb, n-i-nb, nb, -one, a[ii+nb:], lda, work[ldwrkx*nb+nb:], ldwrky, one, a[ii+nb+nb*lda:], lda)
+		gemm(bl, blas.TransN, blas.TransN, m-i-nb, n-i-nb, nb, -one, work[nb:], ldwrkx, a[ii+nb*lda:], lda, one, a[ii+nb+nb*lda:], lda)
+		// Copy the bidiagonal back into A.
+		for j := i; j < i+nb; j++ {
+			a[j+j*lda] = fromReal[T](float64(d[j]))
+			if m >= n {
+				a[j+(j+1)*lda] = fromReal[T](float64(e[j]))
+			} else {
+				a[j+1+j*lda] = fromReal[T](float64(e[j]))
+			}
+		}
+	}
+	gebd2(bl, m-i, n-i, a[i+i*lda:], lda, d[i:], e[i:], tauq[i:], taup[i:], work)
+}
+
+// orgbrWork returns the optimal workspace length of orgbr.
+func orgbrWork(m, n int) int {
+	return max(1, min(m, n)) * qrBlock
+}
+
+// orgbr overwrites the m×n matrix A, which holds reflectors left by gebrd
+// for a matrix with k columns (vect Q) or k rows (vect P), with the first n
+// columns of Q or the first m rows of P**H, as xORGBR. Q is m×m and P is
+// n×n; vect Q needs m >= n >= min(m,k) and vect P needs n >= m >= min(n,k).
+// work holds lwork >= min(m,n) elements; a workspace query, lwork = -1, only
+// sets work[0] to the optimal lwork.
+func orgbr[T gen.Scalar](bl blas.BLAS, vect Vect, m, n, k int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(orgbrWork(m, n)))
+		return
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	if vect == VectQ {
+		if m >= k {
+			orgqr(bl, m, n, k, a, lda, tau, work, lwork)
+			return
+		}
+		// The reflectors of gebrd with m < k start below the diagonal: shift
+		// them one column to the right and set the first row and column of
+		// Q to those of the identity.
+		for j := m - 1; j >= 1; j-- {
+			a[j*lda] = 0
+			for i := j + 1; i < m; i++ {
+				a[i+j*lda] = a[i+(j-1)*lda]
+			}
+		}
+		a[0] = 1
+		for i := 1; i < m; i++ {
+			a[i] = 0
+		}
+		if m > 1 {
+			orgqr(bl, m-1, m-1, m-1, a[1+lda:], lda, tau, work, lwork)
+		}
+		return
+	}
+	if k < n {
+		orglq(bl, m, n, k, a, lda, tau, work, lwork)
+		return
+	}
+	// The reflectors of gebrd with k >= n start right of the diagonal: shift
+	// them one row down and set the first row and column of P**H to those of
+	// the identity.
+	a[0] = 1
+	for i := 1; i < n; i++ {
+		a[i] = 0
+	}
+	for j := 1; j < n; j++ {
+		for i := j - 1; i >= 1; i-- {
+			a[i+j*lda] = a[i-1+j*lda]
+		}
+		a[j*lda] = 0
+	}
+	if n > 1 {
+		orglq(bl, n-1, n-1, n-1, a[1+lda:], lda, tau, work, lwork)
+	}
+}
+
+// ormbr overwrites the m×n matrix C with op(Q)*C or C*op(Q) (vect Q), or
+// op(P)*C or C*op(P) (vect P), where Q and P are given by the reflectors
+// left in A and tau by gebrd for a matrix with nq rows and k columns (vect
+// Q) or k rows and nq columns (vect P), nq being m for side L and n for
+// side R, as xORMBR. work holds lwork >= nw elements, where nw is n for
+// side L and m for side R; a workspace query, lwork = -1, only sets work[0]
+// to the optimal lwork.
+func ormbr[T gen.Scalar](bl blas.BLAS, vect Vect, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	nq := m
+	if side == blas.SideR {
+		nq = n
+	}
+	// With nq <= k the reflectors start one row or column off the diagonal
+	// and apply to C without its first row (side L) or column (side R).
+	mi, ni, ic := m-1, n, 1
+	if side == blas.SideR {
+		mi, ni, ic = m, n-1, ldc
+	}
+	if vect == VectQ {
+		switch {
+		case nq >= k:
+			ormqr(bl, side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+		case nq > 1:
+			ormqr(bl, side, trans, mi, ni, nq-1, a[1:], lda, tau, c[ic:], ldc, work, lwork)
+		}
+		return
+	}
+	// P = G(0)*...*G(k-1) is applied through the LQ routines, whose Q is
+	// P**H.
+	transt := blas.TransC
+	if trans != blas.TransN {
+		transt = blas.TransN
+	}
+	switch {
+	case nq > k:
+		ormlq(bl, side, transt, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	case nq > 1:
+		ormlq(bl, side, transt, mi, ni, nq-1, a[lda:], lda, tau, c[ic:], ldc, work, lwork)
+	}
+}
@@ -0,0 +1,268 @@
+package lapack
+
+import (
+	"fmt"
+
+	"github.com/visionom/lapack/blas"
+)
+
+// checker validates the arguments of a single call in the manner of the
+// checker of package blas: the checks are made in the order of the LAPACK
+// routines, followed by the slice lengths, and only the first violation is
+// reported to the error handler.
+type checker struct {
+	routine string
+	err     *blas.Error
+}
+
+// fail reports that parameter param, named name, violates reason.
+func (c *checker) fail(param int, name, reason string) {
+	if c.err != nil {
+		return
+	}
+	c.err = &blas.Error{Routine: c.routine, Param: param, Name: name, Reason: reason}
+	blas.Xerbla(c.err)
+}
+
+func (c *checker) ok() bool {
+	return c.err == nil
+}
+
+// result returns the reported error, or nil.
+func (c *checker) result() error {
+	if c.err == nil {
+		return nil
+	}
+	return c.err
+}
+
+func (c *checker) trans(param int, t blas.Transpose) {
+	if t != blas.TransN && t != blas.TransT && t != blas.TransC {
+		c.fail(param, "trans", "must be N, T or C")
+	}
+}
+
+// transQ checks the trans argument of the routines that apply an orthogonal
+// or unitary matrix: N or T for real types and N or C for complex ones.
+func (c *checker) transQ(param int, t blas.Transpose, complex bool) {
+	switch {
+	case t == blas.TransN:
+	case complex && t != blas.TransC:
+		c.fail(param, "trans", "must be N or C")
+	case !complex && t != blas.TransT:
+		c.fail(param, "trans", "must be N or T")
+	}
+}
+
+func (c *checker) uplo(param int, u blas.Uplo) {
+	if u != blas.UploU && u != blas.UploL {
+		c.fail(param, "uplo", "must be U or L")
+	}
+}
+
+func (c *checker) diag(param int, d blas.Diag) {
+	if d != blas.DiagU && d != blas.DiagN {
+		c.fail(param, "diag", "must be U or N")
+	}
+}
+
+func (c *checker) side(param int, s blas.Side) {
+	if s != blas.SideL && s != blas.SideR {
+		c.fail(param, "side", "must be L or R")
+	}
+}
+
+func (c *checker) direct(param int, d Direct) {
+	if d != DirectF && d != DirectB {
+		c.fail(param, "direct", "must be F or B")
+	}
+}
+
+func (c *checker) storev(param int, s StoreV) {
+	if s != StoreVC && s != StoreVR {
+		c.fail(param, "storev", "must be C or R")
+	}
+}
+
+func (c *checker) jobz(param int, j JobZ) {
+	if j != JobZN && j != JobZV {
+		c.fail(param, "jobz", "must be N or V")
+	}
+}
+
+func (c *checker) compz(param int, z CompZ) {
+	if z != CompZN && z != CompZI && z != CompZV {
+		c.fail(param, "compz", "must be N, I or V")
+	}
+}
+
+func (c *checker) rng(param int, r Range) {
+	if r != RangeA && r != RangeV && r != RangeI {
+		c.fail(param, "range", "must be A, V or I")
+	}
+}
+
+// jobv checks a JOBVL or JOBVR argument named name that selects
+// whether eigenvectors are computed.
+func (c *checker) jobv(param int, name string, j JobZ) {
+	if j != JobZN && j != JobZV {
+		c.fail(param, name, "must be N or V")
+	}
+}
+
+func (c *checker) balance(param int, j BalanceJob) {
+	if j != BalanceN && j != BalanceP && j != BalanceS && j != BalanceB {
+		c.fail(param, "job", "must be N, P, S or B")
+	}
+}
+
+func (c *checker) schur(param int, j SchurJob) {
+	if j != SchurE && j != SchurS {
+		c.fail(param, "job", "must be E or S")
+	}
+}
+
+func (c *checker) evSide(param int, s EVSide) {
+	if s != EVRight && s != EVLeft && s != EVBoth {
+		c.fail(param, "side", "must be R, L or B")
+	}
+}
+
+func (c *checker) howMany(param int, h HowMany) {
+	if h != HowManyA && h != HowManyB && h != HowManyS {
+		c.fail(param, "howmny", "must be A, B or S")
+	}
+}
+
+func (c *checker) sense(param int, s Sense) {
+	if s != SenseN && s != SenseE {
+		c.fail(param, "sense", "must be N or E")
+	}
+}
+
+func (c *checker) vect(param int, v Vect) {
+	if v != VectQ && v != VectP {
+		c.fail(param, "vect", "must be Q or P")
+	}
+}
+
+// svdJob checks a JOBU, JOBVT or JOBZ argument named name of the SVD
+// drivers.
+func (c *checker) svdJob(param int, name string, j SVDJob) {
+	if j != SVDA && j != SVDS && j != SVDO && j != SVDN {
+		c.fail(param, name, "must be A, S, O or N")
+	}
+}
+
+func (c *checker) svjMatrix(param int, j SVJMatrix) {
+	if j != SVJGeneral && j != SVJUpper && j != SVJLower {
+		c.fail(param, "joba", "must be G, U or L")
+	}
+}
+
+// svjLeft checks the JOBU argument of the Jacobi SVD routines, of which
+// only xGEJSV computes a full set of vectors, as full says.
+func (c *checker) svjLeft(param int, j SVJLeft, full bool) {
+	switch {
+	case j == SVJLeftU || j == SVJLeftN:
+	case full && j != SVJLeftF:
+		c.fail(param, "jobu", "must be U, F or N")
+	case !full:
+		c.fail(param, "jobu", "must be U or N")
+	}
+}
+
+// svjRight checks the JOBV argument of the Jacobi SVD routines, of which
+// only xGESVJ applies the rotations to a given matrix, as apply says.
+func (c *checker) svjRight(param int, j SVJRight, apply bool) {
+	switch {
+	case j == SVJRightV || j == SVJRightN:
+	case apply && j != SVJRightA:
+		c.fail(param, "jobv", "must be V, A or N")
+	case !apply:
+		c.fail(param, "jobv", "must be V or N")
+	}
+}
+
+func (c *checker) jsvAccuracy(param int, j JSVAccuracy) {
+	switch j {
+	case JSVC, JSVE, JSVF, JSVG, JSVA, JSVR:
+	default:
+		c.fail(param, "joba", "must be C, E, F, G, A or R")
+	}
+}
+
+// atLeast checks that v >= min, where expr is min as written in the message.
+func (c *checker) atLeast(param int, name string, v, min int, expr string) {
+	if v < min {
+		c.fail(param, name, "must be >= "+expr)
+	}
+}
+
+func (c *checker) nonNeg(param int, name string, v int) {
+	c.atLeast(param, name, v, 0, "0")
+}
+
+// ld checks a leading dimension against the number of rows, named rows in
+// the message.
+func (c *checker) ld(param int, name string, ld, m int, rows string) {
+	c.atLeast(param, name, ld, max(1, m), "max(1,"+rows+")")
+}
+
+// iloIhi checks the bounds ilo and ihi, at positions param and param+1,
+// of the active block of an n×n matrix: 0 <= ilo <= max(0,n-1) and
+// min(ilo,n-1) <= ihi <= n-1.
+func (c *checker) iloIhi(param, n, ilo, ihi int) {
+	if ilo < 0 || ilo > max(0, n-1) {
+		c.fail(param, "ilo", "must be in [0,max(0,n-1)]")
+	} else if ihi < min(ilo, n-1) || ihi > n-1 {
+		c.fail(param+1, "ihi", "must be in [min(ilo,n-1),n-1]")
+	}
+}
+
+// length checks that a slice of length have holds at least need elements.
+func (c *checker) length(param int, name string, have, need int) {
+	if have < need {
+		c.fail(param, name, fmt.Sprintf("has length %d, need at least %d", have, need))
+	}
+}
+
+// lwork checks the workspace length lwork against the minimum need, named
+// expr in the message. lwork = -1 is a workspace query.
+func (c *checker) lwork(param int, lwork, need int, expr string) {
+	if lwork != -1 && lwork < need {
+		c.fail(param, "lwork", "must be >= "+expr+" or -1")
+	}
+}
+
+// noLwork is passed as lwork to the checkers shared by a blocked routine and
+// its unblocked version, which has no lwork and needs a fixed length of work.
+const noLwork = -2
+
+// work checks that the slice work at position param holds the lwork
+// elements it is declared to hold, or the one element that receives the
+// optimal size of a workspace query.
+func (c *checker) work(param int, lenWork, lwork int) {
+	c.length(param, "work", lenWork, max(1, lwork))
+}
+
+// matLen returns the length needed by an m×n column-major matrix with
+// leading dimension ld.
+func matLen(m, n, ld int) int {
+	if m == 0 || n == 0 {
+		return 0
+	}
+	return ld*(n-1) + m
+}
+
+// vecLen returns the length needed by a vector of n elements with increment
+// inc.
+func vecLen(n, inc int) int {
+	if n <= 0 {
+		return 0
+	}
+	if inc < 0 {
+		inc = -inc
+	}
+	return 1 + (n-1)*inc
+}
@@ -0,0 +1,444 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SPOTF2 computes the Cholesky factorization A = U**H*U (uplo U) or
+// A = L*L**H (uplo L) of an n×n Hermitian positive definite matrix, one
+// column at a time. Only the uplo triangle of A is referenced, and it is
+// overwritten by the factor. A *NotPositiveDefiniteError is returned if A
+// is not positive definite.
+func (impl Implementation) SPOTF2(uplo blas.Uplo, n int, a []float32, lda int) error {
+	if err := checkPotrf("SPOTF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("SPOTF2", potf2(impl.bl(), uplo, n, a, lda))
+}
+
+// SPOTRF2 computes the Cholesky factorization of SPOTF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) SPOTRF2(uplo blas.Uplo, n int, a []float32, lda int) error {
+	if err := checkPotrf("SPOTRF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("SPOTRF2", potrf2(impl.bl(), uplo, n, a, lda))
+}
+
+// SPOTRF computes the Cholesky factorization of SPOTF2 with a blocked
+// algorithm whose diagonal blocks are factored by SPOTRF2.
+func (impl Implementation) SPOTRF(uplo blas.Uplo, n int, a []float32, lda int) error {
+	if err := checkPotrf("SPOTRF", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("SPOTRF", potrf(impl.bl(), uplo, n, a, lda))
+}
+
+// SPOTRS solves A*X = B for the n×nrhs matrix X using the Cholesky
+// factorization of A computed by SPOTRF. B is overwritten by X.
+func (impl Implementation) SPOTRS(uplo blas.Uplo, n, nrhs int, a []float32, lda int, b []float32, ldb int) error {
+	if err := checkPotrs("SPOTRS", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	potrs(impl.bl(), uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// SPOSV solves A*X = B for the n×nrhs matrix X, where A is Hermitian
+// positive definite. The uplo triangle of A is overwritten by its Cholesky
+// factor as computed by SPOTRF and B by X. A *NotPositiveDefiniteError is
+// returned, and B is left unchanged, if A is not positive definite.
+func (impl Implementation) SPOSV(uplo blas.Uplo, n, nrhs int, a []float32, lda int, b []float32, ldb int) error {
+	if err := checkPotrs("SPOSV", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	bl := impl.bl()
+	if info := potrf(bl, uplo, n, a, lda); info >= 0 {
+		return notPositiveDefinite("SPOSV", info)
+	}
+	potrs(bl, uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// SPOTRI computes the inverse of a Hermitian positive definite matrix from
+// its Cholesky factorization computed by SPOTRF. The factor is overwritten
+// by the uplo triangle of the inverse. A *SingularError is returned if the
+// factor has a zero diagonal element.
+func (impl Implementation) SPOTRI(uplo blas.Uplo, n int, a []float32, lda int) error {
+	if err := checkPotrf("SPOTRI", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("SPOTRI", potri(impl.bl(), uplo, n, a, lda))
+}
+
+// DPOTF2 computes the Cholesky factorization A = U**H*U (uplo U) or
+// A = L*L**H (uplo L) of an n×n Hermitian positive definite matrix, one
+// column at a time. Only the uplo triangle of A is referenced, and it is
+// overwritten by the factor. A *NotPositiveDefiniteError is returned if A
+// is not positive definite.
+func (impl Implementation) DPOTF2(uplo blas.Uplo, n int, a []float64, lda int) error {
+	if err := checkPotrf("DPOTF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("DPOTF2", potf2(impl.bl(), uplo, n, a, lda))
+}
+
+// DPOTRF2 computes the Cholesky factorization of DPOTF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) DPOTRF2(uplo blas.Uplo, n int, a []float64, lda int) error {
+	if err := checkPotrf("DPOTRF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("DPOTRF2", potrf2(impl.bl(), uplo, n, a, lda))
+}
+
+// DPOTRF computes the Cholesky factorization of DPOTF2 with a blocked
+// algorithm whose diagonal blocks are factored by DPOTRF2.
+func (impl Implementation) DPOTRF(uplo blas.Uplo, n int, a []float64, lda int) error {
+	if err := checkPotrf("DPOTRF", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("DPOTRF", potrf(impl.bl(), uplo, n, a, lda))
+}
+
+// DPOTRS solves A*X = B for the n×nrhs matrix X using the Cholesky
+// factorization of A computed by DPOTRF. B is overwritten by X.
+func (impl Implementation) DPOTRS(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) error {
+	if err := checkPotrs("DPOTRS", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	potrs(impl.bl(), uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// DPOSV solves A*X = B for the n×nrhs matrix X, where A is Hermitian
+// positive definite. The uplo triangle of A is overwritten by its Cholesky
+// factor as computed by DPOTRF and B by X. A *NotPositiveDefiniteError is
+// returned, and B is left unchanged, if A is not positive definite.
+func (impl Implementation) DPOSV(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) error {
+	if err := checkPotrs("DPOSV", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	bl := impl.bl()
+	if info := potrf(bl, uplo, n, a, lda); info >= 0 {
+		return notPositiveDefinite("DPOSV", info)
+	}
+	potrs(bl, uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// DPOTRI computes the inverse of a Hermitian positive definite matrix from
+// its Cholesky factorization computed by DPOTRF. The factor is overwritten
+// by the uplo triangle of the inverse. A *SingularError is returned if the
+// factor has a zero diagonal element.
+func (impl Implementation) DPOTRI(uplo blas.Uplo, n int, a []float64, lda int) error {
+	if err := checkPotrf("DPOTRI", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("DPOTRI", potri(impl.bl(), uplo, n, a, lda))
+}
+
+// CPOTF2 computes the Cholesky factorization A = U**H*U (uplo U) or
+// A = L*L**H (uplo L) of an n×n Hermitian positive definite matrix, one
+// column at a time. Only the uplo triangle of A is referenced, and it is
+// overwritten by the factor. A *NotPositiveDefiniteError is returned if A
+// is not positive definite.
+func (impl Implementation) CPOTF2(uplo blas.Uplo, n int, a []complex64, lda int) error {
+	if err := checkPotrf("CPOTF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("CPOTF2", potf2(impl.bl(), uplo, n, a, lda))
+}
+
+// CPOTRF2 computes the Cholesky factorization of CPOTF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) CPOTRF2(uplo blas.Uplo, n int, a []complex64, lda int) error {
+	if err := checkPotrf("CPOTRF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("CPOTRF2", potrf2(impl.bl(), uplo, n, a, lda))
+}
+
+// CPOTRF computes the Cholesky factorization of CPOTF2 with a blocked
+// algorithm whose diagonal blocks are factored by CPOTRF2.
+func (impl Implementation) CPOTRF(uplo blas.Uplo, n int, a []complex64, lda int) error {
+	if err := checkPotrf("CPOTRF", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("CPOTRF", potrf(impl.bl(), uplo, n, a, lda))
+}
+
+// CPOTRS solves A*X = B for the n×nrhs matrix X using the Cholesky
+// factorization of A computed by CPOTRF. B is overwritten by X.
+func (impl Implementation) CPOTRS(uplo blas.Uplo, n, nrhs int, a []complex64, lda int, b []complex64, ldb int) error {
+	if err := checkPotrs("CPOTRS", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	potrs(impl.bl(), uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// CPOSV solves A*X = B for the n×nrhs matrix X, where A is Hermitian
+// positive definite. The uplo triangle of A is overwritten by its Cholesky
+// factor as computed by CPOTRF and B by X. A *NotPositiveDefiniteError is
+// returned, and B is left unchanged, if A is not positive definite.
+func (impl Implementation) CPOSV(uplo blas.Uplo, n, nrhs int, a []complex64, lda int, b []complex64, ldb int) error {
+	if err := checkPotrs("CPOSV", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	bl := impl.bl()
+	if info := potrf(bl, uplo, n, a, lda); info >= 0 {
+		return notPositiveDefinite("CPOSV", info)
+	}
+	potrs(bl, uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// CPOTRI computes the inverse of a Hermitian positive definite matrix from
+// its Cholesky factorization computed by CPOTRF. The factor is overwritten
+// by the uplo triangle of the inverse. A *SingularError is returned if the
+// factor has a zero diagonal element.
+func (impl Implementation) CPOTRI(uplo blas.Uplo, n int, a []complex64, lda int) error {
+	if err := checkPotrf("CPOTRI", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("CPOTRI", potri(impl.bl(), uplo, n, a, lda))
+}
+
+// ZPOTF2 computes the Cholesky factorization A = U**H*U (uplo U) or
+// A = L*L**H (uplo L) of an n×n Hermitian positive definite matrix, one
+// column at a time. Only the uplo triangle of A is referenced, and it is
+// overwritten by the factor. A *NotPositiveDefiniteError is returned if A
+// is not positive definite.
+func (impl Implementation) ZPOTF2(uplo blas.Uplo, n int, a []complex128, lda int) error {
+	if err := checkPotrf("ZPOTF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("ZPOTF2", potf2(impl.bl(), uplo, n, a, lda))
+}
+
+// ZPOTRF2 computes the Cholesky factorization of ZPOTF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) ZPOTRF2(uplo blas.Uplo, n int, a []complex128, lda int) error {
+	if err := checkPotrf("ZPOTRF2", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("ZPOTRF2", potrf2(impl.bl(), uplo, n, a, lda))
+}
+
+// ZPOTRF computes the Cholesky factorization of ZPOTF2 with a blocked
+// algorithm whose diagonal blocks are factored by ZPOTRF2.
+func (impl Implementation) ZPOTRF(uplo blas.Uplo, n int, a []complex128, lda int) error {
+	if err := checkPotrf("ZPOTRF", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return notPositiveDefinite("ZPOTRF", potrf(impl.bl(), uplo, n, a, lda))
+}
+
+// ZPOTRS solves A*X = B for the n×nrhs matrix X using the Cholesky
+// factorization of A computed by ZPOTRF. B is overwritten by X.
+func (impl Implementation) ZPOTRS(uplo blas.Uplo, n, nrhs int, a []complex128, lda int, b []complex128, ldb int) error {
+	if err := checkPotrs("ZPOTRS", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	potrs(impl.bl(), uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// ZPOSV solves A*X = B for the n×nrhs matrix X, where A is Hermitian
+// positive definite. The uplo triangle of A is overwritten by its Cholesky
+// factor as computed by ZPOTRF and B by X. A *NotPositiveDefiniteError is
+// returned, and B is left unchanged, if A is not positive definite.
+func (impl Implementation) ZPOSV(uplo blas.Uplo, n, nrhs int, a []complex128, lda int, b []complex128, ldb int) error {
+	if err := checkPotrs("ZPOSV", uplo, n, nrhs, len(a), lda, len(b), ldb); err != nil {
+		return err
+	}
+	bl := impl.bl()
+	if info := potrf(bl, uplo, n, a, lda); info >= 0 {
+		return notPositiveDefinite("ZPOSV", info)
+	}
+	potrs(bl, uplo, n, nrhs, a, lda, b, ldb)
+	return nil
+}
+
+// ZPOTRI computes the inverse of a Hermitian positive definite matrix from
+// its Cholesky factorization computed by ZPOTRF. The factor is overwritten
+// by the uplo triangle of the inverse. A *SingularError is returned if the
+// factor has a zero diagonal element.
+func (impl Implementation) ZPOTRI(uplo blas.Uplo, n int, a []complex128, lda int) error {
+	if err := checkPotrf("ZPOTRI", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("ZPOTRI", potri(impl.bl(), uplo, n, a, lda))
+}
+
+// cholBlock is the block size of potrf, the value of ILAENV for xPOTRF.
+const cholBlock = 64
+
+// checkPotrf checks the POTF2, POTRF2, POTRF, POTRI and LAUUM routines.
+func checkPotrf(routine string, uplo blas.Uplo, n, lenA, lda int) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, n, "n")
+	if c.ok() {
+		c.length(3, "a", lenA, matLen(n, n, lda))
+	}
+	return c.result()
+}
+
+// checkPotrs checks the POTRS and POSV routines.
+func checkPotrs(routine string, uplo blas.Uplo, n, nrhs, lenA, lda, lenB, ldb int) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.nonNeg(3, "nrhs", nrhs)
+	c.ld(5, "lda", lda, n, "n")
+	c.ld(7, "ldb", ldb, n, "n")
+	if c.ok() {
+		c.length(4, "a", lenA, matLen(n, n, lda))
+		c.length(6, "b", lenB, matLen(n, nrhs, ldb))
+	}
+	return c.result()
+}
+
+// potf2 computes the Cholesky factorization A = U**H*U (uplo U) or
+// A = L*L**H (uplo L) of the n×n Hermitian positive definite matrix A one
+// column at a time. It returns the index of the first non-positive pivot,
+// or -1.
+func potf2[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int) (info int) {
+	for j := 0; j < n; j++ {
+		if uplo == blas.UploU {
+			// Compute U(j,j) and then the elements of row j of U.
+			ajj := re(a[j+j*lda]) - re(dotc(bl, j, a[j*lda:], 1, a[j*lda:], 1))
+			if ajj <= 0 || math.IsNaN(ajj) {
+				a[j+j*lda] = fromReal[T](ajj)
+				return j
+			}
+			ajj = math.Sqrt(ajj)
+			a[j+j*lda] = fromReal[T](ajj)
+			if j < n-1 {
+				lacgv(j, a[j*lda:], 1)
+				gemv(bl, blas.TransT, j, n-j-1, -1, a[(j+1)*lda:], lda, a[j*lda:], 1, 1, a[j+(j+1)*lda:], lda)
+				lacgv(j, a[j*lda:], 1)
+				rscal(bl, n-j-1, 1/ajj, a[j+(j+1)*lda:], lda)
+			}
+			continue
+		}
+		// Compute L(j,j) and then the elements of column j of L.
+		ajj := re(a[j+j*lda]) - re(dotc(bl, j, a[j:], lda, a[j:], lda))
+		if ajj <= 0 || math.IsNaN(ajj) {
+			a[j+j*lda] = fromReal[T](ajj)
+			return j
+		}
+		ajj = math.Sqrt(ajj)
+		a[j+j*lda] = fromReal[T](ajj)
+		if j < n-1 {
+			lacgv(j, a[j:], lda)
+			gemv(bl, blas.TransN, n-j-1, j, -1, a[j+1:], lda, a[j:], lda, 1, a[j+1+j*lda:], 1)
+			lacgv(j, a[j:], lda)
+			rscal(bl, n-j-1, 1/ajj, a[j+1+j*lda:], 1)
+		}
+	}
+	return -1
+}
+
+// potrf2 computes the Cholesky factorization of potf2 by recursively
+// splitting A in halves, as the LAPACK 3.6 xPOTRF2.
+func potrf2[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int) (info int) {
+	switch n {
+	case 0:
+		return -1
+	case 1:
+		ajj := re(a[0])
+		if ajj <= 0 || math.IsNaN(ajj) {
+			return 0
+		}
+		a[0] = fromReal[T](math.Sqrt(ajj))
+		return -1
+	}
+	n1 := n / 2
+	n2 := n - n1
+	if info = potrf2(bl, uplo, n1, a, lda); info >= 0 {
+		return info
+	}
+	a22 := a[n1+n1*lda:]
+	if uplo == blas.UploU {
+		a12 := a[n1*lda:]
+		trsm(bl, blas.SideL, blas.UploU, blas.TransC, blas.DiagN, n1, n2, 1, a, lda, a12, lda)
+		herk(bl, blas.UploU, blas.TransC, n2, n1, -1, a12, lda, 1, a22, lda)
+	} else {
+		a21 := a[n1:]
+		trsm(bl, blas.SideR, blas.UploL, blas.TransC, blas.DiagN, n2, n1, 1, a, lda, a21, lda)
+		herk(bl, blas.UploL, blas.TransN, n2, n1, -1, a21, lda, 1, a22, lda)
+	}
+	if info = potrf2(bl, uplo, n2, a22, lda); info >= 0 {
+		return info + n1
+	}
+	return -1
+}
+
+// potrf computes the Cholesky factorization of potf2 with the blocked
+// algorithm of xPOTRF, factoring the diagonal blocks with potrf2.
+func potrf[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int) (info int) {
+	if cholBlock <= 1 || cholBlock >= n {
+		return potrf2(bl, uplo, n, a, lda)
+	}
+	for j := 0; j < n; j += cholBlock {
+		jb := min(cholBlock, n-j)
+		ajj := a[j+j*lda:]
+		if uplo == blas.UploU {
+			// Update and factor the diagonal block, then compute the
+			// block row of U.
+			herk(bl, blas.UploU, blas.TransC, jb, j, -1, a[j*lda:], lda, 1, ajj, lda)
+			if info = potrf2(bl, uplo, jb, ajj, lda); info >= 0 {
+				return info + j
+			}
+			if j+jb < n {
+				gemm(bl, blas.TransC, blas.TransN, jb, n-j-jb, j, -1, a[j*lda:], lda, a[(j+jb)*lda:], lda, 1, a[j+(j+jb)*lda:], lda)
+				trsm(bl, blas.SideL, blas.UploU, blas.TransC, blas.DiagN, jb, n-j-jb, 1, ajj, lda, a[j+(j+jb)*lda:], lda)
+			}
+			continue
+		}
+		// Update and factor the diagonal block, then compute the block
+		// column of L.
+		herk(bl, blas.UploL, blas.TransN, jb, j, -1, a[j:], lda, 1, ajj, lda)
+		if info = potrf2(bl, uplo, jb, ajj, lda); info >= 0 {
+			return info + j
+		}
+		if j+jb < n {
+			gemm(bl, blas.TransN, blas.TransC, n-j-jb, jb, j, -1, a[j+jb:], lda, a[j:], lda, 1, a[j+jb+j*lda:], lda)
+			trsm(bl, blas.SideR, blas.UploL, blas.TransC, blas.DiagN, n-j-jb, jb, 1, ajj, lda, a[j+jb+j*lda:], lda)
+		}
+	}
+	return -1
+}
+
+// potrs solves A*X = B with the Cholesky factorization of A computed by
+// potrf, overwriting B by X.
+func potrs[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n, nrhs int, a []T, lda int, b []T, ldb int) {
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if uplo == blas.UploU {
+		trsm(bl, blas.SideL, blas.UploU, blas.TransC, blas.DiagN, n, nrhs, 1, a, lda, b, ldb)
+		trsm(bl, blas.SideL, blas.UploU, blas.TransN, blas.DiagN, n, nrhs, 1, a, lda, b, ldb)
+		return
+	}
+	trsm(bl, blas.SideL, blas.UploL, blas.TransN, blas.DiagN, n, nrhs, 1, a, lda, b, ldb)
+	trsm(bl, blas.SideL, blas.UploL, blas.TransC, blas.DiagN, n, nrhs, 1, a, lda, b, ldb)
+}
+
+// potri computes the inverse of A from the Cholesky factorization computed
+// by potrf, overwriting the factor by the uplo triangle of the inverse. It
+// returns the index of the first zero diagonal element of the factor, or -1.
+func potri[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int) (info int) {
+	if info = trtri(bl, uplo, blas.DiagN, n, a, lda); info >= 0 {
+		return info
+	}
+	lauum(bl, uplo, n, a, lda)
+	return -1
+}
@@ -0,0 +1,270 @@
+package lapack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// cholRoutines holds the Cholesky routines of one precision.
+type cholRoutines[T gen.Scalar] struct {
+	potf2, potrf2, potrf func(uplo blas.Uplo, n int, a []T, lda int) error
+	potrs, posv          func(uplo blas.Uplo, n, nrhs int, a []T, lda int, b []T, ldb int) error
+	potri                func(uplo blas.Uplo, n int, a []T, lda int) error
+}
+
+func TestCholesky(t *testing.T) {
+	var impl Implementation
+	testCholesky(t, "S", cholRoutines[float32]{impl.SPOTF2, impl.SPOTRF2, impl.SPOTRF, impl.SPOTRS, impl.SPOSV, impl.SPOTRI})
+	testCholesky(t, "D", cholRoutines[float64]{impl.DPOTF2, impl.DPOTRF2, impl.DPOTRF, impl.DPOTRS, impl.DPOSV, impl.DPOTRI})
+	testCholesky(t, "C", cholRoutines[complex64]{impl.CPOTF2, impl.CPOTRF2, impl.CPOTRF, impl.CPOTRS, impl.CPOSV, impl.CPOTRI})
+	testCholesky(t, "Z", cholRoutines[complex128]{impl.ZPOTF2, impl.ZPOTRF2, impl.ZPOTRF, impl.ZPOTRS, impl.ZPOSV, impl.ZPOTRI})
+}
+
+func testCholesky[T gen.Scalar](t *testing.T, prec string, f cholRoutines[T]) {
+	rnd := rand.New(rand.NewSource(1))
+	factors := []struct {
+		name string
+		f    func(uplo blas.Uplo, n int, a []T, lda int) error
+	}{{"POTF2", f.potf2}, {"POTRF2", f.potrf2}, {"POTRF", f.potrf}}
+
+	// The orders larger than cholBlock run the blocked code of xPOTRF. The
+	// triangle opposite to uplo holds NaN, which must be neither read nor
+	// written.
+	for _, uplo := range []blas.Uplo{blas.UploU, blas.UploL} {
+		for _, n := range []int{0, 1, 5, 70, 150} {
+			lda := n + 3
+			full := hpdMat[T](rnd, n)
+			for _, fac := range factors {
+				name := fmt.Sprintf("%s%s uplo=%c n=%d", prec, fac.name, uplo, n)
+				a := storeHerm(rnd, uplo, n, full, lda)
+				a0 := slices.Clone(a)
+				if err := fac.f(uplo, n, a, lda); err != nil {
+					t.Errorf("%s: unexpected error %v", name, err)
+					continue
+				}
+				if !samePad(n, n, lda, a, a0) || !sameOpposite(uplo, n, a, a0, lda) {
+					t.Errorf("%s: elements outside the uplo triangle modified", name)
+				}
+				for i := 0; i < n; i++ {
+					if d := a[i+i*lda]; im(d) != 0 || re(d) <= 0 {
+						t.Errorf("%s: diagonal element %d of the factor is %v, want real positive", name, i, d)
+						break
+					}
+				}
+				if r := ratio[T](diffF(n, n, cholProduct(uplo, n, a, lda), n, full, n), normF(n, n, full, n), n); r > maxRatio {
+					t.Errorf("%s: ‖A - factor product‖ ratio %.3g", name, r)
+				}
+			}
+
+			// xPOTRS and xPOSV solve with the factor of xPOTRF, and
+			// xPOTRI inverts A with it.
+			const nrhs = 3
+			ldb := n + 4
+			fa := storeHerm(rnd, uplo, n, full, lda)
+			if err := f.potrf(uplo, n, fa, lda); err != nil {
+				t.Fatalf("%sPOTRF uplo=%c n=%d: unexpected error %v", prec, uplo, n, err)
+			}
+			x := randMat[T](rnd, n, nrhs, n)
+			rhs := mulMat(blas.TransN, blas.TransN, n, nrhs, n, full, n, x, n)
+			b := make([]T, ldb*nrhs)
+			for j := 0; j < nrhs; j++ {
+				copy(b[j*ldb:j*ldb+n], rhs[j*n:])
+			}
+			bs := slices.Clone(b)
+			name := fmt.Sprintf("%sPOTRS uplo=%c n=%d", prec, uplo, n)
+			if err := f.potrs(uplo, n, nrhs, fa, lda, bs, ldb); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+			} else if r := solveRatio(blas.TransN, n, nrhs, full, n, bs, ldb, rhs, n); r > maxRatio {
+				t.Errorf("%s: ‖A*X - B‖ ratio %.3g", name, r)
+			}
+			name = fmt.Sprintf("%sPOSV uplo=%c n=%d", prec, uplo, n)
+			sv := storeHerm(rnd, uplo, n, full, lda)
+			if err := f.posv(uplo, n, nrhs, sv, lda, b, ldb); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+			} else {
+				if !sameTriangle(uplo, n, sv, fa, lda) {
+					t.Errorf("%s: factorization differs from %sPOTRF", name, prec)
+				}
+				if r := solveRatio(blas.TransN, n, nrhs, full, n, b, ldb, rhs, n); r > maxRatio {
+					t.Errorf("%s: ‖A*X - B‖ ratio %.3g", name, r)
+				}
+			}
+
+			name = fmt.Sprintf("%sPOTRI uplo=%c n=%d", prec, uplo, n)
+			inv := slices.Clone(fa)
+			if err := f.potri(uplo, n, inv, lda); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if !samePad(n, n, lda, inv, fa) || !sameOpposite(uplo, n, inv, fa, lda) {
+				t.Errorf("%s: elements outside the uplo triangle modified", name)
+			}
+			if r := invRatio(n, full, n, hermOf(uplo, n, inv, lda), n); r > maxRatio {
+				t.Errorf("%s: ‖A*inv(A) - I‖ ratio %.3g", name, r)
+			}
+		}
+	}
+
+	// A negative diagonal element k makes the leading minor of order k+1
+	// negative while the smaller ones stay positive.
+	for _, n := range []int{6, 150} {
+		k := n / 2
+		if n > cholBlock {
+			k = cholBlock + 5
+		}
+		full := hpdMat[T](rnd, n)
+		full[k+k*n] = -1
+		for _, uplo := range []blas.Uplo{blas.UploU, blas.UploL} {
+			for _, fac := range factors {
+				name := fmt.Sprintf("%s%s uplo=%c n=%d", prec, fac.name, uplo, n)
+				a := storeHerm(rnd, uplo, n, full, n)
+				var npd *NotPositiveDefiniteError
+				if err := fac.f(uplo, n, a, n); !errors.As(err, &npd) {
+					t.Errorf("%s: err = %v, want a *NotPositiveDefiniteError", name, err)
+				} else if npd.Routine != prec+fac.name || npd.Index != k {
+					t.Errorf("%s: err = %+v, want Routine %s%s and Index %d", name, *npd, prec, fac.name, k)
+				}
+			}
+			a := storeHerm(rnd, uplo, n, full, n)
+			b := randMat[T](rnd, n, 1, n)
+			b0 := slices.Clone(b)
+			err := f.posv(uplo, n, 1, a, n, b, n)
+			if npd := (*NotPositiveDefiniteError)(nil); !errors.As(err, &npd) || npd.Index != k {
+				t.Errorf("%sPOSV uplo=%c n=%d: err = %v, want a *NotPositiveDefiniteError with Index %d", prec, uplo, n, err, k)
+			}
+			if !slices.Equal(b, b0) {
+				t.Errorf("%sPOSV uplo=%c n=%d: B modified although A is not positive definite", prec, uplo, n)
+			}
+
+			// xPOTRI reports a zero diagonal element of the factor.
+			fa := storeHerm(rnd, uplo, n, hpdMat[T](rnd, n), n)
+			if err := f.potrf(uplo, n, fa, n); err != nil {
+				t.Fatalf("%sPOTRF uplo=%c n=%d: unexpected error %v", prec, uplo, n, err)
+			}
+			fa[k+k*n] = 0
+			err = f.potri(uplo, n, fa, n)
+			if se := (*SingularError)(nil); !errors.As(err, &se) || se.Routine != prec+"POTRI" || se.Index != k {
+				t.Errorf("%sPOTRI uplo=%c n=%d: err = %v, want a *SingularError with Index %d", prec, uplo, n, err, k)
+			}
+		}
+	}
+}
+
+// hpdMat returns an n×n Hermitian positive definite matrix with leading
+// dimension n and a condition number of a few units.
+func hpdMat[T gen.Scalar](rnd *rand.Rand, n int) []T {
+	b := randMat[T](rnd, n, n, n)
+	a := mulMat(blas.TransC, blas.TransN, n, n, n, b, n, b, n)
+	for i := 0; i < n; i++ {
+		a[i+i*n] = fromReal[T](re(a[i+i*n]) + float64(n))
+	}
+	return a
+}
+
+// storeHerm returns the uplo triangle of the n×n Hermitian matrix full
+// stored with leading dimension lda. The opposite triangle holds NaN and
+// the rows below n random numbers.
+func storeHerm[T gen.Scalar](rnd *rand.Rand, uplo blas.Uplo, n int, full []T, lda int) []T {
+	a := randMat[T](rnd, n, n, lda)
+	nan := fromParts[T](math.NaN(), math.NaN())
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if i == j || (i < j) == (uplo == blas.UploU) {
+				a[i+j*lda] = full[i+j*n]
+			} else {
+				a[i+j*lda] = nan
+			}
+		}
+	}
+	return a
+}
+
+// hermOf returns the n×n Hermitian matrix whose uplo triangle is stored in
+// a, with leading dimension n.
+func hermOf[T gen.Scalar](uplo blas.Uplo, n int, a []T, lda int) []T {
+	h := make([]T, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if i == j || (i < j) == (uplo == blas.UploU) {
+				h[i+j*n] = a[i+j*lda]
+			} else {
+				h[i+j*n] = conj(a[j+i*lda])
+			}
+		}
+	}
+	return h
+}
+
+// triOf returns the n×n triangular matrix stored in the uplo triangle of a,
+// with a unit diagonal for DiagU, with leading dimension n.
+func triOf[T gen.Scalar](uplo blas.Uplo, diag blas.Diag, n int, a []T, lda int) []T {
+	r := make([]T, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			switch {
+			case i == j && diag == blas.DiagU:
+				r[i+j*n] = 1
+			case i == j || (i < j) == (uplo == blas.UploU):
+				r[i+j*n] = a[i+j*lda]
+			}
+		}
+	}
+	return r
+}
+
+// cholProduct returns Uᴴ*U for uplo U or L*Lᴴ for uplo L, where the factor
+// is held in the uplo triangle of a, with leading dimension n.
+func cholProduct[T gen.Scalar](uplo blas.Uplo, n int, a []T, lda int) []T {
+	f := triOf(uplo, blas.DiagN, n, a, lda)
+	if uplo == blas.UploU {
+		return mulMat(blas.TransC, blas.TransN, n, n, n, f, n, f, n)
+	}
+	return mulMat(blas.TransN, blas.TransC, n, n, n, f, n, f, n)
+}
+
+// invRatio returns the test ratio of ‖A*X - I‖ for the n×n matrix A and
+// its computed inverse X.
+func invRatio[T gen.Scalar](n int, a []T, lda int, x []T, ldx int) float64 {
+	r := mulMat(blas.TransN, blas.TransN, n, n, n, a, lda, x, ldx)
+	for i := 0; i < n; i++ {
+		r[i+i*n]--
+	}
+	return ratio[T](normF(n, n, r, n), normF(n, n, a, lda)*normF(n, n, x, ldx), n)
+}
+
+// sameTriangle reports whether a and b agree in the uplo triangle of their
+// n×n matrices.
+func sameTriangle[T gen.Scalar](uplo blas.Uplo, n int, a, b []T, lda int) bool {
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if (i == j || (i < j) == (uplo == blas.UploU)) && a[i+j*lda] != b[i+j*lda] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sameOpposite reports whether a and b agree in the strict triangle of
+// their n×n matrices opposite to uplo, comparing NaN equal to NaN.
+func sameOpposite[T gen.Scalar](uplo blas.Uplo, n int, a, b []T, lda int) bool {
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if i == j || (i < j) == (uplo == blas.UploU) {
+				continue
+			}
+			x, y := a[i+j*lda], b[i+j*lda]
+			if x != y && (x == x || y == y) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package lapack
+
+import "fmt"
+
+// SingularError reports that a triangular matrix has an exactly zero
+// diagonal element, as a positive INFO does in LAPACK. For an LU
+// factorization U(Index,Index) is zero: the factorization has been
+// completed, but U is singular and cannot be used to solve a system.
+type SingularError struct {
+	Routine string // name of the routine, e.g. "DGETRF"
+	Index   int    // 0-based index of the first zero diagonal element
+}
+
+func (e *SingularError) Error() string {
+	return fmt.Sprintf("%s: diagonal element %d of the triangular factor is exactly zero", e.Routine, e.Index)
+}
+
+// singular returns a *SingularError for the 0-based index info of the
+// first zero diagonal element, or nil when info is negative.
+func singular(routine string, info int) error {
+	if info < 0 {
+		return nil
+	}
+	return &SingularError{Routine: routine, Index: info}
+}
+
+// NotPositiveDefiniteError reports that a Cholesky factorization broke down
+// because the matrix is not positive definite: the leading minor of order
+// Index+1 is not positive, or is NaN. The factorization has been completed
+// for the leading Index rows and columns only.
+type NotPositiveDefiniteError struct {
+	Routine string // name of the routine, e.g. "DPOTRF"
+	Index   int    // 0-based index of the first non-positive pivot
+}
+
+func (e *NotPositiveDefiniteError) Error() string {
+	return fmt.Sprintf("%s: the leading minor of order %d is not positive, the matrix is not positive definite", e.Routine, e.Index+1)
+}
+
+// notPositiveDefinite returns a *NotPositiveDefiniteError for the 0-based
+// index info of the first non-positive pivot, or nil when info is negative.
+func notPositiveDefinite(routine string, info int) error {
+	if info < 0 {
+		return nil
+	}
+	return &NotPositiveDefiniteError{Routine: routine, Index: info}
+}
+
+// ConvergenceError reports that an iterative algorithm failed to converge,
+// as a positive INFO does in LAPACK for the eigenvalue and singular value
+// routines. The results are not reliable. Info is the positive INFO of
+// LAPACK, whose meaning is given by the documentation of each routine.
+type ConvergenceError struct {
+	Routine string // name of the routine, e.g. "DGELSS"
+	Info    int    // routine-specific count or index, always positive
+}
+
+func (e *ConvergenceError) Error() string {
+	return fmt.Sprintf("%s: the algorithm failed to converge, INFO = %d", e.Routine, e.Info)
+}
+
+// convergence returns a *ConvergenceError for the positive info, or nil
+// when info is not positive.
+func convergence(routine string, info int) error {
+	if info <= 0 {
+		return nil
+	}
+	return &ConvergenceError{Routine: routine, Info: info}
+}
@@ -0,0 +1,338 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEBAL balances the n×n matrix A to improve the accuracy of its computed
+// eigenvalues. For job P or B, rows and columns are permuted to isolate
+// eigenvalues on the diagonal, so that A(i,j) = 0 for i > j and j < ilo or
+// i > ihi; for job S or B, rows and columns ilo through ihi are then scaled
+// by powers of 2 to make their norms as close as possible. job N does
+// nothing but return ilo = 0, ihi = n-1. For j < ilo and j > ihi, scale[j]
+// receives the index of the row and column interchanged with j, and for the
+// others the scaling factor of row and column j. The permutations are made
+// in the order n-1 down to ihi+1, then 0 up to ilo-1.
+func (impl Implementation) SGEBAL(job BalanceJob, n int, a []float32, lda int, scale []float32) (ilo, ihi int, err error) {
+	if err := checkGebal("SGEBAL", job, n, len(a), lda, len(scale)); err != nil {
+		return 0, 0, err
+	}
+	ilo, ihi, ok := gebal(impl.bl(), job, n, a, lda, scale)
+	if !ok {
+		return ilo, ihi, nanError("SGEBAL", 3)
+	}
+	return ilo, ihi, nil
+}
+
+// SGEBAK back-transforms the m eigenvectors in the columns of the n×m matrix
+// V, computed for the matrix balanced by SGEBAL with the same job, ilo and
+// ihi and the returned scale, into those of the original matrix. V holds
+// right eigenvectors for side R and left ones for side L.
+func (impl Implementation) SGEBAK(job BalanceJob, side blas.Side, n, ilo, ihi int, scale []float32, m int, v []float32, ldv int) error {
+	if err := checkGebak("SGEBAK", job, side, n, ilo, ihi, len(scale), m, len(v), ldv); err != nil {
+		return err
+	}
+	gebak(impl.bl(), job, side, n, ilo, ihi, scale, m, v, ldv)
+	return nil
+}
+
+// DGEBAL balances the n×n matrix A to improve the accuracy of its computed
+// eigenvalues. For job P or B, rows and columns are permuted to isolate
+// eigenvalues on the diagonal, so that A(i,j) = 0 for i > j and j < ilo or
+// i > ihi; for job S or B, rows and columns ilo through ihi are then scaled
+// by powers of 2 to make their norms as close as possible. job N does
+// nothing but return ilo = 0, ihi = n-1. For j < ilo and j > ihi, scale[j]
+// receives the index of the row and column interchanged with j, and for the
+// others the scaling factor of row and column j. The permutations are made
+// in the order n-1 down to ihi+1, then 0 up to ilo-1.
+func (impl Implementation) DGEBAL(job BalanceJob, n int, a []float64, lda int, scale []float64) (ilo, ihi int, err error) {
+	if err := checkGebal("DGEBAL", job, n, len(a), lda, len(scale)); err != nil {
+		return 0, 0, err
+	}
+	ilo, ihi, ok := gebal(impl.bl(), job, n, a, lda, scale)
+	if !ok {
+		return ilo, ihi, nanError("DGEBAL", 3)
+	}
+	return ilo, ihi, nil
+}
+
+// DGEBAK back-transforms the m eigenvectors in the columns of the n×m matrix
+// V, computed for the matrix balanced by DGEBAL with the same job, ilo and
+// ihi and the returned scale, into those of the original matrix. V holds
+// right eigenvectors for side R and left ones for side L.
+func (impl Implementation) DGEBAK(job BalanceJob, side blas.Side, n, ilo, ihi int, scale []float64, m int, v []float64, ldv int) error {
+	if err := checkGebak("DGEBAK", job, side, n, ilo, ihi, len(scale), m, len(v), ldv); err != nil {
+		return err
+	}
+	gebak(impl.bl(), job, side, n, ilo, ihi, scale, m, v, ldv)
+	return nil
+}
+
+// CGEBAL balances the n×n matrix A to improve the accuracy of its computed
+// eigenvalues. For job P or B, rows and columns are permuted to isolate
+// eigenvalues on the diagonal, so that A(i,j) = 0 for i > j and j < ilo or
+// i > ihi; for job S or B, rows and columns ilo through ihi are then scaled
+// by powers of 2 to make their norms as close as possible. job N does
+// nothing but return ilo = 0, ihi = n-1. For j < ilo and j > ihi, scale[j]
+// receives the index of the row and column interchanged with j, and for the
+// others the scaling factor of row and column j. The permutations are made
+// in the order n-1 down to ihi+1, then 0 up to ilo-1.
+func (impl Implementation) CGEBAL(job BalanceJob, n int, a []complex64, lda int, scale []float32) (ilo, ihi int, err error) {
+	if err := checkGebal("CGEBAL", job, n, len(a), lda, len(scale)); err != nil {
+		return 0, 0, err
+	}
+	ilo, ihi, ok := gebal(impl.bl(), job, n, a, lda, scale)
+	if !ok {
+		return ilo, ihi, nanError("CGEBAL", 3)
+	}
+	return ilo, ihi, nil
+}
+
+// CGEBAK back-transforms the m eigenvectors in the columns of the n×m matrix
+// V, computed for the matrix balanced by CGEBAL with the same job, ilo and
+// ihi and the returned scale, into those of the original matrix. V holds
+// right eigenvectors for side R and left ones for side L.
+func (impl Implementation) CGEBAK(job BalanceJob, side blas.Side, n, ilo, ihi int, scale []float32, m int, v []complex64, ldv int) error {
+	if err := checkGebak("CGEBAK", job, side, n, ilo, ihi, len(scale), m, len(v), ldv); err != nil {
+		return err
+	}
+	gebak(impl.bl(), job, side, n, ilo, ihi, scale, m, v, ldv)
+	return nil
+}
+
+// ZGEBAL balances the n×n matrix A to improve the accuracy of its computed
+// eigenvalues. For job P or B, rows and columns are permuted to isolate
+// eigenvalues on the diagonal, so that A(i,j) = 0 for i > j and j < ilo or
+// i > ihi; for job S or B, rows and columns ilo through ihi are then scaled
+// by powers of 2 to make their norms as close as possible. job N does
+// nothing but return ilo = 0, ihi = n-1. For j < ilo and j > ihi, scale[j]
+// receives the index of the row and column interchanged with j, and for the
+// others the scaling factor of row and column j. The permutations are made
+// in the order n-1 down to ihi+1, then 0 up to ilo-1.
+func (impl Implementation) ZGEBAL(job BalanceJob, n int, a []complex128, lda int, scale []float64) (ilo, ihi int, err error) {
+	if err := checkGebal("ZGEBAL", job, n, len(a), lda, len(scale)); err != nil {
+		return 0, 0, err
+	}
+	ilo, ihi, ok := gebal(impl.bl(), job, n, a, lda, scale)
+	if !ok {
+		return ilo, ihi, nanError("ZGEBAL", 3)
+	}
+	return ilo, ihi, nil
+}
+
+// ZGEBAK back-transforms the m eigenvectors in the columns of the n×m matrix
+// V, computed for the matrix balanced by ZGEBAL with the same job, ilo and
+// ihi and the returned scale, into those of the original matrix. V holds
+// right eigenvectors for side R and left ones for side L.
+func (impl Implementation) ZGEBAK(job BalanceJob, side blas.Side, n, ilo, ihi int, scale []float64, m int, v []complex128, ldv int) error {
+	if err := checkGebak("ZGEBAK", job, side, n, ilo, ihi, len(scale), m, len(v), ldv); err != nil {
+		return err
+	}
+	gebak(impl.bl(), job, side, n, ilo, ihi, scale, m, v, ldv)
+	return nil
+}
+
+// checkGebal checks the GEBAL routines.
+func checkGebal(routine string, job BalanceJob, n, lenA, lda, lenScale int) error {
+	c := checker{routine: routine}
+	c.balance(1, job)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, n, "n")
+	if c.ok() {
+		c.length(3, "a", lenA, matLen(n, n, lda))
+		c.length(7, "scale", lenScale, n)
+	}
+	return c.result()
+}
+
+// nanError reports a NaN met by gebal in A, parameter param, as an illegal
+// value of A, as xGEBAL does.
+func nanError(routine string, param int) error {
+	c := checker{routine: routine}
+	c.fail(param, "a", "must not contain NaN")
+	return c.result()
+}
+
+// checkGebak checks the GEBAK routines.
+func checkGebak(routine string, job BalanceJob, side blas.Side, n, ilo, ihi, lenScale, m, lenV, ldv int) error {
+	c := checker{routine: routine}
+	c.balance(1, job)
+	c.side(2, side)
+	c.nonNeg(3, "n", n)
+	c.iloIhi(4, n, ilo, ihi)
+	c.nonNeg(7, "m", m)
+	c.ld(9, "ldv", ldv, n, "n")
+	if c.ok() {
+		c.length(6, "scale", lenScale, n)
+		c.length(8, "v", lenV, matLen(n, m, ldv))
+	}
+	return c.result()
+}
+
+// gebal balances the n×n matrix A as xGEBAL and returns the bounds ilo and
+// ihi of the balanced block. ok is false if the scaling met a NaN, which
+// would not terminate; A and scale are then partly balanced.
+func gebal[T gen.Scalar, R gen.Float](bl blas.BLAS, job BalanceJob, n int, a []T, lda int, scale []R) (ilo, ihi int, ok bool) {
+	if n == 0 {
+		return 0, -1, true
+	}
+	if job == BalanceN {
+		for i := range scale[:n] {
+			scale[i] = 1
+		}
+		return 0, n - 1, true
+	}
+	k, l := 0, n-1
+	if job != BalanceS {
+		// Search for rows isolating an eigenvalue and push them down,
+		// restarting after each exchange.
+		for found := true; found; {
+			found = false
+			for i := l; i >= 0 && !found; i-- {
+				if !isolated(a[i:], lda, 0, l, i) {
+					continue
+				}
+				scale[l] = R(i)
+				if i != l {
+					swap(bl, l+1, a[i*lda:], 1, a[l*lda:], 1)
+					swap(bl, n-k, a[i+k*lda:], lda, a[l+k*lda:], lda)
+				}
+				if l == 0 {
+					return 0, 0, true
+				}
+				l--
+				found = true
+			}
+		}
+		// Search for columns isolating an eigenvalue and push them left.
+		for found := true; found; {
+			found = false
+			for j := k; j <= l && !found; j++ {
+				if !isolated(a[j*lda:], 1, k, l, j) {
+					continue
+				}
+				scale[k] = R(j)
+				if j != k {
+					swap(bl, l+1, a[j*lda:], 1, a[k*lda:], 1)
+					swap(bl, n-k, a[j+k*lda:], lda, a[k+k*lda:], lda)
+				}
+				k++
+				found = true
+			}
+		}
+	}
+	for i := k; i <= l; i++ {
+		scale[i] = 1
+	}
+	if job == BalanceP {
+		return k, l, true
+	}
+
+	// Scale rows and columns k through l by powers of 2 until their norms
+	// no longer decrease by a significant factor.
+	const (
+		sclfac = 2
+		factor = 0.95
+	)
+	sfmin1 := safmin[T]() / (2 * eps[T]())
+	sfmax1 := 1 / sfmin1
+	sfmin2 := sfmin1 * sclfac
+	sfmax2 := 1 / sfmin2
+	for noconv := true; noconv; {
+		noconv = false
+		for i := k; i <= l; i++ {
+			c := nrm2(bl, l-k+1, a[k+i*lda:], 1)
+			r := nrm2(bl, l-k+1, a[i+k*lda:], lda)
+			ca := abs(a[iamax(bl, l+1, a[i*lda:], 1)+i*lda])
+			ra := abs(a[i+(k+iamax(bl, n-k, a[i+k*lda:], lda))*lda])
+			if c == 0 || r == 0 {
+				// Guard against zero norms due to underflow.
+				continue
+			}
+			if math.IsNaN(c + ca + r + ra) {
+				return k, l, false
+			}
+			g := r / sclfac
+			f := 1.0
+			s := c + r
+			for c < g && max(f, c, ca) < sfmax2 && min(r, g, ra) > sfmin2 {
+				f *= sclfac
+				c *= sclfac
+				ca *= sclfac
+				r /= sclfac
+				g /= sclfac
+				ra /= sclfac
+			}
+			g = c / sclfac
+			for g >= r && max(r, ra) < sfmax2 && min(f, c, g, ca) > sfmin2 {
+				f /= sclfac
+				c /= sclfac
+				g /= sclfac
+				ca /= sclfac
+				r *= sclfac
+				ra *= sclfac
+			}
+			if c+r >= factor*s {
+				continue
+			}
+			si := float64(scale[i])
+			if f < 1 && si < 1 && f*si <= sfmin1 {
+				continue
+			}
+			if f > 1 && si > 1 && si >= sfmax1/f {
+				continue
+			}
+			scale[i] = R(si * f)
+			noconv = true
+			rscal(bl, n-k, 1/f, a[i+k*lda:], lda)
+			rscal(bl, l+1, f, a[i*lda:], 1)
+		}
+	}
+	return k, l, true
+}
+
+// isolated reports whether the elements lo through hi of the vector x with
+// increment inc are zero, except for element skip.
+func isolated[T gen.Scalar](x []T, inc, lo, hi, skip int) bool {
+	for j := lo; j <= hi; j++ {
+		if j != skip && x[j*inc] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// gebak back-transforms the eigenvectors in V for the balancing of gebal,
+// as xGEBAK.
+func gebak[T gen.Scalar, R gen.Float](bl blas.BLAS, job BalanceJob, side blas.Side, n, ilo, ihi int, scale []R, m int, v []T, ldv int) {
+	if n == 0 || m == 0 || job == BalanceN {
+		return
+	}
+	if ilo != ihi && (job == BalanceS || job == BalanceB) {
+		for i := ilo; i <= ihi; i++ {
+			s := float64(scale[i])
+			if side == blas.SideL {
+				s = 1 / s
+			}
+			rscal(bl, m, s, v[i:], ldv)
+		}
+	}
+	if job == BalanceP || job == BalanceB {
+		// Undo the permutations in the reverse order of gebal.
+		for ii := 0; ii < n; ii++ {
+			i := ii
+			if i >= ilo && i <= ihi {
+				continue
+			}
+			if i < ilo {
+				i = ilo - 1 - ii
+			}
+			if k := int(scale[i]); k != i {
+				swap(bl, m, v[i:], ldv, v[k:], ldv)
+			}
+		}
+	}
+}
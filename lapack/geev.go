@@ -0,0 +1,545 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEEV computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n real matrix A. The
+// right eigenvector v and left eigenvector u of the eigenvalue w satisfy
+// A*v = w*v and u**H*A = w*u**H. A is balanced by SGEBAL, reduced to
+// Hessenberg form by SGEHRD and to Schur form by SHSEQR, whose eigenvectors
+// are computed by STREVC and transformed back. The real and imaginary parts
+// of the eigenvalues are returned in wr and wi, complex conjugate pairs
+// consecutively with the positive imaginary part first. The eigenvectors are
+// stored in the columns of VL and VR in the order of the eigenvalues, those
+// of a complex pair j, j+1 as the real part in column j and the imaginary
+// part in column j+1 of the eigenvector of w[j], that of w[j+1] being its
+// conjugate. Each is normalized to unit Euclidean norm with its component
+// of largest modulus real. A is overwritten. A *ConvergenceError is returned
+// if the QR algorithm failed: no eigenvectors have been computed and only
+// wr[Info:] and wi[Info:] hold eigenvalues. work holds
+// lwork >= max(1,3*n) elements, or 4*n if eigenvectors are computed; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) SGEEV(jobvl, jobvr JobZ, n int, a []float32, lda int, wr, wi, vl []float32, ldvl int, vr []float32, ldvr int, work []float32, lwork int) error {
+	if err := checkGeev("SGEEV", jobvl, jobvr, n, len(a), lda, len(wr), len(wi), len(vl), ldvl, len(vr), ldvr, len(work), lwork, -1); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = float32(n + geevWork(n, jobvl == JobZV || jobvr == JobZV, false))
+		return nil
+	}
+	// The balancing factors precede the workspace.
+	_, _, _, info, ok := geev(impl.bl(), BalanceB, jobvl, jobvr, SenseN, n, a, lda, wr, wi, vl, ldvl, vr, ldvr, work[:n], nil, work[n:], lwork-n, nil)
+	if !ok {
+		return nanError("SGEEV", 4)
+	}
+	return convergence("SGEEV", info)
+}
+
+// SGEEVX computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n real matrix A as SGEEV
+// does, with A balanced by SGEBAL as selected by balanc, and for sense E
+// the reciprocal condition numbers of the eigenvalues, which require both
+// the left and right eigenvectors. SGEEVX returns the bounds ilo and ihi of
+// the balanced block and the one-norm abnrm of the balanced matrix, and
+// scale receives the permutations and scaling factors as in SGEBAL. For
+// sense E rconde[j] receives |u**H*v|/(||u||*||v||) for the left and right
+// eigenvectors u and v of the eigenvalue j of the balanced matrix. work
+// holds lwork >= max(1,2*n) elements, or 3*n if eigenvectors are
+// computed; the optimal lwork is returned in work[0] by a call with
+// lwork = -1 that does nothing else.
+func (impl Implementation) SGEEVX(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []float32, lda int, wr, wi, vl []float32, ldvl int, vr []float32, ldvr int, scale, rconde, work []float32, lwork int) (ilo, ihi int, abnrm float32, err error) {
+	if err := checkGeevx("SGEEVX", balanc, jobvl, jobvr, sense, n, len(a), lda, len(wr), len(wi), len(vl), ldvl, len(vr), ldvr, len(scale), len(rconde), len(work), lwork, -1); err != nil {
+		return 0, 0, 0, err
+	}
+	if lwork == -1 {
+		work[0] = float32(geevWork(n, jobvl == JobZV || jobvr == JobZV, false))
+		return 0, 0, 0, nil
+	}
+	ilo, ihi, nrm, info, ok := geev(impl.bl(), balanc, jobvl, jobvr, sense, n, a, lda, wr, wi, vl, ldvl, vr, ldvr, scale, rconde, work, lwork, nil)
+	if !ok {
+		return 0, 0, 0, nanError("SGEEVX", 6)
+	}
+	return ilo, ihi, float32(nrm), convergence("SGEEVX", info)
+}
+
+// DGEEV computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n real matrix A. The
+// right eigenvector v and left eigenvector u of the eigenvalue w satisfy
+// A*v = w*v and u**H*A = w*u**H. A is balanced by DGEBAL, reduced to
+// Hessenberg form by DGEHRD and to Schur form by DHSEQR, whose eigenvectors
+// are computed by DTREVC and transformed back. The real and imaginary parts
+// of the eigenvalues are returned in wr and wi, complex conjugate pairs
+// consecutively with the positive imaginary part first. The eigenvectors are
+// stored in the columns of VL and VR in the order of the eigenvalues, those
+// of a complex pair j, j+1 as the real part in column j and the imaginary
+// part in column j+1 of the eigenvector of w[j], that of w[j+1] being its
+// conjugate. Each is normalized to unit Euclidean norm with its component
+// of largest modulus real. A is overwritten. A *ConvergenceError is returned
+// if the QR algorithm failed: no eigenvectors have been computed and only
+// wr[Info:] and wi[Info:] hold eigenvalues. work holds
+// lwork >= max(1,3*n) elements, or 4*n if eigenvectors are computed; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) DGEEV(jobvl, jobvr JobZ, n int, a []float64, lda int, wr, wi, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) error {
+	if err := checkGeev("DGEEV", jobvl, jobvr, n, len(a), lda, len(wr), len(wi), len(vl), ldvl, len(vr), ldvr, len(work), lwork, -1); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = float64(n + geevWork(n, jobvl == JobZV || jobvr == JobZV, false))
+		return nil
+	}
+	// The balancing factors precede the workspace.
+	_, _, _, info, ok := geev(impl.bl(), BalanceB, jobvl, jobvr, SenseN, n, a, lda, wr, wi, vl, ldvl, vr, ldvr, work[:n], nil, work[n:], lwork-n, nil)
+	if !ok {
+		return nanError("DGEEV", 4)
+	}
+	return convergence("DGEEV", info)
+}
+
+// DGEEVX computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n real matrix A as DGEEV
+// does, with A balanced by DGEBAL as selected by balanc, and for sense E
+// the reciprocal condition numbers of the eigenvalues, which require both
+// the left and right eigenvectors. DGEEVX returns the bounds ilo and ihi of
+// the balanced block and the one-norm abnrm of the balanced matrix, and
+// scale receives the permutations and scaling factors as in DGEBAL. For
+// sense E rconde[j] receives |u**H*v|/(||u||*||v||) for the left and right
+// eigenvectors u and v of the eigenvalue j of the balanced matrix. work
+// holds lwork >= max(1,2*n) elements, or 3*n if eigenvectors are
+// computed; the optimal lwork is returned in work[0] by a call with
+// lwork = -1 that does nothing else.
+func (impl Implementation) DGEEVX(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []float64, lda int, wr, wi, vl []float64, ldvl int, vr []float64, ldvr int, scale, rconde, work []float64, lwork int) (ilo, ihi int, abnrm float64, err error) {
+	if err := checkGeevx("DGEEVX", balanc, jobvl, jobvr, sense, n, len(a), lda, len(wr), len(wi), len(vl), ldvl, len(vr), ldvr, len(scale), len(rconde), len(work), lwork, -1); err != nil {
+		return 0, 0, 0, err
+	}
+	if lwork == -1 {
+		work[0] = float64(geevWork(n, jobvl == JobZV || jobvr == JobZV, false))
+		return 0, 0, 0, nil
+	}
+	ilo, ihi, abnrm, info, ok := geev(impl.bl(), balanc, jobvl, jobvr, sense, n, a, lda, wr, wi, vl, ldvl, vr, ldvr, scale, rconde, work, lwork, nil)
+	if !ok {
+		return 0, 0, 0, nanError("DGEEVX", 6)
+	}
+	return ilo, ihi, abnrm, convergence("DGEEVX", info)
+}
+
+// CGEEV computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n complex matrix A. The
+// right eigenvector v and left eigenvector u of the eigenvalue w satisfy
+// A*v = w*v and u**H*A = w*u**H. A is balanced by CGEBAL, reduced to
+// Hessenberg form by CGEHRD and to Schur form by CHSEQR, whose eigenvectors
+// are computed by CTREVC and transformed back. The eigenvalues are returned
+// in w and the eigenvectors in the columns of VL and VR in the same order,
+// each normalized to unit Euclidean norm with its component of largest
+// modulus real. A is overwritten. A *ConvergenceError is returned if the QR
+// algorithm failed: no eigenvectors have been computed and only w[Info:]
+// holds eigenvalues. work holds lwork >= max(1,2*n) elements and rwork
+// 2*n; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CGEEV(jobvl, jobvr JobZ, n int, a []complex64, lda int, w, vl []complex64, ldvl int, vr []complex64, ldvr int, work []complex64, lwork int, rwork []float32) error {
+	if err := checkGeev("CGEEV", jobvl, jobvr, n, len(a), lda, len(w), -1, len(vl), ldvl, len(vr), ldvr, len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = complex(float32(geevWork(n, jobvl == JobZV || jobvr == JobZV, true)), 0)
+		return nil
+	}
+	// The balancing factors precede the real workspace of CTREVC.
+	_, _, _, info, ok := geev(impl.bl(), BalanceB, jobvl, jobvr, SenseN, n, a, lda, w, nil, vl, ldvl, vr, ldvr, rwork[:n], nil, work, lwork, rwork[n:])
+	if !ok {
+		return nanError("CGEEV", 4)
+	}
+	return convergence("CGEEV", info)
+}
+
+// CGEEVX computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n complex matrix A as
+// CGEEV does, with A balanced by CGEBAL as selected by balanc, and for
+// sense E the reciprocal condition numbers of the eigenvalues, which
+// require both the left and right eigenvectors. CGEEVX returns the bounds
+// ilo and ihi of the balanced block and the one-norm abnrm of the balanced
+// matrix, and scale receives the permutations and scaling factors as in
+// CGEBAL. For sense E rconde[j] receives |u**H*v|/(||u||*||v||) for the
+// left and right eigenvectors u and v of the eigenvalue j of the balanced
+// matrix. work holds lwork >= max(1,2*n) elements and rwork 2*n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) CGEEVX(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []complex64, lda int, w, vl []complex64, ldvl int, vr []complex64, ldvr int, scale, rconde []float32, work []complex64, lwork int, rwork []float32) (ilo, ihi int, abnrm float32, err error) {
+	if err := checkGeevx("CGEEVX", balanc, jobvl, jobvr, sense, n, len(a), lda, len(w), -1, len(vl), ldvl, len(vr), ldvr, len(scale), len(rconde), len(work), lwork, len(rwork)); err != nil {
+		return 0, 0, 0, err
+	}
+	if lwork == -1 {
+		work[0] = complex(float32(geevWork(n, jobvl == JobZV || jobvr == JobZV, true)), 0)
+		return 0, 0, 0, nil
+	}
+	ilo, ihi, nrm, info, ok := geev(impl.bl(), balanc, jobvl, jobvr, sense, n, a, lda, w, nil, vl, ldvl, vr, ldvr, scale, rconde, work, lwork, rwork)
+	if !ok {
+		return 0, 0, 0, nanError("CGEEVX", 6)
+	}
+	return ilo, ihi, float32(nrm), convergence("CGEEVX", info)
+}
+
+// ZGEEV computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n complex matrix A. The
+// right eigenvector v and left eigenvector u of the eigenvalue w satisfy
+// A*v = w*v and u**H*A = w*u**H. A is balanced by ZGEBAL, reduced to
+// Hessenberg form by ZGEHRD and to Schur form by ZHSEQR, whose eigenvectors
+// are computed by ZTREVC and transformed back. The eigenvalues are returned
+// in w and the eigenvectors in the columns of VL and VR in the same order,
+// each normalized to unit Euclidean norm with its component of largest
+// modulus real. A is overwritten. A *ConvergenceError is returned if the QR
+// algorithm failed: no eigenvectors have been computed and only w[Info:]
+// holds eigenvalues. work holds lwork >= max(1,2*n) elements and rwork
+// 2*n; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZGEEV(jobvl, jobvr JobZ, n int, a []complex128, lda int, w, vl []complex128, ldvl int, vr []complex128, ldvr int, work []complex128, lwork int, rwork []float64) error {
+	if err := checkGeev("ZGEEV", jobvl, jobvr, n, len(a), lda, len(w), -1, len(vl), ldvl, len(vr), ldvr, len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(geevWork(n, jobvl == JobZV || jobvr == JobZV, true)), 0)
+		return nil
+	}
+	// The balancing factors precede the real workspace of ZTREVC.
+	_, _, _, info, ok := geev(impl.bl(), BalanceB, jobvl, jobvr, SenseN, n, a, lda, w, nil, vl, ldvl, vr, ldvr, rwork[:n], nil, work, lwork, rwork[n:])
+	if !ok {
+		return nanError("ZGEEV", 4)
+	}
+	return convergence("ZGEEV", info)
+}
+
+// ZGEEVX computes the eigenvalues and, for jobvr V, the right eigenvectors
+// and, for jobvl V, the left eigenvectors of the n×n complex matrix A as
+// ZGEEV does, with A balanced by ZGEBAL as selected by balanc, and for
+// sense E the reciprocal condition numbers of the eigenvalues, which
+// require both the left and right eigenvectors. ZGEEVX returns the bounds
+// ilo and ihi of the balanced block and the one-norm abnrm of the balanced
+// matrix, and scale receives the permutations and scaling factors as in
+// ZGEBAL. For sense E rconde[j] receives |u**H*v|/(||u||*||v||) for the
+// left and right eigenvectors u and v of the eigenvalue j of the balanced
+// matrix. work holds lwork >= max(1,2*n) elements and rwork 2*n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) ZGEEVX(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []complex128, lda int, w, vl []complex128, ldvl int, vr []complex128, ldvr int, scale, rconde []float64, work []complex128, lwork int, rwork []float64) (ilo, ihi int, abnrm float64, err error) {
+	if err := checkGeevx("ZGEEVX", balanc, jobvl, jobvr, sense, n, len(a), lda, len(w), -1, len(vl), ldvl, len(vr), ldvr, len(scale), len(rconde), len(work), lwork, len(rwork)); err != nil {
+		return 0, 0, 0, err
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(geevWork(n, jobvl == JobZV || jobvr == JobZV, true)), 0)
+		return 0, 0, 0, nil
+	}
+	ilo, ihi, abnrm, info, ok := geev(impl.bl(), balanc, jobvl, jobvr, sense, n, a, lda, w, nil, vl, ldvl, vr, ldvr, scale, rconde, work, lwork, rwork)
+	if !ok {
+		return 0, 0, 0, nanError("ZGEEVX", 6)
+	}
+	return ilo, ihi, abnrm, convergence("ZGEEVX", info)
+}
+
+// checkGeev checks the GEEV routines. The real routines pass
+// lenRwork = -1 and the complex ones, which have no wi, lenWi = -1.
+func checkGeev(routine string, jobvl, jobvr JobZ, n, lenA, lda, lenW, lenWi, lenVL, ldvl, lenVR, ldvr, lenWork, lwork, lenRwork int) error {
+	c := checker{routine: routine}
+	c.jobv(1, "jobvl", jobvl)
+	c.jobv(2, "jobvr", jobvr)
+	c.nonNeg(3, "n", n)
+	c.ld(5, "lda", lda, n, "n")
+	// The parameters after w are shifted by wi in the real routines.
+	off := 0
+	if lenWi >= 0 {
+		off = 1
+	}
+	wantvl, wantvr := jobvl == JobZV, jobvr == JobZV
+	if wantvl {
+		c.ld(8+off, "ldvl", ldvl, n, "n")
+	} else {
+		c.atLeast(8+off, "ldvl", ldvl, 1, "1")
+	}
+	if wantvr {
+		c.ld(10+off, "ldvr", ldvr, n, "n")
+	} else {
+		c.atLeast(10+off, "ldvr", ldvr, 1, "1")
+	}
+	switch {
+	case lenRwork >= 0:
+		c.lwork(12, lwork, max(1, 2*n), "max(1,2*n)")
+	case wantvl || wantvr:
+		c.lwork(13, lwork, max(1, 4*n), "4*n")
+	default:
+		c.lwork(13, lwork, max(1, 3*n), "max(1,3*n)")
+	}
+	if c.ok() {
+		c.work(11+off, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(n, n, lda))
+			if off == 1 {
+				c.length(6, "wr", lenW, n)
+				c.length(7, "wi", lenWi, n)
+			} else {
+				c.length(6, "w", lenW, n)
+			}
+			if wantvl {
+				c.length(7+off, "vl", lenVL, matLen(n, n, ldvl))
+			}
+			if wantvr {
+				c.length(9+off, "vr", lenVR, matLen(n, n, ldvr))
+			}
+			if off == 0 {
+				c.length(13, "rwork", lenRwork, 2*n)
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkGeevx checks the GEEVX routines. The real routines pass
+// lenRwork = -1 and the complex ones, which have no wi, lenWi = -1. The
+// parameters are numbered as in LAPACK, where ilo, ihi, abnrm, rcondv and
+// the integer workspace are arguments too.
+func checkGeevx(routine string, balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n, lenA, lda, lenW, lenWi, lenVL, ldvl, lenVR, ldvr, lenScale, lenRconde, lenWork, lwork, lenRwork int) error {
+	c := checker{routine: routine}
+	wantvl, wantvr := jobvl == JobZV, jobvr == JobZV
+	c.balance(1, balanc)
+	c.jobv(2, "jobvl", jobvl)
+	c.jobv(3, "jobvr", jobvr)
+	c.sense(4, sense)
+	if sense == SenseE && !(wantvl && wantvr) {
+		c.fail(4, "sense", "E requires jobvl = jobvr = V")
+	}
+	c.nonNeg(5, "n", n)
+	c.ld(7, "lda", lda, n, "n")
+	off := 0
+	if lenWi >= 0 {
+		off = 1
+	}
+	if wantvl {
+		c.ld(10+off, "ldvl", ldvl, n, "n")
+	} else {
+		c.atLeast(10+off, "ldvl", ldvl, 1, "1")
+	}
+	if wantvr {
+		c.ld(12+off, "ldvr", ldvr, n, "n")
+	} else {
+		c.atLeast(12+off, "ldvr", ldvr, 1, "1")
+	}
+	switch {
+	case off == 0:
+		c.lwork(20, lwork, max(1, 2*n), "max(1,2*n)")
+	case wantvl || wantvr:
+		c.lwork(21, lwork, max(1, 3*n), "3*n")
+	default:
+		c.lwork(21, lwork, max(1, 2*n), "max(1,2*n)")
+	}
+	if c.ok() {
+		c.work(19+off, lenWork, lwork)
+		if lwork != -1 {
+			c.length(6, "a", lenA, matLen(n, n, lda))
+			if off == 1 {
+				c.length(8, "wr", lenW, n)
+				c.length(9, "wi", lenWi, n)
+			} else {
+				c.length(8, "w", lenW, n)
+			}
+			if wantvl {
+				c.length(9+off, "vl", lenVL, matLen(n, n, ldvl))
+			}
+			if wantvr {
+				c.length(11+off, "vr", lenVR, matLen(n, n, ldvr))
+			}
+			c.length(15+off, "scale", lenScale, n)
+			if sense == SenseE {
+				c.length(17+off, "rconde", lenRconde, n)
+			}
+			if off == 0 {
+				c.length(21, "rwork", lenRwork, 2*n)
+			}
+		}
+	}
+	return c.result()
+}
+
+// geevWork returns the optimal workspace length of geev, which holds the
+// reflectors of the reduction followed by the workspace of gehrd and orghr,
+// and then the workspace of hseqr and trevc.
+func geevWork(n int, wantv, complex bool) int {
+	if n == 0 {
+		return 1
+	}
+	lw := max(n+gehrdWork(n, 0, n-1), hseqrWork(n, 0, n-1))
+	if wantv {
+		lw = max(lw, n+orgqrWork(n-1))
+		if !complex {
+			lw = max(lw, 3*n)
+		}
+	}
+	return lw
+}
+
+// geev computes the eigenvalues and the eigenvectors selected by jobvl and
+// jobvr of the n×n matrix A as xGEEVX: A is scaled if its elements are very
+// small or large, balanced by gebal as selected by balanc, reduced to
+// Hessenberg form by gehrd and to Schur form by hseqr, with the Schur
+// vectors accumulated by orghr and hseqr into VL or VR, whose products with
+// the eigenvectors of the Schur form are computed by trevc and transformed
+// back by gebak. For sense E the reciprocal condition numbers of the
+// eigenvalues are stored in rconde. The eigenvalues are stored as by hseqr.
+// scale holds n elements for gebal. work holds lwork >= 2*n elements, or
+// 3*n for real types computing eigenvectors, and for complex types rwork n
+// for trevc. geev returns the bounds ilo and ihi of gebal, the one-norm
+// abnrm of the balanced matrix and the info of hseqr; ok is false if A
+// contains NaN, and nothing has then been done.
+func geev[T gen.Scalar, R gen.Float](bl blas.BLAS, balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []T, lda int, w []T, wi []R, vl []T, ldvl int, vr []T, ldvr int, scale, rconde []R, work []T, lwork int, rwork []R) (ilo, ihi int, abnrm float64, info int, ok bool) {
+	wantvl, wantvr := jobvl == JobZV, jobvr == JobZV
+	if n == 0 {
+		return 0, -1, 0, 0, true
+	}
+	anrm := lange(normMax, n, n, a, lda)
+	if math.IsNaN(anrm) {
+		return 0, 0, 0, 0, false
+	}
+	smlnum := math.Sqrt(safmin[T]()) / (2 * eps[T]())
+	bignum := 1 / smlnum
+	cscale := 0.0
+	switch {
+	case anrm > 0 && anrm < smlnum:
+		cscale = smlnum
+	case anrm > bignum:
+		cscale = bignum
+	}
+	if cscale != 0 {
+		lascl(uploAll, anrm, cscale, n, n, a, lda)
+	}
+
+	ilo, ihi, _ = gebal(bl, balanc, n, a, lda, scale)
+	abnrm = lange(normOne, n, n, a, lda)
+	if cscale != 0 {
+		abnrm = abnrm / cscale * anrm
+	}
+	tau, wk := work[:n], work[n:lwork]
+	gehrd(bl, n, ilo, ihi, a, lda, tau, wk, len(wk))
+
+	// The Schur vectors are accumulated into VL if it is wanted, and copied
+	// to VR if that is wanted too.
+	side := EVRight
+	switch {
+	case wantvl:
+		side = EVLeft
+		lacpy(blas.UploL, n, n, a, lda, vl, ldvl)
+		orghr(bl, n, ilo, ihi, vl, ldvl, tau, wk, len(wk))
+		info = hseqr(bl, SchurS, CompZV, n, ilo, ihi, a, lda, w, wi, vl, ldvl, work, lwork)
+		if wantvr {
+			side = EVBoth
+			lacpy(uploAll, n, n, vl, ldvl, vr, ldvr)
+		}
+	case wantvr:
+		lacpy(blas.UploL, n, n, a, lda, vr, ldvr)
+		orghr(bl, n, ilo, ihi, vr, ldvr, tau, wk, len(wk))
+		info = hseqr(bl, SchurS, CompZV, n, ilo, ihi, a, lda, w, wi, vr, ldvr, work, lwork)
+	default:
+		info = hseqr(bl, SchurE, CompZN, n, ilo, ihi, a, lda, w, wi, nil, 1, work, lwork)
+	}
+
+	if info == 0 {
+		if wantvl || wantvr {
+			trevc(bl, side, HowManyB, nil, n, a, lda, vl, ldvl, vr, ldvr, n, work, rwork)
+		}
+		if sense == SenseE {
+			eigCond(bl, n, wi, vl, ldvl, vr, ldvr, rconde)
+		}
+		if wantvl {
+			gebak(bl, balanc, blas.SideL, n, ilo, ihi, scale, n, vl, ldvl)
+			geevNormalize(bl, n, vl, ldvl, wi)
+		}
+		if wantvr {
+			gebak(bl, balanc, blas.SideR, n, ilo, ihi, scale, n, vr, ldvr)
+			geevNormalize(bl, n, vr, ldvr, wi)
+		}
+	}
+
+	// Undo the scaling of the eigenvalues that have been computed.
+	if cscale != 0 {
+		lascl(uploAll, cscale, anrm, n-info, 1, w[info:], max(1, n-info))
+		if wi != nil {
+			lascl(uploAll, cscale, anrm, n-info, 1, wi[info:], max(1, n-info))
+		}
+		if info > 0 {
+			lascl(uploAll, cscale, anrm, ilo, 1, w, n)
+			if wi != nil {
+				lascl(uploAll, cscale, anrm, ilo, 1, wi, n)
+			}
+		}
+	}
+	return ilo, ihi, abnrm, info, true
+}
+
+// eigCond computes the reciprocal condition numbers s[j] = |u**H*v| /
+// (||u||*||v||) of the eigenvalues of an n×n matrix from their left and
+// right eigenvectors u and v in the columns of VL and VR, as xTRSNA with
+// job E. For real types the eigenvectors of a complex pair, with wi[j] > 0,
+// are stored as by trevc, and both eigenvalues have the same condition.
+func eigCond[T gen.Scalar, R gen.Float](bl blas.BLAS, n int, wi []R, vl []T, ldvl int, vr []T, ldvr int, s []R) {
+	for j := 0; j < n; j++ {
+		u, v := vl[j*ldvl:], vr[j*ldvr:]
+		if wi == nil || wi[j] == 0 {
+			prod := dotc(bl, n, v, 1, u, 1)
+			s[j] = R(abs(prod) / (nrm2(bl, n, v, 1) * nrm2(bl, n, u, 1)))
+			continue
+		}
+		// The eigenvector of the pair is v + i*v2 and its left one u + i*u2.
+		u2, v2 := vl[(j+1)*ldvl:], vr[(j+1)*ldvr:]
+		prodr := re(dotu(bl, n, v, 1, u, 1)) + re(dotu(bl, n, v2, 1, u2, 1))
+		prodi := re(dotu(bl, n, u, 1, v2, 1)) - re(dotu(bl, n, u2, 1, v, 1))
+		rnrm := lapy2(nrm2(bl, n, v, 1), nrm2(bl, n, v2, 1))
+		lnrm := lapy2(nrm2(bl, n, u, 1), nrm2(bl, n, u2, 1))
+		s[j] = R(lapy2(prodr, prodi) / (rnrm * lnrm))
+		s[j+1] = s[j]
+		j++
+	}
+}
+
+// geevNormalize scales the n eigenvectors in the columns of V, stored as by
+// trevc, to unit Euclidean norm and rotates each so that its component of
+// largest modulus is real.
+func geevNormalize[T gen.Scalar, R gen.Float](bl blas.BLAS, n int, v []T, ldv int, wi []R) {
+	// largest returns the index of the component of largest modulus of
+	// the vector x + i*y, with y nil for a complex x.
+	largest := func(x, y []T) (int, float64) {
+		k, vmax := 0, 0.0
+		for i := 0; i < n; i++ {
+			m := re(x[i])*re(x[i]) + im(x[i])*im(x[i])
+			if y != nil {
+				m += re(y[i]) * re(y[i])
+			}
+			if m > vmax {
+				k, vmax = i, m
+			}
+		}
+		return k, vmax
+	}
+	for j := 0; j < n; j++ {
+		x := v[j*ldv:]
+		if wi == nil || wi[j] == 0 {
+			rscal(bl, n, 1/nrm2(bl, n, x, 1), x, 1)
+			if isComplex[T]() {
+				k, vmax := largest(x, nil)
+				scal(bl, n, conj(x[k])/fromReal[T](math.Sqrt(vmax)), x, 1)
+				x[k] = fromReal[T](re(x[k]))
+			}
+			continue
+		}
+		y := v[(j+1)*ldv:]
+		scl := 1 / lapy2(nrm2(bl, n, x, 1), nrm2(bl, n, y, 1))
+		rscal(bl, n, scl, x, 1)
+		rscal(bl, n, scl, y, 1)
+		k, _ := largest(x, y)
+		cs, sn, _ := lartg(re(x[k]), re(y[k]))
+		rrot(bl, n, x, 1, y, 1, cs, sn)
+		y[k] = 0
+		j++
+	}
+}
@@ -0,0 +1,398 @@
+package lapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// geevRoutines holds xGEEV and xGEEVX of one precision, with the complex
+// rwork allocated by the test. The eigenvalues are returned as complex128,
+// from wr and wi for the real routines, and the balancing factors and the
+// condition numbers as float64.
+type geevRoutines[T gen.Scalar] struct {
+	geev  func(jobvl, jobvr JobZ, n int, a []T, lda int, w []complex128, vl []T, ldvl int, vr []T, ldvr int, work []T, lwork int) error
+	geevx func(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []T, lda int, w []complex128, vl []T, ldvl int, vr []T, ldvr int, scale, rconde []float64, work []T, lwork int) (ilo, ihi int, abnrm float64, err error)
+}
+
+func TestGEEV(t *testing.T) {
+	var impl Implementation
+	testGEEV(t, "S", realGeev(impl.SGEEV, impl.SGEEVX))
+	testGEEV(t, "D", realGeev(impl.DGEEV, impl.DGEEVX))
+	testGEEV(t, "C", complexGeev(impl.CGEEV, impl.CGEEVX))
+	testGEEV(t, "Z", complexGeev(impl.ZGEEV, impl.ZGEEVX))
+}
+
+// realGeev returns the geevRoutines of the real xGEEV and xGEEVX.
+func realGeev[T gen.Float](geev func(jobvl, jobvr JobZ, n int, a []T, lda int, wr, wi, vl []T, ldvl int, vr []T, ldvr int, work []T, lwork int) error,
+	geevx func(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []T, lda int, wr, wi, vl []T, ldvl int, vr []T, ldvr int, scale, rconde, work []T, lwork int) (int, int, T, error)) geevRoutines[T] {
+	join := func(w []complex128, wr, wi []T) {
+		for j := range w {
+			w[j] = complex(float64(wr[j]), float64(wi[j]))
+		}
+	}
+	return geevRoutines[T]{
+		func(jobvl, jobvr JobZ, n int, a []T, lda int, w []complex128, vl []T, ldvl int, vr []T, ldvr int, work []T, lwork int) error {
+			wr, wi := make([]T, n), make([]T, n)
+			err := geev(jobvl, jobvr, n, a, lda, wr, wi, vl, ldvl, vr, ldvr, work, lwork)
+			join(w, wr, wi)
+			return err
+		},
+		func(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []T, lda int, w []complex128, vl []T, ldvl int, vr []T, ldvr int, scale, rconde []float64, work []T, lwork int) (int, int, float64, error) {
+			wr, wi := make([]T, n), make([]T, n)
+			sc, rc := make([]T, n), make([]T, len(rconde))
+			ilo, ihi, abnrm, err := geevx(balanc, jobvl, jobvr, sense, n, a, lda, wr, wi, vl, ldvl, vr, ldvr, sc, rc, work, lwork)
+			join(w, wr, wi)
+			copy(scale, toFloat64(sc))
+			copy(rconde, toFloat64(rc))
+			return ilo, ihi, float64(abnrm), err
+		},
+	}
+}
+
+// complexGeev returns the geevRoutines of the complex xGEEV and xGEEVX.
+func complexGeev[T complex64 | complex128, R gen.Float](geev func(jobvl, jobvr JobZ, n int, a []T, lda int, w, vl []T, ldvl int, vr []T, ldvr int, work []T, lwork int, rwork []R) error,
+	geevx func(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []T, lda int, w, vl []T, ldvl int, vr []T, ldvr int, scale, rconde []R, work []T, lwork int, rwork []R) (int, int, R, error)) geevRoutines[T] {
+	join := func(w []complex128, wc []T) {
+		for j := range w {
+			w[j] = complex128(wc[j])
+		}
+	}
+	return geevRoutines[T]{
+		func(jobvl, jobvr JobZ, n int, a []T, lda int, w []complex128, vl []T, ldvl int, vr []T, ldvr int, work []T, lwork int) error {
+			wc := make([]T, n)
+			err := geev(jobvl, jobvr, n, a, lda, wc, vl, ldvl, vr, ldvr, work, lwork, make([]R, 2*n))
+			join(w, wc)
+			return err
+		},
+		func(balanc BalanceJob, jobvl, jobvr JobZ, sense Sense, n int, a []T, lda int, w []complex128, vl []T, ldvl int, vr []T, ldvr int, scale, rconde []float64, work []T, lwork int) (int, int, float64, error) {
+			wc := make([]T, n)
+			sc, rc := make([]R, n), make([]R, len(rconde))
+			ilo, ihi, abnrm, err := geevx(balanc, jobvl, jobvr, sense, n, a, lda, wc, vl, ldvl, vr, ldvr, sc, rc, work, lwork, make([]R, 2*n))
+			join(w, wc)
+			copy(scale, toFloat64(sc))
+			copy(rconde, toFloat64(rc))
+			return ilo, ihi, float64(abnrm), err
+		},
+	}
+}
+
+// eigBlock is a diagonal block of a matrix of known eigenvalues and
+// condition numbers: the 2×2 upper triangular block [l1 b; 0 l2] for
+// l1 != l2, whose eigenvalues both have the reciprocal condition number
+// 1/sqrt(1+(b/(l1-l2))²), or, for pair, the real block [l1 b; -c l1] for
+// b, c > 0, whose eigenvalues l1 ± i*sqrt(b*c) both have 2*sqrt(b*c)/(b+c).
+type eigBlock struct {
+	pair         bool
+	l1, l2, b, c float64
+}
+
+func testGEEV[T gen.Scalar](t *testing.T, prec string, f geevRoutines[T]) {
+	rnd := rand.New(rand.NewSource(1))
+	minWork := func(x, wantv bool, n int) int {
+		switch {
+		case isComplex[T]():
+			return 2 * n
+		case x && wantv:
+			return 3 * n
+		case x:
+			return 2 * n
+		case wantv:
+			return 4 * n
+		}
+		return 3 * n
+	}
+
+	// Random matrices, of orders above laqrNmin for the multishift QR
+	// algorithm, and the real ones with complex conjugate pairs, with
+	// every combination of eigenvectors.
+	for _, n := range []int{0, 1, 2, 5, 40, 100} {
+		lda := n + 2
+		a := randMat[T](rnd, n, n, lda)
+		for _, jobvl := range []JobZ{JobZN, JobZV} {
+			for _, jobvr := range []JobZ{JobZN, JobZV} {
+				for _, v := range workVariants[1:] {
+					name := fmt.Sprintf("%sGEEV %s jobvl=%c jobvr=%c n=%d", prec, v, jobvl, jobvr, n)
+					ac := slices.Clone(a)
+					w := make([]complex128, n)
+					ldvl, ldvr := n+1, n+3
+					vl, vr := make([]T, ldvl*n), make([]T, ldvr*n)
+					if err := withWork(v, minWork(false, jobvl == JobZV || jobvr == JobZV, n), nil, func(work []T, lwork int) error {
+						return f.geev(jobvl, jobvr, n, ac, lda, w, vl, ldvl, vr, ldvr, work, lwork)
+					}); err != nil {
+						t.Errorf("%s: unexpected error %v", name, err)
+						continue
+					}
+					if !samePad(n, n, lda, ac, a) {
+						t.Errorf("%s: elements outside A modified", name)
+					}
+					checkEigpairs(t, name, n, a, lda, w, jobvl, vl, ldvl, jobvr, vr, ldvr)
+				}
+			}
+		}
+
+		// Without scaling, rconde is |uᴴ*v| for the unit eigenvectors of
+		// A, whatever their phases.
+		for _, balanc := range []BalanceJob{BalanceN, BalanceP, BalanceB} {
+			name := fmt.Sprintf("%sGEEVX balanc=%c n=%d", prec, balanc, n)
+			ac := slices.Clone(a)
+			w := make([]complex128, n)
+			vl, vr := make([]T, n*n), make([]T, n*n)
+			scale, rconde := make([]float64, n), make([]float64, n)
+			if err := withWork("optimal lwork", minWork(true, true, n), nil, func(work []T, lwork int) error {
+				_, _, _, err := f.geevx(balanc, JobZV, JobZV, SenseE, n, ac, lda, w, vl, max(1, n), vr, max(1, n), scale, rconde, work, lwork)
+				return err
+			}); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			checkEigpairs(t, name, n, a, lda, w, JobZV, vl, max(1, n), JobZV, vr, max(1, n))
+			if balanc == BalanceB {
+				continue
+			}
+			u, v := eigvecs(n, vl, max(1, n), w), eigvecs(n, vr, max(1, n), w)
+			for j := 0; j < n; j++ {
+				var uv complex128
+				for i := 0; i < n; i++ {
+					uv += cmplx.Conj(u[i+j*n]) * v[i+j*n]
+				}
+				if r := ratio[T](math.Abs(rconde[j]-cmplx.Abs(uv)), 1, n); r > maxRatio {
+					t.Errorf("%s: rconde[%d] = %.6g, want |uᴴ*v| = %.6g", name, j, rconde[j], cmplx.Abs(uv))
+				}
+			}
+		}
+	}
+
+	// Matrices Q*T*Qᴴ of a block diagonal T, of which the eigenvalues and
+	// their condition numbers are known, with a unitary Q. The condition
+	// numbers do not change under unitary similarity, nor under the
+	// permutations of balancing, and rconde is checked against them when
+	// A is not scaled.
+	for _, blocks := range [][]eigBlock{
+		{{l1: 1, l2: 2, b: 0}, {l1: -3, l2: 5, b: 0}},
+		{{l1: 1, l2: 2, b: 1}, {l1: 3, l2: 5, b: 20}, {l1: -1, l2: -1.5, b: 1e3}},
+		{{l1: 1, l2: 1 + 1e-4, b: 1}, {l1: -2, l2: 4, b: 0.5}},
+		{{pair: true, l1: 1, b: 2, c: 2}, {pair: true, l1: -1, b: 100, c: 1}, {l1: 0, l2: 3, b: 1}},
+		{{pair: true, l1: 0.5, b: 1, c: 1e-4}, {pair: true, l1: 0.5, b: 4, c: 1}, {pair: true, l1: 7, b: 1e-3, c: 10}},
+	} {
+		n := 2 * len(blocks)
+		var want []complex128
+		var wantCond []float64
+		tm := make([]T, n*n)
+		for k, blk := range blocks {
+			i := 2 * k
+			tm[i+i*n] = fromReal[T](blk.l1)
+			if blk.pair {
+				tm[i+1+(i+1)*n] = fromReal[T](blk.l1)
+				tm[i+(i+1)*n] = fromReal[T](blk.b)
+				tm[i+1+i*n] = fromReal[T](-blk.c)
+				mu := math.Sqrt(blk.b * blk.c)
+				want = append(want, complex(blk.l1, mu), complex(blk.l1, -mu))
+				cond := 2 * mu / (blk.b + blk.c)
+				wantCond = append(wantCond, cond, cond)
+				continue
+			}
+			tm[i+1+(i+1)*n] = fromReal[T](blk.l2)
+			tm[i+(i+1)*n] = fromReal[T](blk.b)
+			want = append(want, complex(blk.l1, 0), complex(blk.l2, 0))
+			r := blk.b / (blk.l1 - blk.l2)
+			cond := 1 / math.Sqrt(1+r*r)
+			wantCond = append(wantCond, cond, cond)
+		}
+		q := unitaryMat[T](rnd, n)
+		a := mulMat(blas.TransN, blas.TransC, n, n, n, mulMat(blas.TransN, blas.TransN, n, n, n, q, n, tm, n), n, q, n)
+		for _, balanc := range []BalanceJob{BalanceN, BalanceP, BalanceS, BalanceB} {
+			name := fmt.Sprintf("%sGEEVX balanc=%c blocks %v", prec, balanc, blocks)
+			ac := slices.Clone(a)
+			w := make([]complex128, n)
+			vl, vr := make([]T, n*n), make([]T, n*n)
+			scale, rconde := make([]float64, n), make([]float64, n)
+			var ilo, ihi int
+			var abnrm float64
+			if err := withWork("optimal lwork", minWork(true, true, n), nil, func(work []T, lwork int) (err error) {
+				ilo, ihi, abnrm, err = f.geevx(balanc, JobZV, JobZV, SenseE, n, ac, n, w, vl, n, vr, n, scale, rconde, work, lwork)
+				return err
+			}); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			checkEigpairs(t, name, n, a, n, w, JobZV, vl, n, JobZV, vr, n)
+			if balanc == BalanceN && (ilo != 0 || ihi != n-1 || slices.ContainsFunc(scale, func(s float64) bool { return s != 1 })) {
+				t.Errorf("%s: ilo = %d, ihi = %d and scale %v, want no balancing", name, ilo, ihi, scale)
+			}
+
+			// Permutations leave the one-norm of A unchanged.
+			if norm1 := lange(normOne, n, n, a, n); (balanc == BalanceN || balanc == BalanceP) && math.Abs(abnrm-norm1) > maxRatio*eps[T]()*norm1 {
+				t.Errorf("%s: abnrm = %.6g, want ‖A‖₁ = %.6g", name, abnrm, norm1)
+			}
+
+			// Each computed eigenvalue is matched to the nearest known one,
+			// which it approximates to within n*eps*‖A‖ divided by its
+			// reciprocal condition number.
+			norm := normF(n, n, a, n)
+			used := make([]bool, n)
+			for j, wj := range w {
+				k := -1
+				for i, wi := range want {
+					if !used[i] && (k < 0 || cmplx.Abs(wj-wi) < cmplx.Abs(wj-want[k])) {
+						k = i
+					}
+				}
+				used[k] = true
+				if r := ratio[T](cmplx.Abs(w[j]-want[k]), norm/wantCond[k], n); r > maxRatio {
+					t.Errorf("%s: eigenvalue %d is %v, want %v, ratio %.3g", name, j, w[j], want[k], r)
+				}
+				if balanc != BalanceN && balanc != BalanceP {
+					continue
+				}
+				if !(rconde[j] > 0 && rconde[j] <= 1+maxRatio*eps[T]()) {
+					t.Errorf("%s: rconde[%d] = %g outside (0,1]", name, j, rconde[j])
+				}
+				if r := ratio[T](math.Abs(rconde[j]-wantCond[k]), norm/wantCond[k], n); r > maxRatio {
+					t.Errorf("%s: rconde[%d] = %.6g, want %.6g, ratio %.3g", name, j, rconde[j], wantCond[k], r)
+				}
+			}
+		}
+	}
+}
+
+// unitaryMat returns a random n×n unitary matrix with leading dimension n,
+// the product of two Householder reflectors.
+func unitaryMat[T gen.Scalar](rnd *rand.Rand, n int) []T {
+	q := make([]T, n*n)
+	for i := 0; i < n; i++ {
+		q[i+i*n] = 1
+	}
+	for range 2 {
+		v := randMat[T](rnd, n, 1, n)
+		vv := normF(n, 1, v, n)
+		h := make([]T, n*n)
+		for j := 0; j < n; j++ {
+			for i := 0; i < n; i++ {
+				h[i+j*n] = -v[i] * conj(v[j]) * fromReal[T](2/(vv*vv))
+			}
+			h[j+j*n]++
+		}
+		q = mulMat(blas.TransN, blas.TransN, n, n, n, h, n, q, n)
+	}
+	return q
+}
+
+// checkEigpairs checks the eigenvalues w and the eigenvectors in VL and VR, as
+// selected by jobvl and jobvr, of the n×n matrix A: the trace of A is the
+// sum of w, the complex conjugate pairs of a real A are adjacent with the
+// positive imaginary part first, A*v = w*v and uᴴ*A = w*uᴴ for the right and
+// left eigenvectors v and u, and each eigenvector has unit norm and its
+// component of largest modulus real.
+func checkEigpairs[T gen.Scalar](t *testing.T, name string, n int, a []T, lda int, w []complex128, jobvl JobZ, vl []T, ldvl int, jobvr JobZ, vr []T, ldvr int) {
+	t.Helper()
+	norm := normF(n, n, a, lda)
+	var trace, sum complex128
+	for i := 0; i < n; i++ {
+		trace += complex(re(a[i+i*lda]), im(a[i+i*lda]))
+		sum += w[i]
+	}
+	if r := ratio[T](cmplx.Abs(trace-sum), norm, n); r > maxRatio {
+		t.Errorf("%s: sum of the eigenvalues %v, want the trace %v", name, sum, trace)
+	}
+	if !isComplex[T]() {
+		for j := 0; j < n; j++ {
+			if imag(w[j]) == 0 {
+				continue
+			}
+			if imag(w[j]) < 0 || j == n-1 || w[j+1] != cmplx.Conj(w[j]) {
+				t.Errorf("%s: eigenvalue %d, %v, not followed by its conjugate", name, j, w[j])
+				return
+			}
+			j++
+		}
+	}
+
+	ac := make([]complex128, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			ac[i+j*n] = complex(re(a[i+j*lda]), im(a[i+j*lda]))
+		}
+	}
+	for _, side := range []struct {
+		left bool
+		job  JobZ
+		v    []T
+		ldv  int
+	}{{true, jobvl, vl, ldvl}, {false, jobvr, vr, ldvr}} {
+		if side.job != JobZV {
+			continue
+		}
+		v := eigvecs(n, side.v, side.ldv, w)
+		// The left eigenvectors are the right ones of Aᴴ, for the
+		// conjugate eigenvalues.
+		var res []complex128
+		if side.left {
+			res = mulMat(blas.TransC, blas.TransN, n, n, n, ac, n, v, n)
+		} else {
+			res = mulMat(blas.TransN, blas.TransN, n, n, n, ac, n, v, n)
+		}
+		what := "A*v - w*v"
+		if side.left {
+			what = "uᴴ*A - w*uᴴ"
+		}
+		for j := 0; j < n; j++ {
+			wj := w[j]
+			if side.left {
+				wj = cmplx.Conj(wj)
+			}
+			for i := 0; i < n; i++ {
+				res[i+j*n] -= wj * v[i+j*n]
+			}
+			if r := ratio[T](normF(n, 1, res[j*n:], n), norm, n); r > maxRatio {
+				t.Errorf("%s: ‖%s‖ ratio %.3g for eigenvalue %d", name, what, r, j)
+			}
+			vj := v[j*n : (j+1)*n]
+			if r := ratio[T](math.Abs(normF(n, 1, vj, n)-1), 1, n); r > maxRatio {
+				t.Errorf("%s: eigenvector %d has norm %.6g", name, j, normF(n, 1, vj, n))
+			}
+			k := 0
+			for i := range vj {
+				if cmplx.Abs(vj[i]) > cmplx.Abs(vj[k]) {
+					k = i
+				}
+			}
+			if r := ratio[T](math.Abs(imag(vj[k])), 1, n); r > maxRatio {
+				t.Errorf("%s: largest component %v of eigenvector %d not real", name, vj[k], j)
+			}
+		}
+	}
+}
+
+// eigvecs returns the n eigenvectors in the columns of V for the
+// eigenvalues w as an n×n complex128 matrix. For a real V, those of a
+// complex pair j, j+1 are stored as the real part in column j and the
+// imaginary part in column j+1 of the eigenvector of w[j].
+func eigvecs[T gen.Scalar](n int, v []T, ldv int, w []complex128) []complex128 {
+	c := make([]complex128, n*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			c[i+j*n] = complex(re(v[i+j*ldv]), im(v[i+j*ldv]))
+		}
+	}
+	if isComplex[T]() {
+		return c
+	}
+	for j := 0; j < n-1; j++ {
+		if imag(w[j]) == 0 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			x, y := real(c[i+j*n]), real(c[i+(j+1)*n])
+			c[i+j*n], c[i+(j+1)*n] = complex(x, y), complex(x, -y)
+		}
+		j++
+	}
+	return c
+}
@@ -0,0 +1,664 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGESVD computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A, where U and V are orthogonal and Σ is m×n, real and zero apart
+// from its diagonal, which holds the singular values of A in decreasing
+// order, returned in s. A is reduced to bidiagonal form by SGEBRD, after its
+// QR (m >= n) or LQ (m < n) factorization if one dimension is much larger
+// than the other, and the SVD of the bidiagonal matrix is computed by
+// SBDSQR. jobu selects the columns of U that are computed: all m in U (jobu
+// A), the first min(m,n) in U (jobu S) or overwriting A (jobu O), or none
+// (jobu N). jobvt likewise selects the n rows of V**T that are computed, in
+// VT or overwriting A; jobu and jobvt cannot both be O. If neither is O, A
+// is destroyed. A *ConvergenceError is returned if SBDSQR failed, Info
+// superdiagonals of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= max(1,7*k+max(m,n)) elements, where k = min(m,n); the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) SGESVD(jobu, jobvt SVDJob, m, n int, a []float32, lda int, s []float32, u []float32, ldu int, vt []float32, ldvt int, work []float32, lwork int) error {
+	if err := checkGesvd("SGESVD", jobu, jobvt, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, -1); err != nil {
+		return err
+	}
+	_, opt, lrwork := gesvdWork(m, n, jobu, jobvt, false)
+	if lwork == -1 {
+		work[0] = float32(opt + lrwork)
+		return nil
+	}
+	return convergence("SGESVD", gesvd(impl.bl(), false, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work[lrwork:], lwork-lrwork, work[:lrwork], nil))
+}
+
+// SGESDD computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A as SGESVD does, with the SVD of the bidiagonal matrix computed by
+// the divide and conquer method of SBDSDC, which is much faster for large
+// matrices. jobz selects the singular vectors that are computed: all m
+// columns of U and n rows of V**T (jobz A), the first min(m,n) of each (jobz
+// S), none (jobz N), or, for jobz O, the first min(m,n) of each, those of
+// the longer dimension overwriting A and the others returned in full in U or
+// VT. If jobz is not O, A is destroyed. A *ConvergenceError is returned if
+// the SVD of a subproblem failed to converge. work holds lwork >=
+// max(1,7*k+max(m,n)) elements for jobz N and
+// 3*k+2*k*k+max(1,6*k*k+8*k)+max(m,n) otherwise, k*k more for jobz O, where
+// k = min(m,n), and iwork 3*k; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) SGESDD(jobz SVDJob, m, n int, a []float32, lda int, s []float32, u []float32, ldu int, vt []float32, ldvt int, work []float32, lwork int, iwork []int) error {
+	if err := checkGesdd("SGESDD", jobz, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, -1, len(iwork)); err != nil {
+		return err
+	}
+	jobu, jobvt := gesddJobs(jobz, m, n)
+	_, opt, lrwork := gesvdWork(m, n, jobu, jobvt, true)
+	if lwork == -1 {
+		work[0] = float32(opt + lrwork)
+		return nil
+	}
+	return convergence("SGESDD", gesvd(impl.bl(), true, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work[lrwork:], lwork-lrwork, work[:lrwork], iwork))
+}
+
+// DGESVD computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A, where U and V are orthogonal and Σ is m×n, real and zero apart
+// from its diagonal, which holds the singular values of A in decreasing
+// order, returned in s. A is reduced to bidiagonal form by DGEBRD, after its
+// QR (m >= n) or LQ (m < n) factorization if one dimension is much larger
+// than the other, and the SVD of the bidiagonal matrix is computed by
+// DBDSQR. jobu selects the columns of U that are computed: all m in U (jobu
+// A), the first min(m,n) in U (jobu S) or overwriting A (jobu O), or none
+// (jobu N). jobvt likewise selects the n rows of V**T that are computed, in
+// VT or overwriting A; jobu and jobvt cannot both be O. If neither is O, A
+// is destroyed. A *ConvergenceError is returned if DBDSQR failed, Info
+// superdiagonals of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= max(1,7*k+max(m,n)) elements, where k = min(m,n); the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) DGESVD(jobu, jobvt SVDJob, m, n int, a []float64, lda int, s []float64, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int) error {
+	if err := checkGesvd("DGESVD", jobu, jobvt, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, -1); err != nil {
+		return err
+	}
+	_, opt, lrwork := gesvdWork(m, n, jobu, jobvt, false)
+	if lwork == -1 {
+		work[0] = float64(opt + lrwork)
+		return nil
+	}
+	return convergence("DGESVD", gesvd(impl.bl(), false, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work[lrwork:], lwork-lrwork, work[:lrwork], nil))
+}
+
+// DGESDD computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A as DGESVD does, with the SVD of the bidiagonal matrix computed by
+// the divide and conquer method of DBDSDC, which is much faster for large
+// matrices. jobz selects the singular vectors that are computed: all m
+// columns of U and n rows of V**T (jobz A), the first min(m,n) of each (jobz
+// S), none (jobz N), or, for jobz O, the first min(m,n) of each, those of
+// the longer dimension overwriting A and the others returned in full in U or
+// VT. If jobz is not O, A is destroyed. A *ConvergenceError is returned if
+// the SVD of a subproblem failed to converge. work holds lwork >=
+// max(1,7*k+max(m,n)) elements for jobz N and
+// 3*k+2*k*k+max(1,6*k*k+8*k)+max(m,n) otherwise, k*k more for jobz O, where
+// k = min(m,n), and iwork 3*k; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) DGESDD(jobz SVDJob, m, n int, a []float64, lda int, s []float64, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) error {
+	if err := checkGesdd("DGESDD", jobz, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, -1, len(iwork)); err != nil {
+		return err
+	}
+	jobu, jobvt := gesddJobs(jobz, m, n)
+	_, opt, lrwork := gesvdWork(m, n, jobu, jobvt, true)
+	if lwork == -1 {
+		work[0] = float64(opt + lrwork)
+		return nil
+	}
+	return convergence("DGESDD", gesvd(impl.bl(), true, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work[lrwork:], lwork-lrwork, work[:lrwork], iwork))
+}
+
+// CGESVD computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A, where U and V are unitary and Σ is m×n, real and zero apart from
+// its diagonal, which holds the singular values of A in decreasing order,
+// returned in s. A is reduced to bidiagonal form by CGEBRD, after its QR (m
+// >= n) or LQ (m < n) factorization if one dimension is much larger than the
+// other, and the SVD of the bidiagonal matrix is computed by CBDSQR. jobu
+// selects the columns of U that are computed: all m in U (jobu A), the first
+// min(m,n) in U (jobu S) or overwriting A (jobu O), or none (jobu N). jobvt
+// likewise selects the n rows of V**H that are computed, in VT or
+// overwriting A; jobu and jobvt cannot both be O. If neither is O, A is
+// destroyed. A *ConvergenceError is returned if CBDSQR failed, Info
+// superdiagonals of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= max(1,2*k+max(m,n)) elements, where k = min(m,n), and
+// rwork 5*k; the optimal lwork is returned in work[0] by a call with lwork =
+// -1 that does nothing else.
+func (impl Implementation) CGESVD(jobu, jobvt SVDJob, m, n int, a []complex64, lda int, s []float32, u []complex64, ldu int, vt []complex64, ldvt int, work []complex64, lwork int, rwork []float32) error {
+	if err := checkGesvd("CGESVD", jobu, jobvt, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		_, opt, _ := gesvdWork(m, n, jobu, jobvt, false)
+		work[0] = complex(float32(opt), 0)
+		return nil
+	}
+	return convergence("CGESVD", gesvd(impl.bl(), false, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, nil))
+}
+
+// CGESDD computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A as CGESVD does, with the SVD of the bidiagonal matrix computed by
+// the divide and conquer method of CBDSDC, which is much faster for large
+// matrices. jobz selects the singular vectors that are computed: all m
+// columns of U and n rows of V**H (jobz A), the first min(m,n) of each (jobz
+// S), none (jobz N), or, for jobz O, the first min(m,n) of each, those of
+// the longer dimension overwriting A and the others returned in full in U or
+// VT. If jobz is not O, A is destroyed. A *ConvergenceError is returned if
+// the SVD of a subproblem failed to converge. work holds lwork >=
+// max(1,2*k+max(m,n)) elements, k*k more for jobz O, where k = min(m,n),
+// rwork 5*k (jobz N) or k+2*k*k+max(1,6*k*k+8*k) and iwork 3*k; the optimal
+// lwork is returned in work[0] by a call with lwork = -1 that does nothing
+// else.
+func (impl Implementation) CGESDD(jobz SVDJob, m, n int, a []complex64, lda int, s []float32, u []complex64, ldu int, vt []complex64, ldvt int, work []complex64, lwork int, rwork []float32, iwork []int) error {
+	if err := checkGesdd("CGESDD", jobz, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return err
+	}
+	jobu, jobvt := gesddJobs(jobz, m, n)
+	if lwork == -1 {
+		_, opt, _ := gesvdWork(m, n, jobu, jobvt, true)
+		work[0] = complex(float32(opt), 0)
+		return nil
+	}
+	return convergence("CGESDD", gesvd(impl.bl(), true, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork))
+}
+
+// ZGESVD computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A, where U and V are unitary and Σ is m×n, real and zero apart from
+// its diagonal, which holds the singular values of A in decreasing order,
+// returned in s. A is reduced to bidiagonal form by ZGEBRD, after its QR (m
+// >= n) or LQ (m < n) factorization if one dimension is much larger than the
+// other, and the SVD of the bidiagonal matrix is computed by ZBDSQR. jobu
+// selects the columns of U that are computed: all m in U (jobu A), the first
+// min(m,n) in U (jobu S) or overwriting A (jobu O), or none (jobu N). jobvt
+// likewise selects the n rows of V**H that are computed, in VT or
+// overwriting A; jobu and jobvt cannot both be O. If neither is O, A is
+// destroyed. A *ConvergenceError is returned if ZBDSQR failed, Info
+// superdiagonals of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= max(1,2*k+max(m,n)) elements, where k = min(m,n), and
+// rwork 5*k; the optimal lwork is returned in work[0] by a call with lwork =
+// -1 that does nothing else.
+func (impl Implementation) ZGESVD(jobu, jobvt SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) error {
+	if err := checkGesvd("ZGESVD", jobu, jobvt, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		_, opt, _ := gesvdWork(m, n, jobu, jobvt, false)
+		work[0] = complex(float64(opt), 0)
+		return nil
+	}
+	return convergence("ZGESVD", gesvd(impl.bl(), false, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, nil))
+}
+
+// ZGESDD computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A as ZGESVD does, with the SVD of the bidiagonal matrix computed by
+// the divide and conquer method of ZBDSDC, which is much faster for large
+// matrices. jobz selects the singular vectors that are computed: all m
+// columns of U and n rows of V**H (jobz A), the first min(m,n) of each (jobz
+// S), none (jobz N), or, for jobz O, the first min(m,n) of each, those of
+// the longer dimension overwriting A and the others returned in full in U or
+// VT. If jobz is not O, A is destroyed. A *ConvergenceError is returned if
+// the SVD of a subproblem failed to converge. work holds lwork >=
+// max(1,2*k+max(m,n)) elements, k*k more for jobz O, where k = min(m,n),
+// rwork 5*k (jobz N) or k+2*k*k+max(1,6*k*k+8*k) and iwork 3*k; the optimal
+// lwork is returned in work[0] by a call with lwork = -1 that does nothing
+// else.
+func (impl Implementation) ZGESDD(jobz SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64, iwork []int) error {
+	if err := checkGesdd("ZGESDD", jobz, m, n, len(a), lda, len(s), len(u), ldu, len(vt), ldvt, len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return err
+	}
+	jobu, jobvt := gesddJobs(jobz, m, n)
+	if lwork == -1 {
+		_, opt, _ := gesvdWork(m, n, jobu, jobvt, true)
+		work[0] = complex(float64(opt), 0)
+		return nil
+	}
+	return convergence("ZGESDD", gesvd(impl.bl(), true, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork))
+}
+
+// checkGesvd checks the GESVD routines. The real routines keep their real
+// workspace in work and pass -1 for lenRwork.
+func checkGesvd(routine string, jobu, jobvt SVDJob, m, n, lenA, lda, lenS, lenU, ldu, lenVt, ldvt, lenWork, lwork, lenRwork int) error {
+	c := checker{routine: routine}
+	c.svdJob(1, "jobu", jobu)
+	c.svdJob(2, "jobvt", jobvt)
+	if c.ok() && jobu == SVDO && jobvt == SVDO {
+		c.fail(2, "jobvt", "must not be O when jobu is O")
+	}
+	c.nonNeg(3, "m", m)
+	c.nonNeg(4, "n", n)
+	c.ld(6, "lda", lda, m, "m")
+	svdLd(&c, 9, 11, jobu, jobvt, m, n, ldu, ldvt)
+	minWork, _, minRwork := gesvdWork(m, n, jobu, jobvt, false)
+	complex := lenRwork >= 0
+	if complex {
+		c.lwork(13, lwork, minWork, "2*min(m,n)+max(m,n)")
+	} else {
+		c.lwork(13, lwork, minWork+minRwork, "7*min(m,n)+max(m,n)")
+	}
+	if c.ok() {
+		c.work(12, lenWork, lwork)
+		if lwork != -1 {
+			c.length(5, "a", lenA, matLen(m, n, lda))
+			c.length(7, "s", lenS, min(m, n))
+			svdLen(&c, 8, 10, jobu, jobvt, m, n, lenU, ldu, lenVt, ldvt)
+			if complex {
+				c.length(14, "rwork", lenRwork, minRwork)
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkGesdd checks the GESDD routines. The real routines keep their real
+// workspace in work and pass -1 for lenRwork.
+func checkGesdd(routine string, jobz SVDJob, m, n, lenA, lda, lenS, lenU, ldu, lenVt, ldvt, lenWork, lwork, lenRwork, lenIwork int) error {
+	c := checker{routine: routine}
+	c.svdJob(1, "jobz", jobz)
+	c.nonNeg(2, "m", m)
+	c.nonNeg(3, "n", n)
+	c.ld(5, "lda", lda, m, "m")
+	if !c.ok() {
+		return c.result()
+	}
+	jobu, jobvt := gesddJobs(jobz, m, n)
+	svdLd(&c, 8, 10, jobu, jobvt, m, n, ldu, ldvt)
+	minWork, _, minRwork := gesvdWork(m, n, jobu, jobvt, true)
+	complex := lenRwork >= 0
+	switch {
+	case complex && jobz == SVDO:
+		c.lwork(12, lwork, minWork, "2*k+k*k+max(m,n), k = min(m,n)")
+	case complex:
+		c.lwork(12, lwork, minWork, "2*min(m,n)+max(m,n)")
+	case jobz == SVDN:
+		c.lwork(12, lwork, minWork+minRwork, "7*min(m,n)+max(m,n)")
+	case jobz == SVDO:
+		c.lwork(12, lwork, minWork+minRwork, "3*k+3*k*k+max(1,6*k*k+8*k)+max(m,n), k = min(m,n)")
+	default:
+		c.lwork(12, lwork, minWork+minRwork, "3*k+2*k*k+max(1,6*k*k+8*k)+max(m,n), k = min(m,n)")
+	}
+	if c.ok() {
+		c.work(11, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(m, n, lda))
+			c.length(6, "s", lenS, min(m, n))
+			svdLen(&c, 7, 9, jobu, jobvt, m, n, lenU, ldu, lenVt, ldvt)
+			iwork := 13
+			if complex {
+				c.length(13, "rwork", lenRwork, minRwork)
+				iwork = 14
+			}
+			c.length(iwork, "iwork", lenIwork, 3*min(m, n))
+		}
+	}
+	return c.result()
+}
+
+// svdLd checks the leading dimensions ldu and ldvt, at positions pu and
+// pvt, of the singular vectors that the SVD drivers return in U and VT.
+func svdLd(c *checker, pu, pvt int, jobu, jobvt SVDJob, m, n, ldu, ldvt int) {
+	if jobu == SVDA || jobu == SVDS {
+		c.ld(pu, "ldu", ldu, m, "m")
+	} else {
+		c.atLeast(pu, "ldu", ldu, 1, "1")
+	}
+	switch jobvt {
+	case SVDA:
+		c.ld(pvt, "ldvt", ldvt, n, "n")
+	case SVDS:
+		c.ld(pvt, "ldvt", ldvt, min(m, n), "min(m,n)")
+	default:
+		c.atLeast(pvt, "ldvt", ldvt, 1, "1")
+	}
+}
+
+// svdLen checks the lengths of U and VT, at positions pu and pvt, for the
+// singular vectors that the SVD drivers return in them.
+func svdLen(c *checker, pu, pvt int, jobu, jobvt SVDJob, m, n, lenU, ldu, lenVt, ldvt int) {
+	ncu, nrvt := svdDims(jobu, jobvt, m, n)
+	if jobu == SVDA || jobu == SVDS {
+		c.length(pu, "u", lenU, matLen(m, ncu, ldu))
+	}
+	if jobvt == SVDA || jobvt == SVDS {
+		c.length(pvt, "vt", lenVt, matLen(nrvt, n, ldvt))
+	}
+}
+
+// svdDims returns the number of columns of U and of rows of VT that the SVD
+// of an m×n matrix computes for jobu and jobvt A or S.
+func svdDims(jobu, jobvt SVDJob, m, n int) (ncu, nrvt int) {
+	ncu, nrvt = min(m, n), min(m, n)
+	if jobu == SVDA {
+		ncu = m
+	}
+	if jobvt == SVDA {
+		nrvt = n
+	}
+	return ncu, nrvt
+}
+
+// gesddJobs returns the jobu and jobvt of gesvd that compute the singular
+// vectors selected by jobz of xGESDD.
+func gesddJobs(jobz SVDJob, m, n int) (jobu, jobvt SVDJob) {
+	switch {
+	case jobz != SVDO:
+		return jobz, jobz
+	case m >= n:
+		return SVDO, SVDS
+	}
+	return SVDS, SVDO
+}
+
+// gesddCrossover returns the ratio of the dimensions of A above which gesvd
+// with dc first computes its QR or LQ factorization, the value of MNTHR of
+// xGESDD.
+func gesddCrossover(minmn int) int {
+	return minmn * 11 / 6
+}
+
+// gesvdWork returns the minimum and optimal lengths of the workspace work
+// of gesvd, and the length of its real workspace rwork, for the SVD by QR
+// iteration or by divide and conquer (dc). The real routines keep rwork at
+// the start of work.
+func gesvdWork(m, n int, jobu, jobvt SVDJob, dc bool) (minWork, opt, lrwork int) {
+	k := min(m, n)
+	minWork, opt = gesvdBrdWork(m, n, jobu, jobvt, dc)
+	if k == 0 {
+		return minWork, opt, 0
+	}
+	lrwork = 5 * k
+	if dc && (jobu != SVDN || jobvt != SVDN) {
+		lrwork = k + 2*k*k + lasd0Work(k)
+	}
+	mnthr := gelssCrossover(k)
+	if dc {
+		mnthr = gesddCrossover(k)
+	}
+	// The factorization of A, the SVD of its triangular factor and, when
+	// the vectors of the long dimension are wanted, their product by Q.
+	switch {
+	case m >= n && m >= mnthr && jobu == SVDN:
+		_, sub := gesvdBrdWork(n, n, SVDN, jobvt, dc)
+		opt = max(n+geqrfWork(m, n), sub)
+	case m >= n && m >= mnthr:
+		ncu, _ := svdDims(jobu, jobvt, m, n)
+		_, sub := gesvdBrdWork(n, n, SVDO, svdJobS(jobvt), dc)
+		opt = n*n + max(n+geqrfWork(m, n), n+orgqrWork(ncu), sub, m*n)
+	case m < n && n >= mnthr && jobvt == SVDN:
+		_, sub := gesvdBrdWork(m, m, jobu, SVDN, dc)
+		opt = max(m+geqrfWork(n, m), sub)
+	case m < n && n >= mnthr:
+		_, nrvt := svdDims(jobu, jobvt, m, n)
+		_, sub := gesvdBrdWork(m, m, svdJobS(jobu), SVDO, dc)
+		opt = m*m + max(m+geqrfWork(n, m), m+orgqrWork(nrvt), sub, m*n)
+	}
+	return minWork, max(minWork, opt), lrwork
+}
+
+// svdJobS returns S for job O and job otherwise: the job of the SVD of the
+// triangular factor of A whose vectors are not overwritten by those of A.
+func svdJobS(job SVDJob) SVDJob {
+	if job == SVDO {
+		return SVDS
+	}
+	return job
+}
+
+// gesvdBrdWork returns the minimum and optimal workspace lengths of
+// gesvdBrd.
+func gesvdBrdWork(m, n int, jobu, jobvt SVDJob, dc bool) (minWork, opt int) {
+	k := min(m, n)
+	over := jobu == SVDO || jobvt == SVDO
+	minWork = max(1, 2*k+max(m, n))
+	if dc && over {
+		minWork += k * k
+	}
+	if k == 0 {
+		return minWork, minWork
+	}
+	ncu, nrvt := svdDims(jobu, jobvt, m, n)
+	w := gebrdWork(m, n)
+	switch {
+	case !dc:
+		if jobu != SVDN {
+			w = max(w, orgbrWork(m, ncu))
+		}
+		if jobvt != SVDN {
+			w = max(w, orgbrWork(nrvt, n))
+		}
+	case jobu != SVDN || jobvt != SVDN:
+		if jobu == SVDA || jobu == SVDS {
+			w = max(w, ormWork(blas.SideL, m, ncu))
+		}
+		if jobvt == SVDA || jobvt == SVDS {
+			w = max(w, ormWork(blas.SideR, nrvt, n))
+		}
+		if over {
+			w = max(w, k*k+max(orgbrWork(m, n), m*n))
+		}
+	}
+	return minWork, max(minWork, 2*k+w)
+}
+
+// gesvd computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A by QR iteration, as xGESVD, or by divide and conquer when dc is
+// true, as xGESDD with the jobu and jobvt of gesddJobs. The singular values
+// are returned in s in decreasing order. gesvd returns 0, or the info of
+// bdsqr or bdsdc if the SVD failed to converge. work holds lwork elements
+// and rwork lrwork, as returned by gesvdWork, and iwork 3*min(m,n) when dc
+// is true. The vectors of the long dimension of a matrix with many more rows
+// than columns, or columns than rows, are computed from its QR or LQ
+// factorization only if lwork holds its triangular factor.
+func gesvd[T gen.Scalar, R gen.Float](bl blas.BLAS, dc bool, jobu, jobvt SVDJob, m, n int, a []T, lda int, s []R, u []T, ldu int, vt []T, ldvt int, work []T, lwork int, rwork []R, iwork []int) (info int) {
+	k := min(m, n)
+	if k == 0 {
+		return 0
+	}
+
+	// Scale A to the range [smlnum,bignum] if needed.
+	smlnum := math.Sqrt(safmin[T]()) / eps[T]()
+	bignum := 1 / smlnum
+	anrm := lange(normMax, m, n, a, lda)
+	ascl := lsScale(anrm, smlnum, bignum)
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, m, n, a, lda)
+	}
+
+	mnthr := gelssCrossover(k)
+	if dc {
+		mnthr = gesddCrossover(k)
+	}
+	switch {
+	case m >= n && m >= mnthr && jobu == SVDN:
+		// The triangular factor R of A = Q*R has the singular values and
+		// right singular vectors of A.
+		tau := work[:n]
+		geqrf(bl, m, n, a, lda, tau, work[n:], lwork-n)
+		laset(blas.UploL, n-1, n-1, 0, 0, a[1:], lda)
+		info = gesvdBrd(bl, dc, SVDN, jobvt, n, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork)
+	case m >= n && m >= mnthr:
+		ncu, _ := svdDims(jobu, jobvt, m, n)
+		jv, vtr, ldvtr := svdJobS(jobvt), vt, ldvt
+		if jobvt == SVDO {
+			vtr, ldvtr = a, lda
+		}
+		subMin, _ := gesvdBrdWork(n, n, SVDO, jv, dc)
+		if lwork < n*n+max(n+ncu, subMin) {
+			info = gesvdBrd(bl, dc, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork)
+			break
+		}
+		// Compute the SVD R = UR*Σ*V**H of the triangular factor of
+		// A = Q*R, held in work, and U = Q*UR.
+		r, tau := work[:n*n], work[n*n:n*n+n]
+		w, lw := work[n*n+n:], lwork-n*n-n
+		geqrf(bl, m, n, a, lda, tau, w, lw)
+		lacpy(blas.UploU, n, n, a, lda, r, n)
+		laset(blas.UploL, n-1, n-1, 0, 0, r[1:], n)
+		q, ldq := a, lda
+		if jobu != SVDO {
+			lacpy(blas.UploL, m, n, a, lda, u, ldu)
+			q, ldq = u, ldu
+		}
+		orgqr(bl, m, ncu, n, q, ldq, tau, w, lw)
+		info = gesvdBrd(bl, dc, SVDO, jv, n, n, r, n, s, nil, 1, vtr, ldvtr, work[n*n:], lwork-n*n, rwork, iwork)
+		mulRight(bl, m, n, q, ldq, r, n, work[n*n:], lwork-n*n)
+	case m < n && n >= mnthr && jobvt == SVDN:
+		// The triangular factor L of A = L*Q has the singular values and
+		// left singular vectors of A.
+		tau := work[:m]
+		gelqf(bl, m, n, a, lda, tau, work[m:], lwork-m)
+		laset(blas.UploU, m-1, m-1, 0, 0, a[lda:], lda)
+		info = gesvdBrd(bl, dc, jobu, SVDN, m, m, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork)
+	case m < n && n >= mnthr:
+		_, nrvt := svdDims(jobu, jobvt, m, n)
+		ju, ur, ldur := svdJobS(jobu), u, ldu
+		if jobu == SVDO {
+			ur, ldur = a, lda
+		}
+		subMin, _ := gesvdBrdWork(m, m, ju, SVDO, dc)
+		if lwork < m*m+max(m+nrvt, subMin) {
+			info = gesvdBrd(bl, dc, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork)
+			break
+		}
+		// Compute the SVD L = U*Σ*VL**H of the triangular factor of
+		// A = L*Q, held in work, and V**H = VL**H*Q.
+		l, tau := work[:m*m], work[m*m:m*m+m]
+		w, lw := work[m*m+m:], lwork-m*m-m
+		gelqf(bl, m, n, a, lda, tau, w, lw)
+		lacpy(blas.UploL, m, m, a, lda, l, m)
+		laset(blas.UploU, m-1, m-1, 0, 0, l[m:], m)
+		q, ldq := a, lda
+		if jobvt != SVDO {
+			lacpy(blas.UploU, m, n, a, lda, vt, ldvt)
+			q, ldq = vt, ldvt
+		}
+		orglq(bl, nrvt, n, m, q, ldq, tau, w, lw)
+		info = gesvdBrd(bl, dc, ju, SVDO, m, m, l, m, s, ur, ldur, nil, 1, work[m*m:], lwork-m*m, rwork, iwork)
+		mulLeft(bl, m, n, l, m, q, ldq, work[m*m:], lwork-m*m)
+	default:
+		info = gesvdBrd(bl, dc, jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, rwork, iwork)
+	}
+
+	// Undo the scaling.
+	if ascl != 0 {
+		lascl(uploAll, ascl, anrm, k, 1, s, k)
+	}
+	return info
+}
+
+// gesvdBrd computes the SVD of gesvd for the m×n matrix A by its reduction
+// to bidiagonal form. The vectors of jobu or jobvt O overwrite A. work holds
+// lwork >= 2*min(m,n)+max(m,n) elements, and min(m,n)**2 more if dc is true
+// and jobu or jobvt is O.
+func gesvdBrd[T gen.Scalar, R gen.Float](bl blas.BLAS, dc bool, jobu, jobvt SVDJob, m, n int, a []T, lda int, s []R, u []T, ldu int, vt []T, ldvt int, work []T, lwork int, rwork []R, iwork []int) (info int) {
+	k := min(m, n)
+	ncu, nrvt := svdDims(jobu, jobvt, m, n)
+	e := rwork[:k]
+	tauq, taup := work[:k], work[k:2*k]
+	w, lw := work[2*k:], lwork-2*k
+
+	// Reduce A to bidiagonal form B = Q**H*A*P.
+	gebrd(bl, m, n, a, lda, s, e, tauq, taup, w, lw)
+	uplo := blas.UploU
+	if m < n {
+		uplo = blas.UploL
+	}
+
+	if !dc || (jobu == SVDN && jobvt == SVDN) {
+		// Generate Q and P**H, where the vectors are wanted, and multiply
+		// them by the singular vectors of B in bdsqr. The reflectors are
+		// copied out of A before either is generated in place.
+		if jobu == SVDA || jobu == SVDS {
+			lacpy(blas.UploL, m, k, a, lda, u, ldu)
+		}
+		if jobvt == SVDA || jobvt == SVDS {
+			lacpy(blas.UploU, k, n, a, lda, vt, ldvt)
+		}
+		var nru, ncvt int
+		switch jobu {
+		case SVDA, SVDS:
+			orgbr(bl, VectQ, m, ncu, n, u, ldu, tauq, w, lw)
+			nru = m
+		case SVDO:
+			orgbr(bl, VectQ, m, k, n, a, lda, tauq, w, lw)
+			u, ldu, nru = a, lda, m
+		}
+		switch jobvt {
+		case SVDA, SVDS:
+			orgbr(bl, VectP, nrvt, n, m, vt, ldvt, taup, w, lw)
+			ncvt = n
+		case SVDO:
+			orgbr(bl, VectP, k, n, m, a, lda, taup, w, lw)
+			vt, ldvt, ncvt = a, lda, n
+		}
+		return bdsqr(bl, uplo, k, ncvt, nru, 0, s, e, vt, ldvt, u, ldu, nil, 1, rwork[k:])
+	}
+
+	// Compute the SVD B = UB*Σ*VB**T in rwork and the singular vectors of
+	// A, U = Q*[UB 0; 0 I] and V**H = [VB**T 0; 0 I]*P**H, those of jobu or
+	// jobvt O last since A holds the reflectors.
+	ub, vb := rwork[k:k+k*k], rwork[k+k*k:k+2*k*k]
+	if info = bdsdc(bl, uplo, CompZI, k, s, e, ub, k, vb, k, rwork[k+2*k*k:], iwork); info != 0 {
+		return info
+	}
+	if jobvt == SVDA || jobvt == SVDS {
+		laset(uploAll, nrvt, n, 0, 0, vt, ldvt)
+		lacp2(k, k, vb, k, vt, ldvt)
+		if nrvt > k {
+			laset(uploAll, nrvt-k, n-k, 0, 1, vt[k+k*ldvt:], ldvt)
+		}
+		ormbr(bl, VectP, blas.SideR, blas.TransC, nrvt, n, m, a, lda, taup, vt, ldvt, w, lw)
+	}
+	if jobu == SVDA || jobu == SVDS {
+		laset(uploAll, m, ncu, 0, 0, u, ldu)
+		lacp2(k, k, ub, k, u, ldu)
+		if ncu > k {
+			laset(uploAll, m-k, ncu-k, 0, 1, u[k+k*ldu:], ldu)
+		}
+		ormbr(bl, VectQ, blas.SideL, blas.TransN, m, ncu, n, a, lda, tauq, u, ldu, w, lw)
+	}
+	switch {
+	case jobu == SVDO:
+		x := w[:k*k]
+		lacp2(k, k, ub, k, x, k)
+		orgbr(bl, VectQ, m, k, n, a, lda, tauq, w[k*k:], lw-k*k)
+		mulRight(bl, m, k, a, lda, x, k, w[k*k:], lw-k*k)
+	case jobvt == SVDO:
+		x := w[:k*k]
+		lacp2(k, k, vb, k, x, k)
+		orgbr(bl, VectP, k, n, m, a, lda, taup, w[k*k:], lw-k*k)
+		mulLeft(bl, k, n, x, k, a, lda, w[k*k:], lw-k*k)
+	}
+	return 0
+}
+
+// mulRight overwrites the m×n matrix A with A*X, where X is n×n, a block
+// of rows at a time. work holds lwork >= n elements.
+func mulRight[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, x []T, ldx int, work []T, lwork int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	nb := max(1, min(m, lwork/n))
+	for i := 0; i < m; i += nb {
+		ib := min(nb, m-i)
+		gemm(bl, blas.TransN, blas.TransN, ib, n, n, 1, a[i:], lda, x, ldx, 0, work, ib)
+		lacpy(uploAll, ib, n, work, ib, a[i:], lda)
+	}
+}
+
+// mulLeft overwrites the m×n matrix A with X*A, where X is m×m, a block of
+// columns at a time. work holds lwork >= m elements.
+func mulLeft[T gen.Scalar](bl blas.BLAS, m, n int, x []T, ldx int, a []T, lda int, work []T, lwork int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	nb := max(1, min(n, lwork/m))
+	for j := 0; j < n; j += nb {
+		jb := min(nb, n-j)
+		gemm(bl, blas.TransN, blas.TransN, m, jb, m, 1, x, ldx, a[j*lda:], lda, 0, work, m)
+		lacpy(uploAll, m, jb, work, m, a[j*lda:], lda)
+	}
+}
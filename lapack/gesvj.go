@@ -0,0 +1,975 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGESVJ computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A, m >= n, by the one-sided Jacobi method, which applies plane
+// rotations from the right until the columns of A*V are orthogonal. It
+// computes the small singular values of a graded matrix, A = B*D with D
+// diagonal and B well conditioned, to high relative accuracy. joba says
+// whether A is general (G), upper triangular (U) or lower triangular (L).
+// The singular values are returned in sva in decreasing order and A is
+// overwritten by the first n columns of U (jobu U) or by U*Σ (jobu N). The
+// orthogonal matrix V is computed in V (jobv V), or the rotations are
+// applied to the mv×n matrix V (jobv A), or V is not referenced (jobv N).
+// SGESVJ returns the number of nonzero singular values. A *ConvergenceError
+// with Info 29 is returned if the columns were not orthogonal to working
+// precision after 30 sweeps of rotations, and an error if A has an infinite
+// or NaN element. work holds m elements.
+func (impl Implementation) SGESVJ(joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n int, a []float32, lda int, sva []float32, mv int, v []float32, ldv int, work []float32) (rank int, err error) {
+	if err := checkGesvj("SGESVJ", joba, jobu, jobv, m, n, len(a), lda, len(sva), mv, len(v), ldv, len(work)); err != nil {
+		return 0, err
+	}
+	rank, info, ok := gesvj(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, mv, v, ldv, work)
+	if !ok {
+		return 0, infError("SGESVJ", 6)
+	}
+	return rank, convergence("SGESVJ", info)
+}
+
+// SGEJSV computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A, m >= n, to high relative accuracy by the preconditioned Jacobi
+// method: the rows of A are sorted by decreasing max-norm, the QR
+// factorization with column pivoting A*P = Q1*R1 is computed, and the SVD of
+// R1 is computed by SGESVJ from the triangular factor of the QR
+// factorization of R1**T. joba selects the numerical rank of R1: a diagonal
+// element below the underflow threshold is negligible for joba C, E, F and
+// G, one below sqrt(n)*eps*|R1(0,0)| for joba A, and, for joba R, also one
+// below eps times its predecessor. For joba E and G the scaled condition
+// number sconda of A, sqrt(||(B**T*B)**-1||_1) for A = B*D with the columns
+// of B of unit norm, is returned if A has full rank and -1 otherwise; it
+// bounds the relative error of the singular values. For other joba it is -1.
+// The singular values are returned in sva in decreasing order; the first n
+// columns of U (jobu U) or all m of them (jobu F) are returned in U and V in
+// V (jobv V), and A is destroyed. SGEJSV returns the numerical rank of A,
+// beyond which the singular values are set to zero. A *ConvergenceError is
+// returned if SGESVJ did not converge. work holds lwork >= max(m,2*n)+n+w
+// elements, where w is max(n*n+2*n,n1) for jobu U or F and jobv V,
+// max(2*n,n1) for jobu U or F only, n*n+2*n for joba E or G without vectors
+// and 2*n otherwise, n1 being n for jobu U and m for jobu F; iwork m+n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) SGEJSV(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []float32, lda int, sva []float32, u []float32, ldu int, v []float32, ldv int, work []float32, lwork int, iwork []int) (rank int, sconda float32, err error) {
+	if err := checkGejsv("SGEJSV", joba, jobu, jobv, m, n, len(a), lda, len(sva), len(u), ldu, len(v), ldv, len(work), lwork, -1, len(iwork)); err != nil {
+		return 0, 0, err
+	}
+	_, opt, lrwork := gejsvWork(m, n, joba, jobu, jobv)
+	if lwork == -1 {
+		work[0] = float32(opt + lrwork)
+		return 0, 0, nil
+	}
+	rank, cond, info, ok := gejsv(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work[lrwork:], lwork-lrwork, work[:lrwork], iwork)
+	if !ok {
+		return 0, 0, infError("SGEJSV", 9)
+	}
+	return rank, float32(cond), convergence("SGEJSV", info)
+}
+
+// DGESVJ computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A, m >= n, by the one-sided Jacobi method, which applies plane
+// rotations from the right until the columns of A*V are orthogonal. It
+// computes the small singular values of a graded matrix, A = B*D with D
+// diagonal and B well conditioned, to high relative accuracy. joba says
+// whether A is general (G), upper triangular (U) or lower triangular (L).
+// The singular values are returned in sva in decreasing order and A is
+// overwritten by the first n columns of U (jobu U) or by U*Σ (jobu N). The
+// orthogonal matrix V is computed in V (jobv V), or the rotations are
+// applied to the mv×n matrix V (jobv A), or V is not referenced (jobv N).
+// DGESVJ returns the number of nonzero singular values. A *ConvergenceError
+// with Info 29 is returned if the columns were not orthogonal to working
+// precision after 30 sweeps of rotations, and an error if A has an infinite
+// or NaN element. work holds m elements.
+func (impl Implementation) DGESVJ(joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n int, a []float64, lda int, sva []float64, mv int, v []float64, ldv int, work []float64) (rank int, err error) {
+	if err := checkGesvj("DGESVJ", joba, jobu, jobv, m, n, len(a), lda, len(sva), mv, len(v), ldv, len(work)); err != nil {
+		return 0, err
+	}
+	rank, info, ok := gesvj(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, mv, v, ldv, work)
+	if !ok {
+		return 0, infError("DGESVJ", 6)
+	}
+	return rank, convergence("DGESVJ", info)
+}
+
+// DGEJSV computes the singular value decomposition A = U*Σ*V**T of the m×n
+// matrix A, m >= n, to high relative accuracy by the preconditioned Jacobi
+// method: the rows of A are sorted by decreasing max-norm, the QR
+// factorization with column pivoting A*P = Q1*R1 is computed, and the SVD of
+// R1 is computed by DGESVJ from the triangular factor of the QR
+// factorization of R1**T. joba selects the numerical rank of R1: a diagonal
+// element below the underflow threshold is negligible for joba C, E, F and
+// G, one below sqrt(n)*eps*|R1(0,0)| for joba A, and, for joba R, also one
+// below eps times its predecessor. For joba E and G the scaled condition
+// number sconda of A, sqrt(||(B**T*B)**-1||_1) for A = B*D with the columns
+// of B of unit norm, is returned if A has full rank and -1 otherwise; it
+// bounds the relative error of the singular values. For other joba it is -1.
+// The singular values are returned in sva in decreasing order; the first n
+// columns of U (jobu U) or all m of them (jobu F) are returned in U and V in
+// V (jobv V), and A is destroyed. DGEJSV returns the numerical rank of A,
+// beyond which the singular values are set to zero. A *ConvergenceError is
+// returned if DGESVJ did not converge. work holds lwork >= max(m,2*n)+n+w
+// elements, where w is max(n*n+2*n,n1) for jobu U or F and jobv V,
+// max(2*n,n1) for jobu U or F only, n*n+2*n for joba E or G without vectors
+// and 2*n otherwise, n1 being n for jobu U and m for jobu F; iwork m+n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) DGEJSV(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []float64, lda int, sva []float64, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (rank int, sconda float64, err error) {
+	if err := checkGejsv("DGEJSV", joba, jobu, jobv, m, n, len(a), lda, len(sva), len(u), ldu, len(v), ldv, len(work), lwork, -1, len(iwork)); err != nil {
+		return 0, 0, err
+	}
+	_, opt, lrwork := gejsvWork(m, n, joba, jobu, jobv)
+	if lwork == -1 {
+		work[0] = float64(opt + lrwork)
+		return 0, 0, nil
+	}
+	rank, cond, info, ok := gejsv(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work[lrwork:], lwork-lrwork, work[:lrwork], iwork)
+	if !ok {
+		return 0, 0, infError("DGEJSV", 9)
+	}
+	return rank, float64(cond), convergence("DGEJSV", info)
+}
+
+// CGESVJ computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A, m >= n, by the one-sided Jacobi method, which applies plane
+// rotations from the right until the columns of A*V are orthogonal. It
+// computes the small singular values of a graded matrix, A = B*D with D
+// diagonal and B well conditioned, to high relative accuracy. joba says
+// whether A is general (G), upper triangular (U) or lower triangular (L).
+// The singular values are returned in sva in decreasing order and A is
+// overwritten by the first n columns of U (jobu U) or by U*Σ (jobu N). The
+// unitary matrix V is computed in V (jobv V), or the rotations are applied
+// to the mv×n matrix V (jobv A), or V is not referenced (jobv N). CGESVJ
+// returns the number of nonzero singular values. A *ConvergenceError with
+// Info 29 is returned if the columns were not orthogonal to working
+// precision after 30 sweeps of rotations, and an error if A has an infinite
+// or NaN element. work holds m elements.
+func (impl Implementation) CGESVJ(joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n int, a []complex64, lda int, sva []float32, mv int, v []complex64, ldv int, work []complex64) (rank int, err error) {
+	if err := checkGesvj("CGESVJ", joba, jobu, jobv, m, n, len(a), lda, len(sva), mv, len(v), ldv, len(work)); err != nil {
+		return 0, err
+	}
+	rank, info, ok := gesvj(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, mv, v, ldv, work)
+	if !ok {
+		return 0, infError("CGESVJ", 6)
+	}
+	return rank, convergence("CGESVJ", info)
+}
+
+// CGEJSV computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A, m >= n, to high relative accuracy by the preconditioned Jacobi
+// method: the rows of A are sorted by decreasing max-norm, the QR
+// factorization with column pivoting A*P = Q1*R1 is computed, and the SVD of
+// R1 is computed by CGESVJ from the triangular factor of the QR
+// factorization of R1**H. joba selects the numerical rank of R1: a diagonal
+// element below the underflow threshold is negligible for joba C, E, F and
+// G, one below sqrt(n)*eps*|R1(0,0)| for joba A, and, for joba R, also one
+// below eps times its predecessor. For joba E and G the scaled condition
+// number sconda of A, sqrt(||(B**H*B)**-1||_1) for A = B*D with the columns
+// of B of unit norm, is returned if A has full rank and -1 otherwise; it
+// bounds the relative error of the singular values. For other joba it is -1.
+// The singular values are returned in sva in decreasing order; the first n
+// columns of U (jobu U) or all m of them (jobu F) are returned in U and V in
+// V (jobv V), and A is destroyed. CGEJSV returns the numerical rank of A,
+// beyond which the singular values are set to zero. A *ConvergenceError is
+// returned if CGESVJ did not converge. work holds lwork >= n+w elements,
+// where w is max(n*n+2*n,n1) for jobu U or F and jobv V, max(2*n,n1) for
+// jobu U or F only, n*n+2*n for joba E or G without vectors and 2*n
+// otherwise, n1 being n for jobu U and m for jobu F; rwork holds max(m,2*n)
+// elements and iwork m+n; the optimal lwork is returned in work[0] by a call
+// with lwork = -1 that does nothing else.
+func (impl Implementation) CGEJSV(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []complex64, lda int, sva []float32, u []complex64, ldu int, v []complex64, ldv int, work []complex64, lwork int, rwork []float32, iwork []int) (rank int, sconda float32, err error) {
+	if err := checkGejsv("CGEJSV", joba, jobu, jobv, m, n, len(a), lda, len(sva), len(u), ldu, len(v), ldv, len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return 0, 0, err
+	}
+	if lwork == -1 {
+		_, opt, _ := gejsvWork(m, n, joba, jobu, jobv)
+		work[0] = complex(float32(opt), 0)
+		return 0, 0, nil
+	}
+	rank, cond, info, ok := gejsv(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, rwork, iwork)
+	if !ok {
+		return 0, 0, infError("CGEJSV", 9)
+	}
+	return rank, float32(cond), convergence("CGEJSV", info)
+}
+
+// ZGESVJ computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A, m >= n, by the one-sided Jacobi method, which applies plane
+// rotations from the right until the columns of A*V are orthogonal. It
+// computes the small singular values of a graded matrix, A = B*D with D
+// diagonal and B well conditioned, to high relative accuracy. joba says
+// whether A is general (G), upper triangular (U) or lower triangular (L).
+// The singular values are returned in sva in decreasing order and A is
+// overwritten by the first n columns of U (jobu U) or by U*Σ (jobu N). The
+// unitary matrix V is computed in V (jobv V), or the rotations are applied
+// to the mv×n matrix V (jobv A), or V is not referenced (jobv N). ZGESVJ
+// returns the number of nonzero singular values. A *ConvergenceError with
+// Info 29 is returned if the columns were not orthogonal to working
+// precision after 30 sweeps of rotations, and an error if A has an infinite
+// or NaN element. work holds m elements.
+func (impl Implementation) ZGESVJ(joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n int, a []complex128, lda int, sva []float64, mv int, v []complex128, ldv int, work []complex128) (rank int, err error) {
+	if err := checkGesvj("ZGESVJ", joba, jobu, jobv, m, n, len(a), lda, len(sva), mv, len(v), ldv, len(work)); err != nil {
+		return 0, err
+	}
+	rank, info, ok := gesvj(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, mv, v, ldv, work)
+	if !ok {
+		return 0, infError("ZGESVJ", 6)
+	}
+	return rank, convergence("ZGESVJ", info)
+}
+
+// ZGEJSV computes the singular value decomposition A = U*Σ*V**H of the m×n
+// matrix A, m >= n, to high relative accuracy by the preconditioned Jacobi
+// method: the rows of A are sorted by decreasing max-norm, the QR
+// factorization with column pivoting A*P = Q1*R1 is computed, and the SVD of
+// R1 is computed by ZGESVJ from the triangular factor of the QR
+// factorization of R1**H. joba selects the numerical rank of R1: a diagonal
+// element below the underflow threshold is negligible for joba C, E, F and
+// G, one below sqrt(n)*eps*|R1(0,0)| for joba A, and, for joba R, also one
+// below eps times its predecessor. For joba E and G the scaled condition
+// number sconda of A, sqrt(||(B**H*B)**-1||_1) for A = B*D with the columns
+// of B of unit norm, is returned if A has full rank and -1 otherwise; it
+// bounds the relative error of the singular values. For other joba it is -1.
+// The singular values are returned in sva in decreasing order; the first n
+// columns of U (jobu U) or all m of them (jobu F) are returned in U and V in
+// V (jobv V), and A is destroyed. ZGEJSV returns the numerical rank of A,
+// beyond which the singular values are set to zero. A *ConvergenceError is
+// returned if ZGESVJ did not converge. work holds lwork >= n+w elements,
+// where w is max(n*n+2*n,n1) for jobu U or F and jobv V, max(2*n,n1) for
+// jobu U or F only, n*n+2*n for joba E or G without vectors and 2*n
+// otherwise, n1 being n for jobu U and m for jobu F; rwork holds max(m,2*n)
+// elements and iwork m+n; the optimal lwork is returned in work[0] by a call
+// with lwork = -1 that does nothing else.
+func (impl Implementation) ZGEJSV(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []complex128, lda int, sva []float64, u []complex128, ldu int, v []complex128, ldv int, work []complex128, lwork int, rwork []float64, iwork []int) (rank int, sconda float64, err error) {
+	if err := checkGejsv("ZGEJSV", joba, jobu, jobv, m, n, len(a), lda, len(sva), len(u), ldu, len(v), ldv, len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return 0, 0, err
+	}
+	if lwork == -1 {
+		_, opt, _ := gejsvWork(m, n, joba, jobu, jobv)
+		work[0] = complex(float64(opt), 0)
+		return 0, 0, nil
+	}
+	rank, cond, info, ok := gejsv(impl.bl(), joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, rwork, iwork)
+	if !ok {
+		return 0, 0, infError("ZGEJSV", 9)
+	}
+	return rank, float64(cond), convergence("ZGEJSV", info)
+}
+
+// checkGesvj checks the GESVJ routines.
+func checkGesvj(routine string, joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n, lenA, lda, lenSva, mv, lenV, ldv, lenWork int) error {
+	c := checker{routine: routine}
+	c.svjMatrix(1, joba)
+	c.svjLeft(2, jobu, false)
+	c.svjRight(3, jobv, true)
+	c.nonNeg(4, "m", m)
+	if n < 0 || n > m {
+		c.fail(5, "n", "must be in [0,m]")
+	}
+	c.ld(7, "lda", lda, m, "m")
+	if jobv == SVJRightA {
+		c.nonNeg(9, "mv", mv)
+	}
+	switch jobv {
+	case SVJRightV:
+		c.ld(11, "ldv", ldv, n, "n")
+	case SVJRightA:
+		c.ld(11, "ldv", ldv, mv, "mv")
+	default:
+		c.atLeast(11, "ldv", ldv, 1, "1")
+	}
+	if c.ok() {
+		c.length(6, "a", lenA, matLen(m, n, lda))
+		c.length(8, "sva", lenSva, n)
+		switch jobv {
+		case SVJRightV:
+			c.length(10, "v", lenV, matLen(n, n, ldv))
+		case SVJRightA:
+			c.length(10, "v", lenV, matLen(mv, n, ldv))
+		}
+		c.length(12, "work", lenWork, m)
+	}
+	return c.result()
+}
+
+// checkGejsv checks the GEJSV routines, with the parameter numbers of
+// LAPACK, which has the arguments JOBR, JOBT and JOBP before m. The real
+// routines keep their real workspace in work and pass -1 for lenRwork.
+func checkGejsv(routine string, joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n, lenA, lda, lenSva, lenU, ldu, lenV, ldv, lenWork, lwork, lenRwork, lenIwork int) error {
+	c := checker{routine: routine}
+	c.jsvAccuracy(1, joba)
+	c.svjLeft(2, jobu, true)
+	c.svjRight(3, jobv, false)
+	c.nonNeg(7, "m", m)
+	if n < 0 || n > m {
+		c.fail(8, "n", "must be in [0,m]")
+	}
+	c.ld(10, "lda", lda, m, "m")
+	if jobu != SVJLeftN {
+		c.ld(13, "ldu", ldu, m, "m")
+	} else {
+		c.atLeast(13, "ldu", ldu, 1, "1")
+	}
+	if jobv == SVJRightV {
+		c.ld(15, "ldv", ldv, n, "n")
+	} else {
+		c.atLeast(15, "ldv", ldv, 1, "1")
+	}
+	if !c.ok() {
+		return c.result()
+	}
+	minWork, _, minRwork := gejsvWork(m, n, joba, jobu, jobv)
+	complex := lenRwork >= 0
+	var expr string
+	switch {
+	case jobu == SVJLeftF && jobv == SVJRightV:
+		expr = "n+max(n*n+2*n,m)"
+	case jobu == SVJLeftF:
+		expr = "n+max(2*n,m)"
+	case jobu == SVJLeftU && jobv == SVJRightV:
+		expr = "n*n+3*n"
+	case jobv == SVJRightN && (joba == JSVE || joba == JSVG):
+		expr = "n*n+3*n"
+	default:
+		expr = "3*n"
+	}
+	if complex {
+		c.lwork(17, lwork, minWork, expr)
+	} else {
+		c.lwork(17, lwork, minWork+minRwork, "max(m,2*n)+"+expr)
+	}
+	if c.ok() {
+		c.work(16, lenWork, lwork)
+		if lwork != -1 {
+			c.length(9, "a", lenA, matLen(m, n, lda))
+			c.length(11, "sva", lenSva, n)
+			if jobu != SVJLeftN {
+				n1 := n
+				if jobu == SVJLeftF {
+					n1 = m
+				}
+				c.length(12, "u", lenU, matLen(m, n1, ldu))
+			}
+			if jobv == SVJRightV {
+				c.length(14, "v", lenV, matLen(n, n, ldv))
+			}
+			iwork := 18
+			if complex {
+				c.length(18, "rwork", lenRwork, minRwork)
+				iwork = 20
+			}
+			c.length(iwork, "iwork", lenIwork, m+n)
+		}
+	}
+	return c.result()
+}
+
+// infError reports an infinite or NaN element met by the Jacobi SVD
+// routines in A, parameter param, as an illegal value of A, as xGESVJ
+// does.
+func infError(routine string, param int) error {
+	c := checker{routine: routine}
+	c.fail(param, "a", "must not contain Inf or NaN")
+	return c.result()
+}
+
+// svjSweeps is the maximum number of sweeps of gesvj, NSWEEP of xGESVJ.
+const svjSweeps = 30
+
+// svjNorms sets sva to the norms of the n columns of the m×n matrix A,
+// multiplied by the returned skl, 1/sqrt(m*n), if one of them would
+// overflow, and 1 otherwise. It reports false if A has an element that is
+// not finite.
+func svjNorms[T gen.Scalar, R gen.Float](m, n int, a []T, lda int, sva []R) (skl float64, ok bool) {
+	big := 1 / safmin[T]()
+	skl = 1 / math.Sqrt(float64(m)*float64(n))
+	noscale := true
+	for p := 0; p < n; p++ {
+		scl, ssq := lassq(m, a[p*lda:], 1, 0, 1)
+		if math.IsInf(scl, 0) || math.IsNaN(scl) || math.IsNaN(ssq) {
+			return 0, false
+		}
+		ssq = math.Sqrt(ssq)
+		if noscale && scl < big/ssq {
+			sva[p] = R(scl * ssq)
+			continue
+		}
+		if noscale {
+			noscale = false
+			for q := 0; q < p; q++ {
+				sva[q] = R(float64(sva[q]) * skl)
+			}
+		}
+		sva[p] = R(scl * (ssq * skl))
+	}
+	if noscale {
+		skl = 1
+	}
+	return skl, true
+}
+
+// gesvj computes the singular value decomposition of the m×n matrix A,
+// m >= n, by the one-sided Jacobi method of xGESVJ with row-cyclic sweeps
+// and de Rijk's pivoting, which brings the column of largest norm forward
+// before each row of rotations. The singular values are returned in sva in
+// decreasing order, and A is overwritten by the normalized left singular
+// vectors (jobu U) or by U*Σ (jobu N). The rotations form V (jobv V) or are
+// applied to the mv×n matrix V (jobv A). gesvj returns the number of
+// nonzero singular values and 0, or svjSweeps-1 if the columns were not
+// orthogonal after svjSweeps sweeps, and reports false if A has an element
+// that is not finite. work holds m elements.
+func gesvj[T gen.Scalar, R gen.Float](bl blas.BLAS, joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n int, a []T, lda int, sva []R, mv int, v []T, ldv int, work []T) (rank, info int, ok bool) {
+	if n == 0 {
+		return 0, 0, true
+	}
+	lsvec := jobu == SVJLeftU
+	mvl := 0
+	switch jobv {
+	case SVJRightV:
+		mvl = n
+		laset(uploAll, n, n, 0, 1, v, ldv)
+	case SVJRightA:
+		mvl = mv
+	}
+	switch joba {
+	case SVJUpper:
+		laset(blas.UploL, m-1, n, 0, 0, a[1:], lda)
+	case SVJLower:
+		laset(blas.UploU, m, n-1, 0, 0, a[lda:], lda)
+	}
+
+	epsilon := eps[T]()
+	sfmin := safmin[T]()
+	big := 1 / sfmin
+	small := sfmin / epsilon
+	rooteps := math.Sqrt(epsilon)
+	bigtheta := 1 / rooteps
+	ctol := float64(m)
+	if lsvec || mvl > 0 {
+		ctol = math.Sqrt(float64(m))
+	}
+	tol := ctol * epsilon
+
+	skl, ok := svjNorms(m, n, a, lda, sva)
+	if !ok {
+		return 0, 0, false
+	}
+	aapp, aaqq := 0.0, big
+	for _, s := range sva[:n] {
+		if s := float64(s); s != 0 {
+			aaqq = min(aaqq, s)
+		}
+		aapp = max(aapp, float64(s))
+	}
+	if aapp == 0 {
+		if lsvec {
+			laset(uploAll, m, n, 0, 1, a, lda)
+		}
+		return 0, 0, true
+	}
+	if n == 1 {
+		if lsvec {
+			lascl(uploAll, float64(sva[0]), skl, m, 1, a, lda)
+		}
+		sva[0] = R(float64(sva[0]) / skl)
+		return 1, 0, true
+	}
+
+	// Scale A so that the squares of its column norms neither underflow
+	// nor overflow, as far as their range allows.
+	sn := math.Sqrt(sfmin / epsilon)
+	temp1 := math.Sqrt(big / float64(n))
+	switch {
+	case aapp <= sn || aaqq >= temp1 || (sn <= aaqq && aapp <= temp1):
+		temp1 = min(big, temp1/aapp)
+	case aaqq <= sn && aapp <= temp1:
+		temp1 = min(sn/aaqq, big/(aapp*math.Sqrt(float64(n))))
+	case aaqq >= sn && aapp >= temp1:
+		temp1 = max(sn/aaqq, temp1/aapp)
+	case aaqq <= sn && aapp >= temp1:
+		temp1 = min(sn/aaqq, big/(math.Sqrt(float64(n))*aapp))
+	default:
+		temp1 = 1
+	}
+	if temp1 != 1 {
+		lascl(uploAll, 1, temp1, n, 1, sva, n)
+	}
+	skl *= temp1
+	if skl != 1 {
+		lascl(uploAll, 1, skl, m, n, a, lda)
+		skl = 1 / skl
+	}
+
+	emptsw := n * (n - 1) / 2
+	info = svjSweeps - 1
+	for sweep := 0; sweep < svjSweeps; sweep++ {
+		mxaapq, mxsinj := 0.0, 0.0
+		notrot := 0
+		for p := 0; p < n-1; p++ {
+			// Bring the column of largest norm forward and recompute its
+			// norm, which the rotations update only approximately.
+			if q := p + iamax(bl, n-p, sva[p:], 1); q != p {
+				swap(bl, m, a[p*lda:], 1, a[q*lda:], 1)
+				if mvl > 0 {
+					swap(bl, mvl, v[p*ldv:], 1, v[q*ldv:], 1)
+				}
+				sva[p], sva[q] = sva[q], sva[p]
+			}
+			aapp := nrm2(bl, m, a[p*lda:], 1)
+			if aapp == 0 {
+				sva[p] = 0
+				notrot += n - p - 1
+				continue
+			}
+			ap := a[p*lda : p*lda+m]
+			for q := p + 1; q < n; q++ {
+				aaqq := float64(sva[q])
+				if aaqq == 0 {
+					notrot++
+					continue
+				}
+				aq := a[q*lda : q*lda+m]
+
+				// Compute the cosine aapq of the angle between the columns,
+				// scaling one of them first if their product would
+				// overflow or underflow.
+				aapp0 := aapp
+				var aapq T
+				var rotok bool
+				if aaqq >= 1 {
+					rotok = small*aapp <= aaqq
+					if aapp < big/aaqq {
+						aapq = dotc(bl, m, ap, 1, aq, 1) / fromReal[T](aaqq) / fromReal[T](aapp)
+					} else {
+						copy(work[:m], ap)
+						lascl(uploAll, aapp, 1, m, 1, work, m)
+						aapq = dotc(bl, m, work, 1, aq, 1) / fromReal[T](aaqq)
+					}
+				} else {
+					rotok = aapp <= aaqq/small
+					if aapp > small/aaqq {
+						aapq = dotc(bl, m, ap, 1, aq, 1) / fromReal[T](aapp) / fromReal[T](aaqq)
+					} else {
+						copy(work[:m], aq)
+						lascl(uploAll, aaqq, 1, m, 1, work, m)
+						aapq = dotc(bl, m, ap, 1, work, 1) / fromReal[T](aapp)
+					}
+				}
+				aapq1 := abs(aapq)
+				mxaapq = max(mxaapq, aapq1)
+				if aapq1 <= tol {
+					notrot++
+					continue
+				}
+
+				ompq := aapq / fromReal[T](aapq1)
+				if rotok {
+					// Rotate the columns to make them orthogonal, and
+					// update their norms.
+					aqoap := aaqq / aapp
+					apoaq := aapp / aaqq
+					theta := 0.5 * math.Abs(aqoap-apoaq) / aapq1
+					var t, cs float64
+					if theta > bigtheta {
+						t, cs = 0.5/theta, 1
+					} else {
+						t = 1 / (theta + math.Sqrt(1+theta*theta))
+						cs = math.Sqrt(1 / (1 + t*t))
+					}
+					mxsinj = max(mxsinj, t*cs)
+					sva[q] = R(aaqq * math.Sqrt(max(0, 1-t*apoaq*aapq1)))
+					aapp *= math.Sqrt(max(0, 1+t*aqoap*aapq1))
+					s := conj(ompq) * fromReal[T](t*cs)
+					rot(bl, m, ap, 1, aq, 1, cs, s)
+					if mvl > 0 {
+						rot(bl, mvl, v[p*ldv:], 1, v[q*ldv:], 1, cs, s)
+					}
+				} else {
+					// The columns differ too much in norm for a rotation:
+					// orthogonalize column q against column p instead.
+					copy(work[:m], ap)
+					lascl(uploAll, aapp, 1, m, 1, work, m)
+					lascl(uploAll, aaqq, 1, m, 1, aq, m)
+					axpy(bl, m, -aapq, work, 1, aq, 1)
+					lascl(uploAll, 1, aaqq, m, 1, aq, m)
+					sva[q] = R(aaqq * math.Sqrt(max(0, 1-aapq1*aapq1)))
+					mxsinj = max(mxsinj, sfmin)
+				}
+
+				// Recompute the norms whose update suffered cancellation.
+				if r := float64(sva[q]) / aaqq; r*r <= rooteps {
+					sva[q] = R(nrm2(bl, m, aq, 1))
+				}
+				if aapp/aapp0 <= rooteps {
+					aapp = nrm2(bl, m, ap, 1)
+				}
+			}
+			sva[p] = R(aapp)
+		}
+		sva[n-1] = R(nrm2(bl, m, a[(n-1)*lda:], 1))
+
+		if (sweep > 0 && mxaapq < math.Sqrt(float64(n))*tol && float64(n)*mxaapq*mxsinj < tol) || notrot >= emptsw {
+			info = 0
+			break
+		}
+	}
+
+	// Sort the singular values in decreasing order, normalize the left
+	// and right singular vectors and undo the scaling.
+	for p := 0; p < n-1; p++ {
+		if q := p + iamax(bl, n-p, sva[p:], 1); q != p {
+			swap(bl, m, a[p*lda:], 1, a[q*lda:], 1)
+			if mvl > 0 {
+				swap(bl, mvl, v[p*ldv:], 1, v[q*ldv:], 1)
+			}
+			sva[p], sva[q] = sva[q], sva[p]
+		}
+	}
+	if lsvec {
+		for p := 0; p < n && sva[p] != 0; p++ {
+			lascl(uploAll, float64(sva[p]), 1, m, 1, a[p*lda:], lda)
+		}
+	}
+	if jobv == SVJRightV {
+		for p := 0; p < n; p++ {
+			rscal(bl, n, 1/nrm2(bl, n, v[p*ldv:], 1), v[p*ldv:], 1)
+		}
+	}
+	if skl != 1 {
+		lascl(uploAll, 1, skl, n, 1, sva, n)
+		if !lsvec {
+			lascl(uploAll, 1, skl, m, n, a, lda)
+		}
+	}
+	for rank < n && sva[rank] != 0 {
+		rank++
+	}
+	return rank, info, true
+}
+
+// gejsvWork returns the minimum and optimal lengths of the workspace work
+// of gejsv, and the length of its real workspace rwork. The real routines
+// keep rwork at the start of work.
+func gejsvWork(m, n int, joba JSVAccuracy, jobu SVJLeft, jobv SVJRight) (minWork, opt, lrwork int) {
+	if n == 0 {
+		return 1, 1, 0
+	}
+	lsvec, rsvec := jobu != SVJLeftN, jobv == SVJRightV
+	n1 := n
+	if jobu == SVJLeftF {
+		n1 = m
+	}
+	w := 2 * n
+	switch {
+	case lsvec && rsvec:
+		w = max(n*n+2*n, n1)
+	case lsvec:
+		w = max(2*n, n1)
+	case !rsvec && (joba == JSVE || joba == JSVG):
+		w = n*n + 2*n
+	}
+	minWork = n + w
+	_, ow := geqp3Work(m, n, true)
+	ow = max(ow, n+geqrfWork(n, n))
+	if rsvec {
+		ow = max(ow, ormWork(blas.SideL, n, n))
+	}
+	if lsvec {
+		ow = max(ow, ormWork(blas.SideL, m, n1))
+	}
+	if lsvec && rsvec {
+		ow = max(ow, n+n*n+max(geqrfWork(n, n), ormWork(blas.SideL, n, n)))
+	}
+	return minWork, max(minWork, n+ow), max(m, 2*n)
+}
+
+// gejsv computes the singular value decomposition of the m×n matrix A,
+// m >= n, as xGEJSV with JOBR = 'N', JOBT = 'N' and JOBP = 'P': the rows of
+// A are sorted by decreasing max-norm, the QR factorization with column
+// pivoting A*P = Q1*R1 is computed, R1 is truncated to its numerical rank
+// nr as joba says, and the SVD of R1 is computed by gesvj from the
+// triangular factor of R1**H = Q2*R2, which is much better conditioned.
+// The singular values are returned in sva in decreasing order, the left
+// singular vectors in U for jobu U or F and the right ones in V for jobv V,
+// and A is destroyed. gejsv returns nr, the scaled condition number sconda
+// of joba E and G or -1, and the info of gesvj, and reports false if A has
+// an element that is not finite. work holds lwork elements and rwork
+// lrwork, as returned by gejsvWork, and iwork m+n.
+func gejsv[T gen.Scalar, R gen.Float](bl blas.BLAS, joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []T, lda int, sva []R, u []T, ldu int, v []T, ldv int, work []T, lwork int, rwork []R, iwork []int) (rank int, sconda float64, info int, ok bool) {
+	sconda = -1
+	if n == 0 {
+		return 0, sconda, 0, true
+	}
+	lsvec, rsvec := jobu != SVJLeftN, jobv == SVJRightV
+	errest := joba == JSVE || joba == JSVG
+	n1 := n
+	if jobu == SVJLeftF {
+		n1 = m
+	}
+	epsilon := eps[T]()
+	sfmin := safmin[T]()
+	small := sfmin / epsilon
+	big := 1 / sfmin
+
+	scalem, ok := svjNorms(m, n, a, lda, sva)
+	if !ok {
+		return 0, sconda, 0, false
+	}
+	aapp, aaqq := 0.0, big
+	for _, s := range sva[:n] {
+		if s := float64(s); s != 0 {
+			aaqq = min(aaqq, s)
+		}
+		aapp = max(aapp, float64(s))
+	}
+	if aapp == 0 {
+		if lsvec {
+			laset(uploAll, m, n1, 0, 1, u, ldu)
+		}
+		if rsvec {
+			laset(uploAll, n, n, 0, 1, v, ldv)
+		}
+		return 0, sconda, 0, true
+	}
+	l2kill := aaqq <= sfmin
+	l2rank := joba == JSVR || l2kill
+	if n == 1 {
+		if lsvec {
+			lascl(uploAll, float64(sva[0]), scalem, m, 1, a, lda)
+			lacpy(uploAll, m, 1, a, lda, u, ldu)
+			if n1 > 1 {
+				// Complete the column to an orthonormal basis.
+				geqrf(bl, m, 1, u, ldu, work[:1], work[1:], lwork-1)
+				orgqr(bl, m, n1, 1, u, ldu, work[:1], work[1:], lwork-1)
+				copy(u[:m], a[:m])
+			}
+		}
+		if rsvec {
+			v[0] = 1
+		}
+		sva[0] = R(float64(sva[0]) / scalem)
+		if errest {
+			sconda = 1
+		}
+		return 1, sconda, 0, true
+	}
+
+	// Scale A so that its largest singular value is below sqrt(big), and
+	// flush the columns whose norms are below the working range to zero.
+	temp1 := math.Sqrt(big / float64(n))
+	lascl(uploAll, aapp, temp1, n, 1, sva, n)
+	if aaqq > aapp*sfmin {
+		aaqq = aaqq / aapp * temp1
+	} else {
+		aaqq = aaqq * temp1 / aapp
+	}
+	temp1 *= scalem
+	lascl(uploAll, aapp, temp1, m, n, a, lda)
+	uscal1, uscal2 := temp1, aapp
+	xsc := small
+	if l2kill {
+		xsc = math.Sqrt(sfmin)
+	}
+	if aaqq < xsc {
+		for p := 0; p < n; p++ {
+			if float64(sva[p]) < xsc {
+				laset(uploAll, m, 1, 0, 0, a[p*lda:], lda)
+				sva[p] = 0
+			}
+		}
+	}
+
+	// Sort the rows by decreasing max-norm and compute A*P = Q1*R1, which
+	// together have the effect of complete pivoting.
+	rowNrm := rwork[:m]
+	for i := 0; i < m; i++ {
+		rowNrm[i] = R(abs(a[i+iamax(bl, n, a[i:], lda)*lda]))
+	}
+	jpvt, ipiv := iwork[:n], iwork[n:n+m]
+	for p := 0; p < m-1; p++ {
+		q := p + iamax(bl, m-p, rowNrm[p:], 1)
+		ipiv[p] = q
+		rowNrm[p], rowNrm[q] = rowNrm[q], rowNrm[p]
+	}
+	laswp(n, a, lda, 0, m-2, ipiv, 1)
+	for p := range jpvt {
+		jpvt[p] = -1
+	}
+	tau1 := work[:n]
+	geqp3(bl, m, n, a, lda, jpvt, tau1, work[n:], lwork-n, rwork[:n], rwork[n:2*n])
+
+	// Determine the numerical rank nr from the diagonal of R1.
+	nr := 1
+	diag := func(p int) float64 { return abs(a[p+p*lda]) }
+	switch {
+	case joba == JSVA:
+		thr := math.Sqrt(float64(n)) * epsilon * diag(0)
+		for nr < n && diag(nr) >= thr {
+			nr++
+		}
+	case l2rank:
+		for nr < n && diag(nr) >= epsilon*diag(nr-1) && diag(nr) >= small && !(l2kill && diag(nr) < math.Sqrt(sfmin)) {
+			nr++
+		}
+	default:
+		for nr < n && diag(nr) >= small && !(l2kill && diag(nr) < math.Sqrt(sfmin)) {
+			nr++
+		}
+	}
+	if errest && nr == n {
+		switch {
+		case rsvec:
+			sconda = jsvCond(bl, n, a, lda, sva, jpvt, v, ldv, work[n:])
+		case lsvec:
+			sconda = jsvCond(bl, n, a, lda, sva, jpvt, u, ldu, work[n:])
+		default:
+			sconda = jsvCond(bl, n, a, lda, sva, jpvt, work[n:], n, work[n+n*n:])
+		}
+	}
+
+	switch {
+	case !lsvec && !rsvec:
+		// The singular values of R1 are those of R2**H, where
+		// R1**H = Q2*R2.
+		upperH(nr, n, a, lda, a, lda)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, a[lda:], lda)
+		geqrf(bl, n, nr, a, lda, work[:nr], work[n:], lwork-n)
+		upperH(nr, nr, a, lda, a, lda)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, a[lda:], lda)
+		_, info, _ = gesvj(bl, SVJLower, SVJLeftN, SVJRightN, nr, nr, a, lda, sva, 0, nil, 1, work[n:])
+	case !lsvec:
+		// With R1 = [L3 0]*Q3 and L3 = Q4*R3, the left singular vectors
+		// W of R3**H give the right singular vectors Q3**H*[W 0; 0 I] of
+		// R1.
+		laset(blas.UploL, nr-1, nr-1, 0, 0, a[1:], lda)
+		gelqf(bl, nr, n, a, lda, work[:nr], work[n:], lwork-n)
+		lacpy(blas.UploL, nr, nr, a, lda, v, ldv)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, v[ldv:], ldv)
+		geqrf(bl, nr, nr, v, ldv, work[n:n+nr], work[2*n:], lwork-2*n)
+		upperH(nr, nr, v, ldv, v, ldv)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, v[ldv:], ldv)
+		_, info, _ = gesvj(bl, SVJLower, SVJLeftU, SVJRightN, nr, nr, v, ldv, sva, 0, nil, 1, work[n:])
+		jsvExtend(n, n, nr, v, ldv)
+		ormlq(bl, blas.SideL, blas.TransC, n, n, nr, a, lda, work[:nr], v, ldv, work[n:], lwork-n)
+		for j := 0; j < n; j++ {
+			for p := 0; p < n; p++ {
+				a[jpvt[p]+j*lda] = v[p+j*ldv]
+			}
+		}
+		lacpy(uploAll, n, n, a, lda, v, ldv)
+	case !rsvec:
+		// The left singular vectors of R1 are those of R2**H, where
+		// R1**H = Q2*R2.
+		upperH(nr, n, a, lda, u, ldu)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, u[ldu:], ldu)
+		geqrf(bl, n, nr, u, ldu, work[n:n+nr], work[2*n:], lwork-2*n)
+		upperH(nr, nr, u, ldu, u, ldu)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, u[ldu:], ldu)
+		_, info, _ = gesvj(bl, SVJLower, SVJLeftU, SVJRightN, nr, nr, u, ldu, sva, 0, nil, 1, work[n:])
+		jsvLeft(bl, m, n, n1, nr, a, lda, tau1, u, ldu, ipiv, work[n:], lwork-n)
+	default:
+		// With R1**H = Q2*R2 and the SVD R2**H = W*Σ*Z**H computed with
+		// accumulated rotations, R1 = W*Σ*(Q2*[Z 0; 0 I])**H.
+		upperH(nr, n, a, lda, v, ldv)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, v[ldv:], ldv)
+		tau2, q2 := work[n:n+nr], work[2*n:2*n+n*nr]
+		w, lw := work[2*n+n*nr:], lwork-2*n-n*nr
+		geqrf(bl, n, nr, v, ldv, tau2, w, lw)
+		lacpy(blas.UploL, n, nr, v, ldv, q2, n)
+		upperH(nr, nr, v, ldv, u, ldu)
+		laset(blas.UploU, nr-1, nr-1, 0, 0, u[ldu:], ldu)
+		_, info, _ = gesvj(bl, SVJLower, SVJLeftU, SVJRightV, nr, nr, u, ldu, sva, 0, v, ldv, w)
+		jsvExtend(n, n, nr, v, ldv)
+		ormqr(bl, blas.SideL, blas.TransN, n, n, nr, q2, n, tau2, v, ldv, w, lw)
+
+		// Permute the rows of V by P and normalize its columns.
+		for j := 0; j < n; j++ {
+			vj := v[j*ldv : j*ldv+n]
+			for p, jp := range jpvt {
+				w[jp] = vj[p]
+			}
+			copy(vj, w[:n])
+			if nrm := nrm2(bl, n, vj, 1); nrm != 0 {
+				rscal(bl, n, 1/nrm, vj, 1)
+			}
+		}
+		jsvLeft(bl, m, n, n1, nr, a, lda, tau1, u, ldu, ipiv, work[n:], lwork-n)
+	}
+
+	// Undo the scaling.
+	lascl(uploAll, uscal1, uscal2, nr, 1, sva, n)
+	for p := nr; p < n; p++ {
+		sva[p] = 0
+	}
+	return nr, sconda, info, true
+}
+
+// jsvCond returns sqrt(||(S**H*S)**-1||_1) for the n×n triangular factor
+// R1 of gejsv with its columns scaled to unit norm, S = R1*D with
+// D(j,j) = 1/sva[jpvt[j]], computed with S**-1 in the n×n matrix X. work
+// holds 2*n elements.
+func jsvCond[T gen.Scalar, R gen.Float](bl blas.BLAS, n int, a []T, lda int, sva []R, jpvt []int, x []T, ldx int, work []T) float64 {
+	lacpy(blas.UploU, n, n, a, lda, x, ldx)
+	laset(blas.UploL, n-1, n-1, 0, 0, x[1:], ldx)
+	for j := 0; j < n; j++ {
+		rscal(bl, j+1, 1/float64(sva[jpvt[j]]), x[j*ldx:], 1)
+	}
+	if trtri(bl, blas.UploU, blas.DiagN, n, x, ldx) >= 0 {
+		return math.Inf(1)
+	}
+	// Column j of X*X**H is X times the conjugate of row j of X.
+	y, z := work[:n], work[n:2*n]
+	nrm := 0.0
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			y[i] = conj(x[j+i*ldx])
+		}
+		gemv(bl, blas.TransN, n, n, 1, x, ldx, y, 1, 0, z, 1)
+		sum := 0.0
+		for _, zi := range z {
+			sum += abs(zi)
+		}
+		nrm = max(nrm, sum)
+	}
+	return math.Sqrt(nrm)
+}
+
+// jsvLeft computes the left singular vectors of A in gejsv from those of
+// R1, held in the leading nr×nr block of U: it extends them to the first
+// n1 columns of [W 0; 0 I], multiplies them by Q1, undoes the row
+// interchanges ipiv and normalizes the columns. work holds lwork >= n1
+// elements.
+func jsvLeft[T gen.Scalar](bl blas.BLAS, m, n, n1, nr int, a []T, lda int, tau1, u []T, ldu int, ipiv []int, work []T, lwork int) {
+	jsvExtend(m, n1, nr, u, ldu)
+	ormqr(bl, blas.SideL, blas.TransN, m, n1, n, a, lda, tau1, u, ldu, work, lwork)
+	laswp(n1, u, ldu, 0, m-2, ipiv, -1)
+	for j := 0; j < n1; j++ {
+		if nrm := nrm2(bl, m, u[j*ldu:], 1); nrm != 0 {
+			rscal(bl, m, 1/nrm, u[j*ldu:], 1)
+		}
+	}
+}
+
+// jsvExtend sets the m×n matrix A, whose leading nr×nr block holds
+// singular vectors, to [A11 0; 0 I].
+func jsvExtend[T gen.Scalar](m, n, nr int, a []T, lda int) {
+	laset(uploAll, m-nr, nr, 0, 0, a[nr:], lda)
+	if nr < n {
+		laset(uploAll, nr, n-nr, 0, 0, a[nr*lda:], lda)
+		laset(uploAll, m-nr, n-nr, 0, 1, a[nr+nr*lda:], lda)
+	}
+}
+
+// upperH copies the conjugate transpose of the upper trapezoid of the m×n
+// matrix A, m <= n, to the lower trapezoid of the n×m matrix B, which may
+// be A itself.
+func upperH[T gen.Scalar](m, n int, a []T, lda int, b []T, ldb int) {
+	for i := 0; i < m; i++ {
+		for j := i; j < n; j++ {
+			b[j+i*ldb] = conj(a[i+j*lda])
+		}
+	}
+}
@@ -0,0 +1,723 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SLARFG generates an elementary reflector H of order n such that
+// H**H * [alpha; x] = [beta; 0] with beta real, represented as
+// H = I - tau*[1; v]*[1; v]**H. x is overwritten by v. tau is zero when x is
+// zero and alpha is real.
+func (impl Implementation) SLARFG(n int, alpha float32, x []float32, incX int) (beta, tau float32, err error) {
+	if err := checkLarfg("SLARFG", n, len(x), incX); err != nil {
+		return 0, 0, err
+	}
+	beta, tau = larfg(impl.bl(), n, alpha, x, incX)
+	return beta, tau, nil
+}
+
+// SLARF applies the elementary reflector H = I - tau*v*v**H to the m×n
+// matrix C from the left (side L) or the right (side R). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) SLARF(side blas.Side, m, n int, v []float32, incV int, tau float32, c []float32, ldc int, work []float32) error {
+	if err := checkLarf("SLARF", side, m, n, len(v), incV, len(c), ldc, len(work)); err != nil {
+		return err
+	}
+	larf(impl.bl(), side, m, n, v, incV, tau, c, ldc, work)
+	return nil
+}
+
+// SLARFT forms the k×k triangular factor T of a block reflector
+// H = I - V*T*V**H of order n made of k elementary reflectors. The vectors
+// of the reflectors are the columns (storev C) or rows (storev R) of V, and
+// H = H(0)*H(1)*...*H(k-1) for direct F, with T upper triangular, or
+// H = H(k-1)*...*H(1)*H(0) for direct B, with T lower triangular.
+func (impl Implementation) SLARFT(direct Direct, storev StoreV, n, k int, v []float32, ldv int, tau []float32, t []float32, ldt int) error {
+	if err := checkLarft("SLARFT", direct, storev, n, k, len(v), ldv, len(tau), len(t), ldt); err != nil {
+		return err
+	}
+	larft(impl.bl(), direct, storev, n, k, v, ldv, tau, t, ldt)
+	return nil
+}
+
+// SLARFB applies the block reflector H formed by SLARFT, or its
+// conjugate transpose, to the m×n matrix C from the left (side L) or the
+// right (side R). work is an nw×k matrix with leading dimension ldwork, where
+// nw is n for side L and m for side R.
+func (impl Implementation) SLARFB(side blas.Side, trans blas.Transpose, direct Direct, storev StoreV, m, n, k int, v []float32, ldv int, t []float32, ldt int, c []float32, ldc int, work []float32, ldwork int) error {
+	if err := checkLarfb("SLARFB", side, trans, direct, storev, m, n, k, len(v), ldv, len(t), ldt, len(c), ldc, len(work), ldwork, false); err != nil {
+		return err
+	}
+	larfb(impl.bl(), side, trans, direct, storev, m, n, k, v, ldv, t, ldt, c, ldc, work, ldwork)
+	return nil
+}
+
+// DLARFG generates an elementary reflector H of order n such that
+// H**H * [alpha; x] = [beta; 0] with beta real, represented as
+// H = I - tau*[1; v]*[1; v]**H. x is overwritten by v. tau is zero when x is
+// zero and alpha is real.
+func (impl Implementation) DLARFG(n int, alpha float64, x []float64, incX int) (beta, tau float64, err error) {
+	if err := checkLarfg("DLARFG", n, len(x), incX); err != nil {
+		return 0, 0, err
+	}
+	beta, tau = larfg(impl.bl(), n, alpha, x, incX)
+	return beta, tau, nil
+}
+
+// DLARF applies the elementary reflector H = I - tau*v*v**H to the m×n
+// matrix C from the left (side L) or the right (side R). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) DLARF(side blas.Side, m, n int, v []float64, incV int, tau float64, c []float64, ldc int, work []float64) error {
+	if err := checkLarf("DLARF", side, m, n, len(v), incV, len(c), ldc, len(work)); err != nil {
+		return err
+	}
+	larf(impl.bl(), side, m, n, v, incV, tau, c, ldc, work)
+	return nil
+}
+
+// DLARFT forms the k×k triangular factor T of a block reflector
+// H = I - V*T*V**H of order n made of k elementary reflectors. The vectors
+// of the reflectors are the columns (storev C) or rows (storev R) of V, and
+// H = H(0)*H(1)*...*H(k-1) for direct F, with T upper triangular, or
+// H = H(k-1)*...*H(1)*H(0) for direct B, with T lower triangular.
+func (impl Implementation) DLARFT(direct Direct, storev StoreV, n, k int, v []float64, ldv int, tau []float64, t []float64, ldt int) error {
+	if err := checkLarft("DLARFT", direct, storev, n, k, len(v), ldv, len(tau), len(t), ldt); err != nil {
+		return err
+	}
+	larft(impl.bl(), direct, storev, n, k, v, ldv, tau, t, ldt)
+	return nil
+}
+
+// DLARFB applies the block reflector H formed by DLARFT, or its
+// conjugate transpose, to the m×n matrix C from the left (side L) or the
+// right (side R). work is an nw×k matrix with leading dimension ldwork, where
+// nw is n for side L and m for side R.
+func (impl Implementation) DLARFB(side blas.Side, trans blas.Transpose, direct Direct, storev StoreV, m, n, k int, v []float64, ldv int, t []float64, ldt int, c []float64, ldc int, work []float64, ldwork int) error {
+	if err := checkLarfb("DLARFB", side, trans, direct, storev, m, n, k, len(v), ldv, len(t), ldt, len(c), ldc, len(work), ldwork, false); err != nil {
+		return err
+	}
+	larfb(impl.bl(), side, trans, direct, storev, m, n, k, v, ldv, t, ldt, c, ldc, work, ldwork)
+	return nil
+}
+
+// CLARFG generates an elementary reflector H of order n such that
+// H**H * [alpha; x] = [beta; 0] with beta real, represented as
+// H = I - tau*[1; v]*[1; v]**H. x is overwritten by v. tau is zero when x is
+// zero and alpha is real.
+func (impl Implementation) CLARFG(n int, alpha complex64, x []complex64, incX int) (beta, tau complex64, err error) {
+	if err := checkLarfg("CLARFG", n, len(x), incX); err != nil {
+		return 0, 0, err
+	}
+	beta, tau = larfg(impl.bl(), n, alpha, x, incX)
+	return beta, tau, nil
+}
+
+// CLARF applies the elementary reflector H = I - tau*v*v**H to the m×n
+// matrix C from the left (side L) or the right (side R). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) CLARF(side blas.Side, m, n int, v []complex64, incV int, tau complex64, c []complex64, ldc int, work []complex64) error {
+	if err := checkLarf("CLARF", side, m, n, len(v), incV, len(c), ldc, len(work)); err != nil {
+		return err
+	}
+	larf(impl.bl(), side, m, n, v, incV, tau, c, ldc, work)
+	return nil
+}
+
+// CLARFT forms the k×k triangular factor T of a block reflector
+// H = I - V*T*V**H of order n made of k elementary reflectors. The vectors
+// of the reflectors are the columns (storev C) or rows (storev R) of V, and
+// H = H(0)*H(1)*...*H(k-1) for direct F, with T upper triangular, or
+// H = H(k-1)*...*H(1)*H(0) for direct B, with T lower triangular.
+func (impl Implementation) CLARFT(direct Direct, storev StoreV, n, k int, v []complex64, ldv int, tau []complex64, t []complex64, ldt int) error {
+	if err := checkLarft("CLARFT", direct, storev, n, k, len(v), ldv, len(tau), len(t), ldt); err != nil {
+		return err
+	}
+	larft(impl.bl(), direct, storev, n, k, v, ldv, tau, t, ldt)
+	return nil
+}
+
+// CLARFB applies the block reflector H formed by CLARFT, or its
+// conjugate transpose, to the m×n matrix C from the left (side L) or the
+// right (side R). work is an nw×k matrix with leading dimension ldwork, where
+// nw is n for side L and m for side R.
+func (impl Implementation) CLARFB(side blas.Side, trans blas.Transpose, direct Direct, storev StoreV, m, n, k int, v []complex64, ldv int, t []complex64, ldt int, c []complex64, ldc int, work []complex64, ldwork int) error {
+	if err := checkLarfb("CLARFB", side, trans, direct, storev, m, n, k, len(v), ldv, len(t), ldt, len(c), ldc, len(work), ldwork, true); err != nil {
+		return err
+	}
+	larfb(impl.bl(), side, trans, direct, storev, m, n, k, v, ldv, t, ldt, c, ldc, work, ldwork)
+	return nil
+}
+
+// ZLARFG generates an elementary reflector H of order n such that
+// H**H * [alpha; x] = [beta; 0] with beta real, represented as
+// H = I - tau*[1; v]*[1; v]**H. x is overwritten by v. tau is zero when x is
+// zero and alpha is real.
+func (impl Implementation) ZLARFG(n int, alpha complex128, x []complex128, incX int) (beta, tau complex128, err error) {
+	if err := checkLarfg("ZLARFG", n, len(x), incX); err != nil {
+		return 0, 0, err
+	}
+	beta, tau = larfg(impl.bl(), n, alpha, x, incX)
+	return beta, tau, nil
+}
+
+// ZLARF applies the elementary reflector H = I - tau*v*v**H to the m×n
+// matrix C from the left (side L) or the right (side R). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) ZLARF(side blas.Side, m, n int, v []complex128, incV int, tau complex128, c []complex128, ldc int, work []complex128) error {
+	if err := checkLarf("ZLARF", side, m, n, len(v), incV, len(c), ldc, len(work)); err != nil {
+		return err
+	}
+	larf(impl.bl(), side, m, n, v, incV, tau, c, ldc, work)
+	return nil
+}
+
+// ZLARFT forms the k×k triangular factor T of a block reflector
+// H = I - V*T*V**H of order n made of k elementary reflectors. The vectors
+// of the reflectors are the columns (storev C) or rows (storev R) of V, and
+// H = H(0)*H(1)*...*H(k-1) for direct F, with T upper triangular, or
+// H = H(k-1)*...*H(1)*H(0) for direct B, with T lower triangular.
+func (impl Implementation) ZLARFT(direct Direct, storev StoreV, n, k int, v []complex128, ldv int, tau []complex128, t []complex128, ldt int) error {
+	if err := checkLarft("ZLARFT", direct, storev, n, k, len(v), ldv, len(tau), len(t), ldt); err != nil {
+		return err
+	}
+	larft(impl.bl(), direct, storev, n, k, v, ldv, tau, t, ldt)
+	return nil
+}
+
+// ZLARFB applies the block reflector H formed by ZLARFT, or its
+// conjugate transpose, to the m×n matrix C from the left (side L) or the
+// right (side R). work is an nw×k matrix with leading dimension ldwork, where
+// nw is n for side L and m for side R.
+func (impl Implementation) ZLARFB(side blas.Side, trans blas.Transpose, direct Direct, storev StoreV, m, n, k int, v []complex128, ldv int, t []complex128, ldt int, c []complex128, ldc int, work []complex128, ldwork int) error {
+	if err := checkLarfb("ZLARFB", side, trans, direct, storev, m, n, k, len(v), ldv, len(t), ldt, len(c), ldc, len(work), ldwork, true); err != nil {
+		return err
+	}
+	larfb(impl.bl(), side, trans, direct, storev, m, n, k, v, ldv, t, ldt, c, ldc, work, ldwork)
+	return nil
+}
+
+// checkLarfg checks the LARFG routines.
+func checkLarfg(routine string, n, lenX, incX int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	if incX <= 0 {
+		c.fail(4, "incX", "must be positive")
+	}
+	if c.ok() {
+		c.length(3, "x", lenX, vecLen(n-1, incX))
+	}
+	return c.result()
+}
+
+// checkLarf checks the LARF routines.
+func checkLarf(routine string, side blas.Side, m, n, lenV, incV, lenC, ldc, lenWork int) error {
+	c := checker{routine: routine}
+	c.side(1, side)
+	c.nonNeg(2, "m", m)
+	c.nonNeg(3, "n", n)
+	if incV == 0 {
+		c.fail(5, "incV", "must not be zero")
+	}
+	c.ld(8, "ldc", ldc, m, "m")
+	if c.ok() {
+		nv, nw := m, n
+		if side == blas.SideR {
+			nv, nw = n, m
+		}
+		c.length(4, "v", lenV, vecLen(nv, incV))
+		c.length(7, "c", lenC, matLen(m, n, ldc))
+		c.length(9, "work", lenWork, nw)
+	}
+	return c.result()
+}
+
+// checkLarft checks the LARFT routines.
+func checkLarft(routine string, direct Direct, storev StoreV, n, k, lenV, ldv, lenTau, lenT, ldt int) error {
+	c := checker{routine: routine}
+	c.direct(1, direct)
+	c.storev(2, storev)
+	c.nonNeg(3, "n", n)
+	c.atLeast(4, "k", k, 1, "1")
+	if storev == StoreVC {
+		c.ld(6, "ldv", ldv, n, "n")
+	} else {
+		c.ld(6, "ldv", ldv, k, "k")
+	}
+	c.ld(9, "ldt", ldt, k, "k")
+	if c.ok() {
+		if storev == StoreVC {
+			c.length(5, "v", lenV, matLen(n, k, ldv))
+		} else {
+			c.length(5, "v", lenV, matLen(k, n, ldv))
+		}
+		c.length(7, "tau", lenTau, k)
+		c.length(8, "t", lenT, matLen(k, k, ldt))
+	}
+	return c.result()
+}
+
+// checkLarfb checks the LARFB routines.
+func checkLarfb(routine string, side blas.Side, trans blas.Transpose, direct Direct, storev StoreV, m, n, k, lenV, ldv, lenT, ldt, lenC, ldc, lenWork, ldwork int, complex bool) error {
+	c := checker{routine: routine}
+	c.side(1, side)
+	c.transQ(2, trans, complex)
+	c.direct(3, direct)
+	c.storev(4, storev)
+	c.nonNeg(5, "m", m)
+	c.nonNeg(6, "n", n)
+	c.nonNeg(7, "k", k)
+	nq, nw := m, n
+	if side == blas.SideR {
+		nq, nw = n, m
+	}
+	if storev == StoreVC {
+		c.ld(9, "ldv", ldv, nq, "nq")
+	} else {
+		c.ld(9, "ldv", ldv, k, "k")
+	}
+	c.ld(11, "ldt", ldt, k, "k")
+	c.ld(13, "ldc", ldc, m, "m")
+	c.ld(15, "ldwork", ldwork, nw, "nw")
+	if c.ok() {
+		if storev == StoreVC {
+			c.length(8, "v", lenV, matLen(nq, k, ldv))
+		} else {
+			c.length(8, "v", lenV, matLen(k, nq, ldv))
+		}
+		c.length(10, "t", lenT, matLen(k, k, ldt))
+		c.length(12, "c", lenC, matLen(m, n, ldc))
+		c.length(14, "work", lenWork, matLen(nw, k, ldwork))
+	}
+	return c.result()
+}
+
+// lapy3 returns sqrt(x**2+y**2+z**2) without unnecessary overflow.
+func lapy3(x, y, z float64) float64 {
+	x, y, z = math.Abs(x), math.Abs(y), math.Abs(z)
+	w := max(x, y, z)
+	if w == 0 {
+		return x + y + z
+	}
+	return w * math.Sqrt((x/w)*(x/w)+(y/w)*(y/w)+(z/w)*(z/w))
+}
+
+// larfg generates an elementary reflector H of order n such that
+// H**H * [alpha; x] = [beta; 0] and H**H*H = I, where beta is real. H is
+// represented as H = I - tau*[1; v]*[1; v]**H, and x is overwritten by v.
+// tau is zero, and H the identity, when x is zero and alpha is real.
+func larfg[T gen.Scalar](bl blas.BLAS, n int, alpha T, x []T, incX int) (beta, tau T) {
+	if n <= 0 {
+		return alpha, 0
+	}
+	xnorm := nrm2(bl, n-1, x, incX)
+	alphr, alphi := re(alpha), im(alpha)
+	if xnorm == 0 && alphi == 0 {
+		return alpha, 0
+	}
+	b := -math.Copysign(lapy3(alphr, alphi, xnorm), alphr)
+	sfmin := safmin[T]() / eps[T]()
+	knt := 0
+	if math.Abs(b) < sfmin {
+		// xnorm and beta may be inaccurate; scale x and recompute them.
+		rsfmn := 1 / sfmin
+		for {
+			knt++
+			rscal(bl, n-1, rsfmn, x, incX)
+			b *= rsfmn
+			alphi *= rsfmn
+			alphr *= rsfmn
+			if math.Abs(b) >= sfmin || knt >= 20 {
+				break
+			}
+		}
+		xnorm = nrm2(bl, n-1, x, incX)
+		b = -math.Copysign(lapy3(alphr, alphi, xnorm), alphr)
+	}
+	tau = fromParts[T]((b-alphr)/b, -alphi/b)
+	scal(bl, n-1, 1/(fromParts[T](alphr, alphi)-fromReal[T](b)), x, incX)
+	for j := 0; j < knt; j++ {
+		b *= sfmin
+	}
+	return fromReal[T](b), tau
+}
+
+// larf applies the elementary reflector H = I - tau*v*v**H to the m×n
+// matrix C from the left (side L) or the right (side R). work must hold n
+// elements for side L and m for side R. Trailing zeros of v and the rows or
+// columns of C they would touch are skipped.
+func larf[T gen.Scalar](bl blas.BLAS, side blas.Side, m, n int, v []T, incV int, tau T, c []T, ldc int, work []T) {
+	if tau == 0 {
+		return
+	}
+	lastv := m
+	if side == blas.SideR {
+		lastv = n
+	}
+	i := 0
+	if incV > 0 {
+		i = (lastv - 1) * incV
+	}
+	for lastv > 0 && v[i] == 0 {
+		lastv--
+		i -= incV
+	}
+	if lastv == 0 {
+		return
+	}
+	if side == blas.SideL {
+		// w = C**H*v, C -= tau*v*w**H.
+		lastc := ilalc(lastv, n, c, ldc)
+		if lastc == 0 {
+			return
+		}
+		gemv(bl, blas.TransC, lastv, lastc, 1, c, ldc, v, incV, 0, work, 1)
+		gerc(bl, lastv, lastc, -tau, v, incV, work, 1, c, ldc)
+		return
+	}
+	// w = C*v, C -= tau*w*v**H.
+	lastc := ilalr(m, lastv, c, ldc)
+	if lastc == 0 {
+		return
+	}
+	gemv(bl, blas.TransN, lastc, lastv, 1, c, ldc, v, incV, 0, work, 1)
+	gerc(bl, lastc, lastv, -tau, work, 1, v, incV, c, ldc)
+}
+
+// ilalr returns the number of leading rows of the m×n matrix A that
+// contain its non-zero elements, as ILADLR.
+func ilalr[T gen.Scalar](m, n int, a []T, lda int) int {
+	if m == 0 || n == 0 {
+		return 0
+	}
+	if a[m-1] != 0 || a[m-1+(n-1)*lda] != 0 {
+		return m
+	}
+	last := 0
+	for j := 0; j < n; j++ {
+		i := m
+		for i > 0 && a[i-1+j*lda] == 0 {
+			i--
+		}
+		last = max(last, i)
+	}
+	return last
+}
+
+// ilalc returns the number of leading columns of the m×n matrix A that
+// contain its non-zero elements, as ILADLC.
+func ilalc[T gen.Scalar](m, n int, a []T, lda int) int {
+	if m == 0 || n == 0 {
+		return 0
+	}
+	if a[(n-1)*lda] != 0 || a[m-1+(n-1)*lda] != 0 {
+		return n
+	}
+	for j := n; j > 0; j-- {
+		for _, v := range a[(j-1)*lda : (j-1)*lda+m] {
+			if v != 0 {
+				return j
+			}
+		}
+	}
+	return 0
+}
+
+// larft forms the k×k triangular factor T of the block reflector H of order
+// n defined by k elementary reflectors, so that H = I - V*T*V**H. T is upper
+// triangular for direct F and lower triangular for direct B. V holds the
+// vectors of the reflectors in its columns (storev C) or rows (storev R)
+// with the unit elements implied, as left by geqrf and the other
+// factorizations.
+func larft[T gen.Scalar](bl blas.BLAS, direct Direct, storev StoreV, n, k int, v []T, ldv int, tau []T, t []T, ldt int) {
+	if n == 0 {
+		return
+	}
+	colwise := storev == StoreVC
+	if direct == DirectF {
+		prevlastv := n - 1
+		for i := 0; i < k; i++ {
+			prevlastv = max(i, prevlastv)
+			if tau[i] == 0 {
+				for j := 0; j <= i; j++ {
+					t[j+i*ldt] = 0
+				}
+				continue
+			}
+			var lastv int
+			if colwise {
+				// Skip the trailing zeros of V(:,i).
+				for lastv = n - 1; lastv > i; lastv-- {
+					if v[lastv+i*ldv] != 0 {
+						break
+					}
+				}
+				for j := 0; j < i; j++ {
+					t[j+i*ldt] = -tau[i] * conj(v[i+j*ldv])
+				}
+				// T(0:i,i) -= tau(i) * V(i+1:j,0:i)**H * V(i+1:j,i).
+				if j := min(lastv, prevlastv); j > i {
+					gemv(bl, blas.TransC, j-i, i, -tau[i], v[i+1:], ldv, v[i+1+i*ldv:], 1, 1, t[i*ldt:], 1)
+				}
+			} else {
+				// Skip the trailing zeros of V(i,:).
+				for lastv = n - 1; lastv > i; lastv-- {
+					if v[i+lastv*ldv] != 0 {
+						break
+					}
+				}
+				for j := 0; j < i; j++ {
+					t[j+i*ldt] = -tau[i] * v[j+i*ldv]
+				}
+				// T(0:i,i) -= tau(i) * V(0:i,i+1:j) * V(i,i+1:j)**H.
+				if j := min(lastv, prevlastv); j > i {
+					gemm(bl, blas.TransN, blas.TransC, i, 1, j-i, -tau[i], v[(i+1)*ldv:], ldv, v[i+(i+1)*ldv:], ldv, 1, t[i*ldt:], ldt)
+				}
+			}
+			// T(0:i,i) = T(0:i,0:i) * T(0:i,i).
+			trmv(bl, blas.UploU, blas.TransN, blas.DiagN, i, t, ldt, t[i*ldt:], 1)
+			t[i+i*ldt] = tau[i]
+			if i > 0 {
+				prevlastv = max(prevlastv, lastv)
+			} else {
+				prevlastv = lastv
+			}
+		}
+		return
+	}
+	prevlastv := 0
+	for i := k - 1; i >= 0; i-- {
+		if tau[i] == 0 {
+			for j := i; j < k; j++ {
+				t[j+i*ldt] = 0
+			}
+			continue
+		}
+		if i < k-1 {
+			// Row n-k+i of V(:,i) or column n-k+i of V(i,:) is the unit
+			// element.
+			ni := n - k + i
+			var lastv int
+			if colwise {
+				// Skip the leading zeros of V(:,i).
+				for lastv = 0; lastv < ni; lastv++ {
+					if v[lastv+i*ldv] != 0 {
+						break
+					}
+				}
+				for j := i + 1; j < k; j++ {
+					t[j+i*ldt] = -tau[i] * conj(v[ni+j*ldv])
+				}
+				// T(i+1:k,i) -= tau(i) * V(j:ni,i+1:k)**H * V(j:ni,i).
+				if j := max(lastv, prevlastv); j < ni {
+					gemv(bl, blas.TransC, ni-j, k-i-1, -tau[i], v[j+(i+1)*ldv:], ldv, v[j+i*ldv:], 1, 1, t[i+1+i*ldt:], 1)
+				}
+			} else {
+				// Skip the leading zeros of V(i,:).
+				for lastv = 0; lastv < ni; lastv++ {
+					if v[i+lastv*ldv] != 0 {
+						break
+					}
+				}
+				for j := i + 1; j < k; j++ {
+					t[j+i*ldt] = -tau[i] * v[j+ni*ldv]
+				}
+				// T(i+1:k,i) -= tau(i) * V(i+1:k,j:ni) * V(i,j:ni)**H.
+				if j := max(lastv, prevlastv); j < ni {
+					gemm(bl, blas.TransN, blas.TransC, k-i-1, 1, ni-j, -tau[i], v[i+1+j*ldv:], ldv, v[i+j*ldv:], ldv, 1, t[i+1+i*ldt:], ldt)
+				}
+			}
+			// T(i+1:k,i) = T(i+1:k,i+1:k) * T(i+1:k,i).
+			trmv(bl, blas.UploL, blas.TransN, blas.DiagN, k-i-1, t[i+1+(i+1)*ldt:], ldt, t[i+1+i*ldt:], 1)
+			if i > 0 {
+				prevlastv = min(prevlastv, lastv)
+			} else {
+				prevlastv = lastv
+			}
+		}
+		t[i+i*ldt] = tau[i]
+	}
+}
+
+// larfb applies the block reflector H, or H**H when trans is not N, to the
+// m×n matrix C from the left (side L) or the right (side R). H is given by
+// the vectors V of its k reflectors and the triangular factor T formed by
+// larft. work is an nw×k matrix with leading dimension ldwork, where nw is n
+// for side L and m for side R.
+func larfb[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, direct Direct, storev StoreV, m, n, k int, v []T, ldv int, t []T, ldt int, c []T, ldc int, work []T, ldwork int) {
+	if m <= 0 || n <= 0 {
+		return
+	}
+	const (
+		nt = blas.TransN
+		ct = blas.TransC
+		L  = blas.SideL
+		R  = blas.SideR
+		U  = blas.UploU
+		Lo = blas.UploL
+		un = blas.DiagU
+		nn = blas.DiagN
+	)
+	if trans != nt {
+		trans = ct
+	}
+	transt := ct
+	if trans != nt {
+		transt = nt
+	}
+	// The work matrix W is formed from the k rows (side L) or columns
+	// (side R) of C that meet the triangular part of V, starting at row or
+	// column c0, and conjugated for side L.
+	loadW := func(c0 int) {
+		for j := 0; j < k; j++ {
+			if side == L {
+				copyVec(bl, n, c[c0+j:], ldc, work[j*ldwork:], 1)
+				lacgv(n, work[j*ldwork:], 1)
+			} else {
+				copyVec(bl, m, c[(c0+j)*ldc:], 1, work[j*ldwork:], 1)
+			}
+		}
+	}
+	// storeW subtracts W**H (side L) or W (side R) from the rows or columns
+	// of C read by loadW.
+	storeW := func(c0 int) {
+		for j := 0; j < k; j++ {
+			if side == L {
+				for i := 0; i < n; i++ {
+					c[c0+j+i*ldc] -= conj(work[i+j*ldwork])
+				}
+			} else {
+				for i := 0; i < m; i++ {
+					c[i+(c0+j)*ldc] -= work[i+j*ldwork]
+				}
+			}
+		}
+	}
+	if storev == StoreVC {
+		if direct == DirectF {
+			// V = [V1; V2] with V1 unit lower triangular.
+			if side == L {
+				// W = C**H*V = C1**H*V1 + C2**H*V2, W = W*op(T)**H,
+				// C -= V*W**H.
+				loadW(0)
+				trmm(bl, R, Lo, nt, un, n, k, 1, v, ldv, work, ldwork)
+				if m > k {
+					gemm(bl, ct, nt, n, k, m-k, 1, c[k:], ldc, v[k:], ldv, 1, work, ldwork)
+				}
+				trmm(bl, R, U, transt, nn, n, k, 1, t, ldt, work, ldwork)
+				if m > k {
+					gemm(bl, nt, ct, m-k, n, k, -1, v[k:], ldv, work, ldwork, 1, c[k:], ldc)
+				}
+				trmm(bl, R, Lo, ct, un, n, k, 1, v, ldv, work, ldwork)
+				storeW(0)
+				return
+			}
+			// W = C*V = C1*V1 + C2*V2, W = W*op(T), C -= W*V**H.
+			loadW(0)
+			trmm(bl, R, Lo, nt, un, m, k, 1, v, ldv, work, ldwork)
+			if n > k {
+				gemm(bl, nt, nt, m, k, n-k, 1, c[k*ldc:], ldc, v[k:], ldv, 1, work, ldwork)
+			}
+			trmm(bl, R, U, trans, nn, m, k, 1, t, ldt, work, ldwork)
+			if n > k {
+				gemm(bl, nt, ct, m, n-k, k, -1, work, ldwork, v[k:], ldv, 1, c[k*ldc:], ldc)
+			}
+			trmm(bl, R, Lo, ct, un, m, k, 1, v, ldv, work, ldwork)
+			storeW(0)
+			return
+		}
+		// V = [V1; V2] with V2 unit upper triangular.
+		if side == L {
+			// W = C**H*V = C1**H*V1 + C2**H*V2, W = W*op(T)**H,
+			// C -= V*W**H.
+			loadW(m - k)
+			trmm(bl, R, U, nt, un, n, k, 1, v[m-k:], ldv, work, ldwork)
+			if m > k {
+				gemm(bl, ct, nt, n, k, m-k, 1, c, ldc, v, ldv, 1, work, ldwork)
+			}
+			trmm(bl, R, Lo, transt, nn, n, k, 1, t, ldt, work, ldwork)
+			if m > k {
+				gemm(bl, nt, ct, m-k, n, k, -1, v, ldv, work, ldwork, 1, c, ldc)
+			}
+			trmm(bl, R, U, ct, un, n, k, 1, v[m-k:], ldv, work, ldwork)
+			storeW(m - k)
+			return
+		}
+		// W = C*V = C1*V1 + C2*V2, W = W*op(T), C -= W*V**H.
+		loadW(n - k)
+		trmm(bl, R, U, nt, un, m, k, 1, v[n-k:], ldv, work, ldwork)
+		if n > k {
+			gemm(bl, nt, nt, m, k, n-k, 1, c, ldc, v, ldv, 1, work, ldwork)
+		}
+		trmm(bl, R, Lo, trans, nn, m, k, 1, t, ldt, work, ldwork)
+		if n > k {
+			gemm(bl, nt, ct, m, n-k, k, -1, work, ldwork, v, ldv, 1, c, ldc)
+		}
+		trmm(bl, R, U, ct, un, m, k, 1, v[n-k:], ldv, work, ldwork)
+		storeW(n - k)
+		return
+	}
+	if direct == DirectF {
+		// V = [V1 V2] with V1 unit upper triangular.
+		if side == L {
+			// W = C**H*V**H = C1**H*V1**H + C2**H*V2**H,
+			// W = W*op(T)**H, C -= V**H*W**H.
+			loadW(0)
+			trmm(bl, R, U, ct, un, n, k, 1, v, ldv, work, ldwork)
+			if m > k {
+				gemm(bl, ct, ct, n, k, m-k, 1, c[k:], ldc, v[k*ldv:], ldv, 1, work, ldwork)
+			}
+			trmm(bl, R, U, transt, nn, n, k, 1, t, ldt, work, ldwork)
+			if m > k {
+				gemm(bl, ct, ct, m-k, n, k, -1, v[k*ldv:], ldv, work, ldwork, 1, c[k:], ldc)
+			}
+			trmm(bl, R, U, nt, un, n, k, 1, v, ldv, work, ldwork)
+			storeW(0)
+			return
+		}
+		// W = C*V**H = C1*V1**H + C2*V2**H, W = W*op(T), C -= W*V.
+		loadW(0)
+		trmm(bl, R, U, ct, un, m, k, 1, v, ldv, work, ldwork)
+		if n > k {
+			gemm(bl, nt, ct, m, k, n-k, 1, c[k*ldc:], ldc, v[k*ldv:], ldv, 1, work, ldwork)
+		}
+		trmm(bl, R, U, trans, nn, m, k, 1, t, ldt, work, ldwork)
+		if n > k {
+			gemm(bl, nt, nt, m, n-k, k, -1, work, ldwork, v[k*ldv:], ldv, 1, c[k*ldc:], ldc)
+		}
+		trmm(bl, R, U, nt, un, m, k, 1, v, ldv, work, ldwork)
+		storeW(0)
+		return
+	}
+	// V = [V1 V2] with V2 unit lower triangular.
+	if side == L {
+		// W = C**H*V**H = C1**H*V1**H + C2**H*V2**H, W = W*op(T)**H,
+		// C -= V**H*W**H.
+		loadW(m - k)
+		trmm(bl, R, Lo, ct, un, n, k, 1, v[(m-k)*ldv:], ldv, work, ldwork)
+		if m > k {
+			gemm(bl, ct, ct, n, k, m-k, 1, c, ldc, v, ldv, 1, work, ldwork)
+		}
+		trmm(bl, R, Lo, transt, nn, n, k, 1, t, ldt, work, ldwork)
+		if m > k {
+			gemm(bl, ct, ct, m-k, n, k, -1, v, ldv, work, ldwork, 1, c, ldc)
+		}
+		trmm(bl, R, Lo, nt, un, n, k, 1, v[(m-k)*ldv:], ldv, work, ldwork)
+		storeW(m - k)
+		return
+	}
+	// W = C*V**H = C1*V1**H + C2*V2**H, W = W*op(T), C -= W*V.
+	loadW(n - k)
+	trmm(bl, R, Lo, ct, un, m, k, 1, v[(n-k)*ldv:], ldv, work, ldwork)
+	if n > k {
+		gemm(bl, nt, ct, m, k, n-k, 1, c, ldc, v, ldv, 1, work, ldwork)
+	}
+	trmm(bl, R, Lo, trans, nn, m, k, 1, t, ldt, work, ldwork)
+	if n > k {
+		gemm(bl, nt, nt, m, n-k, k, -1, work, ldwork, v, ldv, 1, c, ldc)
+	}
+	trmm(bl, R, Lo, nt, un, m, k, 1, v[(n-k)*ldv:], ldv, work, ldwork)
+	storeW(n - k)
+}
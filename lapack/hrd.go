@@ -0,0 +1,417 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEHD2 reduces the n×n matrix A to upper Hessenberg form H = Q**T*A*Q by
+// an orthogonal similarity transformation, one column at a time. A is assumed
+// to be upper triangular in rows and columns 0 to ilo-1 and ihi+1 to n-1,
+// as left by SGEBAL, and only its rows and columns ilo through ihi are
+// reduced. H overwrites the upper triangle and first subdiagonal of A. Q is
+// represented as the product H(ilo)*...*H(ihi-1) of elementary reflectors
+// H(i) = I - tau[i]*v*v**H, where v[:i+1] = 0, v[i+1] = 1, v[ihi+1:] = 0
+// and v[i+2:ihi+1] is stored below the subdiagonal in column i of A. tau
+// holds n-1 elements and work n.
+func (impl Implementation) SGEHD2(n, ilo, ihi int, a []float32, lda int, tau, work []float32) error {
+	if err := checkGehrd("SGEHD2", n, ilo, ihi, len(a), lda, len(tau), len(work), noLwork); err != nil {
+		return err
+	}
+	gehd2(impl.bl(), n, ilo, ihi, a, lda, tau, work)
+	return nil
+}
+
+// SGEHRD computes the reduction of SGEHD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with matrix-matrix
+// products. tau[:ilo] and tau[ihi:] are set to zero. work holds
+// lwork >= max(1,n) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) SGEHRD(n, ilo, ihi int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkGehrd("SGEHRD", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	gehrd(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORGHR overwrites the n×n matrix A, which holds the reflectors left by
+// SGEHRD with the same ilo and ihi, with the orthogonal matrix Q of the
+// reduction. work holds lwork >= max(1,ihi-ilo) elements; the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGHR(n, ilo, ihi int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrghr("SORGHR", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orghr(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DGEHD2 reduces the n×n matrix A to upper Hessenberg form H = Q**T*A*Q by
+// an orthogonal similarity transformation, one column at a time. A is assumed
+// to be upper triangular in rows and columns 0 to ilo-1 and ihi+1 to n-1,
+// as left by DGEBAL, and only its rows and columns ilo through ihi are
+// reduced. H overwrites the upper triangle and first subdiagonal of A. Q is
+// represented as the product H(ilo)*...*H(ihi-1) of elementary reflectors
+// H(i) = I - tau[i]*v*v**H, where v[:i+1] = 0, v[i+1] = 1, v[ihi+1:] = 0
+// and v[i+2:ihi+1] is stored below the subdiagonal in column i of A. tau
+// holds n-1 elements and work n.
+func (impl Implementation) DGEHD2(n, ilo, ihi int, a []float64, lda int, tau, work []float64) error {
+	if err := checkGehrd("DGEHD2", n, ilo, ihi, len(a), lda, len(tau), len(work), noLwork); err != nil {
+		return err
+	}
+	gehd2(impl.bl(), n, ilo, ihi, a, lda, tau, work)
+	return nil
+}
+
+// DGEHRD computes the reduction of DGEHD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with matrix-matrix
+// products. tau[:ilo] and tau[ihi:] are set to zero. work holds
+// lwork >= max(1,n) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) DGEHRD(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkGehrd("DGEHRD", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	gehrd(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORGHR overwrites the n×n matrix A, which holds the reflectors left by
+// DGEHRD with the same ilo and ihi, with the orthogonal matrix Q of the
+// reduction. work holds lwork >= max(1,ihi-ilo) elements; the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGHR(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrghr("DORGHR", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orghr(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CGEHD2 reduces the n×n matrix A to upper Hessenberg form H = Q**H*A*Q by
+// an unitary similarity transformation, one column at a time. A is assumed
+// to be upper triangular in rows and columns 0 to ilo-1 and ihi+1 to n-1,
+// as left by CGEBAL, and only its rows and columns ilo through ihi are
+// reduced. H overwrites the upper triangle and first subdiagonal of A. Q is
+// represented as the product H(ilo)*...*H(ihi-1) of elementary reflectors
+// H(i) = I - tau[i]*v*v**H, where v[:i+1] = 0, v[i+1] = 1, v[ihi+1:] = 0
+// and v[i+2:ihi+1] is stored below the subdiagonal in column i of A. tau
+// holds n-1 elements and work n.
+func (impl Implementation) CGEHD2(n, ilo, ihi int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkGehrd("CGEHD2", n, ilo, ihi, len(a), lda, len(tau), len(work), noLwork); err != nil {
+		return err
+	}
+	gehd2(impl.bl(), n, ilo, ihi, a, lda, tau, work)
+	return nil
+}
+
+// CGEHRD computes the reduction of CGEHD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with matrix-matrix
+// products. tau[:ilo] and tau[ihi:] are set to zero. work holds
+// lwork >= max(1,n) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) CGEHRD(n, ilo, ihi int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkGehrd("CGEHRD", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	gehrd(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNGHR overwrites the n×n matrix A, which holds the reflectors left by
+// CGEHRD with the same ilo and ihi, with the unitary matrix Q of the
+// reduction. work holds lwork >= max(1,ihi-ilo) elements; the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGHR(n, ilo, ihi int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrghr("CUNGHR", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orghr(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZGEHD2 reduces the n×n matrix A to upper Hessenberg form H = Q**H*A*Q by
+// an unitary similarity transformation, one column at a time. A is assumed
+// to be upper triangular in rows and columns 0 to ilo-1 and ihi+1 to n-1,
+// as left by ZGEBAL, and only its rows and columns ilo through ihi are
+// reduced. H overwrites the upper triangle and first subdiagonal of A. Q is
+// represented as the product H(ilo)*...*H(ihi-1) of elementary reflectors
+// H(i) = I - tau[i]*v*v**H, where v[:i+1] = 0, v[i+1] = 1, v[ihi+1:] = 0
+// and v[i+2:ihi+1] is stored below the subdiagonal in column i of A. tau
+// holds n-1 elements and work n.
+func (impl Implementation) ZGEHD2(n, ilo, ihi int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkGehrd("ZGEHD2", n, ilo, ihi, len(a), lda, len(tau), len(work), noLwork); err != nil {
+		return err
+	}
+	gehd2(impl.bl(), n, ilo, ihi, a, lda, tau, work)
+	return nil
+}
+
+// ZGEHRD computes the reduction of ZGEHD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with matrix-matrix
+// products. tau[:ilo] and tau[ihi:] are set to zero. work holds
+// lwork >= max(1,n) elements; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) ZGEHRD(n, ilo, ihi int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkGehrd("ZGEHRD", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	gehrd(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNGHR overwrites the n×n matrix A, which holds the reflectors left by
+// ZGEHRD with the same ilo and ihi, with the unitary matrix Q of the
+// reduction. work holds lwork >= max(1,ihi-ilo) elements; the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGHR(n, ilo, ihi int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrghr("ZUNGHR", n, ilo, ihi, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orghr(impl.bl(), n, ilo, ihi, a, lda, tau, work, lwork)
+	return nil
+}
+
+// hrdBlock is the block size of gehrd, the value of ILAENV for xGEHRD.
+const hrdBlock = 32
+
+// hrdCrossover is the order below which gehrd uses the unblocked code, the
+// value of ILAENV(3, ...) for xGEHRD.
+const hrdCrossover = 128
+
+// checkGehrd checks the GEHRD and GEHD2 routines. GEHD2 has a workspace of
+// n elements and passes noLwork.
+func checkGehrd(routine string, n, ilo, ihi, lenA, lda, lenTau, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	c.iloIhi(2, n, ilo, ihi)
+	c.ld(5, "lda", lda, n, "n")
+	if lwork != noLwork {
+		c.lwork(8, lwork, max(1, n), "max(1,n)")
+	}
+	if c.ok() {
+		if lwork != noLwork {
+			c.work(7, lenWork, lwork)
+		} else {
+			c.length(7, "work", lenWork, n)
+		}
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(n, n, lda))
+			c.length(6, "tau", lenTau, n-1)
+		}
+	}
+	return c.result()
+}
+
+// checkOrghr checks the ORGHR and UNGHR routines.
+func checkOrghr(routine string, n, ilo, ihi, lenA, lda, lenTau, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	c.iloIhi(2, n, ilo, ihi)
+	c.ld(5, "lda", lda, n, "n")
+	c.lwork(8, lwork, max(1, ihi-ilo), "max(1,ihi-ilo)")
+	if c.ok() {
+		c.work(7, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(n, n, lda))
+			c.length(6, "tau", lenTau, n-1)
+		}
+	}
+	return c.result()
+}
+
+// gehd2 reduces rows and columns ilo through ihi of the n×n matrix A to
+// upper Hessenberg form H = Q**H*A*Q one column at a time, as xGEHD2. The
+// vector of H(i) is stored below the subdiagonal in column i of A with its
+// unit element i+1 implied. work holds n elements.
+func gehd2[T gen.Scalar](bl blas.BLAS, n, ilo, ihi int, a []T, lda int, tau, work []T) {
+	for i := ilo; i < ihi; i++ {
+		// Generate H(i) to annihilate A(i+2:ihi+1,i).
+		v := a[i+1+i*lda:]
+		beta, taui := larfg(bl, ihi-i, v[0], a[min(i+2, n-1)+i*lda:], 1)
+		tau[i] = taui
+		v[0] = 1
+		// Apply H(i) to A(0:ihi+1,i+1:ihi+1) from the right and H(i)**H to
+		// A(i+1:ihi+1,i+1:n) from the left.
+		larf(bl, blas.SideR, ihi+1, ihi-i, v, 1, taui, a[(i+1)*lda:], lda, work)
+		larf(bl, blas.SideL, ihi-i, n-i-1, v, 1, conj(taui), a[i+1+(i+1)*lda:], lda, work)
+		v[0] = beta
+	}
+}
+
+// lahr2 reduces the first nb columns of the n×(n-k+1) matrix A, whose
+// first k-1 rows are left unreduced, so that the elements below the k-th
+// subdiagonal are zero, as xLAHR2. The reduction is performed by the
+// unitary similarity transformation Q**H*A*Q with Q = I - V*T*V**H, whose
+// nb×nb upper triangular T is returned in t, and Y = A*V*T is returned in
+// the n×nb matrix y. The vectors of the reflectors are stored as in gehd2,
+// and their scalar factors in tau.
+func lahr2[T gen.Scalar](bl blas.BLAS, n, k, nb int, a []T, lda int, tau, t []T, ldt int, y []T, ldy int) {
+	if n <= 1 {
+		return
+	}
+	w := t[(nb-1)*ldt:]
+	var ei T
+	for i := 0; i < nb; i++ {
+		if i > 0 {
+			// Update column i of A as A - Y*V**H, with row k+i-1 of A
+			// holding the last row of V.
+			lacgv(i, a[k+i-1:], lda)
+			gemv(bl, blas.TransN, n-k, i, -1, y[k:], ldy, a[k+i-1:], lda, 1, a[k+i*lda:], 1)
+			lacgv(i, a[k+i-1:], lda)
+
+			// Apply I - V*T**H*V**H to this column b from the left,
+			// using the last column of T as the workspace w. V is
+			// split into its unit lower triangular first i rows V1
+			// and the rest V2, and b likewise into b1 and b2.
+			b := a[k+i*lda:]
+			// w = V1**H*b1 + V2**H*b2.
+			copyVec(bl, i, b, 1, w, 1)
+			trmv(bl, blas.UploL, blas.TransC, blas.DiagU, i, a[k:], lda, w, 1)
+			gemv(bl, blas.TransC, n-k-i, i, 1, a[k+i:], lda, b[i:], 1, 1, w, 1)
+			// w = T**H*w.
+			trmv(bl, blas.UploU, blas.TransC, blas.DiagN, i, t, ldt, w, 1)
+			// b2 -= V2*w and b1 -= V1*w.
+			gemv(bl, blas.TransN, n-k-i, i, -1, a[k+i:], lda, w, 1, 1, b[i:], 1)
+			trmv(bl, blas.UploL, blas.TransN, blas.DiagU, i, a[k:], lda, w, 1)
+			axpy(bl, i, -1, w, 1, b, 1)
+			a[k+i-1+(i-1)*lda] = ei
+		}
+
+		// Generate H(i) to annihilate A(k+i+1:n,i).
+		v := a[k+i+i*lda:]
+		ei, tau[i] = larfg(bl, n-k-i, v[0], a[min(k+i+1, n-1)+i*lda:], 1)
+		v[0] = 1
+
+		// Compute Y(k:n,i).
+		yi := y[k+i*ldy:]
+		gemv(bl, blas.TransN, n-k, n-k-i, 1, a[k+(i+1)*lda:], lda, v, 1, 0, yi, 1)
+		gemv(bl, blas.TransC, n-k-i, i, 1, a[k+i:], lda, v, 1, 0, t[i*ldt:], 1)
+		gemv(bl, blas.TransN, n-k, i, -1, y[k:], ldy, t[i*ldt:], 1, 1, yi, 1)
+		scal(bl, n-k, tau[i], yi, 1)
+
+		// Compute T(0:i+1,i).
+		scal(bl, i, -tau[i], t[i*ldt:], 1)
+		trmv(bl, blas.UploU, blas.TransN, blas.DiagN, i, t, ldt, t[i*ldt:], 1)
+		t[i+i*ldt] = tau[i]
+	}
+	a[k+nb-1+(nb-1)*lda] = ei
+
+	// Compute Y(0:k,0:nb).
+	lacpy(uploAll, k, nb, a[lda:], lda, y, ldy)
+	trmm(bl, blas.SideR, blas.UploL, blas.TransN, blas.DiagU, k, nb, 1, a[k:], lda, y, ldy)
+	if n > k+nb {
+		gemm(bl, blas.TransN, blas.TransN, k, nb, n-k-nb, 1, a[(nb+1)*lda:], lda, a[k+nb:], lda, 1, y, ldy)
+	}
+	trmm(bl, blas.SideR, blas.UploU, blas.TransN, blas.DiagN, k, nb, 1, t, ldt, y, ldy)
+}
+
+// gehrdWork returns the optimal workspace length of gehrd.
+func gehrdWork(n, ilo, ihi int) int {
+	if ihi-ilo < 1 {
+		return max(1, n)
+	}
+	return n*hrdBlock + hrdBlock*hrdBlock
+}
+
+// gehrd computes the reduction of gehd2 with the blocked algorithm of
+// xGEHRD, which reduces panels with lahr2 and applies their block reflectors
+// to the rest of A with gemm and larfb. tau[:ilo] and tau[ihi:] are set to
+// zero. work holds lwork >= max(1,n) elements, and the block size is reduced
+// to fit. A workspace query, lwork = -1, only sets work[0] to the optimal
+// lwork.
+func gehrd[T gen.Scalar](bl blas.BLAS, n, ilo, ihi int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(gehrdWork(n, ilo, ihi)))
+		return
+	}
+	for i := 0; i < ilo; i++ {
+		tau[i] = 0
+	}
+	for i := max(0, ihi); i < n-1; i++ {
+		tau[i] = 0
+	}
+	nh := ihi - ilo + 1
+	if nh <= 1 {
+		return
+	}
+	nb, nx := hrdBlock, nh
+	if nb < nh {
+		nx = max(nb, hrdCrossover)
+		if nx < nh && lwork < gehrdWork(n, ilo, ihi) {
+			nb = lwork / (n + hrdBlock)
+		}
+	}
+	ldwork := n
+	i := ilo
+	if nx < nh && nb >= 2 {
+		t, ldt := work[n*nb:], nb
+		for ; i < ihi-nx; i += nb {
+			ib := min(nb, ihi-i)
+			// Reduce columns i:i+ib to Hessenberg form, returning the
+			// V and T of the block reflector H = I - V*T*V**H and
+			// Y = A*V*T.
+			lahr2(bl, ihi+1, i+1, ib, a[i*lda:], lda, tau[i:], t, ldt, work, ldwork)
+
+			// Apply H to A(0:ihi+1,i+ib:ihi+1) from the right as
+			// A - Y*V**H, with the unit element of the last column of V
+			// set explicitly.
+			ei := a[i+ib+(i+ib-1)*lda]
+			a[i+ib+(i+ib-1)*lda] = 1
+			gemm(bl, blas.TransN, blas.TransC, ihi+1, ihi-i-ib+1, ib, -1, work, ldwork, a[i+ib+i*lda:], lda, 1, a[(i+ib)*lda:], lda)
+			a[i+ib+(i+ib-1)*lda] = ei
+
+			// Apply H to A(0:i+1,i+1:i+ib) from the right.
+			trmm(bl, blas.SideR, blas.UploL, blas.TransC, blas.DiagU, i+1, ib-1, 1, a[i+1+i*lda:], lda, work, ldwork)
+			for j := 0; j < ib-1; j++ {
+				axpy(bl, i+1, -1, work[ldwork*j:], 1, a[(i+j+1)*lda:], 1)
+			}
+
+			// Apply H**H to A(i+1:ihi+1,i+ib:n) from the left.
+			larfb(bl, blas.SideL, blas.TransC, DirectF, StoreVC, ihi-i, n-i-ib, ib, a[i+1+i*lda:], lda, t, ldt, a[i+1+(i+ib)*lda:], lda, work, ldwork)
+		}
+	}
+	gehd2(bl, n, i, ihi, a, lda, tau, work)
+}
+
+// orghr overwrites the n×n matrix A, which holds the reflectors left by
+// gehrd, with the unitary matrix Q of the reduction, as xORGHR and xUNGHR.
+// work holds lwork >= max(1,ihi-ilo) elements; a workspace query,
+// lwork = -1, only sets work[0] to the optimal lwork.
+func orghr[T gen.Scalar](bl blas.BLAS, n, ilo, ihi int, a []T, lda int, tau, work []T, lwork int) {
+	nh := ihi - ilo
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(orgqrWork(nh)))
+		return
+	}
+	if n == 0 {
+		return
+	}
+	// Q is the QR factor of its block ilo+1:ihi+1, whose reflectors are
+	// stored one column to the left: shift them right and set the other
+	// rows and columns of Q to those of the identity.
+	for j := ihi; j > ilo; j-- {
+		for i := 0; i < j; i++ {
+			a[i+j*lda] = 0
+		}
+		for i := j + 1; i <= ihi; i++ {
+			a[i+j*lda] = a[i+(j-1)*lda]
+		}
+		for i := ihi + 1; i < n; i++ {
+			a[i+j*lda] = 0
+		}
+	}
+	for j := 0; j < n; j++ {
+		if j > ilo && j <= ihi {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			a[i+j*lda] = 0
+		}
+		a[j+j*lda] = 1
+	}
+	if nh > 0 {
+		orgqr(bl, nh, nh, nh, a[ilo+1+(ilo+1)*lda:], lda, tau[ilo:], work, lwork)
+	}
+}
@@ -0,0 +1,194 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SHSEQR computes the eigenvalues of the n×n upper Hessenberg matrix H,
+// which is upper triangular in rows and columns outside ilo through ihi as
+// left by SGEBAL, and for job S its real Schur form H = Z*T*Z**T. T is upper
+// quasi-triangular with 1×1 and 2×2 diagonal blocks, the latter in standard
+// form with equal diagonal elements and off-diagonal elements of opposite
+// signs. Small matrices use the double-shift QR algorithm and larger ones
+// the small-bulge multishift QR algorithm with aggressive early deflation.
+// The real and imaginary parts of the eigenvalues are returned in wr and wi,
+// complex conjugate pairs consecutively with the positive imaginary part
+// first, and for job S in the order of the diagonal of T, which overwrites
+// H; for job E the contents of H are unspecified. compz I sets Z to the
+// Schur vectors, compz V multiplies by them the orthogonal Z given on entry,
+// such as the Q of SGEHRD and SORGHR, and compz N does not reference Z.
+// A *ConvergenceError is returned if the QR algorithm failed: the
+// eigenvalues outside rows ilo through Info-1 have then been stored. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SHSEQR(job SchurJob, compz CompZ, n, ilo, ihi int, h []float32, ldh int, wr, wi, z []float32, ldz int, work []float32, lwork int) error {
+	if err := checkHseqr("SHSEQR", job, compz, n, ilo, ihi, len(h), ldh, len(wr), len(wi), len(z), ldz, len(work), lwork); err != nil {
+		return err
+	}
+	return convergence("SHSEQR", hseqr(impl.bl(), job, compz, n, ilo, ihi, h, ldh, wr, wi, z, ldz, work, lwork))
+}
+
+// DHSEQR computes the eigenvalues of the n×n upper Hessenberg matrix H,
+// which is upper triangular in rows and columns outside ilo through ihi as
+// left by DGEBAL, and for job S its real Schur form H = Z*T*Z**T. T is upper
+// quasi-triangular with 1×1 and 2×2 diagonal blocks, the latter in standard
+// form with equal diagonal elements and off-diagonal elements of opposite
+// signs. Small matrices use the double-shift QR algorithm and larger ones
+// the small-bulge multishift QR algorithm with aggressive early deflation.
+// The real and imaginary parts of the eigenvalues are returned in wr and wi,
+// complex conjugate pairs consecutively with the positive imaginary part
+// first, and for job S in the order of the diagonal of T, which overwrites
+// H; for job E the contents of H are unspecified. compz I sets Z to the
+// Schur vectors, compz V multiplies by them the orthogonal Z given on entry,
+// such as the Q of DGEHRD and DORGHR, and compz N does not reference Z.
+// A *ConvergenceError is returned if the QR algorithm failed: the
+// eigenvalues outside rows ilo through Info-1 have then been stored. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DHSEQR(job SchurJob, compz CompZ, n, ilo, ihi int, h []float64, ldh int, wr, wi, z []float64, ldz int, work []float64, lwork int) error {
+	if err := checkHseqr("DHSEQR", job, compz, n, ilo, ihi, len(h), ldh, len(wr), len(wi), len(z), ldz, len(work), lwork); err != nil {
+		return err
+	}
+	return convergence("DHSEQR", hseqr(impl.bl(), job, compz, n, ilo, ihi, h, ldh, wr, wi, z, ldz, work, lwork))
+}
+
+// CHSEQR computes the eigenvalues of the n×n upper Hessenberg matrix H,
+// which is upper triangular in rows and columns outside ilo through ihi as
+// left by CGEBAL, and for job S its Schur form H = Z*T*Z**H with T upper
+// triangular. Small matrices use the single-shift QR algorithm and larger
+// ones the small-bulge multishift QR algorithm with aggressive early
+// deflation. The eigenvalues are returned in w, for job S in the order of
+// the diagonal of T, which overwrites H; for job E the contents of H are
+// unspecified. compz I sets Z to the Schur vectors, compz V multiplies by
+// them the unitary Z given on entry, such as the Q of CGEHRD and CUNGHR, and
+// compz N does not reference Z. A *ConvergenceError is returned if the QR
+// algorithm failed: the eigenvalues outside rows ilo through Info-1 have
+// then been stored. work holds lwork >= max(1,n) elements; the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CHSEQR(job SchurJob, compz CompZ, n, ilo, ihi int, h []complex64, ldh int, w, z []complex64, ldz int, work []complex64, lwork int) error {
+	if err := checkHseqr("CHSEQR", job, compz, n, ilo, ihi, len(h), ldh, len(w), -1, len(z), ldz, len(work), lwork); err != nil {
+		return err
+	}
+	return convergence("CHSEQR", hseqr[complex64, float32](impl.bl(), job, compz, n, ilo, ihi, h, ldh, w, nil, z, ldz, work, lwork))
+}
+
+// ZHSEQR computes the eigenvalues of the n×n upper Hessenberg matrix H,
+// which is upper triangular in rows and columns outside ilo through ihi as
+// left by ZGEBAL, and for job S its Schur form H = Z*T*Z**H with T upper
+// triangular. Small matrices use the single-shift QR algorithm and larger
+// ones the small-bulge multishift QR algorithm with aggressive early
+// deflation. The eigenvalues are returned in w, for job S in the order of
+// the diagonal of T, which overwrites H; for job E the contents of H are
+// unspecified. compz I sets Z to the Schur vectors, compz V multiplies by
+// them the unitary Z given on entry, such as the Q of ZGEHRD and ZUNGHR, and
+// compz N does not reference Z. A *ConvergenceError is returned if the QR
+// algorithm failed: the eigenvalues outside rows ilo through Info-1 have
+// then been stored. work holds lwork >= max(1,n) elements; the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZHSEQR(job SchurJob, compz CompZ, n, ilo, ihi int, h []complex128, ldh int, w, z []complex128, ldz int, work []complex128, lwork int) error {
+	if err := checkHseqr("ZHSEQR", job, compz, n, ilo, ihi, len(h), ldh, len(w), -1, len(z), ldz, len(work), lwork); err != nil {
+		return err
+	}
+	return convergence("ZHSEQR", hseqr[complex128, float64](impl.bl(), job, compz, n, ilo, ihi, h, ldh, w, nil, z, ldz, work, lwork))
+}
+
+// checkHseqr checks the HSEQR routines. The complex routines, which have no
+// wi, pass lenWi = -1.
+func checkHseqr(routine string, job SchurJob, compz CompZ, n, ilo, ihi, lenH, ldh, lenW, lenWi, lenZ, ldz, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.schur(1, job)
+	c.compz(2, compz)
+	c.nonNeg(3, "n", n)
+	c.iloIhi(4, n, ilo, ihi)
+	c.ld(7, "ldh", ldh, n, "n")
+	// The parameters after w are shifted by wi in the real routines.
+	off := 0
+	if lenWi >= 0 {
+		off = 1
+	}
+	wantz := compz != CompZN
+	if wantz {
+		c.ld(10+off, "ldz", ldz, n, "n")
+	} else {
+		c.atLeast(10+off, "ldz", ldz, 1, "1")
+	}
+	c.lwork(12+off, lwork, max(1, n), "max(1,n)")
+	if c.ok() {
+		c.work(11+off, lenWork, lwork)
+		if lwork != -1 {
+			c.length(6, "h", lenH, matLen(n, n, ldh))
+			if off == 1 {
+				c.length(8, "wr", lenW, n)
+				c.length(9, "wi", lenWi, n)
+			} else {
+				c.length(8, "w", lenW, n)
+			}
+			if wantz {
+				c.length(9+off, "z", lenZ, matLen(n, n, ldz))
+			}
+		}
+	}
+	return c.result()
+}
+
+// hseqrNl is the smallest order for which hseqr retries with laqr0 when
+// lahqr fails. Smaller matrices lack the workspace below the subdiagonal.
+const hseqrNl = 49
+
+// hseqrWork returns the optimal workspace length of hseqr.
+func hseqrWork(n, ilo, ihi int) int {
+	return max(1, n, laqr0Work(n, ilo, ihi))
+}
+
+// hseqr computes the eigenvalues and, for job S, the Schur form of the
+// upper Hessenberg matrix H as xHSEQR, with lahqr for n <= laqrNmin and
+// laqr0 above. The eigenvalues are stored as by lahqr. It returns the info
+// of lahqr or laqr0.
+func hseqr[T gen.Scalar, R gen.Float](bl blas.BLAS, job SchurJob, compz CompZ, n, ilo, ihi int, h []T, ldh int, w []T, wi []R, z []T, ldz int, work []T, lwork int) (info int) {
+	wantt := job == SchurS
+	initz := compz == CompZI
+	wantz := initz || compz == CompZV
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(hseqrWork(n, ilo, ihi)))
+		return 0
+	}
+	if n == 0 {
+		return 0
+	}
+
+	// Copy the eigenvalues isolated by gebal.
+	for i := 0; i < n; i++ {
+		if i < ilo || i > ihi {
+			w[i] = h[i+i*ldh]
+			if wi != nil {
+				wi[i] = 0
+			}
+		}
+	}
+	if initz {
+		laset(uploAll, n, n, 0, 1, z, ldz)
+	}
+	if ilo == ihi {
+		w[ilo] = h[ilo+ilo*ldh]
+		if wi != nil {
+			wi[ilo] = 0
+		}
+		return 0
+	}
+
+	if n > laqrNmin {
+		info = laqr0(bl, wantt, wantz, n, ilo, ihi, h, ldh, w, wi, ilo, ihi, z, ldz, work, lwork)
+	} else {
+		info = lahqr(bl, wantt, wantz, n, ilo, ihi, h, ldh, w, wi, ilo, ihi, z, ldz)
+		// laqr0 sometimes succeeds when lahqr fails.
+		if info > 0 && n >= hseqrNl {
+			info = laqr0(bl, wantt, wantz, n, ilo, info-1, h, ldh, w, wi, ilo, ihi, z, ldz, work, lwork)
+		}
+	}
+	// Clear out the workspace below the subdiagonal.
+	if (wantt || info != 0) && n > 2 {
+		laset(blas.UploL, n-2, n-2, 0, 0, h[2:], ldh)
+	}
+	return info
+}
@@ -0,0 +1,585 @@
+package lapack
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// Exceptional shifts of lahqr, applied after kexsh and 2*kexsh iterations
+// without a deflation.
+const (
+	lahqrKexsh = 10
+	lahqrDat1  = 0.75
+	lahqrDat2  = -0.4375
+)
+
+// lahqr computes the eigenvalues and, for wantt, the Schur form of the
+// upper Hessenberg matrix H, which is already upper triangular in rows and
+// columns outside ilo through ihi, with the double-shift (real types) or
+// single-shift (complex types) QR algorithm, as xLAHQR. It is the kernel of
+// hseqr for small matrices and for the deflation windows of laqr2.
+//
+// The eigenvalues ilo through ihi are stored in w; for real types w holds
+// their real parts and wi their imaginary parts, complex conjugate pairs
+// being stored in consecutive elements with the positive imaginary part
+// first, while wi is unused for complex types. For wantt, H is overwritten
+// by the quasi-triangular Schur form T, with 2×2 diagonal blocks in
+// standard form for the complex pairs of real types, or by the triangular
+// one of complex types. For wantz, the transformations are applied to rows
+// iloz through ihiz of Z from the right.
+//
+// info is zero on success. Otherwise the iteration failed to converge for
+// the eigenvalue info-1 and the eigenvalues info through ihi have been
+// stored; rows and columns ilo through info-1 of H then hold an upper
+// Hessenberg matrix whose eigenvalues are the remaining ones.
+func lahqr[T gen.Scalar, R gen.Float](bl blas.BLAS, wantt, wantz bool, n, ilo, ihi int, h []T, ldh int, w []T, wi []R, iloz, ihiz int, z []T, ldz int) (info int) {
+	if n == 0 {
+		return 0
+	}
+	if ilo == ihi {
+		w[ilo] = h[ilo+ilo*ldh]
+		if !isComplex[T]() {
+			wi[ilo] = 0
+		}
+		return 0
+	}
+	// Clear out the trash below the subdiagonal.
+	for j := ilo; j <= ihi-3; j++ {
+		h[j+2+j*ldh] = 0
+		h[j+3+j*ldh] = 0
+	}
+	if ilo <= ihi-2 {
+		h[ihi+(ihi-2)*ldh] = 0
+	}
+	if isComplex[T]() {
+		return lahqrComplex(bl, wantt, wantz, n, ilo, ihi, h, ldh, w, iloz, ihiz, z, ldz)
+	}
+	return lahqrReal(bl, wantt, wantz, n, ilo, ihi, h, ldh, w, wi, iloz, ihiz, z, ldz)
+}
+
+// lahqrSplit returns the largest k in (l,i] whose subdiagonal element
+// H(k,k-1) is negligible, or l if there is none. The test is the classical
+// comparison with the neighbouring diagonal elements combined with the more
+// conservative criterion of Ahues and Kressner.
+func lahqrSplit[T gen.Scalar](h []T, ldh, ilo, ihi, l, i int, smlnum, ulp float64) int {
+	k := i
+	for ; k > l; k-- {
+		hkk1 := abs1(h[k+(k-1)*ldh])
+		if hkk1 <= smlnum {
+			break
+		}
+		tst := abs1(h[k-1+(k-1)*ldh]) + abs1(h[k+k*ldh])
+		if tst == 0 {
+			if k-2 >= ilo {
+				tst += math.Abs(re(h[k-1+(k-2)*ldh]))
+			}
+			if k+1 <= ihi {
+				tst += math.Abs(re(h[k+1+k*ldh]))
+			}
+		}
+		if math.Abs(re(h[k+(k-1)*ldh])) <= ulp*tst {
+			hk1k := abs1(h[k-1+k*ldh])
+			ab, ba := max(hkk1, hk1k), min(hkk1, hk1k)
+			hkk := abs1(h[k+k*ldh])
+			d := abs1(h[k-1+(k-1)*ldh] - h[k+k*ldh])
+			aa, bb := max(hkk, d), min(hkk, d)
+			s := aa + ab
+			if ba*(ab/s) <= max(smlnum, ulp*(bb*(aa/s))) {
+				break
+			}
+		}
+	}
+	return k
+}
+
+// lahqrReal is lahqr for real types.
+func lahqrReal[T gen.Scalar, R gen.Float](bl blas.BLAS, wantt, wantz bool, n, ilo, ihi int, h []T, ldh int, wr []T, wi []R, iloz, ihiz int, z []T, ldz int) (info int) {
+	nh := ihi - ilo + 1
+	nz := ihiz - iloz + 1
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() * (float64(nh) / ulp)
+	hij := func(i, j int) float64 { return re(h[i+j*ldh]) }
+
+	// i1 and i2 are the first row and last column of H to which the
+	// transformations are applied.
+	var i1, i2 int
+	if wantt {
+		i1, i2 = 0, n-1
+	}
+	itmax := 30 * max(10, nh)
+	kdefl := 0
+	var v [3]T
+
+	// The main loop works on the active block in rows and columns l
+	// through i, deflating 1×1 or 2×2 blocks at its bottom; the
+	// eigenvalues i+1 through ihi have converged.
+	for i := ihi; i >= ilo; {
+		l := ilo
+		converged := false
+		for its := 0; its <= itmax; its++ {
+			l = lahqrSplit(h, ldh, ilo, ihi, l, i, smlnum, ulp)
+			if l > ilo {
+				h[l+(l-1)*ldh] = 0
+			}
+			if l >= i-1 {
+				converged = true
+				break
+			}
+			kdefl++
+			if !wantt {
+				i1, i2 = l, i
+			}
+
+			var h11, h12, h21, h22 float64
+			switch {
+			case kdefl%(2*lahqrKexsh) == 0:
+				// Exceptional shift.
+				s := math.Abs(hij(i, i-1)) + math.Abs(hij(i-1, i-2))
+				h11 = lahqrDat1*s + hij(i, i)
+				h12, h21, h22 = lahqrDat2*s, s, h11
+			case kdefl%lahqrKexsh == 0:
+				// Exceptional shift.
+				s := math.Abs(hij(l+1, l)) + math.Abs(hij(l+2, l+1))
+				h11 = lahqrDat1*s + hij(l, l)
+				h12, h21, h22 = lahqrDat2*s, s, h11
+			default:
+				// Francis double shift, the eigenvalues of the
+				// trailing 2×2 block.
+				h11, h21, h12, h22 = hij(i-1, i-1), hij(i, i-1), hij(i-1, i), hij(i, i)
+			}
+			var rt1r, rt1i, rt2r, rt2i float64
+			if s := math.Abs(h11) + math.Abs(h12) + math.Abs(h21) + math.Abs(h22); s != 0 {
+				h11 /= s
+				h21 /= s
+				h12 /= s
+				h22 /= s
+				tr := (h11 + h22) / 2
+				det := (h11-tr)*(h22-tr) - h12*h21
+				rtdisc := math.Sqrt(math.Abs(det))
+				if det >= 0 {
+					// Complex conjugate shifts.
+					rt1r, rt2r = tr*s, tr*s
+					rt1i, rt2i = rtdisc*s, -rtdisc*s
+				} else {
+					// Real shifts: use only the one closer to h22.
+					rt1r, rt2r = tr+rtdisc, tr-rtdisc
+					if math.Abs(rt1r-h22) <= math.Abs(rt2r-h22) {
+						rt2r = rt1r
+					} else {
+						rt1r = rt2r
+					}
+					rt1r *= s
+					rt2r *= s
+				}
+			}
+
+			// Look for two consecutive small subdiagonal elements: find
+			// the row m at which the double-shift step starts, such that
+			// starting there makes H(m,m-1) negligible.
+			m := i - 2
+			for ; ; m-- {
+				h21s := hij(m+1, m)
+				s := math.Abs(hij(m, m)-rt2r) + math.Abs(rt2i) + math.Abs(h21s)
+				h21s /= s
+				v0 := h21s*hij(m, m+1) + (hij(m, m)-rt1r)*((hij(m, m)-rt2r)/s) - rt1i*(rt2i/s)
+				v1 := h21s * (hij(m, m) + hij(m+1, m+1) - rt1r - rt2r)
+				v2 := h21s * hij(m+2, m+1)
+				s = math.Abs(v0) + math.Abs(v1) + math.Abs(v2)
+				v0, v1, v2 = v0/s, v1/s, v2/s
+				v[0], v[1], v[2] = fromReal[T](v0), fromReal[T](v1), fromReal[T](v2)
+				if m == l {
+					break
+				}
+				h00 := math.Abs(hij(m-1, m-1)) + math.Abs(hij(m, m)) + math.Abs(hij(m+1, m+1))
+				if math.Abs(hij(m, m-1))*(math.Abs(v1)+math.Abs(v2)) <= ulp*math.Abs(v0)*h00 {
+					break
+				}
+			}
+
+			// Double-shift QR step. The first reflector creates a bulge
+			// below the subdiagonal, the following ones chase it down.
+			for k := m; k < i; k++ {
+				nr := min(3, i-k+1)
+				if k > m {
+					copy(v[:nr], h[k+(k-1)*ldh:k+(k-1)*ldh+nr])
+				}
+				beta, t1 := larfg(bl, nr, v[0], v[1:], 1)
+				v[0] = beta
+				if k > m {
+					h[k+(k-1)*ldh] = beta
+					h[k+1+(k-1)*ldh] = 0
+					if k < i-1 {
+						h[k+2+(k-1)*ldh] = 0
+					}
+				} else if m > l {
+					// Avoid a bug when v[1] and v[2] underflow, rather
+					// than negating H(k,k-1).
+					h[k+(k-1)*ldh] *= 1 - t1
+				}
+				v2 := v[1]
+				t2 := t1 * v2
+				if nr == 3 {
+					v3 := v[2]
+					t3 := t1 * v3
+					for j := k; j <= i2; j++ {
+						sum := h[k+j*ldh] + v2*h[k+1+j*ldh] + v3*h[k+2+j*ldh]
+						h[k+j*ldh] -= sum * t1
+						h[k+1+j*ldh] -= sum * t2
+						h[k+2+j*ldh] -= sum * t3
+					}
+					for j := i1; j <= min(k+3, i); j++ {
+						sum := h[j+k*ldh] + v2*h[j+(k+1)*ldh] + v3*h[j+(k+2)*ldh]
+						h[j+k*ldh] -= sum * t1
+						h[j+(k+1)*ldh] -= sum * t2
+						h[j+(k+2)*ldh] -= sum * t3
+					}
+					if wantz {
+						for j := iloz; j <= ihiz; j++ {
+							sum := z[j+k*ldz] + v2*z[j+(k+1)*ldz] + v3*z[j+(k+2)*ldz]
+							z[j+k*ldz] -= sum * t1
+							z[j+(k+1)*ldz] -= sum * t2
+							z[j+(k+2)*ldz] -= sum * t3
+						}
+					}
+					continue
+				}
+				for j := k; j <= i2; j++ {
+					sum := h[k+j*ldh] + v2*h[k+1+j*ldh]
+					h[k+j*ldh] -= sum * t1
+					h[k+1+j*ldh] -= sum * t2
+				}
+				for j := i1; j <= i; j++ {
+					sum := h[j+k*ldh] + v2*h[j+(k+1)*ldh]
+					h[j+k*ldh] -= sum * t1
+					h[j+(k+1)*ldh] -= sum * t2
+				}
+				if wantz {
+					for j := iloz; j <= ihiz; j++ {
+						sum := z[j+k*ldz] + v2*z[j+(k+1)*ldz]
+						z[j+k*ldz] -= sum * t1
+						z[j+(k+1)*ldz] -= sum * t2
+					}
+				}
+			}
+		}
+		if !converged {
+			return i + 1
+		}
+
+		if l == i {
+			// One eigenvalue has converged.
+			wr[i] = h[i+i*ldh]
+			wi[i] = 0
+		} else {
+			// A pair has converged: reduce the 2×2 block to standard
+			// form and apply the rotation to the rest of H and to Z.
+			a, b, c, d, rt1r, rt1i, rt2r, rt2i, cs, sn := lanv2(hij(i-1, i-1), hij(i-1, i), hij(i, i-1), hij(i, i), ulp)
+			h[i-1+(i-1)*ldh], h[i-1+i*ldh] = fromReal[T](a), fromReal[T](b)
+			h[i+(i-1)*ldh], h[i+i*ldh] = fromReal[T](c), fromReal[T](d)
+			wr[i-1], wi[i-1] = fromReal[T](rt1r), R(rt1i)
+			wr[i], wi[i] = fromReal[T](rt2r), R(rt2i)
+			if wantt {
+				if i2 > i {
+					rrot(bl, i2-i, h[i-1+(i+1)*ldh:], ldh, h[i+(i+1)*ldh:], ldh, cs, sn)
+				}
+				rrot(bl, i-i1-1, h[i1+(i-1)*ldh:], 1, h[i1+i*ldh:], 1, cs, sn)
+			}
+			if wantz {
+				rrot(bl, nz, z[iloz+(i-1)*ldz:], 1, z[iloz+i*ldz:], 1, cs, sn)
+			}
+		}
+		kdefl = 0
+		i = l - 1
+	}
+	return 0
+}
+
+// lahqrComplex is lahqr for complex types. The subdiagonal of the active
+// block is kept real.
+func lahqrComplex[T gen.Scalar](bl blas.BLAS, wantt, wantz bool, n, ilo, ihi int, h []T, ldh int, w []T, iloz, ihiz int, z []T, ldz int) (info int) {
+	// Make the subdiagonal real by a diagonal similarity transformation.
+	jlo, jhi := ilo, ihi
+	if wantt {
+		jlo, jhi = 0, n-1
+	}
+	for i := ilo + 1; i <= ihi; i++ {
+		if im(h[i+(i-1)*ldh]) == 0 {
+			continue
+		}
+		// The redundant normalization avoids underflow in abs.
+		sc := h[i+(i-1)*ldh] / fromReal[T](abs1(h[i+(i-1)*ldh]))
+		sc = conj(sc) / fromReal[T](abs(sc))
+		h[i+(i-1)*ldh] = fromReal[T](abs(h[i+(i-1)*ldh]))
+		scal(bl, jhi-i+1, sc, h[i+i*ldh:], ldh)
+		scal(bl, min(jhi, i+1)-jlo+1, conj(sc), h[jlo+i*ldh:], 1)
+		if wantz {
+			scal(bl, ihiz-iloz+1, conj(sc), z[iloz+i*ldz:], 1)
+		}
+	}
+
+	nh := ihi - ilo + 1
+	nz := ihiz - iloz + 1
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() * (float64(nh) / ulp)
+	hij := func(i, j int) complex128 { return toC128(h[i+j*ldh]) }
+
+	var i1, i2 int
+	if wantt {
+		i1, i2 = 0, n-1
+	}
+	itmax := 30 * max(10, nh)
+	kdefl := 0
+	var v [2]T
+
+	for i := ihi; i >= ilo; {
+		l := ilo
+		converged := false
+		for its := 0; its <= itmax; its++ {
+			l = lahqrSplit(h, ldh, ilo, ihi, l, i, smlnum, ulp)
+			if l > ilo {
+				h[l+(l-1)*ldh] = 0
+			}
+			if l >= i {
+				converged = true
+				break
+			}
+			kdefl++
+			if !wantt {
+				i1, i2 = l, i
+			}
+
+			var t complex128
+			switch {
+			case kdefl%(2*lahqrKexsh) == 0:
+				// Exceptional shift.
+				t = complex(lahqrDat1*math.Abs(real(hij(i, i-1))), 0) + hij(i, i)
+			case kdefl%lahqrKexsh == 0:
+				// Exceptional shift.
+				t = complex(lahqrDat1*math.Abs(real(hij(l+1, l))), 0) + hij(l, l)
+			default:
+				// Wilkinson shift.
+				t = hij(i, i)
+				u := cmplx.Sqrt(hij(i-1, i)) * cmplx.Sqrt(hij(i, i-1))
+				if s := abs1(u); s != 0 {
+					x := (hij(i-1, i-1) - t) / 2
+					sx := abs1(x)
+					s = max(s, sx)
+					cs := complex(s, 0)
+					y := cs * cmplx.Sqrt((x/cs)*(x/cs)+(u/cs)*(u/cs))
+					if sx > 0 {
+						xs := x / complex(sx, 0)
+						if real(xs)*real(y)+imag(xs)*imag(y) < 0 {
+							y = -y
+						}
+					}
+					t -= u * (u / (x + y))
+				}
+			}
+
+			// Look for two consecutive small subdiagonal elements.
+			m := i - 1
+			for ; ; m-- {
+				h11, h22 := hij(m, m), hij(m+1, m+1)
+				h11s := h11 - t
+				h21 := real(hij(m+1, m))
+				s := abs1(h11s) + math.Abs(h21)
+				h11s /= complex(s, 0)
+				h21 /= s
+				v[0], v[1] = fromC128[T](h11s), fromReal[T](h21)
+				if m == l {
+					break
+				}
+				h10 := real(hij(m, m-1))
+				if math.Abs(h10)*math.Abs(h21) <= ulp*(abs1(h11s)*(abs1(h11)+abs1(h22))) {
+					break
+				}
+			}
+
+			// Single-shift QR step. Since v[1] is real on entry to larfg,
+			// t1*v[1] is real after it.
+			for k := m; k < i; k++ {
+				if k > m {
+					v[0], v[1] = h[k+(k-1)*ldh], h[k+1+(k-1)*ldh]
+				}
+				beta, t1 := larfg(bl, 2, v[0], v[1:], 1)
+				v[0] = beta
+				if k > m {
+					h[k+(k-1)*ldh] = beta
+					h[k+1+(k-1)*ldh] = 0
+				}
+				v2 := v[1]
+				t2 := fromReal[T](re(t1 * v2))
+				for j := k; j <= i2; j++ {
+					sum := conj(t1)*h[k+j*ldh] + t2*h[k+1+j*ldh]
+					h[k+j*ldh] -= sum
+					h[k+1+j*ldh] -= sum * v2
+				}
+				for j := i1; j <= min(k+2, i); j++ {
+					sum := t1*h[j+k*ldh] + t2*h[j+(k+1)*ldh]
+					h[j+k*ldh] -= sum
+					h[j+(k+1)*ldh] -= sum * conj(v2)
+				}
+				if wantz {
+					for j := iloz; j <= ihiz; j++ {
+						sum := t1*z[j+k*ldz] + t2*z[j+(k+1)*ldz]
+						z[j+k*ldz] -= sum
+						z[j+(k+1)*ldz] -= sum * conj(v2)
+					}
+				}
+				if k == m && m > l {
+					// The step started at m > l: scale to keep H(m,m-1)
+					// real.
+					temp := 1 - t1
+					temp /= fromReal[T](abs(temp))
+					h[m+1+m*ldh] *= conj(temp)
+					if m+2 <= i {
+						h[m+2+(m+1)*ldh] *= temp
+					}
+					for j := m; j <= i; j++ {
+						if j == m+1 {
+							continue
+						}
+						if i2 > j {
+							scal(bl, i2-j, temp, h[j+(j+1)*ldh:], ldh)
+						}
+						scal(bl, j-i1, conj(temp), h[i1+j*ldh:], 1)
+						if wantz {
+							scal(bl, nz, conj(temp), z[iloz+j*ldz:], 1)
+						}
+					}
+				}
+			}
+
+			// Ensure that H(i,i-1) is real.
+			if temp := h[i+(i-1)*ldh]; im(temp) != 0 {
+				rtemp := abs(temp)
+				h[i+(i-1)*ldh] = fromReal[T](rtemp)
+				temp /= fromReal[T](rtemp)
+				if i2 > i {
+					scal(bl, i2-i, conj(temp), h[i+(i+1)*ldh:], ldh)
+				}
+				scal(bl, i-i1, temp, h[i1+i*ldh:], 1)
+				if wantz {
+					scal(bl, nz, temp, z[iloz+i*ldz:], 1)
+				}
+			}
+		}
+		if !converged {
+			return i + 1
+		}
+		// One eigenvalue has converged.
+		w[i] = h[i+i*ldh]
+		kdefl = 0
+		i = l - 1
+	}
+	return 0
+}
+
+// lanv2 computes the Schur factorization of the real 2×2 nonsymmetric
+// matrix [a b; c d] in standard form, as xLANV2:
+//
+//	[a b] = [cs -sn] [aa bb] [ cs sn]
+//	[c d]   [sn  cs] [cc dd] [-sn cs]
+//
+// where either cc = 0, so that aa and dd are the real eigenvalues, or
+// aa = dd and bb*cc < 0, so that aa ± sqrt(bb*cc) are the complex
+// conjugate eigenvalues. The eigenvalues are also returned as
+// (rt1r,rt1i) and (rt2r,rt2i), with rt1i >= 0 for a complex pair. ulp is
+// the relative precision of the data, which decides when nearly equal real
+// eigenvalues are kept as a 2×2 block.
+func lanv2(a, b, c, d, ulp float64) (aa, bb, cc, dd, rt1r, rt1i, rt2r, rt2i, cs, sn float64) {
+	const multpl = 4
+	safmn2 := math.Ldexp(1, int(math.Log2(0x1p-1022/ulp)/2))
+	safmx2 := 1 / safmn2
+	switch {
+	case c == 0:
+		cs, sn = 1, 0
+	case b == 0:
+		// Swap rows and columns.
+		cs, sn = 0, 1
+		a, d = d, a
+		b, c = -c, 0
+	case a-d == 0 && math.Signbit(b) != math.Signbit(c):
+		cs, sn = 1, 0
+	default:
+		temp := a - d
+		p := temp / 2
+		bcmax := max(math.Abs(b), math.Abs(c))
+		bcmis := min(math.Abs(b), math.Abs(c)) * math.Copysign(1, b) * math.Copysign(1, c)
+		scale := max(math.Abs(p), bcmax)
+		z := (p/scale)*p + (bcmax/scale)*bcmis
+		if z >= multpl*ulp {
+			// Real eigenvalues: compute a and d.
+			z = p + math.Copysign(math.Sqrt(scale)*math.Sqrt(z), p)
+			a = d + z
+			d -= (bcmax / z) * bcmis
+			tau := lapy2(c, z)
+			cs = z / tau
+			sn = c / tau
+			b -= c
+			c = 0
+			break
+		}
+		// Complex or real (almost) equal eigenvalues: make the diagonal
+		// elements equal.
+		sigma := b + c
+		for count := 0; count < 20; count++ {
+			scale = max(math.Abs(temp), math.Abs(sigma))
+			if scale >= safmx2 {
+				sigma *= safmn2
+				temp *= safmn2
+				continue
+			}
+			if scale <= safmn2 {
+				sigma *= safmx2
+				temp *= safmx2
+				continue
+			}
+			break
+		}
+		p = temp / 2
+		tau := lapy2(sigma, temp)
+		cs = math.Sqrt((1 + math.Abs(sigma)/tau) / 2)
+		sn = -(p / (tau * cs)) * math.Copysign(1, sigma)
+		// [aa bb; cc dd] = [a b; c d]*[cs -sn; sn cs].
+		a1 := a*cs + b*sn
+		b1 := -a*sn + b*cs
+		c1 := c*cs + d*sn
+		d1 := -c*sn + d*cs
+		// [a b; c d] = [cs sn; -sn cs]*[aa bb; cc dd].
+		a = a1*cs + c1*sn
+		b = b1*cs + d1*sn
+		c = -a1*sn + c1*cs
+		d = -b1*sn + d1*cs
+		temp = (a + d) / 2
+		a, d = temp, temp
+		if c != 0 {
+			if b == 0 {
+				b, c = -c, 0
+				cs, sn = -sn, cs
+			} else if math.Signbit(b) == math.Signbit(c) {
+				// Real eigenvalues: reduce to upper triangular form.
+				sab := math.Sqrt(math.Abs(b))
+				sac := math.Sqrt(math.Abs(c))
+				p = math.Copysign(sab*sac, c)
+				tau = 1 / math.Sqrt(math.Abs(b+c))
+				a = temp + p
+				d = temp - p
+				b -= c
+				c = 0
+				cs1 := sab * tau
+				sn1 := sac * tau
+				cs, sn = cs*cs1-sn*sn1, cs*sn1+sn*cs1
+			}
+		}
+	}
+	rt1r, rt2r = a, d
+	if c != 0 {
+		rt1i = math.Sqrt(math.Abs(b)) * math.Sqrt(math.Abs(c))
+		rt2i = -rt1i
+	}
+	return a, b, c, d, rt1r, rt1i, rt2r, rt2i, cs, sn
+}
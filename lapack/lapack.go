@@ -0,0 +1,261 @@
+// Package lapack provides a pure Go implementation of LAPACK routines for
+// the four element types of BLAS, built on the routines of a blas.BLAS, so
+// that any BLAS implementation can be plugged in.
+//
+// Matrices are stored in column-major order as in package blas, and are
+// factored and overwritten in place. Indices, including pivot indices, are
+// 0-based. Routines report illegal arguments to the handler installed with
+// blas.SetErrorHandler and return the reported *blas.Error if the handler
+// returns. Numerical failures, which LAPACK reports with a positive INFO,
+// are returned as the typed errors of this package.
+//
+// Routines that take a workspace work of length lwork accept any lwork from
+// the documented minimum up, and run blocked code when lwork allows it. As
+// in LAPACK, a call with lwork = -1 is a workspace query: it only stores the
+// optimal lwork in work[0].
+package lapack
+
+import "github.com/visionom/lapack/blas"
+
+// Implementation is the pure Go implementation of LAPACK. The zero value
+// uses blas.Reference.
+type Implementation struct {
+	// BLAS provides the BLAS routines used by the LAPACK routines. A nil
+	// BLAS uses blas.Reference.
+	BLAS blas.BLAS
+}
+
+// bl returns the BLAS implementation of impl.
+func (impl Implementation) bl() blas.BLAS {
+	if impl.BLAS == nil {
+		return blas.Reference{}
+	}
+	return impl.BLAS
+}
+
+// Direct specifies the order in which the elementary reflectors of a block
+// reflector are multiplied.
+type Direct rune
+
+// StoreV specifies how the vectors of elementary reflectors are stored.
+type StoreV rune
+
+const (
+	// DirectF means DIRECT = 'F'  H = H(0)*H(1)*...*H(k-1).
+	DirectF Direct = 'F'
+
+	// DirectB means DIRECT = 'B'  H = H(k-1)*...*H(1)*H(0).
+	DirectB Direct = 'B'
+
+	// StoreVC means STOREV = 'C'  the vectors are stored in the columns of V.
+	StoreVC StoreV = 'C'
+
+	// StoreVR means STOREV = 'R'  the vectors are stored in the rows of V.
+	StoreVR StoreV = 'R'
+)
+
+// JobZ specifies whether the eigenvalue routines compute eigenvectors.
+type JobZ rune
+
+// CompZ specifies how the tridiagonal and Hessenberg eigenvalue routines
+// compute eigenvectors.
+type CompZ rune
+
+// Range specifies which eigenvalues are computed.
+type Range rune
+
+const (
+	// JobZN means JOBZ = 'N'  eigenvalues only.
+	JobZN JobZ = 'N'
+
+	// JobZV means JOBZ = 'V'  eigenvalues and eigenvectors.
+	JobZV JobZ = 'V'
+
+	// CompZN means COMPZ = 'N'  eigenvalues only.
+	CompZN CompZ = 'N'
+
+	// CompZI means COMPZ = 'I'  eigenvectors of the tridiagonal matrix, or
+	// Schur vectors of the Hessenberg matrix.
+	CompZI CompZ = 'I'
+
+	// CompZV means COMPZ = 'V'  eigenvectors or Schur vectors of the matrix
+	// reduced to the tridiagonal or Hessenberg one, which Z holds on entry.
+	CompZV CompZ = 'V'
+
+	// RangeA means RANGE = 'A'  all eigenvalues.
+	RangeA Range = 'A'
+
+	// RangeV means RANGE = 'V'  the eigenvalues in the half-open interval
+	// (vl,vu].
+	RangeV Range = 'V'
+
+	// RangeI means RANGE = 'I'  the eigenvalues il through iu, in ascending
+	// order, counted from 0.
+	RangeI Range = 'I'
+)
+
+// BalanceJob specifies how a general matrix is balanced.
+type BalanceJob rune
+
+// SchurJob specifies whether the Schur form is computed.
+type SchurJob rune
+
+// EVSide specifies which eigenvectors are computed.
+type EVSide rune
+
+// HowMany specifies which eigenvectors of a triangular matrix are computed
+// and how they are returned.
+type HowMany rune
+
+// Sense specifies which reciprocal condition numbers are computed.
+type Sense rune
+
+const (
+	// BalanceN means JOB = 'N'  no balancing.
+	BalanceN BalanceJob = 'N'
+
+	// BalanceP means JOB = 'P'  permutation only.
+	BalanceP BalanceJob = 'P'
+
+	// BalanceS means JOB = 'S'  scaling only.
+	BalanceS BalanceJob = 'S'
+
+	// BalanceB means JOB = 'B'  permutation and scaling.
+	BalanceB BalanceJob = 'B'
+
+	// SchurE means JOB = 'E'  eigenvalues only.
+	SchurE SchurJob = 'E'
+
+	// SchurS means JOB = 'S'  eigenvalues and the Schur form.
+	SchurS SchurJob = 'S'
+
+	// EVRight means SIDE = 'R'  right eigenvectors.
+	EVRight EVSide = 'R'
+
+	// EVLeft means SIDE = 'L'  left eigenvectors.
+	EVLeft EVSide = 'L'
+
+	// EVBoth means SIDE = 'B'  right and left eigenvectors.
+	EVBoth EVSide = 'B'
+
+	// HowManyA means HOWMNY = 'A'  all eigenvectors of the triangular
+	// matrix.
+	HowManyA HowMany = 'A'
+
+	// HowManyB means HOWMNY = 'B'  all eigenvectors, back-transformed by the
+	// matrices that VL and VR hold on entry.
+	HowManyB HowMany = 'B'
+
+	// HowManyS means HOWMNY = 'S'  the eigenvectors selected by a logical
+	// array.
+	HowManyS HowMany = 'S'
+
+	// SenseN means SENSE = 'N'  no condition numbers.
+	SenseN Sense = 'N'
+
+	// SenseE means SENSE = 'E'  condition numbers of the eigenvalues.
+	SenseE Sense = 'E'
+)
+
+// Vect specifies which of the orthogonal or unitary matrices of a reduction
+// to bidiagonal form is generated or applied.
+type Vect rune
+
+// SVDJob specifies which singular vectors the SVD drivers compute and where
+// they are stored.
+type SVDJob rune
+
+const (
+	// VectQ means VECT = 'Q'  the matrix Q that multiplies A from the left.
+	VectQ Vect = 'Q'
+
+	// VectP means VECT = 'P'  the matrix P**H that multiplies A from the
+	// right.
+	VectP Vect = 'P'
+
+	// SVDA means JOB = 'A'  all the singular vectors, in U or VT.
+	SVDA SVDJob = 'A'
+
+	// SVDS means JOB = 'S'  the first min(m,n) singular vectors, in U or
+	// VT.
+	SVDS SVDJob = 'S'
+
+	// SVDO means JOB = 'O'  the first min(m,n) singular vectors, which
+	// overwrite A.
+	SVDO SVDJob = 'O'
+
+	// SVDN means JOB = 'N'  no singular vectors.
+	SVDN SVDJob = 'N'
+)
+
+// SVJMatrix specifies the structure of the matrix of the one-sided Jacobi
+// SVD.
+type SVJMatrix rune
+
+// SVJLeft specifies whether the one-sided Jacobi SVD routines compute the
+// left singular vectors.
+type SVJLeft rune
+
+// SVJRight specifies whether the one-sided Jacobi SVD routines compute the
+// right singular vectors.
+type SVJRight rune
+
+// JSVAccuracy specifies the accuracy that the preconditioned Jacobi SVD
+// aims at, and with it how the numerical rank is determined.
+type JSVAccuracy rune
+
+const (
+	// SVJGeneral means JOBA = 'G'  a general matrix.
+	SVJGeneral SVJMatrix = 'G'
+
+	// SVJUpper means JOBA = 'U'  an upper triangular matrix.
+	SVJUpper SVJMatrix = 'U'
+
+	// SVJLower means JOBA = 'L'  a lower triangular matrix.
+	SVJLower SVJMatrix = 'L'
+
+	// SVJLeftU means JOBU = 'U'  the left singular vectors of the n columns
+	// of A.
+	SVJLeftU SVJLeft = 'U'
+
+	// SVJLeftF means JOBU = 'F'  a full set of m left singular vectors.
+	SVJLeftF SVJLeft = 'F'
+
+	// SVJLeftN means JOBU = 'N'  no left singular vectors.
+	SVJLeftN SVJLeft = 'N'
+
+	// SVJRightV means JOBV = 'V'  the right singular vectors.
+	SVJRightV SVJRight = 'V'
+
+	// SVJRightA means JOBV = 'A'  the rotations applied to the matrix V
+	// given on entry.
+	SVJRightA SVJRight = 'A'
+
+	// SVJRightN means JOBV = 'N'  no right singular vectors.
+	SVJRightN SVJRight = 'N'
+
+	// JSVC means JOBA = 'C'  high relative accuracy for A = B*D with B well
+	// conditioned and D diagonal; only singular values that underflow are
+	// set to zero.
+	JSVC JSVAccuracy = 'C'
+
+	// JSVE means JOBA = 'E'  as JSVC, with an estimate of the condition
+	// number of B.
+	JSVE JSVAccuracy = 'E'
+
+	// JSVF means JOBA = 'F'  high relative accuracy for A = D1*C*D2 with C
+	// well conditioned and D1, D2 diagonal, computed as for JSVC.
+	JSVF JSVAccuracy = 'F'
+
+	// JSVG means JOBA = 'G'  as JSVF, with an estimate of the condition
+	// number.
+	JSVG JSVAccuracy = 'G'
+
+	// JSVA means JOBA = 'A'  an absolute error bound: the singular values
+	// below sqrt(n)*eps times the largest are set to zero.
+	JSVA JSVAccuracy = 'A'
+
+	// JSVR means JOBA = 'R'  as JSVA, with the rank determined by a sudden
+	// drop by eps on the diagonal of the triangular factor.
+	JSVR JSVAccuracy = 'R'
+)
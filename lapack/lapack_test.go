@@ -0,0 +1,109 @@
+package lapack
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// The tests of this package check each routine against the identities that
+// define its result, such as A = P*L*U or A*X = B, evaluated naively in the
+// precision under test. As in the test programs of LAPACK, a residual is
+// scaled to a test ratio by n*eps and the norm of the data, and a ratio up to
+// maxRatio passes.
+
+const maxRatio = 30
+
+// randMat returns an m×n matrix with leading dimension ld, n ≥ 0, whose
+// elements, including those of the rows m to ld-1 outside the matrix, are
+// normally distributed.
+func randMat[T gen.Scalar](rnd *rand.Rand, m, n, ld int) []T {
+	a := make([]T, ld*n)
+	for i := range a {
+		a[i] = fromParts[T](rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return a
+}
+
+// opElem returns element (i,j) of op(A).
+func opElem[T gen.Scalar](t blas.Transpose, a []T, lda, i, j int) T {
+	switch t {
+	case blas.TransN:
+		return a[i+j*lda]
+	case blas.TransT:
+		return a[j+i*lda]
+	}
+	return conj(a[j+i*lda])
+}
+
+// mulMat returns the m×n product op(A)*op(B), whose inner dimension is k,
+// with leading dimension m.
+func mulMat[T gen.Scalar](ta, tb blas.Transpose, m, n, k int, a []T, lda int, b []T, ldb int) []T {
+	c := make([]T, m*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			var s T
+			for l := 0; l < k; l++ {
+				s += opElem(ta, a, lda, i, l) * opElem(tb, b, ldb, l, j)
+			}
+			c[i+j*m] = s
+		}
+	}
+	return c
+}
+
+// normF returns the Frobenius norm of the m×n matrix A.
+func normF[T gen.Scalar](m, n int, a []T, lda int) float64 {
+	var s float64
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			s = math.Hypot(s, abs(a[i+j*lda]))
+		}
+	}
+	return s
+}
+
+// diffF returns the Frobenius norm of A-B for the m×n matrices A and B.
+func diffF[T gen.Scalar](m, n int, a []T, lda int, b []T, ldb int) float64 {
+	var s float64
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			s = math.Hypot(s, abs(a[i+j*lda]-b[i+j*ldb]))
+		}
+	}
+	return s
+}
+
+// ratio returns the test ratio res/(n*eps*norm) of a residual res.
+func ratio[T gen.Scalar](res, norm float64, n int) float64 {
+	if res == 0 {
+		return 0
+	}
+	return res / (float64(max(n, 1)) * eps[T]() * norm)
+}
+
+// orthRatio returns the test ratio of ‖Qᴴ*Q - I‖ for the m×n matrix Q,
+// which is small when the columns of Q are orthonormal.
+func orthRatio[T gen.Scalar](m, n int, q []T, ldq int) float64 {
+	r := mulMat(blas.TransC, blas.TransN, n, n, m, q, ldq, q, ldq)
+	for i := 0; i < n; i++ {
+		r[i+i*n]--
+	}
+	return ratio[T](normF(n, n, r, n), 1, max(m, n))
+}
+
+// samePad reports whether a and b agree in the rows m to ld-1 of their n
+// columns, which lie outside the m×n matrices stored with leading dimension
+// ld. The routines must not write there.
+func samePad[T gen.Scalar](m, n, ld int, a, b []T) bool {
+	for j := 0; j < n; j++ {
+		for i := m; i < ld && i+j*ld < len(a); i++ {
+			if a[i+j*ld] != b[i+j*ld] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,906 @@
+package lapack
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// Parameters of the multishift QR algorithm, the values of IPARMQ and the
+// constants of xLAQR0.
+const (
+	// laqrNmin is the order above which hseqr uses laqr0 rather than
+	// lahqr, the value of IPARMQ for ISPEC = 12.
+	laqrNmin = 75
+	// laqrNtiny is the order up to which laqr0 calls lahqr itself.
+	laqrNtiny = 15
+	// laqrNibble is the percentage of eigenvalues deflated by aggressive
+	// early deflation above which the next QR sweep is skipped, the value
+	// of IPARMQ for ISPEC = 14.
+	laqrNibble = 14
+	// laqrAccum is the number of shifts from which laqr5 accumulates its
+	// reflectors and applies them with gemm, as IPARMQ for ISPEC = 16.
+	laqrAccum = 14
+	// laqrKexnw and laqrKexsh are the numbers of iterations without a
+	// deflation after which the deflation window grows and exceptional
+	// shifts are used.
+	laqrKexnw = 5
+	laqrKexsh = 6
+	laqrWilk1 = 0.75
+	laqrWilk2 = -0.4375
+)
+
+// laqrShifts returns the number of simultaneous shifts laqr0 uses for an
+// active block of order nh, the value of IPARMQ for ISPEC = 15.
+func laqrShifts(nh int) int {
+	ns := 2
+	switch {
+	case nh >= 6000:
+		ns = 256
+	case nh >= 3000:
+		ns = 128
+	case nh >= 590:
+		ns = 64
+	case nh >= 150:
+		ns = max(10, nh/int(math.Round(math.Log2(float64(nh)))))
+	case nh >= 60:
+		ns = 10
+	case nh >= 30:
+		ns = 4
+	}
+	return max(2, ns-ns%2)
+}
+
+// laqrWindow returns the size of the deflation window laqr0 uses for an
+// active block of order nh, the value of IPARMQ for ISPEC = 13.
+func laqrWindow(nh int) int {
+	ns := laqrShifts(nh)
+	if nh <= 500 {
+		return ns
+	}
+	return 3 * ns / 2
+}
+
+// sliceFrom returns s[i:], or nil if s is nil. It slices the imaginary parts
+// of eigenvalues, which are absent for complex types.
+func sliceFrom[R gen.Float](s []R, i int) []R {
+	if s == nil {
+		return nil
+	}
+	return s[i:]
+}
+
+// laqr0Work returns the optimal workspace length of laqr0, that of the
+// deflation window and of the shifts of the whole active block.
+func laqr0Work(n, ilo, ihi int) int {
+	if n <= laqrNtiny {
+		return 1
+	}
+	nh := ihi - ilo + 1
+	nwr := min(nh, (n-1)/3, max(2, laqrWindow(nh)))
+	nsr := min(laqrShifts(nh), (n-3)/6, ihi-ilo)
+	nsr = max(2, nsr-nsr%2)
+	return max(3*nsr/2, laqr2Work(min(nwr+1, nh)))
+}
+
+// laqr0 computes the eigenvalues and, for wantt, the Schur form of the upper
+// Hessenberg matrix H with the small-bulge multishift QR algorithm with
+// aggressive early deflation, as xLAQR0 with lahqr in place of xLAQR4 for
+// the small subproblems. The arguments are those of lahqr. The part of H
+// below its first subdiagonal is used as workspace. work holds
+// lwork >= max(1,n) elements; a workspace query, lwork = -1, only sets
+// work[0] to the optimal lwork.
+//
+// info is zero on success. Otherwise the iteration failed for the
+// eigenvalue info-1: the eigenvalues info through ihi have been stored and
+// rows and columns ilo through info-1 of H hold an upper Hessenberg matrix
+// whose eigenvalues are the remaining ones.
+func laqr0[T gen.Scalar, R gen.Float](bl blas.BLAS, wantt, wantz bool, n, ilo, ihi int, h []T, ldh int, w []T, wi []R, iloz, ihiz int, z []T, ldz int, work []T, lwork int) (info int) {
+	if n == 0 {
+		work[0] = 1
+		return 0
+	}
+	if n <= laqrNtiny {
+		work[0] = 1
+		if lwork == -1 {
+			return 0
+		}
+		return lahqr(bl, wantt, wantz, n, ilo, ihi, h, ldh, w, wi, iloz, ihiz, z, ldz)
+	}
+
+	cplx := isComplex[T]()
+	wiAt := func(i int) float64 {
+		if wi == nil {
+			return 0
+		}
+		return float64(wi[i])
+	}
+	hij := func(i, j int) T { return h[i+j*ldh] }
+
+	// The deflation window size and the number of shifts for the whole
+	// active block.
+	nh := ihi - ilo + 1
+	nwr := min(nh, (n-1)/3, max(2, laqrWindow(nh)))
+	nsr := min(laqrShifts(nh), (n-3)/6, ihi-ilo)
+	nsr = max(2, nsr-nsr%2)
+	lwkopt := laqr0Work(n, ilo, ihi)
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(lwkopt))
+		return 0
+	}
+	accum := laqrShifts(nh) >= laqrAccum
+	nwmax := min((n-1)/3, lwork/2)
+	nw := nwmax
+	nsmax := min((n-3)/6, 2*lwork/3)
+	nsmax -= nsmax % 2
+	ulp := 2 * eps[T]()
+
+	ndfl, ndec := 1, -1
+	itmax := 30 * max(10, nh)
+	kbot := ihi
+	for it := 0; it < itmax; it++ {
+		if kbot < ilo {
+			work[0] = fromReal[T](float64(lwkopt))
+			return 0
+		}
+		// Locate the active block.
+		k := kbot
+		for ; k > ilo; k-- {
+			if h[k+(k-1)*ldh] == 0 {
+				break
+			}
+		}
+		ktop := k
+
+		// Select the deflation window size: grow it after kexnw
+		// iterations without a deflation, and shrink it after it reached
+		// the largest allowed size to avoid repeated failures.
+		nh := kbot - ktop + 1
+		nwupbd := min(nh, nwmax)
+		if ndfl < laqrKexnw {
+			nw = min(nwupbd, nwr)
+		} else {
+			nw = min(nwupbd, 2*nw)
+		}
+		if nw < nwmax {
+			if nw >= nh-1 {
+				nw = nh
+			} else {
+				kwtop := kbot - nw + 1
+				if abs1(hij(kwtop, kwtop-1)) > abs1(hij(kwtop-1, kwtop-2)) {
+					nw++
+				}
+			}
+		}
+		if ndfl < laqrKexnw {
+			ndec = -1
+		} else if ndec >= 0 || nw >= nwupbd {
+			ndec++
+			if nw-ndec < 2 {
+				ndec = 0
+			}
+			nw -= ndec
+		}
+
+		// Aggressive early deflation, with V, T and WV carved from the
+		// lower left part of H.
+		kv := n - nw
+		kt := nw
+		kwv := nw + 1
+		nho := n - 2*nw - 1
+		nve := n - 2*nw - 1
+		ls, ld := laqr2(bl, wantt, wantz, n, ktop, kbot, nw, h, ldh, iloz, ihiz, z, ldz, w, wi, h[kv:], ldh, nho, h[kv+kt*ldh:], ldh, nve, h[kwv:], ldh, work, lwork)
+		kbot -= ld
+		ks := kbot - ls + 1
+
+		// Skip the sweep if aggressive early deflation found enough
+		// eigenvalues and the active block is not yet small.
+		if ld == 0 || (100*ld <= nw*laqrNibble && kbot-ktop+1 > min(laqrNmin, nwmax)) {
+			ns := min(nsmax, nsr, max(2, kbot-ktop))
+			ns -= ns % 2
+
+			if ndfl%laqrKexsh == 0 {
+				// Exceptional shifts.
+				ks = kbot - ns + 1
+				if cplx {
+					for i := kbot; i > ks; i -= 2 {
+						w[i] = hij(i, i) + fromReal[T](laqrWilk1*abs1(hij(i, i-1)))
+						w[i-1] = w[i]
+					}
+				} else {
+					for i := kbot; i >= max(ks+1, ktop+2); i -= 2 {
+						ss := math.Abs(re(hij(i, i-1))) + math.Abs(re(hij(i-1, i-2)))
+						aa := laqrWilk1*ss + re(hij(i, i))
+						_, _, _, _, rt1r, rt1i, rt2r, rt2i, _, _ := lanv2(aa, ss, laqrWilk2*ss, aa, ulp)
+						w[i-1], wi[i-1] = fromReal[T](rt1r), R(rt1i)
+						w[i], wi[i] = fromReal[T](rt2r), R(rt2i)
+					}
+					if ks == ktop {
+						w[ks+1], wi[ks+1] = hij(ks+1, ks+1), 0
+						w[ks], wi[ks] = w[ks+1], wi[ks+1]
+					}
+				}
+			} else {
+				// With ns/2 or fewer shifts from the deflation window,
+				// compute more from a trailing principal submatrix copied
+				// to the lower left part of H.
+				if kbot-ks+1 <= ns/2 {
+					ks = kbot - ns + 1
+					kt := n - ns
+					lacpy(uploAll, ns, ns, h[ks+ks*ldh:], ldh, h[kt:], ldh)
+					ks += lahqr(bl, false, false, ns, 0, ns-1, h[kt:], ldh, w[ks:], sliceFrom(wi, ks), 0, 0, nil, 1)
+					if ks >= kbot {
+						// In case of a rare failure use the eigenvalues
+						// of the trailing 2×2 submatrix.
+						laqrShifts2(h, ldh, kbot, w, wi, ulp)
+						ks = kbot - 1
+					}
+				}
+				if kbot-ks+1 > ns {
+					// Sort the shifts by decreasing magnitude; bubble sort
+					// keeps complex conjugate pairs together.
+					for k := kbot; k > ks; k-- {
+						sorted := true
+						for i := ks; i < k; i++ {
+							if abs1(w[i])+math.Abs(wiAt(i)) < abs1(w[i+1])+math.Abs(wiAt(i+1)) {
+								sorted = false
+								w[i], w[i+1] = w[i+1], w[i]
+								if wi != nil {
+									wi[i], wi[i+1] = wi[i+1], wi[i]
+								}
+							}
+						}
+						if sorted {
+							break
+						}
+					}
+				}
+				if !cplx {
+					// Shuffle the shifts into pairs of real shifts and
+					// pairs of complex conjugate shifts.
+					for i := kbot; i >= ks+2; i -= 2 {
+						if wi[i] != -wi[i-1] {
+							w[i], w[i-1], w[i-2] = w[i-1], w[i-2], w[i]
+							wi[i], wi[i-1], wi[i-2] = wi[i-1], wi[i-2], wi[i]
+						}
+					}
+				}
+			}
+
+			// With only two shifts, both real, use the one closer to the
+			// trailing diagonal element twice.
+			if kbot-ks+1 == 2 && wiAt(kbot) == 0 {
+				if abs1(w[kbot]-hij(kbot, kbot)) < abs1(w[kbot-1]-hij(kbot, kbot)) {
+					w[kbot-1] = w[kbot]
+				} else {
+					w[kbot] = w[kbot-1]
+				}
+			}
+
+			// Use up to ns of the smallest shifts, an even number of them.
+			ns = min(ns, kbot-ks+1)
+			ns -= ns % 2
+			ks = kbot - ns + 1
+
+			// The small-bulge sweep, with U, WH and WV carved from the
+			// lower left part of H.
+			kdu := 2 * ns
+			ku := n - kdu
+			kwh := kdu
+			kwv := kdu + 3
+			nho := n - 2*kdu - 4
+			nve := n - 2*kdu - 3
+			laqr5(bl, wantt, wantz, accum, n, ktop, kbot, ns, w[ks:], sliceFrom(wi, ks), h, ldh, iloz, ihiz, z, ldz, work, 3, h[ku:], ldh, nve, h[kwv:], ldh, nho, h[ku+kwh*ldh:], ldh)
+		}
+		if ld > 0 {
+			ndfl = 1
+		} else {
+			ndfl++
+		}
+	}
+	work[0] = fromReal[T](float64(lwkopt))
+	return kbot + 1
+}
+
+// laqrShifts2 stores the eigenvalues of the 2×2 submatrix of H ending in
+// row and column kbot in w[kbot-1:kbot+1] and, for real types,
+// wi[kbot-1:kbot+1].
+func laqrShifts2[T gen.Scalar, R gen.Float](h []T, ldh, kbot int, w []T, wi []R, ulp float64) {
+	k := kbot - 1
+	aa, bb := h[k+k*ldh], h[k+kbot*ldh]
+	cc, dd := h[kbot+k*ldh], h[kbot+kbot*ldh]
+	if !isComplex[T]() {
+		_, _, _, _, rt1r, rt1i, rt2r, rt2i, _, _ := lanv2(re(aa), re(bb), re(cc), re(dd), ulp)
+		w[k], wi[k] = fromReal[T](rt1r), R(rt1i)
+		w[kbot], wi[kbot] = fromReal[T](rt2r), R(rt2i)
+		return
+	}
+	s := abs1(aa) + abs1(bb) + abs1(cc) + abs1(dd)
+	cs := complex(s, 0)
+	a, b, c, d := toC128(aa)/cs, toC128(bb)/cs, toC128(cc)/cs, toC128(dd)/cs
+	tr2 := (a + d) / 2
+	det := (a-tr2)*(d-tr2) - b*c
+	rtdisc := cmplx.Sqrt(-det)
+	w[k] = fromC128[T]((tr2 + rtdisc) * cs)
+	w[kbot] = fromC128[T]((tr2 - rtdisc) * cs)
+}
+
+// laqr2Work returns the optimal workspace length of laqr2 for a deflation
+// window of order jw.
+func laqr2Work(jw int) int {
+	if jw <= 2 {
+		return 1
+	}
+	return jw + max(gehrdWork(jw, 0, jw-2), ormWork(blas.SideR, jw, jw))
+}
+
+// laqr2 performs aggressive early deflation on the active block in rows and
+// columns ktop through kbot of the upper Hessenberg matrix H, as xLAQR2: it
+// computes the Schur form of the trailing deflation window of order nw with
+// lahqr, deflates the eigenvalues whose spike components are negligible,
+// and returns the window to Hessenberg form. The other arguments are those
+// of lahqr.
+//
+// It returns the number nd of deflated eigenvalues, stored in
+// w[kbot-nd+1:kbot+1], and the number ns of the remaining eigenvalues of the
+// window, stored in w[kbot-nd-ns+1:kbot-nd+1] for use as shifts; wi holds
+// the imaginary parts for real types. V (nw×nw), T (nw×nh) and WV (nv×nw)
+// are workspace, as is work of length lwork >= 2*nw; a workspace query,
+// lwork = -1, only sets work[0] to the optimal lwork.
+func laqr2[T gen.Scalar, R gen.Float](bl blas.BLAS, wantt, wantz bool, n, ktop, kbot, nw int, h []T, ldh int, iloz, ihiz int, z []T, ldz int, w []T, wi []R, v []T, ldv int, nh int, t []T, ldt int, nv int, wv []T, ldwv int, work []T, lwork int) (ns, nd int) {
+	jw := min(nw, kbot-ktop+1)
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(laqr2Work(jw)))
+		return 0, 0
+	}
+	if ktop > kbot || nw < 1 {
+		return 0, 0
+	}
+	cplx := isComplex[T]()
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() * (float64(n) / ulp)
+	tij := func(i, j int) float64 { return abs(t[i+j*ldt]) }
+
+	kwtop := kbot - jw + 1
+	var s T
+	if kwtop > ktop {
+		s = h[kwtop+(kwtop-1)*ldh]
+	}
+	if kbot == kwtop {
+		// A 1×1 deflation window.
+		w[kwtop] = h[kwtop+kwtop*ldh]
+		if !cplx {
+			wi[kwtop] = 0
+		}
+		if abs1(s) <= max(smlnum, ulp*abs1(h[kwtop+kwtop*ldh])) {
+			if kwtop > ktop {
+				h[kwtop+(kwtop-1)*ldh] = 0
+			}
+			return 0, 1
+		}
+		return 1, 0
+	}
+
+	// Convert the window to spike-triangular form: T = V**H*H*V with the
+	// spike s*V(0,:) in the column to its left.
+	lacpy(blas.UploU, jw, jw, h[kwtop+kwtop*ldh:], ldh, t, ldt)
+	copyVec(bl, jw-1, h[kwtop+1+kwtop*ldh:], ldh+1, t[1:], ldt+1)
+	laset(uploAll, jw, jw, 0, 1, v, ldv)
+	infqr := lahqr(bl, true, true, jw, 0, jw-1, t, ldt, w[kwtop:], sliceFrom(wi, kwtop), 0, jw-1, v, ldv)
+
+	// trexc needs a clean margin near the diagonal.
+	for j := 0; j < jw-3; j++ {
+		t[j+2+j*ldt] = 0
+		t[j+3+j*ldt] = 0
+	}
+	if jw > 2 {
+		t[jw-1+(jw-3)*ldt] = 0
+	}
+
+	// Deflation detection: deflate the trailing eigenvalue or block if its
+	// spike components are negligible, otherwise move it to the top of the
+	// undeflatable ones.
+	ns = jw
+	ilst := infqr
+	for ilst < ns {
+		if cplx || ns == 1 || t[ns-1+(ns-2)*ldt] == 0 {
+			foo := abs1(t[ns-1+(ns-1)*ldt])
+			if foo == 0 {
+				foo = abs1(s)
+			}
+			if abs1(s)*abs1(v[(ns-1)*ldv]) <= max(smlnum, ulp*foo) {
+				ns--
+				continue
+			}
+			// trexc cannot fail to move a 1×1 block.
+			_, ilst, _ = trexc(bl, true, jw, t, ldt, v, ldv, ns-1, ilst, work)
+			ilst++
+			continue
+		}
+		// A complex conjugate pair.
+		foo := tij(ns-1, ns-1) + math.Sqrt(tij(ns-1, ns-2))*math.Sqrt(tij(ns-2, ns-1))
+		if foo == 0 {
+			foo = abs1(s)
+		}
+		if max(abs1(s)*abs1(v[(ns-1)*ldv]), abs1(s)*abs1(v[(ns-2)*ldv])) <= max(smlnum, ulp*foo) {
+			ns -= 2
+			continue
+		}
+		_, ilst, _ = trexc(bl, true, jw, t, ldt, v, ldv, ns-1, ilst, work)
+		ilst += 2
+	}
+	if ns == 0 {
+		s = 0
+	}
+
+	if ns < jw {
+		// Sorting the diagonal blocks of T by decreasing magnitude
+		// improves accuracy for graded matrices.
+		if cplx {
+			for i := infqr; i < ns; i++ {
+				ifst := i
+				for j := i + 1; j < ns; j++ {
+					if abs1(t[j+j*ldt]) > abs1(t[ifst+ifst*ldt]) {
+						ifst = j
+					}
+				}
+				if ifst != i {
+					trexc(bl, true, jw, t, ldt, v, ldv, ifst, i, work)
+				}
+			}
+		} else {
+			// Bubble sort deals well with exchange failures.
+			next := func(i, last int) int {
+				if i == last || t[i+1+i*ldt] == 0 {
+					return i + 1
+				}
+				return i + 2
+			}
+			mag := func(i, k int) float64 {
+				if k == i+1 {
+					return tij(i, i)
+				}
+				return tij(i, i) + math.Sqrt(tij(i+1, i))*math.Sqrt(tij(i, i+1))
+			}
+			i := ns
+			for sorted := false; !sorted; {
+				sorted = true
+				kend := i - 1
+				i = infqr
+				k := next(i, ns-1)
+				for k <= kend {
+					evi := mag(i, k)
+					evk := mag(k, next(k, kend))
+					if evi >= evk {
+						i = k
+					} else {
+						sorted = false
+						if _, il, ok := trexc(bl, true, jw, t, ldt, v, ldv, i, k, work); ok {
+							i = il
+						} else {
+							i = k
+						}
+					}
+					k = next(i, kend)
+				}
+			}
+		}
+	}
+
+	// Restore the shifts and eigenvalues from T.
+	if cplx {
+		for i := infqr; i < jw; i++ {
+			w[kwtop+i] = t[i+i*ldt]
+		}
+	} else {
+		for i := jw - 1; i >= infqr; {
+			if i == infqr || t[i+(i-1)*ldt] == 0 {
+				w[kwtop+i], wi[kwtop+i] = t[i+i*ldt], 0
+				i--
+				continue
+			}
+			_, _, _, _, rt1r, rt1i, rt2r, rt2i, _, _ := lanv2(re(t[i-1+(i-1)*ldt]), re(t[i-1+i*ldt]), re(t[i+(i-1)*ldt]), re(t[i+i*ldt]), ulp)
+			w[kwtop+i-1], wi[kwtop+i-1] = fromReal[T](rt1r), R(rt1i)
+			w[kwtop+i], wi[kwtop+i] = fromReal[T](rt2r), R(rt2i)
+			i -= 2
+		}
+	}
+
+	if ns < jw || s == 0 {
+		if ns > 1 && s != 0 {
+			// Reflect the spike back into the lower triangle and return
+			// the undeflated part of the window to Hessenberg form.
+			copyVec(bl, ns, v, ldv, work, 1)
+			lacgv(ns, work, 1)
+			_, tau := larfg(bl, ns, work[0], work[1:], 1)
+			work[0] = 1
+			laset(blas.UploL, jw-2, jw-2, 0, 0, t[2:], ldt)
+			larf(bl, blas.SideL, ns, jw, work, 1, conj(tau), t, ldt, work[jw:])
+			larf(bl, blas.SideR, ns, ns, work, 1, tau, t, ldt, work[jw:])
+			larf(bl, blas.SideR, jw, ns, work, 1, tau, v, ldv, work[jw:])
+			gehrd(bl, jw, 0, ns-1, t, ldt, work, work[jw:], lwork-jw)
+		}
+
+		// Copy the reduced window into place.
+		if kwtop > 0 {
+			h[kwtop+(kwtop-1)*ldh] = s * conj(v[0])
+		}
+		lacpy(blas.UploU, jw, jw, t, ldt, h[kwtop+kwtop*ldh:], ldh)
+		copyVec(bl, jw-1, t[1:], ldt+1, h[kwtop+1+kwtop*ldh:], ldh+1)
+
+		// Accumulate the reflectors of the reduction into V, as xORMHR.
+		if ns > 1 && s != 0 {
+			ormqr(bl, blas.SideR, blas.TransN, jw, ns-1, ns-1, t[1:], ldt, work, v[ldv:], ldv, work[jw:], lwork-jw)
+		}
+
+		// Update the vertical slab of H above the window.
+		ltop := ktop
+		if wantt {
+			ltop = 0
+		}
+		for krow := ltop; krow < kwtop; krow += nv {
+			kln := min(nv, kwtop-krow)
+			gemm(bl, blas.TransN, blas.TransN, kln, jw, jw, 1, h[krow+kwtop*ldh:], ldh, v, ldv, 0, wv, ldwv)
+			lacpy(uploAll, kln, jw, wv, ldwv, h[krow+kwtop*ldh:], ldh)
+		}
+		// Update the horizontal slab of H right of the window.
+		if wantt {
+			for kcol := kbot + 1; kcol < n; kcol += nh {
+				kln := min(nh, n-kcol)
+				gemm(bl, blas.TransC, blas.TransN, jw, kln, jw, 1, v, ldv, h[kwtop+kcol*ldh:], ldh, 0, t, ldt)
+				lacpy(uploAll, jw, kln, t, ldt, h[kwtop+kcol*ldh:], ldh)
+			}
+		}
+		// Update the vertical slab of Z.
+		if wantz {
+			for krow := iloz; krow <= ihiz; krow += nv {
+				kln := min(nv, ihiz-krow+1)
+				gemm(bl, blas.TransN, blas.TransN, kln, jw, jw, 1, z[krow+kwtop*ldz:], ldz, v, ldv, 0, wv, ldwv)
+				lacpy(uploAll, kln, jw, wv, ldwv, z[krow+kwtop*ldz:], ldz)
+			}
+		}
+	}
+	return ns - infqr, jw - ns
+}
+
+// laqr1 sets v, of length n = 2 or 3, to a multiple of the first column of
+// (H - s1*I)*(H - s2*I) for the leading n×n part of the Hessenberg matrix
+// H, scaled to avoid overflow, as xLAQR1. For real types the shifts must be
+// real or a complex conjugate pair, which makes v real.
+func laqr1[T gen.Scalar](n int, h []T, ldh int, s1, s2 complex128, v []T) {
+	hij := func(i, j int) complex128 { return toC128(h[i+j*ldh]) }
+	h11, h21 := hij(0, 0), hij(1, 0)
+	if n == 2 {
+		s := abs1(h11-s2) + abs1(h21)
+		if s == 0 {
+			v[0], v[1] = 0, 0
+			return
+		}
+		cs := complex(s, 0)
+		h21s := h21 / cs
+		v[0] = fromC128[T](h21s*hij(0, 1) + (h11-s1)*((h11-s2)/cs))
+		v[1] = fromC128[T](h21s * (h11 + hij(1, 1) - s1 - s2))
+		return
+	}
+	h31 := hij(2, 0)
+	s := abs1(h11-s2) + abs1(h21) + abs1(h31)
+	if s == 0 {
+		v[0], v[1], v[2] = 0, 0, 0
+		return
+	}
+	cs := complex(s, 0)
+	h21s, h31s := h21/cs, h31/cs
+	v[0] = fromC128[T]((h11-s1)*((h11-s2)/cs) + hij(0, 1)*h21s + hij(0, 2)*h31s)
+	v[1] = fromC128[T](h21s*(h11+hij(1, 1)-s1-s2) + hij(1, 2)*h31s)
+	v[2] = fromC128[T](h31s*(h11+hij(2, 2)-s1-s2) + h21s*hij(2, 1))
+}
+
+// laqr5 performs a single small-bulge multishift QR sweep on the active
+// block in rows and columns ktop through kbot of the upper Hessenberg
+// matrix H with the nshfts shifts in sr and, for real types, si, as
+// xLAQR5. For real types the shifts are real or complex conjugate pairs
+// stored consecutively. The chain of bulges is chased with 3×3 reflectors,
+// which for accum are accumulated into U and applied to the rest of H and to
+// Z with gemm. The other arguments are those of lahqr.
+//
+// V (3×nshfts/2), U (2*nshfts×2*nshfts), WV (nv×2*nshfts) and WH
+// (2*nshfts×nh) are workspace.
+func laqr5[T gen.Scalar, R gen.Float](bl blas.BLAS, wantt, wantz, accum bool, n, ktop, kbot, nshfts int, sr []T, si []R, h []T, ldh int, iloz, ihiz int, z []T, ldz int, v []T, ldv int, u []T, ldu int, nv int, wv []T, ldwv int, nh int, wh []T, ldwh int) {
+	if nshfts < 2 || ktop >= kbot {
+		return
+	}
+	cplx := isComplex[T]()
+	if !cplx {
+		// Shuffle the shifts into pairs of real shifts and pairs of
+		// complex conjugate shifts.
+		for i := 0; i < nshfts-2; i += 2 {
+			if si[i] != -si[i+1] {
+				sr[i], sr[i+1], sr[i+2] = sr[i+1], sr[i+2], sr[i]
+				si[i], si[i+1], si[i+2] = si[i+1], si[i+2], si[i]
+			}
+		}
+	}
+	shift := func(i int) complex128 {
+		if cplx {
+			return toC128(sr[i])
+		}
+		return complex(re(sr[i]), float64(si[i]))
+	}
+	// An odd number of shifts is reduced by one.
+	ns := nshfts - nshfts%2
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() * (float64(n) / ulp)
+	hij := func(i, j int) T { return h[i+j*ldh] }
+	ah := func(i, j int) float64 { return abs1(h[i+j*ldh]) }
+
+	// deflate sets H(k+1,k) to zero if it is negligible by the classical
+	// criterion and that of Ahues and Kressner.
+	deflate := func(k int) {
+		if k < ktop || hij(k+1, k) == 0 {
+			return
+		}
+		tst1 := ah(k, k) + ah(k+1, k+1)
+		if tst1 == 0 {
+			if k >= ktop+1 {
+				tst1 += ah(k, k-1)
+			}
+			if k >= ktop+2 {
+				tst1 += ah(k, k-2)
+			}
+			if k >= ktop+3 {
+				tst1 += ah(k, k-3)
+			}
+			if k <= kbot-2 {
+				tst1 += ah(k+2, k+1)
+			}
+			if k <= kbot-3 {
+				tst1 += ah(k+3, k+1)
+			}
+			if k <= kbot-4 {
+				tst1 += ah(k+4, k+1)
+			}
+		}
+		if ah(k+1, k) > max(smlnum, ulp*tst1) {
+			return
+		}
+		h12 := max(ah(k+1, k), ah(k, k+1))
+		h21 := min(ah(k+1, k), ah(k, k+1))
+		d := abs1(hij(k, k) - hij(k+1, k+1))
+		h11 := max(ah(k+1, k+1), d)
+		h22 := min(ah(k+1, k+1), d)
+		scl := h11 + h12
+		tst2 := h22 * (h11 / scl)
+		if tst2 == 0 || h21*(h12/scl) <= max(smlnum, ulp*tst2) {
+			h[k+1+k*ldh] = 0
+		}
+	}
+
+	if ktop+2 <= kbot {
+		h[ktop+2+ktop*ldh] = 0
+	}
+	// nbmps 2-shift bulges are chased in a chain through a diagonal slab of
+	// width kdu.
+	nbmps := ns / 2
+	kdu := 4 * nbmps
+
+	// incol is the first column of the slab; it starts left of ktop and
+	// the slab may extend beyond kbot, the phantom rows and columns from
+	// which bulges are introduced and to which they are chased.
+	for incol := ktop - 2*nbmps + 1; incol <= kbot-2; incol += 2 * nbmps {
+		jtop := ktop
+		switch {
+		case accum:
+			jtop = max(ktop, incol)
+		case wantt:
+			jtop = 0
+		}
+		ndcol := incol + kdu
+		if accum {
+			laset(uploAll, kdu, kdu, 0, 1, u, ldu)
+		}
+
+		// Chase the chain of bulges 2*nbmps columns to the right, near
+		// the diagonal.
+		for krcol := incol; krcol <= min(incol+2*nbmps-1, kbot-2); krcol++ {
+			// Bulges mtop through mbot are active 3×3 bulges; a 2×2
+			// bulge m22 may also fit at the bottom.
+			mtop := max(0, (ktop-krcol)/2)
+			mbot := min(nbmps, (kbot-krcol-1)/2) - 1
+			m22 := mbot + 1
+			bmp22 := m22 < nbmps && krcol+2*m22 == kbot-2
+
+			jbot := kbot
+			switch {
+			case accum:
+				jbot = min(ndcol, kbot)
+			case wantt:
+				jbot = n - 1
+			}
+
+			if bmp22 {
+				// The 2×2 reflector at the bottom.
+				k := krcol + 2*m22
+				vm := v[m22*ldv : m22*ldv+2]
+				if k == ktop-1 {
+					laqr1(2, h[k+1+(k+1)*ldh:], ldh, shift(2*m22), shift(2*m22+1), vm)
+					_, vm[0] = larfg(bl, 2, vm[0], vm[1:], 1)
+				} else {
+					var beta T
+					vm[1] = hij(k+2, k)
+					beta, vm[0] = larfg(bl, 2, hij(k+1, k), vm[1:], 1)
+					h[k+1+k*ldh] = beta
+					h[k+2+k*ldh] = 0
+				}
+				t1, v2 := vm[0], vm[1]
+				t2 := t1 * conj(v2)
+				for j := jtop; j <= min(kbot, k+3); j++ {
+					refsum := hij(j, k+1) + v2*hij(j, k+2)
+					h[j+(k+1)*ldh] -= refsum * t1
+					h[j+(k+2)*ldh] -= refsum * t2
+				}
+				ct1 := conj(t1)
+				ct2 := ct1 * v2
+				for j := k + 1; j <= jbot; j++ {
+					refsum := hij(k+1, j) + conj(v2)*hij(k+2, j)
+					h[k+1+j*ldh] -= refsum * ct1
+					h[k+2+j*ldh] -= refsum * ct2
+				}
+				deflate(k)
+				switch {
+				case accum:
+					kms := k - incol - 1
+					for j := max(0, ktop-incol-1); j < kdu; j++ {
+						refsum := t1 * (u[j+(kms+1)*ldu] + v2*u[j+(kms+2)*ldu])
+						u[j+(kms+1)*ldu] -= refsum
+						u[j+(kms+2)*ldu] -= refsum * conj(v2)
+					}
+				case wantz:
+					for j := iloz; j <= ihiz; j++ {
+						refsum := t1 * (z[j+(k+1)*ldz] + v2*z[j+(k+2)*ldz])
+						z[j+(k+1)*ldz] -= refsum
+						z[j+(k+2)*ldz] -= refsum * conj(v2)
+					}
+				}
+			}
+
+			// The chain of 3×3 reflectors.
+			for m := mbot; m >= mtop; m-- {
+				k := krcol + 2*m
+				vm := v[m*ldv : m*ldv+3]
+				if k == ktop-1 {
+					laqr1(3, h[ktop+ktop*ldh:], ldh, shift(2*m), shift(2*m+1), vm)
+					_, vm[0] = larfg(bl, 3, vm[0], vm[1:], 1)
+				} else {
+					// Perform the delayed transformation of the row below
+					// the bulge, whose first two elements are zero.
+					t1 := vm[0]
+					refsum := vm[2] * hij(k+3, k+2)
+					h[k+3+k*ldh] = -refsum * t1
+					h[k+3+(k+1)*ldh] = -refsum * t1 * conj(vm[1])
+					h[k+3+(k+2)*ldh] -= refsum * t1 * conj(vm[2])
+
+					// Compute the reflector that moves the bulge one step.
+					vm[1], vm[2] = hij(k+2, k), hij(k+3, k)
+					var beta T
+					beta, vm[0] = larfg(bl, 3, hij(k+1, k), vm[1:], 1)
+
+					// The bulge may collapse because of vigilant deflation
+					// or underflow. Then try to reintroduce it from the
+					// shifts, ignoring H(k+1,k) and H(k+2,k), unless that
+					// creates non-negligible fill.
+					if hij(k+3, k) != 0 || hij(k+3, k+1) != 0 || hij(k+3, k+2) == 0 {
+						h[k+1+k*ldh] = beta
+						h[k+2+k*ldh] = 0
+						h[k+3+k*ldh] = 0
+					} else {
+						var vt [3]T
+						laqr1(3, h[k+1+(k+1)*ldh:], ldh, shift(2*m), shift(2*m+1), vt[:])
+						_, vt[0] = larfg(bl, 3, vt[0], vt[1:], 1)
+						refsum := conj(vt[0]) * (hij(k+1, k) + conj(vt[1])*hij(k+2, k))
+						if abs1(hij(k+2, k)-refsum*vt[1])+abs1(refsum*vt[2]) > ulp*(ah(k, k)+ah(k+1, k+1)+ah(k+2, k+2)) {
+							h[k+1+k*ldh] = beta
+						} else {
+							h[k+1+k*ldh] -= refsum
+							copy(vm, vt[:])
+						}
+						h[k+2+k*ldh] = 0
+						h[k+3+k*ldh] = 0
+					}
+				}
+
+				// Apply the reflector from the right, and from the left to
+				// the next column as needed by the deflation check; the
+				// rest of the update from the left is delayed.
+				t1, v2, v3 := vm[0], vm[1], vm[2]
+				t2, t3 := t1*conj(v2), t1*conj(v3)
+				for j := jtop; j <= min(kbot, k+3); j++ {
+					refsum := hij(j, k+1) + v2*hij(j, k+2) + v3*hij(j, k+3)
+					h[j+(k+1)*ldh] -= refsum * t1
+					h[j+(k+2)*ldh] -= refsum * t2
+					h[j+(k+3)*ldh] -= refsum * t3
+				}
+				refsum := conj(t1) * (hij(k+1, k+1) + conj(v2)*hij(k+2, k+1) + conj(v3)*hij(k+3, k+1))
+				h[k+1+(k+1)*ldh] -= refsum
+				h[k+2+(k+1)*ldh] -= refsum * v2
+				h[k+3+(k+1)*ldh] -= refsum * v3
+				deflate(k)
+			}
+
+			// Apply the reflectors from the left.
+			for m := mbot; m >= mtop; m-- {
+				k := krcol + 2*m
+				vm := v[m*ldv : m*ldv+3]
+				t1 := conj(vm[0])
+				v2, v3 := vm[1], vm[2]
+				t2, t3 := t1*v2, t1*v3
+				for j := max(ktop, krcol+2*m+2); j <= jbot; j++ {
+					refsum := hij(k+1, j) + conj(v2)*hij(k+2, j) + conj(v3)*hij(k+3, j)
+					h[k+1+j*ldh] -= refsum * t1
+					h[k+2+j*ldh] -= refsum * t2
+					h[k+3+j*ldh] -= refsum * t3
+				}
+			}
+
+			// Accumulate the reflectors into U, or apply them to Z.
+			for m := mbot; m >= mtop; m-- {
+				if !accum && !wantz {
+					break
+				}
+				k := krcol + 2*m
+				vm := v[m*ldv : m*ldv+3]
+				t1, v2, v3 := vm[0], vm[1], vm[2]
+				t2, t3 := t1*conj(v2), t1*conj(v3)
+				if accum {
+					kms := k - incol - 1
+					i2 := max(0, ktop-incol-1, kms-(krcol-incol)+1)
+					i4 := min(kdu-1, krcol+2*mbot-incol+4)
+					for j := i2; j <= i4; j++ {
+						refsum := u[j+(kms+1)*ldu] + v2*u[j+(kms+2)*ldu] + v3*u[j+(kms+3)*ldu]
+						u[j+(kms+1)*ldu] -= refsum * t1
+						u[j+(kms+2)*ldu] -= refsum * t2
+						u[j+(kms+3)*ldu] -= refsum * t3
+					}
+					continue
+				}
+				for j := iloz; j <= ihiz; j++ {
+					refsum := z[j+(k+1)*ldz] + v2*z[j+(k+2)*ldz] + v3*z[j+(k+3)*ldz]
+					z[j+(k+1)*ldz] -= refsum * t1
+					z[j+(k+2)*ldz] -= refsum * t2
+					z[j+(k+3)*ldz] -= refsum * t3
+				}
+			}
+		}
+
+		if !accum {
+			continue
+		}
+		// Apply the accumulated U to the far-from-diagonal parts of H and
+		// to Z.
+		jtop, jbot := ktop, kbot
+		if wantt {
+			jtop, jbot = 0, n-1
+		}
+		k1 := max(0, ktop-incol-1)
+		nu := kdu - max(0, ndcol-kbot) - k1
+		uk := u[k1+k1*ldu:]
+		for jcol := min(ndcol, kbot) + 1; jcol <= jbot; jcol += nh {
+			jlen := min(nh, jbot-jcol+1)
+			gemm(bl, blas.TransC, blas.TransN, nu, jlen, nu, 1, uk, ldu, h[incol+k1+1+jcol*ldh:], ldh, 0, wh, ldwh)
+			lacpy(uploAll, nu, jlen, wh, ldwh, h[incol+k1+1+jcol*ldh:], ldh)
+		}
+		for jrow := jtop; jrow < max(ktop, incol); jrow += nv {
+			jlen := min(nv, max(ktop, incol)-jrow)
+			gemm(bl, blas.TransN, blas.TransN, jlen, nu, nu, 1, h[jrow+(incol+k1+1)*ldh:], ldh, uk, ldu, 0, wv, ldwv)
+			lacpy(uploAll, jlen, nu, wv, ldwv, h[jrow+(incol+k1+1)*ldh:], ldh)
+		}
+		if wantz {
+			for jrow := iloz; jrow <= ihiz; jrow += nv {
+				jlen := min(nv, ihiz-jrow+1)
+				gemm(bl, blas.TransN, blas.TransN, jlen, nu, nu, 1, z[jrow+(incol+k1+1)*ldz:], ldz, uk, ldu, 0, wv, ldwv)
+				lacpy(uploAll, jlen, nu, wv, ldwv, z[jrow+(incol+k1+1)*ldz:], ldz)
+			}
+		}
+	}
+}
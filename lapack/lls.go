@@ -0,0 +1,1009 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGELS solves the overdetermined or underdetermined linear system op(A)*X =
+// B in the least squares or minimum norm sense, where A is an m×n matrix of
+// full rank, with its QR (m >= n) or LQ (m < n) factorization. op(A) is A
+// (trans N) or A**T (trans T). B has max(m,n) rows; it holds the right-hand
+// sides in its first m rows (trans N) or n rows on entry, and the solution
+// in its first n rows (trans N) or m rows on return. For an overdetermined
+// system, the residual sum of squares of each column of the solution is the
+// sum of squares of the rows of B after it. A is overwritten by its
+// factorization as computed by SGEQRF or SGELQF. A *SingularError is
+// returned, and no solution is computed, if the triangular factor has an
+// exactly zero diagonal element. work holds lwork >= max(1,k+max(k,nrhs))
+// elements, where k = min(m,n); the optimal lwork is returned in work[0] by
+// a call with lwork = -1 that does nothing else.
+func (impl Implementation) SGELS(trans blas.Transpose, m, n, nrhs int, a []float32, lda int, b []float32, ldb int, work []float32, lwork int) error {
+	if err := checkGels("SGELS", trans, m, n, nrhs, len(a), lda, len(b), ldb, len(work), lwork, false); err != nil {
+		return err
+	}
+	return singular("SGELS", gels(impl.bl(), trans, m, n, nrhs, a, lda, b, ldb, work, lwork))
+}
+
+// SGELSS computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with the singular value decomposition of A. B has max(m,n) rows; it holds
+// the m×nrhs right-hand sides on entry and the n×nrhs solution on return.
+// The singular values of A are returned in decreasing order in s, of length
+// min(m,n). Those not greater than rcond times the largest are treated as
+// zero, and rcond < 0 means machine precision. SGELSS returns the effective
+// rank of A, the number of singular values above that threshold. A is
+// destroyed. A *ConvergenceError is returned if the SVD failed to converge,
+// Info elements of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= 7*k+max(m,n,nrhs) elements, where k = min(m,n); the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) SGELSS(m, n, nrhs int, a []float32, lda int, b []float32, ldb int, s []float32, rcond float32, work []float32, lwork int) (rank int, err error) {
+	if err := checkGelss("SGELSS", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, -1, -1, false); err != nil {
+		return 0, err
+	}
+	_, opt, lrwork := gelssWork(m, n, nrhs, false)
+	if lwork == -1 {
+		work[0] = float32(opt + lrwork)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), false, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work[lrwork:], lwork-lrwork, work[:lrwork], nil)
+	return rank, convergence("SGELSS", info)
+}
+
+// SGELSD computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| as SGELSS does, with the singular value
+// decomposition of A computed by divide and conquer, which is faster for
+// large matrices. Singular values not greater than rcond times the largest
+// are treated as zero, and rcond <= 0 or rcond >= 1 means machine precision.
+// SGELSD returns the effective rank of A. A is destroyed. A
+// *ConvergenceError is returned if the SVD of a subproblem failed to
+// converge. work holds lwork >=
+// 3*k+max(m,n,nrhs)+2*k*k+max(6*k*k+8*k,2*k*nrhs) elements, where
+// k = min(m,n), and iwork 3*k; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) SGELSD(m, n, nrhs int, a []float32, lda int, b []float32, ldb int, s []float32, rcond float32, work []float32, lwork int, iwork []int) (rank int, err error) {
+	if err := checkGelss("SGELSD", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, -1, len(iwork), true); err != nil {
+		return 0, err
+	}
+	_, opt, lrwork := gelssWork(m, n, nrhs, true)
+	if lwork == -1 {
+		work[0] = float32(opt + lrwork)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), true, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work[lrwork:], lwork-lrwork, work[:lrwork], iwork)
+	return rank, convergence("SGELSD", info)
+}
+
+// SGELSY computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with a complete orthogonal factorization of A. The QR factorization with
+// column pivoting A*P = Q*[R11 R12; 0 R22] of SGEQP3 is truncated at the
+// largest leading triangle R11 whose estimated condition number is less than
+// 1/rcond, R22 being treated as zero, and [R11 R12] is reduced to [T11 0]*Z
+// by STZRZF. B has max(m,n) rows; it holds the m×nrhs right-hand sides on
+// entry and the n×nrhs solution on return. jpvt is as in SGEQP3: columns j
+// with jpvt[j] >= 0 on entry are moved to the front, the others must have
+// jpvt[j] = -1, and on return jpvt[j] is the column of A that is column j of
+// A*P. SGELSY returns the effective rank of A, the order of R11, and leaves
+// T11 in the leading triangle of A. work holds lwork >=
+// 2*n+k+max(2*k,n+1,k+nrhs) elements, where k = min(m,n); the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SGELSY(m, n, nrhs int, a []float32, lda int, b []float32, ldb int, jpvt []int, rcond float32, work []float32, lwork int) (rank int, err error) {
+	if err := checkGelsy("SGELSY", m, n, nrhs, len(a), lda, len(b), ldb, len(jpvt), len(work), lwork, -1); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt := gelsyWork(m, n, nrhs, false)
+		work[0] = float32(opt)
+		return 0, nil
+	}
+	return gelsy(impl.bl(), m, n, nrhs, a, lda, b, ldb, jpvt, float64(rcond), work[2*n:], lwork-2*n, work[:2*n]), nil
+}
+
+// DGELS solves the overdetermined or underdetermined linear system op(A)*X =
+// B in the least squares or minimum norm sense, where A is an m×n matrix of
+// full rank, with its QR (m >= n) or LQ (m < n) factorization. op(A) is A
+// (trans N) or A**T (trans T). B has max(m,n) rows; it holds the right-hand
+// sides in its first m rows (trans N) or n rows on entry, and the solution
+// in its first n rows (trans N) or m rows on return. For an overdetermined
+// system, the residual sum of squares of each column of the solution is the
+// sum of squares of the rows of B after it. A is overwritten by its
+// factorization as computed by DGEQRF or DGELQF. A *SingularError is
+// returned, and no solution is computed, if the triangular factor has an
+// exactly zero diagonal element. work holds lwork >= max(1,k+max(k,nrhs))
+// elements, where k = min(m,n); the optimal lwork is returned in work[0] by
+// a call with lwork = -1 that does nothing else.
+func (impl Implementation) DGELS(trans blas.Transpose, m, n, nrhs int, a []float64, lda int, b []float64, ldb int, work []float64, lwork int) error {
+	if err := checkGels("DGELS", trans, m, n, nrhs, len(a), lda, len(b), ldb, len(work), lwork, false); err != nil {
+		return err
+	}
+	return singular("DGELS", gels(impl.bl(), trans, m, n, nrhs, a, lda, b, ldb, work, lwork))
+}
+
+// DGELSS computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with the singular value decomposition of A. B has max(m,n) rows; it holds
+// the m×nrhs right-hand sides on entry and the n×nrhs solution on return.
+// The singular values of A are returned in decreasing order in s, of length
+// min(m,n). Those not greater than rcond times the largest are treated as
+// zero, and rcond < 0 means machine precision. DGELSS returns the effective
+// rank of A, the number of singular values above that threshold. A is
+// destroyed. A *ConvergenceError is returned if the SVD failed to converge,
+// Info elements of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= 7*k+max(m,n,nrhs) elements, where k = min(m,n); the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) DGELSS(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, err error) {
+	if err := checkGelss("DGELSS", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, -1, -1, false); err != nil {
+		return 0, err
+	}
+	_, opt, lrwork := gelssWork(m, n, nrhs, false)
+	if lwork == -1 {
+		work[0] = float64(opt + lrwork)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), false, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work[lrwork:], lwork-lrwork, work[:lrwork], nil)
+	return rank, convergence("DGELSS", info)
+}
+
+// DGELSD computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| as DGELSS does, with the singular value
+// decomposition of A computed by divide and conquer, which is faster for
+// large matrices. Singular values not greater than rcond times the largest
+// are treated as zero, and rcond <= 0 or rcond >= 1 means machine precision.
+// DGELSD returns the effective rank of A. A is destroyed. A
+// *ConvergenceError is returned if the SVD of a subproblem failed to
+// converge. work holds lwork >=
+// 3*k+max(m,n,nrhs)+2*k*k+max(6*k*k+8*k,2*k*nrhs) elements, where
+// k = min(m,n), and iwork 3*k; the optimal lwork is returned in work[0] by a
+// call with lwork = -1 that does nothing else.
+func (impl Implementation) DGELSD(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int, iwork []int) (rank int, err error) {
+	if err := checkGelss("DGELSD", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, -1, len(iwork), true); err != nil {
+		return 0, err
+	}
+	_, opt, lrwork := gelssWork(m, n, nrhs, true)
+	if lwork == -1 {
+		work[0] = float64(opt + lrwork)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), true, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work[lrwork:], lwork-lrwork, work[:lrwork], iwork)
+	return rank, convergence("DGELSD", info)
+}
+
+// DGELSY computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with a complete orthogonal factorization of A. The QR factorization with
+// column pivoting A*P = Q*[R11 R12; 0 R22] of DGEQP3 is truncated at the
+// largest leading triangle R11 whose estimated condition number is less than
+// 1/rcond, R22 being treated as zero, and [R11 R12] is reduced to [T11 0]*Z
+// by DTZRZF. B has max(m,n) rows; it holds the m×nrhs right-hand sides on
+// entry and the n×nrhs solution on return. jpvt is as in DGEQP3: columns j
+// with jpvt[j] >= 0 on entry are moved to the front, the others must have
+// jpvt[j] = -1, and on return jpvt[j] is the column of A that is column j of
+// A*P. DGELSY returns the effective rank of A, the order of R11, and leaves
+// T11 in the leading triangle of A. work holds lwork >=
+// 2*n+k+max(2*k,n+1,k+nrhs) elements, where k = min(m,n); the optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DGELSY(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, jpvt []int, rcond float64, work []float64, lwork int) (rank int, err error) {
+	if err := checkGelsy("DGELSY", m, n, nrhs, len(a), lda, len(b), ldb, len(jpvt), len(work), lwork, -1); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt := gelsyWork(m, n, nrhs, false)
+		work[0] = float64(opt)
+		return 0, nil
+	}
+	return gelsy(impl.bl(), m, n, nrhs, a, lda, b, ldb, jpvt, float64(rcond), work[2*n:], lwork-2*n, work[:2*n]), nil
+}
+
+// CGELS solves the overdetermined or underdetermined linear system op(A)*X =
+// B in the least squares or minimum norm sense, where A is an m×n matrix of
+// full rank, with its QR (m >= n) or LQ (m < n) factorization. op(A) is A
+// (trans N) or A**H (trans C). B has max(m,n) rows; it holds the right-hand
+// sides in its first m rows (trans N) or n rows on entry, and the solution
+// in its first n rows (trans N) or m rows on return. For an overdetermined
+// system, the residual sum of squares of each column of the solution is the
+// sum of squares of the rows of B after it. A is overwritten by its
+// factorization as computed by CGEQRF or CGELQF. A *SingularError is
+// returned, and no solution is computed, if the triangular factor has an
+// exactly zero diagonal element. work holds lwork >= max(1,k+max(k,nrhs))
+// elements, where k = min(m,n); the optimal lwork is returned in work[0] by
+// a call with lwork = -1 that does nothing else.
+func (impl Implementation) CGELS(trans blas.Transpose, m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, work []complex64, lwork int) error {
+	if err := checkGels("CGELS", trans, m, n, nrhs, len(a), lda, len(b), ldb, len(work), lwork, true); err != nil {
+		return err
+	}
+	return singular("CGELS", gels(impl.bl(), trans, m, n, nrhs, a, lda, b, ldb, work, lwork))
+}
+
+// CGELSS computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with the singular value decomposition of A. B has max(m,n) rows; it holds
+// the m×nrhs right-hand sides on entry and the n×nrhs solution on return.
+// The singular values of A are returned in decreasing order in s, of length
+// min(m,n). Those not greater than rcond times the largest are treated as
+// zero, and rcond < 0 means machine precision. CGELSS returns the effective
+// rank of A, the number of singular values above that threshold. A is
+// destroyed. A *ConvergenceError is returned if the SVD failed to converge,
+// Info elements of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= 2*k+max(m,n,nrhs) elements, where k = min(m,n), and
+// rwork 5*k; the optimal lwork is returned in work[0] by a call with
+// lwork = -1 that does nothing else.
+func (impl Implementation) CGELSS(m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, s []float32, rcond float32, work []complex64, lwork int, rwork []float32) (rank int, err error) {
+	if err := checkGelss("CGELSS", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, len(rwork), -1, false); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt, _ := gelssWork(m, n, nrhs, false)
+		work[0] = complex(float32(opt), 0)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), false, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work, lwork, rwork, nil)
+	return rank, convergence("CGELSS", info)
+}
+
+// CGELSD computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| as CGELSS does, with the singular value
+// decomposition of A computed by divide and conquer, which is faster for
+// large matrices. Singular values not greater than rcond times the largest
+// are treated as zero, and rcond <= 0 or rcond >= 1 means machine precision.
+// CGELSD returns the effective rank of A. A is destroyed. A
+// *ConvergenceError is returned if the SVD of a subproblem failed to
+// converge. work holds lwork >= 2*k+max(m,n,nrhs) elements, where
+// k = min(m,n), rwork k*(2*k+1)+max(6*k*k+8*k,2*k*nrhs) and iwork 3*k; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) CGELSD(m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, s []float32, rcond float32, work []complex64, lwork int, rwork []float32, iwork []int) (rank int, err error) {
+	if err := checkGelss("CGELSD", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, len(rwork), len(iwork), true); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt, _ := gelssWork(m, n, nrhs, true)
+		work[0] = complex(float32(opt), 0)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), true, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work, lwork, rwork, iwork)
+	return rank, convergence("CGELSD", info)
+}
+
+// CGELSY computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with a complete orthogonal factorization of A. The QR factorization with
+// column pivoting A*P = Q*[R11 R12; 0 R22] of CGEQP3 is truncated at the
+// largest leading triangle R11 whose estimated condition number is less than
+// 1/rcond, R22 being treated as zero, and [R11 R12] is reduced to [T11 0]*Z
+// by CTZRZF. B has max(m,n) rows; it holds the m×nrhs right-hand sides on
+// entry and the n×nrhs solution on return. jpvt is as in CGEQP3: columns j
+// with jpvt[j] >= 0 on entry are moved to the front, the others must have
+// jpvt[j] = -1, and on return jpvt[j] is the column of A that is column j of
+// A*P. CGELSY returns the effective rank of A, the order of R11, and leaves
+// T11 in the leading triangle of A. work holds lwork >=
+// k+max(2*k,n+1,k+nrhs) elements, where k = min(m,n), and rwork 2*n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) CGELSY(m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, jpvt []int, rcond float32, work []complex64, lwork int, rwork []float32) (rank int, err error) {
+	if err := checkGelsy("CGELSY", m, n, nrhs, len(a), lda, len(b), ldb, len(jpvt), len(work), lwork, len(rwork)); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt := gelsyWork(m, n, nrhs, true)
+		work[0] = complex(float32(opt), 0)
+		return 0, nil
+	}
+	return gelsy(impl.bl(), m, n, nrhs, a, lda, b, ldb, jpvt, float64(rcond), work, lwork, rwork), nil
+}
+
+// ZGELS solves the overdetermined or underdetermined linear system op(A)*X =
+// B in the least squares or minimum norm sense, where A is an m×n matrix of
+// full rank, with its QR (m >= n) or LQ (m < n) factorization. op(A) is A
+// (trans N) or A**H (trans C). B has max(m,n) rows; it holds the right-hand
+// sides in its first m rows (trans N) or n rows on entry, and the solution
+// in its first n rows (trans N) or m rows on return. For an overdetermined
+// system, the residual sum of squares of each column of the solution is the
+// sum of squares of the rows of B after it. A is overwritten by its
+// factorization as computed by ZGEQRF or ZGELQF. A *SingularError is
+// returned, and no solution is computed, if the triangular factor has an
+// exactly zero diagonal element. work holds lwork >= max(1,k+max(k,nrhs))
+// elements, where k = min(m,n); the optimal lwork is returned in work[0] by
+// a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZGELS(trans blas.Transpose, m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, work []complex128, lwork int) error {
+	if err := checkGels("ZGELS", trans, m, n, nrhs, len(a), lda, len(b), ldb, len(work), lwork, true); err != nil {
+		return err
+	}
+	return singular("ZGELS", gels(impl.bl(), trans, m, n, nrhs, a, lda, b, ldb, work, lwork))
+}
+
+// ZGELSS computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with the singular value decomposition of A. B has max(m,n) rows; it holds
+// the m×nrhs right-hand sides on entry and the n×nrhs solution on return.
+// The singular values of A are returned in decreasing order in s, of length
+// min(m,n). Those not greater than rcond times the largest are treated as
+// zero, and rcond < 0 means machine precision. ZGELSS returns the effective
+// rank of A, the number of singular values above that threshold. A is
+// destroyed. A *ConvergenceError is returned if the SVD failed to converge,
+// Info elements of an intermediate bidiagonal form not converging to zero.
+// work holds lwork >= 2*k+max(m,n,nrhs) elements, where k = min(m,n), and
+// rwork 5*k; the optimal lwork is returned in work[0] by a call with
+// lwork = -1 that does nothing else.
+func (impl Implementation) ZGELSS(m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, s []float64, rcond float64, work []complex128, lwork int, rwork []float64) (rank int, err error) {
+	if err := checkGelss("ZGELSS", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, len(rwork), -1, false); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt, _ := gelssWork(m, n, nrhs, false)
+		work[0] = complex(float64(opt), 0)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), false, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work, lwork, rwork, nil)
+	return rank, convergence("ZGELSS", info)
+}
+
+// ZGELSD computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| as ZGELSS does, with the singular value
+// decomposition of A computed by divide and conquer, which is faster for
+// large matrices. Singular values not greater than rcond times the largest
+// are treated as zero, and rcond <= 0 or rcond >= 1 means machine precision.
+// ZGELSD returns the effective rank of A. A is destroyed. A
+// *ConvergenceError is returned if the SVD of a subproblem failed to
+// converge. work holds lwork >= 2*k+max(m,n,nrhs) elements, where
+// k = min(m,n), rwork k*(2*k+1)+max(6*k*k+8*k,2*k*nrhs) and iwork 3*k; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) ZGELSD(m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, s []float64, rcond float64, work []complex128, lwork int, rwork []float64, iwork []int) (rank int, err error) {
+	if err := checkGelss("ZGELSD", m, n, nrhs, len(a), lda, len(b), ldb, len(s), len(work), lwork, len(rwork), len(iwork), true); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt, _ := gelssWork(m, n, nrhs, true)
+		work[0] = complex(float64(opt), 0)
+		return 0, nil
+	}
+	rank, info := gelss(impl.bl(), true, m, n, nrhs, a, lda, b, ldb, s, float64(rcond), work, lwork, rwork, iwork)
+	return rank, convergence("ZGELSD", info)
+}
+
+// ZGELSY computes the minimum norm solution to the linear least squares
+// problem min ||B - A*X|| for the m×n matrix A, which may be rank deficient,
+// with a complete orthogonal factorization of A. The QR factorization with
+// column pivoting A*P = Q*[R11 R12; 0 R22] of ZGEQP3 is truncated at the
+// largest leading triangle R11 whose estimated condition number is less than
+// 1/rcond, R22 being treated as zero, and [R11 R12] is reduced to [T11 0]*Z
+// by ZTZRZF. B has max(m,n) rows; it holds the m×nrhs right-hand sides on
+// entry and the n×nrhs solution on return. jpvt is as in ZGEQP3: columns j
+// with jpvt[j] >= 0 on entry are moved to the front, the others must have
+// jpvt[j] = -1, and on return jpvt[j] is the column of A that is column j of
+// A*P. ZGELSY returns the effective rank of A, the order of R11, and leaves
+// T11 in the leading triangle of A. work holds lwork >=
+// k+max(2*k,n+1,k+nrhs) elements, where k = min(m,n), and rwork 2*n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) ZGELSY(m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, jpvt []int, rcond float64, work []complex128, lwork int, rwork []float64) (rank int, err error) {
+	if err := checkGelsy("ZGELSY", m, n, nrhs, len(a), lda, len(b), ldb, len(jpvt), len(work), lwork, len(rwork)); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		_, opt := gelsyWork(m, n, nrhs, true)
+		work[0] = complex(float64(opt), 0)
+		return 0, nil
+	}
+	return gelsy(impl.bl(), m, n, nrhs, a, lda, b, ldb, jpvt, float64(rcond), work, lwork, rwork), nil
+}
+
+// checkGels checks the GELS routines.
+func checkGels(routine string, trans blas.Transpose, m, n, nrhs, lenA, lda, lenB, ldb, lenWork, lwork int, complex bool) error {
+	c := checker{routine: routine}
+	c.transQ(1, trans, complex)
+	c.nonNeg(2, "m", m)
+	c.nonNeg(3, "n", n)
+	c.nonNeg(4, "nrhs", nrhs)
+	c.ld(6, "lda", lda, m, "m")
+	c.ld(8, "ldb", ldb, max(m, n), "m,n")
+	minWork, _ := gelsWork(m, n, nrhs)
+	c.lwork(10, lwork, minWork, "min(m,n)+max(min(m,n),nrhs)")
+	if c.ok() {
+		c.work(9, lenWork, lwork)
+		if lwork != -1 {
+			c.length(5, "a", lenA, matLen(m, n, lda))
+			c.length(7, "b", lenB, matLen(max(m, n), nrhs, ldb))
+		}
+	}
+	return c.result()
+}
+
+// checkGelss checks the GELSS routines, and the GELSD routines when dc is
+// true. The real routines keep their real workspace in work and pass -1 for
+// lenRwork; the GELSS routines pass -1 for lenIwork.
+func checkGelss(routine string, m, n, nrhs, lenA, lda, lenB, ldb, lenS, lenWork, lwork, lenRwork, lenIwork int, dc bool) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.nonNeg(3, "nrhs", nrhs)
+	c.ld(5, "lda", lda, m, "m")
+	c.ld(7, "ldb", ldb, max(m, n), "m,n")
+	minWork, _, minRwork := gelssWork(m, n, nrhs, dc)
+	complex := lenRwork >= 0
+	switch {
+	case complex:
+		c.lwork(11, lwork, minWork, "2*min(m,n)+max(m,n,nrhs)")
+	case dc:
+		c.lwork(11, lwork, minWork+minRwork, "3*k+max(m,n,nrhs)+2*k*k+max(6*k*k+8*k,2*k*nrhs), k = min(m,n)")
+	default:
+		c.lwork(11, lwork, minWork+minRwork, "7*min(m,n)+max(m,n,nrhs)")
+	}
+	if c.ok() {
+		c.work(10, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(m, n, lda))
+			c.length(6, "b", lenB, matLen(max(m, n), nrhs, ldb))
+			c.length(8, "s", lenS, min(m, n))
+			iwork := 12
+			if complex {
+				c.length(12, "rwork", lenRwork, minRwork)
+				iwork = 13
+			}
+			if dc {
+				c.length(iwork, "iwork", lenIwork, 3*min(m, n))
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkGelsy checks the GELSY routines. The real routines keep the column
+// norms of GEQP3 in work and pass -1 for lenRwork.
+func checkGelsy(routine string, m, n, nrhs, lenA, lda, lenB, ldb, lenJpvt, lenWork, lwork, lenRwork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.nonNeg(3, "nrhs", nrhs)
+	c.ld(5, "lda", lda, m, "m")
+	c.ld(7, "ldb", ldb, max(m, n), "m,n")
+	minWork, _ := gelsyWork(m, n, nrhs, lenRwork >= 0)
+	if lenRwork < 0 {
+		c.lwork(11, lwork, minWork, "2*n+k+max(2*k,n+1,k+nrhs), k = min(m,n)")
+	} else {
+		c.lwork(11, lwork, minWork, "k+max(2*k,n+1,k+nrhs), k = min(m,n)")
+	}
+	if c.ok() {
+		c.work(10, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(m, n, lda))
+			c.length(6, "b", lenB, matLen(max(m, n), nrhs, ldb))
+			c.length(8, "jpvt", lenJpvt, n)
+			if lenRwork >= 0 {
+				c.length(12, "rwork", lenRwork, 2*n)
+			}
+		}
+	}
+	return c.result()
+}
+
+// lsScale returns the norm to which the least squares drivers scale a
+// matrix of max-norm nrm that is outside [smlnum,bignum], or 0 if nrm is
+// zero or in range.
+func lsScale(nrm, smlnum, bignum float64) float64 {
+	switch {
+	case nrm > 0 && nrm < smlnum:
+		return smlnum
+	case nrm > bignum:
+		return bignum
+	}
+	return 0
+}
+
+// gelsWork returns the minimum and optimal workspace lengths of gels.
+func gelsWork(m, n, nrhs int) (minWork, opt int) {
+	mn := min(m, n)
+	minWork = max(1, mn+max(mn, nrhs))
+	if mn == 0 {
+		return minWork, minWork
+	}
+	return minWork, max(minWork, mn+max(mn*qrBlock, ormWork(blas.SideL, 0, nrhs)))
+}
+
+// gels solves the overdetermined or underdetermined system op(A)*X = B,
+// where A is an m×n matrix of full rank, in the least squares or minimum
+// norm sense with the QR or LQ factorization of A, as xGELS. B has
+// max(m,n) rows. gels returns the index of the first zero diagonal element
+// of the triangular factor, leaving no solution in B, or -1. work holds
+// lwork >= min(m,n)+max(min(m,n),nrhs) elements, and the block size is
+// reduced to fit. A workspace query, lwork = -1, only sets work[0] to the
+// optimal lwork.
+func gels[T gen.Scalar](bl blas.BLAS, trans blas.Transpose, m, n, nrhs int, a []T, lda int, b []T, ldb int, work []T, lwork int) (info int) {
+	if lwork == -1 {
+		_, opt := gelsWork(m, n, nrhs)
+		work[0] = fromReal[T](float64(opt))
+		return -1
+	}
+	mn := min(m, n)
+	if mn == 0 || nrhs == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		return -1
+	}
+
+	// Scale A and B to the range [smlnum,bignum] if needed.
+	smlnum := safmin[T]() / eps[T]()
+	bignum := 1 / smlnum
+	anrm := lange(normMax, m, n, a, lda)
+	if anrm == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		return -1
+	}
+	ascl := lsScale(anrm, smlnum, bignum)
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, m, n, a, lda)
+	}
+	notran := trans == blas.TransN
+	brow := m
+	if !notran {
+		brow = n
+	}
+	bnrm := lange(normMax, brow, nrhs, b, ldb)
+	bscl := lsScale(bnrm, smlnum, bignum)
+	if bscl != 0 {
+		lascl(uploAll, bnrm, bscl, brow, nrhs, b, ldb)
+	}
+
+	tau, w, lw := work[:mn], work[mn:], lwork-mn
+	scllen := m
+	if m >= n {
+		geqrf(bl, m, n, a, lda, tau, w, lw)
+		if notran {
+			// B(0:n,:) := inv(R)*Q**H*B.
+			ormqr(bl, blas.SideL, blas.TransC, m, nrhs, n, a, lda, tau, b, ldb, w, lw)
+			if info = trtrs(bl, blas.UploU, blas.TransN, blas.DiagN, n, nrhs, a, lda, b, ldb); info >= 0 {
+				return info
+			}
+			scllen = n
+		} else {
+			// B(0:m,:) := Q*[inv(R**H)*B; 0].
+			if info = trtrs(bl, blas.UploU, blas.TransC, blas.DiagN, n, nrhs, a, lda, b, ldb); info >= 0 {
+				return info
+			}
+			laset(uploAll, m-n, nrhs, 0, 0, b[n:], ldb)
+			ormqr(bl, blas.SideL, blas.TransN, m, nrhs, n, a, lda, tau, b, ldb, w, lw)
+		}
+	} else {
+		gelqf(bl, m, n, a, lda, tau, w, lw)
+		if notran {
+			// B(0:n,:) := Q**H*[inv(L)*B; 0].
+			if info = trtrs(bl, blas.UploL, blas.TransN, blas.DiagN, m, nrhs, a, lda, b, ldb); info >= 0 {
+				return info
+			}
+			laset(uploAll, n-m, nrhs, 0, 0, b[m:], ldb)
+			ormlq(bl, blas.SideL, blas.TransC, n, nrhs, m, a, lda, tau, b, ldb, w, lw)
+			scllen = n
+		} else {
+			// B(0:m,:) := inv(L**H)*Q*B.
+			ormlq(bl, blas.SideL, blas.TransN, n, nrhs, m, a, lda, tau, b, ldb, w, lw)
+			if info = trtrs(bl, blas.UploL, blas.TransC, blas.DiagN, m, nrhs, a, lda, b, ldb); info >= 0 {
+				return info
+			}
+		}
+	}
+
+	// Undo the scaling.
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, scllen, nrhs, b, ldb)
+	}
+	if bscl != 0 {
+		lascl(uploAll, bscl, bnrm, scllen, nrhs, b, ldb)
+	}
+	return -1
+}
+
+// gelssWork returns the minimum and optimal lengths of the workspace work
+// of gelss, and the length of its real workspace rwork, for the SVD by QR
+// iteration or by divide and conquer (dc). The real routines keep rwork at
+// the start of work.
+func gelssWork(m, n, nrhs int, dc bool) (minWork, opt, lrwork int) {
+	k := min(m, n)
+	minWork = max(1, 2*k+max(m, n, nrhs))
+	if k == 0 {
+		return minWork, minWork, 0
+	}
+	lrwork = 5 * k
+	if dc {
+		lrwork = k + lalsdWork(k, nrhs)
+	}
+	// The reduction to bidiagonal form of the m×n matrix A, or of its
+	// triangular factor when one dimension is much larger than the other,
+	// and the product by the singular vectors.
+	brd := func(m, n int) int {
+		w := max(gebrdWork(m, n), ormWork(blas.SideL, 0, nrhs))
+		if !dc {
+			w = max(w, orgbrWork(k, n), n*nrhs)
+		}
+		return 2*k + w
+	}
+	mnthr := gelssCrossover(k)
+	switch {
+	case m >= n && m >= mnthr:
+		opt = max(n+max(geqrfWork(m, n), ormWork(blas.SideL, 0, nrhs)), brd(n, n))
+	case m < n && n >= mnthr:
+		opt = max(brd(m, n), m+m*m+max(m*qrBlock, brd(m, m), ormWork(blas.SideL, 0, nrhs)))
+	default:
+		opt = brd(m, n)
+	}
+	return minWork, max(minWork, opt), lrwork
+}
+
+// gelssCrossover returns the ratio of the dimensions of A above which gelss
+// first computes its QR or LQ factorization, the value of ILAENV for
+// xGELSS.
+func gelssCrossover(minmn int) int {
+	return int(1.6 * float64(minmn))
+}
+
+// gelss computes the minimum norm solution of the least squares problem
+// min ||B - A*X|| for the m×n matrix A, which may be rank deficient, with
+// the SVD of A computed by QR iteration, as xGELSS, or by divide and
+// conquer when dc is true, as xGELSD. B has max(m,n) rows. The singular
+// values are returned in s in decreasing order, and those not greater than
+// rcond times the largest are treated as zero, with the meaning of a
+// non-positive rcond of xGELSS or xGELSD. gelss returns the effective rank
+// of A and 0, or the info of bdsqr or lasd0 if the SVD failed to converge.
+// work holds lwork elements and rwork lrwork, as returned by gelssWork, and
+// iwork 3*min(m,n) when dc is true. The LQ factorization of a matrix with
+// many more columns than rows is used only if lwork holds its triangular
+// factor.
+func gelss[T gen.Scalar, R gen.Float](bl blas.BLAS, dc bool, m, n, nrhs int, a []T, lda int, b []T, ldb int, s []R, rcond float64, work []T, lwork int, rwork []R, iwork []int) (rank, info int) {
+	k := min(m, n)
+	if k == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		return 0, 0
+	}
+
+	// Scale A and B to the range [smlnum,bignum] if needed.
+	smlnum := safmin[T]() / eps[T]()
+	bignum := 1 / smlnum
+	anrm := lange(normMax, m, n, a, lda)
+	if anrm == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		for i := range s[:k] {
+			s[i] = 0
+		}
+		return 0, 0
+	}
+	ascl := lsScale(anrm, smlnum, bignum)
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, m, n, a, lda)
+	}
+	bnrm := lange(normMax, m, nrhs, b, ldb)
+	bscl := lsScale(bnrm, smlnum, bignum)
+	if bscl != 0 {
+		lascl(uploAll, bnrm, bscl, m, nrhs, b, ldb)
+	}
+
+	mnthr := gelssCrossover(k)
+	switch {
+	case m >= n && m >= mnthr:
+		// Solve the problem for the triangular factor R of A = Q*R, with
+		// Q**H*B in place of B.
+		tau, w, lw := work[:n], work[n:], lwork-n
+		geqrf(bl, m, n, a, lda, tau, w, lw)
+		ormqr(bl, blas.SideL, blas.TransC, m, nrhs, n, a, lda, tau, b, ldb, w, lw)
+		laset(blas.UploL, n-1, n-1, 0, 0, a[1:], lda)
+		rank, info = gelssBrd(bl, dc, n, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, rwork, iwork)
+	case m < n && n >= mnthr && lwork >= m+m*m+2*m+max(m, nrhs):
+		// Solve the problem for the triangular factor L of A = L*Q, held
+		// in work, and compute the solution Q**H*[X; 0].
+		tau, l := work[:m], work[m:m+m*m]
+		gelqf(bl, m, n, a, lda, tau, work[m:], lwork-m)
+		lacpy(blas.UploL, m, m, a, lda, l, m)
+		laset(blas.UploU, m-1, m-1, 0, 0, l[m:], m)
+		w, lw := work[m+m*m:], lwork-m-m*m
+		rank, info = gelssBrd(bl, dc, m, m, nrhs, l, m, b, ldb, s, rcond, w, lw, rwork, iwork)
+		if info == 0 && nrhs > 0 {
+			laset(uploAll, n-m, nrhs, 0, 0, b[m:], ldb)
+			ormlq(bl, blas.SideL, blas.TransC, n, nrhs, m, a, lda, tau, b, ldb, work[m:], lwork-m)
+		}
+	default:
+		rank, info = gelssBrd(bl, dc, m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, rwork, iwork)
+	}
+
+	// Undo the scaling.
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, n, nrhs, b, ldb)
+		lascl(uploAll, ascl, anrm, k, 1, s, k)
+	}
+	if bscl != 0 {
+		lascl(uploAll, bscl, bnrm, n, nrhs, b, ldb)
+	}
+	return rank, info
+}
+
+// gelssBrd solves the least squares problem of gelss for the m×n matrix A
+// by its reduction to bidiagonal form. work holds lwork >=
+// 2*min(m,n)+max(m,n,nrhs) elements.
+func gelssBrd[T gen.Scalar, R gen.Float](bl blas.BLAS, dc bool, m, n, nrhs int, a []T, lda int, b []T, ldb int, s []R, rcond float64, work []T, lwork int, rwork []R, iwork []int) (rank, info int) {
+	k := min(m, n)
+	e := rwork[:k]
+	tauq, taup := work[:k], work[k:2*k]
+	w, lw := work[2*k:], lwork-2*k
+
+	// Reduce A to bidiagonal form B = Q**H*A*P and B to Q**H*B.
+	gebrd(bl, m, n, a, lda, s, e, tauq, taup, w, lw)
+	ormbr(bl, VectQ, blas.SideL, blas.TransC, m, nrhs, n, a, lda, tauq, b, ldb, w, lw)
+	uplo := blas.UploU
+	if m < n {
+		uplo = blas.UploL
+	}
+
+	if dc {
+		// Solve the bidiagonal least squares problem and multiply the
+		// solution by P.
+		if rank, info = lalsd(bl, uplo, k, nrhs, s, e, b, ldb, rcond, rwork[k:], iwork); info > 0 {
+			return 0, info
+		}
+		if m < n && nrhs > 0 {
+			laset(uploAll, n-m, nrhs, 0, 0, b[m:], ldb)
+		}
+		ormbr(bl, VectP, blas.SideL, blas.TransN, n, nrhs, k, a, lda, taup, b, ldb, w, lw)
+		return rank, 0
+	}
+
+	// Compute the SVD of the bidiagonal matrix with the right singular
+	// vectors in A and U**H*B in place of B.
+	orgbr(bl, VectP, k, n, m, a, lda, taup, w, lw)
+	if info = bdsqr(bl, uplo, k, n, 0, nrhs, s, e, a, lda, nil, 1, b, ldb, rwork[k:]); info > 0 {
+		return 0, info
+	}
+
+	// Divide B by the singular values above the threshold and zero the
+	// rest.
+	sfmin := safmin[T]()
+	thr := max(rcond*float64(s[0]), sfmin)
+	if rcond < 0 {
+		thr = max(eps[T]()*float64(s[0]), sfmin)
+	}
+	for rank < k && float64(s[rank]) > thr {
+		rank++
+	}
+	for j := 0; j < nrhs; j++ {
+		bj := b[j*ldb : j*ldb+k]
+		for i := range bj {
+			if i < rank {
+				bj[i] *= fromReal[T](1 / float64(s[i]))
+			} else {
+				bj[i] = 0
+			}
+		}
+	}
+
+	// B := VT**H*B in blocks of columns through work.
+	nb := lwork / n
+	for j := 0; j < nrhs; j += nb {
+		jb := min(nb, nrhs-j)
+		gemm(bl, blas.TransC, blas.TransN, n, jb, k, 1, a, lda, b[j*ldb:], ldb, 0, work, n)
+		lacpy(uploAll, n, jb, work, n, b[j*ldb:], ldb)
+	}
+	return rank, 0
+}
+
+// lalsdWork returns the length of the workspace of lalsd.
+func lalsdWork(n, nrhs int) int {
+	return 2*n*n + max(lasd0Work(n), 2*n*nrhs)
+}
+
+// lalsd solves the least squares problem min ||B - D*X|| for the n×nrhs
+// matrix X, which overwrites B, where D is the n×n real upper (uplo U) or
+// lower (uplo L) bidiagonal matrix with diagonal d and off-diagonal e, with
+// the SVD of D computed by lasd0, as xLALSD. Singular values not greater
+// than rcond times the largest are treated as zero, and rcond <= 0 or
+// rcond >= 1 means machine precision. On return d holds the singular values
+// in decreasing order and e is destroyed. lalsd returns the effective rank
+// of D and 0, or the info of lasd0 if it failed to converge. work holds
+// lalsdWork(n,nrhs) elements and iwork 3*n.
+func lalsd[T gen.Scalar, R gen.Float](bl blas.BLAS, uplo blas.Uplo, n, nrhs int, d, e []R, b []T, ldb int, rcond float64, work []R, iwork []int) (rank, info int) {
+	if n == 0 {
+		return 0, 0
+	}
+	if rcond <= 0 || rcond >= 1 {
+		rcond = eps[T]()
+	}
+	if n == 1 {
+		if d[0] == 0 {
+			laset(uploAll, 1, nrhs, 0, 0, b, ldb)
+			return 0, 0
+		}
+		lascl(uploAll, float64(d[0]), 1, 1, nrhs, b, ldb)
+		d[0] = R(math.Abs(float64(d[0])))
+		return 1, 0
+	}
+
+	if uplo == blas.UploL {
+		// Rotate the matrix to upper bidiagonal form, applying the
+		// rotations to B from the left.
+		for i := 0; i < n-1; i++ {
+			c, s, r := lartg(float64(d[i]), float64(e[i]))
+			d[i] = R(r)
+			e[i] = R(s * float64(d[i+1]))
+			d[i+1] = R(c * float64(d[i+1]))
+			if nrhs > 0 {
+				rrot(bl, nrhs, b[i:], ldb, b[i+1:], ldb, c, s)
+			}
+		}
+	}
+
+	// Scale the matrix to unit max-norm.
+	orgnrm := lanst(normMax, n, d, e)
+	if orgnrm == 0 {
+		laset(uploAll, n, nrhs, 0, 0, b, ldb)
+		return 0, 0
+	}
+	lascl(uploAll, orgnrm, 1, n, 1, d, n)
+	lascl(uploAll, orgnrm, 1, n-1, 1, e, n-1)
+
+	// Compute D = U*S*VT and the solution VT**T*inv(S)*U**T*B, with the
+	// singular values not above the threshold treated as zero.
+	u, vt, w := work[:n*n], work[n*n:2*n*n], work[2*n*n:]
+	if info = lasd0(bl, n, 0, d, e, u, n, vt, n, w, iwork); info > 0 {
+		return 0, info
+	}
+	svdSort(bl, n, d, n, u, n, n, vt, n)
+	realMul(bl, n, nrhs, u, n, b, ldb, w)
+	tol := rcond * float64(d[0])
+	for rank < n && float64(d[rank]) > tol {
+		rank++
+	}
+	for j := 0; j < nrhs; j++ {
+		bj := b[j*ldb : j*ldb+n]
+		for i := range bj {
+			if i < rank {
+				bj[i] *= fromReal[T](1 / float64(d[i]))
+			} else {
+				bj[i] = 0
+			}
+		}
+	}
+	realMul(bl, n, nrhs, vt, n, b, ldb, w)
+
+	// Undo the scaling.
+	lascl(uploAll, 1, orgnrm, n, 1, d, n)
+	lascl(uploAll, orgnrm, 1, n, nrhs, b, ldb)
+	return rank, 0
+}
+
+// realMul overwrites the n×nrhs matrix B by X**T*B, where X is a real n×n
+// matrix, one part of B at a time through w, which holds 2*n*nrhs elements.
+func realMul[T gen.Scalar, R gen.Float](bl blas.BLAS, n, nrhs int, x []R, ldx int, b []T, ldb int, w []R) {
+	p, q := w[:n*nrhs], w[n*nrhs:2*n*nrhs]
+	for j := 0; j < nrhs; j++ {
+		for i := 0; i < n; i++ {
+			p[i+j*n] = R(re(b[i+j*ldb]))
+		}
+	}
+	gemm(bl, blas.TransT, blas.TransN, n, nrhs, n, 1, x, ldx, p, n, 0, q, n)
+	for j := 0; j < nrhs; j++ {
+		for i := 0; i < n; i++ {
+			bij := b[i+j*ldb]
+			b[i+j*ldb] = fromParts[T](float64(q[i+j*n]), im(bij))
+			p[i+j*n] = R(im(bij))
+		}
+	}
+	if !isComplex[T]() {
+		return
+	}
+	gemm(bl, blas.TransT, blas.TransN, n, nrhs, n, 1, x, ldx, p, n, 0, q, n)
+	for j := 0; j < nrhs; j++ {
+		for i := 0; i < n; i++ {
+			b[i+j*ldb] = fromParts[T](re(b[i+j*ldb]), float64(q[i+j*n]))
+		}
+	}
+}
+
+// gelsyWork returns the minimum and optimal workspace lengths of gelsy. The
+// real routines also keep the 2*n column norms of geqp3 in work.
+func gelsyWork(m, n, nrhs int, complex bool) (minWork, opt int) {
+	mn := min(m, n)
+	minWork = max(1, mn+max(2*mn, n+1, mn+nrhs))
+	_, qp3 := geqp3Work(m, n, true)
+	opt = max(minWork, mn+qp3, 2*mn+max(mn*qrBlock, ormWork(blas.SideL, 0, nrhs)))
+	if !complex {
+		return minWork + 2*n, opt + 2*n
+	}
+	return minWork, opt
+}
+
+// gelsy computes the minimum norm solution of the least squares problem
+// min ||B - A*X|| for the m×n matrix A, which may be rank deficient, with a
+// complete orthogonal factorization of A, as xGELSY. The QR factorization
+// with column pivoting A*P = Q*[R11 R12; 0 R22] of geqp3 is truncated at
+// the largest leading triangle R11 whose condition number, estimated by
+// laic1, is less than 1/rcond, and [R11 R12] is reduced to [T11 0]*Z by
+// tzrzf. B has max(m,n) rows. jpvt is as in geqp3. gelsy returns the
+// effective rank of A, the order of R11. work holds lwork >=
+// min(m,n)+max(2*min(m,n),n+1,min(m,n)+nrhs) elements and rwork 2*n.
+func gelsy[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n, nrhs int, a []T, lda int, b []T, ldb int, jpvt []int, rcond float64, work []T, lwork int, rwork []R) (rank int) {
+	mn := min(m, n)
+	if mn == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		return 0
+	}
+
+	// Scale A and B to the range [smlnum,bignum] if needed.
+	smlnum := safmin[T]() / eps[T]()
+	bignum := 1 / smlnum
+	anrm := lange(normMax, m, n, a, lda)
+	if anrm == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		return 0
+	}
+	ascl := lsScale(anrm, smlnum, bignum)
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, m, n, a, lda)
+	}
+	bnrm := lange(normMax, m, nrhs, b, ldb)
+	bscl := lsScale(bnrm, smlnum, bignum)
+	if bscl != 0 {
+		lascl(uploAll, bnrm, bscl, m, nrhs, b, ldb)
+	}
+
+	tau := work[:mn]
+	geqp3(bl, m, n, a, lda, jpvt, tau, work[mn:], lwork-mn, rwork[:n], rwork[n:2*n])
+
+	// Determine the rank by incremental condition estimation of the
+	// leading triangles of R, with the approximate singular vectors of the
+	// smallest and largest singular values in xmin and xmax.
+	xmin, xmax := work[mn:2*mn], work[2*mn:3*mn]
+	xmin[0], xmax[0] = 1, 1
+	smax := abs(a[0])
+	smin := smax
+	if smax == 0 {
+		laset(uploAll, max(m, n), nrhs, 0, 0, b, ldb)
+		return 0
+	}
+	for rank = 1; rank < mn; rank++ {
+		col, gamma := a[rank*lda:], a[rank+rank*lda]
+		sminpr, s1, c1 := laic1(bl, 2, rank, xmin, smin, col, gamma)
+		smaxpr, s2, c2 := laic1(bl, 1, rank, xmax, smax, col, gamma)
+		if smaxpr*rcond > sminpr {
+			break
+		}
+		for i := 0; i < rank; i++ {
+			xmin[i] *= s1
+			xmax[i] *= s2
+		}
+		xmin[rank], xmax[rank] = c1, c2
+		smin, smax = sminpr, smaxpr
+	}
+
+	// Reduce [R11 R12] to [T11 0]*Z, with the reflectors of Z in place of
+	// xmin.
+	tauz, w, lw := work[mn:2*mn], work[2*mn:], lwork-2*mn
+	if rank < n {
+		tzrzf(bl, rank, n, a, lda, tauz, w, lw)
+	}
+
+	// B(0:n,:) := P*Z**H*[inv(T11)*Q**H*B; 0].
+	ormqr(bl, blas.SideL, blas.TransC, m, nrhs, mn, a, lda, tau, b, ldb, w, lw)
+	trsm(bl, blas.SideL, blas.UploU, blas.TransN, blas.DiagN, rank, nrhs, 1, a, lda, b, ldb)
+	if nrhs > 0 {
+		laset(uploAll, n-rank, nrhs, 0, 0, b[rank:], ldb)
+	}
+	if rank < n {
+		ormrz(bl, blas.SideL, blas.TransC, n, nrhs, rank, n-rank, a, lda, tauz, b, ldb, w, lw)
+	}
+	for j := 0; j < nrhs; j++ {
+		bj := b[j*ldb:]
+		for i := 0; i < n; i++ {
+			work[jpvt[i]] = bj[i]
+		}
+		copyVec(bl, n, work, 1, bj, 1)
+	}
+
+	// Undo the scaling.
+	if ascl != 0 {
+		lascl(uploAll, anrm, ascl, n, nrhs, b, ldb)
+		lascl(blas.UploU, ascl, anrm, rank, rank, a, lda)
+	}
+	if bscl != 0 {
+		lascl(uploAll, bscl, bnrm, n, nrhs, b, ldb)
+	}
+	return rank
+}
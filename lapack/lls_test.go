@@ -0,0 +1,364 @@
+package lapack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// llsRoutines holds the least squares routines of one precision, with the
+// rwork and iwork of their complex and divide and conquer versions
+// allocated by the test.
+type llsRoutines[T gen.Scalar, R gen.Float] struct {
+	gels  func(trans blas.Transpose, m, n, nrhs int, a []T, lda int, b []T, ldb int, work []T, lwork int) error
+	gelss func(m, n, nrhs int, a []T, lda int, b []T, ldb int, s []R, rcond R, work []T, lwork int) (int, error)
+	gelsd func(m, n, nrhs int, a []T, lda int, b []T, ldb int, s []R, rcond R, work []T, lwork int) (int, error)
+	gelsy func(m, n, nrhs int, a []T, lda int, b []T, ldb int, jpvt []int, rcond R, work []T, lwork int) (int, error)
+}
+
+// gelsdRwork returns the length of the rwork of the complex GELSD routines.
+func gelsdRwork(m, n, nrhs int) int {
+	k := min(m, n)
+	return k*(2*k+1) + max(6*k*k+8*k, 2*k*nrhs)
+}
+
+func TestLLS(t *testing.T) {
+	var impl Implementation
+	testLLS(t, "S", llsRoutines[float32, float32]{impl.SGELS, impl.SGELSS,
+		func(m, n, nrhs int, a []float32, lda int, b []float32, ldb int, s []float32, rcond float32, work []float32, lwork int) (int, error) {
+			return impl.SGELSD(m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, make([]int, 3*min(m, n)))
+		}, impl.SGELSY})
+	testLLS(t, "D", llsRoutines[float64, float64]{impl.DGELS, impl.DGELSS,
+		func(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (int, error) {
+			return impl.DGELSD(m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, make([]int, 3*min(m, n)))
+		}, impl.DGELSY})
+	testLLS(t, "C", llsRoutines[complex64, float32]{impl.CGELS,
+		func(m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, s []float32, rcond float32, work []complex64, lwork int) (int, error) {
+			return impl.CGELSS(m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, make([]float32, 5*min(m, n)))
+		},
+		func(m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, s []float32, rcond float32, work []complex64, lwork int) (int, error) {
+			return impl.CGELSD(m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, make([]float32, gelsdRwork(m, n, nrhs)), make([]int, 3*min(m, n)))
+		},
+		func(m, n, nrhs int, a []complex64, lda int, b []complex64, ldb int, jpvt []int, rcond float32, work []complex64, lwork int) (int, error) {
+			return impl.CGELSY(m, n, nrhs, a, lda, b, ldb, jpvt, rcond, work, lwork, make([]float32, 2*n))
+		}})
+	testLLS(t, "Z", llsRoutines[complex128, float64]{impl.ZGELS,
+		func(m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, s []float64, rcond float64, work []complex128, lwork int) (int, error) {
+			return impl.ZGELSS(m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, make([]float64, 5*min(m, n)))
+		},
+		func(m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, s []float64, rcond float64, work []complex128, lwork int) (int, error) {
+			return impl.ZGELSD(m, n, nrhs, a, lda, b, ldb, s, rcond, work, lwork, make([]float64, gelsdRwork(m, n, nrhs)), make([]int, 3*min(m, n)))
+		},
+		func(m, n, nrhs int, a []complex128, lda int, b []complex128, ldb int, jpvt []int, rcond float64, work []complex128, lwork int) (int, error) {
+			return impl.ZGELSY(m, n, nrhs, a, lda, b, ldb, jpvt, rcond, work, lwork, make([]float64, 2*n))
+		}})
+}
+
+// llsSolver is one of xGELSS, xGELSD and xGELSY called with the optimal
+// workspace. It overwrites A and B, and returns the effective rank of A
+// and, but for xGELSY, the singular values of A.
+type llsSolver[T gen.Scalar] struct {
+	name  string
+	solve func(m, n, nrhs int, a []T, lda int, b []T, ldb int, rcond float64) (rank int, s []float64, err error)
+}
+
+func testLLS[T gen.Scalar, R gen.Float](t *testing.T, prec string, f llsRoutines[T, R]) {
+	rnd := rand.New(rand.NewSource(1))
+	svd := func(gelss func(m, n, nrhs int, a []T, lda int, b []T, ldb int, s []R, rcond R, work []T, lwork int) (int, error)) func(m, n, nrhs int, a []T, lda int, b []T, ldb int, rcond float64) (int, []float64, error) {
+		return func(m, n, nrhs int, a []T, lda int, b []T, ldb int, rcond float64) (rank int, s []float64, err error) {
+			sr := make([]R, min(m, n))
+			err = withWork("optimal lwork", 1, nil, func(work []T, lwork int) error {
+				rank, err = gelss(m, n, nrhs, a, lda, b, ldb, sr, R(rcond), work, lwork)
+				return err
+			})
+			for _, v := range sr {
+				s = append(s, float64(v))
+			}
+			return rank, s, err
+		}
+	}
+	solvers := []llsSolver[T]{
+		{prec + "GELSS", svd(f.gelss)},
+		{prec + "GELSD", svd(f.gelsd)},
+		{prec + "GELSY", func(m, n, nrhs int, a []T, lda int, b []T, ldb int, rcond float64) (rank int, s []float64, err error) {
+			jpvt := make([]int, n)
+			for j := range jpvt {
+				jpvt[j] = -1
+			}
+			err = withWork("optimal lwork", 1, nil, func(work []T, lwork int) error {
+				rank, err = f.gelsy(m, n, nrhs, a, lda, b, ldb, jpvt, R(rcond), work, lwork)
+				return err
+			})
+			return rank, nil, err
+		}},
+	}
+	transC := blas.TransT
+	if isComplex[T]() {
+		transC = blas.TransC
+	}
+
+	// Over- and underdetermined systems of full rank and of lower rank,
+	// with right-hand sides outside the range of A. The singular values of
+	// the matrices of lower rank beyond the rank are of the order of eps,
+	// well below rcond. The largest shapes run the blocked factorizations.
+	rcond := math.Sqrt(eps[T]())
+	for _, c := range []struct{ m, n, rank int }{
+		{0, 0, 0}, {0, 4, 0}, {4, 0, 0}, {1, 1, 1}, {20, 12, 12}, {12, 20, 12}, {15, 15, 15},
+		{20, 12, 5}, {12, 20, 5}, {15, 15, 9}, {160, 140, 140}, {140, 160, 90},
+	} {
+		const nrhs = 3
+		m, n := c.m, c.n
+		lda, ldb := m+2, max(m, n)+3
+		a := rankMat[T](rnd, m, n, c.rank, lda)
+		b := randMat[T](rnd, max(m, n), nrhs, ldb)
+		var want []T
+		var kappa float64
+		for _, sv := range solvers {
+			name := fmt.Sprintf("%s m=%d n=%d rank=%d", sv.name, m, n, c.rank)
+			ac, x := slices.Clone(a), slices.Clone(b)
+			rank, s, err := sv.solve(m, n, nrhs, ac, lda, x, ldb, rcond)
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if rank != c.rank {
+				t.Errorf("%s: rank = %d, want %d", name, rank, c.rank)
+				continue
+			}
+			if s != nil {
+				checkRank(t, name, s, rcond, c.rank)
+			}
+			if want == nil {
+				want = x
+				kappa = 1
+				if rank > 0 {
+					kappa = s[0] / s[rank-1]
+				}
+			}
+			if r := lsRatio(blas.TransN, m, n, nrhs, a, lda, x, ldb, b, ldb); r > maxRatio {
+				t.Errorf("%s: ‖Aᴴ*(A*X - B)‖ ratio %.3g", name, r)
+			}
+
+			// The minimum norm solution is unique, and its sensitivity is
+			// the condition number of A restricted to its rank.
+			if r := ratio[T](diffF(n, nrhs, x, ldb, want, ldb), kappa*normF(n, nrhs, want, ldb), n); r > maxRatio {
+				t.Errorf("%s: solution differs from that of %sGELSS, ratio %.3g", name, prec, r)
+			}
+		}
+		if c.rank < min(m, n) {
+			continue
+		}
+
+		// xGELS solves the systems of full rank with op(A) too. The
+		// solution is compared with that of xGELSS for the explicit op(A).
+		for _, trans := range []blas.Transpose{blas.TransN, transC} {
+			om, on := m, n
+			if trans != blas.TransN {
+				om, on = n, m
+			}
+			opA := make([]T, om*on)
+			for j := 0; j < on; j++ {
+				for i := 0; i < om; i++ {
+					opA[i+j*om] = opElem(trans, a, lda, i, j)
+				}
+			}
+			ref := slices.Clone(b)
+			_, s, err := solvers[0].solve(om, on, nrhs, slices.Clone(opA), max(1, om), ref, ldb, rcond)
+			if err != nil {
+				t.Fatalf("%sGELSS m=%d n=%d: unexpected error %v", prec, om, on, err)
+			}
+			kappa := 1.0
+			if len(s) > 0 {
+				kappa = s[0] / s[len(s)-1]
+			}
+			k := min(m, n)
+			for _, v := range workVariants[1:] {
+				name := fmt.Sprintf("%sGELS %s trans=%c m=%d n=%d", prec, v, trans, m, n)
+				ac, x := slices.Clone(a), slices.Clone(b)
+				if err := withWork(v, k+max(k, nrhs), nil, func(work []T, lwork int) error {
+					return f.gels(trans, m, n, nrhs, ac, lda, x, ldb, work, lwork)
+				}); err != nil {
+					t.Errorf("%s: unexpected error %v", name, err)
+					continue
+				}
+				if r := lsRatio(trans, m, n, nrhs, a, lda, x, ldb, b, ldb); r > maxRatio {
+					t.Errorf("%s: ‖op(A)ᴴ*(op(A)*X - B)‖ ratio %.3g", name, r)
+				}
+				if r := ratio[T](diffF(on, nrhs, x, ldb, ref, ldb), kappa*normF(on, nrhs, ref, ldb), on); r > maxRatio {
+					t.Errorf("%s: solution differs from that of %sGELSS, ratio %.3g", name, prec, r)
+				}
+
+				// The rows of B after the solution of an overdetermined
+				// system hold the residual. As in LAPACK, an empty A gives
+				// the zero solution and residual.
+				if k == 0 && normF(max(m, n), nrhs, x, ldb) != 0 {
+					t.Errorf("%s: B not zeroed for an empty A", name)
+				}
+				if om > on && on > 0 {
+					res := mulMat(blas.TransN, blas.TransN, om, nrhs, on, opA, om, x, ldb)
+					for j := 0; j < nrhs; j++ {
+						for i := 0; i < om; i++ {
+							res[i+j*om] -= b[i+j*ldb]
+						}
+						got, want := normF(om-on, 1, x[on+j*ldb:], ldb), normF(om, 1, res[j*om:], om)
+						if r := ratio[T](math.Abs(got-want), normF(om, 1, b[j*ldb:], ldb), om); r > maxRatio {
+							t.Errorf("%s: column %d: residual norm in B %.6g, want %.6g", name, j, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// A zero column makes the triangular factor singular.
+	for _, sh := range [][2]int{{8, 5}, {5, 8}} {
+		m, n := sh[0], sh[1]
+		a := randMat[T](rnd, m, n, m)
+		if m >= n {
+			clear(a[2*m : 3*m])
+		} else {
+			for j := 0; j < n; j++ {
+				a[2+j*m] = 0
+			}
+		}
+		b := randMat[T](rnd, max(m, n), 1, max(m, n))
+		err := withWork("optimal lwork", 1, nil, func(work []T, lwork int) error {
+			return f.gels(blas.TransN, m, n, 1, a, m, b, max(m, n), work, lwork)
+		})
+		if se := (*SingularError)(nil); !errors.As(err, &se) || se.Routine != prec+"GELS" || se.Index != 2 {
+			t.Errorf("%sGELS m=%d n=%d: err = %v, want a *SingularError with Index 2", prec, m, n, err)
+		}
+	}
+
+	// The effective rank of the Hilbert matrices and of the Vandermonde
+	// matrices of the points 0, 1/(n-1), ..., 1. The ranks were counted
+	// with exact rational arithmetic and change neither at a quarter nor at
+	// four times rcond. The cases whose rcond is too close to eps for the
+	// rounding of A not to matter are skipped. The singular values left out
+	// of the solution are below rcond*‖A‖, which bounds ‖Aᴴ*(A*X - B)‖ by
+	// rcond*‖A‖*‖B‖ besides the rounding errors.
+	for _, c := range []struct {
+		name  string
+		n     int
+		rcond float64
+		rank  int
+	}{
+		{"Hilbert", 4, 1e-3, 3}, {"Hilbert", 6, 1e-6, 5}, {"Hilbert", 8, 1e-7, 6}, {"Hilbert", 8, 1e-11, 8},
+		{"Hilbert", 10, 1e-8, 7}, {"Hilbert", 10, 1e-10, 8}, {"Hilbert", 12, 1e-9, 8}, {"Hilbert", 12, 1e-11, 9},
+		{"Vandermonde", 5, 1e-4, 5}, {"Vandermonde", 8, 1e-7, 8}, {"Vandermonde", 10, 1e-8, 10},
+		{"Vandermonde", 12, 1e-8, 11}, {"Vandermonde", 12, 1e-10, 12},
+	} {
+		if c.rcond < 1e3*eps[T]() {
+			continue
+		}
+		const nrhs = 2
+		n := c.n
+		a := make([]T, n*n)
+		for j := 0; j < n; j++ {
+			for i := 0; i < n; i++ {
+				if c.name == "Hilbert" {
+					a[i+j*n] = fromReal[T](1 / float64(i+j+1))
+				} else {
+					a[i+j*n] = fromReal[T](math.Pow(float64(i)/float64(n-1), float64(j)))
+				}
+			}
+		}
+		b := mulMat(blas.TransN, blas.TransN, n, nrhs, n, a, n, randMat[T](rnd, n, nrhs, n), n)
+		var want []T
+		for _, sv := range solvers {
+			name := fmt.Sprintf("%s %s(%d) rcond=%g", sv.name, c.name, n, c.rcond)
+			x := slices.Clone(b)
+			rank, s, err := sv.solve(n, n, nrhs, slices.Clone(a), n, x, n, c.rcond)
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if rank != c.rank {
+				t.Errorf("%s: rank = %d, want %d", name, rank, c.rank)
+				continue
+			}
+			res, normA := lsResidual(blas.TransN, n, n, nrhs, a, n, x, n, b, n), normF(n, n, a, n)
+			normB := normF(n, nrhs, b, n)
+			if tol := 4*c.rcond*normA*normB + maxRatio*float64(n)*eps[T]()*normA*(normA*normF(n, nrhs, x, n)+normB); res > tol {
+				t.Errorf("%s: ‖Aᴴ*(A*X - B)‖ = %.3g, want at most %.3g", name, res, tol)
+			}
+			if s == nil {
+				continue
+			}
+			checkRank(t, name, s, c.rcond, c.rank)
+
+			// xGELSS and xGELSD truncate the same singular values.
+			if want == nil {
+				want = x
+			} else if r := ratio[T](diffF(n, nrhs, x, n, want, n), s[0]/s[rank-1]*normF(n, nrhs, want, n), n); r > maxRatio {
+				t.Errorf("%s: solution differs from that of %sGELSS, ratio %.3g", name, prec, r)
+			}
+		}
+	}
+}
+
+// rankMat returns a random m×n matrix of rank r with leading dimension lda,
+// the product of random m×r and r×n matrices.
+func rankMat[T gen.Scalar](rnd *rand.Rand, m, n, r, lda int) []T {
+	if r == min(m, n) {
+		return randMat[T](rnd, m, n, lda)
+	}
+	p := mulMat(blas.TransN, blas.TransN, m, n, r, randMat[T](rnd, m, r, m), m, randMat[T](rnd, r, n, r), r)
+	a := randMat[T](rnd, m, n, lda)
+	for j := 0; j < n; j++ {
+		copy(a[j*lda:j*lda+m], p[j*m:(j+1)*m])
+	}
+	return a
+}
+
+// checkRank checks that the singular values s are non-increasing and that
+// rank of them are above rcond times the largest.
+func checkRank(t *testing.T, name string, s []float64, rcond float64, rank int) {
+	t.Helper()
+	for i := range s {
+		if i > 0 && s[i] > s[i-1] {
+			t.Errorf("%s: singular values %v not in decreasing order", name, s)
+			return
+		}
+		if (i < rank) != (s[i] > rcond*s[0]) {
+			t.Errorf("%s: singular value %d is %.3g, with rank %d and threshold %.3g", name, i, s[i], rank, rcond*s[0])
+			return
+		}
+	}
+}
+
+// lsRatio returns the test ratio of ‖op(A)ᴴ*(op(A)*X - B)‖ for the m×n
+// matrix A, which vanishes at the least squares solution X.
+func lsRatio[T gen.Scalar](trans blas.Transpose, m, n, nrhs int, a []T, lda int, x []T, ldx int, b []T, ldb int) float64 {
+	om, on := m, n
+	if trans != blas.TransN {
+		om, on = n, m
+	}
+	norm := normF(m, n, a, lda)
+	return ratio[T](lsResidual(trans, m, n, nrhs, a, lda, x, ldx, b, ldb), norm*(norm*normF(on, nrhs, x, ldx)+normF(om, nrhs, b, ldb)), max(m, n, nrhs))
+}
+
+// lsResidual returns ‖op(A)ᴴ*(op(A)*X - B)‖ for the m×n matrix A.
+func lsResidual[T gen.Scalar](trans blas.Transpose, m, n, nrhs int, a []T, lda int, x []T, ldx int, b []T, ldb int) float64 {
+	om, on := m, n
+	if trans != blas.TransN {
+		om, on = n, m
+	}
+	r := mulMat(trans, blas.TransN, om, nrhs, on, a, lda, x, ldx)
+	for j := 0; j < nrhs; j++ {
+		for i := 0; i < om; i++ {
+			r[i+j*om] -= b[i+j*ldb]
+		}
+	}
+	ct := blas.TransC
+	if trans != blas.TransN {
+		ct = blas.TransN
+	}
+	return normF(on, nrhs, mulMat(ct, blas.TransN, on, nrhs, om, a, lda, r, om), on)
+}
@@ -0,0 +1,553 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGELQ2 computes the LQ factorization A = L*Q of an m×n matrix one row
+// at a time. L is left in the lower triangle of A and Q is represented as
+// the product Q = H(k-1)**H*...*H(1)**H*H(0)**H, k = min(m,n), of elementary
+// reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row i right of the diagonal with their unit first element
+// implied. work must hold m elements.
+func (impl Implementation) SGELQ2(m, n int, a []float32, lda int, tau, work []float32) error {
+	if err := checkGeqrf("SGELQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gelq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// SGELQF computes the LQ factorization of SGELQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SGELQF(m, n int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkGeqrf("SGELQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gelqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORGL2 overwrites the m×n matrix A, whose first k rows hold the
+// reflectors of an LQ factorization as left by SGELQF, with the first m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) SORGL2(m, n, k int, a []float32, lda int, tau, work []float32) error {
+	if err := checkOrgqr("SORGL2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgl2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// SORGLQ generates the Q of SORGL2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGLQ(m, n, k int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrgqr("SORGLQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orglq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORML2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// SGELQF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) SORML2(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32) error {
+	if err := checkOrmqr("SORML2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, true); err != nil {
+		return err
+	}
+	orml2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// SORMLQ computes the product of SORML2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) SORMLQ(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmqr("SORMLQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, true); err != nil {
+		return err
+	}
+	ormlq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DGELQ2 computes the LQ factorization A = L*Q of an m×n matrix one row
+// at a time. L is left in the lower triangle of A and Q is represented as
+// the product Q = H(k-1)**H*...*H(1)**H*H(0)**H, k = min(m,n), of elementary
+// reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row i right of the diagonal with their unit first element
+// implied. work must hold m elements.
+func (impl Implementation) DGELQ2(m, n int, a []float64, lda int, tau, work []float64) error {
+	if err := checkGeqrf("DGELQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gelq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// DGELQF computes the LQ factorization of DGELQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DGELQF(m, n int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkGeqrf("DGELQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gelqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORGL2 overwrites the m×n matrix A, whose first k rows hold the
+// reflectors of an LQ factorization as left by DGELQF, with the first m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) DORGL2(m, n, k int, a []float64, lda int, tau, work []float64) error {
+	if err := checkOrgqr("DORGL2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgl2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// DORGLQ generates the Q of DORGL2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGLQ(m, n, k int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrgqr("DORGLQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orglq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORML2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// DGELQF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) DORML2(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64) error {
+	if err := checkOrmqr("DORML2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, true); err != nil {
+		return err
+	}
+	orml2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// DORMLQ computes the product of DORML2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) DORMLQ(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmqr("DORMLQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, true); err != nil {
+		return err
+	}
+	ormlq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CGELQ2 computes the LQ factorization A = L*Q of an m×n matrix one row
+// at a time. L is left in the lower triangle of A and Q is represented as
+// the product Q = H(k-1)**H*...*H(1)**H*H(0)**H, k = min(m,n), of elementary
+// reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row i right of the diagonal with their unit first element
+// implied. work must hold m elements.
+func (impl Implementation) CGELQ2(m, n int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkGeqrf("CGELQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gelq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// CGELQF computes the LQ factorization of CGELQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CGELQF(m, n int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkGeqrf("CGELQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gelqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNGL2 overwrites the m×n matrix A, whose first k rows hold the
+// reflectors of an LQ factorization as left by CGELQF, with the first m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) CUNGL2(m, n, k int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkOrgqr("CUNGL2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgl2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// CUNGLQ generates the Q of CUNGL2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGLQ(m, n, k int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrgqr("CUNGLQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orglq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNML2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// CGELQF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) CUNML2(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64) error {
+	if err := checkOrmqr("CUNML2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, true); err != nil {
+		return err
+	}
+	orml2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// CUNMLQ computes the product of CUNML2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CUNMLQ(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmqr("CUNMLQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, true); err != nil {
+		return err
+	}
+	ormlq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZGELQ2 computes the LQ factorization A = L*Q of an m×n matrix one row
+// at a time. L is left in the lower triangle of A and Q is represented as
+// the product Q = H(k-1)**H*...*H(1)**H*H(0)**H, k = min(m,n), of elementary
+// reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row i right of the diagonal with their unit first element
+// implied. work must hold m elements.
+func (impl Implementation) ZGELQ2(m, n int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkGeqrf("ZGELQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gelq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// ZGELQF computes the LQ factorization of ZGELQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZGELQF(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkGeqrf("ZGELQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gelqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNGL2 overwrites the m×n matrix A, whose first k rows hold the
+// reflectors of an LQ factorization as left by ZGELQF, with the first m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) ZUNGL2(m, n, k int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkOrgqr("ZUNGL2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgl2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// ZUNGLQ generates the Q of ZUNGL2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGLQ(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrgqr("ZUNGLQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orglq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNML2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// ZGELQF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) ZUNML2(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128) error {
+	if err := checkOrmqr("ZUNML2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, true); err != nil {
+		return err
+	}
+	orml2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// ZUNMLQ computes the product of ZUNML2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZUNMLQ(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmqr("ZUNMLQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, true); err != nil {
+		return err
+	}
+	ormlq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// gelq2 computes the LQ factorization A = L*Q of the m×n matrix A one row at
+// a time. L is left in the lower triangle of A and Q is represented by the
+// reflectors H(i) = I - tau[i]*v*v**H, Q = H(k-1)**H*...*H(1)**H*H(0)**H,
+// whose vectors are stored conjugated to the right of the diagonal with
+// their unit first element implied. work must hold m elements.
+func gelq2[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T) {
+	for i := 0; i < min(m, n); i++ {
+		ii := i + i*lda
+		lacgv(n-i, a[ii:], lda)
+		a[ii], tau[i] = larfg(bl, n-i, a[ii], a[i+min(i+1, n-1)*lda:], lda)
+		if i < m-1 {
+			// Apply H(i) to A(i+1:m,i:n) from the right.
+			aii := a[ii]
+			a[ii] = 1
+			larf(bl, blas.SideR, m-i-1, n-i, a[ii:], lda, tau[i], a[ii+1:], lda, work)
+			a[ii] = aii
+		}
+		lacgv(n-i, a[ii:], lda)
+	}
+}
+
+// gelqf computes the LQ factorization of gelq2 with the blocked algorithm of
+// xGELQF. work holds lwork >= m elements, and the block size is reduced to
+// fit. A workspace query, lwork = -1, only sets work[0] to the optimal
+// lwork.
+func gelqf[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T, lwork int) {
+	k := min(m, n)
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, m)))
+		if k > 0 {
+			work[0] = fromReal[T](float64(m * qrBlock))
+		}
+		return
+	}
+	if k == 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := m
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	i := 0
+	if nb >= 2 && nb < k && nx < k {
+		for ; i < k-nx; i += nb {
+			ib := min(k-i, nb)
+			ii := i + i*lda
+			gelq2(bl, ib, n-i, a[ii:], lda, tau[i:], work)
+			if i+ib < m {
+				// Form the triangular factor of the block reflector
+				// H = H(i)*H(i+1)*...*H(i+ib-1) and apply it to
+				// A(i+ib:m,i:n) from the right.
+				larft(bl, DirectF, StoreVR, n-i, ib, a[ii:], lda, tau[i:], work, ldwork)
+				larfb(bl, blas.SideR, blas.TransN, DirectF, StoreVR, m-i-ib, n-i, ib, a[ii:], lda, work, ldwork, a[ii+ib:], lda, work[ib:], ldwork)
+			}
+		}
+	}
+	if i < k {
+		gelq2(bl, m-i, n-i, a[i+i*lda:], lda, tau[i:], work)
+	}
+}
+
+// orgl2 overwrites the m×n matrix A, whose first k rows hold the reflectors
+// of an LQ factorization as left by gelqf, with the first m rows of Q. work
+// must hold m elements.
+func orgl2[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T) {
+	if m <= 0 {
+		return
+	}
+	if k < m {
+		// Rows k:m are rows of the identity.
+		for j := 0; j < n; j++ {
+			for l := k; l < m; l++ {
+				a[l+j*lda] = 0
+			}
+			if j >= k && j < m {
+				a[j+j*lda] = 1
+			}
+		}
+	}
+	for i := k - 1; i >= 0; i-- {
+		ii := i + i*lda
+		// Apply H(i)**H to A(i:m,i:n) from the right.
+		if i < n-1 {
+			lacgv(n-i-1, a[ii+lda:], lda)
+			if i < m-1 {
+				a[ii] = 1
+				larf(bl, blas.SideR, m-i-1, n-i, a[ii:], lda, conj(tau[i]), a[ii+1:], lda, work)
+			}
+			scal(bl, n-i-1, -tau[i], a[ii+lda:], lda)
+			lacgv(n-i-1, a[ii+lda:], lda)
+		}
+		a[ii] = 1 - conj(tau[i])
+		for l := 0; l < i; l++ {
+			a[i+l*lda] = 0
+		}
+	}
+}
+
+// orglq generates the Q of orgl2 with the blocked algorithm of xORGLQ. work
+// holds lwork >= m elements, and the block size is reduced to fit. A
+// workspace query, lwork = -1, only sets work[0] to the optimal lwork.
+func orglq[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, m) * qrBlock))
+		return
+	}
+	if m <= 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := m
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	ki, kk := 0, 0
+	if nb >= 2 && nb < k && nx < k {
+		// The last kk rows are handled by the unblocked code and the first
+		// ones by blocks of nb, starting at ki.
+		ki = ((k - nx - 1) / nb) * nb
+		kk = min(k, ki+nb)
+		for j := 0; j < kk; j++ {
+			for i := kk; i < m; i++ {
+				a[i+j*lda] = 0
+			}
+		}
+	}
+	if kk < m {
+		orgl2(bl, m-kk, n-kk, k-kk, a[kk+kk*lda:], lda, tau[kk:], work)
+	}
+	if kk == 0 {
+		return
+	}
+	for i := ki; i >= 0; i -= nb {
+		ib := min(nb, k-i)
+		ii := i + i*lda
+		if i+ib < m {
+			// Form the triangular factor of the block reflector
+			// H = H(i)*H(i+1)*...*H(i+ib-1) and apply H**H to
+			// A(i+ib:m,i:n) from the right.
+			larft(bl, DirectF, StoreVR, n-i, ib, a[ii:], lda, tau[i:], work, ldwork)
+			larfb(bl, blas.SideR, blas.TransC, DirectF, StoreVR, m-i-ib, n-i, ib, a[ii:], lda, work, ldwork, a[ii+ib:], lda, work[ib:], ldwork)
+		}
+		// Apply H**H to the columns i:n of the block itself and zero its
+		// columns 0:i.
+		orgl2(bl, ib, n-i, ib, a[ii:], lda, tau[i:], work)
+		for j := 0; j < i; j++ {
+			for l := i; l < i+ib; l++ {
+				a[l+j*lda] = 0
+			}
+		}
+	}
+}
+
+// orml2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where op(Q) is Q or Q**H and Q is given by the k reflectors left
+// in the rows of A and in tau by gelqf. work must hold n elements for side L
+// and m for side R.
+func orml2[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T) {
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq := m
+	if !left {
+		nq = n
+	}
+	mi, ni, ic, jc := m, n, 0, 0
+	for j := 0; j < k; j++ {
+		// H(0) is applied first for Q*C and C*Q**H, and last otherwise.
+		i := j
+		if left != notran {
+			i = k - 1 - j
+		}
+		if left {
+			mi, ic = m-i, i
+		} else {
+			ni, jc = n-i, i
+		}
+		taui := tau[i]
+		if notran {
+			taui = conj(taui)
+		}
+		ii := i + i*lda
+		if i < nq-1 {
+			lacgv(nq-i-1, a[ii+lda:], lda)
+		}
+		aii := a[ii]
+		a[ii] = 1
+		larf(bl, side, mi, ni, a[ii:], lda, taui, c[ic+jc*ldc:], ldc, work)
+		a[ii] = aii
+		if i < nq-1 {
+			lacgv(nq-i-1, a[ii+lda:], lda)
+		}
+	}
+}
+
+// ormlq computes the product of orml2 with the blocked algorithm of xORMLQ.
+// work holds lwork >= nw elements, where nw is n for side L and m for side
+// R, and the block size is reduced to fit. A workspace query, lwork = -1,
+// only sets work[0] to the optimal lwork.
+func ormlq[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq, nw := m, n
+	if !left {
+		nq, nw = n, m
+	}
+	nb := min(ormBlockMax, qrBlock)
+	if nb > 1 && nb < k && lwork < ormWork(side, m, n) {
+		nb = (lwork - ormTSize) / nw
+	}
+	if nb < 2 || nb >= k {
+		orml2(bl, side, trans, m, n, k, a, lda, tau, c, ldc, work)
+		return
+	}
+	transt := blas.TransC
+	if !notran {
+		transt = blas.TransN
+	}
+	t := work[nw*nb:]
+	mi, ni, ic, jc := m, n, 0, 0
+	last := ((k - 1) / nb) * nb
+	for j := 0; j <= last; j += nb {
+		i := j
+		if left != notran {
+			i = last - j
+		}
+		ib := min(nb, k-i)
+		ii := i + i*lda
+		// Form the triangular factor of the block reflector
+		// H = H(i)*H(i+1)*...*H(i+ib-1) and apply H or H**H to C(i:m,0:n)
+		// or C(0:m,i:n).
+		larft(bl, DirectF, StoreVR, nq-i, ib, a[ii:], lda, tau[i:], t, ormBlockMax+1)
+		if left {
+			mi, ic = m-i, i
+		} else {
+			ni, jc = n-i, i
+		}
+		larfb(bl, side, transt, DirectF, StoreVR, mi, ni, ib, a[ii:], lda, t, ormBlockMax+1, c[ic+jc*ldc:], ldc, work, nw)
+	}
+}
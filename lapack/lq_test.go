@@ -0,0 +1,13 @@
+package lapack
+
+import "testing"
+
+var lqKind = orthKind{false, false, [6]string{"GELQ2", "GELQF", "ORGL2", "ORGLQ", "ORML2", "ORMLQ"}}
+
+func TestLQ(t *testing.T) {
+	var impl Implementation
+	testOrth(t, "S", lqKind, orthRoutines[float32]{impl.SGELQ2, impl.SGELQF, impl.SORGL2, impl.SORGLQ, impl.SORML2, impl.SORMLQ})
+	testOrth(t, "D", lqKind, orthRoutines[float64]{impl.DGELQ2, impl.DGELQF, impl.DORGL2, impl.DORGLQ, impl.DORML2, impl.DORMLQ})
+	testOrth(t, "C", lqKind, orthRoutines[complex64]{impl.CGELQ2, impl.CGELQF, impl.CUNGL2, impl.CUNGLQ, impl.CUNML2, impl.CUNMLQ})
+	testOrth(t, "Z", lqKind, orthRoutines[complex128]{impl.ZGELQ2, impl.ZGELQF, impl.ZUNGL2, impl.ZUNGLQ, impl.ZUNML2, impl.ZUNMLQ})
+}
@@ -0,0 +1,491 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGETF2 computes the LU factorization A = P*L*U of a general m×n matrix
+// with partial pivoting, one column at a time. On return a holds L, with a
+// unit diagonal that is not stored, and U; row i was interchanged with row
+// ipiv[i]. A *SingularError is returned if U has a zero diagonal element.
+func (impl Implementation) SGETF2(m, n int, a []float32, lda int, ipiv []int) error {
+	if err := checkGetrf("SGETF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("SGETF2", getf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// SGETRF2 computes the LU factorization of SGETF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) SGETRF2(m, n int, a []float32, lda int, ipiv []int) error {
+	if err := checkGetrf("SGETRF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("SGETRF2", getrf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// SGETRF computes the LU factorization of SGETF2 with a blocked
+// algorithm whose panels are factored by SGETRF2.
+func (impl Implementation) SGETRF(m, n int, a []float32, lda int, ipiv []int) error {
+	if err := checkGetrf("SGETRF", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("SGETRF", getrf(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// SGETRS solves op(A)*X = B for the n×nrhs matrix X using the LU
+// factorization of A computed by SGETRF. B is overwritten by X.
+func (impl Implementation) SGETRS(trans blas.Transpose, n, nrhs int, a []float32, lda int, ipiv []int, b []float32, ldb int) error {
+	if err := checkGetrs("SGETRS", trans, n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	getrs(impl.bl(), trans, n, nrhs, a, lda, ipiv, b, ldb)
+	return nil
+}
+
+// SGESV solves A*X = B for the n×nrhs matrix X. A is overwritten by its LU
+// factorization as computed by SGETRF and B by X. A *SingularError is
+// returned, and B is left unchanged, if A is exactly singular.
+func (impl Implementation) SGESV(n, nrhs int, a []float32, lda int, ipiv []int, b []float32, ldb int) error {
+	if err := checkGesv("SGESV", n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	return singular("SGESV", gesv(impl.bl(), n, nrhs, a, lda, ipiv, b, ldb))
+}
+
+// SLASWP interchanges row k of the n columns of A with row ipiv[k0] for
+// k = k1, ..., k2, where k0 = k1 + (k-k1)*|incX|. The interchanges are
+// applied in increasing order of k for a positive incX and in decreasing
+// order, which undoes them, for a negative incX.
+func (impl Implementation) SLASWP(n int, a []float32, lda, k1, k2 int, ipiv []int, incX int) error {
+	if err := checkLaswp("SLASWP", n, len(a), lda, k1, k2, len(ipiv), incX); err != nil {
+		return err
+	}
+	laswp(n, a, lda, k1, k2, ipiv, incX)
+	return nil
+}
+
+// DGETF2 computes the LU factorization A = P*L*U of a general m×n matrix
+// with partial pivoting, one column at a time. On return a holds L, with a
+// unit diagonal that is not stored, and U; row i was interchanged with row
+// ipiv[i]. A *SingularError is returned if U has a zero diagonal element.
+func (impl Implementation) DGETF2(m, n int, a []float64, lda int, ipiv []int) error {
+	if err := checkGetrf("DGETF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("DGETF2", getf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// DGETRF2 computes the LU factorization of DGETF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) DGETRF2(m, n int, a []float64, lda int, ipiv []int) error {
+	if err := checkGetrf("DGETRF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("DGETRF2", getrf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// DGETRF computes the LU factorization of DGETF2 with a blocked
+// algorithm whose panels are factored by DGETRF2.
+func (impl Implementation) DGETRF(m, n int, a []float64, lda int, ipiv []int) error {
+	if err := checkGetrf("DGETRF", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("DGETRF", getrf(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// DGETRS solves op(A)*X = B for the n×nrhs matrix X using the LU
+// factorization of A computed by DGETRF. B is overwritten by X.
+func (impl Implementation) DGETRS(trans blas.Transpose, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) error {
+	if err := checkGetrs("DGETRS", trans, n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	getrs(impl.bl(), trans, n, nrhs, a, lda, ipiv, b, ldb)
+	return nil
+}
+
+// DGESV solves A*X = B for the n×nrhs matrix X. A is overwritten by its LU
+// factorization as computed by DGETRF and B by X. A *SingularError is
+// returned, and B is left unchanged, if A is exactly singular.
+func (impl Implementation) DGESV(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) error {
+	if err := checkGesv("DGESV", n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	return singular("DGESV", gesv(impl.bl(), n, nrhs, a, lda, ipiv, b, ldb))
+}
+
+// DLASWP interchanges row k of the n columns of A with row ipiv[k0] for
+// k = k1, ..., k2, where k0 = k1 + (k-k1)*|incX|. The interchanges are
+// applied in increasing order of k for a positive incX and in decreasing
+// order, which undoes them, for a negative incX.
+func (impl Implementation) DLASWP(n int, a []float64, lda, k1, k2 int, ipiv []int, incX int) error {
+	if err := checkLaswp("DLASWP", n, len(a), lda, k1, k2, len(ipiv), incX); err != nil {
+		return err
+	}
+	laswp(n, a, lda, k1, k2, ipiv, incX)
+	return nil
+}
+
+// CGETF2 computes the LU factorization A = P*L*U of a general m×n matrix
+// with partial pivoting, one column at a time. On return a holds L, with a
+// unit diagonal that is not stored, and U; row i was interchanged with row
+// ipiv[i]. A *SingularError is returned if U has a zero diagonal element.
+func (impl Implementation) CGETF2(m, n int, a []complex64, lda int, ipiv []int) error {
+	if err := checkGetrf("CGETF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("CGETF2", getf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// CGETRF2 computes the LU factorization of CGETF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) CGETRF2(m, n int, a []complex64, lda int, ipiv []int) error {
+	if err := checkGetrf("CGETRF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("CGETRF2", getrf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// CGETRF computes the LU factorization of CGETF2 with a blocked
+// algorithm whose panels are factored by CGETRF2.
+func (impl Implementation) CGETRF(m, n int, a []complex64, lda int, ipiv []int) error {
+	if err := checkGetrf("CGETRF", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("CGETRF", getrf(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// CGETRS solves op(A)*X = B for the n×nrhs matrix X using the LU
+// factorization of A computed by CGETRF. B is overwritten by X.
+func (impl Implementation) CGETRS(trans blas.Transpose, n, nrhs int, a []complex64, lda int, ipiv []int, b []complex64, ldb int) error {
+	if err := checkGetrs("CGETRS", trans, n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	getrs(impl.bl(), trans, n, nrhs, a, lda, ipiv, b, ldb)
+	return nil
+}
+
+// CGESV solves A*X = B for the n×nrhs matrix X. A is overwritten by its LU
+// factorization as computed by CGETRF and B by X. A *SingularError is
+// returned, and B is left unchanged, if A is exactly singular.
+func (impl Implementation) CGESV(n, nrhs int, a []complex64, lda int, ipiv []int, b []complex64, ldb int) error {
+	if err := checkGesv("CGESV", n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	return singular("CGESV", gesv(impl.bl(), n, nrhs, a, lda, ipiv, b, ldb))
+}
+
+// CLASWP interchanges row k of the n columns of A with row ipiv[k0] for
+// k = k1, ..., k2, where k0 = k1 + (k-k1)*|incX|. The interchanges are
+// applied in increasing order of k for a positive incX and in decreasing
+// order, which undoes them, for a negative incX.
+func (impl Implementation) CLASWP(n int, a []complex64, lda, k1, k2 int, ipiv []int, incX int) error {
+	if err := checkLaswp("CLASWP", n, len(a), lda, k1, k2, len(ipiv), incX); err != nil {
+		return err
+	}
+	laswp(n, a, lda, k1, k2, ipiv, incX)
+	return nil
+}
+
+// ZGETF2 computes the LU factorization A = P*L*U of a general m×n matrix
+// with partial pivoting, one column at a time. On return a holds L, with a
+// unit diagonal that is not stored, and U; row i was interchanged with row
+// ipiv[i]. A *SingularError is returned if U has a zero diagonal element.
+func (impl Implementation) ZGETF2(m, n int, a []complex128, lda int, ipiv []int) error {
+	if err := checkGetrf("ZGETF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("ZGETF2", getf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// ZGETRF2 computes the LU factorization of ZGETF2 with a recursive
+// algorithm that casts most of the work as matrix multiplications.
+func (impl Implementation) ZGETRF2(m, n int, a []complex128, lda int, ipiv []int) error {
+	if err := checkGetrf("ZGETRF2", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("ZGETRF2", getrf2(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// ZGETRF computes the LU factorization of ZGETF2 with a blocked
+// algorithm whose panels are factored by ZGETRF2.
+func (impl Implementation) ZGETRF(m, n int, a []complex128, lda int, ipiv []int) error {
+	if err := checkGetrf("ZGETRF", m, n, len(a), lda, len(ipiv)); err != nil {
+		return err
+	}
+	return singular("ZGETRF", getrf(impl.bl(), m, n, a, lda, ipiv))
+}
+
+// ZGETRS solves op(A)*X = B for the n×nrhs matrix X using the LU
+// factorization of A computed by ZGETRF. B is overwritten by X.
+func (impl Implementation) ZGETRS(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int) error {
+	if err := checkGetrs("ZGETRS", trans, n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	getrs(impl.bl(), trans, n, nrhs, a, lda, ipiv, b, ldb)
+	return nil
+}
+
+// ZGESV solves A*X = B for the n×nrhs matrix X. A is overwritten by its LU
+// factorization as computed by ZGETRF and B by X. A *SingularError is
+// returned, and B is left unchanged, if A is exactly singular.
+func (impl Implementation) ZGESV(n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int) error {
+	if err := checkGesv("ZGESV", n, nrhs, len(a), lda, len(ipiv), len(b), ldb); err != nil {
+		return err
+	}
+	return singular("ZGESV", gesv(impl.bl(), n, nrhs, a, lda, ipiv, b, ldb))
+}
+
+// ZLASWP interchanges row k of the n columns of A with row ipiv[k0] for
+// k = k1, ..., k2, where k0 = k1 + (k-k1)*|incX|. The interchanges are
+// applied in increasing order of k for a positive incX and in decreasing
+// order, which undoes them, for a negative incX.
+func (impl Implementation) ZLASWP(n int, a []complex128, lda, k1, k2 int, ipiv []int, incX int) error {
+	if err := checkLaswp("ZLASWP", n, len(a), lda, k1, k2, len(ipiv), incX); err != nil {
+		return err
+	}
+	laswp(n, a, lda, k1, k2, ipiv, incX)
+	return nil
+}
+
+// luBlock is the block size of getrf, the value of ILAENV for xGETRF.
+const luBlock = 64
+
+// laswpBlock is the number of columns laswp interchanges at a time.
+const laswpBlock = 32
+
+// checkGetrf checks the GETF2, GETRF2 and GETRF routines.
+func checkGetrf(routine string, m, n, lenA, lda, lenIpiv int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, m, "m")
+	if c.ok() {
+		c.length(3, "a", lenA, matLen(m, n, lda))
+		c.length(5, "ipiv", lenIpiv, min(m, n))
+	}
+	return c.result()
+}
+
+// checkGetrs checks the GETRS routines.
+func checkGetrs(routine string, trans blas.Transpose, n, nrhs, lenA, lda, lenIpiv, lenB, ldb int) error {
+	c := checker{routine: routine}
+	c.trans(1, trans)
+	c.nonNeg(2, "n", n)
+	c.nonNeg(3, "nrhs", nrhs)
+	c.ld(5, "lda", lda, n, "n")
+	c.ld(8, "ldb", ldb, n, "n")
+	if c.ok() {
+		c.length(4, "a", lenA, matLen(n, n, lda))
+		c.length(6, "ipiv", lenIpiv, n)
+		c.length(7, "b", lenB, matLen(n, nrhs, ldb))
+	}
+	return c.result()
+}
+
+// checkGesv checks the GESV routines.
+func checkGesv(routine string, n, nrhs, lenA, lda, lenIpiv, lenB, ldb int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	c.nonNeg(2, "nrhs", nrhs)
+	c.ld(4, "lda", lda, n, "n")
+	c.ld(7, "ldb", ldb, n, "n")
+	if c.ok() {
+		c.length(3, "a", lenA, matLen(n, n, lda))
+		c.length(5, "ipiv", lenIpiv, n)
+		c.length(6, "b", lenB, matLen(n, nrhs, ldb))
+	}
+	return c.result()
+}
+
+// checkLaswp checks the LASWP routines. The pivot indices themselves are not
+// checked.
+func checkLaswp(routine string, n, lenA, lda, k1, k2, lenIpiv, incX int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	c.atLeast(3, "lda", lda, 1, "1")
+	c.nonNeg(4, "k1", k1)
+	c.atLeast(5, "k2", k2, k1, "k1")
+	if incX == 0 {
+		c.fail(7, "incX", "must not be zero")
+	}
+	if c.ok() {
+		c.length(2, "a", lenA, matLen(k2+1, n, lda))
+		c.length(6, "ipiv", lenIpiv, k1+(k2-k1)*max(incX, -incX)+1)
+	}
+	return c.result()
+}
+
+// getf2 computes the LU factorization A = P*L*U of the m×n matrix A one
+// column at a time. It returns the index of the first zero diagonal element
+// of U, or -1.
+func getf2[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, ipiv []int) (info int) {
+	info = -1
+	sfmin := safmin[T]()
+	for j := 0; j < min(m, n); j++ {
+		jp := j + iamax(bl, m-j, a[j+j*lda:], 1)
+		ipiv[j] = jp
+		if a[jp+j*lda] == 0 {
+			if info < 0 {
+				info = j
+			}
+		} else {
+			if jp != j {
+				swap(bl, n, a[j:], lda, a[jp:], lda)
+			}
+			if j < m-1 {
+				col := a[j+1+j*lda : m+j*lda]
+				if piv := a[j+j*lda]; abs(piv) >= sfmin {
+					scal(bl, m-j-1, 1/piv, col, 1)
+				} else {
+					for i := range col {
+						col[i] /= piv
+					}
+				}
+			}
+		}
+		if j < min(m, n)-1 {
+			geru(bl, m-j-1, n-j-1, -1, a[j+1+j*lda:], 1, a[j+(j+1)*lda:], lda, a[j+1+(j+1)*lda:], lda)
+		}
+	}
+	return info
+}
+
+// getrf2 computes the LU factorization A = P*L*U of the m×n matrix A by
+// recursively splitting its columns in halves, as the LAPACK 3.6 xGETRF2. It
+// returns the index of the first zero diagonal element of U, or -1.
+func getrf2[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, ipiv []int) (info int) {
+	info = -1
+	switch {
+	case m == 0 || n == 0:
+		return info
+	case m == 1:
+		ipiv[0] = 0
+		if a[0] == 0 {
+			info = 0
+		}
+		return info
+	case n == 1:
+		i := iamax(bl, m, a, 1)
+		ipiv[0] = i
+		if a[i] == 0 {
+			return 0
+		}
+		a[0], a[i] = a[i], a[0]
+		col := a[1:m]
+		if piv := a[0]; abs(piv) >= safmin[T]() {
+			scal(bl, m-1, 1/piv, col, 1)
+		} else {
+			for i := range col {
+				col[i] /= piv
+			}
+		}
+		return info
+	}
+	n1 := min(m, n) / 2
+	n2 := n - n1
+
+	// Factor [A11; A21], apply its interchanges to [A12; A22], compute A12
+	// and update A22.
+	info = getrf2(bl, m, n1, a, lda, ipiv)
+	a12, a21, a22 := a[n1*lda:], a[n1:], a[n1+n1*lda:]
+	laswp(n2, a12, lda, 0, n1-1, ipiv, 1)
+	trsm(bl, blas.SideL, blas.UploL, blas.TransN, blas.DiagU, n1, n2, 1, a, lda, a12, lda)
+	gemm(bl, blas.TransN, blas.TransN, m-n1, n2, n1, -1, a21, lda, a12, lda, 1, a22, lda)
+
+	// Factor A22 and apply its interchanges to A21.
+	if i := getrf2(bl, m-n1, n2, a22, lda, ipiv[n1:]); info < 0 && i >= 0 {
+		info = i + n1
+	}
+	for i := n1; i < min(m, n); i++ {
+		ipiv[i] += n1
+	}
+	laswp(n1, a, lda, n1, min(m, n)-1, ipiv, 1)
+	return info
+}
+
+// getrf computes the LU factorization A = P*L*U of the m×n matrix A with the
+// blocked right-looking algorithm of xGETRF, factoring each panel with
+// getrf2. It returns the index of the first zero diagonal element of U, or
+// -1.
+func getrf[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, ipiv []int) (info int) {
+	mn := min(m, n)
+	if luBlock <= 1 || luBlock >= mn {
+		return getrf2(bl, m, n, a, lda, ipiv)
+	}
+	info = -1
+	for j := 0; j < mn; j += luBlock {
+		jb := min(mn-j, luBlock)
+
+		// Factor the panel and adjust its pivot indices to A.
+		if i := getrf2(bl, m-j, jb, a[j+j*lda:], lda, ipiv[j:]); info < 0 && i >= 0 {
+			info = i + j
+		}
+		for i := j; i < j+jb; i++ {
+			ipiv[i] += j
+		}
+
+		// Apply the interchanges to the columns left and right of the
+		// panel, then compute the block row of U and update the trailing
+		// matrix.
+		laswp(j, a, lda, j, j+jb-1, ipiv, 1)
+		if j+jb < n {
+			laswp(n-j-jb, a[(j+jb)*lda:], lda, j, j+jb-1, ipiv, 1)
+			trsm(bl, blas.SideL, blas.UploL, blas.TransN, blas.DiagU, jb, n-j-jb, 1, a[j+j*lda:], lda, a[j+(j+jb)*lda:], lda)
+			if j+jb < m {
+				gemm(bl, blas.TransN, blas.TransN, m-j-jb, n-j-jb, jb, -1, a[j+jb+j*lda:], lda, a[j+(j+jb)*lda:], lda, 1, a[j+jb+(j+jb)*lda:], lda)
+			}
+		}
+	}
+	return info
+}
+
+// laswp interchanges rows k of the n columns of A with rows ipiv[k] for
+// k = k1, ..., k2, reading ipiv at the stride |incX| from k1. A negative incX
+// applies the interchanges in reverse order, undoing them.
+func laswp[T gen.Scalar](n int, a []T, lda, k1, k2 int, ipiv []int, incX int) {
+	ix0, i1, i2, inc := k1, k1, k2, 1
+	if incX < 0 {
+		ix0, i1, i2, inc = k1+(k1-k2)*incX, k2, k1, -1
+	}
+	for j0 := 0; j0 < n; j0 += laswpBlock {
+		j1 := min(n, j0+laswpBlock)
+		ix := ix0
+		for i := i1; i != i2+inc; i += inc {
+			if ip := ipiv[ix]; ip != i {
+				for j := j0; j < j1; j++ {
+					a[i+j*lda], a[ip+j*lda] = a[ip+j*lda], a[i+j*lda]
+				}
+			}
+			ix += incX
+		}
+	}
+}
+
+// getrs solves op(A)*X = B with the LU factorization of A computed by getrf,
+// overwriting B by X.
+func getrs[T gen.Scalar](bl blas.BLAS, trans blas.Transpose, n, nrhs int, a []T, lda int, ipiv []int, b []T, ldb int) {
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if trans == blas.TransN {
+		laswp(nrhs, b, ldb, 0, n-1, ipiv, 1)
+		trsm(bl, blas.SideL, blas.UploL, blas.TransN, blas.DiagU, n, nrhs, 1, a, lda, b, ldb)
+		trsm(bl, blas.SideL, blas.UploU, blas.TransN, blas.DiagN, n, nrhs, 1, a, lda, b, ldb)
+		return
+	}
+	trsm(bl, blas.SideL, blas.UploU, trans, blas.DiagN, n, nrhs, 1, a, lda, b, ldb)
+	trsm(bl, blas.SideL, blas.UploL, trans, blas.DiagU, n, nrhs, 1, a, lda, b, ldb)
+	laswp(nrhs, b, ldb, 0, n-1, ipiv, -1)
+}
+
+// gesv solves A*X = B with the LU factorization of A, overwriting A by its
+// factors and B by X. It returns the index of the first zero diagonal
+// element of U, or -1; B is left unchanged when U is singular.
+func gesv[T gen.Scalar](bl blas.BLAS, n, nrhs int, a []T, lda int, ipiv []int, b []T, ldb int) (info int) {
+	if info = getrf(bl, n, n, a, lda, ipiv); info < 0 {
+		getrs(bl, blas.TransN, n, nrhs, a, lda, ipiv, b, ldb)
+	}
+	return info
+}
@@ -0,0 +1,231 @@
+package lapack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// luRoutines holds the LU routines of one precision.
+type luRoutines[T gen.Scalar] struct {
+	getf2, getrf2, getrf func(m, n int, a []T, lda int, ipiv []int) error
+	getrs                func(trans blas.Transpose, n, nrhs int, a []T, lda int, ipiv []int, b []T, ldb int) error
+	gesv                 func(n, nrhs int, a []T, lda int, ipiv []int, b []T, ldb int) error
+	laswp                func(n int, a []T, lda, k1, k2 int, ipiv []int, incX int) error
+}
+
+func TestLU(t *testing.T) {
+	var impl Implementation
+	testLU(t, "S", luRoutines[float32]{impl.SGETF2, impl.SGETRF2, impl.SGETRF, impl.SGETRS, impl.SGESV, impl.SLASWP})
+	testLU(t, "D", luRoutines[float64]{impl.DGETF2, impl.DGETRF2, impl.DGETRF, impl.DGETRS, impl.DGESV, impl.DLASWP})
+	testLU(t, "C", luRoutines[complex64]{impl.CGETF2, impl.CGETRF2, impl.CGETRF, impl.CGETRS, impl.CGESV, impl.CLASWP})
+	testLU(t, "Z", luRoutines[complex128]{impl.ZGETF2, impl.ZGETRF2, impl.ZGETRF, impl.ZGETRS, impl.ZGESV, impl.ZLASWP})
+}
+
+func testLU[T gen.Scalar](t *testing.T, prec string, f luRoutines[T]) {
+	rnd := rand.New(rand.NewSource(1))
+	factors := []struct {
+		name string
+		f    func(m, n int, a []T, lda int, ipiv []int) error
+	}{{"GETF2", f.getf2}, {"GETRF2", f.getrf2}, {"GETRF", f.getrf}}
+
+	// The shapes larger than luBlock run the blocked code of xGETRF.
+	for _, fac := range factors {
+		for _, sh := range [][2]int{{0, 0}, {0, 3}, {3, 0}, {1, 1}, {5, 5}, {7, 4}, {4, 7}, {70, 70}, {150, 130}, {130, 150}} {
+			m, n := sh[0], sh[1]
+			name := fmt.Sprintf("%s%s m=%d n=%d", prec, fac.name, m, n)
+			lda := m + 3
+			a := randMat[T](rnd, m, n, lda)
+			a0 := slices.Clone(a)
+			ipiv := make([]int, min(m, n))
+			if err := fac.f(m, n, a, lda, ipiv); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if !samePad(m, n, lda, a, a0) {
+				t.Errorf("%s: elements outside A modified", name)
+			}
+			if r := luRatio(m, n, a0, a, lda, ipiv); r > maxRatio {
+				t.Errorf("%s: ‖P*A - L*U‖ ratio %.3g", name, r)
+			}
+
+			// Partial pivoting bounds the multipliers: the pivot has the
+			// largest |re|+|im| of its column, so a multiplier has a
+			// modulus of at most √2, and of at most one for real types.
+			bound := 1.0
+			if isComplex[T]() {
+				bound = math.Sqrt2
+			}
+			for j := 0; j < min(m, n); j++ {
+				if ipiv[j] < j || ipiv[j] >= m {
+					t.Errorf("%s: ipiv[%d] = %d, want in [%d,%d)", name, j, ipiv[j], j, m)
+				}
+				for i := j + 1; i < m; i++ {
+					if l := abs(a[i+j*lda]); l > bound*(1+4*eps[T]()) {
+						t.Errorf("%s: |L[%d,%d]| = %v exceeds %v", name, i, j, l, bound)
+					}
+				}
+			}
+		}
+	}
+
+	for _, n := range []int{1, 5, 70, 150} {
+		const nrhs = 3
+		lda, ldb := n+2, n+4
+		a := randMat[T](rnd, n, n, lda)
+		x := randMat[T](rnd, n, nrhs, n)
+		lu := slices.Clone(a)
+		ipiv := make([]int, n)
+		if err := f.getrf(n, n, lu, lda, ipiv); err != nil {
+			t.Fatalf("%sGETRF n=%d: unexpected error %v", prec, n, err)
+		}
+		for _, trans := range []blas.Transpose{blas.TransN, blas.TransT, blas.TransC} {
+			name := fmt.Sprintf("%sGETRS trans=%c n=%d", prec, trans, n)
+			b := make([]T, ldb*nrhs)
+			rhs := mulMat(trans, blas.TransN, n, nrhs, n, a, lda, x, n)
+			for j := 0; j < nrhs; j++ {
+				copy(b[j*ldb:j*ldb+n], rhs[j*n:])
+			}
+			if err := f.getrs(trans, n, nrhs, lu, lda, ipiv, b, ldb); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if r := solveRatio(trans, n, nrhs, a, lda, b, ldb, rhs, n); r > maxRatio {
+				t.Errorf("%s: ‖op(A)*X - B‖ ratio %.3g", name, r)
+			}
+		}
+
+		// xGESV factors A as xGETRF does and solves as xGETRS does.
+		name := fmt.Sprintf("%sGESV n=%d", prec, n)
+		rhs := mulMat(blas.TransN, blas.TransN, n, nrhs, n, a, lda, x, n)
+		b := make([]T, ldb*nrhs)
+		for j := 0; j < nrhs; j++ {
+			copy(b[j*ldb:j*ldb+n], rhs[j*n:])
+		}
+		sv := slices.Clone(a)
+		svPiv := make([]int, n)
+		if err := f.gesv(n, nrhs, sv, lda, svPiv, b, ldb); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if !slices.Equal(sv, lu) || !slices.Equal(svPiv, ipiv) {
+			t.Errorf("%s: factorization differs from %sGETRF", name, prec)
+		}
+		if r := solveRatio(blas.TransN, n, nrhs, a, lda, b, ldb, rhs, n); r > maxRatio {
+			t.Errorf("%s: ‖A*X - B‖ ratio %.3g", name, r)
+		}
+	}
+
+	// A zero column makes the diagonal element of U in that column exactly
+	// zero; the factorization is completed and reported singular there.
+	for _, n := range []int{6, 150} {
+		zero := n / 3
+		if n > luBlock {
+			zero = luBlock + 7
+		}
+		for _, fac := range factors {
+			name := fmt.Sprintf("%s%s n=%d", prec, fac.name, n)
+			a := randMat[T](rnd, n, n, n)
+			clear(a[zero*n : (zero+1)*n])
+			a0 := slices.Clone(a)
+			ipiv := make([]int, n)
+			err := fac.f(n, n, a, n, ipiv)
+			var se *SingularError
+			if !errors.As(err, &se) {
+				t.Errorf("%s: err = %v, want a *SingularError", name, err)
+				continue
+			}
+			if se.Routine != prec+fac.name || se.Index != zero {
+				t.Errorf("%s: err = %+v, want Routine %s%s and Index %d", name, *se, prec, fac.name, zero)
+			}
+			if r := luRatio(n, n, a0, a, n, ipiv); r > maxRatio {
+				t.Errorf("%s: ‖P*A - L*U‖ ratio %.3g", name, r)
+			}
+		}
+		a := randMat[T](rnd, n, n, n)
+		clear(a[zero*n : (zero+1)*n])
+		b := randMat[T](rnd, n, 1, n)
+		b0 := slices.Clone(b)
+		err := f.gesv(n, 1, a, n, make([]int, n), b, n)
+		if se := (*SingularError)(nil); !errors.As(err, &se) || se.Index != zero {
+			t.Errorf("%sGESV n=%d: err = %v, want a *SingularError with Index %d", prec, n, err, zero)
+		}
+		if !slices.Equal(b, b0) {
+			t.Errorf("%sGESV n=%d: B modified although A is singular", prec, n)
+		}
+	}
+
+	// xLASWP applies the interchanges in order for a positive incX and
+	// undoes them for a negative one. The matrix is wider than laswpBlock.
+	for _, inc := range []int{1, 2} {
+		const m, n, lda, k1, k2 = 9, 70, 11, 2, 7
+		name := fmt.Sprintf("%sLASWP incX=%d", prec, inc)
+		a := randMat[T](rnd, m, n, lda)
+		a0 := slices.Clone(a)
+		ipiv := make([]int, k1+(k2-k1)*inc+1)
+		for i := range ipiv {
+			ipiv[i] = rnd.Intn(m)
+		}
+		want := slices.Clone(a)
+		for k := k1; k <= k2; k++ {
+			p := ipiv[k1+(k-k1)*inc]
+			for j := 0; j < n; j++ {
+				want[k+j*lda], want[p+j*lda] = want[p+j*lda], want[k+j*lda]
+			}
+		}
+		if err := f.laswp(n, a, lda, k1, k2, ipiv, inc); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if !slices.Equal(a, want) {
+			t.Errorf("%s: rows not interchanged in order", name)
+		}
+		if err := f.laswp(n, a, lda, k1, k2, ipiv, -inc); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if !slices.Equal(a, a0) {
+			t.Errorf("%s: interchanges not undone by incX=%d", name, -inc)
+		}
+	}
+}
+
+// luRatio returns the test ratio of ‖P*A - L*U‖ for the m×n matrix A and
+// its factorization lu computed by xGETRF, with P applied by interchanging
+// the rows of A as ipiv gives.
+func luRatio[T gen.Scalar](m, n int, a, lu []T, lda int, ipiv []int) float64 {
+	mn := min(m, n)
+	pa := slices.Clone(a)
+	for i, p := range ipiv {
+		for j := 0; j < n; j++ {
+			pa[i+j*lda], pa[p+j*lda] = pa[p+j*lda], pa[i+j*lda]
+		}
+	}
+	l, u := make([]T, m*mn), make([]T, mn*n)
+	for j := 0; j < mn; j++ {
+		l[j+j*m] = 1
+		for i := j + 1; i < m; i++ {
+			l[i+j*m] = lu[i+j*lda]
+		}
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i <= min(j, mn-1); i++ {
+			u[i+j*mn] = lu[i+j*lda]
+		}
+	}
+	prod := mulMat(blas.TransN, blas.TransN, m, n, mn, l, m, u, mn)
+	return ratio[T](diffF(m, n, pa, lda, prod, m), normF(m, n, a, lda), max(m, n))
+}
+
+// solveRatio returns the test ratio of ‖op(A)*X - B‖ for the n×n matrix A,
+// the n×nrhs solution X and right-hand side B.
+func solveRatio[T gen.Scalar](trans blas.Transpose, n, nrhs int, a []T, lda int, x []T, ldx int, b []T, ldb int) float64 {
+	ax := mulMat(trans, blas.TransN, n, nrhs, n, a, lda, x, ldx)
+	return ratio[T](diffF(n, nrhs, ax, n, b, ldb), normF(n, n, a, lda)*normF(n, nrhs, x, ldx), n)
+}
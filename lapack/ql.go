@@ -0,0 +1,539 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEQL2 computes the QL factorization A = Q*L of an m×n matrix one
+// column at a time, from the last. L is left in the lower triangle of the
+// last n rows of A if m >= n, or of its last m columns otherwise. Q is
+// represented as the product Q = H(k-1)*...*H(1)*H(0), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// in column n-k+i above row m-k+i with their unit last element implied.
+// work must hold n elements.
+func (impl Implementation) SGEQL2(m, n int, a []float32, lda int, tau, work []float32) error {
+	if err := checkGeqrf("SGEQL2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geql2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// SGEQLF computes the QL factorization of SGEQL2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,n) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SGEQLF(m, n int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkGeqrf("SGEQLF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqlf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORG2L overwrites the m×n matrix A, whose last k columns hold the
+// reflectors of a QL factorization as left by SGEQLF, with the last n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) SORG2L(m, n, k int, a []float32, lda int, tau, work []float32) error {
+	if err := checkOrgqr("SORG2L", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2l(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// SORGQL generates the Q of SORG2L with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGQL(m, n, k int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrgqr("SORGQL", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgql(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORM2L overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// SGEQLF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) SORM2L(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32) error {
+	if err := checkOrmqr("SORM2L", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, false); err != nil {
+		return err
+	}
+	orm2l(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// SORMQL computes the product of SORM2L with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) SORMQL(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmqr("SORMQL", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, false); err != nil {
+		return err
+	}
+	ormql(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DGEQL2 computes the QL factorization A = Q*L of an m×n matrix one
+// column at a time, from the last. L is left in the lower triangle of the
+// last n rows of A if m >= n, or of its last m columns otherwise. Q is
+// represented as the product Q = H(k-1)*...*H(1)*H(0), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// in column n-k+i above row m-k+i with their unit last element implied.
+// work must hold n elements.
+func (impl Implementation) DGEQL2(m, n int, a []float64, lda int, tau, work []float64) error {
+	if err := checkGeqrf("DGEQL2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geql2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// DGEQLF computes the QL factorization of DGEQL2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,n) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DGEQLF(m, n int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkGeqrf("DGEQLF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqlf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORG2L overwrites the m×n matrix A, whose last k columns hold the
+// reflectors of a QL factorization as left by DGEQLF, with the last n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) DORG2L(m, n, k int, a []float64, lda int, tau, work []float64) error {
+	if err := checkOrgqr("DORG2L", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2l(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// DORGQL generates the Q of DORG2L with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGQL(m, n, k int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrgqr("DORGQL", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgql(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORM2L overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// DGEQLF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) DORM2L(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64) error {
+	if err := checkOrmqr("DORM2L", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, false); err != nil {
+		return err
+	}
+	orm2l(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// DORMQL computes the product of DORM2L with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) DORMQL(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmqr("DORMQL", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, false); err != nil {
+		return err
+	}
+	ormql(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CGEQL2 computes the QL factorization A = Q*L of an m×n matrix one
+// column at a time, from the last. L is left in the lower triangle of the
+// last n rows of A if m >= n, or of its last m columns otherwise. Q is
+// represented as the product Q = H(k-1)*...*H(1)*H(0), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// in column n-k+i above row m-k+i with their unit last element implied.
+// work must hold n elements.
+func (impl Implementation) CGEQL2(m, n int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkGeqrf("CGEQL2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geql2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// CGEQLF computes the QL factorization of CGEQL2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,n) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CGEQLF(m, n int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkGeqrf("CGEQLF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqlf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNG2L overwrites the m×n matrix A, whose last k columns hold the
+// reflectors of a QL factorization as left by CGEQLF, with the last n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) CUNG2L(m, n, k int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkOrgqr("CUNG2L", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2l(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// CUNGQL generates the Q of CUNG2L with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGQL(m, n, k int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrgqr("CUNGQL", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgql(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNM2L overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// CGEQLF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) CUNM2L(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64) error {
+	if err := checkOrmqr("CUNM2L", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, false); err != nil {
+		return err
+	}
+	orm2l(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// CUNMQL computes the product of CUNM2L with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CUNMQL(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmqr("CUNMQL", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, false); err != nil {
+		return err
+	}
+	ormql(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZGEQL2 computes the QL factorization A = Q*L of an m×n matrix one
+// column at a time, from the last. L is left in the lower triangle of the
+// last n rows of A if m >= n, or of its last m columns otherwise. Q is
+// represented as the product Q = H(k-1)*...*H(1)*H(0), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// in column n-k+i above row m-k+i with their unit last element implied.
+// work must hold n elements.
+func (impl Implementation) ZGEQL2(m, n int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkGeqrf("ZGEQL2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geql2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// ZGEQLF computes the QL factorization of ZGEQL2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,n) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZGEQLF(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkGeqrf("ZGEQLF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqlf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNG2L overwrites the m×n matrix A, whose last k columns hold the
+// reflectors of a QL factorization as left by ZGEQLF, with the last n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) ZUNG2L(m, n, k int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkOrgqr("ZUNG2L", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2l(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// ZUNGQL generates the Q of ZUNG2L with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGQL(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrgqr("ZUNGQL", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgql(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNM2L overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// ZGEQLF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) ZUNM2L(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128) error {
+	if err := checkOrmqr("ZUNM2L", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, false); err != nil {
+		return err
+	}
+	orm2l(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// ZUNMQL computes the product of ZUNM2L with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZUNMQL(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmqr("ZUNMQL", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, false); err != nil {
+		return err
+	}
+	ormql(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// geql2 computes the QL factorization A = Q*L of the m×n matrix A one column
+// at a time, from the last. For m >= n, L is left in the lower triangle of
+// the last n rows of A; for m < n, in the lower trapezoid from column n-m
+// on. Q is represented by the reflectors H(i) = I - tau[i]*v*v**H,
+// Q = H(k-1)*...*H(1)*H(0), whose vectors are stored in column n-k+i above
+// row m-k+i with their unit last element implied. work must hold n
+// elements.
+func geql2[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T) {
+	k := min(m, n)
+	for i := k - 1; i >= 0; i-- {
+		// Generate H(i) to annihilate A(0:m-k+i,n-k+i) and apply H(i)**H to
+		// A(0:m-k+i+1,0:n-k+i) from the left.
+		mi, ni := m-k+i, n-k+i
+		ii := mi + ni*lda
+		a[ii], tau[i] = larfg(bl, mi+1, a[ii], a[ni*lda:], 1)
+		aii := a[ii]
+		a[ii] = 1
+		larf(bl, blas.SideL, mi+1, ni, a[ni*lda:], 1, conj(tau[i]), a, lda, work)
+		a[ii] = aii
+	}
+}
+
+// geqlf computes the QL factorization of geql2 with the blocked algorithm of
+// xGEQLF. work holds lwork >= n elements, and the block size is reduced to
+// fit. A workspace query, lwork = -1, only sets work[0] to the optimal
+// lwork.
+func geqlf[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T, lwork int) {
+	k := min(m, n)
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, n)))
+		if k > 0 {
+			work[0] = fromReal[T](float64(n * qrBlock))
+		}
+		return
+	}
+	if k == 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := n
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	mu, nu := m, n
+	if nb >= 2 && nb < k && nx < k {
+		// The first kk reflectors, from the last, are computed by blocks of
+		// nb and the rest by the unblocked code.
+		ki := ((k - nx - 1) / nb) * nb
+		kk := min(k, ki+nb)
+		for i := k - kk + ki; i >= k-kk; i -= nb {
+			ib := min(k-i, nb)
+			ni := n - k + i
+			geql2(bl, m-k+i+ib, ib, a[ni*lda:], lda, tau[i:], work)
+			if ni > 0 {
+				// Form the triangular factor of the block reflector
+				// H = H(i+ib-1)*...*H(i+1)*H(i) and apply H**H to
+				// A(0:m-k+i+ib,0:n-k+i) from the left.
+				larft(bl, DirectB, StoreVC, m-k+i+ib, ib, a[ni*lda:], lda, tau[i:], work, ldwork)
+				larfb(bl, blas.SideL, blas.TransC, DirectB, StoreVC, m-k+i+ib, ni, ib, a[ni*lda:], lda, work, ldwork, a, lda, work[ib:], ldwork)
+			}
+		}
+		mu, nu = m-kk, n-kk
+	}
+	if mu > 0 && nu > 0 {
+		geql2(bl, mu, nu, a, lda, tau, work)
+	}
+}
+
+// org2l overwrites the m×n matrix A, whose last k columns hold the
+// reflectors of a QL factorization as left by geqlf, with the last n columns
+// of Q = H(k-1)*...*H(1)*H(0). work must hold n elements.
+func org2l[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T) {
+	if n <= 0 {
+		return
+	}
+	// Columns 0:n-k are columns of the identity.
+	for j := 0; j < n-k; j++ {
+		col := a[j*lda : j*lda+m]
+		for l := range col {
+			col[l] = 0
+		}
+		col[m-n+j] = 1
+	}
+	for i := 0; i < k; i++ {
+		// Apply H(i) to A(0:m-n+ii+1,0:ii) from the left.
+		ii := n - k + i
+		mi := m - n + ii
+		a[mi+ii*lda] = 1
+		larf(bl, blas.SideL, mi+1, ii, a[ii*lda:], 1, tau[i], a, lda, work)
+		scal(bl, mi, -tau[i], a[ii*lda:], 1)
+		a[mi+ii*lda] = 1 - tau[i]
+		for l := mi + 1; l < m; l++ {
+			a[l+ii*lda] = 0
+		}
+	}
+}
+
+// orgql generates the Q of org2l with the blocked algorithm of xORGQL. work
+// holds lwork >= n elements, and the block size is reduced to fit. A
+// workspace query, lwork = -1, only sets work[0] to the optimal lwork.
+func orgql[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, n) * qrBlock))
+		return
+	}
+	if n <= 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := n
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	kk := 0
+	if nb >= 2 && nb < k && nx < k {
+		// The last kk columns are handled by blocks of nb and the first
+		// ones by the unblocked code.
+		kk = min(k, ((k-nx+nb-1)/nb)*nb)
+		for j := 0; j < n-kk; j++ {
+			for i := m - kk; i < m; i++ {
+				a[i+j*lda] = 0
+			}
+		}
+	}
+	org2l(bl, m-kk, n-kk, k-kk, a, lda, tau, work)
+	for i := k - kk; i < k; i += nb {
+		ib := min(nb, k-i)
+		ni := n - k + i
+		if ni > 0 {
+			// Form the triangular factor of the block reflector
+			// H = H(i+ib-1)*...*H(i+1)*H(i) and apply H to
+			// A(0:m-k+i+ib,0:n-k+i) from the left.
+			larft(bl, DirectB, StoreVC, m-k+i+ib, ib, a[ni*lda:], lda, tau[i:], work, ldwork)
+			larfb(bl, blas.SideL, blas.TransN, DirectB, StoreVC, m-k+i+ib, ni, ib, a[ni*lda:], lda, work, ldwork, a, lda, work[ib:], ldwork)
+		}
+		// Apply H to the rows 0:m-k+i+ib of the block itself and zero its
+		// rows below.
+		org2l(bl, m-k+i+ib, ib, ib, a[ni*lda:], lda, tau[i:], work)
+		for j := ni; j < ni+ib; j++ {
+			for l := m - k + i + ib; l < m; l++ {
+				a[l+j*lda] = 0
+			}
+		}
+	}
+}
+
+// orm2l overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where op(Q) is Q or Q**H and Q is given by the k reflectors left
+// in the columns of A and in tau by geqlf. work must hold n elements for
+// side L and m for side R.
+func orm2l[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T) {
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq := m
+	if !left {
+		nq = n
+	}
+	mi, ni := m, n
+	for j := 0; j < k; j++ {
+		// H(0) is applied first for Q*C and C*Q**H, and last otherwise.
+		i := j
+		if left != notran {
+			i = k - 1 - j
+		}
+		// H(i) is applied to C(0:m-k+i+1,0:n) or C(0:m,0:n-k+i+1).
+		if left {
+			mi = m - k + i + 1
+		} else {
+			ni = n - k + i + 1
+		}
+		taui := tau[i]
+		if !notran {
+			taui = conj(taui)
+		}
+		ii := nq - k + i + i*lda
+		aii := a[ii]
+		a[ii] = 1
+		larf(bl, side, mi, ni, a[i*lda:], 1, taui, c, ldc, work)
+		a[ii] = aii
+	}
+}
+
+// ormql computes the product of orm2l with the blocked algorithm of xORMQL.
+// work holds lwork >= nw elements, where nw is n for side L and m for side
+// R, and the block size is reduced to fit. A workspace query, lwork = -1,
+// only sets work[0] to the optimal lwork.
+func ormql[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq, nw := m, n
+	if !left {
+		nq, nw = n, m
+	}
+	nb := min(ormBlockMax, qrBlock)
+	if nb > 1 && nb < k && lwork < ormWork(side, m, n) {
+		nb = (lwork - ormTSize) / nw
+	}
+	if nb < 2 || nb >= k {
+		orm2l(bl, side, trans, m, n, k, a, lda, tau, c, ldc, work)
+		return
+	}
+	t := work[nw*nb:]
+	mi, ni := m, n
+	last := ((k - 1) / nb) * nb
+	for j := 0; j <= last; j += nb {
+		i := j
+		if left != notran {
+			i = last - j
+		}
+		ib := min(nb, k-i)
+		// Form the triangular factor of the block reflector
+		// H = H(i+ib-1)*...*H(i+1)*H(i) and apply H or H**H to
+		// C(0:m-k+i+ib,0:n) or C(0:m,0:n-k+i+ib).
+		larft(bl, DirectB, StoreVC, nq-k+i+ib, ib, a[i*lda:], lda, tau[i:], t, ormBlockMax+1)
+		if left {
+			mi = m - k + i + ib
+		} else {
+			ni = n - k + i + ib
+		}
+		larfb(bl, side, trans, DirectB, StoreVC, mi, ni, ib, a[i*lda:], lda, t, ormBlockMax+1, c, ldc, work, nw)
+	}
+}
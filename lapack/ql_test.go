@@ -0,0 +1,13 @@
+package lapack
+
+import "testing"
+
+var qlKind = orthKind{true, true, [6]string{"GEQL2", "GEQLF", "ORG2L", "ORGQL", "ORM2L", "ORMQL"}}
+
+func TestQL(t *testing.T) {
+	var impl Implementation
+	testOrth(t, "S", qlKind, orthRoutines[float32]{impl.SGEQL2, impl.SGEQLF, impl.SORG2L, impl.SORGQL, impl.SORM2L, impl.SORMQL})
+	testOrth(t, "D", qlKind, orthRoutines[float64]{impl.DGEQL2, impl.DGEQLF, impl.DORG2L, impl.DORGQL, impl.DORM2L, impl.DORMQL})
+	testOrth(t, "C", qlKind, orthRoutines[complex64]{impl.CGEQL2, impl.CGEQLF, impl.CUNG2L, impl.CUNGQL, impl.CUNM2L, impl.CUNMQL})
+	testOrth(t, "Z", qlKind, orthRoutines[complex128]{impl.ZGEQL2, impl.ZGEQLF, impl.ZUNG2L, impl.ZUNGQL, impl.ZUNM2L, impl.ZUNMQL})
+}
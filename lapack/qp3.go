@@ -0,0 +1,524 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEQP3 computes the QR factorization with column pivoting A*P = Q*R of
+// an m×n matrix with a blocked algorithm, choosing at each step the column
+// of largest remaining norm. Columns j with jpvt[j] >= 0 on entry are moved
+// to the front and factored first; the others must have jpvt[j] = -1. On
+// return jpvt[j] is the column of A that is column j of A*P, R is in the
+// upper triangle of A and Q is represented as in SGEQRF. The magnitudes of
+// the diagonal of R are non-increasing from the first free column on, which
+// reveals the numerical rank of A. work holds lwork >= 3*n+1 elements; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) SGEQP3(m, n int, a []float32, lda int, jpvt []int, tau, work []float32, lwork int) error {
+	if err := checkGeqp3("SGEQP3", m, n, len(a), lda, len(jpvt), len(tau), len(work), lwork, -1); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		_, opt := geqp3Work(m, n, false)
+		work[0] = float32(opt)
+		return nil
+	}
+	vn1, vn2, w, lw := normsInWork(m, n, work, lwork)
+	geqp3(impl.bl(), m, n, a, lda, jpvt, tau, w, lw, vn1, vn2)
+	return nil
+}
+
+// SLAQP2 computes the QR factorization with column pivoting of rows
+// offset:m of an m×n matrix one column at a time, after applying each
+// reflector to rows 0:offset, as a step of SGEQP3. vn1 and vn2 hold the
+// partial and exact norms of the columns below row offset and are updated.
+// jpvt is permuted with the columns. work must hold n elements.
+func (impl Implementation) SLAQP2(m, n, offset int, a []float32, lda int, jpvt []int, tau []float32, vn1, vn2 []float32, work []float32) error {
+	if err := checkLaqp2("SLAQP2", m, n, offset, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(work)); err != nil {
+		return err
+	}
+	laqp2(impl.bl(), m, n, offset, a, lda, jpvt, tau, vn1, vn2, work)
+	return nil
+}
+
+// SLAQPS computes a step of the blocked QR factorization with column
+// pivoting of SGEQP3: it factors up to nb columns of rows offset:m of an
+// m×n matrix and applies them to the rest of the matrix as a block. It
+// returns the number of columns factored, which is less than nb when a
+// column norm lost its accuracy and had to be recomputed. vn1, vn2 and jpvt
+// are as in SLAQP2; auxv holds nb elements and f is an n×nb matrix with
+// leading dimension ldf.
+func (impl Implementation) SLAQPS(m, n, offset, nb int, a []float32, lda int, jpvt []int, tau []float32, vn1, vn2 []float32, auxv, f []float32, ldf int) (kb int, err error) {
+	if err := checkLaqps("SLAQPS", m, n, offset, nb, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(auxv), len(f), ldf); err != nil {
+		return 0, err
+	}
+	return laqps(impl.bl(), m, n, offset, nb, a, lda, jpvt, tau, vn1, vn2, auxv, f, ldf), nil
+}
+
+// DGEQP3 computes the QR factorization with column pivoting A*P = Q*R of
+// an m×n matrix with a blocked algorithm, choosing at each step the column
+// of largest remaining norm. Columns j with jpvt[j] >= 0 on entry are moved
+// to the front and factored first; the others must have jpvt[j] = -1. On
+// return jpvt[j] is the column of A that is column j of A*P, R is in the
+// upper triangle of A and Q is represented as in DGEQRF. The magnitudes of
+// the diagonal of R are non-increasing from the first free column on, which
+// reveals the numerical rank of A. work holds lwork >= 3*n+1 elements; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) DGEQP3(m, n int, a []float64, lda int, jpvt []int, tau, work []float64, lwork int) error {
+	if err := checkGeqp3("DGEQP3", m, n, len(a), lda, len(jpvt), len(tau), len(work), lwork, -1); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		_, opt := geqp3Work(m, n, false)
+		work[0] = float64(opt)
+		return nil
+	}
+	vn1, vn2, w, lw := normsInWork(m, n, work, lwork)
+	geqp3(impl.bl(), m, n, a, lda, jpvt, tau, w, lw, vn1, vn2)
+	return nil
+}
+
+// DLAQP2 computes the QR factorization with column pivoting of rows
+// offset:m of an m×n matrix one column at a time, after applying each
+// reflector to rows 0:offset, as a step of DGEQP3. vn1 and vn2 hold the
+// partial and exact norms of the columns below row offset and are updated.
+// jpvt is permuted with the columns. work must hold n elements.
+func (impl Implementation) DLAQP2(m, n, offset int, a []float64, lda int, jpvt []int, tau []float64, vn1, vn2 []float64, work []float64) error {
+	if err := checkLaqp2("DLAQP2", m, n, offset, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(work)); err != nil {
+		return err
+	}
+	laqp2(impl.bl(), m, n, offset, a, lda, jpvt, tau, vn1, vn2, work)
+	return nil
+}
+
+// DLAQPS computes a step of the blocked QR factorization with column
+// pivoting of DGEQP3: it factors up to nb columns of rows offset:m of an
+// m×n matrix and applies them to the rest of the matrix as a block. It
+// returns the number of columns factored, which is less than nb when a
+// column norm lost its accuracy and had to be recomputed. vn1, vn2 and jpvt
+// are as in DLAQP2; auxv holds nb elements and f is an n×nb matrix with
+// leading dimension ldf.
+func (impl Implementation) DLAQPS(m, n, offset, nb int, a []float64, lda int, jpvt []int, tau []float64, vn1, vn2 []float64, auxv, f []float64, ldf int) (kb int, err error) {
+	if err := checkLaqps("DLAQPS", m, n, offset, nb, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(auxv), len(f), ldf); err != nil {
+		return 0, err
+	}
+	return laqps(impl.bl(), m, n, offset, nb, a, lda, jpvt, tau, vn1, vn2, auxv, f, ldf), nil
+}
+
+// CGEQP3 computes the QR factorization with column pivoting A*P = Q*R of
+// an m×n matrix with a blocked algorithm, choosing at each step the column
+// of largest remaining norm. Columns j with jpvt[j] >= 0 on entry are moved
+// to the front and factored first; the others must have jpvt[j] = -1. On
+// return jpvt[j] is the column of A that is column j of A*P, R is in the
+// upper triangle of A and Q is represented as in CGEQRF. The magnitudes of
+// the diagonal of R are non-increasing from the first free column on, which
+// reveals the numerical rank of A. work holds lwork >= n+1 elements and
+// rwork 2*n; the optimal lwork is returned in work[0] by a call with
+// lwork = -1 that does nothing else.
+func (impl Implementation) CGEQP3(m, n int, a []complex64, lda int, jpvt []int, tau, work []complex64, lwork int, rwork []float32) error {
+	if err := checkGeqp3("CGEQP3", m, n, len(a), lda, len(jpvt), len(tau), len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		_, opt := geqp3Work(m, n, true)
+		work[0] = complex(float32(opt), 0)
+		return nil
+	}
+	geqp3(impl.bl(), m, n, a, lda, jpvt, tau, work, lwork, rwork[:n], rwork[n:2*n])
+	return nil
+}
+
+// CLAQP2 computes the QR factorization with column pivoting of rows
+// offset:m of an m×n matrix one column at a time, after applying each
+// reflector to rows 0:offset, as a step of CGEQP3. vn1 and vn2 hold the
+// partial and exact norms of the columns below row offset and are updated.
+// jpvt is permuted with the columns. work must hold n elements.
+func (impl Implementation) CLAQP2(m, n, offset int, a []complex64, lda int, jpvt []int, tau []complex64, vn1, vn2 []float32, work []complex64) error {
+	if err := checkLaqp2("CLAQP2", m, n, offset, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(work)); err != nil {
+		return err
+	}
+	laqp2(impl.bl(), m, n, offset, a, lda, jpvt, tau, vn1, vn2, work)
+	return nil
+}
+
+// CLAQPS computes a step of the blocked QR factorization with column
+// pivoting of CGEQP3: it factors up to nb columns of rows offset:m of an
+// m×n matrix and applies them to the rest of the matrix as a block. It
+// returns the number of columns factored, which is less than nb when a
+// column norm lost its accuracy and had to be recomputed. vn1, vn2 and jpvt
+// are as in CLAQP2; auxv holds nb elements and f is an n×nb matrix with
+// leading dimension ldf.
+func (impl Implementation) CLAQPS(m, n, offset, nb int, a []complex64, lda int, jpvt []int, tau []complex64, vn1, vn2 []float32, auxv, f []complex64, ldf int) (kb int, err error) {
+	if err := checkLaqps("CLAQPS", m, n, offset, nb, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(auxv), len(f), ldf); err != nil {
+		return 0, err
+	}
+	return laqps(impl.bl(), m, n, offset, nb, a, lda, jpvt, tau, vn1, vn2, auxv, f, ldf), nil
+}
+
+// ZGEQP3 computes the QR factorization with column pivoting A*P = Q*R of
+// an m×n matrix with a blocked algorithm, choosing at each step the column
+// of largest remaining norm. Columns j with jpvt[j] >= 0 on entry are moved
+// to the front and factored first; the others must have jpvt[j] = -1. On
+// return jpvt[j] is the column of A that is column j of A*P, R is in the
+// upper triangle of A and Q is represented as in ZGEQRF. The magnitudes of
+// the diagonal of R are non-increasing from the first free column on, which
+// reveals the numerical rank of A. work holds lwork >= n+1 elements and
+// rwork 2*n; the optimal lwork is returned in work[0] by a call with
+// lwork = -1 that does nothing else.
+func (impl Implementation) ZGEQP3(m, n int, a []complex128, lda int, jpvt []int, tau, work []complex128, lwork int, rwork []float64) error {
+	if err := checkGeqp3("ZGEQP3", m, n, len(a), lda, len(jpvt), len(tau), len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		_, opt := geqp3Work(m, n, true)
+		work[0] = complex(float64(opt), 0)
+		return nil
+	}
+	geqp3(impl.bl(), m, n, a, lda, jpvt, tau, work, lwork, rwork[:n], rwork[n:2*n])
+	return nil
+}
+
+// ZLAQP2 computes the QR factorization with column pivoting of rows
+// offset:m of an m×n matrix one column at a time, after applying each
+// reflector to rows 0:offset, as a step of ZGEQP3. vn1 and vn2 hold the
+// partial and exact norms of the columns below row offset and are updated.
+// jpvt is permuted with the columns. work must hold n elements.
+func (impl Implementation) ZLAQP2(m, n, offset int, a []complex128, lda int, jpvt []int, tau []complex128, vn1, vn2 []float64, work []complex128) error {
+	if err := checkLaqp2("ZLAQP2", m, n, offset, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(work)); err != nil {
+		return err
+	}
+	laqp2(impl.bl(), m, n, offset, a, lda, jpvt, tau, vn1, vn2, work)
+	return nil
+}
+
+// ZLAQPS computes a step of the blocked QR factorization with column
+// pivoting of ZGEQP3: it factors up to nb columns of rows offset:m of an
+// m×n matrix and applies them to the rest of the matrix as a block. It
+// returns the number of columns factored, which is less than nb when a
+// column norm lost its accuracy and had to be recomputed. vn1, vn2 and jpvt
+// are as in ZLAQP2; auxv holds nb elements and f is an n×nb matrix with
+// leading dimension ldf.
+func (impl Implementation) ZLAQPS(m, n, offset, nb int, a []complex128, lda int, jpvt []int, tau []complex128, vn1, vn2 []float64, auxv, f []complex128, ldf int) (kb int, err error) {
+	if err := checkLaqps("ZLAQPS", m, n, offset, nb, len(a), lda, len(jpvt), len(tau), len(vn1), len(vn2), len(auxv), len(f), ldf); err != nil {
+		return 0, err
+	}
+	return laqps(impl.bl(), m, n, offset, nb, a, lda, jpvt, tau, vn1, vn2, auxv, f, ldf), nil
+}
+
+// checkGeqp3 checks the GEQP3 routines. The real routines keep the column
+// norms in work and pass -1 for lenRwork.
+func checkGeqp3(routine string, m, n, lenA, lda, lenJpvt, lenTau, lenWork, lwork, lenRwork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, m, "m")
+	minWork, _ := geqp3Work(m, n, lenRwork >= 0)
+	if lenRwork < 0 {
+		c.lwork(8, lwork, minWork, "3*n+1")
+	} else {
+		c.lwork(8, lwork, minWork, "n+1")
+	}
+	if c.ok() {
+		c.work(7, lenWork, lwork)
+		if lwork != -1 {
+			c.length(3, "a", lenA, matLen(m, n, lda))
+			c.length(5, "jpvt", lenJpvt, n)
+			c.length(6, "tau", lenTau, min(m, n))
+			if lenRwork >= 0 {
+				c.length(9, "rwork", lenRwork, 2*n)
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkLaqp2 checks the LAQP2 routines.
+func checkLaqp2(routine string, m, n, offset, lenA, lda, lenJpvt, lenTau, lenVn1, lenVn2, lenWork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	if offset < 0 || offset > m {
+		c.fail(3, "offset", "must satisfy 0 <= offset <= m")
+	}
+	c.ld(5, "lda", lda, m, "m")
+	if c.ok() {
+		c.length(4, "a", lenA, matLen(m, n, lda))
+		c.length(6, "jpvt", lenJpvt, n)
+		c.length(7, "tau", lenTau, min(m-offset, n))
+		c.length(8, "vn1", lenVn1, n)
+		c.length(9, "vn2", lenVn2, n)
+		c.length(10, "work", lenWork, n)
+	}
+	return c.result()
+}
+
+// checkLaqps checks the LAQPS routines.
+func checkLaqps(routine string, m, n, offset, nb, lenA, lda, lenJpvt, lenTau, lenVn1, lenVn2, lenAuxv, lenF, ldf int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	if offset < 0 || offset > m {
+		c.fail(3, "offset", "must satisfy 0 <= offset <= m")
+	}
+	if nb < 0 || nb > min(m-offset, n) {
+		c.fail(4, "nb", "must satisfy 0 <= nb <= min(m-offset,n)")
+	}
+	c.ld(6, "lda", lda, m, "m")
+	c.ld(13, "ldf", ldf, n, "n")
+	if c.ok() {
+		c.length(5, "a", lenA, matLen(m, n, lda))
+		c.length(7, "jpvt", lenJpvt, n)
+		c.length(8, "tau", lenTau, nb)
+		c.length(9, "vn1", lenVn1, n)
+		c.length(10, "vn2", lenVn2, n)
+		c.length(11, "auxv", lenAuxv, nb)
+		c.length(12, "f", lenF, matLen(n, nb, ldf))
+	}
+	return c.result()
+}
+
+// geqp3Work returns the minimum and optimal workspace lengths of the GEQP3
+// routines. The real routines also keep the 2*n column norms in work.
+func geqp3Work(m, n int, complex bool) (minWork, opt int) {
+	if min(m, n) == 0 {
+		return 1, 1
+	}
+	if complex {
+		return n + 1, (n + 1) * qrBlock
+	}
+	return 3*n + 1, 2*n + (n+1)*qrBlock
+}
+
+// normsInWork splits the workspace of the real GEQP3 routines into the two
+// vectors of column norms and the workspace of geqp3.
+func normsInWork[T gen.Float](m, n int, work []T, lwork int) (vn1, vn2, rest []T, lrest int) {
+	if min(m, n) == 0 {
+		return nil, nil, work, lwork
+	}
+	return work[:n], work[n : 2*n], work[2*n:], lwork - 2*n
+}
+
+// geqp3 computes the QR factorization with column pivoting A*P = Q*R of the
+// m×n matrix A with the blocked algorithm of xGEQP3. The columns j with
+// jpvt[j] >= 0 on entry are moved to the front and factored first; the
+// others are chosen by largest remaining norm. On return jpvt[j] is the
+// column of A that is column j of A*P. vn1 and vn2 receive the partial and
+// exact column norms and must hold n elements. work holds lwork elements,
+// at least n+1 when A is not empty, and the block size is reduced to fit.
+func geqp3[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n int, a []T, lda int, jpvt []int, tau, work []T, lwork int, vn1, vn2 []R) {
+	minmn := min(m, n)
+
+	// Move the initial columns to the front.
+	nfxd := 0
+	for j := 0; j < n; j++ {
+		if jpvt[j] < 0 {
+			jpvt[j] = j
+			continue
+		}
+		if j != nfxd {
+			swap(bl, m, a[j*lda:], 1, a[nfxd*lda:], 1)
+			jpvt[j] = jpvt[nfxd]
+			jpvt[nfxd] = j
+		} else {
+			jpvt[j] = j
+		}
+		nfxd++
+	}
+
+	// Factor the initial columns and update the rest.
+	if nfxd > 0 {
+		na := min(m, nfxd)
+		geqrf(bl, m, na, a, lda, tau, work, lwork)
+		if na < n {
+			ormqr(bl, blas.SideL, blas.TransC, m, n-na, na, a, lda, tau, a[na*lda:], lda, work, lwork)
+		}
+	}
+	if nfxd >= minmn {
+		return
+	}
+
+	// Factor the free columns.
+	sm, sn, sminmn := m-nfxd, n-nfxd, minmn-nfxd
+	nb, nx := qrBlock, 0
+	if nb > 1 && nb < sminmn {
+		nx = qrCrossover
+		if nx < sminmn && lwork < (sn+1)*nb {
+			nb = lwork / (sn + 1)
+		}
+	}
+	for j := nfxd; j < n; j++ {
+		vn1[j] = R(nrm2(bl, sm, a[nfxd+j*lda:], 1))
+		vn2[j] = vn1[j]
+	}
+	j := nfxd
+	if nb >= 2 && nb < sminmn && nx < sminmn {
+		// Use blocked code while more than nx columns remain.
+		for topbmn := minmn - nx; j < topbmn; {
+			jb := min(nb, topbmn-j)
+			j += laqps(bl, m, n-j, j, jb, a[j*lda:], lda, jpvt[j:], tau[j:], vn1[j:], vn2[j:], work[:jb], work[jb:], n-j)
+		}
+	}
+	if j < minmn {
+		laqp2(bl, m, n-j, j, a[j*lda:], lda, jpvt[j:], tau[j:], vn1[j:], vn2[j:], work)
+	}
+}
+
+// laqp2 computes the QR factorization with column pivoting of the rows
+// offset:m of the m×n matrix A one column at a time, after applying each
+// reflector to the rows 0:offset. vn1 and vn2 hold the partial and exact
+// norms of the columns below row offset, and are updated; a partial norm is
+// recomputed when downdating has lost too much of its accuracy, as in the
+// LAPACK 3.2 xLAQP2. work must hold n elements.
+func laqp2[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n, offset int, a []T, lda int, jpvt []int, tau []T, vn1, vn2 []R, work []T) {
+	mn := min(m-offset, n)
+	tol3z := math.Sqrt(eps[T]())
+	for i := 0; i < mn; i++ {
+		offpi := offset + i
+
+		// Determine the pivot column and swap if necessary.
+		pvt := i + iamax(bl, n-i, vn1[i:], 1)
+		if pvt != i {
+			swap(bl, m, a[pvt*lda:], 1, a[i*lda:], 1)
+			jpvt[pvt], jpvt[i] = jpvt[i], jpvt[pvt]
+			vn1[pvt] = vn1[i]
+			vn2[pvt] = vn2[i]
+		}
+
+		// Generate the reflector H(i) and apply H(i)**H to A(offpi:m,i+1:n)
+		// from the left.
+		ii := offpi + i*lda
+		a[ii], tau[i] = larfg(bl, m-offpi, a[ii], a[min(offpi+1, m-1)+i*lda:], 1)
+		if i < n-1 {
+			aii := a[ii]
+			a[ii] = 1
+			larf(bl, blas.SideL, m-offpi, n-i-1, a[ii:], 1, conj(tau[i]), a[ii+lda:], lda, work)
+			a[ii] = aii
+		}
+
+		// Update the partial column norms.
+		for j := i + 1; j < n; j++ {
+			if vn1[j] == 0 {
+				continue
+			}
+			temp := abs(a[offpi+j*lda]) / float64(vn1[j])
+			temp = max(0, 1-temp*temp)
+			ratio := float64(vn1[j] / vn2[j])
+			if temp*ratio*ratio > tol3z {
+				vn1[j] *= R(math.Sqrt(temp))
+				continue
+			}
+			if offpi < m-1 {
+				vn1[j] = R(nrm2(bl, m-offpi-1, a[offpi+1+j*lda:], 1))
+			} else {
+				vn1[j] = 0
+			}
+			vn2[j] = vn1[j]
+		}
+	}
+}
+
+// laqps computes a step of the blocked QR factorization with column
+// pivoting of geqp3: it factors up to nb columns of the rows offset:m of the
+// m×n matrix A, updating the pivoted columns with a rank-k update of the
+// form A -= V*F**H, and applies the block to the rest of A. It stops early
+// when a partial column norm must be recomputed, and returns the number of
+// columns factored. auxv holds nb elements and F is an n×nb matrix with
+// leading dimension ldf; vn1, vn2 and jpvt are as in laqp2.
+func laqps[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n, offset, nb int, a []T, lda int, jpvt []int, tau []T, vn1, vn2 []R, auxv, f []T, ldf int) (kb int) {
+	lastrk := min(m, n+offset) - 1
+	tol3z := math.Sqrt(eps[T]())
+
+	// lsticc is the first of the columns whose norm must be recomputed,
+	// which are linked through vn2, or -1.
+	lsticc := -1
+	k := 0
+	for ; k < nb && lsticc < 0; k++ {
+		rk := offset + k
+
+		// Determine the pivot column and swap if necessary.
+		pvt := k + iamax(bl, n-k, vn1[k:], 1)
+		if pvt != k {
+			swap(bl, m, a[pvt*lda:], 1, a[k*lda:], 1)
+			swap(bl, k, f[pvt:], ldf, f[k:], ldf)
+			jpvt[pvt], jpvt[k] = jpvt[k], jpvt[pvt]
+			vn1[pvt] = vn1[k]
+			vn2[pvt] = vn2[k]
+		}
+
+		// Apply the previous reflectors to column k:
+		// A(rk:m,k) -= A(rk:m,0:k) * F(k,0:k)**H.
+		if k > 0 {
+			lacgv(k, f[k:], ldf)
+			gemv(bl, blas.TransN, m-rk, k, -1, a[rk:], lda, f[k:], ldf, 1, a[rk+k*lda:], 1)
+			lacgv(k, f[k:], ldf)
+		}
+
+		// Generate the reflector H(k).
+		kk := rk + k*lda
+		a[kk], tau[k] = larfg(bl, m-rk, a[kk], a[min(rk+1, m-1)+k*lda:], 1)
+		akk := a[kk]
+		a[kk] = 1
+
+		// Compute column k of F:
+		// F(k+1:n,k) = tau(k) * A(rk:m,k+1:n)**H * A(rk:m,k).
+		if k < n-1 {
+			gemv(bl, blas.TransC, m-rk, n-k-1, tau[k], a[kk+lda:], lda, a[kk:], 1, 0, f[k+1+k*ldf:], 1)
+		}
+		for j := 0; j <= k; j++ {
+			f[j+k*ldf] = 0
+		}
+
+		// Incremental updating of F:
+		// F(0:n,k) -= tau(k) * F(0:n,0:k) * A(rk:m,0:k)**H * A(rk:m,k).
+		if k > 0 {
+			gemv(bl, blas.TransC, m-rk, k, -tau[k], a[rk:], lda, a[kk:], 1, 0, auxv, 1)
+			gemv(bl, blas.TransN, n, k, 1, f, ldf, auxv, 1, 1, f[k*ldf:], 1)
+		}
+
+		// Update the current row of A:
+		// A(rk,k+1:n) -= A(rk,0:k+1) * F(k+1:n,0:k+1)**H.
+		if k < n-1 {
+			gemm(bl, blas.TransN, blas.TransC, 1, n-k-1, k+1, -1, a[rk:], lda, f[k+1:], ldf, 1, a[kk+lda:], lda)
+		}
+
+		// Update the partial column norms.
+		if rk < lastrk {
+			for j := k + 1; j < n; j++ {
+				if vn1[j] == 0 {
+					continue
+				}
+				temp := abs(a[rk+j*lda]) / float64(vn1[j])
+				temp = max(0, (1+temp)*(1-temp))
+				ratio := float64(vn1[j] / vn2[j])
+				if temp*ratio*ratio > tol3z {
+					vn1[j] *= R(math.Sqrt(temp))
+					continue
+				}
+				vn2[j] = R(lsticc)
+				lsticc = j
+			}
+		}
+		a[kk] = akk
+	}
+	kb = k
+	rk := offset + kb
+
+	// Apply the block reflector to the rest of the matrix:
+	// A(rk:m,kb:n) -= A(rk:m,0:kb) * F(kb:n,0:kb)**H.
+	if kb < min(n, m-offset) {
+		gemm(bl, blas.TransN, blas.TransC, m-rk, n-kb, kb, -1, a[rk:], lda, f[kb:], ldf, 1, a[rk+kb*lda:], lda)
+	}
+
+	// Recompute the column norms that lost their accuracy.
+	for lsticc >= 0 {
+		next := int(vn2[lsticc])
+		vn1[lsticc] = R(nrm2(bl, m-rk, a[rk+lsticc*lda:], 1))
+		vn2[lsticc] = vn1[lsticc]
+		lsticc = next
+	}
+	return kb
+}
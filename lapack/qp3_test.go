@@ -0,0 +1,215 @@
+package lapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// qp3Routines holds xGEQP3 of one precision, with its complex rwork
+// allocated by the test, and the xORGQR that generates its Q.
+type qp3Routines[T gen.Scalar] struct {
+	geqp3 func(m, n int, a []T, lda int, jpvt []int, tau, work []T, lwork int) error
+	orgqr func(m, n, k int, a []T, lda int, tau, work []T, lwork int) error
+	geqrf func(m, n int, a []T, lda int, tau, work []T, lwork int) error
+}
+
+func TestGEQP3(t *testing.T) {
+	var impl Implementation
+	testGEQP3(t, "S", qp3Routines[float32]{impl.SGEQP3, impl.SORGQR, impl.SGEQRF})
+	testGEQP3(t, "D", qp3Routines[float64]{impl.DGEQP3, impl.DORGQR, impl.DGEQRF})
+	testGEQP3(t, "C", qp3Routines[complex64]{func(m, n int, a []complex64, lda int, jpvt []int, tau, work []complex64, lwork int) error {
+		return impl.CGEQP3(m, n, a, lda, jpvt, tau, work, lwork, make([]float32, 2*n))
+	}, impl.CUNGQR, impl.CGEQRF})
+	testGEQP3(t, "Z", qp3Routines[complex128]{func(m, n int, a []complex128, lda int, jpvt []int, tau, work []complex128, lwork int) error {
+		return impl.ZGEQP3(m, n, a, lda, jpvt, tau, work, lwork, make([]float64, 2*n))
+	}, impl.ZUNGQR, impl.ZGEQRF})
+}
+
+func testGEQP3[T gen.Scalar](t *testing.T, prec string, f qp3Routines[T]) {
+	rnd := rand.New(rand.NewSource(1))
+	// As in LAPACK, an empty A needs a workspace of one element only.
+	minWork := func(m, n int) int {
+		if min(m, n) == 0 {
+			return 1
+		}
+		if isComplex[T]() {
+			return n + 1
+		}
+		return 3*n + 1
+	}
+	free := func(n int) []int {
+		jpvt := make([]int, n)
+		for j := range jpvt {
+			jpvt[j] = -1
+		}
+		return jpvt
+	}
+
+	// With every column free, and with the shapes with min(m,n) above
+	// qrCrossover running the blocked code when the workspace allows it.
+	for _, sh := range [][2]int{{0, 0}, {0, 3}, {3, 0}, {1, 1}, {6, 4}, {4, 6}, {40, 40}, {200, 150}, {150, 200}} {
+		m, n := sh[0], sh[1]
+		for _, v := range workVariants[1:] {
+			name := fmt.Sprintf("%sGEQP3 %s m=%d n=%d", prec, v, m, n)
+			lda := m + 3
+			a := randMat[T](rnd, m, n, lda)
+			a0 := slices.Clone(a)
+			jpvt := free(n)
+			tau := make([]T, min(m, n))
+			err := withWork(v, minWork(m, n), nil, func(work []T, lwork int) error {
+				return f.geqp3(m, n, a, lda, jpvt, tau, work, lwork)
+			})
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if !samePad(m, n, lda, a, a0) {
+				t.Errorf("%s: elements outside A modified", name)
+			}
+			checkQP3(t, name, f, m, n, a0, a, lda, jpvt, tau, 0)
+		}
+	}
+
+	// jpvt[j] = -1 marks a free column and jpvt[j] >= 0 a column that is
+	// moved to the front, in its order, and factored first, whatever the
+	// value. A jpvt of zeros, as make returns it, fixes every column, and
+	// the factorization is that of xGEQRF.
+	for _, sh := range [][2]int{{8, 6}, {6, 8}, {200, 150}} {
+		m, n := sh[0], sh[1]
+		lda := m + 2
+		fixed := []int{1, 4, n - 1}
+		jpvt := free(n)
+		for i, j := range fixed {
+			jpvt[j] = 5 * i
+		}
+		name := fmt.Sprintf("%sGEQP3 fixed columns %v m=%d n=%d", prec, fixed, m, n)
+		a := randMat[T](rnd, m, n, lda)
+		a0 := slices.Clone(a)
+		tau := make([]T, min(m, n))
+		if err := withWork("optimal lwork", minWork(m, n), nil, func(work []T, lwork int) error {
+			return f.geqp3(m, n, a, lda, jpvt, tau, work, lwork)
+		}); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if !slices.Equal(jpvt[:len(fixed)], fixed) {
+			t.Errorf("%s: jpvt = %v, want the fixed columns first", name, jpvt)
+		}
+		checkQP3(t, name, f, m, n, a0, a, lda, jpvt, tau, len(fixed))
+
+		name = fmt.Sprintf("%sGEQP3 zero jpvt m=%d n=%d", prec, m, n)
+		a = slices.Clone(a0)
+		jpvt = make([]int, n)
+		if err := withWork("optimal lwork", minWork(m, n), nil, func(work []T, lwork int) error {
+			return f.geqp3(m, n, a, lda, jpvt, tau, work, lwork)
+		}); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		for j, p := range jpvt {
+			if p != j {
+				t.Errorf("%s: jpvt = %v, want the identity", name, jpvt)
+				break
+			}
+		}
+		qr := slices.Clone(a0)
+		if err := withWork("optimal lwork", n, nil, func(work []T, lwork int) error {
+			return f.geqrf(m, n, qr, lda, make([]T, min(m, n)), work, lwork)
+		}); err != nil {
+			t.Fatalf("%sGEQRF m=%d n=%d: unexpected error %v", prec, m, n, err)
+		}
+		if r := ratio[T](diffF(m, n, a, lda, qr, lda), normF(m, n, a0, lda), max(m, n)); r > maxRatio {
+			t.Errorf("%s: ratio %.3g to the factorization of %sGEQRF", name, r, prec)
+		}
+	}
+
+	// The diagonal of R reveals the rank of a matrix of rank r.
+	const m, n, r = 30, 20, 7
+	b, c := randMat[T](rnd, m, r, m), randMat[T](rnd, r, n, r)
+	a := mulMat(blas.TransN, blas.TransN, m, n, r, b, m, c, r)
+	a0 := slices.Clone(a)
+	jpvt, tau := free(n), make([]T, n)
+	name := fmt.Sprintf("%sGEQP3 rank %d m=%d n=%d", prec, r, m, n)
+	if err := withWork("optimal lwork", minWork(m, n), nil, func(work []T, lwork int) error {
+		return f.geqp3(m, n, a, m, jpvt, tau, work, lwork)
+	}); err != nil {
+		t.Fatalf("%s: unexpected error %v", name, err)
+	}
+	checkQP3(t, name, f, m, n, a0, a, m, jpvt, tau, 0)
+	norm := normF(m, n, a0, m)
+	if d := abs(a[r-1+(r-1)*m]); d < 1e-3*norm {
+		t.Errorf("%s: |R[%d,%d]| = %.3g, want of the order of ‖A‖ = %.3g", name, r-1, r-1, d, norm)
+	}
+	if d := abs(a[r+r*m]); d > maxRatio*float64(m)*eps[T]()*norm {
+		t.Errorf("%s: |R[%d,%d]| = %.3g, want of the order of eps*‖A‖", name, r, r, d)
+	}
+}
+
+// checkQP3 checks the factorization A*P = Q*R of the m×n matrix a0 left in
+// a, jpvt and tau by xGEQP3: jpvt is a permutation, Q is orthogonal, A*P is
+// Q*R, and from the column nfxd on, after the fixed columns, every diagonal
+// element of R has a magnitude of at least the norm of the rest of any
+// later column of R, so the magnitudes on the diagonal are non-increasing.
+func checkQP3[T gen.Scalar](t *testing.T, name string, f qp3Routines[T], m, n int, a0, a []T, lda int, jpvt []int, tau []T, nfxd int) {
+	t.Helper()
+	if p := slices.Sorted(slices.Values(jpvt)); !slices.Equal(p, identity(n)) {
+		t.Errorf("%s: jpvt = %v is not a permutation", name, jpvt)
+		return
+	}
+	k := min(m, n)
+	ldq := m + 1
+	q := make([]T, ldq*m)
+	for j := 0; j < k; j++ {
+		copy(q[j*ldq:j*ldq+m], a[j*lda:j*lda+m])
+	}
+	if err := withWork("optimal lwork", m, nil, func(work []T, lwork int) error {
+		return f.orgqr(m, m, k, q, ldq, tau, work, lwork)
+	}); err != nil {
+		t.Errorf("%s: generating Q: unexpected error %v", name, err)
+		return
+	}
+	if r := orthRatio(m, m, q, ldq); r > maxRatio {
+		t.Errorf("%s: ‖Qᴴ*Q - I‖ ratio %.3g", name, r)
+	}
+	rf, ap := make([]T, m*n), make([]T, m*n)
+	for j := 0; j < n; j++ {
+		copy(ap[j*m:(j+1)*m], a0[jpvt[j]*lda:jpvt[j]*lda+m])
+		for i := 0; i <= min(j, m-1); i++ {
+			rf[i+j*m] = a[i+j*lda]
+		}
+	}
+	qr := mulMat(blas.TransN, blas.TransN, m, n, m, q, ldq, rf, m)
+	if r := ratio[T](diffF(m, n, ap, m, qr, m), normF(m, n, a0, lda), max(m, n)); r > maxRatio {
+		t.Errorf("%s: ‖A*P - Q*R‖ ratio %.3g", name, r)
+	}
+
+	// The norms of the columns are downdated as the factorization goes,
+	// and are recomputed only when they lose more than half their digits,
+	// so the pivot can miss the largest column by that much.
+	slack := 1 + math.Sqrt(eps[T]())
+	norm := normF(m, n, a0, lda)
+	for i := nfxd; i < k; i++ {
+		d := abs(a[i+i*lda])
+		for j := i + 1; j < n; j++ {
+			if c := normF(min(j, m-1)-i+1, 1, a[i+j*lda:], lda); c > slack*d+maxRatio*float64(m)*eps[T]()*norm {
+				t.Errorf("%s: |R[%d,%d]| = %.6g less than the norm %.6g of column %d below row %d", name, i, i, d, c, j, i)
+				return
+			}
+		}
+	}
+}
+
+// identity returns the identity permutation of n elements.
+func identity(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
@@ -0,0 +1,693 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGEQR2 computes the QR factorization A = Q*R of an m×n matrix one
+// column at a time. R is left in the upper triangle of A and Q is
+// represented as the product Q = H(0)*H(1)*...*H(k-1), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// below the diagonal of A with their unit first element implied. work must
+// hold n elements.
+func (impl Implementation) SGEQR2(m, n int, a []float32, lda int, tau, work []float32) error {
+	if err := checkGeqrf("SGEQR2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geqr2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// SGEQRF computes the QR factorization of SGEQR2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector
+// H = I - V*T*V**H. work holds lwork >= max(1,n) elements; the optimal lwork,
+// returned in work[0] by a call with lwork = -1 that does nothing else,
+// allows the full block size.
+func (impl Implementation) SGEQRF(m, n int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkGeqrf("SGEQRF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqrf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORG2R overwrites the m×n matrix A, whose first k columns hold the
+// reflectors of a QR factorization as left by SGEQRF, with the first n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) SORG2R(m, n, k int, a []float32, lda int, tau, work []float32) error {
+	if err := checkOrgqr("SORG2R", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2r(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// SORGQR generates the Q of SORG2R with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGQR(m, n, k int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrgqr("SORGQR", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgqr(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORM2R overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// SGEQRF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) SORM2R(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32) error {
+	if err := checkOrmqr("SORM2R", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, false); err != nil {
+		return err
+	}
+	orm2r(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// SORMQR computes the product of SORM2R with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) SORMQR(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmqr("SORMQR", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, false); err != nil {
+		return err
+	}
+	ormqr(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DGEQR2 computes the QR factorization A = Q*R of an m×n matrix one
+// column at a time. R is left in the upper triangle of A and Q is
+// represented as the product Q = H(0)*H(1)*...*H(k-1), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// below the diagonal of A with their unit first element implied. work must
+// hold n elements.
+func (impl Implementation) DGEQR2(m, n int, a []float64, lda int, tau, work []float64) error {
+	if err := checkGeqrf("DGEQR2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geqr2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// DGEQRF computes the QR factorization of DGEQR2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector
+// H = I - V*T*V**H. work holds lwork >= max(1,n) elements; the optimal lwork,
+// returned in work[0] by a call with lwork = -1 that does nothing else,
+// allows the full block size.
+func (impl Implementation) DGEQRF(m, n int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkGeqrf("DGEQRF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqrf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORG2R overwrites the m×n matrix A, whose first k columns hold the
+// reflectors of a QR factorization as left by DGEQRF, with the first n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) DORG2R(m, n, k int, a []float64, lda int, tau, work []float64) error {
+	if err := checkOrgqr("DORG2R", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2r(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// DORGQR generates the Q of DORG2R with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGQR(m, n, k int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrgqr("DORGQR", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgqr(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORM2R overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// DGEQRF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) DORM2R(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64) error {
+	if err := checkOrmqr("DORM2R", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, false); err != nil {
+		return err
+	}
+	orm2r(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// DORMQR computes the product of DORM2R with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) DORMQR(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmqr("DORMQR", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, false); err != nil {
+		return err
+	}
+	ormqr(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CGEQR2 computes the QR factorization A = Q*R of an m×n matrix one
+// column at a time. R is left in the upper triangle of A and Q is
+// represented as the product Q = H(0)*H(1)*...*H(k-1), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// below the diagonal of A with their unit first element implied. work must
+// hold n elements.
+func (impl Implementation) CGEQR2(m, n int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkGeqrf("CGEQR2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geqr2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// CGEQRF computes the QR factorization of CGEQR2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector
+// H = I - V*T*V**H. work holds lwork >= max(1,n) elements; the optimal lwork,
+// returned in work[0] by a call with lwork = -1 that does nothing else,
+// allows the full block size.
+func (impl Implementation) CGEQRF(m, n int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkGeqrf("CGEQRF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqrf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNG2R overwrites the m×n matrix A, whose first k columns hold the
+// reflectors of a QR factorization as left by CGEQRF, with the first n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) CUNG2R(m, n, k int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkOrgqr("CUNG2R", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2r(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// CUNGQR generates the Q of CUNG2R with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGQR(m, n, k int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrgqr("CUNGQR", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgqr(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNM2R overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// CGEQRF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) CUNM2R(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64) error {
+	if err := checkOrmqr("CUNM2R", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, false); err != nil {
+		return err
+	}
+	orm2r(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// CUNMQR computes the product of CUNM2R with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CUNMQR(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmqr("CUNMQR", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, false); err != nil {
+		return err
+	}
+	ormqr(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZGEQR2 computes the QR factorization A = Q*R of an m×n matrix one
+// column at a time. R is left in the upper triangle of A and Q is
+// represented as the product Q = H(0)*H(1)*...*H(k-1), k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// below the diagonal of A with their unit first element implied. work must
+// hold n elements.
+func (impl Implementation) ZGEQR2(m, n int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkGeqrf("ZGEQR2", m, n, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	geqr2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// ZGEQRF computes the QR factorization of ZGEQR2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector
+// H = I - V*T*V**H. work holds lwork >= max(1,n) elements; the optimal lwork,
+// returned in work[0] by a call with lwork = -1 that does nothing else,
+// allows the full block size.
+func (impl Implementation) ZGEQRF(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkGeqrf("ZGEQRF", m, n, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	geqrf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNG2R overwrites the m×n matrix A, whose first k columns hold the
+// reflectors of a QR factorization as left by ZGEQRF, with the first n
+// columns of Q, one reflector at a time. n must not exceed m and k must not
+// exceed n. work must hold n elements.
+func (impl Implementation) ZUNG2R(m, n, k int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkOrgqr("ZUNG2R", m, n, k, len(a), lda, len(tau), len(work), noLwork, false); err != nil {
+		return err
+	}
+	org2r(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// ZUNGQR generates the Q of ZUNG2R with a blocked algorithm. work
+// holds lwork >= max(1,n) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGQR(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrgqr("ZUNGQR", m, n, k, len(a), lda, len(tau), len(work), lwork, false); err != nil {
+		return err
+	}
+	orgqr(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNM2R overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// ZGEQRF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) ZUNM2R(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128) error {
+	if err := checkOrmqr("ZUNM2R", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, false); err != nil {
+		return err
+	}
+	orm2r(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// ZUNMQR computes the product of ZUNM2R with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZUNMQR(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmqr("ZUNMQR", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, false); err != nil {
+		return err
+	}
+	ormqr(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// qrBlock is the block size of the QR routines, the value of ILAENV for
+// xGEQRF, xORGQR and xORMQR.
+const qrBlock = 32
+
+// qrCrossover is the order below which geqrf and orgqr use the unblocked
+// code, the value of ILAENV(3, ...) for xGEQRF.
+const qrCrossover = 128
+
+// The T matrices of ormqr and of the other blocked routines that apply the Q
+// of a factorization have at most ormBlockMax columns and are stored in the
+// workspace with leading dimension ormBlockMax+1, as in xORMQR.
+const (
+	ormBlockMax = 64
+	ormTSize    = (ormBlockMax + 1) * ormBlockMax
+)
+
+// checkGeqrf checks the QR, QL, LQ and RQ factorization routines. The
+// reflectors of the LQ and RQ factorizations are stored in the rows of A,
+// as rowwise says, and their workspace scales with m rather than n.
+func checkGeqrf(routine string, m, n, lenA, lda, lenTau, lenWork, lwork int, rowwise bool) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, m, "m")
+	nw, expr := n, "max(1,n)"
+	if rowwise {
+		nw, expr = m, "max(1,m)"
+	}
+	if lwork != noLwork {
+		c.lwork(7, lwork, max(1, nw), expr)
+	}
+	if c.ok() {
+		if lwork == noLwork {
+			c.length(6, "work", lenWork, nw)
+		} else {
+			c.work(6, lenWork, lwork)
+		}
+		if lwork != -1 {
+			c.length(3, "a", lenA, matLen(m, n, lda))
+			c.length(5, "tau", lenTau, min(m, n))
+		}
+	}
+	return c.result()
+}
+
+// checkOrgqr checks the routines that generate the Q of a QR, QL, LQ or RQ
+// factorization. Q has orthonormal columns, or orthonormal rows for the LQ
+// and RQ factorizations as rowwise says.
+func checkOrgqr(routine string, m, n, k, lenA, lda, lenTau, lenWork, lwork int, rowwise bool) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	nw := n
+	if rowwise {
+		nw = m
+		if n < m {
+			c.fail(2, "n", "must be >= m")
+		}
+		if k < 0 || k > m {
+			c.fail(3, "k", "must satisfy 0 <= k <= m")
+		}
+	} else {
+		if n < 0 || n > m {
+			c.fail(2, "n", "must satisfy 0 <= n <= m")
+		}
+		if k < 0 || k > n {
+			c.fail(3, "k", "must satisfy 0 <= k <= n")
+		}
+	}
+	c.ld(5, "lda", lda, m, "m")
+	if lwork != noLwork {
+		if rowwise {
+			c.lwork(8, lwork, max(1, m), "max(1,m)")
+		} else {
+			c.lwork(8, lwork, max(1, n), "max(1,n)")
+		}
+	}
+	if c.ok() {
+		if lwork == noLwork {
+			c.length(7, "work", lenWork, nw)
+		} else {
+			c.work(7, lenWork, lwork)
+		}
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(m, n, lda))
+			c.length(6, "tau", lenTau, k)
+		}
+	}
+	return c.result()
+}
+
+// checkOrmqr checks the routines that apply the Q of a QR, QL, LQ or RQ
+// factorization. A holds the reflectors in its k columns, or in its k rows
+// for the LQ and RQ factorizations as rowwise says.
+func checkOrmqr(routine string, side blas.Side, trans blas.Transpose, m, n, k, lenA, lda, lenTau, lenC, ldc, lenWork, lwork int, complex, rowwise bool) error {
+	c := checker{routine: routine}
+	c.side(1, side)
+	c.transQ(2, trans, complex)
+	c.nonNeg(3, "m", m)
+	c.nonNeg(4, "n", n)
+	nq, nw := m, n
+	if side == blas.SideR {
+		nq, nw = n, m
+	}
+	if k < 0 || k > nq {
+		c.fail(5, "k", "must satisfy 0 <= k <= nq")
+	}
+	if rowwise {
+		c.ld(7, "lda", lda, k, "k")
+	} else {
+		c.ld(7, "lda", lda, nq, "nq")
+	}
+	c.ld(10, "ldc", ldc, m, "m")
+	if lwork != noLwork {
+		c.lwork(12, lwork, max(1, nw), "max(1,nw)")
+	}
+	if c.ok() {
+		if lwork == noLwork {
+			c.length(11, "work", lenWork, nw)
+		} else {
+			c.work(11, lenWork, lwork)
+		}
+		if lwork != -1 {
+			if rowwise {
+				c.length(6, "a", lenA, matLen(k, nq, lda))
+			} else {
+				c.length(6, "a", lenA, matLen(nq, k, lda))
+			}
+			c.length(8, "tau", lenTau, k)
+			c.length(9, "c", lenC, matLen(m, n, ldc))
+		}
+	}
+	return c.result()
+}
+
+// geqr2 computes the QR factorization A = Q*R of the m×n matrix A one column
+// at a time. R is left in the upper triangle of A and Q is represented by
+// the reflectors H(i) = I - tau[i]*v*v**H, Q = H(0)*H(1)*...*H(k-1), whose
+// vectors v are stored below the diagonal with their unit first element
+// implied. work must hold n elements.
+func geqr2[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T) {
+	for i := 0; i < min(m, n); i++ {
+		ii := i + i*lda
+		a[ii], tau[i] = larfg(bl, m-i, a[ii], a[min(i+1, m-1)+i*lda:], 1)
+		if i < n-1 {
+			// Apply H(i)**H to A(i:m,i+1:n) from the left.
+			aii := a[ii]
+			a[ii] = 1
+			larf(bl, blas.SideL, m-i, n-i-1, a[ii:], 1, conj(tau[i]), a[ii+lda:], lda, work)
+			a[ii] = aii
+		}
+	}
+}
+
+// geqrfWork returns the optimal workspace length of geqrf, which is not less
+// than the minimum max(1,n) even when A is empty.
+func geqrfWork(m, n int) int {
+	if min(m, n) == 0 {
+		return max(1, n)
+	}
+	return n * qrBlock
+}
+
+// geqrf computes the QR factorization of geqr2 with the blocked algorithm of
+// xGEQRF, which applies the reflectors of each panel to the rest of A as a
+// block reflector. work holds lwork >= n elements, and the block size is
+// reduced to fit. A workspace query, lwork = -1, only sets work[0] to the
+// optimal lwork.
+func geqrf[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(geqrfWork(m, n)))
+		return
+	}
+	k := min(m, n)
+	if k == 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := n
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	i := 0
+	if nb >= 2 && nb < k && nx < k {
+		for ; i < k-nx; i += nb {
+			ib := min(k-i, nb)
+			ii := i + i*lda
+			geqr2(bl, m-i, ib, a[ii:], lda, tau[i:], work)
+			if i+ib < n {
+				// Form the triangular factor of the block reflector
+				// H = H(i)*H(i+1)*...*H(i+ib-1) and apply H**H to
+				// A(i:m,i+ib:n) from the left.
+				larft(bl, DirectF, StoreVC, m-i, ib, a[ii:], lda, tau[i:], work, ldwork)
+				larfb(bl, blas.SideL, blas.TransC, DirectF, StoreVC, m-i, n-i-ib, ib, a[ii:], lda, work, ldwork, a[ii+ib*lda:], lda, work[ib:], ldwork)
+			}
+		}
+	}
+	if i < k {
+		geqr2(bl, m-i, n-i, a[i+i*lda:], lda, tau[i:], work)
+	}
+}
+
+// org2r overwrites the m×n matrix A, which holds the k reflectors of a QR
+// factorization in its first k columns as left by geqrf, with the first n
+// columns of Q = H(0)*H(1)*...*H(k-1). work must hold n elements.
+func org2r[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T) {
+	if n <= 0 {
+		return
+	}
+	// Columns k:n are columns of the identity.
+	for j := k; j < n; j++ {
+		col := a[j*lda : j*lda+m]
+		for i := range col {
+			col[i] = 0
+		}
+		col[j] = 1
+	}
+	for i := k - 1; i >= 0; i-- {
+		ii := i + i*lda
+		// Apply H(i) to A(i:m,i+1:n) from the left.
+		if i < n-1 {
+			a[ii] = 1
+			larf(bl, blas.SideL, m-i, n-i-1, a[ii:], 1, tau[i], a[ii+lda:], lda, work)
+		}
+		if i < m-1 {
+			scal(bl, m-i-1, -tau[i], a[ii+1:], 1)
+		}
+		a[ii] = 1 - tau[i]
+		for l := 0; l < i; l++ {
+			a[l+i*lda] = 0
+		}
+	}
+}
+
+// orgqrWork returns the optimal workspace length of orgqr.
+func orgqrWork(n int) int {
+	return max(1, n) * qrBlock
+}
+
+// orgqr generates the Q of org2r with the blocked algorithm of xORGQR. work
+// holds lwork >= n elements, and the block size is reduced to fit. A
+// workspace query, lwork = -1, only sets work[0] to the optimal lwork.
+func orgqr[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(orgqrWork(n)))
+		return
+	}
+	if n <= 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := n
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	ki, kk := 0, 0
+	if nb >= 2 && nb < k && nx < k {
+		// The last kk columns are handled by the unblocked code and the
+		// first ones by blocks of nb, starting at ki.
+		ki = ((k - nx - 1) / nb) * nb
+		kk = min(k, ki+nb)
+		for j := kk; j < n; j++ {
+			for i := 0; i < kk; i++ {
+				a[i+j*lda] = 0
+			}
+		}
+	}
+	if kk < n {
+		org2r(bl, m-kk, n-kk, k-kk, a[kk+kk*lda:], lda, tau[kk:], work)
+	}
+	if kk == 0 {
+		return
+	}
+	for i := ki; i >= 0; i -= nb {
+		ib := min(nb, k-i)
+		ii := i + i*lda
+		if i+ib < n {
+			// Form the triangular factor of the block reflector
+			// H = H(i)*H(i+1)*...*H(i+ib-1) and apply H to A(i:m,i+ib:n)
+			// from the left.
+			larft(bl, DirectF, StoreVC, m-i, ib, a[ii:], lda, tau[i:], work, ldwork)
+			larfb(bl, blas.SideL, blas.TransN, DirectF, StoreVC, m-i, n-i-ib, ib, a[ii:], lda, work, ldwork, a[ii+ib*lda:], lda, work[ib:], ldwork)
+		}
+		// Apply H to the rows i:m of the block itself and zero its rows
+		// 0:i.
+		org2r(bl, m-i, ib, ib, a[ii:], lda, tau[i:], work)
+		for j := i; j < i+ib; j++ {
+			for l := 0; l < i; l++ {
+				a[l+j*lda] = 0
+			}
+		}
+	}
+}
+
+// orm2r overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where op(Q) is Q or Q**H and Q = H(0)*H(1)*...*H(k-1) is given
+// by the reflectors left in A and tau by geqrf. work must hold n elements
+// for side L and m for side R.
+func orm2r[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T) {
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	mi, ni, ic, jc := m, n, 0, 0
+	for j := 0; j < k; j++ {
+		// H(0) is applied last for Q*C and C*Q**H, and first otherwise.
+		i := j
+		if left == notran {
+			i = k - 1 - j
+		}
+		if left {
+			mi, ic = m-i, i
+		} else {
+			ni, jc = n-i, i
+		}
+		taui := tau[i]
+		if !notran {
+			taui = conj(taui)
+		}
+		ii := i + i*lda
+		aii := a[ii]
+		a[ii] = 1
+		larf(bl, side, mi, ni, a[ii:], 1, taui, c[ic+jc*ldc:], ldc, work)
+		a[ii] = aii
+	}
+}
+
+// ormWork returns the optimal workspace length of ormqr and of the other
+// blocked routines that apply the Q of a factorization.
+func ormWork(side blas.Side, m, n int) int {
+	nw := n
+	if side == blas.SideR {
+		nw = m
+	}
+	return max(1, nw)*min(ormBlockMax, qrBlock) + ormTSize
+}
+
+// ormqr computes the product of orm2r with the blocked algorithm of xORMQR,
+// which applies the reflectors as block reflectors. work holds lwork >= nw
+// elements, where nw is n for side L and m for side R, and the block size is
+// reduced to fit. A workspace query, lwork = -1, only sets work[0] to the
+// optimal lwork.
+func ormqr[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq, nw := m, n
+	if !left {
+		nq, nw = n, m
+	}
+	nb := min(ormBlockMax, qrBlock)
+	if nb > 1 && nb < k && lwork < ormWork(side, m, n) {
+		nb = (lwork - ormTSize) / nw
+	}
+	if nb < 2 || nb >= k {
+		orm2r(bl, side, trans, m, n, k, a, lda, tau, c, ldc, work)
+		return
+	}
+	t := work[nw*nb:]
+	mi, ni, ic, jc := m, n, 0, 0
+	last := ((k - 1) / nb) * nb
+	for j := 0; j <= last; j += nb {
+		i := j
+		if left == notran {
+			i = last - j
+		}
+		ib := min(nb, k-i)
+		ii := i + i*lda
+		// Form the triangular factor of the block reflector
+		// H = H(i)*H(i+1)*...*H(i+ib-1) and apply H or H**H to C(i:m,0:n)
+		// or C(0:m,i:n).
+		larft(bl, DirectF, StoreVC, nq-i, ib, a[ii:], lda, tau[i:], t, ormBlockMax+1)
+		if left {
+			mi, ic = m-i, i
+		} else {
+			ni, jc = n-i, i
+		}
+		larfb(bl, side, trans, DirectF, StoreVC, mi, ni, ib, a[ii:], lda, t, ormBlockMax+1, c[ic+jc*ldc:], ldc, work, nw)
+	}
+}
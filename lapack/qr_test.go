@@ -0,0 +1,253 @@
+package lapack
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// orthRoutines holds the routines of one precision for one of the QR, QL,
+// LQ and RQ factorizations: the factorization, the generation of Q and the
+// application of Q, each unblocked and blocked.
+type orthRoutines[T gen.Scalar] struct {
+	fact2 func(m, n int, a []T, lda int, tau, work []T) error
+	fact  func(m, n int, a []T, lda int, tau, work []T, lwork int) error
+	gen2  func(m, n, k int, a []T, lda int, tau, work []T) error
+	gen   func(m, n, k int, a []T, lda int, tau, work []T, lwork int) error
+	mul2  func(side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T) error
+	mul   func(side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) error
+}
+
+// orthKind describes the layout of the factors of one of the QR, QL, LQ and
+// RQ factorizations.
+type orthKind struct {
+	left     bool      // A = Q*R or A = Q*L rather than A = L*Q or A = R*Q
+	last     bool      // the reflectors are in the last columns or rows of A
+	routines [6]string // the real routines, in the order of orthRoutines
+}
+
+var qrKind = orthKind{true, false, [6]string{"GEQR2", "GEQRF", "ORG2R", "ORGQR", "ORM2R", "ORMQR"}}
+
+// routine returns the name of the routine of orthRoutines at index i, the
+// unblocked one or the blocked one, in precision prec.
+func (k orthKind) routine(prec string, i int, blocked bool) string {
+	if blocked {
+		i++
+	}
+	name := k.routines[i]
+	if i >= 2 && (prec == "C" || prec == "Z") {
+		name = "UN" + name[2:]
+	}
+	return prec + name
+}
+
+// inFactor reports whether element (i,j) of an m×n matrix A lies in the
+// triangular factor that the factorization leaves in A.
+func (k orthKind) inFactor(m, n, i, j int) bool {
+	lower := k.left == k.last
+	off := 0
+	switch {
+	case k.last && lower:
+		off = m - n
+	case k.last:
+		off = n - m
+	}
+	if lower {
+		return i-j >= off
+	}
+	return j-i >= off
+}
+
+// reflectors returns the offset in the m×n matrix A, with leading dimension
+// lda, of its nref columns or rows that hold the reflectors, as the
+// routines that generate and apply Q take them.
+func (k orthKind) reflectors(m, n, nref, lda int) int {
+	switch {
+	case !k.last || nref == 0:
+		return 0
+	case k.left:
+		return (n - nref) * lda
+	}
+	return m - nref
+}
+
+// copyReflectors copies the nref reflectors of the factorization of the m×n
+// matrix A to the dm×dn matrix D, in which the routines that generate Q
+// expect them.
+func copyReflectors[T gen.Scalar](k orthKind, m, n, nref int, a []T, lda int, dm, dn int, d []T, ldd int) {
+	a, d = a[k.reflectors(m, n, nref, lda):], d[k.reflectors(dm, dn, nref, ldd):]
+	for j := 0; j < nref; j++ {
+		for i := 0; i < m && k.left; i++ {
+			d[i+j*ldd] = a[i+j*lda]
+		}
+		for l := 0; l < n && !k.left; l++ {
+			d[j+l*ldd] = a[j+l*lda]
+		}
+	}
+}
+
+// withWork calls a routine that takes a workspace of at least nw elements:
+// the unblocked f2 for the variant "unblocked", and the blocked f with a
+// workspace of the minimum length for "minimal lwork" or of the optimal
+// length it returns to a workspace query for "optimal lwork".
+func withWork[T gen.Scalar](variant string, nw int, f2 func(work []T) error, f func(work []T, lwork int) error) error {
+	switch variant {
+	case "unblocked":
+		return f2(make([]T, nw))
+	case "minimal lwork":
+		return f(make([]T, max(1, nw)), max(1, nw))
+	}
+	query := make([]T, 1)
+	if err := f(query, -1); err != nil {
+		return err
+	}
+	lwork := int(re(query[0]))
+	if lwork < max(1, nw) {
+		return fmt.Errorf("optimal lwork %d is less than the minimum %d", lwork, max(1, nw))
+	}
+	return f(make([]T, lwork), lwork)
+}
+
+var workVariants = []string{"unblocked", "minimal lwork", "optimal lwork"}
+
+func TestQR(t *testing.T) {
+	var impl Implementation
+	testOrth(t, "S", qrKind, orthRoutines[float32]{impl.SGEQR2, impl.SGEQRF, impl.SORG2R, impl.SORGQR, impl.SORM2R, impl.SORMQR})
+	testOrth(t, "D", qrKind, orthRoutines[float64]{impl.DGEQR2, impl.DGEQRF, impl.DORG2R, impl.DORGQR, impl.DORM2R, impl.DORMQR})
+	testOrth(t, "C", qrKind, orthRoutines[complex64]{impl.CGEQR2, impl.CGEQRF, impl.CUNG2R, impl.CUNGQR, impl.CUNM2R, impl.CUNMQR})
+	testOrth(t, "Z", qrKind, orthRoutines[complex128]{impl.ZGEQR2, impl.ZGEQRF, impl.ZUNG2R, impl.ZUNGQR, impl.ZUNM2R, impl.ZUNMQR})
+}
+
+// testOrth checks a factorization by its reconstruction from the triangular
+// factor and the full Q, the orthonormality of Q and of the economy Q of
+// its first or last min(m,n) columns or rows, and the application of Q and
+// of its conjugate transpose from both sides against the explicit product.
+// The shapes with min(m,n) above qrCrossover run the blocked code, when the
+// workspace allows it.
+func testOrth[T gen.Scalar](t *testing.T, prec string, kind orthKind, f orthRoutines[T]) {
+	rnd := rand.New(rand.NewSource(1))
+	transC := blas.TransT
+	if isComplex[T]() {
+		transC = blas.TransC
+	}
+	for _, sh := range [][2]int{{0, 0}, {0, 4}, {4, 0}, {1, 1}, {6, 3}, {3, 6}, {40, 40}, {200, 150}, {150, 200}} {
+		m, n := sh[0], sh[1]
+		k, lda := min(m, n), m+3
+		qn := n
+		if kind.left {
+			qn = m
+		}
+		for _, v := range workVariants {
+			blocked := v != "unblocked"
+			name := fmt.Sprintf("%s %s m=%d n=%d", kind.routine(prec, 0, blocked), v, m, n)
+			a := randMat[T](rnd, m, n, lda)
+			a0 := slices.Clone(a)
+			tau := make([]T, k)
+			nw := m
+			if kind.left {
+				nw = n
+			}
+			err := withWork(v, nw,
+				func(work []T) error { return f.fact2(m, n, a, lda, tau, work) },
+				func(work []T, lwork int) error { return f.fact(m, n, a, lda, tau, work, lwork) })
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if !samePad(m, n, lda, a, a0) {
+				t.Errorf("%s: elements outside A modified", name)
+			}
+
+			// The full Q and the reconstruction of A.
+			gname := fmt.Sprintf("%s %s m=%d n=%d", kind.routine(prec, 2, blocked), v, m, n)
+			ldq := qn + 2
+			q := randMat[T](rnd, qn, qn, ldq)
+			copyReflectors(kind, m, n, k, a, lda, qn, qn, q, ldq)
+			err = withWork(v, qn,
+				func(work []T) error { return f.gen2(qn, qn, k, q, ldq, tau, work) },
+				func(work []T, lwork int) error { return f.gen(qn, qn, k, q, ldq, tau, work, lwork) })
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", gname, err)
+				continue
+			}
+			if r := orthRatio(qn, qn, q, ldq); r > maxRatio {
+				t.Errorf("%s: ‖Qᴴ*Q - I‖ ratio %.3g", gname, r)
+			}
+			fac := make([]T, m*n)
+			for j := 0; j < n; j++ {
+				for i := 0; i < m; i++ {
+					if kind.inFactor(m, n, i, j) {
+						fac[i+j*m] = a[i+j*lda]
+					}
+				}
+			}
+			var prod []T
+			if kind.left {
+				prod = mulMat(blas.TransN, blas.TransN, m, n, m, q, ldq, fac, m)
+			} else {
+				prod = mulMat(blas.TransN, blas.TransN, m, n, n, fac, m, q, ldq)
+			}
+			if r := ratio[T](diffF(m, n, prod, m, a0, lda), normF(m, n, a0, lda), max(m, n)); r > maxRatio {
+				t.Errorf("%s: ‖A - factor product‖ ratio %.3g", name, r)
+			}
+
+			// The economy Q is the part of the full Q that multiplies the
+			// triangular factor.
+			em, en := m, k
+			if !kind.left {
+				em, en = k, n
+			}
+			lde := em + 1
+			e := randMat[T](rnd, em, en, lde)
+			copyReflectors(kind, m, n, k, a, lda, em, en, e, lde)
+			err = withWork(v, min(em, en),
+				func(work []T) error { return f.gen2(em, en, k, e, lde, tau, work) },
+				func(work []T, lwork int) error { return f.gen(em, en, k, e, lde, tau, work, lwork) })
+			if err != nil {
+				t.Errorf("%s economy: unexpected error %v", gname, err)
+				continue
+			}
+			if r := ratio[T](diffF(em, en, e, lde, q[kind.reflectors(qn, qn, k, ldq):], ldq), 1, qn); r > maxRatio {
+				t.Errorf("%s economy: ratio %.3g to the full Q", gname, r)
+			}
+
+			// Q applied to a matrix C from both sides.
+			for _, side := range []blas.Side{blas.SideL, blas.SideR} {
+				for _, trans := range []blas.Transpose{blas.TransN, transC} {
+					mname := fmt.Sprintf("%s %s side=%c trans=%c m=%d n=%d", kind.routine(prec, 4, blocked), v, side, trans, m, n)
+					const p = 5
+					cm, cn, nw := qn, p, p
+					if side == blas.SideR {
+						cm, cn, nw = p, qn, p
+					}
+					ldc := cm + 1
+					c := randMat[T](rnd, cm, cn, ldc)
+					var want []T
+					if side == blas.SideL {
+						want = mulMat(trans, blas.TransN, cm, cn, cm, q, ldq, c, ldc)
+					} else {
+						want = mulMat(blas.TransN, trans, cm, cn, cn, c, ldc, q, ldq)
+					}
+					norm := normF(cm, cn, c, ldc)
+					refl := a[kind.reflectors(m, n, k, lda):]
+					err := withWork(v, nw,
+						func(work []T) error { return f.mul2(side, trans, cm, cn, k, refl, lda, tau, c, ldc, work) },
+						func(work []T, lwork int) error {
+							return f.mul(side, trans, cm, cn, k, refl, lda, tau, c, ldc, work, lwork)
+						})
+					if err != nil {
+						t.Errorf("%s: unexpected error %v", mname, err)
+						continue
+					}
+					if r := ratio[T](diffF(cm, cn, c, ldc, want, cm), norm, qn); r > maxRatio {
+						t.Errorf("%s: ratio %.3g to the explicit product", mname, r)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,552 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SGERQ2 computes the RQ factorization A = R*Q of an m×n matrix one row
+// at a time, from the last. R is left in the upper triangle of the last m
+// columns of A if m <= n, or of its last n rows otherwise. Q is represented
+// as the product Q = H(0)**H*H(1)**H*...*H(k-1)**H, k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row m-k+i left of column n-k+i with their unit last element
+// implied. work must hold m elements.
+func (impl Implementation) SGERQ2(m, n int, a []float32, lda int, tau, work []float32) error {
+	if err := checkGeqrf("SGERQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gerq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// SGERQF computes the RQ factorization of SGERQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SGERQF(m, n int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkGeqrf("SGERQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gerqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORGR2 overwrites the m×n matrix A, whose last k rows hold the
+// reflectors of an RQ factorization as left by SGERQF, with the last m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) SORGR2(m, n, k int, a []float32, lda int, tau, work []float32) error {
+	if err := checkOrgqr("SORGR2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgr2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// SORGRQ generates the Q of SORGR2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGRQ(m, n, k int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrgqr("SORGRQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orgrq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORMR2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// SGERQF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) SORMR2(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32) error {
+	if err := checkOrmqr("SORMR2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, true); err != nil {
+		return err
+	}
+	ormr2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// SORMRQ computes the product of SORMR2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) SORMRQ(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmqr("SORMRQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, true); err != nil {
+		return err
+	}
+	ormrq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DGERQ2 computes the RQ factorization A = R*Q of an m×n matrix one row
+// at a time, from the last. R is left in the upper triangle of the last m
+// columns of A if m <= n, or of its last n rows otherwise. Q is represented
+// as the product Q = H(0)**H*H(1)**H*...*H(k-1)**H, k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row m-k+i left of column n-k+i with their unit last element
+// implied. work must hold m elements.
+func (impl Implementation) DGERQ2(m, n int, a []float64, lda int, tau, work []float64) error {
+	if err := checkGeqrf("DGERQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gerq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// DGERQF computes the RQ factorization of DGERQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DGERQF(m, n int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkGeqrf("DGERQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gerqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORGR2 overwrites the m×n matrix A, whose last k rows hold the
+// reflectors of an RQ factorization as left by DGERQF, with the last m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) DORGR2(m, n, k int, a []float64, lda int, tau, work []float64) error {
+	if err := checkOrgqr("DORGR2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgr2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// DORGRQ generates the Q of DORGR2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGRQ(m, n, k int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrgqr("DORGRQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orgrq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORMR2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// DGERQF and op(Q) is Q (trans N) or Q**T (trans T). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) DORMR2(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64) error {
+	if err := checkOrmqr("DORMR2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false, true); err != nil {
+		return err
+	}
+	ormr2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// DORMRQ computes the product of DORMR2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) DORMRQ(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmqr("DORMRQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false, true); err != nil {
+		return err
+	}
+	ormrq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CGERQ2 computes the RQ factorization A = R*Q of an m×n matrix one row
+// at a time, from the last. R is left in the upper triangle of the last m
+// columns of A if m <= n, or of its last n rows otherwise. Q is represented
+// as the product Q = H(0)**H*H(1)**H*...*H(k-1)**H, k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row m-k+i left of column n-k+i with their unit last element
+// implied. work must hold m elements.
+func (impl Implementation) CGERQ2(m, n int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkGeqrf("CGERQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gerq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// CGERQF computes the RQ factorization of CGERQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CGERQF(m, n int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkGeqrf("CGERQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gerqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNGR2 overwrites the m×n matrix A, whose last k rows hold the
+// reflectors of an RQ factorization as left by CGERQF, with the last m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) CUNGR2(m, n, k int, a []complex64, lda int, tau, work []complex64) error {
+	if err := checkOrgqr("CUNGR2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgr2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// CUNGRQ generates the Q of CUNGR2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGRQ(m, n, k int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrgqr("CUNGRQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orgrq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNMR2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// CGERQF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) CUNMR2(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64) error {
+	if err := checkOrmqr("CUNMR2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, true); err != nil {
+		return err
+	}
+	ormr2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// CUNMRQ computes the product of CUNMR2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CUNMRQ(side blas.Side, trans blas.Transpose, m, n, k int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmqr("CUNMRQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, true); err != nil {
+		return err
+	}
+	ormrq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZGERQ2 computes the RQ factorization A = R*Q of an m×n matrix one row
+// at a time, from the last. R is left in the upper triangle of the last m
+// columns of A if m <= n, or of its last n rows otherwise. Q is represented
+// as the product Q = H(0)**H*H(1)**H*...*H(k-1)**H, k = min(m,n), of
+// elementary reflectors H(i) = I - tau[i]*v*v**H, whose vectors v are stored
+// conjugated in row m-k+i left of column n-k+i with their unit last element
+// implied. work must hold m elements.
+func (impl Implementation) ZGERQ2(m, n int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkGeqrf("ZGERQ2", m, n, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	gerq2(impl.bl(), m, n, a, lda, tau, work)
+	return nil
+}
+
+// ZGERQF computes the RQ factorization of ZGERQ2 with a blocked
+// algorithm that applies the reflectors of each panel as a block reflector.
+// work holds lwork >= max(1,m) elements; the optimal lwork is returned
+// in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZGERQF(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkGeqrf("ZGERQF", m, n, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	gerqf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNGR2 overwrites the m×n matrix A, whose last k rows hold the
+// reflectors of an RQ factorization as left by ZGERQF, with the last m
+// rows of Q, one reflector at a time. m must not exceed n and k must not
+// exceed m. work must hold m elements.
+func (impl Implementation) ZUNGR2(m, n, k int, a []complex128, lda int, tau, work []complex128) error {
+	if err := checkOrgqr("ZUNGR2", m, n, k, len(a), lda, len(tau), len(work), noLwork, true); err != nil {
+		return err
+	}
+	orgr2(impl.bl(), m, n, k, a, lda, tau, work)
+	return nil
+}
+
+// ZUNGRQ generates the Q of ZUNGR2 with a blocked algorithm. work
+// holds lwork >= max(1,m) elements; the optimal lwork is returned in work[0]
+// by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGRQ(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrgqr("ZUNGRQ", m, n, k, len(a), lda, len(tau), len(work), lwork, true); err != nil {
+		return err
+	}
+	orgrq(impl.bl(), m, n, k, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNMR2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where Q is given by the k reflectors left in A and tau by
+// ZGERQF and op(Q) is Q (trans N) or Q**H (trans C). work must hold n
+// elements for side L and m for side R.
+func (impl Implementation) ZUNMR2(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128) error {
+	if err := checkOrmqr("ZUNMR2", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true, true); err != nil {
+		return err
+	}
+	ormr2(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// ZUNMRQ computes the product of ZUNMR2 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZUNMRQ(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmqr("ZUNMRQ", side, trans, m, n, k, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true, true); err != nil {
+		return err
+	}
+	ormrq(impl.bl(), side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// gerq2 computes the RQ factorization A = R*Q of the m×n matrix A one row at
+// a time, from the last. For m <= n, R is left in the upper triangle of the
+// last m columns of A; for m > n, in the upper trapezoid from row m-n on. Q
+// is represented by the reflectors H(i) = I - tau[i]*v*v**H,
+// Q = H(0)**H*H(1)**H*...*H(k-1)**H, whose vectors are stored conjugated in
+// row m-k+i left of column n-k+i with their unit last element implied. work
+// must hold m elements.
+func gerq2[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T) {
+	k := min(m, n)
+	for i := k - 1; i >= 0; i-- {
+		// Generate H(i) to annihilate A(m-k+i,0:n-k+i) and apply it to
+		// A(0:m-k+i,0:n-k+i+1) from the right.
+		mi, ni := m-k+i, n-k+i
+		ii := mi + ni*lda
+		lacgv(ni+1, a[mi:], lda)
+		a[ii], tau[i] = larfg(bl, ni+1, a[ii], a[mi:], lda)
+		aii := a[ii]
+		a[ii] = 1
+		larf(bl, blas.SideR, mi, ni+1, a[mi:], lda, tau[i], a, lda, work)
+		a[ii] = aii
+		lacgv(ni, a[mi:], lda)
+	}
+}
+
+// gerqf computes the RQ factorization of gerq2 with the blocked algorithm of
+// xGERQF. work holds lwork >= m elements, and the block size is reduced to
+// fit. A workspace query, lwork = -1, only sets work[0] to the optimal
+// lwork.
+func gerqf[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T, lwork int) {
+	k := min(m, n)
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, m)))
+		if k > 0 {
+			work[0] = fromReal[T](float64(m * qrBlock))
+		}
+		return
+	}
+	if k == 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := m
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	mu, nu := m, n
+	if nb >= 2 && nb < k && nx < k {
+		// The first kk reflectors, from the last, are computed by blocks of
+		// nb and the rest by the unblocked code.
+		ki := ((k - nx - 1) / nb) * nb
+		kk := min(k, ki+nb)
+		for i := k - kk + ki; i >= k-kk; i -= nb {
+			ib := min(k-i, nb)
+			mi := m - k + i
+			gerq2(bl, ib, n-k+i+ib, a[mi:], lda, tau[i:], work)
+			if mi > 0 {
+				// Form the triangular factor of the block reflector
+				// H = H(i+ib-1)*...*H(i+1)*H(i) and apply it to
+				// A(0:m-k+i,0:n-k+i+ib) from the right.
+				larft(bl, DirectB, StoreVR, n-k+i+ib, ib, a[mi:], lda, tau[i:], work, ldwork)
+				larfb(bl, blas.SideR, blas.TransN, DirectB, StoreVR, mi, n-k+i+ib, ib, a[mi:], lda, work, ldwork, a, lda, work[ib:], ldwork)
+			}
+		}
+		mu, nu = m-kk, n-kk
+	}
+	if mu > 0 && nu > 0 {
+		gerq2(bl, mu, nu, a, lda, tau, work)
+	}
+}
+
+// orgr2 overwrites the m×n matrix A, whose last k rows hold the reflectors
+// of an RQ factorization as left by gerqf, with the last m rows of Q. work
+// must hold m elements.
+func orgr2[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T) {
+	if m <= 0 {
+		return
+	}
+	if k < m {
+		// Rows 0:m-k are rows of the identity.
+		for j := 0; j < n; j++ {
+			for l := 0; l < m-k; l++ {
+				a[l+j*lda] = 0
+			}
+			if j >= n-m && j < n-k {
+				a[m-n+j+j*lda] = 1
+			}
+		}
+	}
+	for i := 0; i < k; i++ {
+		// Apply H(i)**H to A(0:ii+1,0:n-m+ii+1) from the right.
+		ii := m - k + i
+		ni := n - m + ii
+		lacgv(ni, a[ii:], lda)
+		a[ii+ni*lda] = 1
+		larf(bl, blas.SideR, ii, ni+1, a[ii:], lda, conj(tau[i]), a, lda, work)
+		scal(bl, ni, -tau[i], a[ii:], lda)
+		lacgv(ni, a[ii:], lda)
+		a[ii+ni*lda] = 1 - conj(tau[i])
+		for l := ni + 1; l < n; l++ {
+			a[ii+l*lda] = 0
+		}
+	}
+}
+
+// orgrq generates the Q of orgr2 with the blocked algorithm of xORGRQ. work
+// holds lwork >= m elements, and the block size is reduced to fit. A
+// workspace query, lwork = -1, only sets work[0] to the optimal lwork.
+func orgrq[T gen.Scalar](bl blas.BLAS, m, n, k int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, m) * qrBlock))
+		return
+	}
+	if m <= 0 {
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := m
+	if nb > 1 && nb < k {
+		nx = qrCrossover
+		if nx < k && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	kk := 0
+	if nb >= 2 && nb < k && nx < k {
+		// The last kk rows are handled by blocks of nb and the first ones
+		// by the unblocked code.
+		kk = min(k, ((k-nx+nb-1)/nb)*nb)
+		for j := n - kk; j < n; j++ {
+			for i := 0; i < m-kk; i++ {
+				a[i+j*lda] = 0
+			}
+		}
+	}
+	orgr2(bl, m-kk, n-kk, k-kk, a, lda, tau, work)
+	for i := k - kk; i < k; i += nb {
+		ib := min(nb, k-i)
+		ii := m - k + i
+		if ii > 0 {
+			// Form the triangular factor of the block reflector
+			// H = H(i+ib-1)*...*H(i+1)*H(i) and apply H**H to
+			// A(0:m-k+i,0:n-k+i+ib) from the right.
+			larft(bl, DirectB, StoreVR, n-k+i+ib, ib, a[ii:], lda, tau[i:], work, ldwork)
+			larfb(bl, blas.SideR, blas.TransC, DirectB, StoreVR, ii, n-k+i+ib, ib, a[ii:], lda, work, ldwork, a, lda, work[ib:], ldwork)
+		}
+		// Apply H**H to the columns 0:n-k+i+ib of the block itself and
+		// zero its columns to the right.
+		orgr2(bl, ib, n-k+i+ib, ib, a[ii:], lda, tau[i:], work)
+		for l := n - k + i + ib; l < n; l++ {
+			for j := ii; j < ii+ib; j++ {
+				a[j+l*lda] = 0
+			}
+		}
+	}
+}
+
+// ormr2 overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q)
+// (side R), where op(Q) is Q or Q**H and Q is given by the k reflectors left
+// in the rows of A and in tau by gerqf. work must hold n elements for side L
+// and m for side R.
+func ormr2[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T) {
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq := m
+	if !left {
+		nq = n
+	}
+	mi, ni := m, n
+	for j := 0; j < k; j++ {
+		// H(0) is applied last for Q*C and C*Q**H, and first otherwise.
+		i := j
+		if left == notran {
+			i = k - 1 - j
+		}
+		// H(i) is applied to C(0:m-k+i+1,0:n) or C(0:m,0:n-k+i+1).
+		if left {
+			mi = m - k + i + 1
+		} else {
+			ni = n - k + i + 1
+		}
+		taui := tau[i]
+		if notran {
+			taui = conj(taui)
+		}
+		qi := nq - k + i
+		lacgv(qi, a[i:], lda)
+		aii := a[i+qi*lda]
+		a[i+qi*lda] = 1
+		larf(bl, side, mi, ni, a[i:], lda, taui, c, ldc, work)
+		a[i+qi*lda] = aii
+		lacgv(qi, a[i:], lda)
+	}
+}
+
+// ormrq computes the product of ormr2 with the blocked algorithm of xORMRQ.
+// work holds lwork >= nw elements, where nw is n for side L and m for side
+// R, and the block size is reduced to fit. A workspace query, lwork = -1,
+// only sets work[0] to the optimal lwork.
+func ormrq[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nq, nw := m, n
+	if !left {
+		nq, nw = n, m
+	}
+	nb := min(ormBlockMax, qrBlock)
+	if nb > 1 && nb < k && lwork < ormWork(side, m, n) {
+		nb = (lwork - ormTSize) / nw
+	}
+	if nb < 2 || nb >= k {
+		ormr2(bl, side, trans, m, n, k, a, lda, tau, c, ldc, work)
+		return
+	}
+	transt := blas.TransC
+	if !notran {
+		transt = blas.TransN
+	}
+	t := work[nw*nb:]
+	mi, ni := m, n
+	last := ((k - 1) / nb) * nb
+	for j := 0; j <= last; j += nb {
+		i := j
+		if left == notran {
+			i = last - j
+		}
+		ib := min(nb, k-i)
+		// Form the triangular factor of the block reflector
+		// H = H(i+ib-1)*...*H(i+1)*H(i) and apply H or H**H to
+		// C(0:m-k+i+ib,0:n) or C(0:m,0:n-k+i+ib).
+		larft(bl, DirectB, StoreVR, nq-k+i+ib, ib, a[i:], lda, tau[i:], t, ormBlockMax+1)
+		if left {
+			mi = m - k + i + ib
+		} else {
+			ni = n - k + i + ib
+		}
+		larfb(bl, side, transt, DirectB, StoreVR, mi, ni, ib, a[i:], lda, t, ormBlockMax+1, c, ldc, work, nw)
+	}
+}
@@ -0,0 +1,13 @@
+package lapack
+
+import "testing"
+
+var rqKind = orthKind{false, true, [6]string{"GERQ2", "GERQF", "ORGR2", "ORGRQ", "ORMR2", "ORMRQ"}}
+
+func TestRQ(t *testing.T) {
+	var impl Implementation
+	testOrth(t, "S", rqKind, orthRoutines[float32]{impl.SGERQ2, impl.SGERQF, impl.SORGR2, impl.SORGRQ, impl.SORMR2, impl.SORMRQ})
+	testOrth(t, "D", rqKind, orthRoutines[float64]{impl.DGERQ2, impl.DGERQF, impl.DORGR2, impl.DORGRQ, impl.DORMR2, impl.DORMRQ})
+	testOrth(t, "C", rqKind, orthRoutines[complex64]{impl.CGERQ2, impl.CGERQF, impl.CUNGR2, impl.CUNGRQ, impl.CUNMR2, impl.CUNMRQ})
+	testOrth(t, "Z", rqKind, orthRoutines[complex128]{impl.ZGERQ2, impl.ZGERQF, impl.ZUNGR2, impl.ZUNGRQ, impl.ZUNMR2, impl.ZUNMRQ})
+}
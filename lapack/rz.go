@@ -0,0 +1,634 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// STZRZF reduces the m×n upper trapezoidal matrix A, m <= n, to upper
+// triangular form by orthogonal transformations from the right, A = [R 0]*Z,
+// with a blocked algorithm. R is left in the first m columns of A. Z is
+// represented as the product Z = H(0)*H(1)*...*H(m-1) of elementary
+// reflectors H(i) = I - tau[i]*u*u**H, where u is 1 in element i, zero up to
+// column m and holds row i of the last n-m columns of A in its last n-m
+// elements. work holds lwork >= max(1,m) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) STZRZF(m, n int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkTzrzf("STZRZF", m, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	tzrzf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORMR3 overwrites the m×n matrix C with op(Z)*C (side L) or C*op(Z)
+// (side R), where Z is given by the k reflectors left in A and tau by
+// STZRZF, whose vectors are held in the last l columns of A, and op(Z)
+// is Z (trans N) or Z**T (trans T). work must hold n elements for side L
+// and m for side R.
+func (impl Implementation) SORMR3(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float32, lda int, tau, c []float32, ldc int, work []float32) error {
+	if err := checkOrmrz("SORMR3", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false); err != nil {
+		return err
+	}
+	ormr3(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// SORMRZ computes the product of SORMR3 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) SORMRZ(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmrz("SORMRZ", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false); err != nil {
+		return err
+	}
+	ormrz(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DTZRZF reduces the m×n upper trapezoidal matrix A, m <= n, to upper
+// triangular form by orthogonal transformations from the right, A = [R 0]*Z,
+// with a blocked algorithm. R is left in the first m columns of A. Z is
+// represented as the product Z = H(0)*H(1)*...*H(m-1) of elementary
+// reflectors H(i) = I - tau[i]*u*u**H, where u is 1 in element i, zero up to
+// column m and holds row i of the last n-m columns of A in its last n-m
+// elements. work holds lwork >= max(1,m) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DTZRZF(m, n int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkTzrzf("DTZRZF", m, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	tzrzf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORMR3 overwrites the m×n matrix C with op(Z)*C (side L) or C*op(Z)
+// (side R), where Z is given by the k reflectors left in A and tau by
+// DTZRZF, whose vectors are held in the last l columns of A, and op(Z)
+// is Z (trans N) or Z**T (trans T). work must hold n elements for side L
+// and m for side R.
+func (impl Implementation) DORMR3(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float64, lda int, tau, c []float64, ldc int, work []float64) error {
+	if err := checkOrmrz("DORMR3", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, false); err != nil {
+		return err
+	}
+	ormr3(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// DORMRZ computes the product of DORMR3 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) DORMRZ(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmrz("DORMRZ", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false); err != nil {
+		return err
+	}
+	ormrz(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CTZRZF reduces the m×n upper trapezoidal matrix A, m <= n, to upper
+// triangular form by unitary transformations from the right, A = [R 0]*Z,
+// with a blocked algorithm. R is left in the first m columns of A. Z is
+// represented as the product Z = H(0)*H(1)*...*H(m-1) of elementary
+// reflectors H(i) = I - tau[i]*u*u**H, where u is 1 in element i, zero up to
+// column m and holds row i of the last n-m columns of A in its last n-m
+// elements. work holds lwork >= max(1,m) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CTZRZF(m, n int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkTzrzf("CTZRZF", m, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	tzrzf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNMR3 overwrites the m×n matrix C with op(Z)*C (side L) or C*op(Z)
+// (side R), where Z is given by the k reflectors left in A and tau by
+// CTZRZF, whose vectors are held in the last l columns of A, and op(Z)
+// is Z (trans N) or Z**H (trans C). work must hold n elements for side L
+// and m for side R.
+func (impl Implementation) CUNMR3(side blas.Side, trans blas.Transpose, m, n, k, l int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64) error {
+	if err := checkOrmrz("CUNMR3", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true); err != nil {
+		return err
+	}
+	ormr3(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// CUNMRZ computes the product of CUNMR3 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) CUNMRZ(side blas.Side, trans blas.Transpose, m, n, k, l int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmrz("CUNMRZ", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true); err != nil {
+		return err
+	}
+	ormrz(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZTZRZF reduces the m×n upper trapezoidal matrix A, m <= n, to upper
+// triangular form by unitary transformations from the right, A = [R 0]*Z,
+// with a blocked algorithm. R is left in the first m columns of A. Z is
+// represented as the product Z = H(0)*H(1)*...*H(m-1) of elementary
+// reflectors H(i) = I - tau[i]*u*u**H, where u is 1 in element i, zero up to
+// column m and holds row i of the last n-m columns of A in its last n-m
+// elements. work holds lwork >= max(1,m) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZTZRZF(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkTzrzf("ZTZRZF", m, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	tzrzf(impl.bl(), m, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNMR3 overwrites the m×n matrix C with op(Z)*C (side L) or C*op(Z)
+// (side R), where Z is given by the k reflectors left in A and tau by
+// ZTZRZF, whose vectors are held in the last l columns of A, and op(Z)
+// is Z (trans N) or Z**H (trans C). work must hold n elements for side L
+// and m for side R.
+func (impl Implementation) ZUNMR3(side blas.Side, trans blas.Transpose, m, n, k, l int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128) error {
+	if err := checkOrmrz("ZUNMR3", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), noLwork, true); err != nil {
+		return err
+	}
+	ormr3(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work)
+	return nil
+}
+
+// ZUNMRZ computes the product of ZUNMR3 with a blocked algorithm.
+// work holds lwork >= max(1,nw) elements, where nw is n for side L and m for
+// side R; the optimal lwork is returned in work[0] by a call with lwork = -1
+// that does nothing else.
+func (impl Implementation) ZUNMRZ(side blas.Side, trans blas.Transpose, m, n, k, l int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmrz("ZUNMRZ", side, trans, m, n, k, l, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true); err != nil {
+		return err
+	}
+	ormrz(impl.bl(), side, trans, m, n, k, l, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// checkTzrzf checks the TZRZF routines.
+func checkTzrzf(routine string, m, n, lenA, lda, lenTau, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "m", m)
+	c.atLeast(2, "n", n, m, "m")
+	c.ld(4, "lda", lda, m, "m")
+	c.lwork(7, lwork, max(1, m), "max(1,m)")
+	if c.ok() {
+		c.work(6, lenWork, lwork)
+		if lwork != -1 {
+			c.length(3, "a", lenA, matLen(m, n, lda))
+			c.length(5, "tau", lenTau, m)
+		}
+	}
+	return c.result()
+}
+
+// checkOrmrz checks the routines that apply the Z of an RZ factorization.
+func checkOrmrz(routine string, side blas.Side, trans blas.Transpose, m, n, k, l, lenA, lda, lenTau, lenC, ldc, lenWork, lwork int, complex bool) error {
+	c := checker{routine: routine}
+	c.side(1, side)
+	c.transQ(2, trans, complex)
+	c.nonNeg(3, "m", m)
+	c.nonNeg(4, "n", n)
+	nq, nw := m, n
+	if side == blas.SideR {
+		nq, nw = n, m
+	}
+	if k < 0 || k > nq {
+		c.fail(5, "k", "must satisfy 0 <= k <= nq")
+	}
+	if l < 0 || l > nq {
+		c.fail(6, "l", "must satisfy 0 <= l <= nq")
+	}
+	c.ld(8, "lda", lda, k, "k")
+	c.ld(11, "ldc", ldc, m, "m")
+	if lwork != noLwork {
+		c.lwork(13, lwork, max(1, nw), "max(1,nw)")
+	}
+	if c.ok() {
+		if lwork == noLwork {
+			c.length(12, "work", lenWork, nw)
+		} else {
+			c.work(12, lenWork, lwork)
+		}
+		if lwork != -1 {
+			c.length(7, "a", lenA, matLen(k, nq, lda))
+			c.length(9, "tau", lenTau, k)
+			c.length(10, "c", lenC, matLen(m, n, ldc))
+		}
+	}
+	return c.result()
+}
+
+// larz applies the elementary reflector H = I - tau*u*u**H of an RZ
+// factorization to the m×n matrix C from the left (side L) or the right
+// (side R), as xLARZ. u is 1 in its first element, zero down to its last l
+// elements, which are the elements of v. work must hold n elements for
+// side L and m for side R.
+func larz[T gen.Scalar](bl blas.BLAS, side blas.Side, m, n, l int, v []T, incV int, tau T, c []T, ldc int, work []T) {
+	if tau == 0 {
+		return
+	}
+	if side == blas.SideL {
+		// w**T = C(0,:) + v**H*C(m-l:m,:), C(0,:) -= tau*w**T,
+		// C(m-l:m,:) -= tau*v*w**T.
+		copyVec(bl, n, c, ldc, work, 1)
+		lacgv(n, work, 1)
+		gemv(bl, blas.TransC, l, n, 1, c[m-l:], ldc, v, incV, 1, work, 1)
+		lacgv(n, work, 1)
+		axpy(bl, n, -tau, work, 1, c, ldc)
+		geru(bl, l, n, -tau, v, incV, work, 1, c[m-l:], ldc)
+		return
+	}
+	// w = C(:,0) + C(:,n-l:n)*v, C(:,0) -= tau*w, C(:,n-l:n) -= tau*w*v**H.
+	copyVec(bl, m, c, 1, work, 1)
+	gemv(bl, blas.TransN, m, l, 1, c[(n-l)*ldc:], ldc, v, incV, 1, work, 1)
+	axpy(bl, m, -tau, work, 1, c, 1)
+	gerc(bl, m, l, -tau, work, 1, v, incV, c[(n-l)*ldc:], ldc)
+}
+
+// latrz reduces the m×n upper trapezoidal matrix [A1 A2], whose last l
+// columns are A2 and whose first m columns A1 are upper triangular, to the
+// upper triangular form [R 0] = A*Z**H by orthogonal or unitary
+// transformations from the right, as xLATRZ. m must not exceed n. Z is
+// represented by the reflectors H(i) = I - tau[i]*u*u**H, Z =
+// H(0)*H(1)*...*H(m-1), where u is 1 in element i and the elements of row i
+// of A2 in its last l elements. work must hold m elements.
+func latrz[T gen.Scalar](bl blas.BLAS, m, n, l int, a []T, lda int, tau, work []T) {
+	if m == 0 {
+		return
+	}
+	if m == n {
+		for i := range tau[:m] {
+			tau[i] = 0
+		}
+		return
+	}
+	for i := m - 1; i >= 0; i-- {
+		// Generate H(i) to annihilate [A(i,i) A(i,n-l:n)] and apply it to
+		// A(0:i,i:n) from the right.
+		vi := a[i+(n-l)*lda:]
+		lacgv(l, vi, lda)
+		beta, t := larfg(bl, l+1, conj(a[i+i*lda]), vi, lda)
+		tau[i] = conj(t)
+		larz(bl, blas.SideR, i, n-i, l, vi, lda, t, a[i*lda:], lda, work)
+		a[i+i*lda] = conj(beta)
+	}
+}
+
+// larzt forms the k×k lower triangular factor T of the block reflector
+// H = H(k-1)*...*H(1)*H(0) of an RZ factorization, whose k reflectors have
+// the last n elements of their vectors in the rows of V, so that
+// H = I - U*T*U**H, as xLARZT with direct B and storev R.
+func larzt[T gen.Scalar](bl blas.BLAS, n, k int, v []T, ldv int, tau, t []T, ldt int) {
+	for i := k - 1; i >= 0; i-- {
+		if tau[i] == 0 {
+			for j := i; j < k; j++ {
+				t[j+i*ldt] = 0
+			}
+			continue
+		}
+		if i < k-1 {
+			// T(i+1:k,i) = -tau[i]*T(i+1:k,i+1:k)*V(i+1:k,:)*V(i,:)**H.
+			lacgv(n, v[i:], ldv)
+			gemv(bl, blas.TransN, k-i-1, n, -tau[i], v[i+1:], ldv, v[i:], ldv, 0, t[i+1+i*ldt:], 1)
+			lacgv(n, v[i:], ldv)
+			trmv(bl, blas.UploL, blas.TransN, blas.DiagN, k-i-1, t[i+1+(i+1)*ldt:], ldt, t[i+1+i*ldt:], 1)
+		}
+		t[i+i*ldt] = tau[i]
+	}
+}
+
+// larzb applies the block reflector H formed by larzt, or H**H when trans
+// is not N, to the m×n matrix C from the left (side L) or the right (side
+// R), as xLARZB with direct B and storev R. The k reflectors start at the
+// first k rows or columns of C and have the last l of their elements in the
+// rows of V. work is an nw×k matrix with leading dimension ldwork, where nw
+// is n for side L and m for side R.
+func larzb[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k, l int, v []T, ldv int, t []T, ldt int, c []T, ldc int, work []T, ldwork int) {
+	if m <= 0 || n <= 0 {
+		return
+	}
+	transt := blas.TransC
+	if trans != blas.TransN {
+		trans, transt = blas.TransC, blas.TransN
+	}
+	if side == blas.SideL {
+		// W = C(0:k,:)**T + C(m-l:m,:)**T*V**H, W = W*op(T)**T,
+		// C(0:k,:) -= W**T, C(m-l:m,:) -= V**T*W**T.
+		for j := 0; j < k; j++ {
+			copyVec(bl, n, c[j:], ldc, work[j*ldwork:], 1)
+		}
+		if l > 0 {
+			gemm(bl, blas.TransT, blas.TransC, n, k, l, 1, c[m-l:], ldc, v, ldv, 1, work, ldwork)
+		}
+		trmm(bl, blas.SideR, blas.UploL, transt, blas.DiagN, n, k, 1, t, ldt, work, ldwork)
+		for j := 0; j < n; j++ {
+			for i := 0; i < k; i++ {
+				c[i+j*ldc] -= work[j+i*ldwork]
+			}
+		}
+		if l > 0 {
+			gemm(bl, blas.TransT, blas.TransT, l, n, k, -1, v, ldv, work, ldwork, 1, c[m-l:], ldc)
+		}
+		return
+	}
+	// W = C(:,0:k) + C(:,n-l:n)*V**T, W = W*conj(T) or W*T**H,
+	// C(:,0:k) -= W, C(:,n-l:n) -= W*conj(V).
+	for j := 0; j < k; j++ {
+		copyVec(bl, m, c[j*ldc:], 1, work[j*ldwork:], 1)
+	}
+	if l > 0 {
+		gemm(bl, blas.TransN, blas.TransT, m, k, l, 1, c[(n-l)*ldc:], ldc, v, ldv, 1, work, ldwork)
+	}
+	for j := 0; j < k; j++ {
+		lacgv(k-j, t[j+j*ldt:], 1)
+	}
+	trmm(bl, blas.SideR, blas.UploL, trans, blas.DiagN, m, k, 1, t, ldt, work, ldwork)
+	for j := 0; j < k; j++ {
+		lacgv(k-j, t[j+j*ldt:], 1)
+	}
+	for j := 0; j < k; j++ {
+		for i := 0; i < m; i++ {
+			c[i+j*ldc] -= work[i+j*ldwork]
+		}
+	}
+	if l > 0 {
+		for j := 0; j < l; j++ {
+			lacgv(k, v[j*ldv:], 1)
+		}
+		gemm(bl, blas.TransN, blas.TransN, m, l, k, -1, work, ldwork, v, ldv, 1, c[(n-l)*ldc:], ldc)
+		for j := 0; j < l; j++ {
+			lacgv(k, v[j*ldv:], 1)
+		}
+	}
+}
+
+// tzrzf reduces the m×n upper trapezoidal matrix A, m <= n, to upper
+// triangular form with the blocked algorithm of xTZRZF, so that
+// A = [R 0]*Z with Z as left by latrz for l = n-m. work holds lwork >= m
+// elements, and the block size is reduced to fit. A workspace query,
+// lwork = -1, only sets work[0] to the optimal lwork.
+func tzrzf[T gen.Scalar](bl blas.BLAS, m, n int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, m*qrBlock)))
+		return
+	}
+	if m == 0 {
+		return
+	}
+	if m == n {
+		for i := range tau[:m] {
+			tau[i] = 0
+		}
+		return
+	}
+	nb, nx := qrBlock, 0
+	ldwork := m
+	if nb > 1 && nb < m {
+		nx = qrCrossover
+		if nx < m && lwork < ldwork*nb {
+			nb = lwork / ldwork
+		}
+	}
+	mu := m
+	if nb >= 2 && nb < m && nx < m {
+		// The last kk rows are reduced by blocks of nb, from the last, and
+		// the rest by the unblocked code.
+		ki := ((m - nx - 1) / nb) * nb
+		kk := min(m, ki+nb)
+		for i := m - kk + ki; i >= m-kk; i -= nb {
+			ib := min(m-i, nb)
+			latrz(bl, ib, n-i, n-m, a[i+i*lda:], lda, tau[i:], work)
+			if i > 0 {
+				// Form the triangular factor of the block reflector
+				// H = H(i+ib-1)*...*H(i+1)*H(i) and apply it to
+				// A(0:i,i:n) from the right.
+				larzt(bl, n-m, ib, a[i+m*lda:], lda, tau[i:], work, ldwork)
+				larzb(bl, blas.SideR, blas.TransN, i, n-i, ib, n-m, a[i+m*lda:], lda, work, ldwork, a[i*lda:], lda, work[ib:], ldwork)
+			}
+		}
+		mu = m - kk
+	}
+	if mu > 0 {
+		latrz(bl, mu, n, n-m, a, lda, tau, work)
+	}
+}
+
+// ormr3 overwrites the m×n matrix C with op(Z)*C (side L) or C*op(Z)
+// (side R), where op(Z) is Z or Z**H and Z is given by the k reflectors
+// left by tzrzf in the rows of A, whose last l columns hold their vectors,
+// and in tau, as xORMR3. work must hold n elements for side L and m for
+// side R.
+func ormr3[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k, l int, a []T, lda int, tau, c []T, ldc int, work []T) {
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	ja := m - l
+	if !left {
+		ja = n - l
+	}
+	// The vectors of the reflectors are in A(:,ja:ja+l), which is not
+	// referenced when l is zero.
+	v := func(i int) []T {
+		if l == 0 {
+			return nil
+		}
+		return a[i+ja*lda:]
+	}
+	for j := 0; j < k; j++ {
+		// H(0) is applied first for Z**H*C and C*Z, and last otherwise.
+		i := j
+		if left == notran {
+			i = k - 1 - j
+		}
+		taui := tau[i]
+		if !notran {
+			taui = conj(taui)
+		}
+		// H(i) is applied to C(i:m,:) or C(:,i:n).
+		if left {
+			larz(bl, side, m-i, n, l, v(i), lda, taui, c[i:], ldc, work)
+		} else {
+			larz(bl, side, m, n-i, l, v(i), lda, taui, c[i*ldc:], ldc, work)
+		}
+	}
+}
+
+// ormrz computes the product of ormr3 with the blocked algorithm of xORMRZ.
+// work holds lwork >= nw elements, where nw is n for side L and m for side
+// R, and the block size is reduced to fit. A workspace query, lwork = -1,
+// only sets work[0] to the optimal lwork.
+func ormrz[T gen.Scalar](bl blas.BLAS, side blas.Side, trans blas.Transpose, m, n, k, l int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	left, notran := side == blas.SideL, trans == blas.TransN
+	nw, ja := n, m-l
+	if !left {
+		nw, ja = m, n-l
+	}
+	// The vectors of the reflectors are in A(:,ja:ja+l), which is not
+	// referenced when l is zero.
+	v := func(i int) []T {
+		if l == 0 {
+			return nil
+		}
+		return a[i+ja*lda:]
+	}
+	nb := min(ormBlockMax, qrBlock)
+	if nb > 1 && nb < k && lwork < ormWork(side, m, n) {
+		nb = (lwork - ormTSize) / nw
+	}
+	if nb < 2 || nb >= k {
+		ormr3(bl, side, trans, m, n, k, l, a, lda, tau, c, ldc, work)
+		return
+	}
+	transt := blas.TransC
+	if !notran {
+		transt = blas.TransN
+	}
+	t := work[nw*nb:]
+	last := ((k - 1) / nb) * nb
+	for j := 0; j <= last; j += nb {
+		i := j
+		if left == notran {
+			i = last - j
+		}
+		ib := min(nb, k-i)
+		// Form the triangular factor of the block reflector
+		// H = H(i+ib-1)*...*H(i+1)*H(i) and apply it to C(i:m,:) or
+		// C(:,i:n).
+		larzt(bl, l, ib, v(i), lda, tau[i:], t, ormBlockMax+1)
+		if left {
+			larzb(bl, side, transt, m-i, n, ib, l, v(i), lda, t, ormBlockMax+1, c[i:], ldc, work, nw)
+		} else {
+			larzb(bl, side, transt, m, n-i, ib, l, v(i), lda, t, ormBlockMax+1, c[i*ldc:], ldc, work, nw)
+		}
+	}
+}
+
+// laic1 applies one step of incremental condition estimation, as xLAIC1.
+// Given an approximate singular vector x of norm one of the j×j lower
+// triangular matrix L with ‖L*x‖ = sest, it returns sestpr, s and c such
+// that [s*x; c] approximates the singular vector of
+// [L 0; w**H gamma] with ‖[L 0; w**H gamma]*[s*x; c]‖ = sestpr. job is 1
+// to estimate the largest singular value and 2 the smallest.
+func laic1[T gen.Scalar](bl blas.BLAS, job, j int, x []T, sest float64, w []T, gamma T) (sestpr float64, s, c T) {
+	eps := eps[T]()
+	alpha := dotc(bl, j, x, 1, w, 1)
+	absalp, absgam, absest := abs(alpha), abs(gamma), math.Abs(sest)
+	scale := func(x T, f float64) T { return x * fromReal[T](f) }
+	norm := func(s, c T) float64 { return math.Hypot(abs(s), abs(c)) }
+
+	if job == 1 {
+		switch {
+		case sest == 0:
+			s1 := max(absgam, absalp)
+			if s1 == 0 {
+				return 0, 0, 1
+			}
+			s, c = scale(alpha, 1/s1), scale(gamma, 1/s1)
+			tmp := norm(s, c)
+			return s1 * tmp, scale(s, 1/tmp), scale(c, 1/tmp)
+		case absgam <= eps*absest:
+			tmp := max(absest, absalp)
+			s1, s2 := absest/tmp, absalp/tmp
+			return tmp * math.Sqrt(s1*s1+s2*s2), 1, 0
+		case absalp <= eps*absest:
+			if absgam <= absest {
+				return absest, 1, 0
+			}
+			return absgam, 0, 1
+		case absest <= eps*absalp || absest <= eps*absgam:
+			s1, s2 := absgam, absalp
+			if s1 <= s2 {
+				tmp := s1 / s2
+				scl := math.Sqrt(1 + tmp*tmp)
+				return s2 * scl, scale(alpha, 1/s2/scl), scale(gamma, 1/s2/scl)
+			}
+			tmp := s2 / s1
+			scl := math.Sqrt(1 + tmp*tmp)
+			return s1 * scl, scale(alpha, 1/s1/scl), scale(gamma, 1/s1/scl)
+		}
+		// Normal case.
+		zeta1, zeta2 := absalp/absest, absgam/absest
+		b := (1 - zeta1*zeta1 - zeta2*zeta2) / 2
+		cc := zeta1 * zeta1
+		var t float64
+		if b > 0 {
+			t = cc / (b + math.Sqrt(b*b+cc))
+		} else {
+			t = math.Sqrt(b*b+cc) - b
+		}
+		sine := scale(alpha, -1/absest/t)
+		cosine := scale(gamma, -1/absest/(1+t))
+		tmp := norm(sine, cosine)
+		return math.Sqrt(t+1) * absest, scale(sine, 1/tmp), scale(cosine, 1/tmp)
+	}
+
+	switch {
+	case sest == 0:
+		sine, cosine := T(1), T(0)
+		if max(absgam, absalp) != 0 {
+			sine, cosine = -conj(gamma), conj(alpha)
+		}
+		s1 := max(abs(sine), abs(cosine))
+		s, c = scale(sine, 1/s1), scale(cosine, 1/s1)
+		tmp := norm(s, c)
+		return 0, scale(s, 1/tmp), scale(c, 1/tmp)
+	case absgam <= eps*absest:
+		return absgam, 0, 1
+	case absalp <= eps*absest:
+		if absgam <= absest {
+			return absgam, 0, 1
+		}
+		return absest, 1, 0
+	case absest <= eps*absalp || absest <= eps*absgam:
+		s1, s2 := absgam, absalp
+		if s1 <= s2 {
+			tmp := s1 / s2
+			scl := math.Sqrt(1 + tmp*tmp)
+			return absest * (tmp / scl), scale(conj(gamma), -1/s2/scl), scale(conj(alpha), 1/s2/scl)
+		}
+		tmp := s2 / s1
+		scl := math.Sqrt(1 + tmp*tmp)
+		return absest / scl, scale(conj(gamma), -1/s1/scl), scale(conj(alpha), 1/s1/scl)
+	}
+	// Normal case: decide whether the root is closer to zero or to one.
+	zeta1, zeta2 := absalp/absest, absgam/absest
+	norma := max(1+zeta1*zeta1+zeta1*zeta2, zeta1*zeta2+zeta2*zeta2)
+	var sine, cosine T
+	if test := 1 + 2*(zeta1-zeta2)*(zeta1+zeta2); test >= 0 {
+		b := (zeta1*zeta1 + zeta2*zeta2 + 1) / 2
+		cc := zeta2 * zeta2
+		t := cc / (b + math.Sqrt(math.Abs(b*b-cc)))
+		sine = scale(alpha, 1/absest/(1-t))
+		cosine = scale(gamma, -1/absest/t)
+		sestpr = math.Sqrt(t+4*eps*eps*norma) * absest
+	} else {
+		b := (zeta2*zeta2 + zeta1*zeta1 - 1) / 2
+		cc := zeta1 * zeta1
+		var t float64
+		if b >= 0 {
+			t = -cc / (b + math.Sqrt(b*b+cc))
+		} else {
+			t = b - math.Sqrt(b*b+cc)
+		}
+		sine = scale(alpha, -1/absest/t)
+		cosine = scale(gamma, -1/absest/(1+t))
+		sestpr = math.Sqrt(1+t+4*eps*eps*norma) * absest
+	}
+	tmp := norm(sine, cosine)
+	return sestpr, scale(sine, 1/tmp), scale(cosine, 1/tmp)
+}
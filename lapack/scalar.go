@@ -0,0 +1,134 @@
+package lapack
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// as converts x to U, which must be the type of x. It lets the generic
+// routines pass their scalars to the BLAS routine for their element type.
+func as[U, T gen.Scalar](x T) U {
+	return *any(&x).(*U)
+}
+
+// realTrans returns TransT for TransC and t otherwise.
+func realTrans(t blas.Transpose) blas.Transpose {
+	if t == blas.TransC {
+		return blas.TransT
+	}
+	return t
+}
+
+// isComplex reports whether T is a complex type.
+func isComplex[T gen.Scalar]() bool {
+	var x T
+	switch any(x).(type) {
+	case complex64, complex128:
+		return true
+	}
+	return false
+}
+
+// conj returns the complex conjugate of x.
+func conj[T gen.Scalar](x T) T {
+	return gen.Conj(x)
+}
+
+// re returns the real part of x.
+func re[T gen.Scalar](x T) float64 {
+	switch v := any(x).(type) {
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	case complex64:
+		return float64(real(v))
+	case complex128:
+		return real(v)
+	}
+	panic("unreachable")
+}
+
+// im returns the imaginary part of x, zero for real types.
+func im[T gen.Scalar](x T) float64 {
+	switch v := any(x).(type) {
+	case complex64:
+		return float64(imag(v))
+	case complex128:
+		return imag(v)
+	}
+	return 0
+}
+
+// abs returns the modulus of x.
+func abs[T gen.Scalar](x T) float64 {
+	switch v := any(x).(type) {
+	case float32:
+		return math.Abs(float64(v))
+	case float64:
+		return math.Abs(v)
+	case complex64:
+		return cmplx.Abs(complex128(v))
+	case complex128:
+		return cmplx.Abs(v)
+	}
+	panic("unreachable")
+}
+
+// abs1 returns |re(x)|+|im(x)|.
+func abs1[T gen.Scalar](x T) float64 {
+	return math.Abs(re(x)) + math.Abs(im(x))
+}
+
+// toC128 converts x to complex128.
+func toC128[T gen.Scalar](x T) complex128 {
+	return complex(re(x), im(x))
+}
+
+// fromC128 converts z to T, dropping its imaginary part for real types.
+func fromC128[T gen.Scalar](z complex128) T {
+	return fromParts[T](real(z), imag(z))
+}
+
+// fromReal converts r to T.
+func fromReal[T gen.Scalar](r float64) T {
+	return fromParts[T](r, 0)
+}
+
+// fromParts converts re + i*im to T, dropping im for real types.
+func fromParts[T gen.Scalar](re, im float64) (x T) {
+	switch p := any(&x).(type) {
+	case *float32:
+		*p = float32(re)
+	case *float64:
+		*p = re
+	case *complex64:
+		*p = complex(float32(re), float32(im))
+	case *complex128:
+		*p = complex(re, im)
+	}
+	return x
+}
+
+// eps returns the relative machine precision of T, half the distance from
+// one to the next larger number, as DLAMCH('E').
+func eps[T gen.Scalar]() float64 {
+	switch any(*new(T)).(type) {
+	case float32, complex64:
+		return 0x1p-24
+	}
+	return 0x1p-53
+}
+
+// safmin returns the smallest positive number of T whose reciprocal does not
+// overflow, as DLAMCH('S').
+func safmin[T gen.Scalar]() float64 {
+	switch any(*new(T)).(type) {
+	case float32, complex64:
+		return 0x1p-126
+	}
+	return 0x1p-1022
+}
@@ -0,0 +1,473 @@
+package lapack
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SSTEDC computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the divide and conquer method. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the orthogonal matrix of a reduction to T, such as that formed from the
+// output of SSYTRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. For compz N only the eigenvalues are computed, by SSTERF, and Z,
+// work and iwork are not referenced. On return d holds the eigenvalues in
+// ascending order and e is destroyed. Unless compz is N, work holds
+// 3*n*n+5*n elements and iwork 3*n. If the algorithm fails to converge,
+// SSTEDC returns a *ConvergenceError whose Info is that of xSTEDC: the
+// failing submatrix lies in the rows and columns Info/(n+1) through
+// Info%(n+1), counted from 1.
+func (impl Implementation) SSTEDC(compz CompZ, n int, d, e []float32, z []float32, ldz int, work []float32, iwork []int) error {
+	if err := checkStedc("SSTEDC", compz, n, len(d), len(e), len(z), ldz, len(work), len(iwork), false); err != nil {
+		return err
+	}
+	return convergence("SSTEDC", stedc(impl.bl(), compz, n, d, e, z, ldz, work, iwork))
+}
+
+// DSTEDC computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the divide and conquer method. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the orthogonal matrix of a reduction to T, such as that formed from the
+// output of DSYTRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. For compz N only the eigenvalues are computed, by DSTERF, and Z,
+// work and iwork are not referenced. On return d holds the eigenvalues in
+// ascending order and e is destroyed. Unless compz is N, work holds
+// 3*n*n+5*n elements and iwork 3*n. If the algorithm fails to converge,
+// DSTEDC returns a *ConvergenceError whose Info is that of xSTEDC: the
+// failing submatrix lies in the rows and columns Info/(n+1) through
+// Info%(n+1), counted from 1.
+func (impl Implementation) DSTEDC(compz CompZ, n int, d, e []float64, z []float64, ldz int, work []float64, iwork []int) error {
+	if err := checkStedc("DSTEDC", compz, n, len(d), len(e), len(z), ldz, len(work), len(iwork), false); err != nil {
+		return err
+	}
+	return convergence("DSTEDC", stedc(impl.bl(), compz, n, d, e, z, ldz, work, iwork))
+}
+
+// CSTEDC computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the divide and conquer method. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the unitary matrix of a reduction to T, such as that formed from the
+// output of CHETRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. For compz N only the eigenvalues are computed, by SSTERF, and Z,
+// rwork and iwork are not referenced. On return d holds the eigenvalues in
+// ascending order and e is destroyed. Unless compz is N, rwork holds
+// 3*n*n+5*n elements and iwork 3*n. If the algorithm fails to converge,
+// CSTEDC returns a *ConvergenceError whose Info is that of xSTEDC: the
+// failing submatrix lies in the rows and columns Info/(n+1) through
+// Info%(n+1), counted from 1.
+func (impl Implementation) CSTEDC(compz CompZ, n int, d, e []float32, z []complex64, ldz int, rwork []float32, iwork []int) error {
+	if err := checkStedc("CSTEDC", compz, n, len(d), len(e), len(z), ldz, len(rwork), len(iwork), true); err != nil {
+		return err
+	}
+	return convergence("CSTEDC", stedc(impl.bl(), compz, n, d, e, z, ldz, rwork, iwork))
+}
+
+// ZSTEDC computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the divide and conquer method. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the unitary matrix of a reduction to T, such as that formed from the
+// output of ZHETRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. For compz N only the eigenvalues are computed, by DSTERF, and Z,
+// rwork and iwork are not referenced. On return d holds the eigenvalues in
+// ascending order and e is destroyed. Unless compz is N, rwork holds
+// 3*n*n+5*n elements and iwork 3*n. If the algorithm fails to converge,
+// ZSTEDC returns a *ConvergenceError whose Info is that of xSTEDC: the
+// failing submatrix lies in the rows and columns Info/(n+1) through
+// Info%(n+1), counted from 1.
+func (impl Implementation) ZSTEDC(compz CompZ, n int, d, e []float64, z []complex128, ldz int, rwork []float64, iwork []int) error {
+	if err := checkStedc("ZSTEDC", compz, n, len(d), len(e), len(z), ldz, len(rwork), len(iwork), true); err != nil {
+		return err
+	}
+	return convergence("ZSTEDC", stedc(impl.bl(), compz, n, d, e, z, ldz, rwork, iwork))
+}
+
+// stedcLeaf is the order up to which laed0 solves a subproblem by steqr,
+// the value of ILAENV for SMLSIZ.
+const stedcLeaf = 25
+
+// laed4MaxIter bounds the number of iterations of laed4, as lasd4MaxIter
+// does for lasd4.
+const laed4MaxIter = 400
+
+// checkStedc checks the STEDC routines.
+func checkStedc(routine string, compz CompZ, n, lenD, lenE, lenZ, ldz, lenWork, lenIwork int, complex bool) error {
+	c := checker{routine: routine}
+	c.compz(1, compz)
+	c.nonNeg(2, "n", n)
+	if compz == CompZN {
+		c.atLeast(6, "ldz", ldz, 1, "1")
+	} else {
+		c.ld(6, "ldz", ldz, n, "n")
+	}
+	if c.ok() {
+		c.length(3, "d", lenD, n)
+		c.length(4, "e", lenE, n-1)
+		if compz != CompZN {
+			c.length(5, "z", lenZ, matLen(n, n, ldz))
+			work := "work"
+			if complex {
+				work = "rwork"
+			}
+			c.length(7, work, lenWork, stedcWork(n))
+			c.length(8, "iwork", lenIwork, 3*n)
+		}
+	}
+	return c.result()
+}
+
+// stedcWork returns the length of the real workspace of stedc for order n.
+func stedcWork(n int) int {
+	return 3*n*n + 5*n
+}
+
+// stedc computes the eigenvalues and, unless compz is N, the eigenvectors of
+// the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the divide and conquer method of xSTEDC, with Z as in
+// steqr. Matrices of order up to stedcLeaf are passed to steqr; larger ones
+// are split into unreduced blocks, the eigenvectors of each block are
+// computed in work by laed0 and copied into Z (compz I) or multiplied into
+// it (compz V). On return d holds the eigenvalues in ascending order and e
+// is destroyed. Unless compz is N, work holds stedcWork(n) elements and
+// iwork 3*n. stedc returns 0, or the info of steqr or sterf, or
+// (i+1)*(n+1)+j+1 if the block in the rows and columns i through j failed
+// to converge.
+func stedc[T gen.Scalar, R gen.Float](bl blas.BLAS, compz CompZ, n int, d, e []R, z []T, ldz int, work []R, iwork []int) (info int) {
+	if compz == CompZN {
+		return sterf(n, d, e)
+	}
+	if n <= stedcLeaf {
+		return steqr(bl, compz, n, d, e, z, ldz, work)
+	}
+	if compz == CompZI {
+		laset(uploAll, n, n, 0, 1, z, ldz)
+	}
+	eps := eps[R]()
+	for start := 0; start < n; {
+		end := start
+		for ; end < n-1; end++ {
+			tiny := eps * math.Sqrt(math.Abs(float64(d[end]))) * math.Sqrt(math.Abs(float64(d[end+1])))
+			if math.Abs(float64(e[end])) <= tiny {
+				break
+			}
+		}
+		m := end - start + 1
+		if m == 1 {
+			start++
+			continue
+		}
+		// Scale the block to unit norm and compute its eigenvectors W.
+		ds, es := d[start:], e[start:]
+		orgnrm := lanst(normMax, m, ds, es)
+		lascl(uploAll, orgnrm, 1, m, 1, ds, m)
+		lascl(uploAll, orgnrm, 1, m-1, 1, es, m)
+		wq, w := work[:m*m], work[m*m:]
+		if laed0(bl, m, ds, es, wq, m, w, iwork) != 0 {
+			return (start+1)*(n+1) + end + 1
+		}
+		lascl(uploAll, 1, orgnrm, m, 1, ds, m)
+		if compz == CompZI {
+			for j := 0; j < m; j++ {
+				for i := 0; i < m; i++ {
+					z[start+i+(start+j)*ldz] = fromReal[T](float64(wq[i+j*m]))
+				}
+			}
+		} else {
+			realMulRight(bl, n, m, z[start*ldz:], ldz, wq, m, w)
+		}
+		start = end + 1
+	}
+	eigSort(bl, n, d, n, z, ldz)
+	return 0
+}
+
+// laed0 computes the eigenvalues and the n×n matrix Q of the eigenvectors
+// of the real symmetric tridiagonal matrix with diagonal d and off-diagonal
+// e by the divide and conquer method of xLAED0. The eigenvalues are
+// returned in d in no particular order and e is destroyed. work holds
+// 2*n*n+5*n elements and iwork 3*n. laed0 returns 0, or a positive value
+// if a subproblem failed to converge.
+func laed0[R gen.Float](bl blas.BLAS, n int, d, e, q []R, ldq int, work []R, iwork []int) (info int) {
+	if n <= stedcLeaf {
+		return steqr(bl, CompZI, n, d, e, q, ldq, work)
+	}
+	// Split T into two blocks after row nl and a rank-one correction of
+	// the coupling element rho.
+	nl := n / 2
+	rho := float64(e[nl-1])
+	d[nl-1] = R(float64(d[nl-1]) - math.Abs(rho))
+	d[nl] = R(float64(d[nl]) - math.Abs(rho))
+	laset(uploAll, nl, n-nl, 0, 0, q[nl*ldq:], ldq)
+	laset(uploAll, n-nl, nl, 0, 0, q[nl:], ldq)
+	if info = laed0(bl, nl, d, e, q, ldq, work, iwork); info != 0 {
+		return info
+	}
+	if info = laed0(bl, n-nl, d[nl:], e[nl:], q[nl+nl*ldq:], ldq, work, iwork); info != 0 {
+		return info
+	}
+	return laed1(bl, n, nl, d, q, ldq, rho, work, iwork)
+}
+
+// laed1 merges the eigendecompositions of the two blocks of the n×n real
+// symmetric tridiagonal matrix split by laed0 after row nl into that of
+// the whole matrix, as xLAED1. On entry d holds the eigenvalues of the
+// blocks in d[:nl] and d[nl:], Q holds the block diagonal matrix of their
+// eigenvectors, and rho is the coupling element. The merged eigenvalues
+// and eigenvectors overwrite d and Q.
+func laed1[R gen.Float](bl blas.BLAS, n, nl int, d, q []R, ldq int, rho float64, work []R, iwork []int) (info int) {
+	// T = Q*(D + rho*z*z**T)*Q**T, where z holds the last row of the first
+	// block and the first row of the second, scaled to unit norm.
+	z := work[:n]
+	for j := 0; j < nl; j++ {
+		z[j] = R(float64(q[nl-1+j*ldq]) / math.Sqrt2)
+	}
+	sgn := math.Copysign(1/math.Sqrt2, rho)
+	for j := nl; j < n; j++ {
+		z[j] = R(sgn * float64(q[nl+j*ldq]))
+	}
+	rho = 2 * math.Abs(rho)
+
+	// Order the eigenvalues of the blocks increasingly and deflate, as
+	// xLAED2: a negligible z[g] leaves d[g] as an eigenvalue, and of two
+	// close d the first is rotated into the second.
+	idx := iwork[:n]
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(d[a], d[b]) })
+	var zmax float64
+	for i := 0; i < n; i++ {
+		zmax = max(zmax, math.Abs(float64(z[i])))
+	}
+	dmax := max(math.Abs(float64(d[idx[0]])), math.Abs(float64(d[idx[n-1]])))
+	tol := 8 * eps[R]() * max(dmax, zmax)
+	keep := iwork[n : n : 2*n]
+	defl := iwork[2*n : 2*n : 3*n]
+	prev := -1
+	for _, g := range idx {
+		zg := float64(z[g])
+		if rho*math.Abs(zg) <= tol {
+			defl = append(defl, g)
+			continue
+		}
+		if prev >= 0 {
+			zp := float64(z[prev])
+			t := lapy2(zg, zp)
+			c, s := zg/t, -zp/t
+			dp, dg := float64(d[prev]), float64(d[g])
+			if math.Abs((dg-dp)*c*s) <= tol {
+				rrot(bl, n, q[prev*ldq:], 1, q[g*ldq:], 1, c, s)
+				z[g], z[prev] = R(t), 0
+				d[prev], d[g] = R(dp*c*c+dg*s*s), R(dp*s*s+dg*c*c)
+				keep[len(keep)-1] = g
+				defl = append(defl, prev)
+				prev = g
+				continue
+			}
+		}
+		keep = append(keep, g)
+		prev = g
+	}
+
+	// Solve the secular equation of the k undeflated poles and form the
+	// eigenvectors of D + rho*z*z**T from the z recomputed by Löwner's
+	// formula, as xLAED3.
+	k := len(keep)
+	w := work[n:]
+	dd, zz, lam, zh := w[:k], w[k:2*k], w[2*k:3*k], w[3*k:4*k]
+	w = w[4*k:]
+	s, gq := w[:k*k], w[k*k:k*k+n*n]
+	for i, g := range keep {
+		dd[i], zz[i] = d[g], z[g]
+	}
+	eps := eps[R]()
+	for i := 0; i < k; i++ {
+		l, ok := laed4(k, i, dd, zz, s[i*k:], rho, eps)
+		if !ok {
+			return 1
+		}
+		lam[i] = R(l)
+	}
+	for j := 0; j < k; j++ {
+		dj := float64(dd[j])
+		p := -float64(s[j+(k-1)*k]) / rho
+		for r := 0; r < j; r++ {
+			p *= -float64(s[j+r*k]) / (float64(dd[r]) - dj)
+		}
+		for r := j; r < k-1; r++ {
+			p *= -float64(s[j+r*k]) / (float64(dd[r+1]) - dj)
+		}
+		zh[j] = R(math.Copysign(math.Sqrt(math.Abs(p)), float64(zz[j])))
+	}
+	for i := 0; i < k; i++ {
+		si := s[i*k : (i+1)*k]
+		for j := 0; j < k; j++ {
+			si[j] = R(float64(zh[j]) / float64(si[j]))
+		}
+		rscal(bl, k, 1/nrm2(bl, k, si, 1), si, 1)
+	}
+
+	// Gather the vectors of the undeflated and then the deflated poles and
+	// multiply the former by those of D + rho*z*z**T.
+	for i, g := range keep {
+		copyVec(bl, n, q[g*ldq:], 1, gq[i*n:], 1)
+	}
+	for i, g := range defl {
+		copyVec(bl, n, q[g*ldq:], 1, gq[(k+i)*n:], 1)
+		z[i] = d[g]
+	}
+	if k > 0 {
+		gemm(bl, blas.TransN, blas.TransN, n, k, k, 1, gq, n, s, k, 0, q, ldq)
+	}
+	if k < n {
+		lacpy(uploAll, n, n-k, gq[k*n:], n, q[k*ldq:], ldq)
+	}
+	copy(d, lam)
+	copy(d[k:n], z[:n-k])
+	return 0
+}
+
+// laed4 computes the root lambda in (d[i], d[i+1]), or above d[k-1] for
+// i = k-1, of the secular equation 1/rho + sum_j z[j]**2/(d[j]-lambda) = 0,
+// where d[0] < d[1] < ... < d[k-1], rho > 0 and z has no zero element, as
+// xLAED4. The root is sought as lambda = d[o] + tau for the pole d[o]
+// nearer to it, so that delta[j] = d[j]-lambda, which it sets, is accurate.
+// It reports whether the iteration converged.
+func laed4[R gen.Float](k, i int, d, z, delta []R, rho, eps float64) (lambda float64, ok bool) {
+	// Choose the origin and bracket tau; f is increasing between poles.
+	o := i
+	var lo, hi float64
+	if i == k-1 {
+		for j := 0; j < k; j++ {
+			hi += float64(z[j]) * float64(z[j])
+		}
+		hi *= rho
+	} else {
+		di := float64(d[i])
+		mid := (float64(d[i+1]) - di) / 2
+		f := 1 / rho
+		for j := 0; j < k; j++ {
+			zj := float64(z[j])
+			f += zj * zj / ((float64(d[j]) - di) - mid)
+		}
+		if f >= 0 {
+			hi = mid
+		} else {
+			o = i + 1
+			lo = -mid
+		}
+	}
+	do := float64(d[o])
+	dif := func(j int) float64 { return float64(d[j]) - do }
+
+	// Iterate on the rational model c + s/(dif(i)-x) + t/(dif(i+1)-x) as
+	// lasd4 does.
+	tau := (lo + hi) / 2
+	for iter := 0; iter < laed4MaxIter; iter++ {
+		var psi, dpsi, phi, dphi float64
+		for j := 0; j <= i; j++ {
+			t := float64(z[j]) / (dif(j) - tau)
+			psi += float64(z[j]) * t
+			dpsi += t * t
+		}
+		for j := i + 1; j < k; j++ {
+			t := float64(z[j]) / (dif(j) - tau)
+			phi += float64(z[j]) * t
+			dphi += t * t
+		}
+		f := 1/rho + psi + phi
+		if math.Abs(f) <= 8*float64(k)*eps*(1/rho+math.Abs(psi)+math.Abs(phi)) {
+			ok = true
+			break
+		}
+		if f < 0 {
+			lo = tau
+		} else {
+			hi = tau
+		}
+		if hi-lo <= 2*eps*max(math.Abs(lo), math.Abs(hi)) {
+			ok = true
+			break
+		}
+		a := dif(i) - tau
+		c := f - dpsi*a
+		s := dpsi * a * a
+		next := math.NaN()
+		if i == k-1 {
+			if c != 0 {
+				next = tau + a + s/c
+			}
+		} else {
+			b := dif(i+1) - tau
+			t := dphi * b * b
+			c -= dphi * b
+			// Solve c*eta**2 - bb*eta + cc = 0 for the step eta.
+			bb := c*(a+b) + s + t
+			cc := c*a*b + s*b + t*a
+			switch {
+			case c == 0:
+				if bb != 0 {
+					next = tau + cc/bb
+				}
+			case bb*bb >= 4*c*cc:
+				q := (bb + math.Copysign(math.Sqrt(bb*bb-4*c*cc), bb)) / 2
+				for _, eta := range [2]float64{q / c, cc / q} {
+					if x := tau + eta; x > lo && x < hi {
+						next = x
+					}
+				}
+			}
+		}
+		if !(next > lo && (next < hi || i == k-1 && next == hi)) {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-tau) <= eps*math.Abs(next) {
+			tau = next
+			ok = true
+			break
+		}
+		tau = next
+	}
+	if !ok {
+		return 0, false
+	}
+	for j := 0; j < k; j++ {
+		delta[j] = R(dif(j) - tau)
+	}
+	return do + tau, true
+}
+
+// realMulRight overwrites the m×n matrix B by B*X, where X is a real n×n
+// matrix, one part of B at a time through w, which holds 2*m*n elements.
+func realMulRight[T gen.Scalar, R gen.Float](bl blas.BLAS, m, n int, b []T, ldb int, x []R, ldx int, w []R) {
+	p, q := w[:m*n], w[m*n:2*m*n]
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			p[i+j*m] = R(re(b[i+j*ldb]))
+		}
+	}
+	gemm(bl, blas.TransN, blas.TransN, m, n, n, 1, p, m, x, ldx, 0, q, m)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			bij := b[i+j*ldb]
+			b[i+j*ldb] = fromParts[T](float64(q[i+j*m]), im(bij))
+			p[i+j*m] = R(im(bij))
+		}
+	}
+	if !isComplex[T]() {
+		return
+	}
+	gemm(bl, blas.TransN, blas.TransN, m, n, n, 1, p, m, x, ldx, 0, q, m)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			b[i+j*ldb] = fromParts[T](re(b[i+j*ldb]), float64(q[i+j*m]))
+		}
+	}
+}
@@ -0,0 +1,969 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SSTEMR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n real symmetric tridiagonal matrix T with diagonal d and off-diagonal e
+// by the algorithm of multiple relatively robust representations (MRRR).
+// rng A selects all the eigenvalues, rng V those in the half-open interval
+// (vl,vu], and rng I those il through iu in ascending order, counted from 0.
+// SSTEMR returns the number m of eigenvalues found, in ascending order in
+// w[:m]. For jobz V the orthonormal eigenvectors are returned in the first m
+// columns of Z, which has n columns, or iu-il+1 for rng I; eigenvector j is
+// nonzero only in the rows isuppz[2*j] through isuppz[2*j+1]. If tryrac is
+// true and T defines its eigenvalues to high relative accuracy, they are
+// computed to that accuracy; the returned rac reports whether this was
+// done. d and e are destroyed. work holds 18*n elements and iwork 10*n.
+func (impl Implementation) SSTEMR(jobz JobZ, rng Range, n int, d, e []float32, vl, vu float32, il, iu int, w, z []float32, ldz int, isuppz []int, tryrac bool, work []float32, iwork []int) (m int, rac bool, err error) {
+	if err := checkStemr("SSTEMR", jobz, rng, n, len(d), len(e), float64(vl), float64(vu), il, iu, len(w), len(z), ldz, len(isuppz), len(work), len(iwork)); err != nil {
+		return 0, false, err
+	}
+	m, rac = stemr(impl.bl(), jobz, rng, n, d, e, float64(vl), float64(vu), il, iu, w, z, ldz, isuppz, tryrac, work, iwork)
+	return m, rac, nil
+}
+
+// DSTEMR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n real symmetric tridiagonal matrix T with diagonal d and off-diagonal e
+// by the algorithm of multiple relatively robust representations (MRRR).
+// rng A selects all the eigenvalues, rng V those in the half-open interval
+// (vl,vu], and rng I those il through iu in ascending order, counted from 0.
+// DSTEMR returns the number m of eigenvalues found, in ascending order in
+// w[:m]. For jobz V the orthonormal eigenvectors are returned in the first m
+// columns of Z, which has n columns, or iu-il+1 for rng I; eigenvector j is
+// nonzero only in the rows isuppz[2*j] through isuppz[2*j+1]. If tryrac is
+// true and T defines its eigenvalues to high relative accuracy, they are
+// computed to that accuracy; the returned rac reports whether this was
+// done. d and e are destroyed. work holds 18*n elements and iwork 10*n.
+func (impl Implementation) DSTEMR(jobz JobZ, rng Range, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, iwork []int) (m int, rac bool, err error) {
+	if err := checkStemr("DSTEMR", jobz, rng, n, len(d), len(e), vl, vu, il, iu, len(w), len(z), ldz, len(isuppz), len(work), len(iwork)); err != nil {
+		return 0, false, err
+	}
+	m, rac = stemr(impl.bl(), jobz, rng, n, d, e, vl, vu, il, iu, w, z, ldz, isuppz, tryrac, work, iwork)
+	return m, rac, nil
+}
+
+// CSTEMR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n real symmetric tridiagonal matrix T with diagonal d and off-diagonal e
+// as SSTEMR does, returning the eigenvectors in the complex matrix Z. It is
+// used for Hermitian matrices reduced to real tridiagonal form by CHETRD.
+func (impl Implementation) CSTEMR(jobz JobZ, rng Range, n int, d, e []float32, vl, vu float32, il, iu int, w []float32, z []complex64, ldz int, isuppz []int, tryrac bool, work []float32, iwork []int) (m int, rac bool, err error) {
+	if err := checkStemr("CSTEMR", jobz, rng, n, len(d), len(e), float64(vl), float64(vu), il, iu, len(w), len(z), ldz, len(isuppz), len(work), len(iwork)); err != nil {
+		return 0, false, err
+	}
+	m, rac = stemr(impl.bl(), jobz, rng, n, d, e, float64(vl), float64(vu), il, iu, w, z, ldz, isuppz, tryrac, work, iwork)
+	return m, rac, nil
+}
+
+// ZSTEMR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n real symmetric tridiagonal matrix T with diagonal d and off-diagonal e
+// as DSTEMR does, returning the eigenvectors in the complex matrix Z. It is
+// used for Hermitian matrices reduced to real tridiagonal form by ZHETRD.
+func (impl Implementation) ZSTEMR(jobz JobZ, rng Range, n int, d, e []float64, vl, vu float64, il, iu int, w []float64, z []complex128, ldz int, isuppz []int, tryrac bool, work []float64, iwork []int) (m int, rac bool, err error) {
+	if err := checkStemr("ZSTEMR", jobz, rng, n, len(d), len(e), vl, vu, il, iu, len(w), len(z), ldz, len(isuppz), len(work), len(iwork)); err != nil {
+		return 0, false, err
+	}
+	m, rac = stemr(impl.bl(), jobz, rng, n, d, e, vl, vu, il, iu, w, z, ldz, isuppz, tryrac, work, iwork)
+	return m, rac, nil
+}
+
+// stemrMinRelGap is the relative gap below which stemr treats neighbouring
+// eigenvalues as a cluster, the value of MINRGP in xLARRV.
+const stemrMinRelGap = 1e-3
+
+// stemrMaxGrowth bounds the element growth of a child representation in
+// units of the spectral diameter, MAXGROWTH1 of xLARRF.
+const stemrMaxGrowth = 8
+
+// stemrPert is the size, in units of eps, of the random relative
+// perturbation of the root representation, PERT of xLARRE. It separates
+// eigenvalues that agree to working precision, as those of glued matrices
+// do, so that the representation tree can resolve them.
+const stemrPert = 8
+
+// stemrMaxDepth bounds the depth of the representation tree. The
+// eigenvectors of a cluster still unresolved below it are computed by
+// inverse iteration with reorthogonalization, as xSTEIN does.
+const stemrMaxDepth = 10
+
+// steinMaxIter and steinExtra are the maximum number of inverse iterations
+// of a vector and the number of extra iterations made once it has grown
+// enough, MAXITS and EXTRA of xSTEIN.
+const (
+	steinMaxIter = 5
+	steinExtra   = 2
+)
+
+// checkStemr checks the STEMR routines.
+func checkStemr(routine string, jobz JobZ, rng Range, n, lenD, lenE int, vl, vu float64, il, iu, lenW, lenZ, ldz, lenIsuppz, lenWork, lenIwork int) error {
+	c := checker{routine: routine}
+	c.jobz(1, jobz)
+	c.rng(2, rng)
+	c.nonNeg(3, "n", n)
+	switch {
+	case rng == RangeV && n > 0 && vu <= vl:
+		c.fail(7, "vu", "must be greater than vl")
+	case rng == RangeI && (il < 0 || il > max(0, n-1)):
+		c.fail(8, "il", "must be in [0,max(0,n-1)]")
+	case rng == RangeI && (iu < min(n-1, il) || iu > n-1):
+		c.fail(9, "iu", "must be in [min(il,n-1),n-1]")
+	}
+	if jobz == JobZV {
+		c.ld(12, "ldz", ldz, n, "n")
+	} else {
+		c.atLeast(12, "ldz", ldz, 1, "1")
+	}
+	if c.ok() {
+		c.length(4, "d", lenD, n)
+		c.length(5, "e", lenE, n-1)
+		c.length(10, "w", lenW, n)
+		if jobz == JobZV {
+			ncol := n
+			if rng == RangeI {
+				ncol = iu - il + 1
+			}
+			c.length(11, "z", lenZ, matLen(n, ncol, ldz))
+			c.length(13, "isuppz", lenIsuppz, 2*ncol)
+		}
+		c.length(15, "work", lenWork, 18*n)
+		c.length(16, "iwork", lenIwork, 10*n)
+	}
+	return c.result()
+}
+
+// stemr computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n real symmetric tridiagonal matrix T with diagonal d and off-diagonal e
+// by the algorithm of multiple relatively robust representations of
+// xSTEMR. T is scaled and split into unreduced blocks as in xLARRA, and the
+// wanted eigenvalues of each block are selected by Sturm counts. Without
+// eigenvectors they are computed by bisection; with them, larrv computes
+// both from a representation tree. If tryrac and T passes the test of
+// larrr, the eigenvalues are finally refined by bisection on T to full
+// relative accuracy, as xLARRJ does. stemr returns the number m of
+// eigenvalues found, in ascending order in w with their eigenvectors in Z,
+// and whether they are relatively accurate. d and e are destroyed. work
+// holds 18*n elements and iwork 10*n.
+func stemr[T gen.Scalar, R gen.Float](bl blas.BLAS, jobz JobZ, rng Range, n int, d, e []R, vl, vu float64, il, iu int, w []R, z []T, ldz int, isuppz []int, tryrac bool, work []R, iwork []int) (m int, rac bool) {
+	wantz := jobz == JobZV
+	if n == 0 {
+		return 0, tryrac
+	}
+	if n == 1 {
+		if rng != RangeV || (vl < float64(d[0]) && float64(d[0]) <= vu) {
+			w[0] = d[0]
+			if wantz {
+				z[0] = 1
+				isuppz[0], isuppz[1] = 0, 0
+			}
+			m = 1
+		}
+		return m, tryrac
+	}
+
+	eps := eps[R]()
+	safmin := safmin[R]()
+	smlnum := safmin / eps
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(1/smlnum), 1/math.Sqrt(math.Sqrt(safmin)))
+	scale := 1.0
+	tnrm := lanst(normMax, n, d, e)
+	if tnrm > 0 && tnrm < rmin {
+		scale = rmin / tnrm
+	} else if tnrm > rmax {
+		scale = rmax / tnrm
+	}
+	if scale != 1 {
+		lascl(uploAll, 1, scale, n, 1, d, n)
+		lascl(uploAll, 1, scale, n-1, 1, e, n-1)
+		tnrm *= scale
+		vl *= scale
+		vu *= scale
+	}
+	rac = tryrac && larrr(n, d, e)
+
+	// Split T into unreduced blocks, relative to the diagonal if T warrants
+	// relative accuracy and to its norm otherwise. Block b ends at row
+	// blkEnd[b].
+	e2 := work[:n]
+	blkEnd, cl, cu := iwork[:n], iwork[n:2*n], iwork[2*n:3*n]
+	nblk := 0
+	pivmin := 1.0
+	for i := 0; i < n-1; i++ {
+		tiny := eps * tnrm
+		if rac {
+			tiny = eps * math.Sqrt(math.Abs(float64(d[i]))) * math.Sqrt(math.Abs(float64(d[i+1])))
+		}
+		if math.Abs(float64(e[i])) <= tiny {
+			e[i] = 0
+			blkEnd[nblk] = i
+			nblk++
+		}
+		e2[i] = e[i] * e[i]
+		pivmin = math.Max(pivmin, float64(e2[i]))
+	}
+	e2[n-1] = 0
+	blkEnd[nblk] = n - 1
+	nblk++
+	pivmin *= safmin
+	atol := 2 * eps * tnrm
+	if rac {
+		atol = 4 * pivmin
+	}
+	start := func(b int) int {
+		if b == 0 {
+			return 0
+		}
+		return blkEnd[b-1] + 1
+	}
+	blkCount := func(b int, x float64) int {
+		ib := start(b)
+		return sturmCount(blkEnd[b]-ib+1, d[ib:], e2[ib:], x, pivmin)
+	}
+
+	// Select the wanted eigenvalues cl[b] through cu[b]-1 of each block b.
+	switch rng {
+	case RangeA:
+		for b := 0; b < nblk; b++ {
+			cl[b], cu[b] = 0, blkEnd[b]-start(b)+1
+		}
+	case RangeV:
+		for b := 0; b < nblk; b++ {
+			cl[b], cu[b] = blkCount(b, vl), blkCount(b, vu)
+		}
+	case RangeI:
+		// Bracket eigenvalues il and iu of T and count the eigenvalues of
+		// each block below the brackets. Eigenvalues inside a bracket are
+		// tied; take those needed from the first blocks for il and drop
+		// the others from the last blocks for iu.
+		count := func(x float64) int { return sturmCount(n, d, e2, x, pivmin) }
+		gl, gu := gersch(n, d, e, eps, pivmin)
+		lolo, lohi := bisectEig(count, il, gl, gu, 2*eps, atol)
+		hilo, hihi := bisectEig(count, iu, gl, gu, 2*eps, atol)
+		nlo, nhi := 0, 0
+		for b := 0; b < nblk; b++ {
+			cl[b], cu[b] = blkCount(b, lolo), blkCount(b, hihi)
+			nlo += cl[b]
+			nhi += cu[b]
+		}
+		for b := 0; b < nblk && nlo < il; b++ {
+			t := min(blkCount(b, lohi)-cl[b], il-nlo)
+			cl[b] += t
+			nlo += t
+		}
+		for b := nblk - 1; b >= 0 && nhi > iu+1; b-- {
+			t := min(cu[b]-blkCount(b, hilo), nhi-iu-1)
+			cu[b] -= t
+			nhi -= t
+		}
+	}
+
+	for b := 0; b < nblk; b++ {
+		ib, ie := start(b), blkEnd[b]
+		nb := ie - ib + 1
+		k := cu[b] - cl[b]
+		if k <= 0 {
+			continue
+		}
+		db, eb, e2b := d[ib:ie+1], e[ib:ie], e2[ib:ie]
+		count := func(x float64) int { return sturmCount(nb, db, e2b, x, pivmin) }
+		switch {
+		case nb == 1:
+			w[m] = d[ib]
+			if wantz {
+				for i := 0; i < n; i++ {
+					z[i+m*ldz] = 0
+				}
+				z[ib+m*ldz] = 1
+				isuppz[2*m], isuppz[2*m+1] = ib, ib
+			}
+		case !wantz:
+			gl, gu := gersch(nb, db, eb, eps, pivmin)
+			for j := cl[b]; j < cu[b]; j++ {
+				lo, hi := bisectEig(count, j, gl, gu, 2*eps, atol)
+				w[m+j-cl[b]] = R(0.5 * (lo + hi))
+			}
+		default:
+			larrv(nb, ib, n, db, eb, e2b, cl[b], cu[b], pivmin, w[m:], z[m*ldz:], ldz, isuppz[2*m:], work[n:], iwork[3*n:])
+			if rac {
+				for t := 0; t < k; t++ {
+					x := float64(w[m+t])
+					lo, hi := bracketEig(count, cl[b]+t, x, 4*eps*math.Abs(x)+atol)
+					lo, hi = bisectEig(count, cl[b]+t, lo, hi, 2*eps, atol)
+					w[m+t] = R(0.5 * (lo + hi))
+				}
+			}
+		}
+		m += k
+	}
+
+	if scale != 1 {
+		lascl(uploAll, scale, 1, m, 1, w, m)
+	}
+	// Sort the eigenvalues of the blocks, with their eigenvectors, into
+	// ascending order. The eigenvalues of one block are in order but for
+	// those of a cluster, which the representations resolve and the
+	// refinement bisects one at a time, and which may come out of order by
+	// a few ulps.
+	if m > 1 {
+		for i := 0; i < m-1; i++ {
+			p := i
+			for j := i + 1; j < m; j++ {
+				if w[j] < w[p] {
+					p = j
+				}
+			}
+			if p == i {
+				continue
+			}
+			w[i], w[p] = w[p], w[i]
+			if wantz {
+				swap(bl, n, z[i*ldz:], 1, z[p*ldz:], 1)
+				isuppz[2*i], isuppz[2*p] = isuppz[2*p], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*p+1] = isuppz[2*p+1], isuppz[2*i+1]
+			}
+		}
+	}
+	return m, rac
+}
+
+// larrr reports whether the n×n symmetric tridiagonal matrix T with
+// diagonal d and off-diagonal e, n >= 2, defines its eigenvalues to high
+// relative accuracy by the test of xLARRR: T is scaled diagonally dominant
+// with a margin, so that small relative perturbations of its elements make
+// small relative perturbations of its eigenvalues.
+func larrr[R gen.Float](n int, d, e []R) bool {
+	const relcond = 0.999
+	rmin := math.Sqrt(safmin[R]() / eps[R]())
+	tmp := math.Sqrt(math.Abs(float64(d[0])))
+	if tmp < rmin {
+		return false
+	}
+	var offdig float64
+	for i := 1; i < n; i++ {
+		tmp2 := math.Sqrt(math.Abs(float64(d[i])))
+		if tmp2 < rmin {
+			return false
+		}
+		offdig2 := math.Abs(float64(e[i-1])) / (tmp * tmp2)
+		if offdig+offdig2 >= relcond {
+			return false
+		}
+		tmp, offdig = tmp2, offdig2
+	}
+	return true
+}
+
+// gersch returns the bounds gl <= gu of the union of the Gerschgorin
+// intervals of the n×n symmetric tridiagonal matrix with diagonal d and
+// off-diagonal e, widened as in xLARRD so that a Sturm count at gl is 0
+// and at gu is n.
+func gersch[R gen.Float](n int, d, e []R, eps, pivmin float64) (gl, gu float64) {
+	gl, gu = math.Inf(1), math.Inf(-1)
+	for i := 0; i < n; i++ {
+		r := 0.0
+		if i > 0 {
+			r += math.Abs(float64(e[i-1]))
+		}
+		if i < n-1 {
+			r += math.Abs(float64(e[i]))
+		}
+		gl = math.Min(gl, float64(d[i])-r)
+		gu = math.Max(gu, float64(d[i])+r)
+	}
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	gl -= 2*tnorm*eps*float64(n) + 4*pivmin
+	gu += 2*tnorm*eps*float64(n) + 2*pivmin
+	return gl, gu
+}
+
+// sturmCount returns the number of eigenvalues less than x of the n×n
+// symmetric tridiagonal matrix with diagonal d and squared off-diagonal e2,
+// the number of negative pivots of the LDL**T factorization of T - x*I, as
+// xLARRC. Pivots smaller than pivmin in magnitude are replaced by -pivmin.
+func sturmCount[R gen.Float](n int, d, e2 []R, x, pivmin float64) int {
+	var cnt int
+	q := float64(d[0]) - x
+	for i := 0; ; {
+		if math.Abs(q) <= pivmin {
+			q = -pivmin
+		}
+		if q < 0 {
+			cnt++
+		}
+		if i++; i == n {
+			return cnt
+		}
+		q = float64(d[i]) - x - float64(e2[i-1])/q
+	}
+}
+
+// laneg returns the number of eigenvalues less than x of the representation
+// L*D*L**T of order n, where L is unit lower bidiagonal with subdiagonal l,
+// the number of negative pivots of its stationary qd transform by the shift
+// x, as xLANEG. Pivots smaller than pivmin in magnitude are replaced by
+// -pivmin.
+func laneg[R gen.Float](n int, d, l []R, x, pivmin float64) int {
+	var neg int
+	t := -x
+	for i := 0; ; {
+		dplus := float64(d[i]) + t
+		if math.Abs(dplus) < pivmin {
+			dplus = -pivmin
+		}
+		if dplus < 0 {
+			neg++
+		}
+		if i == n-1 {
+			return neg
+		}
+		li := float64(l[i])
+		t = t/dplus*float64(d[i])*li*li - x
+		i++
+	}
+}
+
+// bisectEig narrows by bisection the interval [lo,hi] that holds the
+// eigenvalue j, counted from 0, of a matrix whose count(x) is the number
+// of its eigenvalues less than x, so that count(lo) <= j < count(hi). It
+// stops when the interval is no wider than max(atol,rtol*max(|lo|,|hi|)) or
+// cannot be split further.
+func bisectEig(count func(float64) int, j int, lo, hi, rtol, atol float64) (float64, float64) {
+	for {
+		mid := 0.5 * (lo + hi)
+		if hi-lo <= max(atol, rtol*max(math.Abs(lo), math.Abs(hi))) || mid <= lo || mid >= hi {
+			return lo, hi
+		}
+		if count(mid) > j {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+}
+
+// bracketEig returns an interval [lo,hi] around x that holds the eigenvalue
+// j of a matrix with the eigenvalue count count, as bisectEig needs,
+// starting from the half-width r and doubling it as needed.
+func bracketEig(count func(float64) int, j int, x, r float64) (lo, hi float64) {
+	r = max(r, math.SmallestNonzeroFloat64)
+	lo = x - r
+	for i := 0; i < 2100 && count(lo) > j; i++ {
+		r *= 2
+		lo = x - r
+	}
+	r = max(r, math.SmallestNonzeroFloat64)
+	hi = x + r
+	for i := 0; i < 2100 && count(hi) <= j; i++ {
+		r *= 2
+		hi = x + r
+	}
+	return lo, hi
+}
+
+// larrv computes the eigenvalues cl through cu-1 of the unreduced nb×nb
+// block of T that starts at row ib, with diagonal d, off-diagonal e and
+// squared off-diagonal e2, and their eigenvectors of length n in the
+// columns of Z, by the representation tree of xLARRV. The root
+// representation L*D*L**T = T - sigma*I is definite, shifted just beyond
+// the end of the spectrum nearer the wanted eigenvalues. The eigenvalues of
+// a representation are refined by bisection to full relative accuracy and
+// split into clusters at relative gaps of stemrMinRelGap: an isolated
+// eigenvalue gets its eigenvector from a twisted factorization (lar1v),
+// and a cluster gets a child representation shifted next to it (larrf),
+// which is kept in the first two columns of Z the cluster will occupy until
+// it is processed. Gaps between the eigenvalues are absolute and so are
+// shared by the representations. w receives the eigenvalues and isuppz the
+// supports of the eigenvectors. work holds 9*n+1 elements and iwork 4*n.
+func larrv[T gen.Scalar, R gen.Float](nb, ib, n int, d, e, e2 []R, cl, cu int, pivmin float64, w []R, z []T, ldz int, isuppz []int, work []R, iwork []int) {
+	k := cu - cl
+	eps := eps[R]()
+	werr, gap := work[:n], work[n:2*n+1]
+	drep, lrep := work[2*n+1:3*n+1], work[3*n+1:4*n+1]
+	scratch := work[4*n+1:]
+	queue, piv := iwork[:3*n], iwork[3*n:4*n]
+	gl, gu := gersch(nb, d, e, eps, pivmin)
+	spdiam := gu - gl
+
+	// Choose the root shift outside the spectrum by bisection for its end
+	// eigenvalue, moving away until the factorization is definite, and
+	// perturb the factorization by a few ulps.
+	count := func(x float64) int { return sturmCount(nb, d, e2, x, pivmin) }
+	left := cl+cu <= nb
+	var sigma, delta float64
+	if left {
+		lo, hi := bisectEig(count, 0, gl, gu, 2*eps, pivmin)
+		sigma, delta = lo, max(hi-lo, 4*eps*math.Abs(lo), 4*pivmin)
+	} else {
+		lo, hi := bisectEig(count, nb-1, gl, gu, 2*eps, pivmin)
+		sigma, delta = hi, -max(hi-lo, 4*eps*math.Abs(hi), 4*pivmin)
+	}
+	for try := 0; ; try++ {
+		sigma -= delta
+		delta *= 2
+		if rootRep(nb, d, e, sigma, left, drep, lrep) || try == 100 {
+			break
+		}
+	}
+	seed := uint32(ib+1) * 2654435761
+	for i := 0; i < nb; i++ {
+		seed = seed*1664525 + 1013904223
+		drep[i] *= R(1 + stemrPert*eps*(float64(seed>>8)/(1<<23)-1))
+		if i < nb-1 {
+			seed = seed*1664525 + 1013904223
+			lrep[i] *= R(1 + stemrPert*eps*(float64(seed>>8)/(1<<23)-1))
+		}
+	}
+
+	// Bracket the wanted eigenvalues of the root, and bound the outer gaps
+	// by the neighbouring eigenvalues or by the spectral diameter.
+	for t := 0; t < k; t++ {
+		w[t] = R(0.5*(gl+gu) - sigma)
+		werr[t] = R(0.5 * spdiam)
+	}
+	cnt := func(x float64) int { return laneg(nb, drep, lrep, x, pivmin) }
+	larrvRefine(cnt, 0, k, cl, w, werr, gap, eps, pivmin)
+	gap[0], gap[k] = R(spdiam), R(spdiam)
+	if cl > 0 {
+		lo, hi := bracketEig(cnt, cl-1, 0.5*(gl+gu)-sigma, spdiam)
+		_, hi = bisectEig(cnt, cl-1, lo, hi, 2*eps, pivmin)
+		gap[0] = R(max(0, float64(w[0]-werr[0])-hi))
+	}
+	if cu < nb {
+		lo, hi := bracketEig(cnt, cu, 0.5*(gl+gu)-sigma, spdiam)
+		lo, _ = bisectEig(cnt, cu, lo, hi, 2*eps, pivmin)
+		gap[k] = R(max(0, lo-float64(w[k-1]+werr[k-1])))
+	}
+
+	// Process the representations breadth first. A node of the tree is a
+	// range [f,l) of the wanted eigenvalues with the representation drep,
+	// lrep and the shift sigma from T, and its depth; the root is refined
+	// above.
+	head, tail := 0, 0
+	f, l, depth := 0, k, 0
+	for {
+		// Split the node into clusters at large relative gaps.
+		for s := f; s < l; {
+			t := s + 1
+			for t < l && float64(gap[t]) < stemrMinRelGap*max(math.Abs(float64(w[t-1])), math.Abs(float64(w[t]))) {
+				t++
+			}
+			switch {
+			case t-s == 1:
+				lambda := lar1vRefine(nb, drep, lrep, float64(w[s]), float64(werr[s]), float64(min(gap[s], gap[s+1])), pivmin, eps, scratch)
+				isuppz[2*s], isuppz[2*s+1] = larrvStore(nb, ib, n, scratch[4*nb:5*nb], z[s*ldz:])
+				w[s] = R(lambda + sigma)
+			case depth < stemrMaxDepth && larrf(nb, drep, lrep, s, t, w, werr, gap, spdiam, pivmin, eps, scratch):
+				// Keep the child representation and its shift from T in
+				// columns s and s+1 of Z.
+				tau := float64(scratch[2*nb-1])
+				for i := 0; i < nb; i++ {
+					z[ib+i+s*ldz] = fromReal[T](float64(scratch[i]))
+				}
+				for i := 0; i < nb-1; i++ {
+					z[ib+i+(s+1)*ldz] = fromReal[T](float64(scratch[nb+i]))
+				}
+				z[ib+nb-1+(s+1)*ldz] = fromReal[T](sigma + tau)
+				for j := s; j < t; j++ {
+					w[j] = R(float64(w[j]) - tau)
+				}
+				queue[3*tail], queue[3*tail+1], queue[3*tail+2] = s, t, depth+1
+				tail++
+			default:
+				for j := s; j < t; j++ {
+					w[j] = R(float64(w[j]) + sigma)
+				}
+				stein(nb, ib, n, d, e, w, s, t, z, ldz, isuppz, eps, scratch, piv)
+			}
+			s = t
+		}
+		if head == tail {
+			return
+		}
+		// Load the next cluster and refine its eigenvalues in its own
+		// representation.
+		f, l, depth = queue[3*head], queue[3*head+1], queue[3*head+2]
+		head++
+		for i := 0; i < nb; i++ {
+			drep[i] = R(re(z[ib+i+f*ldz]))
+		}
+		for i := 0; i < nb-1; i++ {
+			lrep[i] = R(re(z[ib+i+(f+1)*ldz]))
+		}
+		sigma = re(z[ib+nb-1+(f+1)*ldz])
+		larrvRefine(cnt, f, l, cl, w, werr, gap, eps, pivmin)
+	}
+}
+
+// rootRep factors T - sigma*I = L*D*L**T for the nb×nb tridiagonal T with
+// diagonal d and off-diagonal e into drep and lrep, and reports whether the
+// factorization is positive definite (left) or negative definite.
+func rootRep[R gen.Float](nb int, d, e []R, sigma float64, left bool, drep, lrep []R) bool {
+	di := float64(d[0]) - sigma
+	for i := 0; ; i++ {
+		drep[i] = R(di)
+		if left && !(drep[i] > 0) || !left && !(drep[i] < 0) || math.IsInf(di, 0) {
+			return false
+		}
+		if i == nb-1 {
+			return true
+		}
+		li := float64(e[i]) / di
+		lrep[i] = R(li)
+		di = float64(d[i+1]) - sigma - li*float64(e[i])
+	}
+}
+
+// larrvRefine refines by bisection in the representation with eigenvalue
+// count cnt the eigenvalues f through l-1 of a node, which have the
+// indices cl+f through cl+l-1 in it, from the approximations w and error
+// bounds werr, and updates the gaps between them.
+func larrvRefine[R gen.Float](cnt func(float64) int, f, l, cl int, w, werr, gap []R, eps, pivmin float64) {
+	for j := f; j < l; j++ {
+		lo, hi := bracketEig(cnt, cl+j, float64(w[j]), float64(werr[j]))
+		lo, hi = bisectEig(cnt, cl+j, lo, hi, 2*eps, pivmin)
+		w[j], werr[j] = R(0.5*(lo+hi)), R(0.5*(hi-lo))
+	}
+	for j := f + 1; j < l; j++ {
+		gap[j] = R(max(0, float64(w[j]-werr[j]-(w[j-1]+werr[j-1]))))
+	}
+}
+
+// larrf computes a child representation L+*D+*L+**T = L*D*L**T - tau*I of
+// the nb×nb representation with diagonal d and subdiagonal l for the
+// cluster of eigenvalues s through t-1, with tau at one of its ends, as
+// xLARRF. The shifts are moved out into the gaps around the cluster until
+// the element growth of the stationary qd transform is at most
+// stemrMaxGrowth times the spectral diameter; failing that, the shift with
+// the least growth is taken if the growth still leaves the cluster
+// separated from its neighbours. D+, L+ and tau are returned in the first
+// 2*nb elements of scratch, tau last, and larrf reports whether a
+// representation was found.
+func larrf[R gen.Float](nb int, d, l []R, s, t int, w, werr, gap []R, spdiam, pivmin, eps float64, scratch []R) bool {
+	clwdth := math.Abs(float64(w[t-1]-w[s])) + float64(werr[s]+werr[t-1])
+	avgap := clwdth / float64(t-s-1)
+	mingap := float64(min(gap[s], gap[t]))
+	dmax := 0.25*mingap + 2*pivmin
+	lsigma := float64(w[s] - werr[s])
+	rsigma := float64(w[t-1] + werr[t-1])
+	lsigma -= 4 * eps * math.Abs(lsigma)
+	rsigma += 4 * eps * math.Abs(rsigma)
+	ldelta := 0.5 * max(avgap, float64(gap[s+1]))
+	rdelta := 0.5 * max(avgap, float64(gap[t-1]))
+
+	dp, lp := scratch[:nb], scratch[nb:2*nb]
+	cand := scratch[2*nb : 4*nb]
+	limit := stemrMaxGrowth * spdiam
+	best, bestGrowth := 0.0, math.Inf(1)
+	for k := 0; k < 2; k++ {
+		ltau := float64(R(lsigma))
+		growth := stqds(nb, d, l, ltau, dp, lp)
+		if growth <= limit {
+			lp[nb-1] = R(ltau)
+			return true
+		}
+		if growth < bestGrowth {
+			best, bestGrowth = ltau, growth
+		}
+		rtau := float64(R(rsigma))
+		growth = stqds(nb, d, l, rtau, cand[:nb], cand[nb:])
+		if growth <= limit {
+			copy(dp, cand[:nb])
+			copy(lp[:nb-1], cand[nb:])
+			lp[nb-1] = R(rtau)
+			return true
+		}
+		if growth < bestGrowth {
+			best, bestGrowth = rtau, growth
+		}
+		lsigma -= min(ldelta, dmax)
+		rsigma += min(rdelta, dmax)
+		ldelta *= 2
+		rdelta *= 2
+	}
+	// Settle for the smallest growth unless it is too large for the
+	// eigenvalues of the cluster to be told apart from its neighbours.
+	if bestGrowth >= float64(nb-1)*mingap/(spdiam*eps) {
+		return false
+	}
+	stqds(nb, d, l, best, dp, lp)
+	lp[nb-1] = R(best)
+	return true
+}
+
+// stqds computes the stationary qd transform L+*D+*L+**T = L*D*L**T - tau*I
+// of the nb×nb representation with diagonal d and subdiagonal l into dp and
+// lp, as the stationary qd step of xLARRF, and returns the largest magnitude of D+, or
+// +Inf if an element is not finite.
+func stqds[R gen.Float](nb int, d, l []R, tau float64, dp, lp []R) float64 {
+	var growth float64
+	s := -tau
+	for i := 0; i < nb-1; i++ {
+		di, li := float64(d[i]), float64(l[i])
+		dplus := di + s
+		lplus := di * li / dplus
+		dp[i], lp[i] = R(dplus), R(lplus)
+		s = s*lplus*li - tau
+		growth = max(growth, math.Abs(dplus))
+		if math.IsInf(dplus, 0) || math.IsNaN(lplus) || math.IsInf(lplus, 0) {
+			return math.Inf(1)
+		}
+	}
+	dp[nb-1] = R(float64(d[nb-1]) + s)
+	growth = max(growth, math.Abs(float64(dp[nb-1])))
+	if math.IsNaN(growth) || math.IsInf(float64(dp[nb-1]), 0) {
+		return math.Inf(1)
+	}
+	return growth
+}
+
+// lar1vRefine computes the eigenvector of the isolated eigenvalue lambda,
+// with error bound werr and absolute gap gap to its neighbours, of the
+// nb×nb representation with diagonal d and subdiagonal l by lar1v, and
+// improves lambda by Rayleigh quotient corrections that stay within its
+// error bound. The unnormalized eigenvector is left in scratch[4*nb:5*nb];
+// lar1vRefine returns the final lambda.
+func lar1vRefine[R gen.Float](nb int, d, l []R, lambda, werr, gap, pivmin, eps float64, scratch []R) float64 {
+	for iter := 0; ; iter++ {
+		ztz, mingma := lar1v(nb, d, l, lambda, pivmin, gap*eps, scratch)
+		rqcorr := mingma / ztz
+		next := lambda + rqcorr
+		if iter == 2 || math.Abs(rqcorr) <= 4*eps*math.Abs(lambda) || math.Abs(next-lambda) > werr {
+			return lambda
+		}
+		lambda = next
+	}
+}
+
+// lar1v computes the eigenvector z of the nb×nb representation L*D*L**T
+// for the approximate eigenvalue lambda from the twisted factorization of
+// L*D*L**T - lambda*I = N(r)*gamma(r)*N(r)**T whose twist index r minimizes
+// |gamma(r)|, as xLAR1V. z[r] = 1 and the other elements follow from the
+// stationary and progressive qd transforms; elements after which the
+// product with the factors falls below gaptol are set to zero. scratch
+// holds 5*nb elements: lar1v leaves z in the last nb and returns z**T*z and
+// gamma(r).
+func lar1v[R gen.Float](nb int, d, l []R, lambda, pivmin, gaptol float64, scratch []R) (ztz, mingma float64) {
+	lplus, uminus := scratch[:nb], scratch[nb:2*nb]
+	s, p, z := scratch[2*nb:3*nb], scratch[3*nb:4*nb], scratch[4*nb:5*nb]
+
+	// Stationary transform L*D*L**T - lambda*I = L+*D+*L+**T, with s[i] the
+	// auxiliary quantity before subtracting lambda.
+	sv := 0.0
+	s[0] = 0
+	for i := 0; i < nb-1; i++ {
+		di, li := float64(d[i]), float64(l[i])
+		dplus := di + sv - lambda
+		if math.Abs(dplus) < pivmin {
+			dplus = -pivmin
+		}
+		lp := di * li / dplus
+		lplus[i] = R(lp)
+		sv = (sv - lambda) * lp * li
+		s[i+1] = R(sv)
+	}
+	// Progressive transform L*D*L**T - lambda*I = U-*D-*U-**T, with p[i]
+	// the auxiliary quantity.
+	pv := float64(d[nb-1]) - lambda
+	p[nb-1] = R(pv)
+	for i := nb - 2; i >= 0; i-- {
+		di, li := float64(d[i]), float64(l[i])
+		dminus := di*li*li + pv
+		if math.Abs(dminus) < pivmin {
+			dminus = -pivmin
+		}
+		tmp := di / dminus
+		uminus[i] = R(li * tmp)
+		pv = pv*tmp - lambda
+		p[i] = R(pv)
+	}
+	// Choose the twist index r with the smallest |gamma(r)|.
+	r := nb - 1
+	mingma = float64(s[r]) + float64(p[r])
+	for i := nb - 2; i >= 0; i-- {
+		if g := float64(s[i]) + float64(p[i]); math.Abs(g) < math.Abs(mingma) || math.IsNaN(mingma) {
+			r, mingma = i, g
+		}
+	}
+
+	// Solve N(r)*gamma(r)*N(r)**T*z = gamma(r)*e(r).
+	for i := range z {
+		z[i] = 0
+	}
+	z[r] = 1
+	ztz = 1
+	for i := r - 1; i >= 0; i-- {
+		zi := -float64(lplus[i]) * float64(z[i+1])
+		if (math.Abs(zi)+math.Abs(float64(z[i+1])))*math.Abs(float64(d[i])*float64(l[i])) < gaptol {
+			break
+		}
+		z[i] = R(zi)
+		ztz += zi * zi
+	}
+	for i := r; i < nb-1; i++ {
+		zi := -float64(uminus[i]) * float64(z[i])
+		if (math.Abs(zi)+math.Abs(float64(z[i])))*math.Abs(float64(d[i])*float64(l[i])) < gaptol {
+			break
+		}
+		z[i+1] = R(zi)
+		ztz += zi * zi
+	}
+	return ztz, mingma
+}
+
+// larrvStore normalizes the eigenvector v of an nb×nb block that starts at
+// row ib and stores it in the column zc of length n, zero outside the
+// block, and returns the first and last rows of its support.
+func larrvStore[T gen.Scalar, R gen.Float](nb, ib, n int, v []R, zc []T) (first, last int) {
+	var ss float64
+	first, last = -1, -1
+	for i := 0; i < nb; i++ {
+		if v[i] != 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+		ss += float64(v[i]) * float64(v[i])
+	}
+	scl := 1 / math.Sqrt(ss)
+	for i := 0; i < n; i++ {
+		zc[i] = 0
+	}
+	for i := first; i <= last; i++ {
+		zc[ib+i] = fromReal[T](scl * float64(v[i]))
+	}
+	return ib + first, ib + last
+}
+
+// stein computes by inverse iteration the eigenvectors for the eigenvalues
+// w[s] through w[t-1] of the nb×nb block that starts at row ib, with
+// diagonal d and off-diagonal e, as xSTEIN: the eigenvalues are perturbed
+// apart when too close, each vector starts from pseudorandom elements, and
+// the vectors are reorthogonalized against the preceding ones by modified
+// Gram-Schmidt. It is the fallback of larrv for clusters that the
+// representation tree does not resolve. scratch holds 5*nb elements and piv
+// nb.
+func stein[T gen.Scalar, R gen.Float](nb, ib, n int, d, e, w []R, s, t int, z []T, ldz int, isuppz []int, eps float64, scratch []R, piv []int) {
+	onenrm := lanst(normOne, nb, d, e)
+	eps3 := eps * onenrm
+	pertol := 10 * eps3
+	dtpcrt := math.Sqrt(0.1 / float64(nb))
+	x := scratch[4*nb : 5*nb]
+	var xjm float64
+	for j := s; j < t; j++ {
+		xj := float64(w[j])
+		if j > s && xj-xjm < pertol {
+			xj = xjm + pertol
+		}
+		xjm = xj
+		gtLU(nb, d, e, xj, scratch, piv)
+		seed := uint32(j+1) * 2654435761
+		for i := range x {
+			seed = seed*1664525 + 1013904223
+			x[i] = R(float64(seed>>8)/(1<<23) - 1)
+		}
+		for iter, nrmchk := 0, 0; iter < steinMaxIter; iter++ {
+			var asum float64
+			for _, v := range x {
+				asum += math.Abs(float64(v))
+			}
+			scl := float64(nb) * onenrm * max(eps, math.Abs(float64(scratch[nb-1]))) / asum
+			for i := range x {
+				x[i] = R(scl * float64(x[i]))
+			}
+			gtSolve(nb, scratch, piv, x, eps3)
+			for c := s; c < j; c++ {
+				col := z[ib+c*ldz:]
+				var dot float64
+				for i := 0; i < nb; i++ {
+					dot += re(col[i]) * float64(x[i])
+				}
+				for i := 0; i < nb; i++ {
+					x[i] = R(float64(x[i]) - dot*re(col[i]))
+				}
+			}
+			var nrm float64
+			for _, v := range x {
+				nrm = max(nrm, math.Abs(float64(v)))
+			}
+			if nrm < dtpcrt {
+				continue
+			}
+			if nrmchk++; nrmchk >= steinExtra+1 {
+				break
+			}
+		}
+		isuppz[2*j], isuppz[2*j+1] = larrvStore(nb, ib, n, x, z[j*ldz:])
+	}
+}
+
+// gtLU factors the nb×nb tridiagonal matrix T - lambda*I, with T given by
+// its diagonal d and off-diagonal e, by Gaussian elimination with partial
+// pivoting as xGTTRF. The diagonal of U is left in f[:nb], its first and
+// second superdiagonals in f[nb:2*nb] and f[2*nb:3*nb], the multipliers in
+// f[3*nb:4*nb], and piv[i] is 1 if rows i and i+1 were interchanged.
+func gtLU[R gen.Float](nb int, d, e []R, lambda float64, f []R, piv []int) {
+	dd, du, du2, dl := f[:nb], f[nb:2*nb], f[2*nb:3*nb], f[3*nb:4*nb]
+	for i := 0; i < nb; i++ {
+		dd[i] = R(float64(d[i]) - lambda)
+		du2[i] = 0
+	}
+	copy(du[:nb-1], e)
+	copy(dl[:nb-1], e)
+	for i := 0; i < nb-1; i++ {
+		if math.Abs(float64(dd[i])) >= math.Abs(float64(dl[i])) {
+			piv[i] = 0
+			if dd[i] != 0 {
+				fact := dl[i] / dd[i]
+				dl[i] = fact
+				dd[i+1] -= fact * du[i]
+			}
+			continue
+		}
+		piv[i] = 1
+		fact := dd[i] / dl[i]
+		dd[i], dl[i] = dl[i], fact
+		tmp := du[i]
+		du[i] = dd[i+1]
+		dd[i+1] = tmp - fact*dd[i+1]
+		if i < nb-2 {
+			du2[i] = du[i+1]
+			du[i+1] = -fact * du[i+1]
+		}
+	}
+}
+
+// gtSolve overwrites x by the solution of (T - lambda*I)*y = x with the
+// factorization of gtLU, replacing the pivots of U smaller than tol in
+// magnitude by tol.
+func gtSolve[R gen.Float](nb int, f []R, piv []int, x []R, tol float64) {
+	dd, du, du2, dl := f[:nb], f[nb:2*nb], f[2*nb:3*nb], f[3*nb:4*nb]
+	for i := 0; i < nb-1; i++ {
+		if piv[i] == 0 {
+			x[i+1] -= dl[i] * x[i]
+		} else {
+			x[i], x[i+1] = x[i+1], x[i]-dl[i]*x[i+1]
+		}
+	}
+	pivot := func(i int) float64 {
+		u := float64(dd[i])
+		if math.Abs(u) < tol {
+			u = math.Copysign(tol, u)
+		}
+		return u
+	}
+	for i := nb - 1; i >= 0; i-- {
+		v := float64(x[i])
+		if i < nb-1 {
+			v -= float64(du[i]) * float64(x[i+1])
+		}
+		if i < nb-2 {
+			v -= float64(du2[i]) * float64(x[i+2])
+		}
+		x[i] = R(v / pivot(i))
+	}
+}
@@ -0,0 +1,617 @@
+package lapack
+
+import (
+	"math"
+	"slices"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SSTERF computes the eigenvalues of the n×n real symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e by the root-free variant of the
+// QL or QR algorithm of Pal, Walker and Kahan. On return d holds the
+// eigenvalues in ascending order and e is destroyed. If the algorithm fails
+// to find all the eigenvalues in 30*n sweeps, SSTERF returns a
+// *ConvergenceError whose Info is the number of elements of e that did not
+// converge to zero.
+func (impl Implementation) SSTERF(n int, d, e []float32) error {
+	if err := checkSterf("SSTERF", n, len(d), len(e)); err != nil {
+		return err
+	}
+	return convergence("SSTERF", sterf(n, d, e))
+}
+
+// SSTEQR computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the implicit QL or QR algorithm. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the orthogonal matrix of a reduction to T, such as that formed from the
+// output of SSYTRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. On return d holds the eigenvalues in ascending order and e is
+// destroyed. work holds 2*n-2 elements unless compz is N, when it and Z are
+// not referenced. If the algorithm fails to find all the eigenvalues in 30*n
+// sweeps, SSTEQR returns a *ConvergenceError whose Info is the number of
+// elements of e that did not converge to zero.
+func (impl Implementation) SSTEQR(compz CompZ, n int, d, e []float32, z []float32, ldz int, work []float32) error {
+	if err := checkSteqr("SSTEQR", compz, n, len(d), len(e), len(z), ldz, len(work)); err != nil {
+		return err
+	}
+	return convergence("SSTEQR", steqr(impl.bl(), compz, n, d, e, z, ldz, work))
+}
+
+// DSTERF computes the eigenvalues of the n×n real symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e by the root-free variant of the
+// QL or QR algorithm of Pal, Walker and Kahan. On return d holds the
+// eigenvalues in ascending order and e is destroyed. If the algorithm fails
+// to find all the eigenvalues in 30*n sweeps, DSTERF returns a
+// *ConvergenceError whose Info is the number of elements of e that did not
+// converge to zero.
+func (impl Implementation) DSTERF(n int, d, e []float64) error {
+	if err := checkSterf("DSTERF", n, len(d), len(e)); err != nil {
+		return err
+	}
+	return convergence("DSTERF", sterf(n, d, e))
+}
+
+// DSTEQR computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the implicit QL or QR algorithm. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the orthogonal matrix of a reduction to T, such as that formed from the
+// output of DSYTRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. On return d holds the eigenvalues in ascending order and e is
+// destroyed. work holds 2*n-2 elements unless compz is N, when it and Z are
+// not referenced. If the algorithm fails to find all the eigenvalues in 30*n
+// sweeps, DSTEQR returns a *ConvergenceError whose Info is the number of
+// elements of e that did not converge to zero.
+func (impl Implementation) DSTEQR(compz CompZ, n int, d, e []float64, z []float64, ldz int, work []float64) error {
+	if err := checkSteqr("DSTEQR", compz, n, len(d), len(e), len(z), ldz, len(work)); err != nil {
+		return err
+	}
+	return convergence("DSTEQR", steqr(impl.bl(), compz, n, d, e, z, ldz, work))
+}
+
+// CSTEQR computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the implicit QL or QR algorithm. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the unitary matrix of a reduction to T, such as that formed from the
+// output of CHETRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. On return d holds the eigenvalues in ascending order and e is
+// destroyed. work holds 2*n-2 elements unless compz is N, when it and Z are
+// not referenced. If the algorithm fails to find all the eigenvalues in 30*n
+// sweeps, CSTEQR returns a *ConvergenceError whose Info is the number of
+// elements of e that did not converge to zero.
+func (impl Implementation) CSTEQR(compz CompZ, n int, d, e []float32, z []complex64, ldz int, work []float32) error {
+	if err := checkSteqr("CSTEQR", compz, n, len(d), len(e), len(z), ldz, len(work)); err != nil {
+		return err
+	}
+	return convergence("CSTEQR", steqr(impl.bl(), compz, n, d, e, z, ldz, work))
+}
+
+// ZSTEQR computes the eigenvalues and, unless compz is N, the eigenvectors
+// of the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the implicit QL or QR algorithm. For compz I the n×n
+// matrix Z is set to the eigenvectors of T. For compz V, Z holds on entry
+// the unitary matrix of a reduction to T, such as that formed from the
+// output of ZHETRD, and it is overwritten by the eigenvectors of the reduced
+// matrix. On return d holds the eigenvalues in ascending order and e is
+// destroyed. work holds 2*n-2 elements unless compz is N, when it and Z are
+// not referenced. If the algorithm fails to find all the eigenvalues in 30*n
+// sweeps, ZSTEQR returns a *ConvergenceError whose Info is the number of
+// elements of e that did not converge to zero.
+func (impl Implementation) ZSTEQR(compz CompZ, n int, d, e []float64, z []complex128, ldz int, work []float64) error {
+	if err := checkSteqr("ZSTEQR", compz, n, len(d), len(e), len(z), ldz, len(work)); err != nil {
+		return err
+	}
+	return convergence("ZSTEQR", steqr(impl.bl(), compz, n, d, e, z, ldz, work))
+}
+
+// steqrMaxIter bounds the number of QL or QR sweeps of sterf and steqr to
+// steqrMaxIter*n, as MAXIT of xSTERF and xSTEQR.
+const steqrMaxIter = 30
+
+// checkSterf checks the STERF routines.
+func checkSterf(routine string, n, lenD, lenE int) error {
+	c := checker{routine: routine}
+	c.nonNeg(1, "n", n)
+	if c.ok() {
+		c.length(2, "d", lenD, n)
+		c.length(3, "e", lenE, n-1)
+	}
+	return c.result()
+}
+
+// checkSteqr checks the STEQR routines.
+func checkSteqr(routine string, compz CompZ, n, lenD, lenE, lenZ, ldz, lenWork int) error {
+	c := checker{routine: routine}
+	c.compz(1, compz)
+	c.nonNeg(2, "n", n)
+	if compz == CompZN {
+		c.atLeast(6, "ldz", ldz, 1, "1")
+	} else {
+		c.ld(6, "ldz", ldz, n, "n")
+	}
+	if c.ok() {
+		c.length(3, "d", lenD, n)
+		c.length(4, "e", lenE, n-1)
+		if compz != CompZN {
+			c.length(5, "z", lenZ, matLen(n, n, ldz))
+			c.length(7, "work", lenWork, 2*n-2)
+		}
+	}
+	return c.result()
+}
+
+// lae2 returns the eigenvalues rt1 >= rt2 in absolute value of the real
+// symmetric 2×2 matrix [a b; b c], as xLAE2.
+func lae2(a, b, c float64) (rt1, rt2 float64) {
+	sm, df := a+c, a-c
+	adf, ab := math.Abs(df), math.Abs(b+b)
+	acmx, acmn := c, a
+	if math.Abs(a) > math.Abs(c) {
+		acmx, acmn = a, c
+	}
+	var rt float64
+	switch {
+	case adf > ab:
+		rt = adf * math.Sqrt(1+(ab/adf)*(ab/adf))
+	case adf < ab:
+		rt = ab * math.Sqrt(1+(adf/ab)*(adf/ab))
+	default:
+		rt = ab * math.Sqrt2
+	}
+	switch {
+	case sm < 0:
+		rt1 = (sm - rt) / 2
+	case sm > 0:
+		rt1 = (sm + rt) / 2
+	default:
+		return rt / 2, -rt / 2
+	}
+	// rt2 is computed from the determinant to avoid cancellation.
+	rt2 = (acmx/rt1)*acmn - (b/rt1)*b
+	return rt1, rt2
+}
+
+// laev2 returns the eigenvalues of [a b; b c] as lae2 and the unit
+// eigenvector (cs1, sn1) of rt1, as xLAEV2, so that
+// [cs1 sn1; -sn1 cs1]*[a b; b c]*[cs1 -sn1; sn1 cs1] = [rt1 0; 0 rt2].
+func laev2(a, b, c float64) (rt1, rt2, cs1, sn1 float64) {
+	rt1, rt2 = lae2(a, b, c)
+	sgn1 := 1.0
+	if a+c < 0 {
+		sgn1 = -1
+	}
+	df, tb := a-c, b+b
+	rt := lapy2(df, tb)
+	cs, sgn2 := df-rt, -1.0
+	if df >= 0 {
+		cs, sgn2 = df+rt, 1
+	}
+	switch ab := math.Abs(tb); {
+	case math.Abs(cs) > ab:
+		ct := -tb / cs
+		sn1 = 1 / math.Sqrt(1+ct*ct)
+		cs1 = ct * sn1
+	case ab == 0:
+		cs1, sn1 = 1, 0
+	default:
+		tn := -cs / tb
+		cs1 = 1 / math.Sqrt(1+tn*tn)
+		sn1 = tn * cs1
+	}
+	if sgn1 == sgn2 {
+		cs1, sn1 = -sn1, cs1
+	}
+	return rt1, rt2, cs1, sn1
+}
+
+// sterf computes the eigenvalues of the n×n real symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e by the root-free variant of the
+// QL or QR algorithm of Pal, Walker and Kahan, as xSTERF. On return d holds
+// the eigenvalues in ascending order and e is destroyed. sterf returns 0,
+// or the number of elements of e that did not converge to zero.
+func sterf[R gen.Float](n int, d, e []R) (info int) {
+	if n <= 1 {
+		return 0
+	}
+	eps := eps[R]()
+	eps2 := eps * eps
+	sfmin := safmin[R]()
+	ssfmax := math.Sqrt(1/sfmin) / 3
+	ssfmin := math.Sqrt(sfmin) / eps2
+	nmaxit := n * steqrMaxIter
+	var jtot int
+
+	// Split the matrix at negligible elements of e and reduce each block
+	// [l, lend] in turn.
+	for l1 := 0; l1 < n; {
+		if l1 > 0 {
+			e[l1-1] = 0
+		}
+		m := l1
+		for ; m < n-1; m++ {
+			if math.Abs(float64(e[m])) <= math.Sqrt(math.Abs(float64(d[m])))*math.Sqrt(math.Abs(float64(d[m+1])))*eps {
+				e[m] = 0
+				break
+			}
+		}
+		l, lend := l1, m
+		lsv, lendsv := l, lend
+		l1 = m + 1
+		if lend == l {
+			continue
+		}
+		// Scale the block.
+		anorm := lanst(normMax, lend-l+1, d[l:], e[l:])
+		if anorm == 0 {
+			continue
+		}
+		var scale float64
+		if anorm > ssfmax {
+			scale = ssfmax
+		} else if anorm < ssfmin {
+			scale = ssfmin
+		}
+		if scale != 0 {
+			lascl(uploAll, anorm, scale, lend-l+1, 1, d[l:], n)
+			lascl(uploAll, anorm, scale, lend-l, 1, e[l:], n)
+		}
+		for i := l; i < lend; i++ {
+			e[i] *= e[i]
+		}
+		// Choose between QL and QR iteration.
+		if math.Abs(float64(d[lend])) < math.Abs(float64(d[l])) {
+			l, lend = lend, l
+		}
+		if lend >= l {
+			// QL iteration: look for a small subdiagonal element.
+			for l <= lend {
+				m := l
+				for ; m < lend; m++ {
+					if math.Abs(float64(e[m])) <= eps2*math.Abs(float64(d[m])*float64(d[m+1])) {
+						break
+					}
+				}
+				if m < lend {
+					e[m] = 0
+				}
+				p := float64(d[l])
+				if m == l {
+					// Eigenvalue found.
+					l++
+					continue
+				}
+				if m == l+1 {
+					rt1, rt2 := lae2(float64(d[l]), math.Sqrt(float64(e[l])), float64(d[l+1]))
+					d[l], d[l+1] = R(rt1), R(rt2)
+					e[l] = 0
+					l += 2
+					continue
+				}
+				if jtot == nmaxit {
+					break
+				}
+				jtot++
+				// Form the shift.
+				rte := math.Sqrt(float64(e[l]))
+				sigma := (float64(d[l+1]) - p) / (2 * rte)
+				r := lapy2(sigma, 1)
+				sigma = p - rte/(sigma+math.Copysign(r, sigma))
+				c, s := 1.0, 0.0
+				gamma := float64(d[m]) - sigma
+				p = gamma * gamma
+				// Inner loop.
+				for i := m - 1; i >= l; i-- {
+					bb := float64(e[i])
+					r := p + bb
+					if i != m-1 {
+						e[i+1] = R(s * r)
+					}
+					oldc := c
+					c, s = p/r, bb/r
+					oldgam := gamma
+					alpha := float64(d[i])
+					gamma = c*(alpha-sigma) - s*oldgam
+					d[i+1] = R(oldgam + (alpha - gamma))
+					if c != 0 {
+						p = gamma * gamma / c
+					} else {
+						p = oldc * bb
+					}
+				}
+				e[l] = R(s * p)
+				d[l] = R(sigma + gamma)
+			}
+		} else {
+			// QR iteration: look for a small superdiagonal element.
+			for l >= lend {
+				m := l
+				for ; m > lend; m-- {
+					if math.Abs(float64(e[m-1])) <= eps2*math.Abs(float64(d[m])*float64(d[m-1])) {
+						break
+					}
+				}
+				if m > lend {
+					e[m-1] = 0
+				}
+				p := float64(d[l])
+				if m == l {
+					l--
+					continue
+				}
+				if m == l-1 {
+					rt1, rt2 := lae2(float64(d[l]), math.Sqrt(float64(e[l-1])), float64(d[l-1]))
+					d[l], d[l-1] = R(rt1), R(rt2)
+					e[l-1] = 0
+					l -= 2
+					continue
+				}
+				if jtot == nmaxit {
+					break
+				}
+				jtot++
+				rte := math.Sqrt(float64(e[l-1]))
+				sigma := (float64(d[l-1]) - p) / (2 * rte)
+				r := lapy2(sigma, 1)
+				sigma = p - rte/(sigma+math.Copysign(r, sigma))
+				c, s := 1.0, 0.0
+				gamma := float64(d[m]) - sigma
+				p = gamma * gamma
+				for i := m; i < l; i++ {
+					bb := float64(e[i])
+					r := p + bb
+					if i != m {
+						e[i-1] = R(s * r)
+					}
+					oldc := c
+					c, s = p/r, bb/r
+					oldgam := gamma
+					alpha := float64(d[i+1])
+					gamma = c*(alpha-sigma) - s*oldgam
+					d[i] = R(oldgam + (alpha - gamma))
+					if c != 0 {
+						p = gamma * gamma / c
+					} else {
+						p = oldc * bb
+					}
+				}
+				e[l-1] = R(s * p)
+				d[l] = R(sigma + gamma)
+			}
+		}
+		// Undo the scaling.
+		if scale != 0 {
+			lascl(uploAll, scale, anorm, lendsv-lsv+1, 1, d[lsv:], n)
+		}
+		if jtot == nmaxit {
+			for i := 0; i < n-1; i++ {
+				if e[i] != 0 {
+					info++
+				}
+			}
+			return info
+		}
+	}
+	slices.Sort(d[:n])
+	return 0
+}
+
+// steqr computes the eigenvalues and, unless compz is N, the eigenvectors of
+// the n×n real symmetric tridiagonal matrix T with diagonal d and
+// off-diagonal e by the implicit QL or QR algorithm, as xSTEQR. For compz I
+// the n×n matrix Z is set to the eigenvectors of T, and for compz V the
+// n×n unitary matrix Z on entry, which reduced a matrix to T, is overwritten
+// by the eigenvectors of that matrix. On return d holds the eigenvalues in
+// ascending order and e is destroyed. work holds 2*n-2 elements unless
+// compz is N. steqr returns 0, or the number of elements of e that did not
+// converge to zero.
+func steqr[T gen.Scalar, R gen.Float](bl blas.BLAS, compz CompZ, n int, d, e []R, z []T, ldz int, work []R) (info int) {
+	if n == 0 {
+		return 0
+	}
+	if compz == CompZI {
+		laset(uploAll, n, n, 0, 1, z, ldz)
+	}
+	if n == 1 {
+		return 0
+	}
+	if compz == CompZN {
+		return sterf(n, d, e)
+	}
+	eps := eps[R]()
+	eps2 := eps * eps
+	sfmin := safmin[R]()
+	ssfmax := math.Sqrt(1/sfmin) / 3
+	ssfmin := math.Sqrt(sfmin) / eps2
+	nmaxit := n * steqrMaxIter
+	var jtot int
+	// The rotations of a sweep are saved in work[l:m] and work[n-1+l:n-1+m]
+	// and applied to the columns l through m of Z.
+	cw, sw := work[:n-1], work[n-1:2*n-2]
+
+	for l1 := 0; l1 < n; {
+		if l1 > 0 {
+			e[l1-1] = 0
+		}
+		m := l1
+		for ; m < n-1; m++ {
+			tst := math.Abs(float64(e[m]))
+			if tst == 0 {
+				break
+			}
+			if tst <= math.Sqrt(math.Abs(float64(d[m])))*math.Sqrt(math.Abs(float64(d[m+1])))*eps {
+				e[m] = 0
+				break
+			}
+		}
+		l, lend := l1, m
+		lsv, lendsv := l, lend
+		l1 = m + 1
+		if lend == l {
+			continue
+		}
+		anorm := lanst(normMax, lend-l+1, d[l:], e[l:])
+		if anorm == 0 {
+			continue
+		}
+		var scale float64
+		if anorm > ssfmax {
+			scale = ssfmax
+		} else if anorm < ssfmin {
+			scale = ssfmin
+		}
+		if scale != 0 {
+			lascl(uploAll, anorm, scale, lend-l+1, 1, d[l:], n)
+			lascl(uploAll, anorm, scale, lend-l, 1, e[l:], n)
+		}
+		if math.Abs(float64(d[lend])) < math.Abs(float64(d[l])) {
+			l, lend = lend, l
+		}
+		if lend > l {
+			// QL iteration.
+			for l <= lend {
+				m := l
+				for ; m < lend; m++ {
+					tst := float64(e[m]) * float64(e[m])
+					if tst <= eps2*math.Abs(float64(d[m]))*math.Abs(float64(d[m+1]))+sfmin {
+						break
+					}
+				}
+				if m < lend {
+					e[m] = 0
+				}
+				p := float64(d[l])
+				if m == l {
+					l++
+					continue
+				}
+				if m == l+1 {
+					rt1, rt2, c, s := laev2(float64(d[l]), float64(e[l]), float64(d[l+1]))
+					cw[l], sw[l] = R(c), R(s)
+					lasr(bl, blas.SideR, DirectB, n, 2, cw[l:], sw[l:], z[l*ldz:], ldz)
+					d[l], d[l+1] = R(rt1), R(rt2)
+					e[l] = 0
+					l += 2
+					continue
+				}
+				if jtot == nmaxit {
+					break
+				}
+				jtot++
+				// Form the shift.
+				g := (float64(d[l+1]) - p) / (2 * float64(e[l]))
+				r := lapy2(g, 1)
+				g = float64(d[m]) - p + float64(e[l])/(g+math.Copysign(r, g))
+				s, c := 1.0, 1.0
+				p = 0
+				for i := m - 1; i >= l; i-- {
+					f := s * float64(e[i])
+					b := c * float64(e[i])
+					c, s, r = lartg(g, f)
+					if i != m-1 {
+						e[i+1] = R(r)
+					}
+					g = float64(d[i+1]) - p
+					r = (float64(d[i])-g)*s + 2*c*b
+					p = s * r
+					d[i+1] = R(g + p)
+					g = c*r - b
+					cw[i], sw[i] = R(c), R(-s)
+				}
+				lasr(bl, blas.SideR, DirectB, n, m-l+1, cw[l:], sw[l:], z[l*ldz:], ldz)
+				d[l] = R(float64(d[l]) - p)
+				e[l] = R(g)
+			}
+		} else {
+			// QR iteration.
+			for l >= lend {
+				m := l
+				for ; m > lend; m-- {
+					tst := float64(e[m-1]) * float64(e[m-1])
+					if tst <= eps2*math.Abs(float64(d[m]))*math.Abs(float64(d[m-1]))+sfmin {
+						break
+					}
+				}
+				if m > lend {
+					e[m-1] = 0
+				}
+				p := float64(d[l])
+				if m == l {
+					l--
+					continue
+				}
+				if m == l-1 {
+					rt1, rt2, c, s := laev2(float64(d[l-1]), float64(e[l-1]), float64(d[l]))
+					cw[m], sw[m] = R(c), R(s)
+					lasr(bl, blas.SideR, DirectF, n, 2, cw[m:], sw[m:], z[(l-1)*ldz:], ldz)
+					d[l-1], d[l] = R(rt1), R(rt2)
+					e[l-1] = 0
+					l -= 2
+					continue
+				}
+				if jtot == nmaxit {
+					break
+				}
+				jtot++
+				g := (float64(d[l-1]) - p) / (2 * float64(e[l-1]))
+				r := lapy2(g, 1)
+				g = float64(d[m]) - p + float64(e[l-1])/(g+math.Copysign(r, g))
+				s, c := 1.0, 1.0
+				p = 0
+				for i := m; i < l; i++ {
+					f := s * float64(e[i])
+					b := c * float64(e[i])
+					c, s, r = lartg(g, f)
+					if i != m {
+						e[i-1] = R(r)
+					}
+					g = float64(d[i]) - p
+					r = (float64(d[i+1])-g)*s + 2*c*b
+					p = s * r
+					d[i] = R(g + p)
+					g = c*r - b
+					cw[i], sw[i] = R(c), R(s)
+				}
+				lasr(bl, blas.SideR, DirectF, n, l-m+1, cw[m:], sw[m:], z[m*ldz:], ldz)
+				d[l] = R(float64(d[l]) - p)
+				e[l-1] = R(g)
+			}
+		}
+		if scale != 0 {
+			lascl(uploAll, scale, anorm, lendsv-lsv+1, 1, d[lsv:], n)
+			lascl(uploAll, scale, anorm, lendsv-lsv, 1, e[lsv:], n)
+		}
+		if jtot == nmaxit {
+			for i := 0; i < n-1; i++ {
+				if e[i] != 0 {
+					info++
+				}
+			}
+			return info
+		}
+	}
+	eigSort(bl, n, d, n, z, ldz)
+	return 0
+}
+
+// eigSort sorts the n eigenvalues in d into ascending order by selection
+// sort, swapping the columns of the m×n matrix Z with them unless m is 0.
+func eigSort[T gen.Scalar, R gen.Float](bl blas.BLAS, n int, d []R, m int, z []T, ldz int) {
+	for i := 0; i < n-1; i++ {
+		k, p := i, d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] < p {
+				k, p = j, d[j]
+			}
+		}
+		if k != i {
+			d[k], d[i] = d[i], p
+			if m > 0 {
+				swap(bl, m, z[i*ldz:], 1, z[k*ldz:], 1)
+			}
+		}
+	}
+}
@@ -0,0 +1,478 @@
+package lapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// svdRoutines holds the singular value decompositions of one precision,
+// with the rwork and iwork of their complex and divide and conquer versions
+// allocated by the test.
+type svdRoutines[T gen.Scalar, R gen.Float] struct {
+	gesvd func(jobu, jobvt SVDJob, m, n int, a []T, lda int, s []R, u []T, ldu int, vt []T, ldvt int, work []T, lwork int) error
+	gesdd func(jobz SVDJob, m, n int, a []T, lda int, s []R, u []T, ldu int, vt []T, ldvt int, work []T, lwork int) error
+	gesvj func(joba SVJMatrix, jobu SVJLeft, jobv SVJRight, m, n int, a []T, lda int, sva []R, mv int, v []T, ldv int, work []T) (int, error)
+	gejsv func(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []T, lda int, sva []R, u []T, ldu int, v []T, ldv int, work []T, lwork int) (int, R, error)
+}
+
+// gesddRwork returns the length of the rwork of the complex GESDD routines.
+func gesddRwork(jobz SVDJob, m, n int) int {
+	k := min(m, n)
+	if jobz == SVDN {
+		return 5 * k
+	}
+	return k + 2*k*k + max(1, 6*k*k+8*k)
+}
+
+func TestSVD(t *testing.T) {
+	var impl Implementation
+	testSVD(t, "S", svdRoutines[float32, float32]{impl.SGESVD,
+		func(jobz SVDJob, m, n int, a []float32, lda int, s []float32, u []float32, ldu int, vt []float32, ldvt int, work []float32, lwork int) error {
+			return impl.SGESDD(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, make([]int, 3*min(m, n)))
+		}, impl.SGESVJ,
+		func(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []float32, lda int, sva []float32, u []float32, ldu int, v []float32, ldv int, work []float32, lwork int) (int, float32, error) {
+			return impl.SGEJSV(joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, make([]int, m+n))
+		}})
+	testSVD(t, "D", svdRoutines[float64, float64]{impl.DGESVD,
+		func(jobz SVDJob, m, n int, a []float64, lda int, s []float64, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int) error {
+			return impl.DGESDD(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, make([]int, 3*min(m, n)))
+		}, impl.DGESVJ,
+		func(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []float64, lda int, sva []float64, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int) (int, float64, error) {
+			return impl.DGEJSV(joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, make([]int, m+n))
+		}})
+	testSVD(t, "C", svdRoutines[complex64, float32]{
+		func(jobu, jobvt SVDJob, m, n int, a []complex64, lda int, s []float32, u []complex64, ldu int, vt []complex64, ldvt int, work []complex64, lwork int) error {
+			return impl.CGESVD(jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, make([]float32, 5*min(m, n)))
+		},
+		func(jobz SVDJob, m, n int, a []complex64, lda int, s []float32, u []complex64, ldu int, vt []complex64, ldvt int, work []complex64, lwork int) error {
+			return impl.CGESDD(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, make([]float32, gesddRwork(jobz, m, n)), make([]int, 3*min(m, n)))
+		}, impl.CGESVJ,
+		func(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []complex64, lda int, sva []float32, u []complex64, ldu int, v []complex64, ldv int, work []complex64, lwork int) (int, float32, error) {
+			return impl.CGEJSV(joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, make([]float32, max(m, 2*n)), make([]int, m+n))
+		}})
+	testSVD(t, "Z", svdRoutines[complex128, float64]{
+		func(jobu, jobvt SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int) error {
+			return impl.ZGESVD(jobu, jobvt, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, make([]float64, 5*min(m, n)))
+		},
+		func(jobz SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int) error {
+			return impl.ZGESDD(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, make([]float64, gesddRwork(jobz, m, n)), make([]int, 3*min(m, n)))
+		}, impl.ZGESVJ,
+		func(joba JSVAccuracy, jobu SVJLeft, jobv SVJRight, m, n int, a []complex128, lda int, sva []float64, u []complex128, ldu int, v []complex128, ldv int, work []complex128, lwork int) (int, float64, error) {
+			return impl.ZGEJSV(joba, jobu, jobv, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, make([]float64, max(m, 2*n)), make([]int, m+n))
+		}})
+}
+
+// svdFactors holds a computed singular value decomposition of an m×n
+// matrix: the singular values s and, if computed, the first nu columns of U
+// and the first nv rows of Vᴴ.
+type svdFactors[T gen.Scalar] struct {
+	s        []float64
+	u        []T
+	ldu, nu  int
+	vt       []T
+	ldvt, nv int
+}
+
+func testSVD[T gen.Scalar, R gen.Float](t *testing.T, prec string, f svdRoutines[T, R]) {
+	rnd := rand.New(rand.NewSource(1))
+	allJobs := []SVDJob{SVDA, SVDS, SVDO, SVDN}
+
+	// xGESVD and xGESDD with every job, on shapes that take the paths
+	// through the QR or LQ factorization when one dimension is much larger
+	// than the other, and the blocked reduction to bidiagonal form.
+	for _, sh := range [][2]int{{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {8, 5}, {5, 8}, {30, 30}, {60, 6}, {6, 60}, {150, 140}} {
+		m, n := sh[0], sh[1]
+		k, lda := min(m, n), m+2
+		a := randMat[T](rnd, m, n, lda)
+		var ref []float64
+		type run struct {
+			routine     string
+			jobu, jobvt SVDJob
+			jobz        SVDJob
+			minWork     int
+			dc          bool
+		}
+		var runs []run
+		for _, jobu := range allJobs {
+			for _, jobvt := range allJobs {
+				if jobu == SVDO && jobvt == SVDO {
+					continue
+				}
+				nw := 2*k + max(m, n)
+				if !isComplex[T]() {
+					nw = 7*k + max(m, n)
+				}
+				runs = append(runs, run{routine: prec + "GESVD", jobu: jobu, jobvt: jobvt, minWork: max(1, nw)})
+			}
+		}
+		for _, jobz := range allJobs {
+			jobu, jobvt := gesddJobs(jobz, m, n)
+			nw := 2*k + max(m, n)
+			switch {
+			case !isComplex[T]() && jobz == SVDN:
+				nw = 7*k + max(m, n)
+			case !isComplex[T]():
+				nw = 3*k + 2*k*k + max(1, 6*k*k+8*k) + max(m, n)
+			}
+			if k == 0 {
+				// The bidiagonal SVD of an empty A takes no workspace.
+				nw = max(m, n)
+			}
+			if jobz == SVDO {
+				nw += k * k
+			}
+			runs = append(runs, run{routine: prec + "GESDD", jobu: jobu, jobvt: jobvt, jobz: jobz, minWork: max(1, nw), dc: true})
+		}
+		for _, r := range runs {
+			for _, v := range workVariants[1:] {
+				name := fmt.Sprintf("%s %s jobu=%c jobvt=%c m=%d n=%d", r.routine, v, r.jobu, r.jobvt, m, n)
+				if r.dc {
+					name = fmt.Sprintf("%s %s jobz=%c m=%d n=%d", r.routine, v, r.jobz, m, n)
+				}
+				ac := slices.Clone(a)
+				s := make([]R, k)
+				ncu, nrvt := k, k
+				if r.jobu == SVDA {
+					ncu = m
+				}
+				if r.jobvt == SVDA {
+					nrvt = n
+				}
+				ldu, ldvt := max(1, m+1), max(1, nrvt+3)
+				u, vt := make([]T, ldu*ncu), make([]T, ldvt*n)
+				err := withWork(v, r.minWork, nil, func(work []T, lwork int) error {
+					if r.dc {
+						return f.gesdd(r.jobz, m, n, ac, lda, s, u, ldu, vt, ldvt, work, lwork)
+					}
+					return f.gesvd(r.jobu, r.jobvt, m, n, ac, lda, s, u, ldu, vt, ldvt, work, lwork)
+				})
+				if err != nil {
+					t.Errorf("%s: unexpected error %v", name, err)
+					continue
+				}
+				if !samePad(m, n, lda, ac, a) {
+					t.Errorf("%s: elements outside A modified", name)
+				}
+				fac := svdFactors[T]{s: toFloat64(s)}
+				switch r.jobu {
+				case SVDA, SVDS:
+					fac.u, fac.ldu, fac.nu = u, ldu, ncu
+				case SVDO:
+					fac.u, fac.ldu, fac.nu = ac, lda, k
+				}
+				switch r.jobvt {
+				case SVDA, SVDS:
+					fac.vt, fac.ldvt, fac.nv = vt, ldvt, nrvt
+				case SVDO:
+					fac.vt, fac.ldvt, fac.nv = ac, lda, k
+				}
+				if ref == nil {
+					ref = fac.s
+				}
+				checkSVD(t, name, m, n, a, lda, fac, ref)
+			}
+		}
+	}
+
+	// xGESVJ with every structure of A and every job, the triangle
+	// opposite to an upper or lower triangular A holding random numbers
+	// that it ignores. With jobu N, A is overwritten by U*Σ, and with jobv A
+	// the rotations are applied to the V given on entry.
+	for _, sh := range [][2]int{{0, 0}, {3, 0}, {1, 1}, {8, 5}, {20, 20}, {60, 6}} {
+		m, n := sh[0], sh[1]
+		lda := m + 2
+		for _, joba := range []SVJMatrix{SVJGeneral, SVJUpper, SVJLower} {
+			a := randMat[T](rnd, m, n, lda)
+			full := slices.Clone(a)
+			for j := 0; j < n; j++ {
+				for i := 0; i < m; i++ {
+					if (joba == SVJUpper && i > j) || (joba == SVJLower && i < j) {
+						full[i+j*lda] = 0
+					}
+				}
+			}
+			ref := svdValues(t, f, m, n, full, lda)
+			var vRef []T
+			for _, jobv := range []SVJRight{SVJRightV, SVJRightA, SVJRightN} {
+				for _, jobu := range []SVJLeft{SVJLeftU, SVJLeftN} {
+					name := fmt.Sprintf("%sGESVJ joba=%c jobu=%c jobv=%c m=%d n=%d", prec, joba, jobu, jobv, m, n)
+					ac := slices.Clone(a)
+					sva := make([]R, n)
+					const mv = 3
+					ldv := max(1, n+1)
+					var v, v0 []T
+					switch jobv {
+					case SVJRightV:
+						v = make([]T, ldv*n)
+					case SVJRightA:
+						ldv = mv + 1
+						v = randMat[T](rnd, mv, n, ldv)
+						v0 = slices.Clone(v)
+					}
+					rank, err := f.gesvj(joba, jobu, jobv, m, n, ac, lda, sva, mv, v, ldv, make([]T, m))
+					if err != nil {
+						t.Errorf("%s: unexpected error %v", name, err)
+						continue
+					}
+					if rank != n {
+						t.Errorf("%s: rank = %d, want %d", name, rank, n)
+					}
+					if !samePad(m, n, lda, ac, a) {
+						t.Errorf("%s: elements outside A modified", name)
+					}
+					fac := svdFactors[T]{s: toFloat64(sva), u: ac, ldu: lda, nu: n}
+					if jobu == SVJLeftN {
+						fac.u = unscaleColumns(m, ac, lda, fac.s)
+					}
+					switch jobv {
+					case SVJRightV:
+						fac.vt, fac.ldvt, fac.nv = conjTrans(n, n, v, ldv), n, n
+						if vRef == nil {
+							vRef = slices.Clone(v)
+						}
+					case SVJRightA:
+						// The rotations are those that form V with jobv V.
+						want := mulMat(blas.TransN, blas.TransN, mv, n, n, v0, ldv, vRef, n+1)
+						if r := ratio[T](diffF(mv, n, v, ldv, want, mv), normF(mv, n, v0, ldv), n); r > maxRatio {
+							t.Errorf("%s: ratio %.3g to V0*V", name, r)
+						}
+					}
+					checkSVD(t, name, m, n, full, lda, fac, ref)
+				}
+			}
+		}
+	}
+
+	// xGEJSV with every accuracy and every job.
+	for _, sh := range [][2]int{{0, 0}, {3, 0}, {1, 1}, {8, 5}, {20, 20}, {60, 6}} {
+		m, n := sh[0], sh[1]
+		lda := m + 2
+		a := randMat[T](rnd, m, n, lda)
+		ref := svdValues(t, f, m, n, a, lda)
+		for _, joba := range []JSVAccuracy{JSVC, JSVE, JSVF, JSVG, JSVA, JSVR} {
+			for _, jobu := range []SVJLeft{SVJLeftU, SVJLeftF, SVJLeftN} {
+				for _, jobv := range []SVJRight{SVJRightV, SVJRightN} {
+					for _, v := range workVariants[1:] {
+						name := fmt.Sprintf("%sGEJSV %s joba=%c jobu=%c jobv=%c m=%d n=%d", prec, v, joba, jobu, jobv, m, n)
+						ac := slices.Clone(a)
+						sva := make([]R, n)
+						nu := n
+						if jobu == SVJLeftF {
+							nu = m
+						}
+						ldu, ldv := max(1, m+1), max(1, n+2)
+						u, vm := make([]T, ldu*nu), make([]T, ldv*n)
+						var rank int
+						var sconda R
+						err := withWork(v, gejsvMinWork[T](m, n, joba, jobu, jobv), nil, func(work []T, lwork int) (err error) {
+							rank, sconda, err = f.gejsv(joba, jobu, jobv, m, n, ac, lda, sva, u, ldu, vm, ldv, work, lwork)
+							return err
+						})
+						if err != nil {
+							t.Errorf("%s: unexpected error %v", name, err)
+							continue
+						}
+						if rank != n {
+							t.Errorf("%s: rank = %d, want %d", name, rank, n)
+						}
+						// sconda bounds the condition number of A scaled
+						// to unit columns from below, which is at least 1.
+						if cond := float64(sconda); (joba == JSVE || joba == JSVG) && n > 0 && !(cond >= 1-maxRatio*eps[T]()) {
+							t.Errorf("%s: sconda = %g, want at least 1", name, cond)
+						} else if joba != JSVE && joba != JSVG && cond != -1 {
+							t.Errorf("%s: sconda = %g, want -1", name, cond)
+						}
+						fac := svdFactors[T]{s: toFloat64(sva)}
+						if jobu != SVJLeftN {
+							fac.u, fac.ldu, fac.nu = u, ldu, nu
+						}
+						if jobv == SVJRightV {
+							fac.vt, fac.ldvt, fac.nv = conjTrans(n, n, vm, ldv), n, n
+						}
+						checkSVD(t, name, m, n, a, lda, fac, ref)
+					}
+				}
+			}
+		}
+	}
+
+	// Unlike LAPACK, which returns singular values scaled by the factor in
+	// work[0] when their squares would overflow, xGESVJ and xGEJSV return
+	// them unscaled in sva. The singular values of A = c*Q*diag(d)*W, with
+	// Q and W unitary, are c*d, and the column norms of A are at least
+	// c/2 = 1/safmin, large enough for the routines to scale A.
+	const m, n = 7, 4
+	d := []float64{1, 0.875, 0.75, 0.625}
+	c := 2 / safmin[T]()
+	q, w := unitaryMat[T](rnd, m), unitaryMat[T](rnd, n)
+	a := make([]T, m*n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			a[i+j*m] = fromReal[T](d[i]) * w[i+j*n]
+		}
+	}
+	a = mulMat(blas.TransN, blas.TransN, m, n, m, q, m, a, m)
+	for i := range a {
+		a[i] *= fromReal[T](c)
+	}
+	for _, routine := range []string{"GESVJ", "GEJSV"} {
+		name := fmt.Sprintf("%s%s with column norms above 1/safmin", prec, routine)
+		ac := slices.Clone(a)
+		sva := make([]R, n)
+		var err error
+		if routine == "GESVJ" {
+			_, err = f.gesvj(SVJGeneral, SVJLeftN, SVJRightN, m, n, ac, m, sva, 0, nil, 1, make([]T, m))
+		} else {
+			err = withWork("optimal lwork", gejsvMinWork[T](m, n, JSVC, SVJLeftN, SVJRightN), nil, func(work []T, lwork int) (err error) {
+				_, _, err = f.gejsv(JSVC, SVJLeftN, SVJRightN, m, n, ac, m, sva, nil, 1, nil, 1, work, lwork)
+				return err
+			})
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		for i, di := range d {
+			if r := ratio[T](math.Abs(float64(sva[i])/c-di), 1, n); r > maxRatio {
+				t.Errorf("%s: sva[%d] = %.6g, want %.6g", name, i, sva[i], c*di)
+			}
+		}
+	}
+}
+
+// gejsvMinWork returns the minimum lwork of xGEJSV.
+func gejsvMinWork[T gen.Scalar](m, n int, joba JSVAccuracy, jobu SVJLeft, jobv SVJRight) int {
+	if n == 0 {
+		return 1
+	}
+	n1 := n
+	if jobu == SVJLeftF {
+		n1 = m
+	}
+	w := 2 * n
+	switch {
+	case jobu != SVJLeftN && jobv == SVJRightV:
+		w = max(n*n+2*n, n1)
+	case jobu != SVJLeftN:
+		w = max(2*n, n1)
+	case jobv == SVJRightN && (joba == JSVE || joba == JSVG):
+		w = n*n + 2*n
+	}
+	if isComplex[T]() {
+		return n + w
+	}
+	return max(m, 2*n) + n + w
+}
+
+// svdValues returns the singular values of the m×n matrix A computed by
+// xGESVD.
+func svdValues[T gen.Scalar, R gen.Float](t *testing.T, f svdRoutines[T, R], m, n int, a []T, lda int) []float64 {
+	t.Helper()
+	s := make([]R, min(m, n))
+	if err := withWork("optimal lwork", 1, nil, func(work []T, lwork int) error {
+		return f.gesvd(SVDN, SVDN, m, n, slices.Clone(a), lda, s, nil, 1, nil, 1, work, lwork)
+	}); err != nil {
+		t.Fatalf("GESVD m=%d n=%d: unexpected error %v", m, n, err)
+	}
+	return toFloat64(s)
+}
+
+// conjTrans returns the conjugate transpose of the m×n matrix A, with
+// leading dimension n.
+func conjTrans[T gen.Scalar](m, n int, a []T, lda int) []T {
+	b := make([]T, n*m)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			b[j+i*n] = conj(a[i+j*lda])
+		}
+	}
+	return b
+}
+
+// unscaleColumns returns the m×n matrix U*Σ in A divided by the singular
+// values s, with leading dimension lda.
+func unscaleColumns[T gen.Scalar](m int, a []T, lda int, s []float64) []T {
+	u := slices.Clone(a)
+	for j, sj := range s {
+		for i := 0; i < m; i++ {
+			u[i+j*lda] /= fromReal[T](sj)
+		}
+	}
+	return u
+}
+
+// checkSVD checks the singular value decomposition f of the m×n matrix A:
+// the singular values are non-negative, in decreasing order and agree with
+// the reference ref, U and V have orthonormal columns, and A = U*Σ*Vᴴ. With
+// only one of U and V, the rows of Uᴴ*A or the columns of A*V have the
+// singular values for norms.
+func checkSVD[T gen.Scalar](t *testing.T, name string, m, n int, a []T, lda int, f svdFactors[T], ref []float64) {
+	t.Helper()
+	k := min(m, n)
+	norm := normF(m, n, a, lda)
+	s := f.s[:k]
+	for i := range s {
+		if s[i] < 0 || (i > 0 && s[i] > s[i-1]) {
+			t.Errorf("%s: singular values %v not non-negative and decreasing", name, s)
+			break
+		}
+	}
+	var diff float64
+	for i := range s {
+		diff = math.Max(diff, math.Abs(s[i]-ref[i]))
+	}
+	if r := ratio[T](diff, norm, max(m, n)); r > maxRatio {
+		t.Errorf("%s: singular values differ from the reference, ratio %.3g", name, r)
+	}
+	if k == 0 {
+		// As in LAPACK, U and V are not referenced when A is empty.
+		return
+	}
+	if f.u != nil {
+		if r := orthRatio(m, f.nu, f.u, f.ldu); r > maxRatio {
+			t.Errorf("%s: ‖Uᴴ*U - I‖ ratio %.3g", name, r)
+		}
+	}
+	var v []T
+	if f.vt != nil {
+		v = conjTrans(f.nv, n, f.vt, f.ldvt)
+		if r := orthRatio(n, f.nv, v, n); r > maxRatio {
+			t.Errorf("%s: ‖Vᴴ*V - I‖ ratio %.3g", name, r)
+		}
+	}
+	switch {
+	case f.u != nil && v != nil:
+		us := make([]T, m*k)
+		for j := 0; j < k; j++ {
+			for i := 0; i < m; i++ {
+				us[i+j*m] = f.u[i+j*f.ldu] * fromReal[T](s[j])
+			}
+		}
+		usv := mulMat(blas.TransN, blas.TransC, m, n, k, us, m, v, n)
+		if r := ratio[T](diffF(m, n, a, lda, usv, m), norm, max(m, n)); r > maxRatio {
+			t.Errorf("%s: ‖A - U*Σ*Vᴴ‖ ratio %.3g", name, r)
+		}
+	case f.u != nil:
+		ua := mulMat(blas.TransC, blas.TransN, k, n, m, f.u, f.ldu, a, lda)
+		for i := 0; i < k; i++ {
+			if r := ratio[T](math.Abs(normF(1, n, ua[i:], k)-s[i]), norm, max(m, n)); r > maxRatio {
+				t.Errorf("%s: row %d of Uᴴ*A has norm %.6g, want %.6g", name, i, normF(1, n, ua[i:], k), s[i])
+				break
+			}
+		}
+	case v != nil:
+		av := mulMat(blas.TransN, blas.TransN, m, k, n, a, lda, v, n)
+		for j := 0; j < k; j++ {
+			if r := ratio[T](math.Abs(normF(m, 1, av[j*m:], m)-s[j]), norm, max(m, n)); r > maxRatio {
+				t.Errorf("%s: column %d of A*V has norm %.6g, want %.6g", name, j, normF(m, 1, av[j*m:], m), s[j])
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,573 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SSYEV computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n symmetric matrix A, of which only the uplo triangle is referenced.
+// A is reduced to tridiagonal form by SSYTRD and the eigenvalues are computed
+// by SSTERF, or with the eigenvectors by SSTEQR. The eigenvalues are
+// returned in ascending order in w. For jobz V A is overwritten by the
+// orthonormal eigenvectors, column j for w[j]; otherwise its uplo triangle
+// is destroyed. A *ConvergenceError is returned if the QL or QR algorithm
+// failed, Info elements of an intermediate tridiagonal form not converging
+// to zero. work holds lwork >= max(1,3*n-1) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SSYEV(jobz JobZ, uplo blas.Uplo, n int, a []float32, lda int, w []float32, work []float32, lwork int) error {
+	if err := checkSyev("SSYEV", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, -1); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = float32(n + syevWork(n))
+		return nil
+	}
+	// The tridiagonal QL or QR iteration runs in the workspace of the
+	// reduction, which it follows.
+	return convergence("SSYEV", syev(impl.bl(), jobz, uplo, n, a, lda, w, work[n:], lwork-n, work[:n], work[n:]))
+}
+
+// SSYEVD computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n symmetric matrix A as SSYEV does, with the eigenvectors of the
+// tridiagonal form computed by the divide and conquer method of SSTEDC,
+// which is much faster for large matrices. A *ConvergenceError is returned
+// if SSTEDC or SSTERF failed, with the Info of that routine. For n > 1,
+// work holds lwork >= 2*n (jobz N) or 3*n*n+6*n (jobz V) elements and iwork
+// 0 or 3*n; for n <= 1, lwork >= 1. The optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SSYEVD(jobz JobZ, uplo blas.Uplo, n int, a []float32, lda int, w []float32, work []float32, lwork int, iwork []int) error {
+	if err := checkSyevd("SSYEVD", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, -1, len(iwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = float32(syevdWork(jobz, n))
+		return nil
+	}
+	// The divide and conquer method runs in the workspace of the
+	// reduction, which it follows.
+	return convergence("SSYEVD", syevd(impl.bl(), jobz, uplo, n, a, lda, w, work[n:], lwork-n, work[:n], work[n:], iwork))
+}
+
+// SSYEVR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n symmetric matrix A, of which only the uplo triangle is referenced. A is
+// reduced to tridiagonal form T by SSYTRD, whose selected eigenpairs are
+// computed by SSTEMR: rng A selects all the eigenvalues, rng V those in
+// the half-open interval (vl,vu], and rng I those il through iu in
+// ascending order, counted from 0. The eigenvalues are computed to high
+// relative accuracy when T warrants it. SSYEVR returns the number m of
+// eigenvalues found, in ascending order in w[:m]. For jobz V their
+// orthonormal eigenvectors are returned in the first m columns of Z, which
+// has n columns, or iu-il+1 for rng I, and isuppz receives the supports of
+// the eigenvectors of T as in SSTEMR. The uplo triangle of A is destroyed.
+// work holds lwork >= max(1,22*n) elements and iwork 10*n; the optimal
+// lwork is returned in work[0] by a call with lwork = -1 that does nothing
+// else.
+func (impl Implementation) SSYEVR(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []float32, lda int, vl, vu float32, il, iu int, w []float32, z []float32, ldz int, isuppz []int, work []float32, lwork int, iwork []int) (m int, err error) {
+	if err := checkSyevr("SSYEVR", jobz, rng, uplo, n, len(a), lda, float64(vl), float64(vu), il, iu, len(w), len(z), ldz, len(isuppz), len(work), lwork, -1, len(iwork)); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		work[0] = float32(20*n + syevrWork(n))
+		return 0, nil
+	}
+	// The tridiagonal form and the workspace of SSTEMR come first.
+	return syevr(impl.bl(), jobz, rng, uplo, n, a, lda, float64(vl), float64(vu), il, iu, w, z, ldz, isuppz, work[20*n:], lwork-20*n, work[:20*n], iwork), nil
+}
+
+// DSYEV computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n symmetric matrix A, of which only the uplo triangle is referenced.
+// A is reduced to tridiagonal form by DSYTRD and the eigenvalues are computed
+// by DSTERF, or with the eigenvectors by DSTEQR. The eigenvalues are
+// returned in ascending order in w. For jobz V A is overwritten by the
+// orthonormal eigenvectors, column j for w[j]; otherwise its uplo triangle
+// is destroyed. A *ConvergenceError is returned if the QL or QR algorithm
+// failed, Info elements of an intermediate tridiagonal form not converging
+// to zero. work holds lwork >= max(1,3*n-1) elements; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DSYEV(jobz JobZ, uplo blas.Uplo, n int, a []float64, lda int, w []float64, work []float64, lwork int) error {
+	if err := checkSyev("DSYEV", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, -1); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = float64(n + syevWork(n))
+		return nil
+	}
+	// The tridiagonal QL or QR iteration runs in the workspace of the
+	// reduction, which it follows.
+	return convergence("DSYEV", syev(impl.bl(), jobz, uplo, n, a, lda, w, work[n:], lwork-n, work[:n], work[n:]))
+}
+
+// DSYEVD computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n symmetric matrix A as DSYEV does, with the eigenvectors of the
+// tridiagonal form computed by the divide and conquer method of DSTEDC,
+// which is much faster for large matrices. A *ConvergenceError is returned
+// if DSTEDC or DSTERF failed, with the Info of that routine. For n > 1,
+// work holds lwork >= 2*n (jobz N) or 3*n*n+6*n (jobz V) elements and iwork
+// 0 or 3*n; for n <= 1, lwork >= 1. The optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DSYEVD(jobz JobZ, uplo blas.Uplo, n int, a []float64, lda int, w []float64, work []float64, lwork int, iwork []int) error {
+	if err := checkSyevd("DSYEVD", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, -1, len(iwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = float64(syevdWork(jobz, n))
+		return nil
+	}
+	// The divide and conquer method runs in the workspace of the
+	// reduction, which it follows.
+	return convergence("DSYEVD", syevd(impl.bl(), jobz, uplo, n, a, lda, w, work[n:], lwork-n, work[:n], work[n:], iwork))
+}
+
+// DSYEVR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n symmetric matrix A, of which only the uplo triangle is referenced. A is
+// reduced to tridiagonal form T by DSYTRD, whose selected eigenpairs are
+// computed by DSTEMR: rng A selects all the eigenvalues, rng V those in
+// the half-open interval (vl,vu], and rng I those il through iu in
+// ascending order, counted from 0. The eigenvalues are computed to high
+// relative accuracy when T warrants it. DSYEVR returns the number m of
+// eigenvalues found, in ascending order in w[:m]. For jobz V their
+// orthonormal eigenvectors are returned in the first m columns of Z, which
+// has n columns, or iu-il+1 for rng I, and isuppz receives the supports of
+// the eigenvectors of T as in DSTEMR. The uplo triangle of A is destroyed.
+// work holds lwork >= max(1,22*n) elements and iwork 10*n; the optimal
+// lwork is returned in work[0] by a call with lwork = -1 that does nothing
+// else.
+func (impl Implementation) DSYEVR(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w []float64, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int) (m int, err error) {
+	if err := checkSyevr("DSYEVR", jobz, rng, uplo, n, len(a), lda, float64(vl), float64(vu), il, iu, len(w), len(z), ldz, len(isuppz), len(work), lwork, -1, len(iwork)); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		work[0] = float64(20*n + syevrWork(n))
+		return 0, nil
+	}
+	// The tridiagonal form and the workspace of DSTEMR come first.
+	return syevr(impl.bl(), jobz, rng, uplo, n, a, lda, float64(vl), float64(vu), il, iu, w, z, ldz, isuppz, work[20*n:], lwork-20*n, work[:20*n], iwork), nil
+}
+
+// CHEEV computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n Hermitian matrix A, of which only the uplo triangle is referenced.
+// A is reduced to tridiagonal form by CHETRD and the eigenvalues are computed
+// by SSTERF, or with the eigenvectors by CSTEQR. The eigenvalues are
+// returned in ascending order in w. For jobz V A is overwritten by the
+// orthonormal eigenvectors, column j for w[j]; otherwise its uplo triangle
+// is destroyed. A *ConvergenceError is returned if the QL or QR algorithm
+// failed, Info elements of an intermediate tridiagonal form not converging
+// to zero. work holds lwork >= max(1,2*n-1) elements and rwork max(1,3*n-2);
+// the optimal lwork is returned in work[0] by a call with lwork = -1 that
+// does nothing else.
+func (impl Implementation) CHEEV(jobz JobZ, uplo blas.Uplo, n int, a []complex64, lda int, w []float32, work []complex64, lwork int, rwork []float32) error {
+	if err := checkSyev("CHEEV", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = complex(float32(syevWork(n)), 0)
+		return nil
+	}
+	return convergence("CHEEV", syev(impl.bl(), jobz, uplo, n, a, lda, w, work, lwork, rwork[:n], rwork[n:]))
+}
+
+// CHEEVD computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n Hermitian matrix A as CHEEV does, with the eigenvectors of the
+// tridiagonal form computed by the divide and conquer method of CSTEDC,
+// which is much faster for large matrices. A *ConvergenceError is returned
+// if CSTEDC or SSTERF failed, with the Info of that routine. For n > 1,
+// work holds lwork >= n (jobz N) or 2*n-2 (jobz V) elements, rwork n or
+// 3*n*n+6*n, and iwork 0 or 3*n; for n <= 1, lwork >= 1. The optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CHEEVD(jobz JobZ, uplo blas.Uplo, n int, a []complex64, lda int, w []float32, work []complex64, lwork int, rwork []float32, iwork []int) error {
+	if err := checkSyevd("CHEEVD", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = complex(float32(syevWork(n)), 0)
+		return nil
+	}
+	return convergence("CHEEVD", syevd(impl.bl(), jobz, uplo, n, a, lda, w, work, lwork, rwork[:n], rwork[n:], iwork))
+}
+
+// CHEEVR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n Hermitian matrix A, of which only the uplo triangle is referenced. A is
+// reduced to tridiagonal form T by CHETRD, whose selected eigenpairs are
+// computed by CSTEMR: rng A selects all the eigenvalues, rng V those in
+// the half-open interval (vl,vu], and rng I those il through iu in
+// ascending order, counted from 0. The eigenvalues are computed to high
+// relative accuracy when T warrants it. CHEEVR returns the number m of
+// eigenvalues found, in ascending order in w[:m]. For jobz V their
+// orthonormal eigenvectors are returned in the first m columns of Z, which
+// has n columns, or iu-il+1 for rng I, and isuppz receives the supports of
+// the eigenvectors of T as in CSTEMR. The uplo triangle of A is destroyed.
+// work holds lwork >= max(1,2*n) elements, rwork 20*n and iwork 10*n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) CHEEVR(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []complex64, lda int, vl, vu float32, il, iu int, w []float32, z []complex64, ldz int, isuppz []int, work []complex64, lwork int, rwork []float32, iwork []int) (m int, err error) {
+	if err := checkSyevr("CHEEVR", jobz, rng, uplo, n, len(a), lda, float64(vl), float64(vu), il, iu, len(w), len(z), ldz, len(isuppz), len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		work[0] = complex(float32(syevrWork(n)), 0)
+		return 0, nil
+	}
+	return syevr(impl.bl(), jobz, rng, uplo, n, a, lda, float64(vl), float64(vu), il, iu, w, z, ldz, isuppz, work, lwork, rwork, iwork), nil
+}
+
+// ZHEEV computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n Hermitian matrix A, of which only the uplo triangle is referenced.
+// A is reduced to tridiagonal form by ZHETRD and the eigenvalues are computed
+// by DSTERF, or with the eigenvectors by ZSTEQR. The eigenvalues are
+// returned in ascending order in w. For jobz V A is overwritten by the
+// orthonormal eigenvectors, column j for w[j]; otherwise its uplo triangle
+// is destroyed. A *ConvergenceError is returned if the QL or QR algorithm
+// failed, Info elements of an intermediate tridiagonal form not converging
+// to zero. work holds lwork >= max(1,2*n-1) elements and rwork max(1,3*n-2);
+// the optimal lwork is returned in work[0] by a call with lwork = -1 that
+// does nothing else.
+func (impl Implementation) ZHEEV(jobz JobZ, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64) error {
+	if err := checkSyev("ZHEEV", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, len(rwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(syevWork(n)), 0)
+		return nil
+	}
+	return convergence("ZHEEV", syev(impl.bl(), jobz, uplo, n, a, lda, w, work, lwork, rwork[:n], rwork[n:]))
+}
+
+// ZHEEVD computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n Hermitian matrix A as ZHEEV does, with the eigenvectors of the
+// tridiagonal form computed by the divide and conquer method of ZSTEDC,
+// which is much faster for large matrices. A *ConvergenceError is returned
+// if ZSTEDC or DSTERF failed, with the Info of that routine. For n > 1,
+// work holds lwork >= n (jobz N) or 2*n-2 (jobz V) elements, rwork n or
+// 3*n*n+6*n, and iwork 0 or 3*n; for n <= 1, lwork >= 1. The optimal lwork
+// is returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZHEEVD(jobz JobZ, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64, iwork []int) error {
+	if err := checkSyevd("ZHEEVD", jobz, uplo, n, len(a), lda, len(w), len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return err
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(syevWork(n)), 0)
+		return nil
+	}
+	return convergence("ZHEEVD", syevd(impl.bl(), jobz, uplo, n, a, lda, w, work, lwork, rwork[:n], rwork[n:], iwork))
+}
+
+// ZHEEVR computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n Hermitian matrix A, of which only the uplo triangle is referenced. A is
+// reduced to tridiagonal form T by ZHETRD, whose selected eigenpairs are
+// computed by ZSTEMR: rng A selects all the eigenvalues, rng V those in
+// the half-open interval (vl,vu], and rng I those il through iu in
+// ascending order, counted from 0. The eigenvalues are computed to high
+// relative accuracy when T warrants it. ZHEEVR returns the number m of
+// eigenvalues found, in ascending order in w[:m]. For jobz V their
+// orthonormal eigenvectors are returned in the first m columns of Z, which
+// has n columns, or iu-il+1 for rng I, and isuppz receives the supports of
+// the eigenvectors of T as in ZSTEMR. The uplo triangle of A is destroyed.
+// work holds lwork >= max(1,2*n) elements, rwork 20*n and iwork 10*n; the
+// optimal lwork is returned in work[0] by a call with lwork = -1 that does
+// nothing else.
+func (impl Implementation) ZHEEVR(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []complex128, lda int, vl, vu float64, il, iu int, w []float64, z []complex128, ldz int, isuppz []int, work []complex128, lwork int, rwork []float64, iwork []int) (m int, err error) {
+	if err := checkSyevr("ZHEEVR", jobz, rng, uplo, n, len(a), lda, float64(vl), float64(vu), il, iu, len(w), len(z), ldz, len(isuppz), len(work), lwork, len(rwork), len(iwork)); err != nil {
+		return 0, err
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(syevrWork(n)), 0)
+		return 0, nil
+	}
+	return syevr(impl.bl(), jobz, rng, uplo, n, a, lda, float64(vl), float64(vu), il, iu, w, z, ldz, isuppz, work, lwork, rwork, iwork), nil
+}
+
+// checkSyev checks the SYEV and HEEV routines. The real routines pass
+// lenRwork = -1.
+func checkSyev(routine string, jobz JobZ, uplo blas.Uplo, n, lenA, lda, lenW, lenWork, lwork, lenRwork int) error {
+	c := checker{routine: routine}
+	c.jobz(1, jobz)
+	c.uplo(2, uplo)
+	c.nonNeg(3, "n", n)
+	c.ld(5, "lda", lda, n, "n")
+	complex := lenRwork >= 0
+	if complex {
+		c.lwork(8, lwork, max(1, 2*n-1), "max(1,2*n-1)")
+	} else {
+		c.lwork(8, lwork, max(1, 3*n-1), "max(1,3*n-1)")
+	}
+	if c.ok() {
+		c.work(7, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(n, n, lda))
+			c.length(6, "w", lenW, n)
+			if complex {
+				c.length(9, "rwork", lenRwork, max(1, 3*n-2))
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkSyevd checks the SYEVD and HEEVD routines. The real routines pass
+// lenRwork = -1.
+func checkSyevd(routine string, jobz JobZ, uplo blas.Uplo, n, lenA, lda, lenW, lenWork, lwork, lenRwork, lenIwork int) error {
+	c := checker{routine: routine}
+	c.jobz(1, jobz)
+	c.uplo(2, uplo)
+	c.nonNeg(3, "n", n)
+	c.ld(5, "lda", lda, n, "n")
+	complex := lenRwork >= 0
+	wantz := jobz == JobZV
+	minWork, minRwork, minIwork := 1, 1, 0
+	expr := "1"
+	if n > 1 {
+		switch {
+		case complex && wantz:
+			minWork, minRwork, expr = 2*n-2, n+stedcWork(n), "2*n-2"
+		case complex:
+			minWork, minRwork, expr = n, n, "n"
+		case wantz:
+			minWork, expr = n+stedcWork(n), "3*n*n+6*n"
+		default:
+			minWork, expr = 2*n, "2*n"
+		}
+		if wantz {
+			minIwork = 3 * n
+		}
+	}
+	c.lwork(8, lwork, minWork, expr)
+	if c.ok() {
+		c.work(7, lenWork, lwork)
+		if lwork != -1 {
+			c.length(4, "a", lenA, matLen(n, n, lda))
+			c.length(6, "w", lenW, n)
+			iwork := 9
+			if complex {
+				c.length(9, "rwork", lenRwork, minRwork)
+				iwork = 10
+			}
+			c.length(iwork, "iwork", lenIwork, minIwork)
+		}
+	}
+	return c.result()
+}
+
+// checkSyevr checks the SYEVR and HEEVR routines. The real routines pass
+// lenRwork = -1.
+func checkSyevr(routine string, jobz JobZ, rng Range, uplo blas.Uplo, n, lenA, lda int, vl, vu float64, il, iu, lenW, lenZ, ldz, lenIsuppz, lenWork, lwork, lenRwork, lenIwork int) error {
+	c := checker{routine: routine}
+	c.jobz(1, jobz)
+	c.rng(2, rng)
+	c.uplo(3, uplo)
+	c.nonNeg(4, "n", n)
+	c.ld(6, "lda", lda, n, "n")
+	switch {
+	case rng == RangeV && n > 0 && vu <= vl:
+		c.fail(8, "vu", "must be greater than vl")
+	case rng == RangeI && (il < 0 || il > max(0, n-1)):
+		c.fail(9, "il", "must be in [0,max(0,n-1)]")
+	case rng == RangeI && (iu < min(n-1, il) || iu > n-1):
+		c.fail(10, "iu", "must be in [min(il,n-1),n-1]")
+	}
+	if jobz == JobZV {
+		c.ld(13, "ldz", ldz, n, "n")
+	} else {
+		c.atLeast(13, "ldz", ldz, 1, "1")
+	}
+	complex := lenRwork >= 0
+	if complex {
+		c.lwork(16, lwork, max(1, 2*n), "max(1,2*n)")
+	} else {
+		c.lwork(16, lwork, max(1, 22*n), "max(1,22*n)")
+	}
+	if c.ok() {
+		c.work(15, lenWork, lwork)
+		if lwork != -1 {
+			c.length(5, "a", lenA, matLen(n, n, lda))
+			c.length(11, "w", lenW, n)
+			if jobz == JobZV {
+				ncol := n
+				if rng == RangeI {
+					ncol = iu - il + 1
+				}
+				c.length(12, "z", lenZ, matLen(n, ncol, ldz))
+				c.length(14, "isuppz", lenIsuppz, 2*ncol)
+			}
+			iwork := 17
+			if complex {
+				c.length(17, "rwork", lenRwork, 20*n)
+				iwork = 18
+			}
+			c.length(iwork, "iwork", lenIwork, 10*n)
+		}
+	}
+	return c.result()
+}
+
+// syevWork returns the optimal length of the workspace of syev, which holds
+// the reflectors of the reduction followed by the workspace of sytrd and
+// orgtr.
+func syevWork(n int) int {
+	return max(1, n-1+max(sytrdWork(n), max(1, n-1)*qrBlock))
+}
+
+// syevdWork returns the optimal length of the workspace of the real SYEVD
+// routines, which hold the off-diagonal of the tridiagonal form followed by
+// the workspace of syev or, for jobz V, of stedc.
+func syevdWork(jobz JobZ, n int) int {
+	if jobz == JobZV && n > 1 {
+		return n + max(syevWork(n), stedcWork(n))
+	}
+	return n + syevWork(n)
+}
+
+// syevrWork returns the optimal length of the workspace of syevr, which
+// holds the reflectors of the reduction followed by the workspace of sytrd
+// and ormtr.
+func syevrWork(n int) int {
+	return max(1, n+max(sytrdWork(n), ormWork(blas.SideL, n, n)))
+}
+
+// syevScale returns the factor by which the Hermitian matrix A with largest
+// absolute element anrm is scaled into [rmin,rmax] before the reduction to
+// tridiagonal form, or 1 if it is not scaled.
+func syevScale(anrm, rmin, rmax float64) float64 {
+	switch {
+	case anrm > 0 && anrm < rmin:
+		return rmin / anrm
+	case anrm > rmax:
+		return rmax / anrm
+	}
+	return 1
+}
+
+// syev computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n Hermitian matrix A, of which the uplo triangle is referenced, as
+// xSYEV and xHEEV: A is scaled if its elements are very small or large,
+// reduced to tridiagonal form by sytrd, and the eigenvalues are computed by
+// sterf, or with the eigenvectors by orgtr and steqr. w receives the
+// eigenvalues in ascending order and, for jobz V, A the eigenvectors. e
+// holds n elements for the off-diagonal and rwork 2*n-2 for steqr; rwork is
+// used only after the reflectors and the workspace, so it may share them.
+// work holds lwork >= max(1,2*n-1) elements. syev returns 0 or the info of
+// sterf or steqr.
+func syev[T gen.Scalar, R gen.Float](bl blas.BLAS, jobz JobZ, uplo blas.Uplo, n int, a []T, lda int, w []R, work []T, lwork int, e, rwork []R) (info int) {
+	wantz := jobz == JobZV
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		w[0] = R(re(a[0]))
+		if wantz {
+			a[0] = 1
+		}
+		return 0
+	}
+	smlnum := safmin[T]() / eps[T]()
+	rmin, rmax := math.Sqrt(smlnum), math.Sqrt(1/smlnum)
+	scale := syevScale(lanhe(uplo, n, a, lda), rmin, rmax)
+	if scale != 1 {
+		lascl(uplo, 1, scale, n, n, a, lda)
+	}
+	tau, wk := work[:n-1], work[n-1:lwork]
+	sytrd(bl, uplo, n, a, lda, w, e, tau, wk, len(wk))
+	if wantz {
+		orgtr(bl, uplo, n, a, lda, tau, wk, len(wk))
+		info = steqr(bl, CompZV, n, w, e, a, lda, rwork)
+	} else {
+		info = sterf(n, w, e)
+	}
+	if scale != 1 {
+		imax := n
+		if info > 0 {
+			imax = info - 1
+		}
+		lascl(uploAll, scale, 1, imax, 1, w, n)
+	}
+	return info
+}
+
+// syevd computes all the eigenvalues and, for jobz V, the eigenvectors of
+// the n×n Hermitian matrix A as syev does, with stedc in place of steqr, as
+// xSYEVD and xHEEVD. e holds n elements for the off-diagonal, and for jobz V
+// rwork holds stedcWork(n) elements and iwork 3*n; rwork is used only after
+// the reflectors and the workspace, so it may share them. work holds
+// lwork >= max(1,2*n-2) elements for jobz V and n for jobz N. syevd returns
+// 0 or the info of sterf or stedc.
+func syevd[T gen.Scalar, R gen.Float](bl blas.BLAS, jobz JobZ, uplo blas.Uplo, n int, a []T, lda int, w []R, work []T, lwork int, e, rwork []R, iwork []int) (info int) {
+	wantz := jobz == JobZV
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		w[0] = R(re(a[0]))
+		if wantz {
+			a[0] = 1
+		}
+		return 0
+	}
+	smlnum := safmin[T]() / eps[T]()
+	rmin, rmax := math.Sqrt(smlnum), math.Sqrt(1/smlnum)
+	scale := syevScale(lanhe(uplo, n, a, lda), rmin, rmax)
+	if scale != 1 {
+		lascl(uplo, 1, scale, n, n, a, lda)
+	}
+	tau, wk := work[:n-1], work[n-1:lwork]
+	sytrd(bl, uplo, n, a, lda, w, e, tau, wk, len(wk))
+	if wantz {
+		orgtr(bl, uplo, n, a, lda, tau, wk, len(wk))
+		info = stedc(bl, CompZV, n, w, e, a, lda, rwork, iwork)
+	} else {
+		info = sterf(n, w, e)
+	}
+	if scale != 1 {
+		lascl(uploAll, scale, 1, n, 1, w, n)
+	}
+	return info
+}
+
+// syevr computes selected eigenvalues and, for jobz V, eigenvectors of the
+// n×n Hermitian matrix A, of which the uplo triangle is referenced, as
+// xSYEVR and xHEEVR: A is scaled if its elements are very small or large,
+// reduced to tridiagonal form T by sytrd, the selected eigenpairs of T are
+// computed by stemr, and its eigenvectors are multiplied by the unitary
+// matrix of the reduction with ormtr. syevr returns the number m of
+// eigenvalues found, in ascending order in w[:m] with their eigenvectors in
+// Z. work holds lwork >= max(1,2*n) elements, rwork 20*n and iwork 10*n.
+func syevr[T gen.Scalar, R gen.Float](bl blas.BLAS, jobz JobZ, rng Range, uplo blas.Uplo, n int, a []T, lda int, vl, vu float64, il, iu int, w []R, z []T, ldz int, isuppz []int, work []T, lwork int, rwork []R, iwork []int) (m int) {
+	wantz := jobz == JobZV
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		a0 := re(a[0])
+		if rng == RangeV && (a0 <= vl || a0 > vu) {
+			return 0
+		}
+		w[0] = R(a0)
+		if wantz {
+			z[0] = 1
+			isuppz[0], isuppz[1] = 0, 0
+		}
+		return 1
+	}
+	sfmin := safmin[T]()
+	smlnum := sfmin / eps[T]()
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(1/smlnum), 1/math.Sqrt(math.Sqrt(sfmin)))
+	scale := syevScale(lanhe(uplo, n, a, lda), rmin, rmax)
+	if scale != 1 {
+		lascl(uplo, 1, scale, n, n, a, lda)
+		vl *= scale
+		vu *= scale
+	}
+	d, e := rwork[:n], rwork[n:2*n]
+	tau, wk := work[:n-1], work[n-1:lwork]
+	sytrd(bl, uplo, n, a, lda, d, e, tau, wk, len(wk))
+	m, _ = stemr(bl, jobz, rng, n, d, e, vl, vu, il, iu, w, z, ldz, isuppz, true, rwork[2*n:], iwork)
+	if wantz && m > 0 {
+		ormtr(bl, blas.SideL, uplo, blas.TransN, n, m, a, lda, tau, z, ldz, wk, len(wk))
+	}
+	if scale != 1 {
+		lascl(uploAll, scale, 1, m, 1, w, n)
+	}
+	return m
+}
@@ -0,0 +1,330 @@
+package lapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// syevRoutines holds the symmetric or Hermitian eigenvalue routines of one
+// precision, with the rwork and iwork of their complex and divide and
+// conquer versions allocated by the test.
+type syevRoutines[T gen.Scalar, R gen.Float] struct {
+	syev  func(jobz JobZ, uplo blas.Uplo, n int, a []T, lda int, w []R, work []T, lwork int) error
+	syevd func(jobz JobZ, uplo blas.Uplo, n int, a []T, lda int, w []R, work []T, lwork int) error
+	syevr func(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []T, lda int, vl, vu R, il, iu int, w []R, z []T, ldz int, isuppz []int, work []T, lwork int) (int, error)
+}
+
+func TestSYEV(t *testing.T) {
+	var impl Implementation
+	testSYEV(t, "S", syevRoutines[float32, float32]{impl.SSYEV,
+		func(jobz JobZ, uplo blas.Uplo, n int, a []float32, lda int, w []float32, work []float32, lwork int) error {
+			return impl.SSYEVD(jobz, uplo, n, a, lda, w, work, lwork, make([]int, 3*n))
+		},
+		func(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []float32, lda int, vl, vu float32, il, iu int, w []float32, z []float32, ldz int, isuppz []int, work []float32, lwork int) (int, error) {
+			return impl.SSYEVR(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, w, z, ldz, isuppz, work, lwork, make([]int, 10*n))
+		}})
+	testSYEV(t, "D", syevRoutines[float64, float64]{impl.DSYEV,
+		func(jobz JobZ, uplo blas.Uplo, n int, a []float64, lda int, w []float64, work []float64, lwork int) error {
+			return impl.DSYEVD(jobz, uplo, n, a, lda, w, work, lwork, make([]int, 3*n))
+		},
+		func(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w []float64, z []float64, ldz int, isuppz []int, work []float64, lwork int) (int, error) {
+			return impl.DSYEVR(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, w, z, ldz, isuppz, work, lwork, make([]int, 10*n))
+		}})
+	testSYEV(t, "C", syevRoutines[complex64, float32]{
+		func(jobz JobZ, uplo blas.Uplo, n int, a []complex64, lda int, w []float32, work []complex64, lwork int) error {
+			return impl.CHEEV(jobz, uplo, n, a, lda, w, work, lwork, make([]float32, max(1, 3*n-2)))
+		},
+		func(jobz JobZ, uplo blas.Uplo, n int, a []complex64, lda int, w []float32, work []complex64, lwork int) error {
+			return impl.CHEEVD(jobz, uplo, n, a, lda, w, work, lwork, make([]float32, max(1, n+stedcWork(n))), make([]int, 3*n))
+		},
+		func(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []complex64, lda int, vl, vu float32, il, iu int, w []float32, z []complex64, ldz int, isuppz []int, work []complex64, lwork int) (int, error) {
+			return impl.CHEEVR(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, w, z, ldz, isuppz, work, lwork, make([]float32, 20*n), make([]int, 10*n))
+		}})
+	testSYEV(t, "Z", syevRoutines[complex128, float64]{
+		func(jobz JobZ, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int) error {
+			return impl.ZHEEV(jobz, uplo, n, a, lda, w, work, lwork, make([]float64, max(1, 3*n-2)))
+		},
+		func(jobz JobZ, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int) error {
+			return impl.ZHEEVD(jobz, uplo, n, a, lda, w, work, lwork, make([]float64, max(1, n+stedcWork(n))), make([]int, 3*n))
+		},
+		func(jobz JobZ, rng Range, uplo blas.Uplo, n int, a []complex128, lda int, vl, vu float64, il, iu int, w []float64, z []complex128, ldz int, isuppz []int, work []complex128, lwork int) (int, error) {
+			return impl.ZHEEVR(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, w, z, ldz, isuppz, work, lwork, make([]float64, 20*n), make([]int, 10*n))
+		}})
+}
+
+func testSYEV[T gen.Scalar, R gen.Float](t *testing.T, prec string, f syevRoutines[T, R]) {
+	rnd := rand.New(rand.NewSource(1))
+	names := [3]string{prec + "SYEV", prec + "SYEVD", prec + "SYEVR"}
+	if isComplex[T]() {
+		names = [3]string{prec + "HEEV", prec + "HEEVD", prec + "HEEVR"}
+	}
+	// The minimum workspaces of xSYEV, xSYEVD and xSYEVR.
+	minWork := func(i int, jobz JobZ, n int) int {
+		switch {
+		case i == 0 && isComplex[T]():
+			return 2*n - 1
+		case i == 0:
+			return 3*n - 1
+		case i == 1 && n <= 1:
+			return 1
+		case i == 1 && isComplex[T]() && jobz == JobZV:
+			return 2*n - 2
+		case i == 1 && isComplex[T]():
+			return n
+		case i == 1 && jobz == JobZV:
+			return 3*n*n + 6*n
+		case i == 1:
+			return 2 * n
+		case isComplex[T]():
+			return 2 * n
+		}
+		return 22 * n
+	}
+
+	// Random matrices, of orders above trdBlock for the blocked reduction,
+	// the identity, whose eigenvalue is of multiplicity n, and matrices of
+	// known spectra with clusters of equal and of close eigenvalues. The
+	// triangle opposite to uplo holds NaN.
+	type matrix struct {
+		name string
+		n    int
+		a    []T
+		want []float64 // the eigenvalues, if known
+	}
+	var mats []matrix
+	for _, n := range []int{0, 1, 2, 5, 40, 100} {
+		mats = append(mats, matrix{"random", n, hermMat[T](rnd, n), nil})
+	}
+	mats = append(mats, matrix{"identity", 12, spectrumMat[T](rnd, slices.Repeat([]float64{1}, 12)), slices.Repeat([]float64{1}, 12)})
+	clustered := []float64{-3, -3, -3, -1e-3, 0, 0, 1e-9, 2, 2 + 1e-10, 2 + 2e-10, 5, 5, 5, 5, 7}
+	mats = append(mats, matrix{"clustered", len(clustered), spectrumMat[T](rnd, clustered), clustered})
+
+	for _, mat := range mats {
+		n := mat.n
+		lda := n + 2
+		for _, uplo := range []blas.Uplo{blas.UploU, blas.UploL} {
+			a := storeHerm(rnd, uplo, n, mat.a, lda)
+			norm := normF(n, n, mat.a, n)
+			desc := fmt.Sprintf("uplo=%c %s n=%d", uplo, mat.name, n)
+
+			// The eigenvalues of xSYEV with the eigenvectors are the
+			// reference for the others.
+			var ref []float64
+			for i, fn := range []func(jobz JobZ, uplo blas.Uplo, n int, a []T, lda int, w []R, work []T, lwork int) error{f.syev, f.syevd} {
+				for _, jobz := range []JobZ{JobZV, JobZN} {
+					for _, v := range workVariants[1:] {
+						name := fmt.Sprintf("%s %s jobz=%c %s", names[i], v, jobz, desc)
+						ac := slices.Clone(a)
+						w := make([]R, n)
+						if err := withWork(v, minWork(i, jobz, n), nil, func(work []T, lwork int) error {
+							return fn(jobz, uplo, n, ac, lda, w, work, lwork)
+						}); err != nil {
+							t.Errorf("%s: unexpected error %v", name, err)
+							continue
+						}
+						if !samePad(n, n, lda, ac, a) {
+							t.Errorf("%s: elements outside A modified", name)
+						}
+						wf := toFloat64(w)
+						if jobz == JobZV {
+							checkEigen(t, name, n, mat.a, ac, lda, wf)
+						}
+						if ref == nil {
+							ref = wf
+						}
+						checkEigenvalues[T](t, name, n, wf, ref, mat.want, norm)
+					}
+				}
+			}
+
+			// xSYEVR with every range. The intervals of rng V end halfway
+			// between eigenvalues, and the empty ones lie between two
+			// eigenvalues or above them all.
+			type selection struct {
+				rng    Range
+				vl, vu float64
+				il, iu int
+			}
+			sels := []selection{{rng: RangeA, il: 0, iu: n - 1}}
+			mid := func(i int) float64 {
+				switch {
+				case i < 0:
+					return ref[0] - 1
+				case i >= n-1:
+					return ref[n-1] + 1
+				}
+				return (ref[i] + ref[i+1]) / 2
+			}
+			if n > 0 {
+				il, iu := n/4, (3*n)/4
+				for iu > il && iu < n-1 && ref[iu+1]-ref[iu] < 1e-2*norm {
+					iu++
+				}
+				for il > 0 && ref[il]-ref[il-1] < 1e-2*norm {
+					il--
+				}
+				sels = append(sels,
+					selection{rng: RangeI, il: il, iu: iu},
+					selection{rng: RangeI, il: il, iu: il},
+					selection{rng: RangeI, il: n - 1, iu: n - 1},
+					selection{rng: RangeI, il: 0, iu: n - 1},
+					selection{rng: RangeV, vl: mid(-1), vu: mid(n - 1), il: 0, iu: n - 1},
+					selection{rng: RangeV, vl: mid(n-1) + 1, vu: mid(n-1) + 2, il: 0, iu: -1})
+				if il > 0 && ref[il]-ref[il-1] > 1e-2*norm {
+					sels = append(sels,
+						selection{rng: RangeV, vl: mid(il - 1), vu: mid(iu), il: il, iu: iu},
+						selection{rng: RangeV, vl: mid(il - 1), vu: mid(il-1) + 1e-3*(ref[il]-ref[il-1]), il: il, iu: il - 1})
+				}
+			}
+			for _, sel := range sels {
+				for _, jobz := range []JobZ{JobZV, JobZN} {
+					for _, v := range workVariants[1:] {
+						name := fmt.Sprintf("%s %s jobz=%c range=%c", names[2], v, jobz, sel.rng)
+						switch sel.rng {
+						case RangeV:
+							name += fmt.Sprintf(" vl=%.6g vu=%.6g", sel.vl, sel.vu)
+						case RangeI:
+							name += fmt.Sprintf(" il=%d iu=%d", sel.il, sel.iu)
+						}
+						name += " " + desc
+						ac := slices.Clone(a)
+						w := make([]R, n)
+						ncol := n
+						if sel.rng == RangeI {
+							ncol = sel.iu - sel.il + 1
+						}
+						ldz := n + 1
+						var z []T
+						var isuppz []int
+						if jobz == JobZV {
+							z, isuppz = make([]T, ldz*ncol), make([]int, 2*ncol)
+						}
+						var m int
+						if err := withWork(v, max(1, minWork(2, jobz, n)), nil, func(work []T, lwork int) (err error) {
+							m, err = f.syevr(jobz, sel.rng, uplo, n, ac, lda, R(sel.vl), R(sel.vu), sel.il, sel.iu, w, z, ldz, isuppz, work, lwork)
+							return err
+						}); err != nil {
+							t.Errorf("%s: unexpected error %v", name, err)
+							continue
+						}
+						if want := sel.iu - sel.il + 1; m != want {
+							t.Errorf("%s: m = %d, want %d", name, m, want)
+							continue
+						}
+						wf := toFloat64(w[:m])
+						if jobz == JobZV {
+							checkEigen(t, name, n, mat.a, z, ldz, wf)
+						}
+						var want []float64
+						if mat.want != nil {
+							want = mat.want[sel.il : sel.iu+1]
+						}
+						checkEigenvalues[T](t, name, n, wf, ref[sel.il:sel.iu+1], want, norm)
+					}
+				}
+			}
+		}
+	}
+}
+
+// hermMat returns a random n×n Hermitian matrix with leading dimension n.
+func hermMat[T gen.Scalar](rnd *rand.Rand, n int) []T {
+	a := randMat[T](rnd, n, n, n)
+	for j := 0; j < n; j++ {
+		a[j+j*n] = fromReal[T](re(a[j+j*n]))
+		for i := j + 1; i < n; i++ {
+			a[j+i*n] = conj(a[i+j*n])
+		}
+	}
+	return a
+}
+
+// spectrumMat returns the n×n Hermitian matrix Q*diag(d)*Qᴴ, with leading
+// dimension n, whose eigenvalues are the n elements of d. Q is the product
+// of two random Householder reflectors, which leaves no element zero.
+func spectrumMat[T gen.Scalar](rnd *rand.Rand, d []float64) []T {
+	n := len(d)
+	a := make([]T, n*n)
+	for i, di := range d {
+		a[i+i*n] = fromReal[T](di)
+	}
+	for range 2 {
+		v := randMat[T](rnd, n, 1, n)
+		vv := normF(n, 1, v, n)
+		q := make([]T, n*n)
+		for j := 0; j < n; j++ {
+			for i := 0; i < n; i++ {
+				q[i+j*n] = -v[i] * conj(v[j]) * fromReal[T](2/(vv*vv))
+			}
+			q[j+j*n]++
+		}
+		a = mulMat(blas.TransN, blas.TransN, n, n, n, q, n, mulMat(blas.TransN, blas.TransC, n, n, n, a, n, q, n), n)
+	}
+	for j := 0; j < n; j++ {
+		a[j+j*n] = fromReal[T](re(a[j+j*n]))
+		for i := j + 1; i < n; i++ {
+			a[j+i*n] = conj(a[i+j*n])
+		}
+	}
+	return a
+}
+
+// toFloat64 returns the elements of w as float64.
+func toFloat64[R gen.Float](w []R) []float64 {
+	wf := make([]float64, len(w))
+	for i, v := range w {
+		wf[i] = float64(v)
+	}
+	return wf
+}
+
+// checkEigen checks the eigenpairs of the n×n Hermitian matrix A, with
+// leading dimension n, whose m eigenvectors are the columns of Z for the
+// eigenvalues w: ‖A*Z - Z*diag(w)‖ and the orthonormality of Z.
+func checkEigen[T gen.Scalar](t *testing.T, name string, n int, a, z []T, ldz int, w []float64) {
+	t.Helper()
+	m := len(w)
+	az := mulMat(blas.TransN, blas.TransN, n, m, n, a, n, z, ldz)
+	for j := 0; j < m; j++ {
+		for i := 0; i < n; i++ {
+			az[i+j*n] -= z[i+j*ldz] * fromReal[T](w[j])
+		}
+	}
+	if r := ratio[T](normF(n, m, az, n), normF(n, n, a, n), n); r > maxRatio {
+		t.Errorf("%s: ‖A*Z - Z*diag(w)‖ ratio %.3g", name, r)
+	}
+	if r := orthRatio(n, m, z, ldz); r > maxRatio {
+		t.Errorf("%s: ‖Zᴴ*Z - I‖ ratio %.3g", name, r)
+	}
+}
+
+// checkEigenvalues checks that the eigenvalues w of an n×n Hermitian matrix
+// with norm ‖A‖ are in ascending order and agree with the reference ref and
+// with the exact eigenvalues want, if known.
+func checkEigenvalues[T gen.Scalar](t *testing.T, name string, n int, w, ref, want []float64, norm float64) {
+	t.Helper()
+	if !slices.IsSorted(w) {
+		t.Errorf("%s: eigenvalues %v not in ascending order", name, w)
+	}
+	for _, c := range []struct {
+		what string
+		w    []float64
+	}{{"the reference", ref}, {"the exact eigenvalues", want}} {
+		if c.w == nil {
+			continue
+		}
+		var d float64
+		for i := range w {
+			d = math.Max(d, math.Abs(w[i]-c.w[i]))
+		}
+		if r := ratio[T](d, norm, n); r > maxRatio {
+			t.Errorf("%s: eigenvalues differ from %s, ratio %.3g", name, c.what, r)
+		}
+	}
+}
@@ -0,0 +1,578 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// SSYTD2 reduces the n×n symmetric matrix A, of which only the uplo triangle
+// is referenced, to real symmetric tridiagonal form T = Q**T*A*Q by an
+// orthogonal similarity transformation, one column at a time. The diagonal
+// and off-diagonal of T are returned in d and e and overwrite the
+// corresponding elements of A. Q is represented as a product of n-1
+// elementary reflectors H(i) = I - tau[i]*v*v**H: for uplo U,
+// Q = H(n-2)*...*H(0) and v[0:i] is stored above the diagonal in column i+1
+// of A, v[i] = 1 and v[i+1:] = 0; for uplo L, Q = H(0)*...*H(n-2),
+// v[:i+1] = 0, v[i+1] = 1 and v[i+2:] is stored below the subdiagonal in
+// column i.
+func (impl Implementation) SSYTD2(uplo blas.Uplo, n int, a []float32, lda int, d, e []float32, tau []float32) error {
+	if err := checkSytrd("SSYTD2", uplo, n, len(a), lda, len(d), len(e), len(tau), 0, noLwork); err != nil {
+		return err
+	}
+	sytd2(impl.bl(), uplo, n, a, lda, d, e, tau)
+	return nil
+}
+
+// SSYTRD computes the reduction of SSYTD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with the rank-2k update
+// SSYR2K. work holds lwork >= 1 elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SSYTRD(uplo blas.Uplo, n int, a []float32, lda int, d, e []float32, tau, work []float32, lwork int) error {
+	if err := checkSytrd("SSYTRD", uplo, n, len(a), lda, len(d), len(e), len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	sytrd(impl.bl(), uplo, n, a, lda, d, e, tau, work, lwork)
+	return nil
+}
+
+// SORGTR overwrites the n×n matrix A, which holds the reflectors left by
+// SSYTRD with the same uplo, with the orthogonal matrix Q of the reduction.
+// work holds lwork >= max(1,n-1) elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) SORGTR(uplo blas.Uplo, n int, a []float32, lda int, tau, work []float32, lwork int) error {
+	if err := checkOrgtr("SORGTR", uplo, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgtr(impl.bl(), uplo, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// SORMTR overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q) (side
+// R), where Q is the orthogonal matrix of order nq, m for side L and n for
+// side R, given by the reflectors left in A and tau by SSYTRD with the same
+// uplo, and op(Q) is Q (trans N) or Q**T (trans T). work holds
+// lwork >= max(1,nw) elements, where nw is n for side L and m for side R;
+// the optimal lwork is returned in work[0] by a call with lwork = -1 that
+// does nothing else.
+func (impl Implementation) SORMTR(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int) error {
+	if err := checkOrmtr("SORMTR", side, uplo, trans, m, n, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false); err != nil {
+		return err
+	}
+	ormtr(impl.bl(), side, uplo, trans, m, n, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// DSYTD2 reduces the n×n symmetric matrix A, of which only the uplo triangle
+// is referenced, to real symmetric tridiagonal form T = Q**T*A*Q by an
+// orthogonal similarity transformation, one column at a time. The diagonal
+// and off-diagonal of T are returned in d and e and overwrite the
+// corresponding elements of A. Q is represented as a product of n-1
+// elementary reflectors H(i) = I - tau[i]*v*v**H: for uplo U,
+// Q = H(n-2)*...*H(0) and v[0:i] is stored above the diagonal in column i+1
+// of A, v[i] = 1 and v[i+1:] = 0; for uplo L, Q = H(0)*...*H(n-2),
+// v[:i+1] = 0, v[i+1] = 1 and v[i+2:] is stored below the subdiagonal in
+// column i.
+func (impl Implementation) DSYTD2(uplo blas.Uplo, n int, a []float64, lda int, d, e []float64, tau []float64) error {
+	if err := checkSytrd("DSYTD2", uplo, n, len(a), lda, len(d), len(e), len(tau), 0, noLwork); err != nil {
+		return err
+	}
+	sytd2(impl.bl(), uplo, n, a, lda, d, e, tau)
+	return nil
+}
+
+// DSYTRD computes the reduction of DSYTD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with the rank-2k update
+// DSYR2K. work holds lwork >= 1 elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DSYTRD(uplo blas.Uplo, n int, a []float64, lda int, d, e []float64, tau, work []float64, lwork int) error {
+	if err := checkSytrd("DSYTRD", uplo, n, len(a), lda, len(d), len(e), len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	sytrd(impl.bl(), uplo, n, a, lda, d, e, tau, work, lwork)
+	return nil
+}
+
+// DORGTR overwrites the n×n matrix A, which holds the reflectors left by
+// DSYTRD with the same uplo, with the orthogonal matrix Q of the reduction.
+// work holds lwork >= max(1,n-1) elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) DORGTR(uplo blas.Uplo, n int, a []float64, lda int, tau, work []float64, lwork int) error {
+	if err := checkOrgtr("DORGTR", uplo, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgtr(impl.bl(), uplo, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// DORMTR overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q) (side
+// R), where Q is the orthogonal matrix of order nq, m for side L and n for
+// side R, given by the reflectors left in A and tau by DSYTRD with the same
+// uplo, and op(Q) is Q (trans N) or Q**T (trans T). work holds
+// lwork >= max(1,nw) elements, where nw is n for side L and m for side R;
+// the optimal lwork is returned in work[0] by a call with lwork = -1 that
+// does nothing else.
+func (impl Implementation) DORMTR(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) error {
+	if err := checkOrmtr("DORMTR", side, uplo, trans, m, n, len(a), lda, len(tau), len(c), ldc, len(work), lwork, false); err != nil {
+		return err
+	}
+	ormtr(impl.bl(), side, uplo, trans, m, n, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// CHETD2 reduces the n×n Hermitian matrix A, of which only the uplo triangle
+// is referenced, to real symmetric tridiagonal form T = Q**H*A*Q by a
+// unitary similarity transformation, one column at a time. The diagonal and
+// off-diagonal of T are returned in d and e and overwrite the corresponding
+// elements of A. Q is represented as a product of n-1 elementary reflectors
+// H(i) = I - tau[i]*v*v**H: for uplo U, Q = H(n-2)*...*H(0) and v[0:i] is
+// stored above the diagonal in column i+1 of A, v[i] = 1 and v[i+1:] = 0;
+// for uplo L, Q = H(0)*...*H(n-2), v[:i+1] = 0, v[i+1] = 1 and v[i+2:] is
+// stored below the subdiagonal in column i.
+func (impl Implementation) CHETD2(uplo blas.Uplo, n int, a []complex64, lda int, d, e []float32, tau []complex64) error {
+	if err := checkSytrd("CHETD2", uplo, n, len(a), lda, len(d), len(e), len(tau), 0, noLwork); err != nil {
+		return err
+	}
+	sytd2(impl.bl(), uplo, n, a, lda, d, e, tau)
+	return nil
+}
+
+// CHETRD computes the reduction of CHETD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with the rank-2k update
+// CHER2K. work holds lwork >= 1 elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CHETRD(uplo blas.Uplo, n int, a []complex64, lda int, d, e []float32, tau, work []complex64, lwork int) error {
+	if err := checkSytrd("CHETRD", uplo, n, len(a), lda, len(d), len(e), len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	sytrd(impl.bl(), uplo, n, a, lda, d, e, tau, work, lwork)
+	return nil
+}
+
+// CUNGTR overwrites the n×n matrix A, which holds the reflectors left by
+// CHETRD with the same uplo, with the unitary matrix Q of the reduction.
+// work holds lwork >= max(1,n-1) elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNGTR(uplo blas.Uplo, n int, a []complex64, lda int, tau, work []complex64, lwork int) error {
+	if err := checkOrgtr("CUNGTR", uplo, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgtr(impl.bl(), uplo, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// CUNMTR overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q) (side
+// R), where Q is the unitary matrix of order nq, m for side L and n for side
+// R, given by the reflectors left in A and tau by CHETRD with the same uplo,
+// and op(Q) is Q (trans N) or Q**H (trans C). work holds lwork >= max(1,nw)
+// elements, where nw is n for side L and m for side R; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) CUNMTR(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []complex64, lda int, tau, c []complex64, ldc int, work []complex64, lwork int) error {
+	if err := checkOrmtr("CUNMTR", side, uplo, trans, m, n, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true); err != nil {
+		return err
+	}
+	ormtr(impl.bl(), side, uplo, trans, m, n, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// ZHETD2 reduces the n×n Hermitian matrix A, of which only the uplo triangle
+// is referenced, to real symmetric tridiagonal form T = Q**H*A*Q by a
+// unitary similarity transformation, one column at a time. The diagonal and
+// off-diagonal of T are returned in d and e and overwrite the corresponding
+// elements of A. Q is represented as a product of n-1 elementary reflectors
+// H(i) = I - tau[i]*v*v**H: for uplo U, Q = H(n-2)*...*H(0) and v[0:i] is
+// stored above the diagonal in column i+1 of A, v[i] = 1 and v[i+1:] = 0;
+// for uplo L, Q = H(0)*...*H(n-2), v[:i+1] = 0, v[i+1] = 1 and v[i+2:] is
+// stored below the subdiagonal in column i.
+func (impl Implementation) ZHETD2(uplo blas.Uplo, n int, a []complex128, lda int, d, e []float64, tau []complex128) error {
+	if err := checkSytrd("ZHETD2", uplo, n, len(a), lda, len(d), len(e), len(tau), 0, noLwork); err != nil {
+		return err
+	}
+	sytd2(impl.bl(), uplo, n, a, lda, d, e, tau)
+	return nil
+}
+
+// ZHETRD computes the reduction of ZHETD2 with a blocked algorithm that
+// reduces panels of A and updates the rest of it with the rank-2k update
+// ZHER2K. work holds lwork >= 1 elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZHETRD(uplo blas.Uplo, n int, a []complex128, lda int, d, e []float64, tau, work []complex128, lwork int) error {
+	if err := checkSytrd("ZHETRD", uplo, n, len(a), lda, len(d), len(e), len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	sytrd(impl.bl(), uplo, n, a, lda, d, e, tau, work, lwork)
+	return nil
+}
+
+// ZUNGTR overwrites the n×n matrix A, which holds the reflectors left by
+// ZHETRD with the same uplo, with the unitary matrix Q of the reduction.
+// work holds lwork >= max(1,n-1) elements; the optimal lwork is returned in
+// work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNGTR(uplo blas.Uplo, n int, a []complex128, lda int, tau, work []complex128, lwork int) error {
+	if err := checkOrgtr("ZUNGTR", uplo, n, len(a), lda, len(tau), len(work), lwork); err != nil {
+		return err
+	}
+	orgtr(impl.bl(), uplo, n, a, lda, tau, work, lwork)
+	return nil
+}
+
+// ZUNMTR overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q) (side
+// R), where Q is the unitary matrix of order nq, m for side L and n for side
+// R, given by the reflectors left in A and tau by ZHETRD with the same uplo,
+// and op(Q) is Q (trans N) or Q**H (trans C). work holds lwork >= max(1,nw)
+// elements, where nw is n for side L and m for side R; the optimal lwork is
+// returned in work[0] by a call with lwork = -1 that does nothing else.
+func (impl Implementation) ZUNMTR(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int) error {
+	if err := checkOrmtr("ZUNMTR", side, uplo, trans, m, n, len(a), lda, len(tau), len(c), ldc, len(work), lwork, true); err != nil {
+		return err
+	}
+	ormtr(impl.bl(), side, uplo, trans, m, n, a, lda, tau, c, ldc, work, lwork)
+	return nil
+}
+
+// trdBlock is the block size of sytrd, the value of ILAENV for xSYTRD.
+const trdBlock = 32
+
+// trdCrossover is the order below which sytrd uses the unblocked code, the
+// value of ILAENV(3, ...) for xSYTRD.
+const trdCrossover = 32
+
+// checkSytrd checks the SYTRD and SYTD2 routines. SYTD2 has no workspace
+// and passes noLwork.
+func checkSytrd(routine string, uplo blas.Uplo, n, lenA, lda, lenD, lenE, lenTau, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, n, "n")
+	if lwork != noLwork {
+		c.lwork(9, lwork, 1, "1")
+	}
+	if c.ok() {
+		if lwork != noLwork {
+			c.work(8, lenWork, lwork)
+		}
+		if lwork != -1 {
+			c.length(3, "a", lenA, matLen(n, n, lda))
+			c.length(5, "d", lenD, n)
+			c.length(6, "e", lenE, n-1)
+			c.length(7, "tau", lenTau, n-1)
+		}
+	}
+	return c.result()
+}
+
+// checkOrgtr checks the ORGTR and UNGTR routines.
+func checkOrgtr(routine string, uplo blas.Uplo, n, lenA, lda, lenTau, lenWork, lwork int) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.nonNeg(2, "n", n)
+	c.ld(4, "lda", lda, n, "n")
+	c.lwork(7, lwork, max(1, n-1), "max(1,n-1)")
+	if c.ok() {
+		c.work(6, lenWork, lwork)
+		if lwork != -1 {
+			c.length(3, "a", lenA, matLen(n, n, lda))
+			c.length(5, "tau", lenTau, n-1)
+		}
+	}
+	return c.result()
+}
+
+// checkOrmtr checks the ORMTR and UNMTR routines.
+func checkOrmtr(routine string, side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n, lenA, lda, lenTau, lenC, ldc, lenWork, lwork int, complex bool) error {
+	c := checker{routine: routine}
+	c.side(1, side)
+	c.uplo(2, uplo)
+	c.transQ(3, trans, complex)
+	c.nonNeg(4, "m", m)
+	c.nonNeg(5, "n", n)
+	nq, nw := m, n
+	if side == blas.SideR {
+		nq, nw = n, m
+	}
+	c.ld(7, "lda", lda, nq, "nq")
+	c.ld(10, "ldc", ldc, m, "m")
+	c.lwork(12, lwork, max(1, nw), "max(1,nw)")
+	if c.ok() {
+		c.work(11, lenWork, lwork)
+		if lwork != -1 {
+			c.length(6, "a", lenA, matLen(nq, nq, lda))
+			c.length(8, "tau", lenTau, nq-1)
+			c.length(9, "c", lenC, matLen(m, n, ldc))
+		}
+	}
+	return c.result()
+}
+
+// sytd2 reduces the n×n Hermitian matrix A, of which the uplo triangle is
+// referenced, to real symmetric tridiagonal form T = Q**H*A*Q one column at
+// a time, as xSYTD2 and xHETD2. The diagonal and off-diagonal of T are
+// returned in d and e and overwrite those of A. For uplo U,
+// Q = H(n-2)*...*H(0) and the vector of H(i) is stored above the diagonal
+// in column i+1 of A with its unit element i implied; for uplo L,
+// Q = H(0)*...*H(n-2) and the vector of H(i) is stored below the
+// subdiagonal in column i with its unit element i+1 implied.
+func sytd2[T gen.Scalar, R gen.Float](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int, d, e []R, tau []T) {
+	if n == 0 {
+		return
+	}
+	half := T(0.5)
+	if uplo == blas.UploU {
+		a[n-1+(n-1)*lda] = fromReal[T](re(a[n-1+(n-1)*lda]))
+		for i := n - 2; i >= 0; i-- {
+			// Generate H(i) to annihilate A(0:i,i+1).
+			col := a[(i+1)*lda:]
+			beta, taui := larfg(bl, i+1, col[i], col, 1)
+			e[i] = R(re(beta))
+			if taui != 0 {
+				col[i] = 1
+				// Compute x = tau*A*v in tau[0:i+1] and w = x -
+				// tau/2*(x**H*v)*v, and apply the rank-2 update
+				// A = A - v*w**H - w*v**H.
+				hemv(bl, blas.UploU, i+1, taui, a, lda, col, 1, 0, tau, 1)
+				alpha := -half * taui * dotc(bl, i+1, tau, 1, col, 1)
+				axpy(bl, i+1, alpha, col, 1, tau, 1)
+				her2(bl, blas.UploU, i+1, -1, col, 1, tau, 1, a, lda)
+			} else {
+				a[i+i*lda] = fromReal[T](re(a[i+i*lda]))
+			}
+			col[i] = fromReal[T](float64(e[i]))
+			d[i+1] = R(re(a[i+1+(i+1)*lda]))
+			tau[i] = taui
+		}
+		d[0] = R(re(a[0]))
+		return
+	}
+	a[0] = fromReal[T](re(a[0]))
+	for i := 0; i < n-1; i++ {
+		// Generate H(i) to annihilate A(i+2:n,i).
+		col := a[i+1+i*lda:]
+		beta, taui := larfg(bl, n-i-1, col[0], a[min(i+2, n-1)+i*lda:], 1)
+		e[i] = R(re(beta))
+		a22 := a[i+1+(i+1)*lda:]
+		if taui != 0 {
+			col[0] = 1
+			hemv(bl, blas.UploL, n-i-1, taui, a22, lda, col, 1, 0, tau[i:], 1)
+			alpha := -half * taui * dotc(bl, n-i-1, tau[i:], 1, col, 1)
+			axpy(bl, n-i-1, alpha, col, 1, tau[i:], 1)
+			her2(bl, blas.UploL, n-i-1, -1, col, 1, tau[i:], 1, a22, lda)
+		} else {
+			a22[0] = fromReal[T](re(a22[0]))
+		}
+		col[0] = fromReal[T](float64(e[i]))
+		d[i] = R(re(a[i+i*lda]))
+		tau[i] = taui
+	}
+	d[n-1] = R(re(a[n-1+(n-1)*lda]))
+}
+
+// latrd reduces nb rows and columns of the n×n Hermitian matrix A to
+// tridiagonal form as sytd2 does, the last nb for uplo U and the first nb
+// for uplo L, and returns the n×nb matrix W needed to update the unreduced
+// part of A as A - V*W**H - W*V**H, as xLATRD. e and tau receive the
+// elements of the reduced columns; the diagonal of A is not updated.
+func latrd[T gen.Scalar, R gen.Float](bl blas.BLAS, uplo blas.Uplo, n, nb int, a []T, lda int, e []R, tau, w []T, ldw int) {
+	if n == 0 {
+		return
+	}
+	half := T(0.5)
+	if uplo == blas.UploU {
+		for i := n - 1; i >= n-nb; i-- {
+			iw := i - n + nb
+			if i < n-1 {
+				// Update A(0:i+1,i).
+				a[i+i*lda] = fromReal[T](re(a[i+i*lda]))
+				wr, ar := w[i+(iw+1)*ldw:], a[i+(i+1)*lda:]
+				lacgv(n-i-1, wr, ldw)
+				gemv(bl, blas.TransN, i+1, n-i-1, -1, a[(i+1)*lda:], lda, wr, ldw, 1, a[i*lda:], 1)
+				lacgv(n-i-1, wr, ldw)
+				lacgv(n-i-1, ar, lda)
+				gemv(bl, blas.TransN, i+1, n-i-1, -1, w[(iw+1)*ldw:], ldw, ar, lda, 1, a[i*lda:], 1)
+				lacgv(n-i-1, ar, lda)
+				a[i+i*lda] = fromReal[T](re(a[i+i*lda]))
+			}
+			if i == 0 {
+				continue
+			}
+			// Generate H(i-1) to annihilate A(0:i-1,i) and compute W(0:i,iw).
+			col, wi := a[i*lda:], w[iw*ldw:]
+			beta, t := larfg(bl, i, col[i-1], col, 1)
+			e[i-1], tau[i-1] = R(re(beta)), t
+			col[i-1] = 1
+			hemv(bl, blas.UploU, i, 1, a, lda, col, 1, 0, wi, 1)
+			if i < n-1 {
+				gemv(bl, blas.TransC, i, n-i-1, 1, w[(iw+1)*ldw:], ldw, col, 1, 0, wi[i+1:], 1)
+				gemv(bl, blas.TransN, i, n-i-1, -1, a[(i+1)*lda:], lda, wi[i+1:], 1, 1, wi, 1)
+				gemv(bl, blas.TransC, i, n-i-1, 1, a[(i+1)*lda:], lda, col, 1, 0, wi[i+1:], 1)
+				gemv(bl, blas.TransN, i, n-i-1, -1, w[(iw+1)*ldw:], ldw, wi[i+1:], 1, 1, wi, 1)
+			}
+			scal(bl, i, t, wi, 1)
+			alpha := -half * t * dotc(bl, i, wi, 1, col, 1)
+			axpy(bl, i, alpha, col, 1, wi, 1)
+		}
+		return
+	}
+	for i := 0; i < nb; i++ {
+		// Update A(i:n,i).
+		a[i+i*lda] = fromReal[T](re(a[i+i*lda]))
+		lacgv(i, w[i:], ldw)
+		gemv(bl, blas.TransN, n-i, i, -1, a[i:], lda, w[i:], ldw, 1, a[i+i*lda:], 1)
+		lacgv(i, w[i:], ldw)
+		lacgv(i, a[i:], lda)
+		gemv(bl, blas.TransN, n-i, i, -1, w[i:], ldw, a[i:], lda, 1, a[i+i*lda:], 1)
+		lacgv(i, a[i:], lda)
+		a[i+i*lda] = fromReal[T](re(a[i+i*lda]))
+		if i == n-1 {
+			continue
+		}
+		// Generate H(i) to annihilate A(i+2:n,i) and compute W(i+1:n,i).
+		col, wi := a[i+1+i*lda:], w[i*ldw:]
+		beta, t := larfg(bl, n-i-1, col[0], a[min(i+2, n-1)+i*lda:], 1)
+		e[i], tau[i] = R(re(beta)), t
+		col[0] = 1
+		hemv(bl, blas.UploL, n-i-1, 1, a[i+1+(i+1)*lda:], lda, col, 1, 0, wi[i+1:], 1)
+		gemv(bl, blas.TransC, n-i-1, i, 1, w[i+1:], ldw, col, 1, 0, wi, 1)
+		gemv(bl, blas.TransN, n-i-1, i, -1, a[i+1:], lda, wi, 1, 1, wi[i+1:], 1)
+		gemv(bl, blas.TransC, n-i-1, i, 1, a[i+1:], lda, col, 1, 0, wi, 1)
+		gemv(bl, blas.TransN, n-i-1, i, -1, w[i+1:], ldw, wi, 1, 1, wi[i+1:], 1)
+		scal(bl, n-i-1, t, wi[i+1:], 1)
+		alpha := -half * t * dotc(bl, n-i-1, wi[i+1:], 1, col, 1)
+		axpy(bl, n-i-1, alpha, col, 1, wi[i+1:], 1)
+	}
+}
+
+// sytrdWork returns the optimal workspace length of sytrd.
+func sytrdWork(n int) int {
+	return max(1, n*trdBlock)
+}
+
+// sytrd computes the reduction of sytd2 with the blocked algorithm of
+// xSYTRD, which reduces panels with latrd and updates the rest of A with a
+// rank-2k update. work holds lwork >= 1 elements, and the block size is
+// reduced to fit. A workspace query, lwork = -1, only sets work[0] to the
+// optimal lwork.
+func sytrd[T gen.Scalar, R gen.Float](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int, d, e []R, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(sytrdWork(n)))
+		return
+	}
+	if n == 0 {
+		return
+	}
+	nb, nx := trdBlock, n
+	ldwork := n
+	if nb > 1 && nb < n {
+		nx = max(nb, trdCrossover)
+		if nx < n && lwork < ldwork*nb {
+			nb = lwork / ldwork
+			if nb < 2 {
+				nx = n
+			}
+		}
+	}
+	if nx >= n {
+		sytd2(bl, uplo, n, a, lda, d, e, tau)
+		return
+	}
+	if uplo == blas.UploU {
+		// Reduce the last columns in blocks, leaving the first kk to
+		// sytd2.
+		kk := n - (n-nx+nb-1)/nb*nb
+		for i := n - nb; i >= kk; i -= nb {
+			latrd(bl, uplo, i+nb, nb, a, lda, e, tau, work, ldwork)
+			// Update A(0:i,0:i) = A - V*W**H - W*V**H.
+			her2k(bl, uplo, blas.TransN, i, nb, -1, a[i*lda:], lda, work, ldwork, 1, a, lda)
+			for j := i; j < i+nb; j++ {
+				a[j-1+j*lda] = fromReal[T](float64(e[j-1]))
+				d[j] = R(re(a[j+j*lda]))
+			}
+		}
+		sytd2(bl, uplo, kk, a, lda, d, e, tau)
+		return
+	}
+	i := 0
+	for ; i < n-nx; i += nb {
+		latrd(bl, uplo, n-i, nb, a[i+i*lda:], lda, e[i:], tau[i:], work, ldwork)
+		// Update A(i+nb:n,i+nb:n) = A - V*W**H - W*V**H.
+		her2k(bl, uplo, blas.TransN, n-i-nb, nb, -1, a[i+nb+i*lda:], lda, work[nb:], ldwork, 1, a[i+nb+(i+nb)*lda:], lda)
+		for j := i; j < i+nb; j++ {
+			a[j+1+j*lda] = fromReal[T](float64(e[j]))
+			d[j] = R(re(a[j+j*lda]))
+		}
+	}
+	sytd2(bl, uplo, n-i, a[i+i*lda:], lda, d[i:], e[i:], tau[i:])
+}
+
+// orgtr overwrites the n×n matrix A, which holds the reflectors left by
+// sytrd, with the unitary matrix Q of the reduction, as xORGTR and xUNGTR.
+// work holds lwork >= max(1,n-1) elements; a workspace query, lwork = -1,
+// only sets work[0] to the optimal lwork.
+func orgtr[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int, tau, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(max(1, n-1) * qrBlock))
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if uplo == blas.UploU {
+		// Q is the QL factor of its leading (n-1)×(n-1) block, whose
+		// reflectors are stored one column to the right: shift them left
+		// and set the last row and column of Q to those of the identity.
+		for j := 0; j < n-1; j++ {
+			copy(a[j*lda:j*lda+j], a[(j+1)*lda:])
+			a[n-1+j*lda] = 0
+		}
+		for i := 0; i < n-1; i++ {
+			a[i+(n-1)*lda] = 0
+		}
+		a[n-1+(n-1)*lda] = 1
+		orgql(bl, n-1, n-1, n-1, a, lda, tau, work, lwork)
+		return
+	}
+	// Q is the QR factor of its trailing (n-1)×(n-1) block, whose
+	// reflectors are stored one column to the left: shift them right and
+	// set the first row and column of Q to those of the identity.
+	for j := n - 1; j >= 1; j-- {
+		a[j*lda] = 0
+		for i := j + 1; i < n; i++ {
+			a[i+j*lda] = a[i+(j-1)*lda]
+		}
+	}
+	a[0] = 1
+	for i := 1; i < n; i++ {
+		a[i] = 0
+	}
+	if n > 1 {
+		orgqr(bl, n-1, n-1, n-1, a[1+lda:], lda, tau, work, lwork)
+	}
+}
+
+// ormtr overwrites the m×n matrix C with op(Q)*C (side L) or C*op(Q) (side
+// R), where Q is the unitary matrix of order nq, m for side L and n for side
+// R, of the reduction left in A and tau by sytrd, as xORMTR and xUNMTR. work
+// holds lwork >= nw elements, where nw is n for side L and m for side R; a
+// workspace query, lwork = -1, only sets work[0] to the optimal lwork.
+func ormtr[T gen.Scalar](bl blas.BLAS, side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []T, lda int, tau, c []T, ldc int, work []T, lwork int) {
+	if lwork == -1 {
+		work[0] = fromReal[T](float64(ormWork(side, m, n)))
+		return
+	}
+	nq := m
+	if side == blas.SideR {
+		nq = n
+	}
+	if m == 0 || n == 0 || nq == 1 {
+		return
+	}
+	// Q acts on the first (uplo U) or last (uplo L) nq-1 rows or columns
+	// of C.
+	mi, ni, ic := m-1, n, 1
+	if side == blas.SideR {
+		mi, ni, ic = m, n-1, ldc
+	}
+	if uplo == blas.UploU {
+		ormql(bl, side, trans, mi, ni, nq-1, a[lda:], lda, tau, c, ldc, work, lwork)
+		return
+	}
+	ormqr(bl, side, trans, mi, ni, nq-1, a[1:], lda, tau, c[ic:], ldc, work, lwork)
+}
@@ -0,0 +1,879 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// STREVC computes right and/or left eigenvectors of the n×n upper
+// quasi-triangular matrix T in the Schur canonical form of SHSEQR. The right
+// eigenvector x and left eigenvector y of the eigenvalue w satisfy
+// T*x = w*x and y**H*T = w*y**H. side selects the right (R), left (L) or
+// both (B) eigenvectors. howmny A computes all of them, howmny B all of them
+// multiplied by the matrices VL and VR given on entry, such as the Schur
+// vectors Q of SHSEQR so that those of A = Q*T*Q**T are obtained, and howmny
+// S those selected by selected[j]; a complex pair is selected when either
+// of its elements is, and selected is not modified. The eigenvectors are
+// stored in the columns of VL and VR in the order of the eigenvalues, the
+// real and imaginary parts of a complex pair in consecutive columns for
+// the eigenvalue with positive imaginary part. Each is scaled so that its
+// element of largest magnitude, measured as |re|+|im|, has magnitude 1.
+// VL and VR hold mm columns, at least the number m of columns required,
+// which is returned. work holds 3*n elements.
+func (impl Implementation) STREVC(side EVSide, howmny HowMany, selected []bool, n int, t []float32, ldt int, vl []float32, ldvl int, vr []float32, ldvr int, mm int, work []float32) (m int, err error) {
+	m, err = checkTrevc("STREVC", side, howmny, selected, n, t, ldt, len(vl), ldvl, len(vr), ldvr, mm, len(work), -1)
+	if err != nil {
+		return 0, err
+	}
+	trevc[float32, float32](impl.bl(), side, howmny, selected, n, t, ldt, vl, ldvl, vr, ldvr, m, work, nil)
+	return m, nil
+}
+
+// DTREVC computes right and/or left eigenvectors of the n×n upper
+// quasi-triangular matrix T in the Schur canonical form of DHSEQR. The right
+// eigenvector x and left eigenvector y of the eigenvalue w satisfy
+// T*x = w*x and y**H*T = w*y**H. side selects the right (R), left (L) or
+// both (B) eigenvectors. howmny A computes all of them, howmny B all of them
+// multiplied by the matrices VL and VR given on entry, such as the Schur
+// vectors Q of DHSEQR so that those of A = Q*T*Q**T are obtained, and howmny
+// S those selected by selected[j]; a complex pair is selected when either
+// of its elements is, and selected is not modified. The eigenvectors are
+// stored in the columns of VL and VR in the order of the eigenvalues, the
+// real and imaginary parts of a complex pair in consecutive columns for
+// the eigenvalue with positive imaginary part. Each is scaled so that its
+// element of largest magnitude, measured as |re|+|im|, has magnitude 1.
+// VL and VR hold mm columns, at least the number m of columns required,
+// which is returned. work holds 3*n elements.
+func (impl Implementation) DTREVC(side EVSide, howmny HowMany, selected []bool, n int, t []float64, ldt int, vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, err error) {
+	m, err = checkTrevc("DTREVC", side, howmny, selected, n, t, ldt, len(vl), ldvl, len(vr), ldvr, mm, len(work), -1)
+	if err != nil {
+		return 0, err
+	}
+	trevc[float64, float64](impl.bl(), side, howmny, selected, n, t, ldt, vl, ldvl, vr, ldvr, m, work, nil)
+	return m, nil
+}
+
+// CTREVC computes right and/or left eigenvectors of the n×n upper
+// triangular matrix T, such as the Schur form of CHSEQR. The right
+// eigenvector x and left eigenvector y of the eigenvalue w satisfy
+// T*x = w*x and y**H*T = w*y**H. side selects the right (R), left (L) or
+// both (B) eigenvectors. howmny A computes all of them, howmny B all of them
+// multiplied by the matrices VL and VR given on entry, such as the Schur
+// vectors Q of CHSEQR so that those of A = Q*T*Q**H are obtained, and howmny
+// S those selected by selected[j]. The eigenvectors are stored in the
+// columns of VL and VR in the order of the eigenvalues, each scaled so that
+// its element of largest magnitude, measured as |re|+|im|, has magnitude 1.
+// VL and VR hold mm columns, at least the number m of eigenvectors, which is
+// returned. work holds n elements and rwork n.
+func (impl Implementation) CTREVC(side EVSide, howmny HowMany, selected []bool, n int, t []complex64, ldt int, vl []complex64, ldvl int, vr []complex64, ldvr int, mm int, work []complex64, rwork []float32) (m int, err error) {
+	m, err = checkTrevc("CTREVC", side, howmny, selected, n, t, ldt, len(vl), ldvl, len(vr), ldvr, mm, len(work), len(rwork))
+	if err != nil {
+		return 0, err
+	}
+	trevc(impl.bl(), side, howmny, selected, n, t, ldt, vl, ldvl, vr, ldvr, m, work, rwork)
+	return m, nil
+}
+
+// ZTREVC computes right and/or left eigenvectors of the n×n upper
+// triangular matrix T, such as the Schur form of ZHSEQR. The right
+// eigenvector x and left eigenvector y of the eigenvalue w satisfy
+// T*x = w*x and y**H*T = w*y**H. side selects the right (R), left (L) or
+// both (B) eigenvectors. howmny A computes all of them, howmny B all of them
+// multiplied by the matrices VL and VR given on entry, such as the Schur
+// vectors Q of ZHSEQR so that those of A = Q*T*Q**H are obtained, and howmny
+// S those selected by selected[j]. The eigenvectors are stored in the
+// columns of VL and VR in the order of the eigenvalues, each scaled so that
+// its element of largest magnitude, measured as |re|+|im|, has magnitude 1.
+// VL and VR hold mm columns, at least the number m of eigenvectors, which is
+// returned. work holds n elements and rwork n.
+func (impl Implementation) ZTREVC(side EVSide, howmny HowMany, selected []bool, n int, t []complex128, ldt int, vl []complex128, ldvl int, vr []complex128, ldvr int, mm int, work []complex128, rwork []float64) (m int, err error) {
+	m, err = checkTrevc("ZTREVC", side, howmny, selected, n, t, ldt, len(vl), ldvl, len(vr), ldvr, mm, len(work), len(rwork))
+	if err != nil {
+		return 0, err
+	}
+	trevc(impl.bl(), side, howmny, selected, n, t, ldt, vl, ldvl, vr, ldvr, m, work, rwork)
+	return m, nil
+}
+
+// checkTrevc checks the TREVC routines and returns the number of columns
+// of VL and VR needed, which depends on selected and the 2×2 blocks of T.
+// The real routines pass lenRwork = -1.
+func checkTrevc[T gen.Scalar](routine string, side EVSide, howmny HowMany, selected []bool, n int, t []T, ldt int, lenVL, ldvl, lenVR, ldvr, mm, lenWork, lenRwork int) (m int, err error) {
+	c := checker{routine: routine}
+	c.evSide(1, side)
+	c.howMany(2, howmny)
+	c.nonNeg(4, "n", n)
+	c.ld(6, "ldt", ldt, n, "n")
+	leftv := side != EVRight
+	rightv := side != EVLeft
+	if leftv {
+		c.ld(8, "ldvl", ldvl, n, "n")
+	} else {
+		c.atLeast(8, "ldvl", ldvl, 1, "1")
+	}
+	if rightv {
+		c.ld(10, "ldvr", ldvr, n, "n")
+	} else {
+		c.atLeast(10, "ldvr", ldvr, 1, "1")
+	}
+	if c.ok() {
+		if howmny == HowManyS {
+			c.length(3, "selected", len(selected), n)
+		}
+		c.length(5, "t", len(t), matLen(n, n, ldt))
+	}
+	if !c.ok() {
+		return 0, c.result()
+	}
+	m = trevcCount(howmny, selected, n, t, ldt)
+	c.atLeast(11, "mm", mm, m, "m")
+	if c.ok() {
+		if leftv {
+			c.length(7, "vl", lenVL, matLen(n, mm, ldvl))
+		}
+		if rightv {
+			c.length(9, "vr", lenVR, matLen(n, mm, ldvr))
+		}
+		if lenRwork >= 0 {
+			c.length(13, "work", lenWork, n)
+			c.length(14, "rwork", lenRwork, n)
+		} else {
+			c.length(13, "work", lenWork, 3*n)
+		}
+	}
+	return m, c.result()
+}
+
+// trevcCount returns the number of columns taken by the eigenvectors of
+// the n×n matrix T selected by howmny and selected: one per eigenvalue, a
+// complex pair of a real T taking two when either of its elements is
+// selected.
+func trevcCount[T gen.Scalar](howmny HowMany, selected []bool, n int, t []T, ldt int) int {
+	if howmny != HowManyS {
+		return n
+	}
+	m := 0
+	for j := 0; j < n; j++ {
+		if !isComplex[T]() && j < n-1 && t[j+1+j*ldt] != 0 {
+			if selected[j] || selected[j+1] {
+				m += 2
+			}
+			j++
+		} else if selected[j] {
+			m++
+		}
+	}
+	return m
+}
+
+// trevc computes the eigenvectors of the upper quasi-triangular T selected
+// by side, howmny and selected as xTREVC into the first m columns of VL and
+// VR. For real types work holds 3*n elements and rwork is not referenced;
+// for complex types work and rwork hold n elements.
+func trevc[T gen.Scalar, R gen.Float](bl blas.BLAS, side EVSide, howmny HowMany, selected []bool, n int, t []T, ldt int, vl []T, ldvl int, vr []T, ldvr int, m int, work []T, rwork []R) {
+	if n == 0 {
+		return
+	}
+	if isComplex[T]() {
+		trevcComplex(bl, side, howmny, selected, n, t, ldt, vl, ldvl, vr, ldvr, m, work, rwork)
+	} else {
+		trevcReal(bl, side, howmny, selected, n, t, ldt, vl, ldvl, vr, ldvr, m, work)
+	}
+}
+
+// trevcReal is trevc for real types, as xTREVC: the quasi-triangular
+// systems are solved by back substitution with the 1×1 and 2×2 diagonal
+// blocks solved by laln2, rescaling the solution to prevent overflow. The
+// real and imaginary parts of a complex eigenvector are held in work[n:2*n]
+// and work[2*n:3*n], and work[:n] holds the 1-norms of the strictly upper
+// triangular columns of T.
+func trevcReal[T gen.Scalar](bl blas.BLAS, side EVSide, howmny HowMany, selected []bool, n int, t []T, ldt int, vl []T, ldvl int, vr []T, ldvr int, m int, work []T) {
+	over := howmny == HowManyB
+	somev := howmny == HowManyS
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() * (float64(n) / ulp)
+	bignum := (1 - ulp) / smlnum
+
+	cnorm := work[:n]
+	for j := 0; j < n; j++ {
+		cnorm[j] = fromReal[T](asum(bl, j, t[j*ldt:], 1))
+	}
+	// laln2 finds the imaginary part n elements after the real part.
+	x1, x2 := work[n:3*n], work[2*n:3*n]
+
+	if side != EVLeft {
+		// The right eigenvectors are computed from the last, so that the
+		// leading columns of VR are still those of Q for howmny B.
+		is := m - 1
+		for ki := n - 1; ki >= 0; ki-- {
+			pair := ki > 0 && t[ki+(ki-1)*ldt] != 0
+			if somev && !selected[ki] && !(pair && selected[ki-1]) {
+				if pair {
+					ki--
+				}
+				continue
+			}
+			wr, wi := re(t[ki+ki*ldt]), 0.0
+			if pair {
+				wi = math.Sqrt(abs(t[ki+(ki-1)*ldt])) * math.Sqrt(abs(t[ki-1+ki*ldt]))
+			}
+			smin := max(ulp*(math.Abs(wr)+math.Abs(wi)), smlnum)
+
+			if !pair {
+				// Real right eigenvector: solve (T(0:ki,0:ki) - wr)*x = 0
+				// with x[ki] = 1.
+				x1[ki] = 1
+				for k := 0; k < ki; k++ {
+					x1[k] = -t[k+ki*ldt]
+				}
+				for j := ki - 1; j >= 0; j-- {
+					if j > 0 && t[j+(j-1)*ldt] != 0 {
+						// 2×2 diagonal block in rows j-1 and j.
+						x, scale, xnorm := laln2(false, 2, 1, smin, 1, t[j-1+(j-1)*ldt:], ldt, 1, 1, x1[j-1:], n, wr, 0)
+						if xnorm > 1 && max(re(cnorm[j-1]), re(cnorm[j])) > bignum/xnorm {
+							x[0] /= xnorm
+							x[1] /= xnorm
+							scale /= xnorm
+						}
+						if scale != 1 {
+							rscal(bl, ki+1, scale, x1, 1)
+						}
+						x1[j-1], x1[j] = fromReal[T](x[0]), fromReal[T](x[1])
+						axpy(bl, j-1, fromReal[T](-x[0]), t[(j-1)*ldt:], 1, x1, 1)
+						axpy(bl, j-1, fromReal[T](-x[1]), t[j*ldt:], 1, x1, 1)
+						j--
+						continue
+					}
+					x, scale, xnorm := laln2(false, 1, 1, smin, 1, t[j+j*ldt:], ldt, 1, 1, x1[j:], n, wr, 0)
+					if xnorm > 1 && re(cnorm[j]) > bignum/xnorm {
+						x[0] /= xnorm
+						scale /= xnorm
+					}
+					if scale != 1 {
+						rscal(bl, ki+1, scale, x1, 1)
+					}
+					x1[j] = fromReal[T](x[0])
+					axpy(bl, j, fromReal[T](-x[0]), t[j*ldt:], 1, x1, 1)
+				}
+
+				if !over {
+					col := vr[is*ldvr:]
+					copyVec(bl, ki+1, x1, 1, col, 1)
+					ii := iamax(bl, ki+1, col, 1)
+					rscal(bl, ki+1, 1/abs(col[ii]), col, 1)
+					for k := ki + 1; k < n; k++ {
+						col[k] = 0
+					}
+				} else {
+					col := vr[ki*ldvr:]
+					if ki > 0 {
+						gemv(bl, blas.TransN, n, ki, 1, vr, ldvr, x1, 1, x1[ki], col, 1)
+					}
+					ii := iamax(bl, n, col, 1)
+					rscal(bl, n, 1/abs(col[ii]), col, 1)
+				}
+				is--
+				continue
+			}
+
+			// Complex right eigenvector x1 + i*x2 for wr + i*wi: solve the
+			// 2×2 block in rows k0 and ki first, then
+			// (T(0:k0,0:k0) - (wr + i*wi))*x = -T(0:k0,k0:ki+1)*x(k0:ki+1).
+			k0 := ki - 1
+			if abs(t[k0+ki*ldt]) >= abs(t[ki+k0*ldt]) {
+				x1[k0] = 1
+				x2[ki] = fromReal[T](wi) / t[k0+ki*ldt]
+			} else {
+				x1[k0] = fromReal[T](-wi) / t[ki+k0*ldt]
+				x2[ki] = 1
+			}
+			x1[ki] = 0
+			x2[k0] = 0
+			for k := 0; k < k0; k++ {
+				x1[k] = -x1[k0] * t[k+k0*ldt]
+				x2[k] = -x2[ki] * t[k+ki*ldt]
+			}
+			for j := k0 - 1; j >= 0; j-- {
+				if j > 0 && t[j+(j-1)*ldt] != 0 {
+					x, scale, xnorm := laln2(false, 2, 2, smin, 1, t[j-1+(j-1)*ldt:], ldt, 1, 1, x1[j-1:], n, wr, wi)
+					if xnorm > 1 && max(re(cnorm[j-1]), re(cnorm[j])) > bignum/xnorm {
+						for k := range x {
+							x[k] /= xnorm
+						}
+						scale /= xnorm
+					}
+					if scale != 1 {
+						rscal(bl, ki+1, scale, x1, 1)
+						rscal(bl, ki+1, scale, x2, 1)
+					}
+					x1[j-1], x1[j] = fromReal[T](x[0]), fromReal[T](x[1])
+					x2[j-1], x2[j] = fromReal[T](x[2]), fromReal[T](x[3])
+					axpy(bl, j-1, fromReal[T](-x[0]), t[(j-1)*ldt:], 1, x1, 1)
+					axpy(bl, j-1, fromReal[T](-x[1]), t[j*ldt:], 1, x1, 1)
+					axpy(bl, j-1, fromReal[T](-x[2]), t[(j-1)*ldt:], 1, x2, 1)
+					axpy(bl, j-1, fromReal[T](-x[3]), t[j*ldt:], 1, x2, 1)
+					j--
+					continue
+				}
+				x, scale, xnorm := laln2(false, 1, 2, smin, 1, t[j+j*ldt:], ldt, 1, 1, x1[j:], n, wr, wi)
+				if xnorm > 1 && re(cnorm[j]) > bignum/xnorm {
+					x[0] /= xnorm
+					x[2] /= xnorm
+					scale /= xnorm
+				}
+				if scale != 1 {
+					rscal(bl, ki+1, scale, x1, 1)
+					rscal(bl, ki+1, scale, x2, 1)
+				}
+				x1[j], x2[j] = fromReal[T](x[0]), fromReal[T](x[2])
+				axpy(bl, j, fromReal[T](-x[0]), t[j*ldt:], 1, x1, 1)
+				axpy(bl, j, fromReal[T](-x[2]), t[j*ldt:], 1, x2, 1)
+			}
+
+			var c1, c2 []T
+			nv := n
+			if !over {
+				c1, c2 = vr[(is-1)*ldvr:], vr[is*ldvr:]
+				copyVec(bl, ki+1, x1, 1, c1, 1)
+				copyVec(bl, ki+1, x2, 1, c2, 1)
+				for k := ki + 1; k < n; k++ {
+					c1[k] = 0
+					c2[k] = 0
+				}
+				nv = ki + 1
+			} else {
+				c1, c2 = vr[k0*ldvr:], vr[ki*ldvr:]
+				if k0 > 0 {
+					gemv(bl, blas.TransN, n, k0, 1, vr, ldvr, x1, 1, x1[k0], c1, 1)
+					gemv(bl, blas.TransN, n, k0, 1, vr, ldvr, x2, 1, x2[ki], c2, 1)
+				} else {
+					scal(bl, n, x1[k0], c1, 1)
+					scal(bl, n, x2[ki], c2, 1)
+				}
+			}
+			trevcNormalizePair(bl, nv, c1, c2)
+			is -= 2
+			ki--
+		}
+	}
+
+	if side != EVRight {
+		is := 0
+		for ki := 0; ki < n; ki++ {
+			pair := ki < n-1 && t[ki+1+ki*ldt] != 0
+			if somev && !selected[ki] && !(pair && selected[ki+1]) {
+				if pair {
+					ki++
+				}
+				continue
+			}
+			wr, wi := re(t[ki+ki*ldt]), 0.0
+			if pair {
+				wi = math.Sqrt(abs(t[ki+(ki+1)*ldt])) * math.Sqrt(abs(t[ki+1+ki*ldt]))
+			}
+			smin := max(ulp*(math.Abs(wr)+math.Abs(wi)), smlnum)
+
+			if !pair {
+				// Real left eigenvector: solve
+				// (T(ki:n,ki:n) - wr)**T*y = 0 with y[ki] = 1, rescaling
+				// when the growth bound vmax makes the dot products
+				// liable to overflow.
+				x1[ki] = 1
+				for k := ki + 1; k < n; k++ {
+					x1[k] = -t[ki+k*ldt]
+				}
+				vmax, vcrit := 1.0, bignum
+				for j := ki + 1; j < n; j++ {
+					if j < n-1 && t[j+1+j*ldt] != 0 {
+						if max(re(cnorm[j]), re(cnorm[j+1])) > vcrit {
+							rscal(bl, n-ki, 1/vmax, x1[ki:], 1)
+							vmax, vcrit = 1, bignum
+						}
+						x1[j] -= dotu(bl, j-ki-1, t[ki+1+j*ldt:], 1, x1[ki+1:], 1)
+						x1[j+1] -= dotu(bl, j-ki-1, t[ki+1+(j+1)*ldt:], 1, x1[ki+1:], 1)
+						x, scale, _ := laln2(true, 2, 1, smin, 1, t[j+j*ldt:], ldt, 1, 1, x1[j:], n, wr, 0)
+						if scale != 1 {
+							rscal(bl, n-ki, scale, x1[ki:], 1)
+						}
+						x1[j], x1[j+1] = fromReal[T](x[0]), fromReal[T](x[1])
+						vmax = max(math.Abs(x[0]), math.Abs(x[1]), vmax)
+						vcrit = bignum / vmax
+						j++
+						continue
+					}
+					if re(cnorm[j]) > vcrit {
+						rscal(bl, n-ki, 1/vmax, x1[ki:], 1)
+						vmax, vcrit = 1, bignum
+					}
+					x1[j] -= dotu(bl, j-ki-1, t[ki+1+j*ldt:], 1, x1[ki+1:], 1)
+					x, scale, _ := laln2(false, 1, 1, smin, 1, t[j+j*ldt:], ldt, 1, 1, x1[j:], n, wr, 0)
+					if scale != 1 {
+						rscal(bl, n-ki, scale, x1[ki:], 1)
+					}
+					x1[j] = fromReal[T](x[0])
+					vmax = max(math.Abs(x[0]), vmax)
+					vcrit = bignum / vmax
+				}
+
+				if !over {
+					col := vl[is*ldvl:]
+					copyVec(bl, n-ki, x1[ki:], 1, col[ki:], 1)
+					ii := ki + iamax(bl, n-ki, col[ki:], 1)
+					rscal(bl, n-ki, 1/abs(col[ii]), col[ki:], 1)
+					for k := 0; k < ki; k++ {
+						col[k] = 0
+					}
+				} else {
+					col := vl[ki*ldvl:]
+					if ki < n-1 {
+						gemv(bl, blas.TransN, n, n-ki-1, 1, vl[(ki+1)*ldvl:], ldvl, x1[ki+1:], 1, x1[ki], col, 1)
+					}
+					ii := iamax(bl, n, col, 1)
+					rscal(bl, n, 1/abs(col[ii]), col, 1)
+				}
+				is++
+				continue
+			}
+
+			// Complex left eigenvector x1 + i*x2 for wr - i*wi, the
+			// conjugate of that of wr + i*wi.
+			k1 := ki + 1
+			if abs(t[ki+k1*ldt]) >= abs(t[k1+ki*ldt]) {
+				x1[ki] = fromReal[T](wi) / t[ki+k1*ldt]
+				x2[k1] = 1
+			} else {
+				x1[ki] = 1
+				x2[k1] = fromReal[T](-wi) / t[k1+ki*ldt]
+			}
+			x1[k1] = 0
+			x2[ki] = 0
+			for k := ki + 2; k < n; k++ {
+				x1[k] = -x1[ki] * t[ki+k*ldt]
+				x2[k] = -x2[k1] * t[k1+k*ldt]
+			}
+			vmax, vcrit := 1.0, bignum
+			for j := ki + 2; j < n; j++ {
+				if j < n-1 && t[j+1+j*ldt] != 0 {
+					if max(re(cnorm[j]), re(cnorm[j+1])) > vcrit {
+						rscal(bl, n-ki, 1/vmax, x1[ki:], 1)
+						rscal(bl, n-ki, 1/vmax, x2[ki:], 1)
+						vmax, vcrit = 1, bignum
+					}
+					x1[j] -= dotu(bl, j-ki-2, t[ki+2+j*ldt:], 1, x1[ki+2:], 1)
+					x2[j] -= dotu(bl, j-ki-2, t[ki+2+j*ldt:], 1, x2[ki+2:], 1)
+					x1[j+1] -= dotu(bl, j-ki-2, t[ki+2+(j+1)*ldt:], 1, x1[ki+2:], 1)
+					x2[j+1] -= dotu(bl, j-ki-2, t[ki+2+(j+1)*ldt:], 1, x2[ki+2:], 1)
+					x, scale, _ := laln2(true, 2, 2, smin, 1, t[j+j*ldt:], ldt, 1, 1, x1[j:], n, wr, -wi)
+					if scale != 1 {
+						rscal(bl, n-ki, scale, x1[ki:], 1)
+						rscal(bl, n-ki, scale, x2[ki:], 1)
+					}
+					x1[j], x1[j+1] = fromReal[T](x[0]), fromReal[T](x[1])
+					x2[j], x2[j+1] = fromReal[T](x[2]), fromReal[T](x[3])
+					vmax = max(math.Abs(x[0]), math.Abs(x[1]), math.Abs(x[2]), math.Abs(x[3]), vmax)
+					vcrit = bignum / vmax
+					j++
+					continue
+				}
+				if re(cnorm[j]) > vcrit {
+					rscal(bl, n-ki, 1/vmax, x1[ki:], 1)
+					rscal(bl, n-ki, 1/vmax, x2[ki:], 1)
+					vmax, vcrit = 1, bignum
+				}
+				x1[j] -= dotu(bl, j-ki-2, t[ki+2+j*ldt:], 1, x1[ki+2:], 1)
+				x2[j] -= dotu(bl, j-ki-2, t[ki+2+j*ldt:], 1, x2[ki+2:], 1)
+				x, scale, _ := laln2(false, 1, 2, smin, 1, t[j+j*ldt:], ldt, 1, 1, x1[j:], n, wr, -wi)
+				if scale != 1 {
+					rscal(bl, n-ki, scale, x1[ki:], 1)
+					rscal(bl, n-ki, scale, x2[ki:], 1)
+				}
+				x1[j], x2[j] = fromReal[T](x[0]), fromReal[T](x[2])
+				vmax = max(math.Abs(x[0]), math.Abs(x[2]), vmax)
+				vcrit = bignum / vmax
+			}
+
+			if !over {
+				c1, c2 := vl[is*ldvl:], vl[(is+1)*ldvl:]
+				copyVec(bl, n-ki, x1[ki:], 1, c1[ki:], 1)
+				copyVec(bl, n-ki, x2[ki:], 1, c2[ki:], 1)
+				for k := 0; k < ki; k++ {
+					c1[k] = 0
+					c2[k] = 0
+				}
+				trevcNormalizePair(bl, n-ki, c1[ki:], c2[ki:])
+			} else {
+				c1, c2 := vl[ki*ldvl:], vl[k1*ldvl:]
+				if ki < n-2 {
+					gemv(bl, blas.TransN, n, n-ki-2, 1, vl[(ki+2)*ldvl:], ldvl, x1[ki+2:], 1, x1[ki], c1, 1)
+					gemv(bl, blas.TransN, n, n-ki-2, 1, vl[(ki+2)*ldvl:], ldvl, x2[ki+2:], 1, x2[k1], c2, 1)
+				} else {
+					scal(bl, n, x1[ki], c1, 1)
+					scal(bl, n, x2[k1], c2, 1)
+				}
+				trevcNormalizePair(bl, n, c1, c2)
+			}
+			is += 2
+			ki++
+		}
+	}
+}
+
+// trevcNormalizePair scales the real and imaginary parts x and y of a
+// complex vector of n elements so that its element of largest |re|+|im|
+// has that measure 1.
+func trevcNormalizePair[T gen.Scalar](bl blas.BLAS, n int, x, y []T) {
+	emax := 0.0
+	for k := 0; k < n; k++ {
+		emax = max(emax, abs(x[k])+abs(y[k]))
+	}
+	rscal(bl, n, 1/emax, x, 1)
+	rscal(bl, n, 1/emax, y, 1)
+}
+
+// trevcComplex is trevc for complex types, as xTREVC with the triangular
+// systems solved by back substitution in the manner of the real routine,
+// rescaling the solution to prevent overflow. The eigenvector is held in
+// work and rwork holds the 1-norms of the strictly upper triangular columns
+// of T.
+func trevcComplex[T gen.Scalar, R gen.Float](bl blas.BLAS, side EVSide, howmny HowMany, selected []bool, n int, t []T, ldt int, vl []T, ldvl int, vr []T, ldvr int, m int, work []T, rwork []R) {
+	over := howmny == HowManyB
+	somev := howmny == HowManyS
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() * (float64(n) / ulp)
+	bignum := (1 - ulp) / smlnum
+
+	cnorm := rwork[:n]
+	for j := 0; j < n; j++ {
+		cnorm[j] = R(asum(bl, j, t[j*ldt:], 1))
+	}
+	x := work[:n]
+
+	if side != EVLeft {
+		is := m - 1
+		for ki := n - 1; ki >= 0; ki-- {
+			if somev && !selected[ki] {
+				continue
+			}
+			w := t[ki+ki*ldt]
+			smin := max(ulp*abs1(w), smlnum)
+			// Solve (T(0:ki,0:ki) - w)*x = 0 with x[ki] = 1, which
+			// receives the scale factor of the solution.
+			x[ki] = 1
+			for k := 0; k < ki; k++ {
+				x[k] = -t[k+ki*ldt]
+			}
+			for j := ki - 1; j >= 0; j-- {
+				xj, scale := trevcSolve1(x[j], t[j+j*ldt]-w, smin, bignum, float64(cnorm[j]))
+				if scale != 1 {
+					rscal(bl, ki+1, scale, x, 1)
+				}
+				x[j] = xj
+				axpy(bl, j, -xj, t[j*ldt:], 1, x, 1)
+			}
+
+			if !over {
+				col := vr[is*ldvr:]
+				copyVec(bl, ki+1, x, 1, col, 1)
+				ii := iamax(bl, ki+1, col, 1)
+				rscal(bl, ki+1, 1/abs1(col[ii]), col, 1)
+				for k := ki + 1; k < n; k++ {
+					col[k] = 0
+				}
+			} else {
+				col := vr[ki*ldvr:]
+				if ki > 0 {
+					gemv(bl, blas.TransN, n, ki, 1, vr, ldvr, x, 1, x[ki], col, 1)
+				}
+				ii := iamax(bl, n, col, 1)
+				rscal(bl, n, 1/abs1(col[ii]), col, 1)
+			}
+			is--
+		}
+	}
+
+	if side != EVRight {
+		is := 0
+		for ki := 0; ki < n; ki++ {
+			if somev && !selected[ki] {
+				continue
+			}
+			w := t[ki+ki*ldt]
+			smin := max(ulp*abs1(w), smlnum)
+			// Solve (T(ki:n,ki:n) - w)**H*y = 0 with y[ki] = 1, rescaling
+			// when the growth bound vmax makes the dot products liable to
+			// overflow.
+			x[ki] = 1
+			for k := ki + 1; k < n; k++ {
+				x[k] = -conj(t[ki+k*ldt])
+			}
+			vmax, vcrit := 1.0, bignum
+			for j := ki + 1; j < n; j++ {
+				if float64(cnorm[j]) > vcrit {
+					rscal(bl, n-ki, 1/vmax, x[ki:], 1)
+					vmax, vcrit = 1, bignum
+				}
+				x[j] -= dotc(bl, j-ki-1, t[ki+1+j*ldt:], 1, x[ki+1:], 1)
+				xj, scale := trevcSolve1(x[j], conj(t[j+j*ldt]-w), smin, bignum, 0)
+				if scale != 1 {
+					rscal(bl, n-ki, scale, x[ki:], 1)
+				}
+				x[j] = xj
+				vmax = max(abs1(xj), vmax)
+				vcrit = bignum / vmax
+			}
+
+			if !over {
+				col := vl[is*ldvl:]
+				copyVec(bl, n-ki, x[ki:], 1, col[ki:], 1)
+				ii := ki + iamax(bl, n-ki, col[ki:], 1)
+				rscal(bl, n-ki, 1/abs1(col[ii]), col[ki:], 1)
+				for k := 0; k < ki; k++ {
+					col[k] = 0
+				}
+			} else {
+				col := vl[ki*ldvl:]
+				if ki < n-1 {
+					gemv(bl, blas.TransN, n, n-ki-1, 1, vl[(ki+1)*ldvl:], ldvl, x[ki+1:], 1, x[ki], col, 1)
+				}
+				ii := iamax(bl, n, col, 1)
+				rscal(bl, n, 1/abs1(col[ii]), col, 1)
+			}
+			is++
+		}
+	}
+}
+
+// trevcSolve1 returns x = scale*b/d for a diagonal element d of a shifted
+// triangular system, perturbing d to smin if it is smaller in |re|+|im|,
+// with scale <= 1 chosen so that x does not overflow and, for cnorm > 0,
+// neither does the update of the right-hand side by x times a column of
+// 1-norm cnorm.
+func trevcSolve1[T gen.Scalar](b, d T, smin, bignum, cnorm float64) (x T, scale float64) {
+	dnorm := abs1(d)
+	if dnorm < smin {
+		d, dnorm = fromReal[T](smin), smin
+	}
+	scale = 1
+	if bnorm := abs1(b); dnorm < 1 && bnorm > 1 && bnorm > bignum*dnorm {
+		scale = 1 / bnorm
+	}
+	x = b * fromReal[T](scale) / d
+	if xnorm := abs1(x); xnorm > 1 && cnorm > bignum/xnorm {
+		x /= fromReal[T](xnorm)
+		scale /= xnorm
+	}
+	return x, scale
+}
+
+// Pivoting tables of laln2 for the 2×2 matrix C stored by columns: when
+// the largest element of C is element k, laln2Pivot[k] gives the elements
+// that become U11, L21, U12 and U22 of its LU factorization, laln2RSwap[k]
+// whether the rows are swapped and laln2XSwap[k] whether the unknowns are.
+var (
+	laln2Pivot = [4][4]int{{0, 1, 2, 3}, {1, 0, 3, 2}, {2, 3, 0, 1}, {3, 2, 1, 0}}
+	laln2RSwap = [4]bool{false, true, false, true}
+	laln2XSwap = [4]bool{false, false, true, true}
+)
+
+// laln2 solves (ca*A - w*D)*X = scale*B or, for trans, (ca*A**T - w*D)*X =
+// scale*B for the na×na real matrix A, na = 1 or 2, with D = diag(d1,d2),
+// as xLALN2. For nw = 1 the shift w = wr is real and X and B are real
+// vectors; for nw = 2 w = wr + i*wi and the real and imaginary parts of X
+// and B are their first and second columns, the latter at b[ldb:]. The
+// system is solved by Gaussian elimination with complete pivoting, a pivot
+// smaller than smin being perturbed to smin, and scale <= 1 is chosen to
+// prevent overflow in X. X is returned in column-major order with leading
+// dimension 2, with its infinity norm xnorm, measuring complex elements
+// as |re|+|im|.
+func laln2[T gen.Scalar](trans bool, na, nw int, smin, ca float64, a []T, lda int, d1, d2 float64, b []T, ldb int, wr, wi float64) (x [4]float64, scale, xnorm float64) {
+	smlnum := 2 * safmin[T]()
+	bignum := 1 / smlnum
+	smini := max(smin, smlnum)
+	scale = 1
+
+	if na == 1 {
+		csr := ca*re(a[0]) - wr*d1
+		if nw == 1 {
+			cnorm := math.Abs(csr)
+			if cnorm < smini {
+				csr, cnorm = smini, smini
+			}
+			bnorm := math.Abs(re(b[0]))
+			if cnorm < 1 && bnorm > 1 && bnorm > bignum*cnorm {
+				scale = 1 / bnorm
+			}
+			x[0] = re(b[0]) * scale / csr
+			return x, scale, math.Abs(x[0])
+		}
+		csi := -wi * d1
+		cnorm := math.Abs(csr) + math.Abs(csi)
+		if cnorm < smini {
+			csr, csi, cnorm = smini, 0, smini
+		}
+		bnorm := math.Abs(re(b[0])) + math.Abs(re(b[ldb]))
+		if cnorm < 1 && bnorm > 1 && bnorm > bignum*cnorm {
+			scale = 1 / bnorm
+		}
+		q := complex(scale*re(b[0]), scale*re(b[ldb])) / complex(csr, csi)
+		x[0], x[2] = real(q), imag(q)
+		return x, scale, math.Abs(x[0]) + math.Abs(x[2])
+	}
+
+	// The 2×2 matrix C = ca*A - w*D stored by columns in cr and ci.
+	var cr, ci [4]float64
+	cr[0] = ca*re(a[0]) - wr*d1
+	cr[3] = ca*re(a[1+lda]) - wr*d2
+	if trans {
+		cr[1], cr[2] = ca*re(a[lda]), ca*re(a[1])
+	} else {
+		cr[1], cr[2] = ca*re(a[1]), ca*re(a[lda])
+	}
+	b11, b21 := re(b[0]), re(b[1])
+
+	if nw == 1 {
+		cmax, icmax := 0.0, 0
+		for j, v := range cr {
+			if math.Abs(v) > cmax {
+				cmax, icmax = math.Abs(v), j
+			}
+		}
+		// If norm(C) < smini, use smini*I.
+		if cmax < smini {
+			bnorm := max(math.Abs(b11), math.Abs(b21))
+			if smini < 1 && bnorm > 1 && bnorm > bignum*smini {
+				scale = 1 / bnorm
+			}
+			temp := scale / smini
+			x[0], x[1] = temp*b11, temp*b21
+			return x, scale, temp * bnorm
+		}
+		// Gaussian elimination with complete pivoting.
+		piv := laln2Pivot[icmax]
+		ur11 := cr[icmax]
+		cr21 := cr[piv[1]]
+		ur12 := cr[piv[2]]
+		cr22 := cr[piv[3]]
+		ur11r := 1 / ur11
+		lr21 := ur11r * cr21
+		ur22 := cr22 - ur12*lr21
+		if math.Abs(ur22) < smini {
+			ur22 = smini
+		}
+		br1, br2 := b11, b21
+		if laln2RSwap[icmax] {
+			br1, br2 = b21, b11
+		}
+		br2 -= lr21 * br1
+		bbnd := max(math.Abs(br1*(ur22*ur11r)), math.Abs(br2))
+		if bbnd > 1 && math.Abs(ur22) < 1 && bbnd >= bignum*math.Abs(ur22) {
+			scale = 1 / bbnd
+		}
+		xr2 := br2 * scale / ur22
+		xr1 := scale*br1*ur11r - xr2*(ur11r*ur12)
+		if laln2XSwap[icmax] {
+			x[0], x[1] = xr2, xr1
+		} else {
+			x[0], x[1] = xr1, xr2
+		}
+		xnorm = max(math.Abs(xr1), math.Abs(xr2))
+		// Further scaling if norm(A)*norm(X) > overflow.
+		if xnorm > 1 && cmax > 1 && xnorm > bignum/cmax {
+			temp := cmax / bignum
+			x[0] *= temp
+			x[1] *= temp
+			xnorm *= temp
+			scale *= temp
+		}
+		return x, scale, xnorm
+	}
+
+	ci[0], ci[3] = -wi*d1, -wi*d2
+	b12, b22 := re(b[ldb]), re(b[1+ldb])
+	cmax, icmax := 0.0, 0
+	for j := range cr {
+		if v := math.Abs(cr[j]) + math.Abs(ci[j]); v > cmax {
+			cmax, icmax = v, j
+		}
+	}
+	if cmax < smini {
+		bnorm := max(math.Abs(b11)+math.Abs(b12), math.Abs(b21)+math.Abs(b22))
+		if smini < 1 && bnorm > 1 && bnorm > bignum*smini {
+			scale = 1 / bnorm
+		}
+		temp := scale / smini
+		x = [4]float64{temp * b11, temp * b21, temp * b12, temp * b22}
+		return x, scale, temp * bnorm
+	}
+	piv := laln2Pivot[icmax]
+	ur11, ui11 := cr[icmax], ci[icmax]
+	cr21, ci21 := cr[piv[1]], ci[piv[1]]
+	ur12, ui12 := cr[piv[2]], ci[piv[2]]
+	cr22, ci22 := cr[piv[3]], ci[piv[3]]
+	var ur11r, ui11r, lr21, li21, ur12s, ui12s, ur22, ui22 float64
+	if icmax == 0 || icmax == 3 {
+		// The off-diagonal elements of the pivoted C are real.
+		if math.Abs(ur11) > math.Abs(ui11) {
+			temp := ui11 / ur11
+			ur11r = 1 / (ur11 * (1 + temp*temp))
+			ui11r = -temp * ur11r
+		} else {
+			temp := ur11 / ui11
+			ui11r = -1 / (ui11 * (1 + temp*temp))
+			ur11r = -temp * ui11r
+		}
+		lr21 = cr21 * ur11r
+		li21 = cr21 * ui11r
+		ur12s = ur12 * ur11r
+		ui12s = ur12 * ui11r
+		ur22 = cr22 - ur12*lr21
+		ui22 = ci22 - ur12*li21
+	} else {
+		// The diagonal elements of the pivoted C are real.
+		ur11r = 1 / ur11
+		lr21 = cr21 * ur11r
+		li21 = ci21 * ur11r
+		ur12s = ur12 * ur11r
+		ui12s = ui12 * ur11r
+		ur22 = cr22 - ur12*lr21 + ui12*li21
+		ui22 = -ur12*li21 - ui12*lr21
+	}
+	u22abs := math.Abs(ur22) + math.Abs(ui22)
+	if u22abs < smini {
+		ur22, ui22 = smini, 0
+	}
+	br1, br2, bi1, bi2 := b11, b21, b12, b22
+	if laln2RSwap[icmax] {
+		br1, br2, bi1, bi2 = b21, b11, b22, b12
+	}
+	br2 = br2 - lr21*br1 + li21*bi1
+	bi2 = bi2 - li21*br1 - lr21*bi1
+	bbnd := max((math.Abs(br1)+math.Abs(bi1))*(u22abs*(math.Abs(ur11r)+math.Abs(ui11r))), math.Abs(br2)+math.Abs(bi2))
+	if bbnd > 1 && u22abs < 1 && bbnd >= bignum*u22abs {
+		scale = 1 / bbnd
+		br1 *= scale
+		bi1 *= scale
+		br2 *= scale
+		bi2 *= scale
+	}
+	q := complex(br2, bi2) / complex(ur22, ui22)
+	xr2, xi2 := real(q), imag(q)
+	xr1 := ur11r*br1 - ui11r*bi1 - ur12s*xr2 + ui12s*xi2
+	xi1 := ui11r*br1 + ur11r*bi1 - ui12s*xr2 - ur12s*xi2
+	if laln2XSwap[icmax] {
+		x = [4]float64{xr2, xr1, xi2, xi1}
+	} else {
+		x = [4]float64{xr1, xr2, xi1, xi2}
+	}
+	xnorm = max(math.Abs(xr1)+math.Abs(xi1), math.Abs(xr2)+math.Abs(xi2))
+	if xnorm > 1 && cmax > 1 && xnorm > bignum/cmax {
+		temp := cmax / bignum
+		for k := range x {
+			x[k] *= temp
+		}
+		xnorm *= temp
+		scale *= temp
+	}
+	return x, scale, xnorm
+}
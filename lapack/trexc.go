@@ -0,0 +1,395 @@
+package lapack
+
+import (
+	"math"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// trexc reorders the Schur factorization A = Q*T*Q**H of an n×n matrix so
+// that the diagonal block of T starting in row ifst moves to row ilst by a
+// sequence of unitary similarity transformations, as xTREXC. T is upper
+// triangular for complex types and in the quasi-triangular Schur canonical
+// form of lahqr for real types, and for wantq the transformations are
+// accumulated into the columns of Q. work holds n elements and is used only
+// by real types.
+//
+// For real types, ifst is adjusted to the first row of its block when it
+// points to the second row of a 2×2 block, and ilst to the first row of the
+// block in its final position. ok is false if two adjacent blocks were too
+// close to swap; T and Q are then still a valid factorization with the block
+// stopped at ilst.
+func trexc[T gen.Scalar](bl blas.BLAS, wantq bool, n int, t []T, ldt int, q []T, ldq int, ifst, ilst int, work []T) (int, int, bool) {
+	if n <= 1 {
+		return ifst, ilst, true
+	}
+	if isComplex[T]() {
+		// Swap adjacent diagonal elements one at a time.
+		if ifst < ilst {
+			for k := ifst; k < ilst; k++ {
+				laexc(bl, wantq, n, t, ldt, q, ldq, k, 1, 1, work)
+			}
+		} else {
+			for k := ifst - 1; k >= ilst; k-- {
+				laexc(bl, wantq, n, t, ldt, q, ldq, k, 1, 1, work)
+			}
+		}
+		return ifst, ilst, true
+	}
+
+	sub := func(i int) bool { return t[i+1+i*ldt] != 0 }
+	// Determine the first row of the specified block and whether it is
+	// 1×1 or 2×2, and the same for the target position.
+	if ifst > 0 && sub(ifst-1) {
+		ifst--
+	}
+	nbf := 1
+	if ifst < n-1 && sub(ifst) {
+		nbf = 2
+	}
+	if ilst > 0 && sub(ilst-1) {
+		ilst--
+	}
+	nbl := 1
+	if ilst < n-1 && sub(ilst) {
+		nbl = 2
+	}
+	if ifst == ilst {
+		return ifst, ilst, true
+	}
+
+	// nbf = 3 stands for a 2×2 block that split into two 1×1 blocks, which
+	// must be moved one at a time.
+	here := ifst
+	if ifst < ilst {
+		// Move the block down.
+		if nbf == 2 && nbl == 1 {
+			ilst--
+		}
+		if nbf == 1 && nbl == 2 {
+			ilst++
+		}
+		for here < ilst {
+			if nbf != 3 {
+				nbnext := 1
+				if here+nbf+1 < n && sub(here+nbf) {
+					nbnext = 2
+				}
+				if !laexc(bl, wantq, n, t, ldt, q, ldq, here, nbf, nbnext, work) {
+					return ifst, here, false
+				}
+				here += nbnext
+				if nbf == 2 && !sub(here) {
+					nbf = 3
+				}
+				continue
+			}
+			nbnext := 1
+			if here+3 < n && sub(here+2) {
+				nbnext = 2
+			}
+			if !laexc(bl, wantq, n, t, ldt, q, ldq, here+1, 1, nbnext, work) {
+				return ifst, here, false
+			}
+			if nbnext == 1 {
+				laexc(bl, wantq, n, t, ldt, q, ldq, here, 1, 1, work)
+				here++
+				continue
+			}
+			if sub(here + 1) {
+				// The 2×2 block did not split.
+				if !laexc(bl, wantq, n, t, ldt, q, ldq, here, 1, 2, work) {
+					return ifst, here, false
+				}
+			} else {
+				laexc(bl, wantq, n, t, ldt, q, ldq, here, 1, 1, work)
+				laexc(bl, wantq, n, t, ldt, q, ldq, here+1, 1, 1, work)
+			}
+			here += 2
+		}
+		return ifst, here, true
+	}
+
+	// Move the block up.
+	for here > ilst {
+		if nbf != 3 {
+			nbnext := 1
+			if here >= 2 && sub(here-2) {
+				nbnext = 2
+			}
+			if !laexc(bl, wantq, n, t, ldt, q, ldq, here-nbnext, nbnext, nbf, work) {
+				return ifst, here, false
+			}
+			here -= nbnext
+			if nbf == 2 && !sub(here) {
+				nbf = 3
+			}
+			continue
+		}
+		nbnext := 1
+		if here >= 2 && sub(here-2) {
+			nbnext = 2
+		}
+		if !laexc(bl, wantq, n, t, ldt, q, ldq, here-nbnext, nbnext, 1, work) {
+			return ifst, here, false
+		}
+		if nbnext == 1 {
+			laexc(bl, wantq, n, t, ldt, q, ldq, here, 1, 1, work)
+			here--
+			continue
+		}
+		if sub(here - 1) {
+			// The 2×2 block did not split.
+			if !laexc(bl, wantq, n, t, ldt, q, ldq, here-1, 2, 1, work) {
+				return ifst, here, false
+			}
+		} else {
+			laexc(bl, wantq, n, t, ldt, q, ldq, here, 1, 1, work)
+			laexc(bl, wantq, n, t, ldt, q, ldq, here-1, 1, 1, work)
+		}
+		here -= 2
+	}
+	return ifst, here, true
+}
+
+// laexc swaps the adjacent diagonal blocks T11 of order n1 and T22 of order
+// n2 starting in row j1 of the n×n upper quasi-triangular matrix T by a
+// unitary similarity transformation, as xLAEXC, and for wantq accumulates
+// it into the columns of Q. The blocks are 1×1 or, for real types only,
+// 2×2 in standard form. work holds n elements.
+//
+// It returns false and leaves T and Q unchanged if the swap was rejected
+// because the blocks have eigenvalues too close to be separated stably.
+func laexc[T gen.Scalar](bl blas.BLAS, wantq bool, n int, t []T, ldt int, q []T, ldq int, j1, n1, n2 int, work []T) bool {
+	if n == 0 || n1 == 0 || n2 == 0 || j1+n1 >= n {
+		return true
+	}
+	j2, j3, j4 := j1+1, j1+2, j1+3
+
+	if n1 == 1 && n2 == 1 {
+		// Swap two 1×1 blocks with the rotation that maps the
+		// eigenvector of T22 to the first unit vector.
+		t11, t22 := t[j1+j1*ldt], t[j2+j2*ldt]
+		cs, sn, _ := clartg(t[j1+j2*ldt], t22-t11)
+		if j3 < n {
+			rot(bl, n-j1-2, t[j1+j3*ldt:], ldt, t[j2+j3*ldt:], ldt, cs, sn)
+		}
+		rot(bl, j1, t[j1*ldt:], 1, t[j2*ldt:], 1, cs, conj(sn))
+		t[j1+j1*ldt], t[j2+j2*ldt] = t22, t11
+		if wantq {
+			rot(bl, n, q[j1*ldq:], 1, q[j2*ldq:], 1, cs, conj(sn))
+		}
+		return true
+	}
+
+	// Swapping involves at least one 2×2 block. Copy the diagonal block of
+	// order nd to D and compute its norm, then solve the Sylvester equation
+	// T11*X - X*T22 = scale*T12 for X.
+	const ldd = 4
+	nd := n1 + n2
+	var d [ldd * ldd]T
+	lacpy(uploAll, nd, nd, t[j1+j1*ldt:], ldt, d[:], ldd)
+	dnorm := lange(normMax, nd, nd, d[:], ldd)
+	ulp := 2 * eps[T]()
+	smlnum := safmin[T]() / ulp
+	thresh := max(10*ulp*dnorm, smlnum)
+	x, scale := lasy2(n1, n2, d[:], ldd, d[n1+n1*ldd:], ldd, d[n1*ldd:], ldd, ulp, smlnum)
+	sc := fromReal[T](scale)
+	x11, x21, x12, x22 := fromReal[T](x[0]), fromReal[T](x[1]), fromReal[T](x[2]), fromReal[T](x[3])
+
+	// The swapping transformation is a product of reflectors that maps
+	// [-X; scale*I] to the leading columns, tested for stability on D
+	// before it is applied to T.
+	switch {
+	case n1 == 1:
+		// Find H such that [scale x11 x12]*H = [0 0 *].
+		u := [3]T{sc, x11, x12}
+		_, tau := larfg(bl, 3, u[2], u[:2], 1)
+		u[2] = 1
+		t11 := t[j1+j1*ldt]
+		larf(bl, blas.SideL, 3, 3, u[:], 1, tau, d[:], ldd, work)
+		larf(bl, blas.SideR, 3, 3, u[:], 1, tau, d[:], ldd, work)
+		if max(abs(d[2]), abs(d[2+ldd]), abs(d[2+2*ldd]-t11)) > thresh {
+			return false
+		}
+		larf(bl, blas.SideL, 3, n-j1, u[:], 1, tau, t[j1+j1*ldt:], ldt, work)
+		larf(bl, blas.SideR, j3, 3, u[:], 1, tau, t[j1*ldt:], ldt, work)
+		t[j3+j1*ldt], t[j3+j2*ldt], t[j3+j3*ldt] = 0, 0, t11
+		if wantq {
+			larf(bl, blas.SideR, n, 3, u[:], 1, tau, q[j1*ldq:], ldq, work)
+		}
+	case n2 == 1:
+		// Find H such that H*[-x11; -x21; scale] = [*; 0; 0].
+		u := [3]T{-x11, -x21, sc}
+		_, tau := larfg(bl, 3, u[0], u[1:], 1)
+		u[0] = 1
+		t33 := t[j3+j3*ldt]
+		larf(bl, blas.SideL, 3, 3, u[:], 1, tau, d[:], ldd, work)
+		larf(bl, blas.SideR, 3, 3, u[:], 1, tau, d[:], ldd, work)
+		if max(abs(d[1]), abs(d[2]), abs(d[0]-t33)) > thresh {
+			return false
+		}
+		larf(bl, blas.SideR, j4, 3, u[:], 1, tau, t[j1*ldt:], ldt, work)
+		larf(bl, blas.SideL, 3, n-j2, u[:], 1, tau, t[j1+j2*ldt:], ldt, work)
+		t[j1+j1*ldt], t[j2+j1*ldt], t[j3+j1*ldt] = t33, 0, 0
+		if wantq {
+			larf(bl, blas.SideR, n, 3, u[:], 1, tau, q[j1*ldq:], ldq, work)
+		}
+	default:
+		// Find H1*H2 such that H2*H1*[-X; scale*I] = [*; 0].
+		u1 := [3]T{-x11, -x21, sc}
+		_, tau1 := larfg(bl, 3, u1[0], u1[1:], 1)
+		u1[0] = 1
+		temp := -tau1 * (x12 + u1[1]*x22)
+		u2 := [3]T{-temp*u1[1] - x22, -temp * u1[2], sc}
+		_, tau2 := larfg(bl, 3, u2[0], u2[1:], 1)
+		u2[0] = 1
+		larf(bl, blas.SideL, 3, 4, u1[:], 1, tau1, d[:], ldd, work)
+		larf(bl, blas.SideR, 4, 3, u1[:], 1, tau1, d[:], ldd, work)
+		larf(bl, blas.SideL, 3, 4, u2[:], 1, tau2, d[1:], ldd, work)
+		larf(bl, blas.SideR, 4, 3, u2[:], 1, tau2, d[ldd:], ldd, work)
+		if max(abs(d[2]), abs(d[2+ldd]), abs(d[3]), abs(d[3+ldd])) > thresh {
+			return false
+		}
+		larf(bl, blas.SideL, 3, n-j1, u1[:], 1, tau1, t[j1+j1*ldt:], ldt, work)
+		larf(bl, blas.SideR, j1+4, 3, u1[:], 1, tau1, t[j1*ldt:], ldt, work)
+		larf(bl, blas.SideL, 3, n-j1, u2[:], 1, tau2, t[j2+j1*ldt:], ldt, work)
+		larf(bl, blas.SideR, j1+4, 3, u2[:], 1, tau2, t[j2*ldt:], ldt, work)
+		t[j3+j1*ldt], t[j3+j2*ldt], t[j4+j1*ldt], t[j4+j2*ldt] = 0, 0, 0, 0
+		if wantq {
+			larf(bl, blas.SideR, n, 3, u1[:], 1, tau1, q[j1*ldq:], ldq, work)
+			larf(bl, blas.SideR, n, 3, u2[:], 1, tau2, q[j2*ldq:], ldq, work)
+		}
+	}
+
+	// Standardize the new 2×2 blocks.
+	if n2 == 2 {
+		laexcStandardize(bl, wantq, n, t, ldt, q, ldq, j1, ulp)
+	}
+	if n1 == 2 {
+		laexcStandardize(bl, wantq, n, t, ldt, q, ldq, j1+n2, ulp)
+	}
+	return true
+}
+
+// laexcStandardize reduces the 2×2 diagonal block of the real T starting
+// in row j to the standard form of lanv2 and applies the rotation to the
+// rest of T and to Q.
+func laexcStandardize[T gen.Scalar](bl blas.BLAS, wantq bool, n int, t []T, ldt int, q []T, ldq int, j int, ulp float64) {
+	a, b, c, d, _, _, _, _, cs, sn := lanv2(re(t[j+j*ldt]), re(t[j+(j+1)*ldt]), re(t[j+1+j*ldt]), re(t[j+1+(j+1)*ldt]), ulp)
+	t[j+j*ldt], t[j+(j+1)*ldt] = fromReal[T](a), fromReal[T](b)
+	t[j+1+j*ldt], t[j+1+(j+1)*ldt] = fromReal[T](c), fromReal[T](d)
+	if j+2 < n {
+		rrot(bl, n-j-2, t[j+(j+2)*ldt:], ldt, t[j+1+(j+2)*ldt:], ldt, cs, sn)
+	}
+	rrot(bl, j, t[j*ldt:], 1, t[(j+1)*ldt:], 1, cs, sn)
+	if wantq {
+		rrot(bl, n, q[j*ldq:], 1, q[(j+1)*ldq:], 1, cs, sn)
+	}
+}
+
+// lasy2 solves the real Sylvester equation TL*X - X*TR = scale*B for the
+// n1×n2 matrix X, where n1 and n2 are 1 or 2, as xLASY2 with ISGN = -1 and
+// no transposes. The equivalent linear system of order n1*n2 is solved by
+// Gaussian elimination with complete pivoting; pivots smaller than
+// max(ulp*max|T|, smlnum) are perturbed to that value, and scale <= 1 is
+// chosen to prevent overflow in X. X is returned in column-major order
+// with leading dimension 2.
+func lasy2[T gen.Scalar](n1, n2 int, tl []T, ldtl int, tr []T, ldtr int, b []T, ldb int, ulp, smlnum float64) (x [4]float64, scale float64) {
+	const lda = 4
+	m := n1 * n2
+	var a [lda * lda]float64
+	var rhs [4]float64
+	var perm [4]int
+	smin := 0.0
+	for i := 0; i < n1; i++ {
+		for j := 0; j < n1; j++ {
+			smin = max(smin, abs(tl[i+j*ldtl]))
+		}
+	}
+	for i := 0; i < n2; i++ {
+		for j := 0; j < n2; j++ {
+			smin = max(smin, abs(tr[i+j*ldtr]))
+		}
+	}
+	smin = max(ulp*smin, smlnum)
+	// Unknown i+k*n1 is X(i,k); the system is (I⊗TL - TR**T⊗I)*vec(X) =
+	// vec(B).
+	for k := 0; k < n2; k++ {
+		for i := 0; i < n1; i++ {
+			r := i + k*n1
+			rhs[r] = re(b[i+k*ldb])
+			for l := 0; l < n2; l++ {
+				for j := 0; j < n1; j++ {
+					c := j + l*n1
+					if k == l {
+						a[r+c*lda] += re(tl[i+j*ldtl])
+					}
+					if i == j {
+						a[r+c*lda] -= re(tr[l+k*ldtr])
+					}
+				}
+			}
+		}
+	}
+	for i := range perm {
+		perm[i] = i
+	}
+	for k := 0; k < m; k++ {
+		ip, jp, amax := k, k, 0.0
+		for j := k; j < m; j++ {
+			for i := k; i < m; i++ {
+				if v := math.Abs(a[i+j*lda]); v > amax {
+					ip, jp, amax = i, j, v
+				}
+			}
+		}
+		if ip != k {
+			for j := 0; j < m; j++ {
+				a[k+j*lda], a[ip+j*lda] = a[ip+j*lda], a[k+j*lda]
+			}
+			rhs[k], rhs[ip] = rhs[ip], rhs[k]
+		}
+		if jp != k {
+			for i := 0; i < m; i++ {
+				a[i+k*lda], a[i+jp*lda] = a[i+jp*lda], a[i+k*lda]
+			}
+			perm[k], perm[jp] = perm[jp], perm[k]
+		}
+		if math.Abs(a[k+k*lda]) < smin {
+			a[k+k*lda] = smin
+		}
+		for i := k + 1; i < m; i++ {
+			l := a[i+k*lda] / a[k+k*lda]
+			rhs[i] -= l * rhs[k]
+			for j := k + 1; j < m; j++ {
+				a[i+j*lda] -= l * a[k+j*lda]
+			}
+		}
+	}
+	scale = 1
+	bmax := 0.0
+	for i := 0; i < m; i++ {
+		bmax = max(bmax, math.Abs(rhs[i]))
+	}
+	if 8*smlnum*bmax > math.Abs(a[0]) {
+		scale = 0.125 / bmax
+		for i := 0; i < m; i++ {
+			rhs[i] *= scale
+		}
+	}
+	var y [4]float64
+	for k := m - 1; k >= 0; k-- {
+		temp := 1 / a[k+k*lda]
+		y[k] = rhs[k] * temp
+		for j := k + 1; j < m; j++ {
+			y[k] -= temp * a[k+j*lda] * y[j]
+		}
+	}
+	for k := 0; k < m; k++ {
+		r := perm[k]
+		x[r%n1+(r/n1)*2] = y[k]
+	}
+	return x, scale
+}
@@ -0,0 +1,252 @@
+package lapack
+
+import (
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// STRTRI computes the inverse of an n×n triangular matrix in place. A
+// *SingularError is returned, and A is left unchanged, if a non-unit A has a
+// zero diagonal element.
+func (impl Implementation) STRTRI(uplo blas.Uplo, diag blas.Diag, n int, a []float32, lda int) error {
+	if err := checkTrtri("STRTRI", uplo, diag, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("STRTRI", trtri(impl.bl(), uplo, diag, n, a, lda))
+}
+
+// SLAUUM computes the product U*U**H (uplo U) or L**H*L (uplo L) of the
+// triangular factor held in the uplo triangle of A, overwriting it by the
+// same triangle of the product.
+func (impl Implementation) SLAUUM(uplo blas.Uplo, n int, a []float32, lda int) error {
+	if err := checkPotrf("SLAUUM", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	lauum(impl.bl(), uplo, n, a, lda)
+	return nil
+}
+
+// DTRTRI computes the inverse of an n×n triangular matrix in place. A
+// *SingularError is returned, and A is left unchanged, if a non-unit A has a
+// zero diagonal element.
+func (impl Implementation) DTRTRI(uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int) error {
+	if err := checkTrtri("DTRTRI", uplo, diag, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("DTRTRI", trtri(impl.bl(), uplo, diag, n, a, lda))
+}
+
+// DLAUUM computes the product U*U**H (uplo U) or L**H*L (uplo L) of the
+// triangular factor held in the uplo triangle of A, overwriting it by the
+// same triangle of the product.
+func (impl Implementation) DLAUUM(uplo blas.Uplo, n int, a []float64, lda int) error {
+	if err := checkPotrf("DLAUUM", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	lauum(impl.bl(), uplo, n, a, lda)
+	return nil
+}
+
+// CTRTRI computes the inverse of an n×n triangular matrix in place. A
+// *SingularError is returned, and A is left unchanged, if a non-unit A has a
+// zero diagonal element.
+func (impl Implementation) CTRTRI(uplo blas.Uplo, diag blas.Diag, n int, a []complex64, lda int) error {
+	if err := checkTrtri("CTRTRI", uplo, diag, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("CTRTRI", trtri(impl.bl(), uplo, diag, n, a, lda))
+}
+
+// CLAUUM computes the product U*U**H (uplo U) or L**H*L (uplo L) of the
+// triangular factor held in the uplo triangle of A, overwriting it by the
+// same triangle of the product.
+func (impl Implementation) CLAUUM(uplo blas.Uplo, n int, a []complex64, lda int) error {
+	if err := checkPotrf("CLAUUM", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	lauum(impl.bl(), uplo, n, a, lda)
+	return nil
+}
+
+// ZTRTRI computes the inverse of an n×n triangular matrix in place. A
+// *SingularError is returned, and A is left unchanged, if a non-unit A has a
+// zero diagonal element.
+func (impl Implementation) ZTRTRI(uplo blas.Uplo, diag blas.Diag, n int, a []complex128, lda int) error {
+	if err := checkTrtri("ZTRTRI", uplo, diag, n, len(a), lda); err != nil {
+		return err
+	}
+	return singular("ZTRTRI", trtri(impl.bl(), uplo, diag, n, a, lda))
+}
+
+// ZLAUUM computes the product U*U**H (uplo U) or L**H*L (uplo L) of the
+// triangular factor held in the uplo triangle of A, overwriting it by the
+// same triangle of the product.
+func (impl Implementation) ZLAUUM(uplo blas.Uplo, n int, a []complex128, lda int) error {
+	if err := checkPotrf("ZLAUUM", uplo, n, len(a), lda); err != nil {
+		return err
+	}
+	lauum(impl.bl(), uplo, n, a, lda)
+	return nil
+}
+
+// triBlock is the block size of trtri and lauum, the value of ILAENV for
+// xTRTRI and xLAUUM.
+const triBlock = 64
+
+// checkTrtri checks the TRTRI routines.
+func checkTrtri(routine string, uplo blas.Uplo, diag blas.Diag, n, lenA, lda int) error {
+	c := checker{routine: routine}
+	c.uplo(1, uplo)
+	c.diag(2, diag)
+	c.nonNeg(3, "n", n)
+	c.ld(5, "lda", lda, n, "n")
+	if c.ok() {
+		c.length(4, "a", lenA, matLen(n, n, lda))
+	}
+	return c.result()
+}
+
+// trti2 computes the inverse of the n×n triangular matrix A in place, one
+// column at a time.
+func trti2[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, diag blas.Diag, n int, a []T, lda int) {
+	nonUnit := diag == blas.DiagN
+	if uplo == blas.UploU {
+		for j := 0; j < n; j++ {
+			ajj := T(-1)
+			if nonUnit {
+				a[j+j*lda] = 1 / a[j+j*lda]
+				ajj = -a[j+j*lda]
+			}
+			// Compute the elements 0:j of column j.
+			trmv(bl, blas.UploU, blas.TransN, diag, j, a, lda, a[j*lda:], 1)
+			scal(bl, j, ajj, a[j*lda:], 1)
+		}
+		return
+	}
+	for j := n - 1; j >= 0; j-- {
+		ajj := T(-1)
+		if nonUnit {
+			a[j+j*lda] = 1 / a[j+j*lda]
+			ajj = -a[j+j*lda]
+		}
+		if j < n-1 {
+			// Compute the elements j+1:n of column j.
+			trmv(bl, blas.UploL, blas.TransN, diag, n-j-1, a[j+1+(j+1)*lda:], lda, a[j+1+j*lda:], 1)
+			scal(bl, n-j-1, ajj, a[j+1+j*lda:], 1)
+		}
+	}
+}
+
+// trtri computes the inverse of the n×n triangular matrix A in place with
+// the blocked algorithm of xTRTRI. It returns the index of the first zero
+// diagonal element of a non-unit A, which is left unchanged, or -1.
+func trtri[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, diag blas.Diag, n int, a []T, lda int) (info int) {
+	if diag == blas.DiagN {
+		for i := 0; i < n; i++ {
+			if a[i+i*lda] == 0 {
+				return i
+			}
+		}
+	}
+	if triBlock <= 1 || triBlock >= n {
+		trti2(bl, uplo, diag, n, a, lda)
+		return -1
+	}
+	if uplo == blas.UploU {
+		for j := 0; j < n; j += triBlock {
+			jb := min(triBlock, n-j)
+
+			// Compute the rows 0:j of the block column and invert the
+			// diagonal block.
+			trmm(bl, blas.SideL, blas.UploU, blas.TransN, diag, j, jb, 1, a, lda, a[j*lda:], lda)
+			trsm(bl, blas.SideR, blas.UploU, blas.TransN, diag, j, jb, -1, a[j+j*lda:], lda, a[j*lda:], lda)
+			trti2(bl, blas.UploU, diag, jb, a[j+j*lda:], lda)
+		}
+		return -1
+	}
+	for j := (n - 1) / triBlock * triBlock; j >= 0; j -= triBlock {
+		jb := min(triBlock, n-j)
+		if j+jb < n {
+			// Compute the rows j+jb:n of the block column.
+			trmm(bl, blas.SideL, blas.UploL, blas.TransN, diag, n-j-jb, jb, 1, a[j+jb+(j+jb)*lda:], lda, a[j+jb+j*lda:], lda)
+			trsm(bl, blas.SideR, blas.UploL, blas.TransN, diag, n-j-jb, jb, -1, a[j+j*lda:], lda, a[j+jb+j*lda:], lda)
+		}
+		trti2(bl, blas.UploL, diag, jb, a[j+j*lda:], lda)
+	}
+	return -1
+}
+
+// trtrs solves op(A)*X = B for the n×nrhs matrix X, which overwrites B,
+// where A is n×n triangular, as xTRTRS. It returns the index of the first
+// zero diagonal element of a non-unit A, leaving B unchanged, or -1.
+func trtrs[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []T, lda int, b []T, ldb int) (info int) {
+	if diag == blas.DiagN {
+		for i := 0; i < n; i++ {
+			if a[i+i*lda] == 0 {
+				return i
+			}
+		}
+	}
+	trsm(bl, blas.SideL, uplo, trans, diag, n, nrhs, 1, a, lda, b, ldb)
+	return -1
+}
+
+// lauu2 computes the product U*U**H (uplo U) or L**H*L (uplo L) of the
+// triangular factor held in A, overwriting it, one row or column at a time.
+func lauu2[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int) {
+	if uplo == blas.UploU {
+		for i := 0; i < n; i++ {
+			aii := re(a[i+i*lda])
+			if i == n-1 {
+				rscal(bl, i+1, aii, a[i*lda:], 1)
+				continue
+			}
+			row := a[i+(i+1)*lda:]
+			a[i+i*lda] = fromReal[T](aii*aii + re(dotc(bl, n-i-1, row, lda, row, lda)))
+			lacgv(n-i-1, row, lda)
+			gemv(bl, blas.TransN, i, n-i-1, 1, a[(i+1)*lda:], lda, row, lda, fromReal[T](aii), a[i*lda:], 1)
+			lacgv(n-i-1, row, lda)
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		aii := re(a[i+i*lda])
+		if i == n-1 {
+			rscal(bl, i+1, aii, a[i:], lda)
+			continue
+		}
+		col := a[i+1+i*lda:]
+		a[i+i*lda] = fromReal[T](aii*aii + re(dotc(bl, n-i-1, col, 1, col, 1)))
+		lacgv(i, a[i:], lda)
+		gemv(bl, blas.TransC, n-i-1, i, 1, a[i+1:], lda, col, 1, fromReal[T](aii), a[i:], lda)
+		lacgv(i, a[i:], lda)
+	}
+}
+
+// lauum computes the product of lauu2 with the blocked algorithm of
+// xLAUUM.
+func lauum[T gen.Scalar](bl blas.BLAS, uplo blas.Uplo, n int, a []T, lda int) {
+	if triBlock <= 1 || triBlock >= n {
+		lauu2(bl, uplo, n, a, lda)
+		return
+	}
+	for i := 0; i < n; i += triBlock {
+		ib := min(triBlock, n-i)
+		aii := a[i+i*lda:]
+		if uplo == blas.UploU {
+			trmm(bl, blas.SideR, blas.UploU, blas.TransC, blas.DiagN, i, ib, 1, aii, lda, a[i*lda:], lda)
+			lauu2(bl, blas.UploU, ib, aii, lda)
+			if i+ib < n {
+				gemm(bl, blas.TransN, blas.TransC, i, ib, n-i-ib, 1, a[(i+ib)*lda:], lda, a[i+(i+ib)*lda:], lda, 1, a[i*lda:], lda)
+				herk(bl, blas.UploU, blas.TransN, ib, n-i-ib, 1, a[i+(i+ib)*lda:], lda, 1, aii, lda)
+			}
+			continue
+		}
+		trmm(bl, blas.SideL, blas.UploL, blas.TransC, blas.DiagN, ib, i, 1, aii, lda, a[i:], lda)
+		lauu2(bl, blas.UploL, ib, aii, lda)
+		if i+ib < n {
+			gemm(bl, blas.TransC, blas.TransN, ib, i, n-i-ib, 1, a[i+ib+i*lda:], lda, a[i+ib:], lda, 1, a[i:], lda)
+			herk(bl, blas.UploL, blas.TransC, ib, n-i-ib, 1, a[i+ib+i*lda:], lda, 1, aii, lda)
+		}
+	}
+}
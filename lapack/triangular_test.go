@@ -0,0 +1,135 @@
+package lapack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/visionom/lapack/blas"
+	"github.com/visionom/lapack/blas/gen"
+)
+
+// triRoutines holds the triangular routines of one precision.
+type triRoutines[T gen.Scalar] struct {
+	trtri func(uplo blas.Uplo, diag blas.Diag, n int, a []T, lda int) error
+	lauum func(uplo blas.Uplo, n int, a []T, lda int) error
+}
+
+func TestTriangular(t *testing.T) {
+	var impl Implementation
+	testTriangular(t, "S", triRoutines[float32]{impl.STRTRI, impl.SLAUUM})
+	testTriangular(t, "D", triRoutines[float64]{impl.DTRTRI, impl.DLAUUM})
+	testTriangular(t, "C", triRoutines[complex64]{impl.CTRTRI, impl.CLAUUM})
+	testTriangular(t, "Z", triRoutines[complex128]{impl.ZTRTRI, impl.ZLAUUM})
+}
+
+func testTriangular[T gen.Scalar](t *testing.T, prec string, f triRoutines[T]) {
+	rnd := rand.New(rand.NewSource(1))
+	nan := fromParts[T](math.NaN(), math.NaN())
+
+	// The orders larger than triBlock run the blocked code. The triangle
+	// opposite to uplo, and the diagonal of a unit triangular matrix, hold
+	// NaN, which must be neither read nor written.
+	for _, uplo := range []blas.Uplo{blas.UploU, blas.UploL} {
+		for _, n := range []int{0, 1, 5, 70, 150} {
+			lda := n + 3
+			for _, diag := range []blas.Diag{blas.DiagN, blas.DiagU} {
+				name := fmt.Sprintf("%sTRTRI uplo=%c diag=%c n=%d", prec, uplo, diag, n)
+				a := triMat[T](rnd, uplo, n, lda)
+				if diag == blas.DiagU {
+					for i := 0; i < n; i++ {
+						a[i+i*lda] = nan
+					}
+				}
+				a0 := slices.Clone(a)
+				if err := f.trtri(uplo, diag, n, a, lda); err != nil {
+					t.Errorf("%s: unexpected error %v", name, err)
+					continue
+				}
+				if !samePad(n, n, lda, a, a0) || !sameOpposite(uplo, n, a, a0, lda) {
+					t.Errorf("%s: elements outside the uplo triangle modified", name)
+				}
+				for i := 0; i < n && diag == blas.DiagU; i++ {
+					if d := a[i+i*lda]; d == d {
+						t.Errorf("%s: unit diagonal element %d modified to %v", name, i, d)
+						break
+					}
+				}
+				if r := invRatio(n, triOf(uplo, diag, n, a0, lda), n, triOf(uplo, diag, n, a, lda), n); r > maxRatio {
+					t.Errorf("%s: ‖A*inv(A) - I‖ ratio %.3g", name, r)
+				}
+			}
+
+			// As in LAPACK, xLAUUM takes the diagonal of the factor to be
+			// real, as that of a Cholesky factor is.
+			name := fmt.Sprintf("%sLAUUM uplo=%c n=%d", prec, uplo, n)
+			a := triMat[T](rnd, uplo, n, lda)
+			for i := 0; i < n; i++ {
+				a[i+i*lda] = fromReal[T](re(a[i+i*lda]))
+			}
+			a0 := slices.Clone(a)
+			if err := f.lauum(uplo, n, a, lda); err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+				continue
+			}
+			if !samePad(n, n, lda, a, a0) || !sameOpposite(uplo, n, a, a0, lda) {
+				t.Errorf("%s: elements outside the uplo triangle modified", name)
+			}
+			tri := triOf(uplo, blas.DiagN, n, a0, lda)
+			want := mulMat(blas.TransN, blas.TransC, n, n, n, tri, n, tri, n)
+			if uplo == blas.UploL {
+				want = mulMat(blas.TransC, blas.TransN, n, n, n, tri, n, tri, n)
+			}
+			if r := ratio[T](diffF(n, n, hermOf(uplo, n, a, lda), n, want, n), normF(n, n, want, n), n); r > maxRatio {
+				t.Errorf("%s: product ratio %.3g", name, r)
+			}
+		}
+	}
+
+	// A zero diagonal element is reported and leaves A unchanged, unless A
+	// has a unit diagonal.
+	for _, n := range []int{6, 150} {
+		k := n / 2
+		if n > triBlock {
+			k = triBlock + 3
+		}
+		for _, uplo := range []blas.Uplo{blas.UploU, blas.UploL} {
+			name := fmt.Sprintf("%sTRTRI uplo=%c n=%d", prec, uplo, n)
+			a := triMat[T](rnd, uplo, n, n)
+			a[k+k*n] = 0
+			a0 := slices.Clone(a)
+			err := f.trtri(uplo, blas.DiagN, n, a, n)
+			if se := (*SingularError)(nil); !errors.As(err, &se) || se.Routine != prec+"TRTRI" || se.Index != k {
+				t.Errorf("%s: err = %v, want a *SingularError with Index %d", name, err, k)
+			}
+			if !sameOpposite(uplo, n, a, a0, n) || !sameTriangle(uplo, n, a, a0, n) {
+				t.Errorf("%s: A modified although it is singular", name)
+			}
+			if err := f.trtri(uplo, blas.DiagU, n, a, n); err != nil {
+				t.Errorf("%s diag=U: unexpected error %v", name, err)
+			}
+		}
+	}
+}
+
+// triMat returns an n×n well-conditioned triangular matrix in the uplo
+// triangle of a matrix with leading dimension lda. The opposite triangle
+// holds NaN.
+func triMat[T gen.Scalar](rnd *rand.Rand, uplo blas.Uplo, n, lda int) []T {
+	a := randMat[T](rnd, n, n, lda)
+	nan := fromParts[T](math.NaN(), math.NaN())
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			switch {
+			case i == j:
+				a[i+j*lda] += fromReal[T](math.Copysign(float64(n), re(a[i+j*lda])))
+			case (i < j) != (uplo == blas.UploU):
+				a[i+j*lda] = nan
+			}
+		}
+	}
+	return a
+}